@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,11 +13,17 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/claude"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/queue"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/prompts"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
 )
 
 var (
@@ -26,8 +33,9 @@ var (
 	buildDate = "unknown"
 
 	// CLI flags
-	configFile string
-	debug      bool
+	configFile   string
+	configFormat string
+	debug        bool
 )
 
 func main() {
@@ -41,6 +49,7 @@ func main() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "config file path")
+	rootCmd.PersistentFlags().StringVar(&configFormat, "config-format", "", "config file format: yaml, json or toml (default: from the file extension)")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "enable debug mode")
 
 	// Add subcommands
@@ -55,7 +64,7 @@ func main() {
 
 func runServer(cmd *cobra.Command, args []string) error {
 	// Load configuration
-	cfg, err := config.Load(configFile)
+	cfg, err := config.LoadWithFormat(configFile, configFormat)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -72,6 +81,10 @@ func runServer(cmd *cobra.Command, args []string) error {
 		Str("version", version).
 		Str("transport", cfg.Server.Transport).
 		Msg("Starting TelemetryFlow GO MCP Server")
+	logger.Info().
+		Interface("config", cfg.Redacted()).
+		Strs("env_overrides", cfg.EnvOverrides()).
+		Msg("Effective configuration")
 
 	// Create Claude client
 	claudeClient, err := claude.NewClient(&cfg.Claude, logger)
@@ -79,25 +92,126 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create Claude client: %w", err)
 	}
 
+	// Connect to PostgreSQL when persistence or the db_query tool needs it
+	var db *persistence.Database
+	if cfg.Persistence.Driver == "postgres" || cfg.Security.EnableDBQuery {
+		db, err = persistence.NewDatabase(&persistence.DatabaseConfig{
+			Host:            cfg.Database.Host,
+			Port:            cfg.Database.Port,
+			User:            cfg.Database.User,
+			Password:        cfg.Database.Password,
+			Database:        cfg.Database.Database,
+			SSLMode:         cfg.Database.SSLMode,
+			MaxIdleConns:    cfg.Database.MaxIdleConns,
+			MaxOpenConns:    cfg.Database.MaxOpenConns,
+			ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+			ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+			LogLevel:        cfg.Database.LogLevel,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() { _ = db.Close() }()
+	}
+
 	// Create repositories
-	sessionRepo := persistence.NewInMemorySessionRepository()
-	conversationRepo := persistence.NewInMemoryConversationRepository()
-	toolRepo := persistence.NewInMemoryToolRepository()
+	repos, err := createRepositories(cfg, db, logger)
+	if err != nil {
+		return err
+	}
+	sessionRepo, conversationRepo, toolRepo, subscriptionRepo := repos.sessions, repos.conversations, repos.tools, repos.subscriptions
 
-	// Create event publisher (simple implementation)
-	eventPublisher := &simpleEventPublisher{logger: logger}
+	// Create event publisher (simple implementation) keeping recent events for status://events
+	eventRepo := persistence.NewInMemoryEventRepository(cfg.MCP.EventLogSize)
+	eventPublisher := &simpleEventPublisher{logger: logger, events: eventRepo}
 
 	// Create handlers
 	sessionHandler := handlers.NewSessionHandler(sessionRepo, eventPublisher)
+	sessionHandler.SetMaxSessions(cfg.Server.MaxSessions)
+	sessionHandler.SetSubscriptionRepository(subscriptionRepo)
 	toolHandler := handlers.NewToolHandler(sessionRepo, toolRepo, eventPublisher)
 	conversationHandler := handlers.NewConversationHandler(sessionRepo, conversationRepo, claudeClient, eventPublisher)
+	toolHandler.SetToolRateLimits(cfg.Security.ToolRateLimits)
+	toolHandler.SetToolRateLimitWindow(cfg.Security.ToolRateLimitWindow)
+	sessionHandler.OnSessionClosed(toolHandler.ReleaseSessionRateLimits)
+	conversationHandler.SetToolHandler(toolHandler)
+	attachmentReader := resources.NewResourceHandler(cfg.Security.AllowedPaths, cfg.MCP.ReadFileMaxBytes)
+	attachmentReader.SetMimeTypeFilter(cfg.Security.AllowedMimeTypes, cfg.Security.DeniedMimeTypes)
+	conversationHandler.SetResourceResolver(attachmentReader)
+	conversationHandler.SetMaxIterations(cfg.Claude.MaxToolIterations)
+	conversationHandler.SetMaxStreamedToolOutput(cfg.Claude.MaxStreamedToolOutput)
+	conversationHandler.SetStopSequenceLimits(vo.StopSequenceLimits{
+		MaxCount:  cfg.Claude.MaxStopSequences,
+		MaxLength: cfg.Claude.MaxStopSequenceLength,
+	})
+	resumeTokens, err := handlers.NewResumeTokens([]byte(cfg.Security.ResumeTokenSecret), cfg.Security.ResumeTokenTTL)
+	if err != nil {
+		return err
+	}
+	resumeTokens.SetSkewTolerance(cfg.Security.ClockSkewTolerance)
+	if cfg.Security.ResumeTokenSecret == "" {
+		logger.Warn().Msg("No resume token secret configured, resume tokens will not survive a restart")
+	}
+	conversationHandler.SetResumeTokens(resumeTokens)
+	resourceHandler := handlers.NewResourceHandler(sessionRepo, subscriptionRepo)
 
 	// Create and register built-in tools
 	toolRegistry := tools.NewToolRegistry(claudeClient)
+	toolRegistry.SetConversationHandler(conversationHandler)
+	if cfg.Security.EnableProcessList {
+		toolRegistry.EnableProcessListing()
+	}
+	if cfg.Security.EnableDBQuery {
+		sqlDB, err := db.DB().DB()
+		if err != nil {
+			return fmt.Errorf("failed to get database handle: %w", err)
+		}
+		toolRegistry.EnableDBQuery(sqlDB, tools.DBQueryOptions{
+			Tables:  cfg.Security.DBQueryTables,
+			MaxRows: cfg.Security.DBQueryMaxRows,
+			Timeout: cfg.Security.DBQueryTimeout,
+		})
+	}
+	toolRegistry.SetAllowedPaths(cfg.Security.AllowedPaths)
+	symlinkPolicy, err := tools.ParseSymlinkPolicy(cfg.Security.SymlinkPolicy)
+	if err != nil {
+		return err
+	}
+	toolRegistry.SetSymlinkPolicy(symlinkPolicy)
+	attachmentReader.SetPathChecker(toolRegistry)
+	attachmentReader.SetSessionRepository(sessionRepo)
+	toolRegistry.SetMaxTokensPolicy(tools.MaxTokensPolicy{
+		Default: cfg.Claude.MaxTokens,
+		Limit:   cfg.Claude.MaxTokensLimit,
+		Clamp:   cfg.Claude.ClampMaxTokens,
+	})
+	toolRegistry.SetMaxResponseChars(cfg.Claude.MaxResponseChars)
+	toolRegistry.SetStreamResponses(cfg.Claude.StreamResponses)
+	toolRegistry.SetExecIdleTimeout(cfg.MCP.ExecIdleTimeout)
+	toolRegistry.SetReadFileMaxBytes(cfg.MCP.ReadFileMaxBytes)
+	if err := toolRegistry.ConfigureBuiltinTools(cfg.MCP.EnabledTools, cfg.MCP.DisabledTools); err != nil {
+		return fmt.Errorf("invalid tool configuration: %w", err)
+	}
+	toolRegistry.SetManifestCommands(cfg.MCP.ManifestCommands)
+	for _, path := range cfg.MCP.ToolManifests {
+		manifestTools, err := toolRegistry.LoadManifest(path)
+		if err != nil {
+			return fmt.Errorf("failed to load tool manifest: %w", err)
+		}
+		logger.Info().Str("manifest", path).Int("tools", len(manifestTools)).Msg("Loaded tool manifest")
+	}
+	if cfg.Server.SafeMode {
+		toolHandler.SetSafeMode(true)
+		disabled := toolRegistry.DisableMutatingTools()
+		logger.Warn().Strs("disabled_tools", disabled).Msg("Safe mode active: only read-only tools can be called")
+	}
+	sessionHandler.OnSessionClosed(toolRegistry.FileWatcher().UnwatchSession)
+	defer toolRegistry.FileWatcher().Close()
 	for _, tool := range toolRegistry.GetTools() {
 		ctx := context.Background()
+		// Built-in tools must not collide; plugins override explicitly via Replace
 		if err := toolRepo.Register(ctx, tool); err != nil {
-			logger.Warn().Err(err).Str("tool", tool.Name().String()).Msg("Failed to register tool")
+			return fmt.Errorf("failed to register built-in tool %s: %w", tool.Name(), err)
 		}
 		// Register handler
 		toolHandler.RegisterToolHandler(tool.Name().String(), tool.Handler())
@@ -105,6 +219,84 @@ func runServer(cmd *cobra.Command, args []string) error {
 
 	// Create server
 	srv := server.NewServer(cfg, logger, sessionHandler, toolHandler, conversationHandler)
+	srv.SetResourceHandler(resourceHandler)
+	// The stdio client is the local user; remote clients hold the scopes of their API key
+	srv.SetLocalScopes([]string{tools.ProcessListScope, tools.DBQueryScope})
+	if cfg.Persistence.Driver == "postgres" {
+		srv.SetAPIKeyStore(persistence.NewAPIKeyRepository(db))
+	}
+	builtinPrompts, err := prompts.BuiltinPrompts()
+	if err != nil {
+		return fmt.Errorf("failed to load prompts: %w", err)
+	}
+	srv.SetPrompts(builtinPrompts)
+	templates, err := persistence.DefaultConversationTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to load conversation templates: %w", err)
+	}
+	srv.SetConversationTemplates(templates)
+	srv.SetMimeTypeFilter(resources.NewMimeTypeFilter(cfg.Security.AllowedMimeTypes, cfg.Security.DeniedMimeTypes))
+	srv.SetEventRepository(eventRepo)
+	toolRegistry.FileWatcher().SetNotifier(srv)
+	srv.SetFileWatches(toolRegistry.FileWatcher())
+	srv.SetFileResourceReader(attachmentReader)
+
+	// Record request admission, session capacity and agentic loop metrics
+	var metrics *telemetry.Metrics
+	if cfg.Telemetry.Enabled && cfg.Telemetry.MetricsEnabled {
+		metrics, err = telemetry.NewMetrics(cfg.Telemetry.ServiceName)
+		if err != nil {
+			return fmt.Errorf("failed to create metrics: %w", err)
+		}
+		srv.SetMetrics(metrics)
+		srv.SetShutdownMetrics(metrics)
+		srv.SetResourceCacheMetrics(metrics)
+		sessionHandler.SetMetrics(metrics)
+		conversationHandler.SetMetrics(metrics)
+		toolHandler.SetMetrics(metrics)
+	}
+
+	// Export traces, logs and metrics to the collector and/or the export file
+	if cfg.Telemetry.Enabled {
+		if obs := setupObservability(cfg, logger); obs != nil {
+			defer func() { _ = obs.Shutdown(context.Background()) }()
+		}
+	}
+
+	// Create task queue
+	if cfg.Queue.Enabled {
+		natsCfg := queue.DefaultNATSConfig()
+		natsCfg.URL = cfg.Queue.URL
+		natsCfg.Required = cfg.Queue.Required
+		natsCfg.MaxDeliver = cfg.Queue.MaxDeliver
+		natsCfg.UpdateStreams = cfg.Queue.UpdateStreams
+		natsCfg.LockSkewTolerance = cfg.Queue.LockSkewTolerance
+
+		taskQueue, err := queue.NewNATSQueue(natsCfg, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create queue: %w", err)
+		}
+		if metrics != nil {
+			taskQueue.SetMetrics(metrics)
+		}
+		if err := taskQueue.Initialize(context.Background()); err != nil {
+			return fmt.Errorf("failed to initialize queue: %w", err)
+		}
+		defer func() { _ = taskQueue.Close() }()
+		srv.SetTaskResults(taskQueue)
+
+		if taskQueue.State() == queue.QueueStateDegraded {
+			logger.Warn().Str("url", natsCfg.URL).Msg("NATS unreachable, queue running in degraded mode")
+		}
+
+		srv.RegisterHealthCheck("queue", func() server.HealthStatus {
+			status := server.HealthStatusOK
+			if taskQueue.State() != queue.QueueStateReady {
+				status = server.HealthStatusDegraded
+			}
+			return server.HealthStatus{Status: status, Details: taskQueue.Health()}
+		})
+	}
 
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -115,23 +307,110 @@ func runServer(cmd *cobra.Command, args []string) error {
 
 	go func() {
 		<-sigChan
-		logger.Info().Msg("Shutdown signal received")
+		logger.Info().Dur("timeout", cfg.Server.ShutdownTimeout).Msg("Shutdown signal received")
+		// Shutdown notifies the client and drains in-flight work before anything is cancelled
+		shutdownCtx := context.Background()
+		if cfg.Server.ShutdownTimeout > 0 {
+			var cancelShutdown context.CancelFunc
+			shutdownCtx, cancelShutdown = context.WithTimeout(shutdownCtx, cfg.Server.ShutdownTimeout)
+			defer cancelShutdown()
+		}
+		_ = srv.Shutdown(shutdownCtx)
 		cancel()
-		srv.Stop()
 	}()
 
 	// Run server
 	if err := srv.Run(ctx); err != nil {
-		if err == server.ErrServerClosed || err == context.Canceled {
+		if errors.Is(err, server.ErrServerClosed) || errors.Is(err, context.Canceled) {
 			logger.Info().Msg("Server stopped")
 			return nil
 		}
+		if errors.Is(err, server.ErrForcedShutdown) {
+			return err
+		}
 		return fmt.Errorf("server error: %w", err)
 	}
 
 	return nil
 }
 
+// repositorySet holds the repositories of the configured persistence driver
+type repositorySet struct {
+	sessions      repositories.ISessionRepository
+	conversations repositories.IConversationRepository
+	tools         repositories.IToolRepository
+	subscriptions repositories.IResourceSubscriptionRepository
+}
+
+// createRepositories creates the repositories of the configured persistence driver.
+// The postgres driver migrates the schema and closes the sessions a previous run left
+// open, along with their resource subscriptions.
+func createRepositories(cfg *config.Config, db *persistence.Database, logger zerolog.Logger) (*repositorySet, error) {
+	if cfg.Persistence.Driver != "postgres" {
+		tools := persistence.NewInMemoryToolRepository()
+		tools.SetLogger(logger)
+		return &repositorySet{
+			sessions:      persistence.NewInMemorySessionRepository(),
+			conversations: persistence.NewInMemoryConversationRepository(),
+			tools:         tools,
+			subscriptions: persistence.NewInMemoryResourceSubscriptionRepository(),
+		}, nil
+	}
+
+	if err := db.Migrate(persistence.AllModels()...); err != nil {
+		return nil, err
+	}
+	tools := persistence.NewGormToolRepository(db)
+	tools.SetLogger(logger)
+	conversations := persistence.NewGormConversationRepository(db, tools)
+	sessions := persistence.NewGormSessionRepository(db, conversations, tools)
+	closed, err := sessions.CloseAbandoned(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to close abandoned sessions: %w", err)
+	}
+	if closed > 0 {
+		logger.Info().Int64("sessions", closed).Msg("Closed sessions left open by a previous run")
+	}
+	return &repositorySet{
+		sessions:      sessions,
+		conversations: conversations,
+		tools:         tools,
+		subscriptions: persistence.NewResourceSubscriptionRepository(db),
+	}, nil
+}
+
+// setupObservability creates the observability facade for the configured export mode.
+// Failures are logged rather than fatal, as telemetry is enabled by default and the
+// server runs without a collector.
+func setupObservability(cfg *config.Config, logger zerolog.Logger) *telemetry.Observability {
+	obsCfg := telemetry.DefaultObservabilityConfigFor(cfg.Telemetry.Environment)
+	obsCfg.ServiceName = cfg.Telemetry.ServiceName
+	obsCfg.ServiceVersion = version
+	if cfg.Telemetry.OTLPEndpoint != "" {
+		obsCfg.Endpoint = cfg.Telemetry.OTLPEndpoint
+		obsCfg.Insecure = cfg.Telemetry.OTLPInsecure
+	}
+	obsCfg.ExportMode = cfg.Telemetry.ExportMode
+	obsCfg.ExportFile = cfg.Telemetry.ExportFile
+	obsCfg.ExportFileMaxBytes = cfg.Telemetry.ExportFileMaxBytes
+	obsCfg.ExportFileMaxBackups = cfg.Telemetry.ExportFileMaxBackups
+
+	obs, err := telemetry.NewObservability(obsCfg)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Telemetry export disabled")
+		return nil
+	}
+	if err := obs.Initialize(context.Background()); err != nil {
+		logger.Warn().Err(err).Msg("Telemetry export disabled")
+		if file := obs.FileExporter(); file != nil {
+			_ = file.Close()
+		}
+		return nil
+	}
+	logger.Info().Str("export_mode", cfg.Telemetry.ExportMode).Str("export_file", cfg.Telemetry.ExportFile).Msg("Telemetry export enabled")
+	return obs
+}
+
 func setupLogger(cfg *config.Config) zerolog.Logger {
 	// Set log level
 	level, err := zerolog.ParseLevel(cfg.Logging.Level)
@@ -182,7 +461,7 @@ func validateCmd() *cobra.Command {
 		Use:   "validate",
 		Short: "Validate configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(configFile)
+			cfg, err := config.LoadWithFormat(configFile, configFormat)
 			if err != nil {
 				return fmt.Errorf("configuration is invalid: %w", err)
 			}
@@ -198,9 +477,10 @@ func validateCmd() *cobra.Command {
 // simpleEventPublisher is a simple event publisher implementation
 type simpleEventPublisher struct {
 	logger zerolog.Logger
+	events *persistence.InMemoryEventRepository
 }
 
 func (p *simpleEventPublisher) Publish(ctx context.Context, event interface{}) error {
 	p.logger.Debug().Interface("event", event).Msg("Event published")
-	return nil
+	return p.events.Store(ctx, event)
 }
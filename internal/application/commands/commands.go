@@ -2,6 +2,9 @@
 package commands
 
 import (
+	"io"
+	"time"
+
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
 	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
 )
@@ -19,6 +22,8 @@ type InitializeSessionCommand struct {
 	ClientVersion   string
 	ProtocolVersion string
 	Capabilities    map[string]interface{}
+	// Scopes the client's credentials grant; tools requiring other scopes are refused
+	Scopes []string
 }
 
 func (c *InitializeSessionCommand) CommandName() string {
@@ -34,6 +39,24 @@ func (c *CloseSessionCommand) CommandName() string {
 	return "CloseSession"
 }
 
+// ResetSessionCommand clears a session's collections without reinitializing it
+type ResetSessionCommand struct {
+	SessionID vo.SessionID
+}
+
+func (c *ResetSessionCommand) CommandName() string {
+	return "ResetSession"
+}
+
+// MarkSessionReadyCommand marks a session ready after the client confirms initialization
+type MarkSessionReadyCommand struct {
+	SessionID vo.SessionID
+}
+
+func (c *MarkSessionReadyCommand) CommandName() string {
+	return "MarkSessionReady"
+}
+
 // SetLogLevelCommand sets the log level for a session
 type SetLogLevelCommand struct {
 	SessionID vo.SessionID
@@ -48,21 +71,37 @@ func (c *SetLogLevelCommand) CommandName() string {
 
 // CreateConversationCommand creates a new conversation
 type CreateConversationCommand struct {
-	SessionID    vo.SessionID
-	Model        vo.Model
-	SystemPrompt string
-	MaxTokens    int
-	Temperature  float64
+	SessionID     vo.SessionID
+	Model         vo.Model
+	SystemPrompt  string
+	MaxTokens     int
+	Temperature   *float64            // Optional, nil keeps the conversation default
+	TopP          *float64            // Optional, nil keeps the conversation default
+	TopK          *int                // Optional, nil keeps the conversation default
+	StopSequences []string            // Optional, nil keeps the conversation default; validated against the configured limits
+	ToolUse       *vo.ToolUseSettings // Optional, defaults to auto over all conversation tools
+	Template      string              // Optional, conversation template to start from; other fields override it
 }
 
 func (c *CreateConversationCommand) CommandName() string {
 	return "CreateConversation"
 }
 
+// SetToolUseCommand changes whether and how Claude may use tools in a conversation
+type SetToolUseCommand struct {
+	ConversationID vo.ConversationID
+	Settings       vo.ToolUseSettings
+}
+
+func (c *SetToolUseCommand) CommandName() string {
+	return "SetToolUse"
+}
+
 // SendMessageCommand sends a message in a conversation
 type SendMessageCommand struct {
 	ConversationID vo.ConversationID
 	Content        string
+	Attachments    []string // Resource URIs whose content is inlined when the message is sent
 	Stream         bool
 }
 
@@ -70,6 +109,45 @@ func (c *SendMessageCommand) CommandName() string {
 	return "SendMessage"
 }
 
+// RunTurnCommand sends a message and executes requested tools until Claude ends the turn
+type RunTurnCommand struct {
+	ConversationID  vo.ConversationID
+	Content         string
+	Attachments     []string // Resource URIs whose content is inlined when the message is sent
+	Stream          bool
+	EditLastMessage bool // Content replaces the text of the last user message instead of following it
+}
+
+func (c *RunTurnCommand) CommandName() string {
+	return "RunTurn"
+}
+
+// ImportConversationCommand imports messages from a JSONL stream into a conversation
+type ImportConversationCommand struct {
+	SessionID      vo.SessionID
+	ConversationID vo.ConversationID // Optional, resumes an existing import when set
+	Model          vo.Model
+	SystemPrompt   string
+	Reader         io.Reader
+	StartLine      int // Lines up to and including this one are skipped
+}
+
+func (c *ImportConversationCommand) CommandName() string {
+	return "ImportConversation"
+}
+
+// EditLastUserMessageCommand replaces the text of the last user message and
+// sends the conversation to Claude again. The edit is kept even if Claude fails.
+type EditLastUserMessageCommand struct {
+	ConversationID vo.ConversationID
+	Content        string
+	Stream         bool
+}
+
+func (c *EditLastUserMessageCommand) CommandName() string {
+	return "EditLastUserMessage"
+}
+
 // AddToolResultCommand adds a tool result to a conversation
 type AddToolResultCommand struct {
 	ConversationID vo.ConversationID
@@ -91,6 +169,15 @@ func (c *CloseConversationCommand) CommandName() string {
 	return "CloseConversation"
 }
 
+// DeleteConversationCommand deletes a conversation and its messages
+type DeleteConversationCommand struct {
+	ConversationID vo.ConversationID
+}
+
+func (c *DeleteConversationCommand) CommandName() string {
+	return "DeleteConversation"
+}
+
 // Tool Commands
 
 // RegisterToolCommand registers a new tool
@@ -101,6 +188,7 @@ type RegisterToolCommand struct {
 	InputSchema *entities.JSONSchema
 	Category    string
 	Tags        []string
+	Replace     bool // Override an existing tool with the same name
 }
 
 func (c *RegisterToolCommand) CommandName() string {
@@ -117,6 +205,67 @@ func (c *UnregisterToolCommand) CommandName() string {
 	return "UnregisterTool"
 }
 
+// SetToolEnabledCommand enables or disables a registered tool
+type SetToolEnabledCommand struct {
+	Name    string
+	Enabled bool
+}
+
+func (c *SetToolEnabledCommand) CommandName() string {
+	return "SetToolEnabled"
+}
+
+// UpdateToolCommand patches the mutable fields of a registered tool. Nil fields are
+// left unchanged; the name and input schema cannot be changed.
+type UpdateToolCommand struct {
+	SessionID   vo.SessionID
+	Name        string
+	Description *string
+	Timeout     *time.Duration
+	Enabled     *bool
+	Tags        []string // Optional, nil keeps the current tags and an empty slice clears them
+	Category    *string
+	Annotations *ToolAnnotationsPatch
+}
+
+// ToolAnnotationsPatch holds the annotation fields to change. Nil fields keep the
+// tool's current value.
+type ToolAnnotationsPatch struct {
+	Title           *string `json:"title"`
+	ReadOnlyHint    *bool   `json:"readOnlyHint"`
+	DestructiveHint *bool   `json:"destructiveHint"`
+	IdempotentHint  *bool   `json:"idempotentHint"`
+	OpenWorldHint   *bool   `json:"openWorldHint"`
+}
+
+// Apply returns annotations with the patched fields changed, leaving annotations as is
+func (p *ToolAnnotationsPatch) Apply(annotations *entities.ToolAnnotations) *entities.ToolAnnotations {
+	merged := entities.ToolAnnotations{}
+	if annotations != nil {
+		merged = *annotations
+	}
+	if p.Title != nil {
+		merged.Title = *p.Title
+	}
+	if p.ReadOnlyHint != nil {
+		merged.ReadOnlyHint = *p.ReadOnlyHint
+	}
+	if p.DestructiveHint != nil {
+		merged.DestructiveHint = *p.DestructiveHint
+	}
+	if p.IdempotentHint != nil {
+		merged.IdempotentHint = *p.IdempotentHint
+	}
+	if p.OpenWorldHint != nil {
+		merged.OpenWorldHint = *p.OpenWorldHint
+	}
+	return &merged
+}
+
+func (c *UpdateToolCommand) CommandName() string {
+	return "UpdateTool"
+}
+
 // ExecuteToolCommand executes a tool
 type ExecuteToolCommand struct {
 	SessionID vo.SessionID
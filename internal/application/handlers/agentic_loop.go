@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Agentic loop errors
+var (
+	ErrToolExecutorNotConfigured = errors.New("tool executor not configured for conversation handler")
+)
+
+// DefaultMaxTurnIterations is the default number of Claude completions allowed in a single turn
+const DefaultMaxTurnIterations = 25
+
+// TurnResult represents the outcome of a complete agentic turn. When IterationLimitReached is
+// set, the result holds the last response and the tool_use blocks it requested were not executed.
+type TurnResult struct {
+	*SendMessageResult
+	Iterations            int
+	ToolCalls             int
+	CachedToolCalls       int
+	ToolsInvoked          []string
+	IterationLimitReached bool
+}
+
+// TurnMetrics records agentic loop metrics
+type TurnMetrics interface {
+	RecordTurnIterationLimitReached(ctx context.Context)
+}
+
+// SetToolHandler sets the tool handler used to execute tools requested during a turn
+func (h *ConversationHandler) SetToolHandler(toolHandler *ToolHandler) {
+	h.toolHandler = toolHandler
+}
+
+// SetMaxIterations sets the maximum number of Claude completions in a turn (0 means unlimited)
+func (h *ConversationHandler) SetMaxIterations(maxIterations int) {
+	h.maxIterations = maxIterations
+}
+
+// SetMetrics sets the recorder for agentic loop metrics
+func (h *ConversationHandler) SetMetrics(metrics TurnMetrics) {
+	h.metrics = metrics
+}
+
+// atIterationLimit reports whether the given completion is the last one allowed in a turn
+func (h *ConversationHandler) atIterationLimit(iteration int) bool {
+	return h.maxIterations > 0 && iteration >= h.maxIterations
+}
+
+// HandleRunTurn handles RunTurnCommand, executing requested tools until Claude stops asking for them
+// or the iteration limit is reached. When streaming, each tool starts as soon as its tool_use input
+// has fully arrived, overlapping tool execution with the rest of the response.
+func (h *ConversationHandler) HandleRunTurn(ctx context.Context, cmd *commands.RunTurnCommand) (*TurnResult, error) {
+	if h.toolHandler == nil {
+		return nil, ErrToolExecutorNotConfigured
+	}
+
+	conversation, err := h.conversationRepo.FindByID(ctx, cmd.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation == nil {
+		return nil, ErrConversationNotFound
+	}
+	sessionID := conversation.SessionID()
+
+	// Results are only reused within this turn
+	cache := newToolResultCache()
+	defer cache.clear()
+
+	// Tools requested by the last allowed completion are never run, so they are not started early
+	streamCtx, pipeline := h.startToolUsePipeline(ctx, cmd.Stream && !h.atIterationLimit(1), sessionID, cache)
+	var result *SendMessageResult
+	if cmd.EditLastMessage {
+		result, err = h.HandleEditLastUserMessage(streamCtx, &commands.EditLastUserMessageCommand{
+			ConversationID: cmd.ConversationID,
+			Content:        cmd.Content,
+			Stream:         cmd.Stream,
+		})
+	} else {
+		result, err = h.HandleSendMessage(streamCtx, &commands.SendMessageCommand{
+			ConversationID: cmd.ConversationID,
+			Content:        cmd.Content,
+			Attachments:    cmd.Attachments,
+			Stream:         cmd.Stream,
+		})
+	}
+	started := pipeline.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	turn := &TurnResult{SendMessageResult: result, Iterations: 1}
+	for turn.HasToolUse {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		conversation, err := h.conversationRepo.FindByID(ctx, cmd.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+		if conversation == nil {
+			return nil, ErrConversationNotFound
+		}
+
+		if h.atIterationLimit(turn.Iterations) {
+			if err := h.stopAtIterationLimit(ctx, conversation, turn); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		blocks := make([]entities.ContentBlock, 0, len(turn.ToolUses))
+		for _, toolUse := range turn.ToolUses {
+			outcome, ok := started[toolUse.ID]
+			if !ok {
+				outcome.block, outcome.cached = h.executeToolUse(ctx, sessionID, toolUse, cache)
+			}
+			turn.ToolCalls++
+			turn.ToolsInvoked = append(turn.ToolsInvoked, toolUse.Name)
+			if outcome.cached {
+				turn.CachedToolCalls++
+			}
+			blocks = append(blocks, outcome.block)
+		}
+
+		msg, err := entities.NewMessage(vo.RoleUser, blocks)
+		if err != nil {
+			return nil, err
+		}
+		if err := conversation.AddMessage(msg); err != nil {
+			return nil, err
+		}
+
+		streamCtx, pipeline := h.startToolUsePipeline(ctx, cmd.Stream && !h.atIterationLimit(turn.Iterations+1), sessionID, cache)
+		next, err := h.complete(streamCtx, conversation, cmd.Stream)
+		started = pipeline.finish()
+		if err != nil {
+			return nil, err
+		}
+		turn.SendMessageResult = next
+		turn.Iterations++
+	}
+
+	return turn, nil
+}
+
+// stopAtIterationLimit ends a turn that hit the iteration limit. The pending tool_use blocks are
+// answered with error results so the conversation stays valid for the next turn.
+func (h *ConversationHandler) stopAtIterationLimit(ctx context.Context, conversation *aggregates.Conversation, turn *TurnResult) error {
+	reason := fmt.Sprintf("tool not executed: turn reached the limit of %d iterations", h.maxIterations)
+	blocks := make([]entities.ContentBlock, 0, len(turn.ToolUses))
+	for _, toolUse := range turn.ToolUses {
+		blocks = append(blocks, entities.ContentBlock{
+			Type:      vo.ContentTypeToolResult,
+			ToolUseID: toolUse.ID,
+			Content:   reason,
+			IsError:   true,
+		})
+	}
+
+	msg, err := entities.NewMessage(vo.RoleUser, blocks)
+	if err != nil {
+		return err
+	}
+	if err := conversation.AddMessage(msg); err != nil {
+		return err
+	}
+
+	turn.IterationLimitReached = true
+	if h.metrics != nil {
+		h.metrics.RecordTurnIterationLimitReached(ctx)
+	}
+	return nil
+}
+
+// executeToolUse runs a single tool_use block and returns the matching tool_result block
+func (h *ConversationHandler) executeToolUse(ctx context.Context, sessionID vo.SessionID, toolUse entities.ContentBlock, cache *toolResultCache) (entities.ContentBlock, bool) {
+	block := entities.ContentBlock{
+		Type:      vo.ContentTypeToolResult,
+		ToolUseID: toolUse.ID,
+	}
+
+	cacheable, readOnly := h.toolHandler.cachePolicy(ctx, toolUse.Name)
+	key := toolCacheKey(toolUse.Name, toolUse.Input)
+	if cacheable {
+		if result, ok := cache.get(key); ok {
+			block.Content = toolResultText(result)
+			block.ResultContent = toolResultContent(result)
+			block.IsError = result.IsError
+			return block, true
+		}
+	}
+
+	// Output the tool streams is passed on as it arrives but recorded once, in the result
+	execCtx, output := h.withToolOutputBuffer(ctx)
+	result, err := h.toolHandler.HandleExecuteTool(execCtx, &commands.ExecuteToolCommand{
+		SessionID: sessionID,
+		Name:      toolUse.Name,
+		Arguments: toolUse.Input,
+	})
+	// A tool that may have changed state invalidates every result cached before it
+	if !readOnly {
+		cache.clear()
+	}
+	if err != nil {
+		block.Content = output.consolidate(entities.NewErrorToolResult(err))
+		block.IsError = true
+		return block, false
+	}
+
+	if cacheable && !result.IsError {
+		cache.put(key, result)
+	}
+
+	block.Content = output.consolidate(result)
+	block.ResultContent = output.resultContent(result, block.Content)
+	block.IsError = result.IsError
+	return block, false
+}
+
+// toolResultText flattens the text content of a tool result
+func toolResultText(result *entities.ToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if content.Text != "" {
+			parts = append(parts, content.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// toolResultContent maps the items of a tool result to the text and image blocks of a
+// tool_result, in order. A result made of a single text item is carried by the
+// block's text alone, so nil is returned for it.
+func toolResultContent(result *entities.ToolResult) []entities.ContentBlock {
+	if len(result.Content) == 1 && result.Content[0].Type == entities.ToolContentText {
+		return nil
+	}
+
+	blocks := make([]entities.ContentBlock, 0, len(result.Content))
+	for _, content := range result.Content {
+		switch content.Type {
+		case entities.ToolContentImage:
+			blocks = append(blocks, entities.ContentBlock{
+				Type:   vo.ContentTypeImage,
+				Source: &entities.ImageSource{Type: "base64", MediaType: content.MimeType, Data: content.Data},
+			})
+		case entities.ToolContentResource:
+			// Claude has no resource block in tool results, so the resource is inlined as text
+			text := content.Text
+			if text == "" {
+				text = content.URI
+			}
+			blocks = append(blocks, entities.ContentBlock{Type: vo.ContentTypeText, Text: text})
+		default:
+			if content.Text != "" {
+				blocks = append(blocks, entities.ContentBlock{Type: vo.ContentTypeText, Text: content.Text})
+			}
+		}
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	return blocks
+}
+
+// toolResultCache holds tool results for the duration of a single turn
+type toolResultCache struct {
+	entries map[string]*entities.ToolResult
+}
+
+func newToolResultCache() *toolResultCache {
+	return &toolResultCache{entries: make(map[string]*entities.ToolResult)}
+}
+
+func (c *toolResultCache) get(key string) (*entities.ToolResult, bool) {
+	result, ok := c.entries[key]
+	return result, ok
+}
+
+func (c *toolResultCache) put(key string, result *entities.ToolResult) {
+	c.entries[key] = result
+}
+
+func (c *toolResultCache) clear() {
+	c.entries = make(map[string]*entities.ToolResult)
+}
+
+// toolCacheKey builds a cache key from the tool name and a hash of its arguments
+func toolCacheKey(name string, input map[string]interface{}) string {
+	// encoding/json sorts map keys, so equal arguments hash identically
+	data, _ := json.Marshal(input)
+	sum := sha256.Sum256(data)
+	return name + ":" + hex.EncodeToString(sum[:])
+}
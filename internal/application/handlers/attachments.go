@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Attachment errors
+var (
+	ErrResourceResolverNotConfigured = errors.New("resource resolver not configured for conversation handler")
+	ErrAttachmentUnreadable          = errors.New("attachment could not be read")
+)
+
+// ResourceResolver reads the resources referenced by message attachments, applying
+// the same access rules as resources/read
+type ResourceResolver interface {
+	ResolveResource(ctx context.Context, sessionID vo.SessionID, uri string) (*entities.ResourceContent, error)
+}
+
+// SetResourceResolver sets the resolver used to inline attached resources into Claude requests
+func (h *ConversationHandler) SetResourceResolver(resolver ResourceResolver) {
+	h.resourceResolver = resolver
+}
+
+// addMessageWithAttachments adds a user message holding the text and a reference to each
+// attached resource. Every attachment is read once up front so an unreadable one fails the send.
+func (h *ConversationHandler) addMessageWithAttachments(ctx context.Context, conversation *aggregates.Conversation, text string, uris []string) error {
+	if h.resourceResolver == nil {
+		return ErrResourceResolverNotConfigured
+	}
+
+	blocks := []entities.ContentBlock{{Type: vo.ContentTypeText, Text: text}}
+	for _, uri := range uris {
+		if _, err := h.resourceResolver.ResolveResource(ctx, conversation.SessionID(), uri); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrAttachmentUnreadable, uri, err)
+		}
+		blocks = append(blocks, entities.NewResourceReferenceBlock(uri))
+	}
+
+	msg, err := entities.NewMessage(vo.RoleUser, blocks)
+	if err != nil {
+		return err
+	}
+	return conversation.AddMessage(msg)
+}
+
+// inlineAttachments replaces resource references with text blocks holding the resources'
+// current content. A resource that can no longer be read is replaced with a note saying so,
+// so an old reference does not break the conversation.
+func (h *ConversationHandler) inlineAttachments(ctx context.Context, sessionID vo.SessionID, blocks []entities.ContentBlock) []entities.ContentBlock {
+	var inlined []entities.ContentBlock
+	for i, block := range blocks {
+		if block.Type != vo.ContentTypeResource {
+			continue
+		}
+		if inlined == nil {
+			// Copy before the first replacement so stored history keeps its references
+			inlined = append([]entities.ContentBlock(nil), blocks...)
+		}
+		inlined[i] = entities.ContentBlock{Type: vo.ContentTypeText, Text: h.attachmentText(ctx, sessionID, block.URI)}
+	}
+	if inlined == nil {
+		return blocks
+	}
+	return inlined
+}
+
+// attachmentText renders the content of the resource at uri for a Claude request
+func (h *ConversationHandler) attachmentText(ctx context.Context, sessionID vo.SessionID, uri string) string {
+	if h.resourceResolver == nil {
+		return fmt.Sprintf("[resource %s could not be read: %v]", uri, ErrResourceResolverNotConfigured)
+	}
+
+	content, err := h.resourceResolver.ResolveResource(ctx, sessionID, uri)
+	if err != nil {
+		return fmt.Sprintf("[resource %s could not be read: %v]", uri, err)
+	}
+	if content.Blob != "" {
+		return fmt.Sprintf("[resource %s is binary (%s) and was not inlined]", uri, content.MimeType)
+	}
+	return fmt.Sprintf("<resource uri=%q mimeType=%q>\n%s\n</resource>", uri, content.MimeType, content.Text)
+}
@@ -4,6 +4,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
@@ -26,6 +27,17 @@ type ConversationHandler struct {
 	conversationRepo repositories.IConversationRepository
 	claudeService    services.IClaudeService
 	eventPublisher   EventPublisher
+	toolHandler      *ToolHandler
+	resourceResolver ResourceResolver
+	maxIterations    int
+	metrics          TurnMetrics
+	resumeTokens     *ResumeTokens
+
+	// Bytes of streamed tool output kept in each tool_result block
+	maxStreamedOutput int
+
+	// Limits on the stop sequences a new conversation may set
+	stopSequenceLimits vo.StopSequenceLimits
 }
 
 // NewConversationHandler creates a new ConversationHandler
@@ -36,13 +48,23 @@ func NewConversationHandler(
 	eventPublisher EventPublisher,
 ) *ConversationHandler {
 	return &ConversationHandler{
-		sessionRepo:      sessionRepo,
-		conversationRepo: conversationRepo,
-		claudeService:    claudeService,
-		eventPublisher:   eventPublisher,
+		sessionRepo:       sessionRepo,
+		conversationRepo:  conversationRepo,
+		claudeService:     claudeService,
+		eventPublisher:    eventPublisher,
+		maxIterations:     DefaultMaxTurnIterations,
+		maxStreamedOutput: DefaultMaxStreamedToolOutput,
+
+		stopSequenceLimits: vo.DefaultStopSequenceLimits(),
 	}
 }
 
+// SetStopSequenceLimits sets the limits on the stop sequences a new conversation may
+// set (default: vo.DefaultStopSequenceLimits)
+func (h *ConversationHandler) SetStopSequenceLimits(limits vo.StopSequenceLimits) {
+	h.stopSequenceLimits = limits
+}
+
 // HandleCreateConversation handles CreateConversationCommand
 func (h *ConversationHandler) HandleCreateConversation(ctx context.Context, cmd *commands.CreateConversationCommand) (*aggregates.Conversation, error) {
 	// Verify session exists
@@ -54,16 +76,28 @@ func (h *ConversationHandler) HandleCreateConversation(ctx context.Context, cmd
 		return nil, ErrSessionNotFound
 	}
 
-	// Validate model
-	model := cmd.Model
-	if !model.IsValid() {
-		model = vo.DefaultModel
-	}
-
-	// Create conversation
-	conversation, err := session.CreateConversation(model)
-	if err != nil {
-		return nil, err
+	// Create conversation, from a template when one is named
+	var conversation *aggregates.Conversation
+	if cmd.Template != "" {
+		conversation, err = session.CreateConversationFromTemplate(cmd.Template, h.lookupTool(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if cmd.Model != "" {
+			if err := conversation.SetModel(cmd.Model); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		// Validate model
+		model := cmd.Model
+		if !model.IsValid() {
+			model = vo.DefaultModel
+		}
+		conversation, err = session.CreateConversation(model)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Set system prompt if provided
@@ -81,8 +115,30 @@ func (h *ConversationHandler) HandleCreateConversation(ctx context.Context, cmd
 	if cmd.MaxTokens > 0 {
 		conversation.SetMaxTokens(cmd.MaxTokens)
 	}
-	if cmd.Temperature >= 0 {
-		conversation.SetTemperature(cmd.Temperature)
+	if cmd.Temperature != nil {
+		if err := conversation.SetTemperatureChecked(*cmd.Temperature); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.TopP != nil {
+		if err := conversation.SetTopPChecked(*cmd.TopP); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.TopK != nil {
+		if err := conversation.SetTopKChecked(*cmd.TopK); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.StopSequences != nil {
+		if err := conversation.SetStopSequencesChecked(cmd.StopSequences, h.stopSequenceLimits); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.ToolUse != nil {
+		if err := h.applyToolUse(ctx, conversation, *cmd.ToolUse); err != nil {
+			return nil, err
+		}
 	}
 
 	// Save session and conversation
@@ -101,11 +157,74 @@ func (h *ConversationHandler) HandleCreateConversation(ctx context.Context, cmd
 	return conversation, nil
 }
 
+// HandleSetToolUse handles SetToolUseCommand
+func (h *ConversationHandler) HandleSetToolUse(ctx context.Context, cmd *commands.SetToolUseCommand) (*aggregates.Conversation, error) {
+	conversation, err := h.conversationRepo.FindByID(ctx, cmd.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation == nil {
+		return nil, ErrConversationNotFound
+	}
+
+	if err := h.applyToolUse(ctx, conversation, cmd.Settings); err != nil {
+		return nil, err
+	}
+	if err := h.conversationRepo.Save(ctx, conversation); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// applyToolUse validates tool-use settings against the registered tools, adds the tools
+// they name to the conversation and applies them
+func (h *ConversationHandler) applyToolUse(ctx context.Context, conversation *aggregates.Conversation, settings vo.ToolUseSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	for _, name := range settings.ToolNames() {
+		toolName, err := vo.NewToolName(name)
+		if err != nil {
+			return err
+		}
+		if conversation.GetTool(toolName) != nil {
+			continue
+		}
+		if h.toolHandler == nil {
+			return fmt.Errorf("%w: %s", ErrToolNotFound, name)
+		}
+		tool, err := h.toolHandler.HandleGetTool(ctx, &queries.GetToolQuery{Name: name})
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+		conversation.AddTool(tool)
+	}
+
+	return conversation.SetToolUse(settings)
+}
+
+// lookupTool returns a lookup of the registered tools, such as the built-ins, for
+// conversation templates; it finds nothing without a tool handler
+func (h *ConversationHandler) lookupTool(ctx context.Context) aggregates.ToolLookup {
+	return func(name string) (*entities.Tool, bool) {
+		if h.toolHandler == nil {
+			return nil, false
+		}
+		tool, err := h.toolHandler.HandleGetTool(ctx, &queries.GetToolQuery{Name: name})
+		if err != nil {
+			return nil, false
+		}
+		return tool, true
+	}
+}
+
 // SendMessageResult represents the result of sending a message
 type SendMessageResult struct {
 	Response   *services.ClaudeResponse
 	ToolUses   []entities.ContentBlock
 	HasToolUse bool
+	StopReason vo.StopReason
 }
 
 // HandleSendMessage handles SendMessageCommand
@@ -129,20 +248,63 @@ func (h *ConversationHandler) HandleSendMessage(ctx context.Context, cmd *comman
 		return nil, aggregates.ErrConversationClosed
 	}
 
-	// Add user message
-	_, err = conversation.AddUserMessage(cmd.Content)
+	// Add user message, keeping attachments as resource references
+	if len(cmd.Attachments) == 0 {
+		_, err = conversation.AddUserMessage(cmd.Content)
+	} else {
+		err = h.addMessageWithAttachments(ctx, conversation, cmd.Content, cmd.Attachments)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return h.complete(ctx, conversation, cmd.Stream)
+}
+
+// HandleEditLastUserMessage handles EditLastUserMessageCommand. The edited message
+// replaces the original and is saved before the conversation is sent, so a failed
+// request to Claude does not lose it; Claude's response is added after it.
+func (h *ConversationHandler) HandleEditLastUserMessage(ctx context.Context, cmd *commands.EditLastUserMessageCommand) (*SendMessageResult, error) {
+	if cmd.Content == "" {
+		return nil, ErrMessageEmpty
+	}
+
+	conversation, err := h.conversationRepo.FindByID(ctx, cmd.ConversationID)
 	if err != nil {
 		return nil, err
 	}
+	if conversation == nil {
+		return nil, ErrConversationNotFound
+	}
+
+	if !conversation.IsActive() {
+		return nil, aggregates.ErrConversationClosed
+	}
+
+	if _, err := conversation.EditLastUserMessage(cmd.Content); err != nil {
+		return nil, err
+	}
+	if err := h.conversationRepo.Save(ctx, conversation); err != nil {
+		return nil, err
+	}
+	for _, event := range conversation.Events() {
+		_ = h.eventPublisher.Publish(ctx, event)
+	}
+
+	return h.complete(ctx, conversation, cmd.Stream)
+}
 
+// complete sends the conversation to Claude and records the assistant response
+func (h *ConversationHandler) complete(ctx context.Context, conversation *aggregates.Conversation, stream bool) (*SendMessageResult, error) {
 	// Build Claude request
-	request := h.buildClaudeRequest(conversation)
+	request := h.buildClaudeRequest(ctx, conversation)
 
 	// Call Claude API
 	var response *services.ClaudeResponse
-	if cmd.Stream {
+	var err error
+	if stream {
 		// For streaming, we collect events and build response
-		response, err = h.handleStreamingRequest(ctx, request)
+		response, err = StreamMessage(ctx, h.claudeService, request)
 	} else {
 		response, err = h.claudeService.CreateMessage(ctx, request)
 	}
@@ -152,11 +314,16 @@ func (h *ConversationHandler) HandleSendMessage(ctx context.Context, cmd *comman
 	}
 
 	// Add assistant message
-	_, err = conversation.AddAssistantMessage(response.Content)
+	assistantMsg, err := conversation.AddAssistantMessage(response.Content)
 	if err != nil {
 		return nil, err
 	}
 
+	// Record finish metadata on the message and conversation
+	stopReason := vo.StopReason(response.StopReason)
+	recordFinishMetadata(assistantMsg, conversation, response)
+	h.recordTokenUsage(ctx, conversation, response)
+
 	// Save conversation
 	if err := h.conversationRepo.Save(ctx, conversation); err != nil {
 		return nil, err
@@ -169,18 +336,23 @@ func (h *ConversationHandler) HandleSendMessage(ctx context.Context, cmd *comman
 
 	// Check for tool use
 	var toolUses []entities.ContentBlock
-	hasToolUse := false
 	for _, block := range response.Content {
 		if block.Type == vo.ContentTypeToolUse {
 			toolUses = append(toolUses, block)
-			hasToolUse = true
 		}
 	}
 
+	// Branch on the stop reason; fall back to content inspection when absent
+	hasToolUse := stopReason == vo.StopReasonToolUse
+	if stopReason == "" {
+		hasToolUse = len(toolUses) > 0
+	}
+
 	return &SendMessageResult{
 		Response:   response,
 		ToolUses:   toolUses,
 		HasToolUse: hasToolUse,
+		StopReason: stopReason,
 	}, nil
 }
 
@@ -235,7 +407,9 @@ func (h *ConversationHandler) HandleCloseConversation(ctx context.Context, cmd *
 		return ErrConversationNotFound
 	}
 
-	conversation.Close()
+	if err := conversation.Close(); err != nil {
+		return err
+	}
 
 	if err := h.conversationRepo.Save(ctx, conversation); err != nil {
 		return err
@@ -249,6 +423,46 @@ func (h *ConversationHandler) HandleCloseConversation(ctx context.Context, cmd *
 	return nil
 }
 
+// HandleDeleteConversation handles DeleteConversationCommand. It deletes the conversation,
+// with its messages, from the repository and then removes it from its session.
+func (h *ConversationHandler) HandleDeleteConversation(ctx context.Context, cmd *commands.DeleteConversationCommand) error {
+	conversation, err := h.conversationRepo.FindByID(ctx, cmd.ConversationID)
+	if err != nil {
+		return err
+	}
+	if conversation == nil {
+		return ErrConversationNotFound
+	}
+
+	// Delete before detaching, so a failed delete leaves the conversation reachable
+	// from its session rather than stored but orphaned
+	if err := h.conversationRepo.Delete(ctx, cmd.ConversationID); err != nil {
+		return err
+	}
+
+	session, err := h.sessionRepo.FindByID(ctx, conversation.SessionID())
+	if err != nil {
+		return err
+	}
+	if session != nil {
+		if err := session.RemoveConversation(cmd.ConversationID); err != nil && !errors.Is(err, aggregates.ErrConversationNotFound) {
+			return err
+		}
+		if err := h.sessionRepo.Save(ctx, session); err != nil {
+			return err
+		}
+	}
+	conversation.MarkDeleted()
+
+	// Publish events (best-effort, don't fail on publish errors)
+	for _, event := range conversation.Events() {
+		_ = h.eventPublisher.Publish(ctx, event)
+	}
+	conversation.ClearEvents()
+
+	return nil
+}
+
 // HandleGetConversation handles GetConversationQuery
 func (h *ConversationHandler) HandleGetConversation(ctx context.Context, query *queries.GetConversationQuery) (*aggregates.Conversation, error) {
 	conversation, err := h.conversationRepo.FindByID(ctx, query.ConversationID)
@@ -319,19 +533,21 @@ func (h *ConversationHandler) HandleGetConversationMessages(ctx context.Context,
 	return messages, nil
 }
 
-// buildClaudeRequest builds a Claude API request from a conversation
-func (h *ConversationHandler) buildClaudeRequest(conversation *aggregates.Conversation) *services.ClaudeRequest {
-	messages := make([]services.ClaudeMessage, len(conversation.Messages()))
-	for i, msg := range conversation.Messages() {
+// buildClaudeRequest builds a Claude API request from a conversation, inlining the
+// content of referenced resources
+func (h *ConversationHandler) buildClaudeRequest(ctx context.Context, conversation *aggregates.Conversation) *services.ClaudeRequest {
+	history := conversation.Messages()
+	messages := make([]services.ClaudeMessage, len(history))
+	for i, msg := range history {
 		messages[i] = services.ClaudeMessage{
 			Role:    msg.Role(),
-			Content: msg.Content(),
+			Content: h.inlineAttachments(ctx, conversation.SessionID(), msg.Content()),
 		}
 	}
 
-	// Convert tools
+	// Convert the tools the conversation's tool-use settings offer to Claude
 	var tools []services.ClaudeTool
-	for _, tool := range conversation.Tools() {
+	for _, tool := range conversation.OfferedTools() {
 		tools = append(tools, services.ClaudeTool{
 			Name:        tool.Name().String(),
 			Description: tool.Description().String(),
@@ -339,6 +555,11 @@ func (h *ConversationHandler) buildClaudeRequest(conversation *aggregates.Conver
 		})
 	}
 
+	var toolChoice *services.ClaudeToolChoice
+	if settings := conversation.ToolUse(); len(tools) > 0 {
+		toolChoice = &services.ClaudeToolChoice{Type: settings.Choice, Name: settings.ToolName}
+	}
+
 	return &services.ClaudeRequest{
 		Model:         conversation.Model(),
 		SystemPrompt:  conversation.SystemPrompt(),
@@ -349,35 +570,34 @@ func (h *ConversationHandler) buildClaudeRequest(conversation *aggregates.Conver
 		TopK:          conversation.TopK(),
 		StopSequences: conversation.StopSequences(),
 		Tools:         tools,
+		ToolChoice:    toolChoice,
 	}
 }
 
-// handleStreamingRequest handles streaming response
-func (h *ConversationHandler) handleStreamingRequest(ctx context.Context, request *services.ClaudeRequest) (*services.ClaudeResponse, error) {
-	request.Stream = true
-	eventChan, err := h.claudeService.CreateMessageStream(ctx, request)
-	if err != nil {
-		return nil, err
+// recordTokenUsage adds the tokens a completion consumed to the usage counters of the
+// conversation's session. Usage is best-effort and never fails the completion.
+func (h *ConversationHandler) recordTokenUsage(ctx context.Context, conversation *aggregates.Conversation, response *services.ClaudeResponse) {
+	if response.Usage == nil {
+		return
 	}
-
-	var response *services.ClaudeResponse
-	var contentBlocks []entities.ContentBlock
-
-	for event := range eventChan {
-		if event.Error != nil {
-			return nil, event.Error
-		}
-		if event.Message != nil {
-			response = event.Message
-		}
-		if event.ContentBlock != nil {
-			contentBlocks = append(contentBlocks, *event.ContentBlock)
-		}
+	session, err := h.sessionRepo.FindByID(ctx, conversation.SessionID())
+	if err != nil || session == nil {
+		return
 	}
+	session.RecordTokenUsage(response.Usage.InputTokens, response.Usage.OutputTokens)
+}
 
-	if response != nil {
-		response.Content = contentBlocks
+// recordFinishMetadata stores the stop reason and usage of a response
+func recordFinishMetadata(msg *entities.Message, conversation *aggregates.Conversation, response *services.ClaudeResponse) {
+	if response.StopReason != "" {
+		msg.SetMetadata("stop_reason", response.StopReason)
+		conversation.SetMetadata("last_stop_reason", response.StopReason)
+	}
+	if response.StopSequence != "" {
+		msg.SetMetadata("stop_sequence", response.StopSequence)
+	}
+	if response.Usage != nil {
+		msg.SetMetadata("input_tokens", response.Usage.InputTokens)
+		msg.SetMetadata("output_tokens", response.Usage.OutputTokens)
 	}
-
-	return response, nil
 }
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// MaxImportLineSize is the maximum size of a single JSONL line accepted by the importer
+const MaxImportLineSize = 10 * 1024 * 1024
+
+// Conversation import errors
+var (
+	ErrImportReaderRequired  = errors.New("import reader is required")
+	ErrImportInvalidJSON     = errors.New("invalid JSON")
+	ErrImportEmptyContent    = errors.New("message has no content blocks")
+	ErrImportInvalidContent  = errors.New("invalid content block")
+	ErrImportFirstMessage    = errors.New("first message must have the user role")
+	ErrImportUnknownToolUse  = errors.New("tool_result does not reference a preceding tool_use")
+	ErrImportSessionMismatch = errors.New("conversation belongs to a different session")
+)
+
+// ImportMessage is a single JSONL line of a conversation import
+type ImportMessage struct {
+	Role    vo.Role                 `json:"role"`
+	Content []entities.ContentBlock `json:"content"`
+}
+
+// ImportLineError describes a line that was skipped during an import
+type ImportLineError struct {
+	Line int
+	Err  error
+}
+
+func (e *ImportLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportLineError) Unwrap() error {
+	return e.Err
+}
+
+// ImportResult represents the outcome of a conversation import
+type ImportResult struct {
+	Conversation *aggregates.Conversation
+	Imported     int
+	LastLine     int // Pass as StartLine to resume after this line
+	Errors       []*ImportLineError
+}
+
+// HandleImportConversation handles ImportConversationCommand. Invalid lines are
+// reported in the result and skipped; the import only aborts when a conversation
+// invariant is violated or the stream cannot be read, in which case the messages
+// imported so far are persisted and the partial result is returned with the error.
+func (h *ConversationHandler) HandleImportConversation(ctx context.Context, cmd *commands.ImportConversationCommand) (*ImportResult, error) {
+	if cmd.Reader == nil {
+		return nil, ErrImportReaderRequired
+	}
+
+	conversation, err := h.importTarget(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	// Only publish events raised by this import
+	conversation.ClearEvents()
+
+	result := &ImportResult{Conversation: conversation, LastLine: cmd.StartLine}
+	scanner := bufio.NewScanner(cmd.Reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxImportLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if line <= cmd.StartLine {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return result, h.saveImport(ctx, conversation, err)
+		}
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			result.LastLine = line
+			continue
+		}
+
+		msg, err := parseImportLine(conversation, text)
+		if err == nil {
+			err = conversation.AddMessage(msg)
+			if isImportInvariant(err) {
+				return result, h.saveImport(ctx, conversation, &ImportLineError{Line: line, Err: err})
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, &ImportLineError{Line: line, Err: err})
+		} else {
+			result.Imported++
+		}
+		result.LastLine = line
+	}
+	if err := scanner.Err(); err != nil {
+		return result, h.saveImport(ctx, conversation, err)
+	}
+
+	return result, h.saveImport(ctx, conversation, nil)
+}
+
+// importTarget loads the conversation being resumed or creates a new one
+func (h *ConversationHandler) importTarget(ctx context.Context, cmd *commands.ImportConversationCommand) (*aggregates.Conversation, error) {
+	if !cmd.ConversationID.IsEmpty() {
+		conversation, err := h.conversationRepo.FindByID(ctx, cmd.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+		if conversation == nil {
+			return nil, ErrConversationNotFound
+		}
+		if !conversation.SessionID().Equals(cmd.SessionID) {
+			return nil, ErrImportSessionMismatch
+		}
+		return conversation, nil
+	}
+
+	return h.HandleCreateConversation(ctx, &commands.CreateConversationCommand{
+		SessionID:    cmd.SessionID,
+		Model:        cmd.Model,
+		SystemPrompt: cmd.SystemPrompt,
+	})
+}
+
+// saveImport persists the imported messages and returns the import error, if any
+func (h *ConversationHandler) saveImport(ctx context.Context, conversation *aggregates.Conversation, importErr error) error {
+	if err := h.conversationRepo.Save(ctx, conversation); err != nil {
+		return err
+	}
+
+	// Publish events (best-effort, don't fail on publish errors)
+	for _, event := range conversation.Events() {
+		_ = h.eventPublisher.Publish(ctx, event)
+	}
+	conversation.ClearEvents()
+
+	return importErr
+}
+
+// parseImportLine decodes and validates a single import line against the conversation so far
+func parseImportLine(conversation *aggregates.Conversation, text string) (*entities.Message, error) {
+	var im ImportMessage
+	if err := json.Unmarshal([]byte(text), &im); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImportInvalidJSON, err)
+	}
+	if !im.Role.IsValid() {
+		return nil, vo.ErrInvalidRole
+	}
+	if len(im.Content) == 0 {
+		return nil, ErrImportEmptyContent
+	}
+	for i, block := range im.Content {
+		if err := validateImportBlock(block); err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+	}
+
+	last := conversation.LastMessage()
+	if last == nil && im.Role != vo.RoleUser {
+		return nil, ErrImportFirstMessage
+	}
+	for _, block := range im.Content {
+		if block.Type == vo.ContentTypeToolResult && !hasToolUse(last, block.ToolUseID) {
+			return nil, fmt.Errorf("%w: %s", ErrImportUnknownToolUse, block.ToolUseID)
+		}
+	}
+
+	return entities.NewMessage(im.Role, im.Content)
+}
+
+// validateImportBlock checks that a content block carries the fields its type requires
+func validateImportBlock(block entities.ContentBlock) error {
+	switch block.Type {
+	case vo.ContentTypeText:
+		if block.Text == "" {
+			return fmt.Errorf("%w: text block is empty", ErrImportInvalidContent)
+		}
+	case vo.ContentTypeToolUse:
+		if block.ID == "" || block.Name == "" {
+			return fmt.Errorf("%w: tool_use requires id and name", ErrImportInvalidContent)
+		}
+	case vo.ContentTypeToolResult:
+		if block.ToolUseID == "" {
+			return fmt.Errorf("%w: tool_result requires tool_use_id", ErrImportInvalidContent)
+		}
+	case vo.ContentTypeImage:
+		if block.Source == nil {
+			return fmt.Errorf("%w: image requires source", ErrImportInvalidContent)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrImportInvalidContent, block.Type)
+	}
+	return nil
+}
+
+// hasToolUse reports whether the message contains a tool_use block with the given ID
+func hasToolUse(message *entities.Message, id string) bool {
+	if message == nil {
+		return false
+	}
+	for _, block := range message.GetToolUseBlocks() {
+		if block.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// isImportInvariant reports whether an error violates an invariant that aborts the import
+func isImportInvariant(err error) bool {
+	return errors.Is(err, aggregates.ErrConversationClosed) || errors.Is(err, aggregates.ErrMaxMessagesExceeded)
+}
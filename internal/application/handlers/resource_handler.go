@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ResourceHandler handles resource subscription commands and queries
+type ResourceHandler struct {
+	sessionRepo      repositories.ISessionRepository
+	subscriptionRepo repositories.IResourceSubscriptionRepository
+}
+
+// NewResourceHandler creates a new ResourceHandler
+func NewResourceHandler(
+	sessionRepo repositories.ISessionRepository,
+	subscriptionRepo repositories.IResourceSubscriptionRepository,
+) *ResourceHandler {
+	return &ResourceHandler{
+		sessionRepo:      sessionRepo,
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// HandleSubscribeResource handles SubscribeResourceCommand
+func (h *ResourceHandler) HandleSubscribeResource(ctx context.Context, cmd *commands.SubscribeResourceCommand) error {
+	session, err := h.findOpenSession(ctx, cmd.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := session.SubscribeResource(cmd.URI); err != nil {
+		return err
+	}
+	if err := h.subscriptionRepo.Subscribe(ctx, cmd.SessionID, cmd.URI); err != nil {
+		return err
+	}
+	return h.sessionRepo.Save(ctx, session)
+}
+
+// HandleUnsubscribeResource handles UnsubscribeResourceCommand
+func (h *ResourceHandler) HandleUnsubscribeResource(ctx context.Context, cmd *commands.UnsubscribeResourceCommand) error {
+	session, err := h.findOpenSession(ctx, cmd.SessionID)
+	if err != nil {
+		return err
+	}
+
+	session.UnsubscribeResource(cmd.URI)
+	if err := h.subscriptionRepo.Unsubscribe(ctx, cmd.SessionID, cmd.URI); err != nil {
+		return err
+	}
+	return h.sessionRepo.Save(ctx, session)
+}
+
+// HandleListResourceSubscribers handles ListResourceSubscribersQuery
+func (h *ResourceHandler) HandleListResourceSubscribers(ctx context.Context, query *queries.ListResourceSubscribersQuery) ([]vo.SessionID, error) {
+	return h.subscriptionRepo.FindSubscribers(ctx, query.URI)
+}
+
+func (h *ResourceHandler) findOpenSession(ctx context.Context, id vo.SessionID) (*aggregates.Session, error) {
+	session, err := h.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if session.IsClosed() {
+		return nil, aggregates.ErrSessionClosed
+	}
+	return session, nil
+}
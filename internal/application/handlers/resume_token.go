@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// DefaultResumeTokenTTL is how long a resume token stays valid when no TTL is configured
+const DefaultResumeTokenTTL = 24 * time.Hour
+
+// Resume token errors
+var (
+	ErrInvalidResumeToken        = errors.New("invalid resume token")
+	ErrResumeTokenExpired        = errors.New("resume token expired")
+	ErrResumeTokensNotConfigured = errors.New("resume tokens are not enabled")
+)
+
+// resumeTokenPayload is the signed part of a resume token
+type resumeTokenPayload struct {
+	ConversationID string `json:"cid"`
+	ExpiresAt      int64  `json:"exp"` // Unix milliseconds
+}
+
+// ResumeTokens issues and verifies resume tokens, which let stateless clients continue
+// a conversation across requests. A token is an HMAC-SHA256 signed conversation ID
+// and expiry; the conversation itself stays in the repository.
+type ResumeTokens struct {
+	secret []byte
+	ttl    time.Duration
+	clock  services.Clock
+	skew   time.Duration
+}
+
+// NewResumeTokens creates a token issuer signing with secret. An empty secret is
+// replaced by a random one, so tokens only verify within this process.
+func NewResumeTokens(secret []byte, ttl time.Duration) (*ResumeTokens, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate resume token secret: %w", err)
+		}
+	}
+	if ttl <= 0 {
+		ttl = DefaultResumeTokenTTL
+	}
+	return &ResumeTokens{secret: secret, ttl: ttl}, nil
+}
+
+// SetClock sets the clock tokens are issued and checked against
+func (t *ResumeTokens) SetClock(clock services.Clock) {
+	t.clock = clock
+}
+
+// SetSkewTolerance keeps tokens valid for skew past their expiry, so a token issued by
+// a replica whose clock runs behind is not rejected early
+func (t *ResumeTokens) SetSkewTolerance(skew time.Duration) {
+	t.skew = skew
+}
+
+// expiry returns the checker comparing token expiry times against the clock
+func (t *ResumeTokens) expiry() services.ExpiryChecker {
+	return services.NewExpiryChecker(t.clock, t.skew)
+}
+
+// TTL returns how long issued tokens stay valid
+func (t *ResumeTokens) TTL() time.Duration {
+	return t.ttl
+}
+
+// Issue returns a token resuming the conversation until the TTL elapses
+func (t *ResumeTokens) Issue(conversationID vo.ConversationID) string {
+	payload, _ := json.Marshal(resumeTokenPayload{
+		ConversationID: conversationID.String(),
+		ExpiresAt:      t.expiry().Now().Add(t.ttl).UnixMilli(),
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(t.sign(encoded))
+}
+
+// Verify checks the token's signature and expiry and returns the conversation it names
+func (t *ResumeTokens) Verify(token string) (vo.ConversationID, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return vo.ConversationID{}, ErrInvalidResumeToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, t.sign(encoded)) {
+		return vo.ConversationID{}, ErrInvalidResumeToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return vo.ConversationID{}, ErrInvalidResumeToken
+	}
+	var payload resumeTokenPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return vo.ConversationID{}, ErrInvalidResumeToken
+	}
+	if t.expiry().Expired(time.UnixMilli(payload.ExpiresAt)) {
+		return vo.ConversationID{}, ErrResumeTokenExpired
+	}
+	conversationID, err := vo.NewConversationID(payload.ConversationID)
+	if err != nil {
+		return vo.ConversationID{}, ErrInvalidResumeToken
+	}
+	return conversationID, nil
+}
+
+// sign returns the HMAC of the encoded payload
+func (t *ResumeTokens) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// SetResumeTokens enables resume tokens for the conversations of this handler
+func (h *ConversationHandler) SetResumeTokens(tokens *ResumeTokens) {
+	h.resumeTokens = tokens
+}
+
+// IssueResumeToken returns a token a stateless client can present to resume the conversation
+func (h *ConversationHandler) IssueResumeToken(conversationID vo.ConversationID) (string, error) {
+	if h.resumeTokens == nil {
+		return "", ErrResumeTokensNotConfigured
+	}
+	return h.resumeTokens.Issue(conversationID), nil
+}
+
+// HandleResumeConversation handles ResumeConversationQuery. The token stands in for the
+// session that created the conversation, so it is not tied to the caller's session.
+func (h *ConversationHandler) HandleResumeConversation(ctx context.Context, query *queries.ResumeConversationQuery) (*aggregates.Conversation, error) {
+	if h.resumeTokens == nil {
+		return nil, ErrResumeTokensNotConfigured
+	}
+	conversationID, err := h.resumeTokens.Verify(query.Token)
+	if err != nil {
+		return nil, err
+	}
+	return h.HandleGetConversation(ctx, &queries.GetConversationQuery{ConversationID: conversationID})
+}
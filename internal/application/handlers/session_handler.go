@@ -4,25 +4,41 @@ package handlers
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/events"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
 )
 
 // Common handler errors
 var (
-	ErrSessionNotFound      = errors.New("session not found")
-	ErrSessionAlreadyExists = errors.New("session already exists")
-	ErrInvalidCommand       = errors.New("invalid command")
-	ErrInvalidQuery         = errors.New("invalid query")
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrSessionAlreadyExists  = errors.New("session already exists")
+	ErrInvalidCommand        = errors.New("invalid command")
+	ErrInvalidQuery          = errors.New("invalid query")
+	ErrSessionNotInitialized = errors.New("session has not been initialized")
+	ErrServerAtCapacity      = errors.New("server at capacity")
 )
 
 // SessionHandler handles session-related commands and queries
 type SessionHandler struct {
-	sessionRepo    repositories.ISessionRepository
-	eventPublisher EventPublisher
+	sessionRepo      repositories.ISessionRepository
+	subscriptionRepo repositories.IResourceSubscriptionRepository
+	eventPublisher   EventPublisher
+	metrics          SessionMetrics
+	maxSessions      int
+	capacityMu       sync.Mutex
+	closeHooks       []func(vo.SessionID)
+}
+
+// SessionMetrics records session capacity metrics
+type SessionMetrics interface {
+	RecordSessionCount(ctx context.Context, count int)
+	RecordSessionRejected(ctx context.Context)
 }
 
 // EventPublisher is the interface for publishing events
@@ -38,8 +54,53 @@ func NewSessionHandler(sessionRepo repositories.ISessionRepository, eventPublish
 	}
 }
 
+// SetMaxSessions sets the maximum number of non-closed sessions (0 means unlimited)
+func (h *SessionHandler) SetMaxSessions(maxSessions int) {
+	h.capacityMu.Lock()
+	defer h.capacityMu.Unlock()
+	h.maxSessions = maxSessions
+}
+
+// MaxSessions returns the maximum number of non-closed sessions
+func (h *SessionHandler) MaxSessions() int {
+	h.capacityMu.Lock()
+	defer h.capacityMu.Unlock()
+	return h.maxSessions
+}
+
+// SetMetrics sets the recorder for session capacity metrics
+func (h *SessionHandler) SetMetrics(metrics SessionMetrics) {
+	h.metrics = metrics
+}
+
+// SetSubscriptionRepository sets the repository whose subscriptions are removed when a session closes
+func (h *SessionHandler) SetSubscriptionRepository(subscriptionRepo repositories.IResourceSubscriptionRepository) {
+	h.subscriptionRepo = subscriptionRepo
+}
+
+// OnSessionClosed registers a hook run after a session is closed, used to release per-session resources
+func (h *SessionHandler) OnSessionClosed(hook func(sessionID vo.SessionID)) {
+	h.closeHooks = append(h.closeHooks, hook)
+}
+
 // HandleInitializeSession handles InitializeSessionCommand
 func (h *SessionHandler) HandleInitializeSession(ctx context.Context, cmd *commands.InitializeSessionCommand) (*aggregates.Session, error) {
+	// Hold the capacity lock until the new session is saved so concurrent
+	// initializations cannot exceed the limit
+	h.capacityMu.Lock()
+	defer h.capacityMu.Unlock()
+
+	openSessions, err := h.countOpenSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if h.maxSessions > 0 && openSessions >= h.maxSessions {
+		if h.metrics != nil {
+			h.metrics.RecordSessionRejected(ctx)
+		}
+		return nil, ErrServerAtCapacity
+	}
+
 	// Create new session
 	session := aggregates.NewSession()
 
@@ -52,14 +113,16 @@ func (h *SessionHandler) HandleInitializeSession(ctx context.Context, cmd *comma
 	if err := session.Initialize(clientInfo, cmd.ProtocolVersion); err != nil {
 		return nil, err
 	}
+	session.GrantScopes(cmd.Scopes)
 
-	// Mark as ready
-	session.MarkReady()
-
+	// The session becomes ready once the client sends notifications/initialized
 	// Save session
 	if err := h.sessionRepo.Save(ctx, session); err != nil {
 		return nil, err
 	}
+	if h.metrics != nil {
+		h.metrics.RecordSessionCount(ctx, openSessions+1)
+	}
 
 	// Publish events (best-effort, don't fail on publish errors)
 	for _, event := range session.Events() {
@@ -69,6 +132,28 @@ func (h *SessionHandler) HandleInitializeSession(ctx context.Context, cmd *comma
 	return session, nil
 }
 
+// HandleMarkSessionReady handles MarkSessionReadyCommand
+func (h *SessionHandler) HandleMarkSessionReady(ctx context.Context, cmd *commands.MarkSessionReadyCommand) error {
+	session, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return ErrSessionNotFound
+	}
+
+	if session.IsClosed() {
+		return aggregates.ErrSessionClosed
+	}
+
+	session.MarkReady()
+	if !session.IsReady() {
+		return ErrSessionNotInitialized
+	}
+
+	return h.sessionRepo.Save(ctx, session)
+}
+
 // HandleCloseSession handles CloseSessionCommand
 func (h *SessionHandler) HandleCloseSession(ctx context.Context, cmd *commands.CloseSessionCommand) error {
 	session, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
@@ -84,6 +169,15 @@ func (h *SessionHandler) HandleCloseSession(ctx context.Context, cmd *commands.C
 	if err := h.sessionRepo.Save(ctx, session); err != nil {
 		return err
 	}
+	if h.subscriptionRepo != nil {
+		if err := h.subscriptionRepo.DeleteBySessionID(ctx, session.ID()); err != nil {
+			return err
+		}
+	}
+	h.recordSessionCount(ctx)
+	for _, hook := range h.closeHooks {
+		hook(session.ID())
+	}
 
 	// Publish events (best-effort, don't fail on publish errors)
 	for _, event := range session.Events() {
@@ -93,6 +187,36 @@ func (h *SessionHandler) HandleCloseSession(ctx context.Context, cmd *commands.C
 	return nil
 }
 
+// HandleResetSession handles ResetSessionCommand
+func (h *SessionHandler) HandleResetSession(ctx context.Context, cmd *commands.ResetSessionCommand) (*aggregates.SessionResetSummary, error) {
+	session, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	summary, err := session.Reset()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.sessionRepo.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	if h.subscriptionRepo != nil {
+		if err := h.subscriptionRepo.DeleteBySessionID(ctx, session.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Publish event (best-effort, don't fail on publish errors)
+	_ = h.eventPublisher.Publish(ctx, events.NewSessionResetEvent(session.ID()))
+
+	return summary, nil
+}
+
 // HandleSetLogLevel handles SetLogLevelCommand
 func (h *SessionHandler) HandleSetLogLevel(ctx context.Context, cmd *commands.SetLogLevelCommand) error {
 	session, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
@@ -182,3 +306,18 @@ func (h *SessionHandler) HandleGetSessionStats(ctx context.Context, query *queri
 
 	return stats, nil
 }
+
+// countOpenSessions counts sessions that have not been closed
+func (h *SessionHandler) countOpenSessions(ctx context.Context) (int, error) {
+	return h.sessionRepo.CountOpen(ctx)
+}
+
+// recordSessionCount reports the current number of open sessions
+func (h *SessionHandler) recordSessionCount(ctx context.Context) {
+	if h.metrics == nil {
+		return
+	}
+	if count, err := h.countOpenSessions(ctx); err == nil {
+		h.metrics.RecordSessionCount(ctx, count)
+	}
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Stream event and delta types
+const (
+	streamEventContentBlockDelta = "content_block_delta"
+	streamEventContentBlockStop  = "content_block_stop"
+
+	streamDeltaText      = "text_delta"
+	streamDeltaInputJSON = "input_json_delta"
+)
+
+// streamAssembler rebuilds content blocks from streaming events. Text deltas are
+// appended to their block and input_json_delta fragments are buffered until the
+// block stops, at which point a complete tool_use block is handed to onToolUse.
+// A block whose input does not parse, as when max_tokens cuts it off, is only
+// marked incomplete; the final stop_reason decides whether that is an error.
+type streamAssembler struct {
+	blocks      map[int]*entities.ContentBlock
+	partialJSON map[int]*strings.Builder
+	incomplete  map[int]error
+	onToolUse   func(entities.ContentBlock)
+}
+
+func newStreamAssembler(onToolUse func(entities.ContentBlock)) *streamAssembler {
+	return &streamAssembler{
+		blocks:      make(map[int]*entities.ContentBlock),
+		partialJSON: make(map[int]*strings.Builder),
+		incomplete:  make(map[int]error),
+		onToolUse:   onToolUse,
+	}
+}
+
+// add applies a streaming event to the blocks being assembled
+func (a *streamAssembler) add(event *services.ClaudeStreamEvent) {
+	switch {
+	case event.ContentBlock != nil:
+		block := *event.ContentBlock
+		a.blocks[event.Index] = &block
+
+	case event.Type == streamEventContentBlockDelta && event.Delta != nil:
+		block, ok := a.blocks[event.Index]
+		if !ok {
+			return
+		}
+		switch event.Delta.Type {
+		case streamDeltaText:
+			block.Text += event.Delta.Text
+		case streamDeltaInputJSON:
+			buf, ok := a.partialJSON[event.Index]
+			if !ok {
+				buf = &strings.Builder{}
+				a.partialJSON[event.Index] = buf
+			}
+			buf.WriteString(event.Delta.PartialJSON)
+		}
+
+	case event.Type == streamEventContentBlockStop:
+		block, ok := a.blocks[event.Index]
+		if !ok || block.Type != vo.ContentTypeToolUse {
+			return
+		}
+		if buf, ok := a.partialJSON[event.Index]; ok && strings.TrimSpace(buf.String()) != "" {
+			var input map[string]interface{}
+			if err := json.Unmarshal([]byte(buf.String()), &input); err != nil {
+				a.incomplete[event.Index] = fmt.Errorf("%w for %s: %v", ErrInvalidToolInput, block.Name, err)
+				return
+			}
+			block.Input = input
+			delete(a.partialJSON, event.Index)
+		}
+		if block.Input == nil {
+			block.Input = map[string]interface{}{}
+		}
+		if a.onToolUse != nil {
+			a.onToolUse(*block)
+		}
+	}
+}
+
+// finish settles incomplete tool_use blocks once the stop reason is known. Claude
+// asking for a tool whose input does not parse is an error; any other stop reason
+// means the response was cut short, so the unusable blocks are dropped.
+func (a *streamAssembler) finish(stopReason string) error {
+	for index, err := range a.incomplete {
+		if stopReason == string(vo.StopReasonToolUse) {
+			return err
+		}
+		delete(a.blocks, index)
+	}
+	return nil
+}
+
+// content returns the assembled blocks in stream order
+func (a *streamAssembler) content() []entities.ContentBlock {
+	indexes := make([]int, 0, len(a.blocks))
+	for index := range a.blocks {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	blocks := make([]entities.ContentBlock, 0, len(indexes))
+	for _, index := range indexes {
+		blocks = append(blocks, *a.blocks[index])
+	}
+	return blocks
+}
+
+// StreamMessage sends request over a streaming connection and assembles the response
+// from its events. When ctx carries tool output, as a tools/call with a progress token
+// does, each text delta is forwarded to it as it arrives. Conversation turns and
+// stateless claude_conversation calls share it.
+func StreamMessage(ctx context.Context, claude services.IClaudeService, request *services.ClaudeRequest) (*services.ClaudeResponse, error) {
+	request.Stream = true
+	eventChan, err := claude.CreateMessageStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &services.ClaudeResponse{Role: vo.RoleAssistant, Model: request.Model.String()}
+	var stopReason, stopSequence string
+	var usage *services.ClaudeUsage
+	assembler := newStreamAssembler(toolUseListenerFromContext(ctx))
+	output, _ := entities.ToolOutputFromContext(ctx)
+
+	for event := range eventChan {
+		if event.Error != nil {
+			return nil, event.Error
+		}
+		if event.Message != nil {
+			response = event.Message
+		}
+		assembler.add(event)
+		if output != nil && event.Delta != nil && event.Delta.Type == streamDeltaText && event.Delta.Text != "" {
+			output(event.Delta.Text)
+		}
+		if event.Delta != nil && event.Delta.StopReason != "" {
+			stopReason = event.Delta.StopReason
+			stopSequence = event.Delta.StopSequence
+		}
+		if event.Usage != nil {
+			usage = event.Usage
+		}
+	}
+
+	if err := assembler.finish(stopReason); err != nil {
+		return nil, err
+	}
+	response.Content = assembler.content()
+	response.StopReason = stopReason
+	response.StopSequence = stopSequence
+	switch {
+	case response.Usage == nil:
+		response.Usage = usage
+	case usage != nil:
+		// message_start counts the input, message_delta the output
+		response.Usage.OutputTokens = usage.OutputTokens
+	}
+	return response, nil
+}
+
+type toolUseListenerKey struct{}
+
+// withToolUseListener returns a context whose streaming requests report each tool_use block as soon as its input is complete
+func withToolUseListener(ctx context.Context, listener func(entities.ContentBlock)) context.Context {
+	return context.WithValue(ctx, toolUseListenerKey{}, listener)
+}
+
+func toolUseListenerFromContext(ctx context.Context) func(entities.ContentBlock) {
+	listener, _ := ctx.Value(toolUseListenerKey{}).(func(entities.ContentBlock))
+	return listener
+}
+
+// toolUseOutcome is the tool_result produced for a tool_use block
+type toolUseOutcome struct {
+	block  entities.ContentBlock
+	cached bool
+}
+
+// toolUsePipeline executes tool_use blocks on a single worker while the response is
+// still streaming. Tools run one at a time in stream order, matching the order the
+// turn would otherwise execute them in, so the per-turn cache behaves the same.
+// Only read-only tools start early: until message_delta confirms stop_reason
+// tool_use the response may still be cut short, so the first tool that could
+// change state, and every tool after it, waits for the turn to run it.
+type toolUsePipeline struct {
+	handler   *ConversationHandler
+	ctx       context.Context
+	sessionID vo.SessionID
+	cache     *toolResultCache
+	queue     chan entities.ContentBlock
+	done      chan struct{}
+	deferred  bool
+
+	mu       sync.Mutex
+	outcomes map[string]toolUseOutcome
+}
+
+// startToolUsePipeline starts a pipeline for a streaming completion and returns a
+// context that feeds it. It returns a nil pipeline when not streaming.
+func (h *ConversationHandler) startToolUsePipeline(ctx context.Context, stream bool, sessionID vo.SessionID, cache *toolResultCache) (context.Context, *toolUsePipeline) {
+	if !stream {
+		return ctx, nil
+	}
+
+	p := &toolUsePipeline{
+		handler:   h,
+		ctx:       ctx,
+		sessionID: sessionID,
+		cache:     cache,
+		queue:     make(chan entities.ContentBlock, 16),
+		done:      make(chan struct{}),
+		outcomes:  make(map[string]toolUseOutcome),
+	}
+	go p.run(ctx)
+	return withToolUseListener(ctx, p.submit), p
+}
+
+func (p *toolUsePipeline) submit(toolUse entities.ContentBlock) {
+	if p.deferred {
+		return
+	}
+	if _, readOnly := p.handler.toolHandler.cachePolicy(p.ctx, toolUse.Name); !readOnly {
+		p.deferred = true
+		return
+	}
+	p.queue <- toolUse
+}
+
+func (p *toolUsePipeline) run(ctx context.Context) {
+	defer close(p.done)
+	for toolUse := range p.queue {
+		if ctx.Err() != nil {
+			continue
+		}
+		block, cached := p.handler.executeToolUse(ctx, p.sessionID, toolUse, p.cache)
+		p.mu.Lock()
+		p.outcomes[toolUse.ID] = toolUseOutcome{block: block, cached: cached}
+		p.mu.Unlock()
+	}
+}
+
+// finish waits for submitted tools to complete and returns their outcomes by tool_use ID
+func (p *toolUsePipeline) finish() map[string]toolUseOutcome {
+	if p == nil {
+		return nil
+	}
+	close(p.queue)
+	<-p.done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.outcomes
+}
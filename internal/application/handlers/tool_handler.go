@@ -4,6 +4,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
@@ -21,6 +22,9 @@ var (
 	ErrToolDisabled      = errors.New("tool is disabled")
 	ErrInvalidToolInput  = errors.New("invalid tool input")
 	ErrToolExecution     = errors.New("tool execution failed")
+	ErrToolScopeRequired = errors.New("tool requires a scope that has not been granted")
+	ErrInvalidToolUpdate = errors.New("invalid tool update")
+	ErrToolSafeMode      = errors.New("tool is not read-only and safe mode is active")
 )
 
 // ToolHandler handles tool-related commands and queries
@@ -29,6 +33,9 @@ type ToolHandler struct {
 	toolRepo       repositories.IToolRepository
 	eventPublisher EventPublisher
 	toolRegistry   map[string]entities.ToolHandler
+	limiter        *toolRateLimiter
+	metrics        ToolMetrics
+	safeMode       bool
 }
 
 // NewToolHandler creates a new ToolHandler
@@ -42,9 +49,24 @@ func NewToolHandler(
 		toolRepo:       toolRepo,
 		eventPublisher: eventPublisher,
 		toolRegistry:   make(map[string]entities.ToolHandler),
+		limiter:        newToolRateLimiter(),
 	}
 }
 
+// SetSafeMode restricts calls to tools annotated read-only. In safe mode other tools
+// cannot be called, re-enabled or re-annotated as read-only.
+func (h *ToolHandler) SetSafeMode(enabled bool) {
+	h.safeMode = enabled
+}
+
+// checkSafeMode refuses tools safe mode does not allow
+func (h *ToolHandler) checkSafeMode(tool *entities.Tool) error {
+	if h.safeMode && !tool.IsReadOnly() {
+		return fmt.Errorf("%w: %s", ErrToolSafeMode, tool.Name())
+	}
+	return nil
+}
+
 // RegisterToolHandler registers a tool handler function
 func (h *ToolHandler) RegisterToolHandler(name string, handler entities.ToolHandler) {
 	h.toolRegistry[name] = handler
@@ -67,9 +89,9 @@ func (h *ToolHandler) HandleRegisterTool(ctx context.Context, cmd *commands.Regi
 		return nil, err
 	}
 
-	// Check if tool already exists
+	// Check if tool already exists, unless an override was requested
 	existing, _ := h.toolRepo.FindByName(ctx, name)
-	if existing != nil {
+	if existing != nil && !cmd.Replace {
 		return nil, ErrToolAlreadyExists
 	}
 
@@ -98,13 +120,20 @@ func (h *ToolHandler) HandleRegisterTool(ctx context.Context, cmd *commands.Regi
 		tool.SetHandler(handler)
 	}
 
-	// Register tool in repository
-	if err := h.toolRepo.Register(ctx, tool); err != nil {
-		return nil, err
+	// Register tool in repository and session
+	if cmd.Replace {
+		if _, err := h.toolRepo.Replace(ctx, tool); err != nil {
+			return nil, err
+		}
+		session.ReplaceTool(tool)
+	} else {
+		if err := h.toolRepo.Register(ctx, tool); err != nil {
+			return nil, err
+		}
+		if err := session.RegisterTool(tool); err != nil {
+			return nil, err
+		}
 	}
-
-	// Register in session
-	session.RegisterTool(tool)
 	if err := h.sessionRepo.Save(ctx, session); err != nil {
 		return nil, err
 	}
@@ -152,6 +181,115 @@ func (h *ToolHandler) HandleUnregisterTool(ctx context.Context, cmd *commands.Un
 	return h.sessionRepo.Save(ctx, session)
 }
 
+// HandleSetToolEnabled enables or disables a registered tool at runtime
+func (h *ToolHandler) HandleSetToolEnabled(ctx context.Context, cmd *commands.SetToolEnabledCommand) (*entities.Tool, error) {
+	name, err := vo.NewToolName(cmd.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tool, err := h.toolRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if tool == nil {
+		return nil, ErrToolNotFound
+	}
+
+	if cmd.Enabled {
+		if err := h.checkSafeMode(tool); err != nil {
+			return nil, err
+		}
+		tool.Enable()
+	} else {
+		tool.Disable()
+	}
+	// Listings filter on the stored state, which a database backend keeps in its rows
+	if err := h.toolRepo.Update(ctx, tool); err != nil {
+		return nil, err
+	}
+	return tool, nil
+}
+
+// HandleUpdateTool patches the mutable fields of a registered tool in the repository
+// and in the session
+func (h *ToolHandler) HandleUpdateTool(ctx context.Context, cmd *commands.UpdateToolCommand) (*entities.Tool, error) {
+	session, err := h.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	name, err := vo.NewToolName(cmd.Name)
+	if err != nil {
+		return nil, err
+	}
+	tool, err := h.toolRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if tool == nil {
+		return nil, ErrToolNotFound
+	}
+
+	// Validate the whole patch before changing anything
+	var description vo.ToolDescription
+	if cmd.Description != nil {
+		description, err = vo.NewToolDescription(*cmd.Description)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidToolUpdate, err)
+		}
+	}
+	if cmd.Timeout != nil && *cmd.Timeout <= 0 {
+		return nil, fmt.Errorf("%w: timeout must be positive", ErrInvalidToolUpdate)
+	}
+	markedReadOnly := cmd.Annotations != nil && cmd.Annotations.ReadOnlyHint != nil && *cmd.Annotations.ReadOnlyHint
+	if (cmd.Enabled != nil && *cmd.Enabled) || markedReadOnly {
+		if err := h.checkSafeMode(tool); err != nil {
+			return nil, err
+		}
+	}
+
+	apply := func(t *entities.Tool) {
+		if cmd.Description != nil {
+			t.SetDescription(description)
+		}
+		if cmd.Timeout != nil {
+			t.SetTimeout(*cmd.Timeout)
+		}
+		if cmd.Enabled != nil {
+			if *cmd.Enabled {
+				t.Enable()
+			} else {
+				t.Disable()
+			}
+		}
+		if cmd.Tags != nil {
+			t.SetTags(append([]string{}, cmd.Tags...))
+		}
+		if cmd.Category != nil {
+			t.SetCategory(*cmd.Category)
+		}
+		if cmd.Annotations != nil {
+			t.SetAnnotations(cmd.Annotations.Apply(t.Annotations()))
+		}
+	}
+
+	apply(tool)
+	if err := h.toolRepo.Update(ctx, tool); err != nil {
+		return nil, err
+	}
+	if sessionTool, ok := session.GetTool(cmd.Name); ok && sessionTool != tool {
+		apply(sessionTool)
+	}
+	if err := h.sessionRepo.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	return tool, nil
+}
+
 // HandleExecuteTool handles ExecuteToolCommand
 func (h *ToolHandler) HandleExecuteTool(ctx context.Context, cmd *commands.ExecuteToolCommand) (*entities.ToolResult, error) {
 	startTime := time.Now()
@@ -180,13 +318,30 @@ func (h *ToolHandler) HandleExecuteTool(ctx context.Context, cmd *commands.Execu
 		return nil, ErrToolNotFound
 	}
 
+	// Safe mode is checked first so callers learn why a mutating tool is unavailable
+	if err := h.checkSafeMode(tool); err != nil {
+		return nil, err
+	}
 	// Check if tool is enabled
 	if !tool.IsEnabled() {
 		return nil, ErrToolDisabled
 	}
+	// Scopes belong to the calling session, so one client's credentials never let
+	// another client call the tool
+	if scope := tool.RequiredScope(); scope != "" && !session.HasScope(scope) {
+		return nil, fmt.Errorf("%w: %s", ErrToolScopeRequired, scope)
+	}
+	if err := h.checkToolRateLimit(ctx, session.ID(), tool); err != nil {
+		return nil, err
+	}
 
-	// Execute tool with timeout
-	execCtx, cancel := context.WithTimeout(ctx, tool.Timeout())
+	// Execute tool with timeout, exposing the session and its store to stateful tools
+	execCtx := entities.ContextWithSessionID(entities.ContextWithSessionStore(ctx, session.Store()), session.ID())
+	// File tools check paths against the roots as they were when the call started
+	if roots, ok := session.Roots(); ok {
+		execCtx = entities.ContextWithRoots(execCtx, roots)
+	}
+	execCtx, cancel := context.WithTimeout(execCtx, tool.Timeout())
 	defer cancel()
 
 	result, err := h.executeToolWithContext(execCtx, tool, cmd.Arguments)
@@ -194,10 +349,15 @@ func (h *ToolHandler) HandleExecuteTool(ctx context.Context, cmd *commands.Execu
 
 	// Publish execution event (best-effort, don't fail on publish errors)
 	success := err == nil && (result == nil || !result.IsError)
+	session.RecordToolExecution(cmd.Name, success, duration)
 	event := events.NewToolExecutedEvent(cmd.SessionID, cmd.Name, success, duration)
 	_ = h.eventPublisher.Publish(ctx, event)
 
 	if err != nil {
+		// A timeout or cancellation is not a tool failure; the caller decides how to report it
+		if ctxErr := execCtx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("tool %s stopped after %s: %w", cmd.Name, duration.Round(time.Millisecond), ctxErr)
+		}
 		return entities.NewErrorToolResult(err), nil
 	}
 
@@ -210,7 +370,7 @@ func (h *ToolHandler) executeToolWithContext(ctx context.Context, tool *entities
 	errChan := make(chan error, 1)
 
 	go func() {
-		result, err := tool.Execute(input)
+		result, err := tool.ExecuteContext(ctx, input)
 		if err != nil {
 			errChan <- err
 			return
@@ -220,6 +380,12 @@ func (h *ToolHandler) executeToolWithContext(ctx context.Context, tool *entities
 
 	select {
 	case <-ctx.Done():
+		// A result that arrived together with the deadline still counts
+		select {
+		case result := <-resultChan:
+			return result, nil
+		default:
+		}
 		return nil, ctx.Err()
 	case err := <-errChan:
 		return nil, err
@@ -228,6 +394,20 @@ func (h *ToolHandler) executeToolWithContext(ctx context.Context, tool *entities
 	}
 }
 
+// cachePolicy reports whether the named tool opted into per-turn result caching and
+// whether it is read-only. Unknown tools are treated as neither.
+func (h *ToolHandler) cachePolicy(ctx context.Context, toolName string) (cacheable, readOnly bool) {
+	name, err := vo.NewToolName(toolName)
+	if err != nil {
+		return false, false
+	}
+	tool, err := h.toolRepo.FindByName(ctx, name)
+	if err != nil || tool == nil {
+		return false, false
+	}
+	return tool.IsCacheable(), tool.IsReadOnly()
+}
+
 // HandleGetTool handles GetToolQuery
 func (h *ToolHandler) HandleGetTool(ctx context.Context, query *queries.GetToolQuery) (*entities.Tool, error) {
 	// Create tool name value object
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// DefaultMaxStreamedToolOutput is the default number of bytes of streamed tool output
+// kept in the conversation history
+const DefaultMaxStreamedToolOutput = 64 * 1024
+
+// SetMaxStreamedToolOutput caps how many bytes of a streaming tool's output are kept in
+// its tool_result block; the most recent output is kept (0 disables the cap)
+func (h *ConversationHandler) SetMaxStreamedToolOutput(limit int) {
+	h.maxStreamedOutput = limit
+}
+
+// toolOutputBuffer collects the output a tool streams during an agentic turn so the
+// conversation records it as a single tool_result, while each chunk is still passed on
+// to whoever streams the turn to the client
+type toolOutputBuffer struct {
+	mu       sync.Mutex
+	limit    int
+	data     []byte
+	dropped  int
+	streamed bool
+	forward  entities.ToolOutputFunc
+}
+
+// withToolOutputBuffer returns a context whose tools stream into a new buffer, which
+// forwards every chunk to the receiver already carried by ctx, if any
+func (h *ConversationHandler) withToolOutputBuffer(ctx context.Context) (context.Context, *toolOutputBuffer) {
+	buffer := &toolOutputBuffer{limit: h.maxStreamedOutput}
+	buffer.forward, _ = entities.ToolOutputFromContext(ctx)
+	return entities.ContextWithToolOutput(ctx, buffer.write), buffer
+}
+
+func (b *toolOutputBuffer) write(chunk string) {
+	b.mu.Lock()
+	b.streamed = true
+	b.data = append(b.data, chunk...)
+	if b.limit > 0 && len(b.data) > b.limit {
+		// Keep the most recent output, cut at a rune boundary
+		cut := len(b.data) - b.limit
+		for cut < len(b.data) && !utf8.RuneStart(b.data[cut]) {
+			cut++
+		}
+		b.dropped += cut
+		b.data = append(b.data[:0], b.data[cut:]...)
+	}
+	b.mu.Unlock()
+
+	if b.forward != nil {
+		b.forward(chunk)
+	}
+}
+
+// consolidate returns the tool_result text for result: the streamed output followed by
+// the text of the final result, unless the tool repeated its output there. Tools that
+// did not stream keep their result text unchanged.
+func (b *toolOutputBuffer) consolidate(result *entities.ToolResult) string {
+	final := ""
+	if result != nil {
+		final = toolResultText(result)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.streamed {
+		return final
+	}
+
+	text := string(b.data)
+	if b.dropped > 0 {
+		text = fmt.Sprintf("[%d bytes of earlier output omitted]\n%s", b.dropped, text)
+	}
+	if final != "" && final != string(b.data) {
+		if text != "" && text[len(text)-1] != '\n' {
+			text += "\n"
+		}
+		text += final
+	}
+	return text
+}
+
+// resultContent returns the tool_result blocks for result, given the text consolidate
+// returned for it. When the tool streamed, that text takes the place of the result's
+// text items and the other items follow it.
+func (b *toolOutputBuffer) resultContent(result *entities.ToolResult, text string) []entities.ContentBlock {
+	blocks := toolResultContent(result)
+	if blocks == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	streamed := b.streamed
+	b.mu.Unlock()
+	if !streamed {
+		return blocks
+	}
+
+	merged := []entities.ContentBlock{{Type: vo.ContentTypeText, Text: text}}
+	for _, block := range blocks {
+		if block.Type != vo.ContentTypeText {
+			merged = append(merged, block)
+		}
+	}
+	return merged
+}
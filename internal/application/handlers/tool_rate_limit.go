@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// DefaultToolRateLimitWindow is the window tool rate limits count calls over
+const DefaultToolRateLimitWindow = time.Minute
+
+// ErrToolRateLimited is returned when a session calls a tool more often than its limit allows
+var ErrToolRateLimited = errors.New("tool rate limit exceeded")
+
+// ToolRateLimitError reports a throttled tool call and when the session may call it again
+type ToolRateLimitError struct {
+	Tool       string
+	Limit      int
+	Window     time.Duration
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *ToolRateLimitError) Error() string {
+	return fmt.Sprintf("%s: %s allows %d calls per %s per session, retry in %s",
+		ErrToolRateLimited, e.Tool, e.Limit, e.Window, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Unwrap returns ErrToolRateLimited
+func (e *ToolRateLimitError) Unwrap() error {
+	return ErrToolRateLimited
+}
+
+// ToolMetrics records tool execution metrics
+type ToolMetrics interface {
+	RecordToolThrottled(ctx context.Context, toolName string)
+}
+
+// SetToolRateLimits sets the maximum number of calls a session may make to each named
+// tool per window. Tools not listed fall back to their own RequestsPerMinute rate
+// limit, if any, which is always counted per minute; a limit of 0 leaves a tool unlimited.
+func (h *ToolHandler) SetToolRateLimits(limits map[string]int) {
+	copied := make(map[string]int, len(limits))
+	for name, limit := range limits {
+		copied[name] = limit
+	}
+	h.limiter.mu.Lock()
+	defer h.limiter.mu.Unlock()
+	h.limiter.limits = copied
+}
+
+// SetToolRateLimitWindow sets the window tool rate limits count calls over
+// (default: DefaultToolRateLimitWindow)
+func (h *ToolHandler) SetToolRateLimitWindow(window time.Duration) {
+	h.limiter.mu.Lock()
+	defer h.limiter.mu.Unlock()
+	h.limiter.window = window
+}
+
+// SetMetrics sets the recorder for tool execution metrics
+func (h *ToolHandler) SetMetrics(metrics ToolMetrics) {
+	h.metrics = metrics
+}
+
+// ReleaseSessionRateLimits forgets the calls a closed session made
+func (h *ToolHandler) ReleaseSessionRateLimits(sessionID vo.SessionID) {
+	h.limiter.release(sessionID.String())
+}
+
+// checkToolRateLimit records a call of tool by the session, or returns a
+// ToolRateLimitError without recording it when the session is over the tool's limit
+func (h *ToolHandler) checkToolRateLimit(ctx context.Context, sessionID vo.SessionID, tool *entities.Tool) error {
+	err := h.limiter.allow(sessionID.String(), tool, time.Now())
+	if err != nil && h.metrics != nil {
+		h.metrics.RecordToolThrottled(ctx, tool.Name().String())
+	}
+	return err
+}
+
+// toolRateLimiter keeps, per session and tool, the times of calls within the window
+type toolRateLimiter struct {
+	mu     sync.Mutex
+	limits map[string]int
+	window time.Duration
+	calls  map[string]map[string][]time.Time // session ID -> tool name -> call times
+}
+
+func newToolRateLimiter() *toolRateLimiter {
+	return &toolRateLimiter{
+		limits: make(map[string]int),
+		window: DefaultToolRateLimitWindow,
+		calls:  make(map[string]map[string][]time.Time),
+	}
+}
+
+// limitFor returns the configured limit for tool and the configured window it is
+// counted over, falling back to the tool's own per-minute rate limit counted over
+// DefaultToolRateLimitWindow
+func (l *toolRateLimiter) limitFor(tool *entities.Tool) (int, time.Duration) {
+	if limit, ok := l.limits[tool.Name().String()]; ok {
+		return limit, l.window
+	}
+	if rl := tool.RateLimitConfig(); rl != nil {
+		return rl.RequestsPerMinute, DefaultToolRateLimitWindow
+	}
+	return 0, l.window
+}
+
+func (l *toolRateLimiter) allow(sessionID string, tool *entities.Tool, now time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit, window := l.limitFor(tool)
+	if limit <= 0 {
+		return nil
+	}
+	name := tool.Name().String()
+	tools, ok := l.calls[sessionID]
+	if !ok {
+		tools = make(map[string][]time.Time)
+		l.calls[sessionID] = tools
+	}
+
+	// Drop calls that have left the window
+	calls := tools[name]
+	cutoff := now.Add(-window)
+	kept := 0
+	for kept < len(calls) && !calls[kept].After(cutoff) {
+		kept++
+	}
+	calls = calls[kept:]
+
+	if len(calls) >= limit {
+		tools[name] = calls
+		return &ToolRateLimitError{Tool: name, Limit: limit, Window: window, RetryAfter: calls[0].Add(window).Sub(now)}
+	}
+	tools[name] = append(calls, now)
+	return nil
+}
+
+func (l *toolRateLimiter) release(sessionID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.calls, sessionID)
+}
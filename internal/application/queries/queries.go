@@ -52,6 +52,15 @@ func (q *GetConversationQuery) QueryName() string {
 	return "GetConversation"
 }
 
+// ResumeConversationQuery retrieves the conversation named by a resume token
+type ResumeConversationQuery struct {
+	Token string
+}
+
+func (q *ResumeConversationQuery) QueryName() string {
+	return "ResumeConversation"
+}
+
 // ListConversationsQuery lists conversations
 type ListConversationsQuery struct {
 	SessionID  vo.SessionID
@@ -135,6 +144,15 @@ func (q *ListResourcesQuery) QueryName() string {
 	return "ListResources"
 }
 
+// ListResourceSubscribersQuery lists the sessions subscribed to a resource
+type ListResourceSubscribersQuery struct {
+	URI string
+}
+
+func (q *ListResourceSubscribersQuery) QueryName() string {
+	return "ListResourceSubscribers"
+}
+
 // Prompt Queries
 
 // GetPromptQuery retrieves a prompt by name
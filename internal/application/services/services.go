@@ -321,7 +321,9 @@ func (s *ConversationService) CloseConversation(ctx context.Context, conversatio
 		return ErrInvalidInput
 	}
 
-	conversation.Close()
+	if err := conversation.Close(); err != nil {
+		return err
+	}
 
 	return s.conversationRepo.Save(ctx, conversation)
 }
@@ -3,6 +3,7 @@ package aggregates
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -19,6 +20,12 @@ var (
 	ErrInvalidMessageOrder   = errors.New("invalid message order")
 	ErrMaxMessagesExceeded   = errors.New("maximum messages exceeded")
 	ErrSystemPromptImmutable = errors.New("system prompt cannot be changed after conversation started")
+	ErrInvalidTemperature    = errors.New("temperature must be between 0 and 2")
+	ErrInvalidTopP           = errors.New("top_p must be between 0 and 1")
+	ErrInvalidTopK           = errors.New("top_k must not be negative")
+	ErrInvalidTransition     = errors.New("invalid conversation status transition")
+	ErrToolNotInConversation = errors.New("tool is not available in the conversation")
+	ErrLastMessageNotUser    = errors.New("last message is not a user message")
 )
 
 // ConversationStatus represents the status of a conversation
@@ -31,6 +38,21 @@ const (
 	ConversationStatusArchived ConversationStatus = "archived"
 )
 
+// conversationTransition describes a status change and the statuses it may start from
+type conversationTransition struct {
+	from []ConversationStatus
+	to   ConversationStatus
+}
+
+// conversationTransitions is the conversation state machine, keyed by action
+var conversationTransitions = map[string]conversationTransition{
+	"pause":   {from: []ConversationStatus{ConversationStatusActive}, to: ConversationStatusPaused},
+	"resume":  {from: []ConversationStatus{ConversationStatusPaused}, to: ConversationStatusActive},
+	"close":   {from: []ConversationStatus{ConversationStatusActive, ConversationStatusPaused}, to: ConversationStatusClosed},
+	"archive": {from: []ConversationStatus{ConversationStatusClosed}, to: ConversationStatusArchived},
+	"reopen":  {from: []ConversationStatus{ConversationStatusArchived}, to: ConversationStatusActive},
+}
+
 // MaxMessages is the maximum number of messages allowed in a conversation
 const MaxMessages = 10000
 
@@ -50,6 +72,7 @@ type Conversation struct {
 	topK          int
 	stopSequences []string
 	tools         []*entities.Tool
+	toolUse       vo.ToolUseSettings
 	createdAt     time.Time
 	updatedAt     time.Time
 	closedAt      *time.Time
@@ -71,6 +94,7 @@ func NewConversation(sessionID vo.SessionID, model vo.Model) *Conversation {
 		topP:        1.0,  // Default top_p
 		topK:        0,    // Default top_k (0 = disabled)
 		tools:       make([]*entities.Tool, 0),
+		toolUse:     vo.DefaultToolUseSettings(),
 		createdAt:   now,
 		updatedAt:   now,
 		metadata:    make(map[string]interface{}),
@@ -128,11 +152,17 @@ func (c *Conversation) SetSystemPrompt(prompt vo.SystemPrompt) error {
 	return nil
 }
 
-// Messages returns all messages
+// Messages returns copies of all messages, so callers can read them while other
+// goroutines add to the conversation
 func (c *Conversation) Messages() []*entities.Message {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.messages
+
+	messages := make([]*entities.Message, len(c.messages))
+	for i, msg := range c.messages {
+		messages[i] = msg.Clone()
+	}
+	return messages
 }
 
 // MessageCount returns the number of messages
@@ -193,6 +223,37 @@ func (c *Conversation) AddUserMessage(text string) (*entities.Message, error) {
 	return msg, nil
 }
 
+// EditLastUserMessage replaces the text of the last message, which must be a user
+// text message, so the next request to Claude sends the edited text. Resource
+// references attached to the message are kept.
+func (c *Conversation) EditLastUserMessage(newText string) (*entities.Message, error) {
+	if newText == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.status == ConversationStatusClosed {
+		return nil, ErrConversationClosed
+	}
+	if len(c.messages) == 0 {
+		return nil, ErrLastMessageNotUser
+	}
+
+	// Tool results are user messages too, but they answer Claude rather than the user
+	last := c.messages[len(c.messages)-1]
+	if !last.IsUserMessage() || last.GetTextContent() == "" {
+		return nil, ErrLastMessageNotUser
+	}
+
+	last.ReplaceText(newText)
+	c.updatedAt = time.Now().UTC()
+
+	c.addEvent(events.NewMessageEditedEvent(c.id, last.ID()))
+	return last, nil
+}
+
 // AddAssistantMessage adds an assistant message
 func (c *Conversation) AddAssistantMessage(content []entities.ContentBlock) (*entities.Message, error) {
 	msg, err := entities.NewMessage(vo.RoleAssistant, content)
@@ -215,47 +276,75 @@ func (c *Conversation) IsActive() bool {
 	return c.status == ConversationStatusActive
 }
 
-// Pause pauses the conversation
-func (c *Conversation) Pause() {
+// Pause pauses an active conversation
+func (c *Conversation) Pause() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.status == ConversationStatusActive {
-		c.status = ConversationStatusPaused
-		c.updatedAt = time.Now().UTC()
-	}
+	return c.transitionLocked("pause")
 }
 
-// Resume resumes the conversation
-func (c *Conversation) Resume() {
+// Resume resumes a paused conversation
+func (c *Conversation) Resume() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.status == ConversationStatusPaused {
-		c.status = ConversationStatusActive
-		c.updatedAt = time.Now().UTC()
-	}
+	return c.transitionLocked("resume")
 }
 
-// Close closes the conversation
-func (c *Conversation) Close() {
+// Close closes an active or paused conversation; closing a closed conversation is a no-op
+func (c *Conversation) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.status != ConversationStatusClosed {
-		c.status = ConversationStatusClosed
-		now := time.Now().UTC()
-		c.closedAt = &now
-		c.updatedAt = now
-		c.addEvent(events.NewConversationClosedEvent(c.id))
+	if c.status == ConversationStatusClosed {
+		return nil
 	}
+	if err := c.transitionLocked("close"); err != nil {
+		return err
+	}
+	closedAt := c.updatedAt
+	c.closedAt = &closedAt
+	c.addEvent(events.NewConversationClosedEvent(c.id))
+	return nil
 }
 
-// Archive archives the conversation
-func (c *Conversation) Archive() {
+// MarkDeleted drops the conversation's messages and records its deletion. The caller
+// removes it from the session and the repository.
+func (c *Conversation) MarkDeleted() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.status == ConversationStatusClosed {
-		c.status = ConversationStatusArchived
-		c.updatedAt = time.Now().UTC()
+	c.addEvent(events.NewConversationDeletedEvent(c.id, c.sessionID, len(c.messages)))
+	c.messages = nil
+	c.updatedAt = time.Now().UTC()
+}
+
+// Archive archives a closed conversation
+func (c *Conversation) Archive() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transitionLocked("archive")
+}
+
+// Reopen makes an archived conversation active again
+func (c *Conversation) Reopen() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.transitionLocked("reopen"); err != nil {
+		return err
+	}
+	c.closedAt = nil
+	return nil
+}
+
+// transitionLocked applies action if the state machine allows it from the current status
+func (c *Conversation) transitionLocked(action string) error {
+	transition := conversationTransitions[action]
+	for _, from := range transition.from {
+		if c.status == from {
+			c.status = transition.to
+			c.updatedAt = time.Now().UTC()
+			return nil
+		}
 	}
+	return fmt.Errorf("%w: cannot %s a %s conversation", ErrInvalidTransition, action, c.status)
 }
 
 // MaxTokens returns the max tokens setting
@@ -290,6 +379,15 @@ func (c *Conversation) SetTemperature(temperature float64) {
 	c.updatedAt = time.Now().UTC()
 }
 
+// SetTemperatureChecked sets the temperature, rejecting out-of-range values
+func (c *Conversation) SetTemperatureChecked(temperature float64) error {
+	if temperature < 0 || temperature > 2 {
+		return ErrInvalidTemperature
+	}
+	c.SetTemperature(temperature)
+	return nil
+}
+
 // TopP returns the top_p setting
 func (c *Conversation) TopP() float64 {
 	return c.topP
@@ -309,6 +407,15 @@ func (c *Conversation) SetTopP(topP float64) {
 	c.updatedAt = time.Now().UTC()
 }
 
+// SetTopPChecked sets the top_p, rejecting out-of-range values
+func (c *Conversation) SetTopPChecked(topP float64) error {
+	if topP < 0 || topP > 1 {
+		return ErrInvalidTopP
+	}
+	c.SetTopP(topP)
+	return nil
+}
+
 // TopK returns the top_k setting
 func (c *Conversation) TopK() int {
 	return c.topK
@@ -325,6 +432,15 @@ func (c *Conversation) SetTopK(topK int) {
 	c.updatedAt = time.Now().UTC()
 }
 
+// SetTopKChecked sets the top_k, rejecting negative values
+func (c *Conversation) SetTopKChecked(topK int) error {
+	if topK < 0 {
+		return ErrInvalidTopK
+	}
+	c.SetTopK(topK)
+	return nil
+}
+
 // StopSequences returns the stop sequences
 func (c *Conversation) StopSequences() []string {
 	return c.stopSequences
@@ -338,6 +454,16 @@ func (c *Conversation) SetStopSequences(sequences []string) {
 	c.updatedAt = time.Now().UTC()
 }
 
+// SetStopSequencesChecked sets the stop sequences, rejecting a set that exceeds the
+// limits on count or length or that contains a blank sequence
+func (c *Conversation) SetStopSequencesChecked(sequences []string, limits vo.StopSequenceLimits) error {
+	if err := limits.Validate(sequences); err != nil {
+		return err
+	}
+	c.SetStopSequences(append([]string(nil), sequences...))
+	return nil
+}
+
 // Tools returns the available tools
 func (c *Conversation) Tools() []*entities.Tool {
 	c.mu.RLock()
@@ -378,6 +504,57 @@ func (c *Conversation) GetTool(name vo.ToolName) *entities.Tool {
 	return nil
 }
 
+// ToolUse returns the tool-use settings
+func (c *Conversation) ToolUse() vo.ToolUseSettings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	settings := c.toolUse
+	settings.AllowedTools = append([]string(nil), c.toolUse.AllowedTools...)
+	return settings
+}
+
+// SetToolUse sets the tool-use settings. Every tool they name must already be a conversation tool.
+func (c *Conversation) SetToolUse(settings vo.ToolUseSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, name := range settings.ToolNames() {
+		if !c.hasToolLocked(name) {
+			return fmt.Errorf("%w: %s", ErrToolNotInConversation, name)
+		}
+	}
+
+	settings.AllowedTools = append([]string(nil), settings.AllowedTools...)
+	c.toolUse = settings
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// OfferedTools returns the conversation tools the tool-use settings let Claude use
+func (c *Conversation) OfferedTools() []*entities.Tool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	tools := make([]*entities.Tool, 0, len(c.tools))
+	for _, tool := range c.tools {
+		if c.toolUse.Allows(tool.Name().String()) {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+func (c *Conversation) hasToolLocked(name string) bool {
+	for _, tool := range c.tools {
+		if tool.Name().String() == name {
+			return true
+		}
+	}
+	return false
+}
+
 // CreatedAt returns the creation timestamp
 func (c *Conversation) CreatedAt() time.Time {
 	return c.createdAt
@@ -393,9 +570,15 @@ func (c *Conversation) ClosedAt() *time.Time {
 	return c.closedAt
 }
 
-// Metadata returns the conversation metadata
+// Metadata returns a copy of the conversation metadata
 func (c *Conversation) Metadata() map[string]interface{} {
-	return c.metadata
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	metadata := make(map[string]interface{}, len(c.metadata))
+	for k, v := range c.metadata {
+		metadata[k] = v
+	}
+	return metadata
 }
 
 // SetMetadata sets a metadata value
@@ -445,7 +628,25 @@ func (c *Conversation) hasUserMessages() bool {
 	return false
 }
 
-// GetMessagesForAPI returns messages formatted for the Claude API
+// apiResultContent formats the text and image blocks of a tool result for the Claude API
+func apiResultContent(blocks []entities.ContentBlock) []map[string]interface{} {
+	content := make([]map[string]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		switch block.Type {
+		case vo.ContentTypeText:
+			content = append(content, map[string]interface{}{"type": "text", "text": block.Text})
+		case vo.ContentTypeImage:
+			if block.Source != nil {
+				source := *block.Source
+				content = append(content, map[string]interface{}{"type": "image", "source": &source})
+			}
+		}
+	}
+	return content
+}
+
+// GetMessagesForAPI returns messages formatted for the Claude API. Nothing in the
+// result is shared with the conversation.
 func (c *Conversation) GetMessagesForAPI() []map[string]interface{} {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -463,10 +664,13 @@ func (c *Conversation) GetMessagesForAPI() []map[string]interface{} {
 			case vo.ContentTypeToolUse:
 				contentBlock["id"] = block.ID
 				contentBlock["name"] = block.Name
-				contentBlock["input"] = block.Input
+				contentBlock["input"] = block.Clone().Input
 			case vo.ContentTypeToolResult:
 				contentBlock["tool_use_id"] = block.ToolUseID
 				contentBlock["content"] = block.Content
+				if len(block.ResultContent) > 0 {
+					contentBlock["content"] = apiResultContent(block.ResultContent)
+				}
 				if block.IsError {
 					contentBlock["is_error"] = block.IsError
 				}
@@ -2,6 +2,8 @@
 package aggregates
 
 import (
+	"errors"
+	"strings"
 	"sync"
 	"testing"
 
@@ -179,19 +181,93 @@ func TestConversation_Archive(t *testing.T) {
 	conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
 
 	// Can't archive an active conversation
-	conv.Archive()
+	if err := conv.Archive(); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition, got %v", err)
+	}
 	if conv.Status() == ConversationStatusArchived {
 		t.Error("Should not be able to archive active conversation")
 	}
 
 	// Close first, then archive
 	conv.Close()
-	conv.Archive()
+	if err := conv.Archive(); err != nil {
+		t.Fatalf("Archive() failed: %v", err)
+	}
 	if conv.Status() != ConversationStatusArchived {
 		t.Errorf("Expected status %s, got %s", ConversationStatusArchived, conv.Status())
 	}
 }
 
+func TestConversation_Reopen(t *testing.T) {
+	conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
+	conv.Close()
+	conv.Archive()
+
+	if err := conv.Reopen(); err != nil {
+		t.Fatalf("Reopen() failed: %v", err)
+	}
+	if !conv.IsActive() {
+		t.Errorf("Expected status %s, got %s", ConversationStatusActive, conv.Status())
+	}
+	if conv.ClosedAt() != nil {
+		t.Error("ClosedAt should be cleared after reopening")
+	}
+	if _, err := conv.AddUserMessage("Hello again"); err != nil {
+		t.Errorf("Expected reopened conversation to accept messages, got %v", err)
+	}
+}
+
+func TestConversation_StatusTransitions(t *testing.T) {
+	statuses := []ConversationStatus{
+		ConversationStatusActive,
+		ConversationStatusPaused,
+		ConversationStatusClosed,
+		ConversationStatusArchived,
+	}
+	actions := map[string]func(*Conversation) error{
+		"pause":   (*Conversation).Pause,
+		"resume":  (*Conversation).Resume,
+		"close":   (*Conversation).Close,
+		"archive": (*Conversation).Archive,
+		"reopen":  (*Conversation).Reopen,
+	}
+
+	// Every legal transition; closing a closed conversation is an idempotent no-op
+	allowed := map[ConversationStatus]map[string]ConversationStatus{
+		ConversationStatusActive:   {"pause": ConversationStatusPaused, "close": ConversationStatusClosed},
+		ConversationStatusPaused:   {"resume": ConversationStatusActive, "close": ConversationStatusClosed},
+		ConversationStatusClosed:   {"archive": ConversationStatusArchived, "close": ConversationStatusClosed},
+		ConversationStatusArchived: {"reopen": ConversationStatusActive},
+	}
+
+	for _, from := range statuses {
+		for action, apply := range actions {
+			t.Run(string(from)+"/"+action, func(t *testing.T) {
+				conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
+				conv.status = from
+
+				err := apply(conv)
+				want, ok := allowed[from][action]
+				if !ok {
+					if !errors.Is(err, ErrInvalidTransition) {
+						t.Fatalf("expected ErrInvalidTransition, got %v", err)
+					}
+					if conv.Status() != from {
+						t.Errorf("status changed to %s on an illegal transition", conv.Status())
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if conv.Status() != want {
+					t.Errorf("status = %s, want %s", conv.Status(), want)
+				}
+			})
+		}
+	}
+}
+
 func TestConversation_MaxTokens(t *testing.T) {
 	conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
 
@@ -276,6 +352,56 @@ func TestConversation_TopK(t *testing.T) {
 	}
 }
 
+func TestConversation_CheckedSamplingSetters(t *testing.T) {
+	conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
+
+	tests := []struct {
+		name    string
+		set     func() error
+		wantErr error
+	}{
+		{"temperature in range", func() error { return conv.SetTemperatureChecked(0.7) }, nil},
+		{"temperature upper bound", func() error { return conv.SetTemperatureChecked(2.0) }, nil},
+		{"temperature negative", func() error { return conv.SetTemperatureChecked(-0.1) }, ErrInvalidTemperature},
+		{"temperature too high", func() error { return conv.SetTemperatureChecked(2.5) }, ErrInvalidTemperature},
+		{"top_p in range", func() error { return conv.SetTopPChecked(0.9) }, nil},
+		{"top_p negative", func() error { return conv.SetTopPChecked(-0.5) }, ErrInvalidTopP},
+		{"top_p too high", func() error { return conv.SetTopPChecked(1.5) }, ErrInvalidTopP},
+		{"top_k in range", func() error { return conv.SetTopKChecked(40) }, nil},
+		{"top_k negative", func() error { return conv.SetTopKChecked(-1) }, ErrInvalidTopK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.set(); err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConversation_CheckedSettersKeepValueOnError(t *testing.T) {
+	conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
+
+	conv.SetTemperature(0.5)
+	conv.SetTopP(0.8)
+	conv.SetTopK(20)
+
+	_ = conv.SetTemperatureChecked(3.0)
+	_ = conv.SetTopPChecked(2.0)
+	_ = conv.SetTopKChecked(-5)
+
+	if conv.Temperature() != 0.5 {
+		t.Errorf("Expected temperature 0.5 to be kept, got %f", conv.Temperature())
+	}
+	if conv.TopP() != 0.8 {
+		t.Errorf("Expected top_p 0.8 to be kept, got %f", conv.TopP())
+	}
+	if conv.TopK() != 20 {
+		t.Errorf("Expected top_k 20 to be kept, got %d", conv.TopK())
+	}
+}
+
 func TestConversation_StopSequences(t *testing.T) {
 	conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
 
@@ -292,6 +418,44 @@ func TestConversation_StopSequences(t *testing.T) {
 	}
 }
 
+func TestConversation_SetStopSequencesChecked(t *testing.T) {
+	limits := vo.DefaultStopSequenceLimits()
+	maxCount, maxLength := limits.MaxCount, limits.MaxLength
+	tooMany := make([]string, maxCount+1)
+	for i := range tooMany {
+		tooMany[i] = "STOP"
+	}
+
+	tests := []struct {
+		name      string
+		sequences []string
+		wantErr   error
+	}{
+		{"valid set", []string{"\n\nHuman:", "END", strings.Repeat("x", maxLength)}, nil},
+		{"too many sequences", tooMany, vo.ErrTooManyStopSequences},
+		{"over-long sequence", []string{"END", strings.Repeat("x", maxLength+1)}, vo.ErrStopSequenceTooLong},
+		{"blank sequence", []string{"END", " \n"}, vo.ErrBlankStopSequence},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
+			conv.SetStopSequences([]string{"KEEP"})
+
+			err := conv.SetStopSequencesChecked(tt.sequences, limits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetStopSequencesChecked() error = %v, want %v", err, tt.wantErr)
+			}
+			want := tt.sequences
+			if tt.wantErr != nil {
+				want = []string{"KEEP"}
+			}
+			if got := conv.StopSequences(); len(got) != len(want) || got[0] != want[0] {
+				t.Errorf("StopSequences() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 func TestConversation_Tools(t *testing.T) {
 	conv := NewConversation(vo.GenerateSessionID(), vo.ModelClaude4Sonnet)
 
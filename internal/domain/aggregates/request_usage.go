@@ -0,0 +1,122 @@
+package aggregates
+
+import (
+	"sort"
+	"sync"
+)
+
+// MaxTrackedMethods bounds the number of methods with individual request counters per session
+const MaxTrackedMethods = 64
+
+// MethodUsageStats holds request counters for a single method
+type MethodUsageStats struct {
+	Method    string  `json:"method"`
+	Requests  int64   `json:"requests"`
+	Errors    int64   `json:"errors"`
+	ErrorRate float64 `json:"errorRate"`
+}
+
+// RequestUsageReport summarizes the requests and token usage of a session
+type RequestUsageReport struct {
+	TotalRequests     int64              `json:"totalRequests"`
+	TotalErrors       int64              `json:"totalErrors"`
+	ErrorRate         float64            `json:"errorRate"`
+	UntrackedRequests int64              `json:"untrackedRequests"`
+	Methods           []MethodUsageStats `json:"methods"`
+	InputTokens       int64              `json:"inputTokens"`
+	OutputTokens      int64              `json:"outputTokens"`
+}
+
+// RequestUsage aggregates per-method request counters and Claude token usage. Once
+// MaxTrackedMethods methods are tracked, requests to other methods only count in the totals.
+type RequestUsage struct {
+	mu                sync.Mutex
+	methods           map[string]*MethodUsageStats
+	totalRequests     int64
+	totalErrors       int64
+	untrackedRequests int64
+	inputTokens       int64
+	outputTokens      int64
+}
+
+// NewRequestUsage creates an empty RequestUsage
+func NewRequestUsage() *RequestUsage {
+	return &RequestUsage{methods: make(map[string]*MethodUsageStats)}
+}
+
+// RecordRequest records an answered request
+func (u *RequestUsage) RecordRequest(method string, failed bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.totalRequests++
+	if failed {
+		u.totalErrors++
+	}
+
+	stats, ok := u.methods[method]
+	if !ok {
+		if len(u.methods) >= MaxTrackedMethods {
+			u.untrackedRequests++
+			return
+		}
+		stats = &MethodUsageStats{Method: method}
+		u.methods[method] = stats
+	}
+	stats.Requests++
+	if failed {
+		stats.Errors++
+	}
+}
+
+// RecordTokens records the tokens a Claude completion consumed
+func (u *RequestUsage) RecordTokens(input, output int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.inputTokens += int64(input)
+	u.outputTokens += int64(output)
+}
+
+// Report returns a snapshot of the counters, busiest methods first
+func (u *RequestUsage) Report() *RequestUsageReport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	report := &RequestUsageReport{
+		TotalRequests:     u.totalRequests,
+		TotalErrors:       u.totalErrors,
+		UntrackedRequests: u.untrackedRequests,
+		Methods:           make([]MethodUsageStats, 0, len(u.methods)),
+		InputTokens:       u.inputTokens,
+		OutputTokens:      u.outputTokens,
+	}
+	if u.totalRequests > 0 {
+		report.ErrorRate = float64(u.totalErrors) / float64(u.totalRequests)
+	}
+	for _, stats := range u.methods {
+		entry := *stats
+		if entry.Requests > 0 {
+			entry.ErrorRate = float64(entry.Errors) / float64(entry.Requests)
+		}
+		report.Methods = append(report.Methods, entry)
+	}
+	sort.Slice(report.Methods, func(i, j int) bool {
+		if report.Methods[i].Requests != report.Methods[j].Requests {
+			return report.Methods[i].Requests > report.Methods[j].Requests
+		}
+		return report.Methods[i].Method < report.Methods[j].Method
+	})
+	return report
+}
+
+// Reset clears all counters
+func (u *RequestUsage) Reset() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.methods = make(map[string]*MethodUsageStats)
+	u.totalRequests = 0
+	u.totalErrors = 0
+	u.untrackedRequests = 0
+	u.inputTokens = 0
+	u.outputTokens = 0
+}
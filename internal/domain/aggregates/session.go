@@ -3,6 +3,7 @@ package aggregates
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -17,6 +18,10 @@ var (
 	ErrSessionClosed          = errors.New("session is closed")
 	ErrSessionNotInitialized  = errors.New("session not initialized")
 	ErrCapabilityNotSupported = errors.New("capability not supported")
+	ErrToolAlreadyRegistered  = errors.New("tool already registered")
+	ErrToolNotRegistered      = errors.New("tool not registered")
+	ErrTemplateNotFound       = errors.New("conversation template not found")
+	ErrTemplateToolNotFound   = errors.New("conversation template references a tool that is not registered")
 )
 
 // SessionState represents the state of an MCP session
@@ -42,8 +47,14 @@ type Session struct {
 	tools           map[string]*entities.Tool
 	resources       map[string]*entities.Resource
 	prompts         map[string]*entities.Prompt
+	templates       map[string]*entities.ConversationTemplate
 	subscriptions   map[string]bool // Resource URI -> subscribed
 	conversations   map[string]*Conversation
+	store           *entities.SessionStore
+	roots           []entities.Root // nil until the client reports its roots
+	scopes          map[string]bool // Granted by the client's credentials
+	toolUsage       *ToolUsage
+	requestUsage    *RequestUsage
 	logLevel        vo.MCPLogLevel
 	createdAt       time.Time
 	updatedAt       time.Time
@@ -112,8 +123,12 @@ func NewSession() *Session {
 		tools:         make(map[string]*entities.Tool),
 		resources:     make(map[string]*entities.Resource),
 		prompts:       make(map[string]*entities.Prompt),
+		templates:     make(map[string]*entities.ConversationTemplate),
 		subscriptions: make(map[string]bool),
 		conversations: make(map[string]*Conversation),
+		store:         entities.NewSessionStore(),
+		toolUsage:     NewToolUsage(),
+		requestUsage:  NewRequestUsage(),
 		logLevel:      vo.LogLevelInfo,
 		createdAt:     now,
 		updatedAt:     now,
@@ -137,6 +152,8 @@ func (s *Session) ProtocolVersion() vo.MCPProtocolVersion {
 
 // State returns the session state
 func (s *Session) State() SessionState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.state
 }
 
@@ -155,6 +172,17 @@ func (s *Session) Capabilities() *SessionCapabilities {
 	return s.capabilities
 }
 
+// SetExperimentalCapability advertises a non-standard capability under capabilities.experimental
+func (s *Session) SetExperimentalCapability(name string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.capabilities.Experimental == nil {
+		s.capabilities.Experimental = make(map[string]interface{})
+	}
+	s.capabilities.Experimental[name] = value
+	s.updatedAt = time.Now().UTC()
+}
+
 // Initialize initializes the session with client info
 func (s *Session) Initialize(clientInfo *ClientInfo, protocolVersion string) error {
 	s.mu.Lock()
@@ -186,11 +214,15 @@ func (s *Session) MarkReady() {
 
 // IsReady returns whether the session is ready
 func (s *Session) IsReady() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.state == SessionStateReady
 }
 
 // IsClosed returns whether the session is closed
 func (s *Session) IsClosed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.state == SessionStateClosed
 }
 
@@ -205,25 +237,158 @@ func (s *Session) Close() {
 		s.closedAt = &now
 		s.updatedAt = now
 
-		// Close all conversations
+		// Close all conversations; archived ones stay archived
 		for _, conv := range s.conversations {
-			conv.Close()
+			_ = conv.Close()
 		}
+		s.subscriptions = make(map[string]bool)
+		s.store.Clear()
+		s.toolUsage.Reset()
+		s.requestUsage.Reset()
 
 		s.addEvent(events.NewSessionClosedEvent(s.id))
 	}
 }
 
+// SessionResetSummary counts what a session reset cleared
+type SessionResetSummary struct {
+	Tools         int `json:"tools"`
+	Resources     int `json:"resources"`
+	Prompts       int `json:"prompts"`
+	Subscriptions int `json:"subscriptions"`
+	Conversations int `json:"conversations"`
+}
+
+// Reset clears the session's tools, resources, prompts, subscriptions and conversations,
+// closing the conversations first. The ID, state, negotiated capabilities and log level are kept.
+func (s *Session) Reset() (*SessionResetSummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == SessionStateClosed {
+		return nil, ErrSessionClosed
+	}
+
+	summary := &SessionResetSummary{
+		Tools:         len(s.tools),
+		Resources:     len(s.resources),
+		Prompts:       len(s.prompts),
+		Subscriptions: len(s.subscriptions),
+		Conversations: len(s.conversations),
+	}
+
+	// Archived conversations stay archived
+	for _, conv := range s.conversations {
+		_ = conv.Close()
+	}
+	s.tools = make(map[string]*entities.Tool)
+	s.resources = make(map[string]*entities.Resource)
+	s.prompts = make(map[string]*entities.Prompt)
+	s.subscriptions = make(map[string]bool)
+	s.conversations = make(map[string]*Conversation)
+	s.store.Clear()
+	s.toolUsage.Reset()
+	s.requestUsage.Reset()
+	s.updatedAt = time.Now().UTC()
+	return summary, nil
+}
+
+// Store returns the session's scratch store for stateful tools
+func (s *Session) Store() *entities.SessionStore {
+	return s.store
+}
+
+// Roots returns the roots the client last reported, and false if it has not reported any
+func (s *Session) Roots() ([]entities.Root, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.roots == nil {
+		return nil, false
+	}
+	return append([]entities.Root{}, s.roots...), true
+}
+
+// SetRoots replaces the roots reported by the client
+func (s *Session) SetRoots(roots []entities.Root) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roots = append([]entities.Root{}, roots...)
+	s.updatedAt = time.Now().UTC()
+}
+
+// GrantScopes grants the session's client scopes, such as those of the API key it
+// connected with
+func (s *Session) GrantScopes(scopes []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.scopes == nil {
+		s.scopes = make(map[string]bool, len(scopes))
+	}
+	for _, scope := range scopes {
+		s.scopes[scope] = true
+	}
+}
+
+// HasScope reports whether the session's client has been granted scope
+func (s *Session) HasScope(scope string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scopes[scope]
+}
+
+// RecordToolExecution records a tool execution in the session usage counters
+func (s *Session) RecordToolExecution(name string, success bool, duration time.Duration) {
+	s.toolUsage.Record(name, success, duration)
+}
+
+// ToolUsage returns a snapshot of the session's tool usage counters
+func (s *Session) ToolUsage() *ToolUsageReport {
+	return s.toolUsage.Report()
+}
+
+// RecordRequest records an answered request in the session usage counters
+func (s *Session) RecordRequest(method string, failed bool) {
+	s.requestUsage.RecordRequest(method, failed)
+}
+
+// RecordTokenUsage records the tokens a Claude completion in the session consumed
+func (s *Session) RecordTokenUsage(input, output int) {
+	s.requestUsage.RecordTokens(input, output)
+}
+
+// RequestUsage returns a snapshot of the session's request and token counters
+func (s *Session) RequestUsage() *RequestUsageReport {
+	return s.requestUsage.Report()
+}
+
 // Tools
 
-// RegisterTool registers a tool
-func (s *Session) RegisterTool(tool *entities.Tool) {
+// RegisterTool registers a tool, rejecting a tool whose name is already registered
+func (s *Session) RegisterTool(tool *entities.Tool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tools[tool.Name().String()]; exists {
+		return ErrToolAlreadyRegistered
+	}
+
+	s.tools[tool.Name().String()] = tool
+	s.updatedAt = time.Now().UTC()
+	s.addEvent(events.NewToolRegisteredEvent(s.id, tool.Name().String()))
+	return nil
+}
+
+// ReplaceTool registers a tool, overriding any tool with the same name, and
+// reports whether an existing tool was replaced
+func (s *Session) ReplaceTool(tool *entities.Tool) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	_, replaced := s.tools[tool.Name().String()]
 	s.tools[tool.Name().String()] = tool
 	s.updatedAt = time.Now().UTC()
 	s.addEvent(events.NewToolRegisteredEvent(s.id, tool.Name().String()))
+	return replaced
 }
 
 // UnregisterTool unregisters a tool
@@ -293,6 +458,27 @@ func (s *Session) GetResource(uri string) (*entities.Resource, bool) {
 	return resource, ok
 }
 
+// FindResource gets the resource uri names: the one registered under it or, failing
+// that, the longest template it matches
+func (s *Session) FindResource(uri string) (*entities.Resource, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if resource, ok := s.resources[uri]; ok {
+		return resource, true
+	}
+	var match *entities.Resource
+	for _, resource := range s.resources {
+		if !resource.IsTemplate() || !resource.MatchesURI(uri) {
+			continue
+		}
+		if match == nil || len(resource.URITemplate()) > len(match.URITemplate()) {
+			match = resource
+		}
+	}
+	return match, match != nil
+}
+
 // ListResources lists all resources
 func (s *Session) ListResources() []*entities.Resource {
 	s.mu.RLock()
@@ -378,6 +564,38 @@ func (s *Session) ListPrompts() []*entities.Prompt {
 	return prompts
 }
 
+// Conversation templates
+
+// RegisterConversationTemplate registers a conversation template
+func (s *Session) RegisterConversationTemplate(template *entities.ConversationTemplate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.templates[template.Name().String()] = template
+	s.updatedAt = time.Now().UTC()
+}
+
+// GetConversationTemplate gets a conversation template by name
+func (s *Session) GetConversationTemplate(name string) (*entities.ConversationTemplate, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	template, ok := s.templates[name]
+	return template, ok
+}
+
+// ListConversationTemplates lists all conversation templates
+func (s *Session) ListConversationTemplates() []*entities.ConversationTemplate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	templates := make([]*entities.ConversationTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+	return templates
+}
+
 // Conversations
 
 // CreateConversation creates a new conversation
@@ -396,6 +614,82 @@ func (s *Session) CreateConversation(model vo.Model) (*Conversation, error) {
 	return conv, nil
 }
 
+// ToolLookup finds a tool by name outside the session, such as in the tool registry
+type ToolLookup func(name string) (*entities.Tool, bool)
+
+// CreateConversationFromTemplate creates a new conversation with the system prompt,
+// tools, model and settings of the named template. Tools the template references are
+// looked up among the session's tools first and then with lookup, which may be nil;
+// every one of them must be found.
+func (s *Session) CreateConversationFromTemplate(name string, lookup ToolLookup) (*Conversation, error) {
+	s.mu.RLock()
+	if s.state == SessionStateClosed {
+		s.mu.RUnlock()
+		return nil, ErrSessionClosed
+	}
+	template, ok := s.templates[name]
+	if !ok {
+		s.mu.RUnlock()
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	tools := make([]*entities.Tool, len(template.Tools()))
+	for i, toolName := range template.Tools() {
+		tools[i] = s.tools[toolName]
+	}
+	s.mu.RUnlock()
+
+	// Look up the remaining tools without holding the lock, as lookup may query a repository
+	for i, toolName := range template.Tools() {
+		if tools[i] != nil {
+			continue
+		}
+		var tool *entities.Tool
+		if lookup != nil {
+			tool, _ = lookup(toolName)
+		}
+		if tool == nil {
+			return nil, fmt.Errorf("%w: %s uses %s", ErrTemplateToolNotFound, name, toolName)
+		}
+		tools[i] = tool
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == SessionStateClosed {
+		return nil, ErrSessionClosed
+	}
+
+	conv := NewConversation(s.id, template.Model())
+	if err := conv.SetSystemPrompt(template.SystemPrompt()); err != nil {
+		return nil, err
+	}
+	if maxTokens := template.MaxTokens(); maxTokens > 0 {
+		conv.SetMaxTokens(maxTokens)
+	}
+	if temperature, ok := template.Temperature(); ok {
+		conv.SetTemperature(temperature)
+	}
+	if topP, ok := template.TopP(); ok {
+		conv.SetTopP(topP)
+	}
+	if topK, ok := template.TopK(); ok {
+		conv.SetTopK(topK)
+	}
+	if sequences := template.StopSequences(); len(sequences) > 0 {
+		conv.SetStopSequences(sequences)
+	}
+	for _, tool := range tools {
+		conv.AddTool(tool)
+	}
+	conv.SetMetadata("template", name)
+
+	s.conversations[conv.ID().String()] = conv
+	s.updatedAt = time.Now().UTC()
+
+	return conv, nil
+}
+
 // GetConversation gets a conversation by ID
 func (s *Session) GetConversation(id vo.ConversationID) (*Conversation, bool) {
 	s.mu.RLock()
@@ -427,7 +721,22 @@ func (s *Session) CloseConversation(id vo.ConversationID) error {
 		return ErrConversationNotFound
 	}
 
-	conv.Close()
+	if err := conv.Close(); err != nil {
+		return err
+	}
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// RemoveConversation drops the session's reference to a conversation being deleted
+func (s *Session) RemoveConversation(id vo.ConversationID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.conversations[id.String()]; !ok {
+		return ErrConversationNotFound
+	}
+	delete(s.conversations, id.String())
 	s.updatedAt = time.Now().UTC()
 	return nil
 }
@@ -163,6 +163,43 @@ func TestSession_Tools(t *testing.T) {
 	}
 }
 
+func TestSession_DuplicateTools(t *testing.T) {
+	session := NewSession()
+
+	toolName, _ := vo.NewToolName("test_tool")
+	original, _ := entities.NewTool(toolName, mustToolDescription(t, "Original"), nil)
+	override, _ := entities.NewTool(toolName, mustToolDescription(t, "Override"), nil)
+
+	if err := session.RegisterTool(original); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	// Duplicate registration is rejected and keeps the original
+	if err := session.RegisterTool(override); err != ErrToolAlreadyRegistered {
+		t.Errorf("RegisterTool() error = %v, want %v", err, ErrToolAlreadyRegistered)
+	}
+	if retrieved, _ := session.GetTool("test_tool"); retrieved != original {
+		t.Error("Duplicate registration should not overwrite the original tool")
+	}
+
+	// Explicit replace overrides it
+	if !session.ReplaceTool(override) {
+		t.Error("ReplaceTool() should report that a tool was replaced")
+	}
+	if retrieved, _ := session.GetTool("test_tool"); retrieved != override {
+		t.Error("ReplaceTool() should overwrite the original tool")
+	}
+}
+
+func mustToolDescription(t *testing.T, description string) vo.ToolDescription {
+	t.Helper()
+	desc, err := vo.NewToolDescription(description)
+	if err != nil {
+		t.Fatalf("NewToolDescription() error = %v", err)
+	}
+	return desc
+}
+
 func TestSession_Resources(t *testing.T) {
 	session := NewSession()
 
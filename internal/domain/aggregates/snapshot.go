@@ -0,0 +1,250 @@
+package aggregates
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/events"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ErrInvalidSnapshot is returned when a snapshot cannot be restored
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// SessionSnapshot is the serializable state of a Session. Tools are referenced by
+// name; resources, prompts and tool usage counters are runtime state and are not kept.
+type SessionSnapshot struct {
+	ID              string                 `json:"id"`
+	ProtocolVersion string                 `json:"protocol_version"`
+	State           SessionState           `json:"state"`
+	ClientInfo      *ClientInfo            `json:"client_info,omitempty"`
+	ServerInfo      *ServerInfo            `json:"server_info,omitempty"`
+	Capabilities    *SessionCapabilities   `json:"capabilities,omitempty"`
+	Tools           []string               `json:"tools,omitempty"`
+	Subscriptions   []string               `json:"subscriptions,omitempty"`
+	Store           map[string]string      `json:"store,omitempty"`
+	LogLevel        vo.MCPLogLevel         `json:"log_level"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	ClosedAt        *time.Time             `json:"closed_at,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Snapshot returns the session's state
+func (s *Session) Snapshot() SessionSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := SessionSnapshot{
+		ID:              s.id.String(),
+		ProtocolVersion: s.protocolVersion.String(),
+		State:           s.state,
+		ClientInfo:      s.clientInfo,
+		ServerInfo:      s.serverInfo,
+		Capabilities:    s.capabilities,
+		Store:           s.store.Values(),
+		LogLevel:        s.logLevel,
+		CreatedAt:       s.createdAt,
+		UpdatedAt:       s.updatedAt,
+		ClosedAt:        s.closedAt,
+		Metadata:        s.metadata,
+	}
+	for name := range s.tools {
+		snapshot.Tools = append(snapshot.Tools, name)
+	}
+	for uri, subscribed := range s.subscriptions {
+		if subscribed {
+			snapshot.Subscriptions = append(snapshot.Subscriptions, uri)
+		}
+	}
+	sort.Strings(snapshot.Tools)
+	sort.Strings(snapshot.Subscriptions)
+	return snapshot
+}
+
+// RestoreSession rebuilds a session from a snapshot, keeping its ID and timestamps.
+// Tool names are resolved against tools, and conversations are attached to the
+// session. The restored session has no pending events.
+func RestoreSession(snapshot SessionSnapshot, tools map[string]*entities.Tool, conversations []*Conversation) (*Session, error) {
+	id, err := vo.NewSessionID(snapshot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: session: %v", ErrInvalidSnapshot, err)
+	}
+	switch snapshot.State {
+	case SessionStateCreated, SessionStateInitializing, SessionStateReady, SessionStateClosed:
+	default:
+		return nil, fmt.Errorf("%w: session %s: unknown state %q", ErrInvalidSnapshot, snapshot.ID, snapshot.State)
+	}
+	if !snapshot.LogLevel.IsValid() {
+		return nil, fmt.Errorf("%w: session %s: unknown log level %q", ErrInvalidSnapshot, snapshot.ID, snapshot.LogLevel)
+	}
+
+	session := NewSession()
+	session.id = id
+	session.protocolVersion = vo.NewMCPProtocolVersion(snapshot.ProtocolVersion)
+	session.state = snapshot.State
+	session.clientInfo = snapshot.ClientInfo
+	if snapshot.ServerInfo != nil {
+		session.serverInfo = snapshot.ServerInfo
+	}
+	if snapshot.Capabilities != nil {
+		session.capabilities = snapshot.Capabilities
+	}
+	session.logLevel = snapshot.LogLevel
+	session.createdAt = snapshot.CreatedAt
+	session.updatedAt = snapshot.UpdatedAt
+	session.closedAt = snapshot.ClosedAt
+	if snapshot.Metadata != nil {
+		session.metadata = snapshot.Metadata
+	}
+	session.events = make([]events.DomainEvent, 0)
+
+	for _, name := range snapshot.Tools {
+		tool, ok := tools[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: session %s: unknown tool %s", ErrInvalidSnapshot, snapshot.ID, name)
+		}
+		session.tools[name] = tool
+	}
+	for _, uri := range snapshot.Subscriptions {
+		session.subscriptions[uri] = true
+	}
+	for key, value := range snapshot.Store {
+		if err := session.store.Set(key, value); err != nil {
+			return nil, fmt.Errorf("%w: session %s: store key %s: %v", ErrInvalidSnapshot, snapshot.ID, key, err)
+		}
+	}
+	for _, conv := range conversations {
+		if !conv.SessionID().Equals(id) {
+			return nil, fmt.Errorf("%w: conversation %s does not belong to session %s", ErrInvalidSnapshot, conv.ID(), snapshot.ID)
+		}
+		session.conversations[conv.ID().String()] = conv
+	}
+	return session, nil
+}
+
+// ConversationSnapshot is the serializable state of a Conversation. Tools are
+// referenced by name.
+type ConversationSnapshot struct {
+	ID            string                     `json:"id"`
+	SessionID     string                     `json:"session_id"`
+	Model         vo.Model                   `json:"model"`
+	SystemPrompt  string                     `json:"system_prompt,omitempty"`
+	Messages      []entities.MessageSnapshot `json:"messages"`
+	Status        ConversationStatus         `json:"status"`
+	MaxTokens     int                        `json:"max_tokens"`
+	Temperature   float64                    `json:"temperature"`
+	TopP          float64                    `json:"top_p"`
+	TopK          int                        `json:"top_k"`
+	StopSequences []string                   `json:"stop_sequences,omitempty"`
+	Tools         []string                   `json:"tools,omitempty"`
+	ToolUse       vo.ToolUseSettings         `json:"tool_use"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	ClosedAt      *time.Time                 `json:"closed_at,omitempty"`
+	Metadata      map[string]interface{}     `json:"metadata,omitempty"`
+}
+
+// Snapshot returns the conversation's state
+func (c *Conversation) Snapshot() ConversationSnapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshot := ConversationSnapshot{
+		ID:            c.id.String(),
+		SessionID:     c.sessionID.String(),
+		Model:         c.model,
+		SystemPrompt:  c.systemPrompt.String(),
+		Messages:      make([]entities.MessageSnapshot, len(c.messages)),
+		Status:        c.status,
+		MaxTokens:     c.maxTokens,
+		Temperature:   c.temperature,
+		TopP:          c.topP,
+		TopK:          c.topK,
+		StopSequences: c.stopSequences,
+		ToolUse:       c.toolUse,
+		CreatedAt:     c.createdAt,
+		UpdatedAt:     c.updatedAt,
+		ClosedAt:      c.closedAt,
+		Metadata:      c.metadata,
+	}
+	for i, msg := range c.messages {
+		snapshot.Messages[i] = msg.Snapshot()
+	}
+	for _, tool := range c.tools {
+		snapshot.Tools = append(snapshot.Tools, tool.Name().String())
+	}
+	return snapshot
+}
+
+// RestoreConversation rebuilds a conversation from a snapshot, keeping its ID, message
+// IDs and timestamps. Tool names are resolved against tools. The restored conversation
+// has no pending events.
+func RestoreConversation(snapshot ConversationSnapshot, tools map[string]*entities.Tool) (*Conversation, error) {
+	id, err := vo.NewConversationID(snapshot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: conversation: %v", ErrInvalidSnapshot, err)
+	}
+	sessionID, err := vo.NewSessionID(snapshot.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: conversation %s: %v", ErrInvalidSnapshot, snapshot.ID, err)
+	}
+	if _, ok := conversationStatuses[snapshot.Status]; !ok {
+		return nil, fmt.Errorf("%w: conversation %s: unknown status %q", ErrInvalidSnapshot, snapshot.ID, snapshot.Status)
+	}
+	if len(snapshot.Messages) > MaxMessages {
+		return nil, fmt.Errorf("%w: conversation %s: %v", ErrInvalidSnapshot, snapshot.ID, ErrMaxMessagesExceeded)
+	}
+	if err := snapshot.ToolUse.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: conversation %s: %v", ErrInvalidSnapshot, snapshot.ID, err)
+	}
+	systemPrompt, err := vo.NewSystemPrompt(snapshot.SystemPrompt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: conversation %s: %v", ErrInvalidSnapshot, snapshot.ID, err)
+	}
+
+	conv := NewConversation(sessionID, snapshot.Model)
+	conv.id = id
+	conv.systemPrompt = systemPrompt
+	conv.status = snapshot.Status
+	conv.maxTokens = snapshot.MaxTokens
+	conv.temperature = snapshot.Temperature
+	conv.topP = snapshot.TopP
+	conv.topK = snapshot.TopK
+	conv.stopSequences = snapshot.StopSequences
+	conv.toolUse = snapshot.ToolUse
+	conv.createdAt = snapshot.CreatedAt
+	conv.updatedAt = snapshot.UpdatedAt
+	conv.closedAt = snapshot.ClosedAt
+	if snapshot.Metadata != nil {
+		conv.metadata = snapshot.Metadata
+	}
+	conv.events = make([]events.DomainEvent, 0)
+
+	for _, ms := range snapshot.Messages {
+		msg, err := entities.RestoreMessage(ms)
+		if err != nil {
+			return nil, fmt.Errorf("%w: conversation %s: %v", ErrInvalidSnapshot, snapshot.ID, err)
+		}
+		conv.messages = append(conv.messages, msg)
+	}
+	for _, name := range snapshot.Tools {
+		tool, ok := tools[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: conversation %s: unknown tool %s", ErrInvalidSnapshot, snapshot.ID, name)
+		}
+		conv.tools = append(conv.tools, tool)
+	}
+	return conv, nil
+}
+
+// conversationStatuses is the set of valid conversation statuses
+var conversationStatuses = map[ConversationStatus]struct{}{
+	ConversationStatusActive:   {},
+	ConversationStatusPaused:   {},
+	ConversationStatusClosed:   {},
+	ConversationStatusArchived: {},
+}
@@ -0,0 +1,121 @@
+package aggregates
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MaxTrackedTools bounds the number of tools with individual usage counters per session
+const MaxTrackedTools = 32
+
+// ToolUsageStats holds execution counters for a single tool
+type ToolUsageStats struct {
+	Name            string    `json:"name"`
+	Calls           int64     `json:"calls"`
+	Failures        int64     `json:"failures"`
+	FailureRate     float64   `json:"failureRate"`
+	TotalDurationMs int64     `json:"totalDurationMs"`
+	LastCalledAt    time.Time `json:"lastCalledAt"`
+}
+
+// ToolUsageReport summarizes tool usage for a session
+type ToolUsageReport struct {
+	TotalCalls     int64            `json:"totalCalls"`
+	TotalFailures  int64            `json:"totalFailures"`
+	UntrackedCalls int64            `json:"untrackedCalls"`
+	Tools          []ToolUsageStats `json:"tools"`
+}
+
+// ToolUsage aggregates per-tool execution counters, keeping only the most used tools
+type ToolUsage struct {
+	mu             sync.Mutex
+	tools          map[string]*ToolUsageStats
+	totalCalls     int64
+	totalFailures  int64
+	untrackedCalls int64
+}
+
+// NewToolUsage creates an empty ToolUsage
+func NewToolUsage() *ToolUsage {
+	return &ToolUsage{tools: make(map[string]*ToolUsageStats)}
+}
+
+// Record records a tool execution
+func (u *ToolUsage) Record(name string, success bool, duration time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.totalCalls++
+	if !success {
+		u.totalFailures++
+	}
+
+	stats, ok := u.tools[name]
+	if !ok {
+		// Evict the least used tool to make room; its calls remain in the totals
+		if len(u.tools) >= MaxTrackedTools {
+			evicted := u.leastUsedLocked()
+			u.untrackedCalls += evicted.Calls
+			delete(u.tools, evicted.Name)
+		}
+		stats = &ToolUsageStats{Name: name}
+		u.tools[name] = stats
+	}
+
+	stats.Calls++
+	if !success {
+		stats.Failures++
+	}
+	stats.TotalDurationMs += duration.Milliseconds()
+	stats.LastCalledAt = time.Now().UTC()
+}
+
+// leastUsedLocked returns the tracked tool with the fewest calls, oldest first on ties
+func (u *ToolUsage) leastUsedLocked() *ToolUsageStats {
+	var least *ToolUsageStats
+	for _, stats := range u.tools {
+		if least == nil || stats.Calls < least.Calls ||
+			(stats.Calls == least.Calls && stats.LastCalledAt.Before(least.LastCalledAt)) {
+			least = stats
+		}
+	}
+	return least
+}
+
+// Report returns a snapshot of the usage counters, most used tools first
+func (u *ToolUsage) Report() *ToolUsageReport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	report := &ToolUsageReport{
+		TotalCalls:     u.totalCalls,
+		TotalFailures:  u.totalFailures,
+		UntrackedCalls: u.untrackedCalls,
+		Tools:          make([]ToolUsageStats, 0, len(u.tools)),
+	}
+	for _, stats := range u.tools {
+		entry := *stats
+		if entry.Calls > 0 {
+			entry.FailureRate = float64(entry.Failures) / float64(entry.Calls)
+		}
+		report.Tools = append(report.Tools, entry)
+	}
+	sort.Slice(report.Tools, func(i, j int) bool {
+		if report.Tools[i].Calls != report.Tools[j].Calls {
+			return report.Tools[i].Calls > report.Tools[j].Calls
+		}
+		return report.Tools[i].Name < report.Tools[j].Name
+	})
+	return report
+}
+
+// Reset clears all counters
+func (u *ToolUsage) Reset() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.tools = make(map[string]*ToolUsageStats)
+	u.totalCalls = 0
+	u.totalFailures = 0
+	u.untrackedCalls = 0
+}
@@ -0,0 +1,106 @@
+package aggregates
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSession_ToolUsage(t *testing.T) {
+	session := NewSession()
+
+	session.RecordToolExecution("echo", true, 10*time.Millisecond)
+	session.RecordToolExecution("echo", true, 20*time.Millisecond)
+	session.RecordToolExecution("read_file", false, 5*time.Millisecond)
+	session.RecordToolExecution("read_file", true, 5*time.Millisecond)
+	session.RecordToolExecution("echo", false, 0)
+
+	report := session.ToolUsage()
+	if report.TotalCalls != 5 || report.TotalFailures != 2 {
+		t.Errorf("totals = %d calls, %d failures, want 5 and 2", report.TotalCalls, report.TotalFailures)
+	}
+	if len(report.Tools) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(report.Tools))
+	}
+
+	echo := report.Tools[0]
+	if echo.Name != "echo" || echo.Calls != 3 || echo.Failures != 1 || echo.TotalDurationMs != 30 {
+		t.Errorf("unexpected echo stats: %+v", echo)
+	}
+	if readFile := report.Tools[1]; readFile.Name != "read_file" || readFile.FailureRate != 0.5 {
+		t.Errorf("unexpected read_file stats: %+v", readFile)
+	}
+
+	session.Close()
+	report = session.ToolUsage()
+	if report.TotalCalls != 0 || len(report.Tools) != 0 {
+		t.Errorf("usage should be reset on close, got %+v", report)
+	}
+}
+
+func TestToolUsage_Bounded(t *testing.T) {
+	usage := NewToolUsage()
+
+	// A heavily used tool survives a flood of one-off tools
+	for i := 0; i < 5; i++ {
+		usage.Record("hot", true, 0)
+	}
+	for i := 0; i < MaxTrackedTools*2; i++ {
+		usage.Record(fmt.Sprintf("tool_%d", i), true, 0)
+	}
+
+	report := usage.Report()
+	if len(report.Tools) != MaxTrackedTools {
+		t.Errorf("tracked tools = %d, want %d", len(report.Tools), MaxTrackedTools)
+	}
+	if report.Tools[0].Name != "hot" || report.Tools[0].Calls != 5 {
+		t.Errorf("most used tool should be kept first, got %+v", report.Tools[0])
+	}
+	if want := int64(5 + MaxTrackedTools*2); report.TotalCalls != want {
+		t.Errorf("total calls = %d, want %d", report.TotalCalls, want)
+	}
+	if want := int64(MaxTrackedTools + 1); report.UntrackedCalls != want {
+		t.Errorf("untracked calls = %d, want %d", report.UntrackedCalls, want)
+	}
+}
+
+func TestSession_RequestUsage(t *testing.T) {
+	session := NewSession()
+
+	session.RecordRequest("tools/call", false)
+	session.RecordRequest("tools/call", true)
+	session.RecordRequest("tools/list", false)
+	session.RecordTokenUsage(100, 40)
+	session.RecordTokenUsage(10, 5)
+
+	report := session.RequestUsage()
+	if report.TotalRequests != 3 || report.TotalErrors != 1 {
+		t.Errorf("totals = %d requests, %d errors, want 3 and 1", report.TotalRequests, report.TotalErrors)
+	}
+	if len(report.Methods) != 2 || report.Methods[0].Method != "tools/call" || report.Methods[0].ErrorRate != 0.5 {
+		t.Errorf("unexpected methods: %+v", report.Methods)
+	}
+	if report.InputTokens != 110 || report.OutputTokens != 45 {
+		t.Errorf("tokens = %d in, %d out, want 110 and 45", report.InputTokens, report.OutputTokens)
+	}
+
+	session.Close()
+	if report := session.RequestUsage(); report.TotalRequests != 0 || report.InputTokens != 0 || len(report.Methods) != 0 {
+		t.Errorf("usage should be reset on close, got %+v", report)
+	}
+}
+
+func TestRequestUsage_BoundsTrackedMethods(t *testing.T) {
+	usage := NewRequestUsage()
+	for i := 0; i < MaxTrackedMethods+10; i++ {
+		usage.RecordRequest(fmt.Sprintf("method/%d", i), false)
+	}
+
+	report := usage.Report()
+	if len(report.Methods) != MaxTrackedMethods {
+		t.Errorf("tracked %d methods, want %d", len(report.Methods), MaxTrackedMethods)
+	}
+	if report.TotalRequests != MaxTrackedMethods+10 || report.UntrackedRequests != 10 {
+		t.Errorf("totals = %d requests, %d untracked", report.TotalRequests, report.UntrackedRequests)
+	}
+}
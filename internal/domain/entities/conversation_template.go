@@ -0,0 +1,196 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Conversation template errors
+var (
+	ErrTemplateInvalidTemperature = errors.New("template temperature must be between 0 and 2")
+	ErrTemplateInvalidTopP        = errors.New("template top_p must be between 0 and 1")
+	ErrTemplateInvalidTopK        = errors.New("template top_k must not be negative")
+	ErrTemplateInvalidMaxTokens   = errors.New("template max_tokens must not be negative")
+)
+
+// ConversationTemplate is a reusable conversation setup: a system prompt, the tools
+// preloaded into the conversation, a model and sampling defaults. Settings left unset
+// keep the conversation defaults.
+type ConversationTemplate struct {
+	name          vo.ToolName // Template names follow the same pattern as tool and prompt names
+	description   string
+	systemPrompt  vo.SystemPrompt
+	tools         []string
+	model         vo.Model
+	maxTokens     int
+	temperature   *float64
+	topP          *float64
+	topK          *int
+	stopSequences []string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewConversationTemplate creates a new ConversationTemplate for model
+func NewConversationTemplate(name vo.ToolName, model vo.Model) (*ConversationTemplate, error) {
+	if !model.IsValid() {
+		return nil, vo.ErrInvalidModel
+	}
+	now := time.Now().UTC()
+	return &ConversationTemplate{
+		name:      name,
+		model:     model,
+		tools:     make([]string, 0),
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// Name returns the template name
+func (t *ConversationTemplate) Name() vo.ToolName {
+	return t.name
+}
+
+// Description returns the template description
+func (t *ConversationTemplate) Description() string {
+	return t.description
+}
+
+// SetDescription sets the template description
+func (t *ConversationTemplate) SetDescription(description string) {
+	t.description = description
+	t.updatedAt = time.Now().UTC()
+}
+
+// SystemPrompt returns the system prompt
+func (t *ConversationTemplate) SystemPrompt() vo.SystemPrompt {
+	return t.systemPrompt
+}
+
+// SetSystemPrompt sets the system prompt
+func (t *ConversationTemplate) SetSystemPrompt(prompt vo.SystemPrompt) {
+	t.systemPrompt = prompt
+	t.updatedAt = time.Now().UTC()
+}
+
+// Model returns the model
+func (t *ConversationTemplate) Model() vo.Model {
+	return t.model
+}
+
+// Tools returns the names of the tools preloaded into conversations
+func (t *ConversationTemplate) Tools() []string {
+	tools := make([]string, len(t.tools))
+	copy(tools, t.tools)
+	return tools
+}
+
+// AddTool adds a tool, by name, to preload into conversations
+func (t *ConversationTemplate) AddTool(name string) {
+	for _, existing := range t.tools {
+		if existing == name {
+			return
+		}
+	}
+	t.tools = append(t.tools, name)
+	t.updatedAt = time.Now().UTC()
+}
+
+// MaxTokens returns the max tokens setting, or 0 to keep the conversation default
+func (t *ConversationTemplate) MaxTokens() int {
+	return t.maxTokens
+}
+
+// SetMaxTokens sets the max tokens; 0 keeps the conversation default
+func (t *ConversationTemplate) SetMaxTokens(maxTokens int) error {
+	if maxTokens < 0 {
+		return ErrTemplateInvalidMaxTokens
+	}
+	t.maxTokens = maxTokens
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Temperature returns the temperature setting and whether it is set
+func (t *ConversationTemplate) Temperature() (float64, bool) {
+	if t.temperature == nil {
+		return 0, false
+	}
+	return *t.temperature, true
+}
+
+// SetTemperature sets the temperature
+func (t *ConversationTemplate) SetTemperature(temperature float64) error {
+	if temperature < 0 || temperature > 2 {
+		return ErrTemplateInvalidTemperature
+	}
+	t.temperature = &temperature
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// TopP returns the top_p setting and whether it is set
+func (t *ConversationTemplate) TopP() (float64, bool) {
+	if t.topP == nil {
+		return 0, false
+	}
+	return *t.topP, true
+}
+
+// SetTopP sets the top_p
+func (t *ConversationTemplate) SetTopP(topP float64) error {
+	if topP < 0 || topP > 1 {
+		return ErrTemplateInvalidTopP
+	}
+	t.topP = &topP
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// TopK returns the top_k setting and whether it is set
+func (t *ConversationTemplate) TopK() (int, bool) {
+	if t.topK == nil {
+		return 0, false
+	}
+	return *t.topK, true
+}
+
+// SetTopK sets the top_k
+func (t *ConversationTemplate) SetTopK(topK int) error {
+	if topK < 0 {
+		return ErrTemplateInvalidTopK
+	}
+	t.topK = &topK
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// StopSequences returns the stop sequences
+func (t *ConversationTemplate) StopSequences() []string {
+	sequences := make([]string, len(t.stopSequences))
+	copy(sequences, t.stopSequences)
+	return sequences
+}
+
+// SetStopSequences sets the stop sequences, rejecting blank ones. The configured
+// limits on their count and length apply when a conversation sends them.
+func (t *ConversationTemplate) SetStopSequences(sequences []string) error {
+	if err := (vo.StopSequenceLimits{}).Validate(sequences); err != nil {
+		return err
+	}
+	t.stopSequences = append([]string(nil), sequences...)
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// CreatedAt returns the creation timestamp
+func (t *ConversationTemplate) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// UpdatedAt returns the last update timestamp
+func (t *ConversationTemplate) UpdatedAt() time.Time {
+	return t.updatedAt
+}
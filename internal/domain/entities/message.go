@@ -27,6 +27,10 @@ type ContentBlock struct {
 	Content   string                 `json:"content,omitempty"`     // For tool_result
 	IsError   bool                   `json:"is_error,omitempty"`    // For tool_result
 	Source    *ImageSource           `json:"source,omitempty"`      // For image
+	URI       string                 `json:"uri,omitempty"`         // For resource
+	// For tool_result: the text and image blocks of a result that is more than a
+	// single text item, in order. Content still holds the result's text.
+	ResultContent []ContentBlock `json:"result_content,omitempty"`
 }
 
 // ImageSource represents an image source
@@ -63,6 +67,15 @@ func NewTextMessage(role vo.Role, text string) (*Message, error) {
 	return NewMessage(role, content)
 }
 
+// NewResourceReferenceBlock creates a block referencing the resource at uri. History keeps
+// the reference; the content is read and inlined each time the message is sent.
+func NewResourceReferenceBlock(uri string) ContentBlock {
+	return ContentBlock{
+		Type: vo.ContentTypeResource,
+		URI:  uri,
+	}
+}
+
 // ID returns the message ID
 func (m *Message) ID() vo.MessageID {
 	return m.id
@@ -136,6 +149,73 @@ func (m *Message) AddContent(block ContentBlock) {
 	m.content = append(m.content, block)
 }
 
+// ReplaceText replaces the text blocks of the message with a single text block,
+// keeping the other blocks in place after it
+func (m *Message) ReplaceText(text string) {
+	content := []ContentBlock{{Type: vo.ContentTypeText, Text: text}}
+	for _, block := range m.content {
+		if block.Type != vo.ContentTypeText {
+			content = append(content, block)
+		}
+	}
+	m.content = content
+}
+
+// Clone returns a deep copy of the message, so it can be read while the original changes
+func (m *Message) Clone() *Message {
+	clone := *m
+	clone.content = CloneContentBlocks(m.content)
+	clone.metadata = make(map[string]interface{}, len(m.metadata))
+	for key, value := range m.metadata {
+		clone.metadata[key] = value
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the block
+func (b ContentBlock) Clone() ContentBlock {
+	if b.Input != nil {
+		b.Input, _ = cloneValue(b.Input).(map[string]interface{})
+	}
+	if b.Source != nil {
+		source := *b.Source
+		b.Source = &source
+	}
+	b.ResultContent = CloneContentBlocks(b.ResultContent)
+	return b
+}
+
+// CloneContentBlocks returns a deep copy of blocks
+func CloneContentBlocks(blocks []ContentBlock) []ContentBlock {
+	if blocks == nil {
+		return nil
+	}
+	clone := make([]ContentBlock, len(blocks))
+	for i, block := range blocks {
+		clone[i] = block.Clone()
+	}
+	return clone
+}
+
+// cloneValue deep copies the maps and slices of a JSON-like value
+func cloneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			clone[key] = cloneValue(item)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, item := range v {
+			clone[i] = cloneValue(item)
+		}
+		return clone
+	}
+	return value
+}
+
 // IsUserMessage checks if the message is from a user
 func (m *Message) IsUserMessage() bool {
 	return m.role == vo.RoleUser
@@ -3,17 +3,22 @@ package entities
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
 )
 
+// ErrPromptPreviewUnsupported is returned by Preview for a prompt without a preview generator
+var ErrPromptPreviewUnsupported = errors.New("prompt does not support preview")
+
 // Prompt represents an MCP prompt entity
 type Prompt struct {
 	name        vo.ToolName // Reusing ToolName validation as prompt names follow same pattern
 	description string
 	arguments   []*PromptArgument
 	generator   PromptGenerator
+	previewer   PromptGenerator
 	createdAt   time.Time
 	updatedAt   time.Time
 	metadata    map[string]interface{}
@@ -31,8 +36,9 @@ type PromptGenerator func(args map[string]string) (*PromptMessages, error)
 
 // PromptMessages represents the generated messages from a prompt
 type PromptMessages struct {
-	Description string          `json:"description,omitempty"`
-	Messages    []PromptMessage `json:"messages"`
+	Description string                 `json:"description,omitempty"`
+	Messages    []PromptMessage        `json:"messages"`
+	Meta        map[string]interface{} `json:"_meta,omitempty"`
 }
 
 // PromptMessage represents a message in prompt output
@@ -133,6 +139,13 @@ func (p *Prompt) SetGenerator(generator PromptGenerator) {
 	p.updatedAt = time.Now().UTC()
 }
 
+// SetPreviewGenerator sets the generator used by Preview. It renders whatever
+// arguments are supplied and must not fail on missing ones.
+func (p *Prompt) SetPreviewGenerator(generator PromptGenerator) {
+	p.previewer = generator
+	p.updatedAt = time.Now().UTC()
+}
+
 // CreatedAt returns the creation timestamp
 func (p *Prompt) CreatedAt() time.Time {
 	return p.createdAt
@@ -174,6 +187,35 @@ func (p *Prompt) Generate(args map[string]string) (*PromptMessages, error) {
 	return p.generator(args)
 }
 
+// Preview renders the prompt with the arguments supplied so far, for clients that
+// build arguments incrementally. Missing arguments are not an error: the preview
+// generator leaves a visible {{name}} marker for each. Prompts without a preview
+// generator return ErrPromptPreviewUnsupported rather than running Generate, which
+// may have side effects. The result is marked as a preview in its _meta along with
+// the declared arguments that were not supplied.
+func (p *Prompt) Preview(args map[string]string) (*PromptMessages, error) {
+	if p.previewer == nil {
+		return nil, ErrPromptPreviewUnsupported
+	}
+	messages, err := p.previewer(args)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make([]string, 0)
+	for _, arg := range p.arguments {
+		if _, ok := args[arg.Name]; !ok {
+			missing = append(missing, arg.Name)
+		}
+	}
+	if messages.Meta == nil {
+		messages.Meta = make(map[string]interface{})
+	}
+	messages.Meta["preview"] = true
+	messages.Meta["missingArguments"] = missing
+	return messages, nil
+}
+
 // ValidateArguments validates the provided arguments
 func (p *Prompt) ValidateArguments(args map[string]string) error {
 	for _, required := range p.RequiredArguments() {
@@ -206,15 +248,14 @@ func (p *Prompt) ToMCPPrompt() map[string]interface{} {
 	if len(p.arguments) > 0 {
 		args := make([]map[string]interface{}, len(p.arguments))
 		for i, arg := range p.arguments {
+			// required is always set so clients can tell optional arguments apart
 			argMap := map[string]interface{}{
-				"name": arg.Name,
+				"name":     arg.Name,
+				"required": arg.Required,
 			}
 			if arg.Description != "" {
 				argMap["description"] = arg.Description
 			}
-			if arg.Required {
-				argMap["required"] = arg.Required
-			}
 			args[i] = argMap
 		}
 		result["arguments"] = args
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// RequestMeta holds the _meta object a client attached to a request's params. The
+// fields the server understands are decoded; every field, known or not, is kept in
+// Raw so handlers can pass it through unchanged.
+type RequestMeta struct {
+	ProgressToken interface{}                // Token to report progress against, a string or number
+	TimeoutMs     *float64                   // Client-requested deadline in milliseconds
+	CorrelationID string                     // Client identifier tying the request to its own traces
+	Raw           map[string]json.RawMessage // Every _meta field as sent
+}
+
+// ParseRequestMeta decodes the _meta object of a request's params. It returns nil when
+// the params carry no _meta object; known fields with the wrong type are ignored.
+func ParseRequestMeta(params json.RawMessage) *RequestMeta {
+	if len(params) == 0 {
+		return nil
+	}
+	var envelope struct {
+		Meta map[string]json.RawMessage `json:"_meta"`
+	}
+	if err := json.Unmarshal(params, &envelope); err != nil || envelope.Meta == nil {
+		return nil
+	}
+
+	meta := &RequestMeta{Raw: envelope.Meta}
+	if raw, ok := envelope.Meta["progressToken"]; ok {
+		var token interface{}
+		if json.Unmarshal(raw, &token) == nil {
+			switch token.(type) {
+			case string, float64:
+				meta.ProgressToken = token
+			}
+		}
+	}
+	if raw, ok := envelope.Meta["timeoutMs"]; ok {
+		var timeout float64
+		if json.Unmarshal(raw, &timeout) == nil {
+			meta.TimeoutMs = &timeout
+		}
+	}
+	if raw, ok := envelope.Meta["correlationId"]; ok {
+		_ = json.Unmarshal(raw, &meta.CorrelationID)
+	}
+	return meta
+}
+
+// Get decodes the _meta field key into v, reporting whether the field was present
+// and decoded
+func (m *RequestMeta) Get(key string, v interface{}) bool {
+	if m == nil {
+		return false
+	}
+	raw, ok := m.Raw[key]
+	return ok && json.Unmarshal(raw, v) == nil
+}
+
+// Unknown returns the _meta fields the server does not interpret, for pass-through
+func (m *RequestMeta) Unknown() map[string]json.RawMessage {
+	unknown := make(map[string]json.RawMessage)
+	if m == nil {
+		return unknown
+	}
+	for key, raw := range m.Raw {
+		switch key {
+		case "progressToken", "timeoutMs", "correlationId":
+			continue
+		}
+		unknown[key] = raw
+	}
+	return unknown
+}
+
+type requestMetaKey struct{}
+
+// ContextWithRequestMeta returns a context carrying the _meta of the request being handled
+func ContextWithRequestMeta(ctx context.Context, meta *RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFromContext returns the request _meta carried by ctx, if any
+func RequestMetaFromContext(ctx context.Context) (*RequestMeta, bool) {
+	meta, ok := ctx.Value(requestMetaKey{}).(*RequestMeta)
+	return meta, ok && meta != nil
+}
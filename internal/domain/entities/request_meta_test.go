@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestParseRequestMeta(t *testing.T) {
+	meta := ParseRequestMeta(json.RawMessage(`{"name":"echo","_meta":{"progressToken":7,"timeoutMs":250,"correlationId":"abc-1","traceparent":"00-01","vendor":{"x":1}}}`))
+	if meta == nil {
+		t.Fatal("ParseRequestMeta() = nil, want meta")
+	}
+	if meta.ProgressToken != 7.0 {
+		t.Errorf("ProgressToken = %v, want 7", meta.ProgressToken)
+	}
+	if meta.TimeoutMs == nil || *meta.TimeoutMs != 250 {
+		t.Errorf("TimeoutMs = %v, want 250", meta.TimeoutMs)
+	}
+	if meta.CorrelationID != "abc-1" {
+		t.Errorf("CorrelationID = %q, want abc-1", meta.CorrelationID)
+	}
+
+	unknown := meta.Unknown()
+	if len(unknown) != 2 || string(unknown["traceparent"]) != `"00-01"` || string(unknown["vendor"]) != `{"x":1}` {
+		t.Errorf("Unknown() = %v, want traceparent and vendor unchanged", unknown)
+	}
+	var traceparent string
+	if !meta.Get("traceparent", &traceparent) || traceparent != "00-01" {
+		t.Errorf("Get(traceparent) = %q", traceparent)
+	}
+}
+
+func TestParseRequestMeta_Absent(t *testing.T) {
+	for _, params := range []string{``, `{}`, `{"_meta":null}`, `[1,2]`, `not json`} {
+		if meta := ParseRequestMeta(json.RawMessage(params)); meta != nil {
+			t.Errorf("ParseRequestMeta(%q) = %+v, want nil", params, meta)
+		}
+	}
+}
+
+func TestParseRequestMeta_IgnoresMistypedFields(t *testing.T) {
+	meta := ParseRequestMeta(json.RawMessage(`{"_meta":{"progressToken":{"a":1},"timeoutMs":"soon","correlationId":5}}`))
+	if meta == nil {
+		t.Fatal("ParseRequestMeta() = nil, want meta")
+	}
+	if meta.ProgressToken != nil || meta.TimeoutMs != nil || meta.CorrelationID != "" {
+		t.Errorf("meta = %+v, want mistyped fields ignored", meta)
+	}
+	if len(meta.Raw) != 3 {
+		t.Errorf("Raw = %v, want every field kept", meta.Raw)
+	}
+}
+
+func TestRequestMetaContext(t *testing.T) {
+	if _, ok := RequestMetaFromContext(context.Background()); ok {
+		t.Error("RequestMetaFromContext() on an empty context should report no meta")
+	}
+	meta := &RequestMeta{CorrelationID: "abc"}
+	got, ok := RequestMetaFromContext(ContextWithRequestMeta(context.Background(), meta))
+	if !ok || got != meta {
+		t.Errorf("RequestMetaFromContext() = %v, %v", got, ok)
+	}
+}
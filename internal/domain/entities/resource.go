@@ -3,6 +3,9 @@ package entities
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
@@ -16,6 +19,8 @@ type Resource struct {
 	mimeType    vo.MimeType
 	annotations *ResourceAnnotations
 	reader      ResourceReader
+	filePath    string
+	resolvePath func(uri string) (string, bool)
 	isTemplate  bool
 	uriTemplate string
 	createdAt   time.Time
@@ -23,24 +28,50 @@ type Resource struct {
 	metadata    map[string]interface{}
 }
 
+// Resource annotation errors
+var (
+	ErrInvalidResourceAudience = errors.New("resource audience must be user or assistant")
+	ErrInvalidResourcePriority = errors.New("resource priority must be between 0 and 1")
+)
+
+// Resource audiences
+const (
+	ResourceAudienceUser      = "user"
+	ResourceAudienceAssistant = "assistant"
+)
+
 // ResourceReader is the function signature for reading resource content
 type ResourceReader func(uri string) (*ResourceContent, error)
 
 // ResourceContent represents the content of a resource
 type ResourceContent struct {
-	URI      string `json:"uri"`
-	MimeType string `json:"mimeType,omitempty"`
-	Text     string `json:"text,omitempty"`
-	Blob     string `json:"blob,omitempty"` // Base64 encoded binary data
+	URI         string               `json:"uri"`
+	MimeType    string               `json:"mimeType,omitempty"`
+	Text        string               `json:"text,omitempty"`
+	Blob        string               `json:"blob,omitempty"` // Base64 encoded binary data
+	Annotations *ResourceAnnotations `json:"annotations,omitempty"`
 }
 
-// ResourceAnnotations represents annotations for a resource
+// ResourceAnnotations represents annotations that guide how clients use a resource
 type ResourceAnnotations struct {
-	Audience    []string `json:"audience,omitempty"`    // ["user", "assistant"]
-	Priority    float64  `json:"priority,omitempty"`    // 0.0 to 1.0
+	Audience    []string `json:"audience,omitempty"`    // Who the resource is for: "user", "assistant" or both
+	Priority    *float64 `json:"priority,omitempty"`    // Importance from 0.0 (optional) to 1.0 (required)
 	Description string   `json:"description,omitempty"` // Extended description
 }
 
+// Validate checks the audience roles and the priority range
+func (a *ResourceAnnotations) Validate() error {
+	for _, audience := range a.Audience {
+		if audience != ResourceAudienceUser && audience != ResourceAudienceAssistant {
+			return fmt.Errorf("%w: %q", ErrInvalidResourceAudience, audience)
+		}
+	}
+	if a.Priority != nil && (*a.Priority < 0 || *a.Priority > 1) {
+		return ErrInvalidResourcePriority
+	}
+	return nil
+}
+
 // NewResource creates a new Resource entity
 func NewResource(uri vo.ResourceURI, name string) (*Resource, error) {
 	now := time.Now().UTC()
@@ -111,10 +142,39 @@ func (r *Resource) Annotations() *ResourceAnnotations {
 	return r.annotations
 }
 
-// SetAnnotations sets the resource annotations
-func (r *Resource) SetAnnotations(annotations *ResourceAnnotations) {
+// SetAnnotations sets the resource annotations; nil clears them
+func (r *Resource) SetAnnotations(annotations *ResourceAnnotations) error {
+	if annotations != nil {
+		if err := annotations.Validate(); err != nil {
+			return err
+		}
+	}
 	r.annotations = annotations
 	r.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetAudience sets who the resource is intended for, keeping other annotations
+func (r *Resource) SetAudience(audience ...string) error {
+	annotations := r.annotationsCopy()
+	annotations.Audience = append([]string(nil), audience...)
+	return r.SetAnnotations(annotations)
+}
+
+// SetPriority sets how important the resource is, from 0 to 1, keeping other annotations
+func (r *Resource) SetPriority(priority float64) error {
+	annotations := r.annotationsCopy()
+	annotations.Priority = &priority
+	return r.SetAnnotations(annotations)
+}
+
+// annotationsCopy returns a copy of the annotations to modify, or empty annotations
+func (r *Resource) annotationsCopy() *ResourceAnnotations {
+	if r.annotations == nil {
+		return &ResourceAnnotations{}
+	}
+	annotations := *r.annotations
+	return &annotations
 }
 
 // Reader returns the resource reader
@@ -128,6 +188,52 @@ func (r *Resource) SetReader(reader ResourceReader) {
 	r.updatedAt = time.Now().UTC()
 }
 
+// FilePath returns the file backing the resource, or "" if it is not file-backed
+func (r *Resource) FilePath() string {
+	return r.filePath
+}
+
+// SetFilePath records the file backing the resource, so large reads can be streamed
+// from it instead of going through the reader
+func (r *Resource) SetFilePath(path string) {
+	r.filePath = path
+	r.updatedAt = time.Now().UTC()
+}
+
+// SetFilePathResolver makes a template file-backed: resolve returns the file a URI
+// matching the template names, or false when it names none
+func (r *Resource) SetFilePathResolver(resolve func(uri string) (string, bool)) {
+	r.resolvePath = resolve
+	r.updatedAt = time.Now().UTC()
+}
+
+// FilePathFor returns the file backing the resource when it is read at uri, or "" if
+// that read is not file-backed
+func (r *Resource) FilePathFor(uri string) string {
+	if r.resolvePath != nil {
+		path, _ := r.resolvePath(uri)
+		return path
+	}
+	return r.filePath
+}
+
+// MatchesURI reports whether uri names the resource. A template matches URIs that
+// fill its single {variable} with a non-empty value.
+func (r *Resource) MatchesURI(uri string) bool {
+	if !r.isTemplate {
+		return uri == r.uri.String()
+	}
+	prefix, rest, ok := strings.Cut(r.uriTemplate, "{")
+	if !ok {
+		return uri == r.uriTemplate
+	}
+	_, suffix, ok := strings.Cut(rest, "}")
+	if !ok || strings.Contains(suffix, "{") {
+		return false
+	}
+	return len(uri) > len(prefix)+len(suffix) && strings.HasPrefix(uri, prefix) && strings.HasSuffix(uri, suffix)
+}
+
 // IsTemplate returns whether the resource is a template
 func (r *Resource) IsTemplate() bool {
 	return r.isTemplate
@@ -171,6 +277,15 @@ func (r *Resource) Read() (*ResourceContent, error) {
 	return r.reader(r.uri.String())
 }
 
+// ReadURI reads the resource content for a URI naming the resource, possibly with
+// query parameters the reader interprets
+func (r *Resource) ReadURI(uri string) (*ResourceContent, error) {
+	if r.reader == nil {
+		return r.Read()
+	}
+	return r.reader(uri)
+}
+
 // ToMCPResource converts the resource to MCP format
 func (r *Resource) ToMCPResource() map[string]interface{} {
 	result := map[string]interface{}{
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+)
+
+// Root is a location the client exposes to the server, as listed by roots/list
+type Root struct {
+	URI  string `json:"uri"`
+	Name string `json:"name,omitempty"`
+}
+
+// Path returns the local path of a file:// root, and false for any other URI
+func (r Root) Path() (string, bool) {
+	u, err := url.Parse(r.URI)
+	if err != nil || u.Scheme != "file" || u.Path == "" {
+		return "", false
+	}
+	return filepath.Clean(filepath.FromSlash(u.Path)), true
+}
+
+type rootsKey struct{}
+
+// ContextWithRoots returns a context carrying the roots of the session executing a tool
+func ContextWithRoots(ctx context.Context, roots []Root) context.Context {
+	return context.WithValue(ctx, rootsKey{}, roots)
+}
+
+// RootsFromContext returns the roots carried by ctx, and false if the client has not
+// reported any
+func RootsFromContext(ctx context.Context) ([]Root, bool) {
+	roots, ok := ctx.Value(rootsKey{}).([]Root)
+	return roots, ok
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"sort"
+)
+
+// SchemaParameter is a human-readable description of a single tool parameter
+type SchemaParameter struct {
+	Name        string        `json:"name"`
+	Type        string        `json:"type"`
+	Required    bool          `json:"required"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Description string        `json:"description,omitempty"`
+}
+
+// Parameters flattens the schema's properties into a list of parameters.
+// Nested object properties use dotted names ("options.depth") and are required
+// only when their parent is. Array types are rendered as "array<item type>".
+// Required parameters come first, then parameters are ordered by name.
+func (s *JSONSchema) Parameters() []SchemaParameter {
+	params := []SchemaParameter{}
+	if s != nil {
+		s.appendParameters(&params, "", true)
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		if params[i].Required != params[j].Required {
+			return params[i].Required
+		}
+		return params[i].Name < params[j].Name
+	})
+	return params
+}
+
+func (s *JSONSchema) appendParameters(params *[]SchemaParameter, prefix string, parentRequired bool) {
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	for name, prop := range s.Properties {
+		if prop == nil {
+			continue
+		}
+		fullName := prefix + name
+		isRequired := parentRequired && required[name]
+
+		*params = append(*params, SchemaParameter{
+			Name:        fullName,
+			Type:        prop.typeLabel(),
+			Required:    isRequired,
+			Enum:        prop.Enum,
+			Default:     prop.Default,
+			Description: prop.Description,
+		})
+		if len(prop.Properties) > 0 {
+			prop.appendParameters(params, fullName+".", isRequired)
+		}
+	}
+}
+
+// typeLabel returns the schema type, including the item type for arrays
+func (s *JSONSchema) typeLabel() string {
+	if s.Type == "array" && s.Items != nil && s.Items.Type != "" {
+		return "array<" + s.Items.typeLabel() + ">"
+	}
+	if s.Type == "" {
+		return "any"
+	}
+	return s.Type
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchema_Parameters(t *testing.T) {
+	maxLen := 10
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"path":     {Type: "string", Description: "File path", MaxLength: &maxLen},
+			"encoding": {Type: "string", Description: "File encoding", Enum: []interface{}{"utf-8", "base64"}, Default: "utf-8"},
+			"tags":     {Type: "array", Items: &JSONSchema{Type: "string"}},
+			"options": {
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"depth": {Type: "integer", Description: "Max depth"},
+				},
+				Required: []string{"depth"},
+			},
+		},
+		Required: []string{"path"},
+	}
+
+	want := []SchemaParameter{
+		{Name: "path", Type: "string", Required: true, Description: "File path"},
+		{Name: "encoding", Type: "string", Enum: []interface{}{"utf-8", "base64"}, Default: "utf-8", Description: "File encoding"},
+		{Name: "options", Type: "object"},
+		// Required within an optional parent, so optional overall
+		{Name: "options.depth", Type: "integer", Description: "Max depth"},
+		{Name: "tags", Type: "array<string>"},
+	}
+
+	got := schema.Parameters()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parameters() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestJSONSchema_ParametersRequiredNested(t *testing.T) {
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"options": {
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"depth": {Type: "integer"}, "follow": {}},
+				Required:   []string{"depth"},
+			},
+		},
+		Required: []string{"options"},
+	}
+
+	want := []SchemaParameter{
+		{Name: "options", Type: "object", Required: true},
+		{Name: "options.depth", Type: "integer", Required: true},
+		{Name: "options.follow", Type: "any"},
+	}
+	if got := schema.Parameters(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parameters() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestJSONSchema_ParametersEmpty(t *testing.T) {
+	var schema *JSONSchema
+	if got := schema.Parameters(); len(got) != 0 {
+		t.Errorf("expected no parameters for a nil schema, got %+v", got)
+	}
+	if got := (&JSONSchema{Type: "object"}).Parameters(); len(got) != 0 {
+		t.Errorf("expected no parameters for an empty schema, got %+v", got)
+	}
+}
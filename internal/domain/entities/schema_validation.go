@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+// Schema validation errors
+var (
+	ErrSchemaViolation = errors.New("value does not match schema")
+)
+
+// Validate reports whether value conforms to the schema. It checks the keywords the
+// schema supports: type, required, properties, additionalProperties, items, enum,
+// minimum/maximum, minLength/maxLength and pattern.
+func (s *JSONSchema) Validate(value interface{}) error {
+	if s == nil {
+		return nil
+	}
+	return s.validate("$", value)
+}
+
+func (s *JSONSchema) validate(path string, value interface{}) error {
+	if s.Type != "" && !matchesSchemaType(s.Type, value) {
+		return schemaViolation(path, "expected %s, got %s", s.Type, jsonTypeName(value))
+	}
+
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		return schemaViolation(path, "value %v is not one of %v", value, s.Enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return s.validateObject(path, v)
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			return schemaViolation(path, "length %d is less than %d", length, *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return schemaViolation(path, "length %d is greater than %d", length, *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			re, err := regexp.Compile(s.Pattern)
+			if err != nil {
+				return schemaViolation(path, "invalid pattern %q: %v", s.Pattern, err)
+			}
+			if !re.MatchString(v) {
+				return schemaViolation(path, "%q does not match pattern %q", v, s.Pattern)
+			}
+		}
+	default:
+		if n, ok := toFloat(value); ok {
+			if s.Minimum != nil && n < *s.Minimum {
+				return schemaViolation(path, "%v is less than %v", n, *s.Minimum)
+			}
+			if s.Maximum != nil && n > *s.Maximum {
+				return schemaViolation(path, "%v is greater than %v", n, *s.Maximum)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *JSONSchema) validateObject(path string, obj map[string]interface{}) error {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			return schemaViolation(path, "missing required property %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return schemaViolation(path, "unexpected property %q", name)
+			}
+			continue
+		}
+		if err := prop.validate(path+"."+name, obj[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func schemaViolation(path, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s: %s", ErrSchemaViolation, path, fmt.Sprintf(format, args...))
+}
+
+// matchesSchemaType reports whether value has the given JSON Schema type
+func matchesSchemaType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		n, ok := toFloat(value)
+		return ok && n == math.Trunc(n)
+	}
+	return true
+}
+
+// jsonTypeName returns the JSON type name of a decoded value
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if _, ok := toFloat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toFloat converts Go numeric values, as decoded from JSON or written in Go code, to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if a, ok := toFloat(candidate); ok {
+			if b, ok := toFloat(value); ok && a == b {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJSONSchema_Validate(t *testing.T) {
+	minDepth, maxDepth := 1.0, 5.0
+	minLen := 1
+	noExtra := false
+	schema := &JSONSchema{
+		Type: "object",
+		Properties: map[string]*JSONSchema{
+			"path":     {Type: "string", MinLength: &minLen},
+			"encoding": {Type: "string", Enum: []interface{}{"utf-8", "base64"}},
+			"depth":    {Type: "integer", Minimum: &minDepth, Maximum: &maxDepth},
+			"tags":     {Type: "array", Items: &JSONSchema{Type: "string", Pattern: "^[a-z]+$"}},
+			"options": {
+				Type:       "object",
+				Properties: map[string]*JSONSchema{"follow": {Type: "boolean"}},
+			},
+		},
+		Required:             []string{"path"},
+		AdditionalProperties: &noExtra,
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"minimal", map[string]interface{}{"path": "a.txt"}, false},
+		{"all properties", map[string]interface{}{
+			"path": "a.txt", "encoding": "base64", "depth": 3, "tags": []interface{}{"go"},
+			"options": map[string]interface{}{"follow": true},
+		}, false},
+		{"decoded JSON integer", map[string]interface{}{"path": "a.txt", "depth": float64(2)}, false},
+		{"not an object", "a.txt", true},
+		{"missing required", map[string]interface{}{"encoding": "utf-8"}, true},
+		{"wrong type", map[string]interface{}{"path": 42}, true},
+		{"too short", map[string]interface{}{"path": ""}, true},
+		{"not in enum", map[string]interface{}{"path": "a.txt", "encoding": "latin1"}, true},
+		{"fractional integer", map[string]interface{}{"path": "a.txt", "depth": 1.5}, true},
+		{"above maximum", map[string]interface{}{"path": "a.txt", "depth": 6}, true},
+		{"item fails pattern", map[string]interface{}{"path": "a.txt", "tags": []interface{}{"Go"}}, true},
+		{"nested wrong type", map[string]interface{}{"path": "a.txt", "options": map[string]interface{}{"follow": "yes"}}, true},
+		{"unexpected property", map[string]interface{}{"path": "a.txt", "mode": "r"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrSchemaViolation) {
+				t.Errorf("Validate() error = %v, want ErrSchemaViolation", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Session store limits
+const (
+	MaxSessionStoreKeys      = 256
+	MaxSessionStoreValueSize = 64 * 1024
+)
+
+// Session store errors
+var (
+	ErrSessionStoreKeyEmpty     = errors.New("session store key cannot be empty")
+	ErrSessionStoreFull         = errors.New("session store key limit reached")
+	ErrSessionStoreValueTooBig  = errors.New("session store value exceeds size limit")
+	ErrSessionStoreNotAvailable = errors.New("session store not available")
+)
+
+// SessionStore is a per-session scratch key/value store for stateful tools
+type SessionStore struct {
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+// NewSessionStore creates an empty SessionStore
+func NewSessionStore() *SessionStore {
+	return &SessionStore{values: make(map[string]string)}
+}
+
+// Get returns the value stored under key
+func (s *SessionStore) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// Set stores a value under key, enforcing the key count and value size limits
+func (s *SessionStore) Set(key, value string) error {
+	if key == "" {
+		return ErrSessionStoreKeyEmpty
+	}
+	if len(value) > MaxSessionStoreValueSize {
+		return ErrSessionStoreValueTooBig
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.values[key]; !exists && len(s.values) >= MaxSessionStoreKeys {
+		return ErrSessionStoreFull
+	}
+	s.values[key] = value
+	return nil
+}
+
+// Delete removes the value stored under key
+func (s *SessionStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+}
+
+// Clear removes all values
+func (s *SessionStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = make(map[string]string)
+}
+
+// Values returns a copy of the stored values
+func (s *SessionStore) Values() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := make(map[string]string, len(s.values))
+	for key, value := range s.values {
+		values[key] = value
+	}
+	return values
+}
+
+// Len returns the number of stored keys
+func (s *SessionStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.values)
+}
+
+type sessionStoreKey struct{}
+
+// ContextWithSessionStore returns a context carrying the session store
+func ContextWithSessionStore(ctx context.Context, store *SessionStore) context.Context {
+	return context.WithValue(ctx, sessionStoreKey{}, store)
+}
+
+// SessionStoreFromContext returns the session store carried by ctx, if any
+func SessionStoreFromContext(ctx context.Context) (*SessionStore, bool) {
+	store, ok := ctx.Value(sessionStoreKey{}).(*SessionStore)
+	return store, ok && store != nil
+}
+
+type sessionIDKey struct{}
+
+// ContextWithSessionID returns a context carrying the ID of the session executing a tool
+func ContextWithSessionID(ctx context.Context, id vo.SessionID) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, id)
+}
+
+// SessionIDFromContext returns the session ID carried by ctx, if any
+func SessionIDFromContext(ctx context.Context) (vo.SessionID, bool) {
+	id, ok := ctx.Value(sessionIDKey{}).(vo.SessionID)
+	return id, ok
+}
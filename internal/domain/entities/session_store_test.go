@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSessionStore_Limits(t *testing.T) {
+	store := NewSessionStore()
+
+	if err := store.Set("", "value"); !errors.Is(err, ErrSessionStoreKeyEmpty) {
+		t.Errorf("Set() with empty key error = %v, want %v", err, ErrSessionStoreKeyEmpty)
+	}
+	if err := store.Set("big", strings.Repeat("x", MaxSessionStoreValueSize+1)); !errors.Is(err, ErrSessionStoreValueTooBig) {
+		t.Errorf("Set() with oversized value error = %v, want %v", err, ErrSessionStoreValueTooBig)
+	}
+
+	for i := 0; i < MaxSessionStoreKeys; i++ {
+		if err := store.Set("key"+strconv.Itoa(i), "v"); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+	if err := store.Set("overflow", "v"); !errors.Is(err, ErrSessionStoreFull) {
+		t.Errorf("Set() beyond key limit error = %v, want %v", err, ErrSessionStoreFull)
+	}
+
+	// Overwriting an existing key does not count against the limit
+	if err := store.Set("key0", "updated"); err != nil {
+		t.Errorf("Set() on existing key error = %v", err)
+	}
+	if value, _ := store.Get("key0"); value != "updated" {
+		t.Errorf("Get() = %q, want updated", value)
+	}
+
+	store.Delete("key0")
+	if _, ok := store.Get("key0"); ok {
+		t.Error("Get() should miss after Delete()")
+	}
+	if err := store.Set("overflow", "v"); err != nil {
+		t.Errorf("Set() after Delete() error = %v", err)
+	}
+
+	store.Clear()
+	if store.Len() != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", store.Len())
+	}
+}
+
+func TestSessionStore_Context(t *testing.T) {
+	if _, ok := SessionStoreFromContext(context.Background()); ok {
+		t.Error("plain context should not carry a session store")
+	}
+
+	store := NewSessionStore()
+	got, ok := SessionStoreFromContext(ContextWithSessionStore(context.Background(), store))
+	if !ok || got != store {
+		t.Error("SessionStoreFromContext() should return the attached store")
+	}
+}
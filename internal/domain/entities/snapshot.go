@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// MessageSnapshot is the serializable state of a Message
+type MessageSnapshot struct {
+	ID        string                 `json:"id"`
+	Role      vo.Role                `json:"role"`
+	Content   []ContentBlock         `json:"content"`
+	CreatedAt time.Time              `json:"created_at"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Snapshot returns the message's state
+func (m *Message) Snapshot() MessageSnapshot {
+	clone := m.Clone()
+	return MessageSnapshot{
+		ID:        clone.id.String(),
+		Role:      clone.role,
+		Content:   clone.content,
+		CreatedAt: clone.createdAt,
+		Metadata:  clone.metadata,
+	}
+}
+
+// RestoreMessage rebuilds a message from a snapshot, keeping its ID and timestamp
+func RestoreMessage(snapshot MessageSnapshot) (*Message, error) {
+	id, err := vo.NewMessageID(snapshot.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !snapshot.Role.IsValid() {
+		return nil, fmt.Errorf("message %s: %w", snapshot.ID, vo.ErrInvalidRole)
+	}
+
+	metadata := snapshot.Metadata
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	return &Message{
+		id:        id,
+		role:      snapshot.Role,
+		content:   CloneContentBlocks(snapshot.Content),
+		createdAt: snapshot.CreatedAt,
+		metadata:  metadata,
+	}, nil
+}
+
+// ToolSnapshot is the serializable state of a Tool. Handlers are code rather than
+// state and are not included.
+type ToolSnapshot struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	InputSchema *JSONSchema              `json:"input_schema,omitempty"`
+	Category    string                   `json:"category,omitempty"`
+	Tags        []string                 `json:"tags,omitempty"`
+	Enabled     bool                     `json:"enabled"`
+	RateLimit   *RateLimit               `json:"rate_limit,omitempty"`
+	Annotations *ToolAnnotations         `json:"annotations,omitempty"`
+	Examples    []map[string]interface{} `json:"examples,omitempty"`
+	Cacheable   bool                     `json:"cacheable,omitempty"`
+	Timeout     time.Duration            `json:"timeout"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Metadata    map[string]interface{}   `json:"metadata,omitempty"`
+}
+
+// Snapshot returns the tool's state
+func (t *Tool) Snapshot() ToolSnapshot {
+	return ToolSnapshot{
+		Name:        t.name.String(),
+		Description: t.description.String(),
+		InputSchema: t.inputSchema,
+		Category:    t.category,
+		Tags:        t.tags,
+		Enabled:     t.isEnabled,
+		RateLimit:   t.rateLimit,
+		Annotations: t.annotations,
+		Examples:    t.examples,
+		Cacheable:   t.cacheable,
+		Timeout:     t.timeout,
+		CreatedAt:   t.createdAt,
+		UpdatedAt:   t.updatedAt,
+		Metadata:    t.metadata,
+	}
+}
+
+// RestoreTool rebuilds a tool from a snapshot, keeping its timestamps. The tool has
+// no handler until one is set.
+func RestoreTool(snapshot ToolSnapshot) (*Tool, error) {
+	name, err := vo.NewToolName(snapshot.Name)
+	if err != nil {
+		return nil, fmt.Errorf("tool %q: %w", snapshot.Name, err)
+	}
+	description, err := vo.NewToolDescription(snapshot.Description)
+	if err != nil {
+		return nil, fmt.Errorf("tool %s: %w", snapshot.Name, err)
+	}
+
+	metadata := snapshot.Metadata
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	return &Tool{
+		name:        name,
+		description: description,
+		inputSchema: snapshot.InputSchema,
+		category:    snapshot.Category,
+		tags:        snapshot.Tags,
+		isEnabled:   snapshot.Enabled,
+		rateLimit:   snapshot.RateLimit,
+		annotations: snapshot.Annotations,
+		examples:    snapshot.Examples,
+		cacheable:   snapshot.Cacheable,
+		timeout:     snapshot.Timeout,
+		createdAt:   snapshot.CreatedAt,
+		updatedAt:   snapshot.UpdatedAt,
+		metadata:    metadata,
+	}, nil
+}
+
+// CopyHandlerFrom gives the tool the handler of other, e.g. to re-attach the code of a
+// registered tool to one restored from a snapshot
+func (t *Tool) CopyHandlerFrom(other *Tool) {
+	t.handler = other.handler
+	t.ctxHandler = other.ctxHandler
+}
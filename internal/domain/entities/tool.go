@@ -2,7 +2,9 @@
 package entities
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
@@ -14,10 +16,14 @@ type Tool struct {
 	description vo.ToolDescription
 	inputSchema *JSONSchema
 	handler     ToolHandler
+	ctxHandler  ContextToolHandler
 	category    string
 	tags        []string
 	isEnabled   bool
 	rateLimit   *RateLimit
+	annotations *ToolAnnotations
+	examples    []map[string]interface{}
+	cacheable   bool
 	timeout     time.Duration
 	createdAt   time.Time
 	updatedAt   time.Time
@@ -27,6 +33,10 @@ type Tool struct {
 // ToolHandler is the function signature for tool execution
 type ToolHandler func(input map[string]interface{}) (*ToolResult, error)
 
+// ContextToolHandler is the function signature for tools that need the execution
+// context, e.g. to reach the session store
+type ContextToolHandler func(ctx context.Context, input map[string]interface{}) (*ToolResult, error)
+
 // JSONSchema represents a JSON Schema for tool input validation
 type JSONSchema struct {
 	Type                 string                 `json:"type"`
@@ -47,19 +57,36 @@ type JSONSchema struct {
 
 // ToolResult represents the result of a tool execution
 type ToolResult struct {
-	Content []ToolResultContent `json:"content"`
-	IsError bool                `json:"isError,omitempty"`
+	Content []ToolResultContent    `json:"content"`
+	IsError bool                   `json:"isError,omitempty"`
+	Meta    map[string]interface{} `json:"_meta,omitempty"`
 }
 
+// Tool result content item types
+const (
+	ToolContentText     = "text"
+	ToolContentImage    = "image"
+	ToolContentResource = "resource"
+)
+
 // ToolResultContent represents content in a tool result
 type ToolResultContent struct {
 	Type     string `json:"type"` // "text", "image", "resource"
 	Text     string `json:"text,omitempty"`
 	Data     string `json:"data,omitempty"`     // For image (base64)
-	MimeType string `json:"mimeType,omitempty"` // For image
+	MimeType string `json:"mimeType,omitempty"` // For image, or a format hint for text
 	URI      string `json:"uri,omitempty"`      // For resource
 }
 
+// ToolAnnotations represents behavioral hints for a tool
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    bool   `json:"readOnlyHint,omitempty"`    // Tool does not modify its environment
+	DestructiveHint bool   `json:"destructiveHint,omitempty"` // Tool may perform destructive updates
+	IdempotentHint  bool   `json:"idempotentHint,omitempty"`  // Repeated calls have no additional effect
+	OpenWorldHint   bool   `json:"openWorldHint,omitempty"`   // Tool interacts with external entities
+}
+
 // RateLimit represents rate limiting configuration for a tool
 type RateLimit struct {
 	RequestsPerMinute int
@@ -92,6 +119,12 @@ func (t *Tool) Description() vo.ToolDescription {
 	return t.description
 }
 
+// SetDescription sets the tool description
+func (t *Tool) SetDescription(description vo.ToolDescription) {
+	t.description = description
+	t.updatedAt = time.Now().UTC()
+}
+
 // InputSchema returns the tool input schema
 func (t *Tool) InputSchema() *JSONSchema {
 	return t.inputSchema
@@ -105,6 +138,17 @@ func (t *Tool) Handler() ToolHandler {
 // SetHandler sets the tool handler
 func (t *Tool) SetHandler(handler ToolHandler) {
 	t.handler = handler
+	t.ctxHandler = nil
+	t.updatedAt = time.Now().UTC()
+}
+
+// SetContextHandler sets a context-aware tool handler. Handler() still returns a
+// plain handler for it, which runs with a background context.
+func (t *Tool) SetContextHandler(handler ContextToolHandler) {
+	t.ctxHandler = handler
+	t.handler = func(input map[string]interface{}) (*ToolResult, error) {
+		return handler(context.Background(), input)
+	}
 	t.updatedAt = time.Now().UTC()
 }
 
@@ -164,6 +208,54 @@ func (t *Tool) SetRateLimit(limit *RateLimit) {
 	t.updatedAt = time.Now().UTC()
 }
 
+// Annotations returns the tool annotations
+func (t *Tool) Annotations() *ToolAnnotations {
+	return t.annotations
+}
+
+// SetAnnotations sets the tool annotations
+func (t *Tool) SetAnnotations(annotations *ToolAnnotations) {
+	t.annotations = annotations
+	t.updatedAt = time.Now().UTC()
+}
+
+// IsReadOnly returns whether the tool is annotated as read-only
+func (t *Tool) IsReadOnly() bool {
+	return t.annotations != nil && t.annotations.ReadOnlyHint
+}
+
+// SetCacheable opts the tool into per-turn result caching in the agentic loop
+func (t *Tool) SetCacheable(cacheable bool) {
+	t.cacheable = cacheable
+	t.updatedAt = time.Now().UTC()
+}
+
+// IsCacheable returns whether results may be reused within a turn; only read-only tools qualify
+func (t *Tool) IsCacheable() bool {
+	return t.cacheable && t.IsReadOnly()
+}
+
+// Examples returns example inputs for the tool
+func (t *Tool) Examples() []map[string]interface{} {
+	return t.examples
+}
+
+// SetExamples sets example inputs for the tool, listed in tools/list
+func (t *Tool) SetExamples(examples []map[string]interface{}) {
+	t.examples = examples
+	t.updatedAt = time.Now().UTC()
+}
+
+// ValidateExamples checks that every example input conforms to the input schema
+func (t *Tool) ValidateExamples() error {
+	for i, example := range t.examples {
+		if err := t.inputSchema.Validate(example); err != nil {
+			return fmt.Errorf("tool %s example %d: %w", t.name, i, err)
+		}
+	}
+	return nil
+}
+
 // Timeout returns the tool timeout
 func (t *Tool) Timeout() time.Duration {
 	return t.timeout
@@ -196,17 +288,39 @@ func (t *Tool) SetMetadata(key string, value interface{}) {
 	t.updatedAt = time.Now().UTC()
 }
 
+// requiredScopeKey is the metadata key holding the scope a caller needs to run the tool
+const requiredScopeKey = "required_scope"
+
+// RequiredScope returns the scope a caller needs to run the tool, or "" if none
+func (t *Tool) RequiredScope() string {
+	scope, _ := t.metadata[requiredScopeKey].(string)
+	return scope
+}
+
+// SetRequiredScope restricts the tool to callers granted scope
+func (t *Tool) SetRequiredScope(scope string) {
+	t.SetMetadata(requiredScopeKey, scope)
+}
+
 // Execute executes the tool with the given input
 func (t *Tool) Execute(input map[string]interface{}) (*ToolResult, error) {
 	if t.handler == nil {
 		return &ToolResult{
-			Content: []ToolResultContent{{Type: "text", Text: "Tool handler not configured"}},
+			Content: []ToolResultContent{{Type: ToolContentText, Text: "Tool handler not configured"}},
 			IsError: true,
 		}, nil
 	}
 	return t.handler(input)
 }
 
+// ExecuteContext executes the tool, passing ctx to context-aware handlers
+func (t *Tool) ExecuteContext(ctx context.Context, input map[string]interface{}) (*ToolResult, error) {
+	if t.ctxHandler != nil {
+		return t.ctxHandler(ctx, input)
+	}
+	return t.Execute(input)
+}
+
 // ToMCPTool converts the tool to MCP format
 func (t *Tool) ToMCPTool() map[string]interface{} {
 	result := map[string]interface{}{
@@ -216,6 +330,12 @@ func (t *Tool) ToMCPTool() map[string]interface{} {
 	if t.inputSchema != nil {
 		result["inputSchema"] = t.inputSchema
 	}
+	if t.annotations != nil {
+		result["annotations"] = t.annotations
+	}
+	if len(t.examples) > 0 {
+		result["examples"] = t.examples
+	}
 	return result
 }
 
@@ -224,20 +344,89 @@ func (t *Tool) ToJSON() ([]byte, error) {
 	return json.Marshal(t.ToMCPTool())
 }
 
+// NewToolResult creates a tool result without content. Items are added in order
+// with AddText, AddFormattedText, AddJSON and AddImage.
+func NewToolResult() *ToolResult {
+	return &ToolResult{Content: []ToolResultContent{}}
+}
+
 // NewTextToolResult creates a text tool result
 func NewTextToolResult(text string) *ToolResult {
-	return &ToolResult{
-		Content: []ToolResultContent{
-			{Type: "text", Text: text},
-		},
+	return NewToolResult().AddText(text)
+}
+
+// AddText appends a text item to the result
+func (r *ToolResult) AddText(text string) *ToolResult {
+	return r.AddFormattedText(text, "")
+}
+
+// AddFormattedText appends a text item with a MIME type hint to the result
+func (r *ToolResult) AddFormattedText(text, mimeType string) *ToolResult {
+	r.Content = append(r.Content, ToolResultContent{Type: ToolContentText, Text: text, MimeType: mimeType})
+	return r
+}
+
+// AddJSON appends a text item holding the indented JSON encoding of value. The
+// result is left unchanged when value cannot be encoded.
+func (r *ToolResult) AddJSON(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
 	}
+	r.AddFormattedText(string(data), MimeTypeJSON)
+	return nil
+}
+
+// AddImage appends a base64-encoded image item to the result
+func (r *ToolResult) AddImage(data, mimeType string) *ToolResult {
+	r.Content = append(r.Content, ToolResultContent{Type: ToolContentImage, Data: data, MimeType: mimeType})
+	return r
+}
+
+// Tool result format hints for text content
+const (
+	MimeTypeJSON     = "application/json"
+	MimeTypeMarkdown = "text/markdown"
+	MimeTypeCSV      = "text/csv"
+)
+
+// NewFormattedToolResult creates a text tool result with a MIME type hint
+func NewFormattedToolResult(text, mimeType string) *ToolResult {
+	return NewToolResult().AddFormattedText(text, mimeType)
+}
+
+// NewJSONToolResult creates a text tool result holding the indented JSON encoding of value
+func NewJSONToolResult(value interface{}) *ToolResult {
+	result := NewToolResult()
+	if err := result.AddJSON(value); err != nil {
+		return NewErrorToolResult(err)
+	}
+	return result
+}
+
+// NewMarkdownToolResult creates a markdown tool result
+func NewMarkdownToolResult(markdown string) *ToolResult {
+	return NewFormattedToolResult(markdown, MimeTypeMarkdown)
+}
+
+// NewCSVToolResult creates a CSV tool result
+func NewCSVToolResult(csv string) *ToolResult {
+	return NewFormattedToolResult(csv, MimeTypeCSV)
+}
+
+// SetMeta sets a metadata value on the tool result
+func (r *ToolResult) SetMeta(key string, value interface{}) {
+	if r.Meta == nil {
+		r.Meta = make(map[string]interface{})
+	}
+	r.Meta[key] = value
 }
 
 // NewErrorToolResult creates an error tool result
 func NewErrorToolResult(err error) *ToolResult {
 	return &ToolResult{
 		Content: []ToolResultContent{
-			{Type: "text", Text: err.Error()},
+			{Type: ToolContentText, Text: err.Error()},
 		},
 		IsError: true,
 	}
@@ -245,18 +434,14 @@ func NewErrorToolResult(err error) *ToolResult {
 
 // NewImageToolResult creates an image tool result
 func NewImageToolResult(data, mimeType string) *ToolResult {
-	return &ToolResult{
-		Content: []ToolResultContent{
-			{Type: "image", Data: data, MimeType: mimeType},
-		},
-	}
+	return NewToolResult().AddImage(data, mimeType)
 }
 
 // NewResourceToolResult creates a resource tool result
 func NewResourceToolResult(uri, text, mimeType string) *ToolResult {
 	return &ToolResult{
 		Content: []ToolResultContent{
-			{Type: "resource", URI: uri, Text: text, MimeType: mimeType},
+			{Type: ToolContentResource, URI: uri, Text: text, MimeType: mimeType},
 		},
 	}
 }
@@ -0,0 +1,30 @@
+package entities
+
+import "context"
+
+// ToolOutputFunc receives output a tool produces while it is still running
+type ToolOutputFunc func(chunk string)
+
+type toolOutputKey struct{}
+
+// ContextWithToolOutput returns a context whose tools stream partial output to fn
+func ContextWithToolOutput(ctx context.Context, fn ToolOutputFunc) context.Context {
+	return context.WithValue(ctx, toolOutputKey{}, fn)
+}
+
+// ToolOutputFromContext returns the partial output receiver carried by ctx, if any
+func ToolOutputFromContext(ctx context.Context) (ToolOutputFunc, bool) {
+	fn, ok := ctx.Value(toolOutputKey{}).(ToolOutputFunc)
+	return fn, ok && fn != nil
+}
+
+// EmitToolOutput streams a chunk of partial output from a running tool, such as a
+// line of a followed command or file. It reports whether anyone was listening; the
+// tool's result should still carry its outcome, since callers may not stream.
+func EmitToolOutput(ctx context.Context, chunk string) bool {
+	fn, ok := ToolOutputFromContext(ctx)
+	if ok && chunk != "" {
+		fn(chunk)
+	}
+	return ok
+}
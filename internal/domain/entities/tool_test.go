@@ -2,7 +2,9 @@
 package entities
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -406,6 +408,61 @@ func TestNewResourceToolResult(t *testing.T) {
 	}
 }
 
+func TestNewJSONToolResult(t *testing.T) {
+	result := NewJSONToolResult(map[string]interface{}{"status": "ok"})
+
+	if result.IsError {
+		t.Error("JSON result should not be an error")
+	}
+
+	if result.Content[0].Type != "text" {
+		t.Errorf("Expected type 'text', got '%s'", result.Content[0].Type)
+	}
+
+	if result.Content[0].MimeType != MimeTypeJSON {
+		t.Errorf("Expected mimeType '%s', got '%s'", MimeTypeJSON, result.Content[0].MimeType)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &decoded); err != nil || decoded["status"] != "ok" {
+		t.Errorf("Expected JSON text, got '%s'", result.Content[0].Text)
+	}
+
+	// Unencodable values produce an error result
+	if !NewJSONToolResult(make(chan int)).IsError {
+		t.Error("Unencodable value should produce an error result")
+	}
+}
+
+func TestNewFormattedToolResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *ToolResult
+		mimeType string
+	}{
+		{"markdown", NewMarkdownToolResult("# Title"), MimeTypeMarkdown},
+		{"csv", NewCSVToolResult("a,b\n1,2"), MimeTypeCSV},
+		{"custom", NewFormattedToolResult("<p>hi</p>", "text/html"), "text/html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result.Content[0].Type != "text" {
+				t.Errorf("Expected type 'text', got '%s'", tt.result.Content[0].Type)
+			}
+			if tt.result.Content[0].MimeType != tt.mimeType {
+				t.Errorf("Expected mimeType '%s', got '%s'", tt.mimeType, tt.result.Content[0].MimeType)
+			}
+		})
+	}
+
+	// Plain text results carry no hint
+	data, _ := json.Marshal(NewTextToolResult("plain"))
+	if strings.Contains(string(data), "mimeType") {
+		t.Errorf("Plain text result should omit mimeType, got %s", data)
+	}
+}
+
 func BenchmarkNewTool(b *testing.B) {
 	name, _ := vo.NewToolName("bench_tool")
 	desc, _ := vo.NewToolDescription("Benchmark tool")
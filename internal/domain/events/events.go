@@ -131,6 +131,25 @@ func NewSessionClosedEvent(sessionID vo.SessionID) *SessionClosedEvent {
 	}
 }
 
+// SessionResetEvent is emitted when a session's collections are cleared
+type SessionResetEvent struct {
+	BaseEvent
+}
+
+// NewSessionResetEvent creates a new SessionResetEvent
+func NewSessionResetEvent(sessionID vo.SessionID) *SessionResetEvent {
+	return &SessionResetEvent{
+		BaseEvent: newBaseEvent(
+			"session.reset",
+			sessionID.String(),
+			"Session",
+			map[string]interface{}{
+				"sessionId": sessionID.String(),
+			},
+		),
+	}
+}
+
 // Conversation Events
 
 // ConversationCreatedEvent is emitted when a conversation is created
@@ -173,6 +192,27 @@ func NewConversationClosedEvent(conversationID vo.ConversationID) *ConversationC
 	}
 }
 
+// ConversationDeletedEvent is emitted when a conversation and its messages are deleted
+type ConversationDeletedEvent struct {
+	BaseEvent
+}
+
+// NewConversationDeletedEvent creates a new ConversationDeletedEvent
+func NewConversationDeletedEvent(conversationID vo.ConversationID, sessionID vo.SessionID, messageCount int) *ConversationDeletedEvent {
+	return &ConversationDeletedEvent{
+		BaseEvent: newBaseEvent(
+			"conversation.deleted",
+			conversationID.String(),
+			"Conversation",
+			map[string]interface{}{
+				"conversationId": conversationID.String(),
+				"sessionId":      sessionID.String(),
+				"messageCount":   messageCount,
+			},
+		),
+	}
+}
+
 // Message Events
 
 // MessageAddedEvent is emitted when a message is added to a conversation
@@ -196,6 +236,26 @@ func NewMessageAddedEvent(conversationID vo.ConversationID, messageID vo.Message
 	}
 }
 
+// MessageEditedEvent is emitted when the text of a message is edited
+type MessageEditedEvent struct {
+	BaseEvent
+}
+
+// NewMessageEditedEvent creates a new MessageEditedEvent
+func NewMessageEditedEvent(conversationID vo.ConversationID, messageID vo.MessageID) *MessageEditedEvent {
+	return &MessageEditedEvent{
+		BaseEvent: newBaseEvent(
+			"message.edited",
+			conversationID.String(),
+			"Conversation",
+			map[string]interface{}{
+				"conversationId": conversationID.String(),
+				"messageId":      messageID.String(),
+			},
+		),
+	}
+}
+
 // Tool Events
 
 // ToolRegisteredEvent is emitted when a tool is registered
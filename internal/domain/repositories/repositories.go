@@ -3,6 +3,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
@@ -31,6 +32,60 @@ type ISessionRepository interface {
 
 	// Count returns the total number of sessions
 	Count(ctx context.Context) (int, error)
+
+	// CountOpen returns the number of sessions that have not been closed
+	CountOpen(ctx context.Context) (int, error)
+
+	// Query retrieves a page of the sessions matching filter
+	Query(ctx context.Context, filter SessionFilter) (*SessionPage, error)
+}
+
+// SessionSortField names the field sessions are ordered by
+type SessionSortField string
+
+// Session sort fields
+const (
+	SessionSortCreatedAt  SessionSortField = "created_at"
+	SessionSortUpdatedAt  SessionSortField = "updated_at"
+	SessionSortClientName SessionSortField = "client_name"
+	SessionSortState      SessionSortField = "state"
+)
+
+// SessionFilter selects sessions for ISessionRepository.Query. Zero-valued fields do
+// not filter, so criteria can be added without changing existing callers.
+type SessionFilter struct {
+	// States matches sessions in any of the given states
+	States []aggregates.SessionState
+	// ClientName matches sessions whose client name contains it, ignoring case
+	ClientName string
+	// ProtocolVersion matches sessions that negotiated exactly this version
+	ProtocolVersion string
+	// CreatedAfter and CreatedBefore bound the session age; sessions older than d
+	// match CreatedBefore: time.Now().Add(-d)
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// SortBy orders the results, by creation time when empty. Results are
+	// newest or last first unless Ascending is set.
+	SortBy    SessionSortField
+	Ascending bool
+
+	// Offset skips matching sessions; Limit caps the page (0 returns the rest)
+	Offset int
+	Limit  int
+}
+
+// SessionPage is one page of sessions matching a filter
+type SessionPage struct {
+	Sessions []*aggregates.Session
+	Total    int
+	Offset   int
+	Limit    int
+}
+
+// HasMore reports whether matching sessions remain after this page
+func (p *SessionPage) HasMore() bool {
+	return p.Offset+len(p.Sessions) < p.Total
 }
 
 // IConversationRepository defines the interface for conversation persistence
@@ -58,13 +113,47 @@ type IConversationRepository interface {
 
 	// CountBySessionID returns the number of conversations for a session
 	CountBySessionID(ctx context.Context, sessionID vo.SessionID) (int, error)
+
+	// FindByMetadata retrieves a page of summaries of conversations whose metadata key holds value
+	FindByMetadata(ctx context.Context, key string, value interface{}, offset, limit int) (*ConversationSummaryPage, error)
+}
+
+// ConversationSummary is a lightweight view of a conversation for listings
+type ConversationSummary struct {
+	ID           vo.ConversationID
+	SessionID    vo.SessionID
+	Model        string
+	Status       string
+	MessageCount int
+	Metadata     map[string]interface{}
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ConversationSummaryPage is one page of conversation summaries, newest first
+type ConversationSummaryPage struct {
+	Summaries []ConversationSummary
+	Total     int
+	Offset    int
+	Limit     int
+}
+
+// HasMore reports whether conversations remain after this page
+func (p *ConversationSummaryPage) HasMore() bool {
+	return p.Offset+len(p.Summaries) < p.Total
 }
 
 // IToolRepository defines the interface for tool registry
 type IToolRepository interface {
-	// Register registers a tool
+	// Register registers a tool, failing if a tool with the same name exists
 	Register(ctx context.Context, tool *entities.Tool) error
 
+	// Replace registers a tool, overriding any tool with the same name
+	Replace(ctx context.Context, tool *entities.Tool) (bool, error)
+
+	// Update stores changes to a registered tool, failing if no tool with its name exists
+	Update(ctx context.Context, tool *entities.Tool) error
+
 	// Unregister removes a tool
 	Unregister(ctx context.Context, name vo.ToolName) error
 
@@ -114,6 +203,24 @@ type IResourceRepository interface {
 	Count(ctx context.Context) (int, error)
 }
 
+// IResourceSubscriptionRepository defines the interface for resource subscription persistence
+type IResourceSubscriptionRepository interface {
+	// Subscribe records a session's subscription to a resource URI
+	Subscribe(ctx context.Context, sessionID vo.SessionID, uri string) error
+
+	// Unsubscribe removes a session's subscription to a resource URI
+	Unsubscribe(ctx context.Context, sessionID vo.SessionID, uri string) error
+
+	// FindBySessionID retrieves the resource URIs a session is subscribed to
+	FindBySessionID(ctx context.Context, sessionID vo.SessionID) ([]string, error)
+
+	// FindSubscribers retrieves the sessions subscribed to a resource URI
+	FindSubscribers(ctx context.Context, uri string) ([]vo.SessionID, error)
+
+	// DeleteBySessionID removes all subscriptions of a session
+	DeleteBySessionID(ctx context.Context, sessionID vo.SessionID) error
+}
+
 // IPromptRepository defines the interface for prompt registry
 type IPromptRepository interface {
 	// Register registers a prompt
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ClaudeErrorCategory classifies a failed Claude API call
+type ClaudeErrorCategory string
+
+// Claude API error categories
+const (
+	ClaudeErrorAuth           ClaudeErrorCategory = "authentication"
+	ClaudeErrorRateLimit      ClaudeErrorCategory = "rate_limit"
+	ClaudeErrorOverloaded     ClaudeErrorCategory = "overloaded"
+	ClaudeErrorInvalidRequest ClaudeErrorCategory = "invalid_request"
+	ClaudeErrorAPI            ClaudeErrorCategory = "api_error"
+)
+
+// ClaudeError is implemented by the typed errors an IClaudeService returns for
+// error responses from the Claude API
+type ClaudeError interface {
+	error
+	// Category classifies the error
+	Category() ClaudeErrorCategory
+	// Retryable reports whether the same request may succeed if sent again
+	Retryable() bool
+	// Response returns the details of the error response
+	Response() *APIError
+}
+
+// APIError is an error response from the Claude API that no more specific type
+// covers, such as an internal server error
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Type       string // Error type reported by the API, e.g. rate_limit_error
+	Message    string // Error message reported by the API
+	RequestID  string // Request ID to quote to Anthropic support
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("Claude API error (%d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("Claude API error (%d %s): %s", e.StatusCode, e.Type, e.Message)
+}
+
+// Category implements ClaudeError
+func (e *APIError) Category() ClaudeErrorCategory { return ClaudeErrorAPI }
+
+// Retryable reports whether the API failed on its side
+func (e *APIError) Retryable() bool { return e.StatusCode >= 500 }
+
+// Response implements ClaudeError
+func (e *APIError) Response() *APIError { return e }
+
+// AuthError is returned when the API key is missing, invalid or lacks permission
+type AuthError struct{ APIError }
+
+// Category implements ClaudeError
+func (e *AuthError) Category() ClaudeErrorCategory { return ClaudeErrorAuth }
+
+// Retryable implements ClaudeError
+func (e *AuthError) Retryable() bool { return false }
+
+// RateLimitError is returned when the account's rate limit is exceeded. RetryAfter
+// is how long the API asked to wait, zero when it did not say.
+type RateLimitError struct {
+	APIError
+	RetryAfter time.Duration
+}
+
+// Category implements ClaudeError
+func (e *RateLimitError) Category() ClaudeErrorCategory { return ClaudeErrorRateLimit }
+
+// Retryable implements ClaudeError
+func (e *RateLimitError) Retryable() bool { return true }
+
+// OverloadError is returned when the API is temporarily overloaded
+type OverloadError struct{ APIError }
+
+// Category implements ClaudeError
+func (e *OverloadError) Category() ClaudeErrorCategory { return ClaudeErrorOverloaded }
+
+// Retryable implements ClaudeError
+func (e *OverloadError) Retryable() bool { return true }
+
+// InvalidRequestError is returned when the API rejects the request itself, for
+// example a malformed body, an unknown model or a prompt that is too long
+type InvalidRequestError struct{ APIError }
+
+// Category implements ClaudeError
+func (e *InvalidRequestError) Category() ClaudeErrorCategory { return ClaudeErrorInvalidRequest }
+
+// Retryable implements ClaudeError
+func (e *InvalidRequestError) Retryable() bool { return false }
+
+// AsClaudeError returns the typed Claude API error in err's chain, if any
+func AsClaudeError(err error) (ClaudeError, bool) {
+	var claudeErr ClaudeError
+	if errors.As(err, &claudeErr) {
+		return claudeErr, true
+	}
+	return nil, false
+}
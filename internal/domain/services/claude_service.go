@@ -20,6 +20,7 @@ type ClaudeRequest struct {
 	TopK          int
 	StopSequences []string
 	Tools         []ClaudeTool
+	ToolChoice    *ClaudeToolChoice // Only sent alongside Tools; nil lets the API default to auto
 	Stream        bool
 	Metadata      map[string]interface{}
 }
@@ -37,6 +38,12 @@ type ClaudeTool struct {
 	InputSchema *entities.JSONSchema `json:"input_schema"`
 }
 
+// ClaudeToolChoice controls how Claude chooses among the request's tools
+type ClaudeToolChoice struct {
+	Type vo.ToolChoiceType
+	Name string // Tool Claude must use when Type is "tool"
+}
+
 // ClaudeResponse represents a response from the Claude API
 type ClaudeResponse struct {
 	ID           string
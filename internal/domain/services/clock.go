@@ -0,0 +1,55 @@
+package services
+
+import "time"
+
+// Clock tells the current time. Expiry checks take a Clock so tests can move time.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the machine's wall clock
+type SystemClock struct{}
+
+// Now returns the current local time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ExpiryChecker decides whether an expiry time has passed. Expiry times may be set by
+// another replica whose clock disagrees with ours, so a deadline only counts as passed
+// once the skew tolerance beyond it has elapsed too. A larger tolerance avoids
+// expiring tokens early and taking over locks that are still held, at the cost of
+// honouring expired tokens and locks of crashed holders for that much longer.
+//
+// The zero value uses the system clock with no tolerance.
+type ExpiryChecker struct {
+	clock Clock
+	skew  time.Duration
+}
+
+// NewExpiryChecker creates an ExpiryChecker reading clock, which defaults to the
+// system clock, and tolerating skewTolerance of disagreement between clocks
+func NewExpiryChecker(clock Clock, skewTolerance time.Duration) ExpiryChecker {
+	if skewTolerance < 0 {
+		skewTolerance = 0
+	}
+	return ExpiryChecker{clock: clock, skew: skewTolerance}
+}
+
+// Now returns the current time of the checker's clock
+func (e ExpiryChecker) Now() time.Time {
+	if e.clock == nil {
+		return time.Now()
+	}
+	return e.clock.Now()
+}
+
+// SkewTolerance returns the tolerated disagreement between clocks
+func (e ExpiryChecker) SkewTolerance() time.Duration {
+	return e.skew
+}
+
+// Expired reports whether expiresAt has passed by more than the skew tolerance
+func (e ExpiryChecker) Expired(expiresAt time.Time) bool {
+	return !e.Now().Before(expiresAt.Add(e.skew))
+}
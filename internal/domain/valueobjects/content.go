@@ -3,7 +3,9 @@ package valueobjects
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // Content validation errors
@@ -13,6 +15,10 @@ var (
 	ErrInvalidContentType = errors.New("invalid content type")
 	ErrInvalidRole        = errors.New("invalid message role")
 	ErrInvalidModel       = errors.New("invalid model identifier")
+
+	ErrTooManyStopSequences = errors.New("too many stop sequences")
+	ErrStopSequenceTooLong  = errors.New("stop sequence is too long")
+	ErrBlankStopSequence    = errors.New("stop sequence must contain non-whitespace characters")
 )
 
 // ContentType represents the type of content in a message
@@ -23,12 +29,16 @@ const (
 	ContentTypeImage      ContentType = "image"
 	ContentTypeToolUse    ContentType = "tool_use"
 	ContentTypeToolResult ContentType = "tool_result"
+
+	// ContentTypeResource references a resource by URI; the server inlines its
+	// content when the message is sent to Claude
+	ContentTypeResource ContentType = "resource"
 )
 
 // IsValid checks if the content type is valid
 func (c ContentType) IsValid() bool {
 	switch c {
-	case ContentTypeText, ContentTypeImage, ContentTypeToolUse, ContentTypeToolResult:
+	case ContentTypeText, ContentTypeImage, ContentTypeToolUse, ContentTypeToolResult, ContentTypeResource:
 		return true
 	}
 	return false
@@ -80,6 +90,42 @@ const (
 // DefaultModel is the default model to use
 const DefaultModel = ModelClaude4Sonnet
 
+// Default stop sequence limits. They are this server's own bounds on what clients
+// may send rather than limits published for the models, and can be changed with
+// claude.max_stop_sequences and claude.max_stop_sequence_length.
+const (
+	MaxStopSequences      = 8
+	MaxStopSequenceLength = 128
+)
+
+// StopSequenceLimits bounds the stop sequences a request may carry. A zero field
+// leaves that bound off.
+type StopSequenceLimits struct {
+	MaxCount  int
+	MaxLength int // In characters
+}
+
+// DefaultStopSequenceLimits returns the limits used when none are configured
+func DefaultStopSequenceLimits() StopSequenceLimits {
+	return StopSequenceLimits{MaxCount: MaxStopSequences, MaxLength: MaxStopSequenceLength}
+}
+
+// Validate checks sequences against the limits. Blank sequences are always rejected.
+func (l StopSequenceLimits) Validate(sequences []string) error {
+	if l.MaxCount > 0 && len(sequences) > l.MaxCount {
+		return fmt.Errorf("%w: %d given, at most %d allowed", ErrTooManyStopSequences, len(sequences), l.MaxCount)
+	}
+	for i, sequence := range sequences {
+		if strings.TrimSpace(sequence) == "" {
+			return fmt.Errorf("%w: sequence %d", ErrBlankStopSequence, i)
+		}
+		if length := utf8.RuneCountInString(sequence); l.MaxLength > 0 && length > l.MaxLength {
+			return fmt.Errorf("%w: sequence %d has %d characters, at most %d allowed", ErrStopSequenceTooLong, i, length, l.MaxLength)
+		}
+	}
+	return nil
+}
+
 // IsValid checks if the model is valid
 func (m Model) IsValid() bool {
 	switch m {
@@ -91,11 +137,55 @@ func (m Model) IsValid() bool {
 	return false
 }
 
+// MaxOutputTokens returns the model's maximum output tokens, or 0 if unknown
+func (m Model) MaxOutputTokens() int {
+	switch m {
+	case ModelClaude4Sonnet, ModelClaude37Sonnet:
+		return 64000
+	case ModelClaude4Opus:
+		return 32000
+	case ModelClaude35Sonnet, ModelClaude35SonnetV2, ModelClaude35Haiku:
+		return 8192
+	case ModelClaude3Opus, ModelClaude3Sonnet, ModelClaude3Haiku:
+		return 4096
+	}
+	return 0
+}
+
 // String returns the string representation
 func (m Model) String() string {
 	return string(m)
 }
 
+// StopReason represents why Claude stopped generating a response
+type StopReason string
+
+const (
+	StopReasonEndTurn      StopReason = "end_turn"
+	StopReasonMaxTokens    StopReason = "max_tokens"
+	StopReasonToolUse      StopReason = "tool_use"
+	StopReasonStopSequence StopReason = "stop_sequence"
+)
+
+// IsValid checks if the stop reason is valid
+func (s StopReason) IsValid() bool {
+	switch s {
+	case StopReasonEndTurn, StopReasonMaxTokens, StopReasonToolUse, StopReasonStopSequence:
+		return true
+	}
+	return false
+}
+
+// IsTruncated reports whether the response was cut off by the token limit
+func (s StopReason) IsTruncated() bool {
+	return s == StopReasonMaxTokens
+}
+
+// String returns the string representation
+func (s StopReason) String() string {
+	return string(s)
+}
+
 // TextContent represents text content value object
 type TextContent struct {
 	value string
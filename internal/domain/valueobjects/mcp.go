@@ -42,8 +42,9 @@ const (
 	MethodShutdown    MCPMethod = "shutdown"
 
 	// Tool methods
-	MethodToolsList MCPMethod = "tools/list"
-	MethodToolsCall MCPMethod = "tools/call"
+	MethodToolsList      MCPMethod = "tools/list"
+	MethodToolsCall      MCPMethod = "tools/call"
+	MethodToolsCallBatch MCPMethod = "tools/callBatch" // Experimental
 
 	// Resource methods
 	MethodResourcesList        MCPMethod = "resources/list"
@@ -61,6 +62,24 @@ const (
 	// Logging methods
 	MethodLoggingSetLevel MCPMethod = "logging/setLevel"
 
+	// Experimental methods
+	MethodExperimentalDescribeTool     MCPMethod = "experimental/describeTool"
+	MethodExperimentalDescribeResource MCPMethod = "experimental/describeResource"
+	MethodExperimentalCapabilitiesDiff MCPMethod = "experimental/capabilitiesDiff"
+	MethodConversationTemplatesList    MCPMethod = "conversationTemplates/list"
+	MethodSessionReset                 MCPMethod = "session/reset"
+	MethodSessionMetrics               MCPMethod = "session/metrics"
+
+	// Admin methods
+	MethodAdminSetToolEnabled MCPMethod = "admin/setToolEnabled"
+	MethodAdminListRequests   MCPMethod = "admin/listRequests"
+	MethodAdminCancelRequest  MCPMethod = "admin/cancelRequest"
+	MethodAdminTaskResult     MCPMethod = "admin/taskResult"
+	MethodToolsUpdate         MCPMethod = "tools/update"
+
+	// Server to client requests
+	MethodRootsList MCPMethod = "roots/list"
+
 	// Notification methods
 	MethodNotificationsCancelled            MCPMethod = "notifications/cancelled"
 	MethodNotificationsProgress             MCPMethod = "notifications/progress"
@@ -69,19 +88,22 @@ const (
 	MethodNotificationsResourcesListChanged MCPMethod = "notifications/resources/list_changed"
 	MethodNotificationsToolsListChanged     MCPMethod = "notifications/tools/list_changed"
 	MethodNotificationsPromptsListChanged   MCPMethod = "notifications/prompts/list_changed"
+	MethodNotificationsRootsListChanged     MCPMethod = "notifications/roots/list_changed"
 )
 
 // IsValid checks if the method is valid
 func (m MCPMethod) IsValid() bool {
 	switch m {
 	case MethodInitialize, MethodInitialized, MethodPing, MethodShutdown,
-		MethodToolsList, MethodToolsCall,
+		MethodToolsList, MethodToolsCall, MethodToolsCallBatch,
 		MethodResourcesList, MethodResourcesRead, MethodResourcesSubscribe, MethodResourcesUnsubscribe,
 		MethodPromptsList, MethodPromptsGet,
 		MethodCompletionComplete, MethodLoggingSetLevel,
+		MethodExperimentalDescribeTool, MethodExperimentalDescribeResource, MethodExperimentalCapabilitiesDiff, MethodConversationTemplatesList, MethodSessionReset, MethodSessionMetrics, MethodAdminSetToolEnabled, MethodAdminListRequests, MethodAdminCancelRequest, MethodAdminTaskResult, MethodToolsUpdate,
 		MethodNotificationsCancelled, MethodNotificationsProgress, MethodNotificationsMessage,
 		MethodNotificationsResourcesUpdated, MethodNotificationsResourcesListChanged,
-		MethodNotificationsToolsListChanged, MethodNotificationsPromptsListChanged:
+		MethodNotificationsToolsListChanged, MethodNotificationsPromptsListChanged,
+		MethodRootsList, MethodNotificationsRootsListChanged:
 		return true
 	}
 	return false
@@ -227,6 +249,10 @@ const (
 	ErrorCodeRateLimited        MCPErrorCode = -32007
 	ErrorCodeTimeout            MCPErrorCode = -32008
 	ErrorCodeCancelled          MCPErrorCode = -32009
+	ErrorCodeServerAtCapacity   MCPErrorCode = -32010
+	ErrorCodeToolDisabled       MCPErrorCode = -32011
+	ErrorCodeServerBusy         MCPErrorCode = -32012
+	ErrorCodeResponseTooLarge   MCPErrorCode = -32013
 )
 
 // IsStandardError checks if the error is a standard JSON-RPC error
@@ -270,6 +296,14 @@ func (e MCPErrorCode) Message() string {
 		return "Request timeout"
 	case ErrorCodeCancelled:
 		return "Request cancelled"
+	case ErrorCodeServerAtCapacity:
+		return "Server at capacity"
+	case ErrorCodeToolDisabled:
+		return "Tool disabled"
+	case ErrorCodeServerBusy:
+		return "Server busy"
+	case ErrorCodeResponseTooLarge:
+		return "Response too large"
 	}
 	return "Unknown error"
 }
@@ -0,0 +1,97 @@
+package valueobjects
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Tool use validation errors
+var (
+	ErrInvalidToolChoice      = errors.New("tool_choice must be 'auto', 'any', 'tool', or 'none'")
+	ErrToolChoiceNameRequired = errors.New("tool_choice 'tool' requires a tool name")
+	ErrToolChoiceNameUnused   = errors.New("a tool name is only allowed with tool_choice 'tool'")
+	ErrToolNotAllowed         = errors.New("tool is not in the allowed tools")
+	ErrToolUseDisabled        = errors.New("tool_choice requires tool use to be enabled")
+)
+
+// ToolChoiceType controls how Claude chooses among the tools it is offered
+type ToolChoiceType string
+
+const (
+	ToolChoiceAuto ToolChoiceType = "auto" // Claude decides whether to use a tool
+	ToolChoiceAny  ToolChoiceType = "any"  // Claude must use one of the tools
+	ToolChoiceTool ToolChoiceType = "tool" // Claude must use the named tool
+	ToolChoiceNone ToolChoiceType = "none" // Claude must not use any tool
+)
+
+// IsValid checks if the tool choice type is valid
+func (t ToolChoiceType) IsValid() bool {
+	switch t {
+	case ToolChoiceAuto, ToolChoiceAny, ToolChoiceTool, ToolChoiceNone:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation
+func (t ToolChoiceType) String() string {
+	return string(t)
+}
+
+// ToolUseSettings controls whether and how Claude may use tools in a conversation
+type ToolUseSettings struct {
+	Enabled      bool           `json:"enabled"`
+	AllowedTools []string       `json:"allowed_tools,omitempty"` // Empty allows every conversation tool
+	Choice       ToolChoiceType `json:"tool_choice"`
+	ToolName     string         `json:"tool_name,omitempty"` // Tool Claude must use when Choice is "tool"
+}
+
+// DefaultToolUseSettings lets Claude decide whether to use any of the conversation's tools
+func DefaultToolUseSettings() ToolUseSettings {
+	return ToolUseSettings{Enabled: true, Choice: ToolChoiceAuto}
+}
+
+// Validate checks that the settings are consistent
+func (s ToolUseSettings) Validate() error {
+	if !s.Choice.IsValid() {
+		return fmt.Errorf("%w, got %q", ErrInvalidToolChoice, s.Choice)
+	}
+	if !s.Enabled && (s.Choice == ToolChoiceAny || s.Choice == ToolChoiceTool) {
+		return fmt.Errorf("%w, got %q", ErrToolUseDisabled, s.Choice)
+	}
+	if s.Choice == ToolChoiceTool && s.ToolName == "" {
+		return ErrToolChoiceNameRequired
+	}
+	if s.Choice != ToolChoiceTool && s.ToolName != "" {
+		return ErrToolChoiceNameUnused
+	}
+	if s.ToolName != "" && !s.Allows(s.ToolName) {
+		return fmt.Errorf("%w: %s", ErrToolNotAllowed, s.ToolName)
+	}
+	return nil
+}
+
+// Allows reports whether the settings let Claude use the named tool
+func (s ToolUseSettings) Allows(name string) bool {
+	if !s.Enabled {
+		return false
+	}
+	if len(s.AllowedTools) == 0 {
+		return true
+	}
+	for _, allowed := range s.AllowedTools {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolNames returns the tool names the settings refer to
+func (s ToolUseSettings) ToolNames() []string {
+	names := append([]string(nil), s.AllowedTools...)
+	if s.ToolName != "" && len(s.AllowedTools) == 0 {
+		names = append(names, s.ToolName)
+	}
+	return names
+}
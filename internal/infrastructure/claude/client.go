@@ -43,6 +43,8 @@ func NewClient(cfg *config.ClaudeConfig, logger zerolog.Logger) (*Client, error)
 
 	opts := []option.RequestOption{
 		option.WithAPIKey(cfg.APIKey),
+		// Retries are handled by the client so they can act on the typed error
+		option.WithMaxRetries(0),
 	}
 
 	if cfg.BaseURL != "" {
@@ -58,6 +60,19 @@ func NewClient(cfg *config.ClaudeConfig, logger zerolog.Logger) (*Client, error)
 	}, nil
 }
 
+// stopSequenceLimits returns the configured stop sequence limits, using the defaults
+// for those left unset
+func (c *Client) stopSequenceLimits() vo.StopSequenceLimits {
+	limits := vo.DefaultStopSequenceLimits()
+	if c.config.MaxStopSequences > 0 {
+		limits.MaxCount = c.config.MaxStopSequences
+	}
+	if c.config.MaxStopSequenceLength > 0 {
+		limits.MaxLength = c.config.MaxStopSequenceLength
+	}
+	return limits
+}
+
 // CreateMessage creates a message (non-streaming)
 func (c *Client) CreateMessage(ctx context.Context, request *services.ClaudeRequest) (*services.ClaudeResponse, error) {
 	if err := c.ValidateRequest(request); err != nil {
@@ -76,11 +91,14 @@ func (c *Client) CreateMessage(ctx context.Context, request *services.ClaudeRequ
 	// Execute with retry
 	var response *anthropic.Message
 	var err error
+	var wait time.Duration
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			c.logger.Debug().Int("attempt", attempt).Msg("Retrying API request")
-			time.Sleep(c.config.RetryDelay * time.Duration(attempt))
+			c.logger.Debug().Int("attempt", attempt).Dur("wait", wait).Msg("Retrying API request")
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrContextCancelled, err)
+			}
 		}
 
 		response, err = c.client.Messages.New(ctx, params)
@@ -88,20 +106,31 @@ func (c *Client) CreateMessage(ctx context.Context, request *services.ClaudeRequ
 			break
 		}
 
+		// The caller gave up; the SDK has already aborted the HTTP request
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())
+		}
+
+		err = classifyError(err)
+		c.logAPIError(err, attempt)
+
 		// Check if error is retryable
 		if !c.isRetryableError(err) {
-			return nil, fmt.Errorf("%w: %v", ErrAPIError, err)
+			return nil, apiFailure(err)
 		}
+		wait = c.retryDelay(err, attempt+1)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrMaxRetriesExceeded, err)
+		return nil, fmt.Errorf("%w: %w", ErrMaxRetriesExceeded, apiFailure(err))
 	}
 
 	return c.convertResponse(response), nil
 }
 
-// CreateMessageStream creates a message with streaming
+// CreateMessageStream creates a message with streaming. A stream that fails before its
+// first event is retried like CreateMessage; once events have been delivered they
+// cannot be taken back, so a later failure ends the stream with an error event.
 func (c *Client) CreateMessageStream(ctx context.Context, request *services.ClaudeRequest) (<-chan *services.ClaudeStreamEvent, error) {
 	if err := c.ValidateRequest(request); err != nil {
 		return nil, err
@@ -119,29 +148,67 @@ func (c *Client) CreateMessageStream(ctx context.Context, request *services.Clau
 	go func() {
 		defer close(eventChan)
 
-		stream := c.client.Messages.NewStreaming(ctx, params)
-
-		for stream.Next() {
-			event := stream.Current()
-			streamEvent := c.convertStreamEvent(event)
-			if streamEvent != nil {
-				select {
-				case eventChan <- streamEvent:
-				case <-ctx.Done():
-					eventChan <- &services.ClaudeStreamEvent{Error: ctx.Err()}
+		var err error
+		var wait time.Duration
+		for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+			if attempt > 0 {
+				c.logger.Debug().Int("attempt", attempt).Dur("wait", wait).Msg("Retrying streaming API request")
+				if err := sleepContext(ctx, wait); err != nil {
+					eventChan <- &services.ClaudeStreamEvent{Error: fmt.Errorf("%w: %v", ErrContextCancelled, err)}
 					return
 				}
 			}
-		}
 
-		if err := stream.Err(); err != nil {
-			eventChan <- &services.ClaudeStreamEvent{Error: err}
+			var delivered bool
+			delivered, err = c.stream(ctx, params, eventChan)
+			if err == nil {
+				return
+			}
+
+			// The caller gave up; the SDK has already aborted the HTTP request
+			if ctx.Err() != nil {
+				eventChan <- &services.ClaudeStreamEvent{Error: fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())}
+				return
+			}
+
+			err = classifyError(err)
+			c.logAPIError(err, attempt)
+
+			if delivered || !c.isRetryableError(err) {
+				eventChan <- &services.ClaudeStreamEvent{Error: apiFailure(err)}
+				return
+			}
+			wait = c.retryDelay(err, attempt+1)
 		}
+
+		eventChan <- &services.ClaudeStreamEvent{Error: fmt.Errorf("%w: %w", ErrMaxRetriesExceeded, apiFailure(err))}
 	}()
 
 	return eventChan, nil
 }
 
+// stream runs one streaming request, forwarding its events to eventChan. It reports
+// whether any event was delivered along with the error that ended the stream.
+func (c *Client) stream(ctx context.Context, params anthropic.MessageNewParams, eventChan chan<- *services.ClaudeStreamEvent) (bool, error) {
+	stream := c.client.Messages.NewStreaming(ctx, params)
+	defer func() { _ = stream.Close() }()
+
+	delivered := false
+	for stream.Next() {
+		streamEvent := c.convertStreamEvent(stream.Current())
+		if streamEvent == nil {
+			continue
+		}
+		select {
+		case eventChan <- streamEvent:
+			delivered = true
+		case <-ctx.Done():
+			return delivered, ctx.Err()
+		}
+	}
+	return delivered, stream.Err()
+}
+
 // CountTokens counts tokens for a message
 func (c *Client) CountTokens(ctx context.Context, request *services.ClaudeRequest) (int, error) {
 	if err := c.ValidateRequest(request); err != nil {
@@ -166,7 +233,12 @@ func (c *Client) CountTokens(ctx context.Context, request *services.ClaudeReques
 
 	result, err := c.client.Messages.CountTokens(ctx, params)
 	if err != nil {
-		return 0, fmt.Errorf("%w: %v", ErrAPIError, err)
+		if ctx.Err() != nil {
+			return 0, fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())
+		}
+		err = classifyError(err)
+		c.logAPIError(err, 0)
+		return 0, apiFailure(err)
 	}
 
 	return int(result.InputTokens), nil
@@ -186,6 +258,10 @@ func (c *Client) ValidateRequest(request *services.ClaudeRequest) error {
 		return fmt.Errorf("%w: messages required", ErrInvalidRequest)
 	}
 
+	if err := c.stopSequenceLimits().Validate(request.StopSequences); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
 	if request.MaxTokens <= 0 {
 		request.MaxTokens = c.config.MaxTokens
 	}
@@ -233,6 +309,9 @@ func (c *Client) buildMessageParams(request *services.ClaudeRequest) anthropic.M
 	// Tools
 	if len(request.Tools) > 0 {
 		params.Tools = c.buildTools(request.Tools)
+		if request.ToolChoice != nil {
+			params.ToolChoice = buildToolChoice(request.ToolChoice)
+		}
 	}
 
 	return params
@@ -259,11 +338,7 @@ func (c *Client) buildMessages(messages []services.ClaudeMessage) []anthropic.Me
 				))
 
 			case vo.ContentTypeToolResult:
-				content = append(content, anthropic.NewToolResultBlock(
-					block.ToolUseID,
-					block.Content,
-					block.IsError,
-				))
+				content = append(content, buildToolResultBlock(block))
 			}
 		}
 
@@ -276,6 +351,49 @@ func (c *Client) buildMessages(messages []services.ClaudeMessage) []anthropic.Me
 	return result
 }
 
+// buildToolResultBlock builds a tool_result block. A result with several items is sent
+// as its text and image blocks, in order, instead of its text alone.
+func buildToolResultBlock(block entities.ContentBlock) anthropic.ContentBlockParamUnion {
+	if len(block.ResultContent) == 0 {
+		return anthropic.NewToolResultBlock(block.ToolUseID, block.Content, block.IsError)
+	}
+
+	param := anthropic.ToolResultBlockParam{
+		ToolUseID: block.ToolUseID,
+		IsError:   anthropic.Bool(block.IsError),
+	}
+	for _, item := range block.ResultContent {
+		switch item.Type {
+		case vo.ContentTypeText:
+			param.Content = append(param.Content, anthropic.ToolResultBlockParamContentUnion{
+				OfRequestTextBlock: &anthropic.TextBlockParam{Text: item.Text},
+			})
+		case vo.ContentTypeImage:
+			if item.Source != nil {
+				param.Content = append(param.Content, anthropic.ToolResultBlockParamContentUnion{
+					OfRequestImageBlock: buildImageBlock(item.Source),
+				})
+			}
+		}
+	}
+	return anthropic.ContentBlockParamUnion{OfRequestToolResultBlock: &param}
+}
+
+// buildImageBlock builds an API image block from an image source
+func buildImageBlock(source *entities.ImageSource) *anthropic.ImageBlockParam {
+	if source.Type == "url" {
+		return &anthropic.ImageBlockParam{Source: anthropic.ImageBlockParamSourceUnion{
+			OfURLImageSource: &anthropic.URLImageSourceParam{URL: source.URL},
+		}}
+	}
+	return &anthropic.ImageBlockParam{Source: anthropic.ImageBlockParamSourceUnion{
+		OfBase64ImageSource: &anthropic.Base64ImageSourceParam{
+			Data:      source.Data,
+			MediaType: anthropic.Base64ImageSourceMediaType(source.MediaType),
+		},
+	}}
+}
+
 // buildTools builds API tools from domain tools
 func (c *Client) buildTools(tools []services.ClaudeTool) []anthropic.ToolUnionParam {
 	result := make([]anthropic.ToolUnionParam, len(tools))
@@ -293,6 +411,20 @@ func (c *Client) buildTools(tools []services.ClaudeTool) []anthropic.ToolUnionPa
 	return result
 }
 
+// buildToolChoice maps a domain tool choice to the API union
+func buildToolChoice(choice *services.ClaudeToolChoice) anthropic.ToolChoiceUnionParam {
+	switch choice.Type {
+	case vo.ToolChoiceAny:
+		return anthropic.ToolChoiceUnionParam{OfToolChoiceAny: &anthropic.ToolChoiceAnyParam{}}
+	case vo.ToolChoiceTool:
+		return anthropic.ToolChoiceParamOfToolChoiceTool(choice.Name)
+	case vo.ToolChoiceNone:
+		return anthropic.ToolChoiceUnionParam{OfToolChoiceNone: &anthropic.ToolChoiceNoneParam{}}
+	default:
+		return anthropic.ToolChoiceUnionParam{OfToolChoiceAuto: &anthropic.ToolChoiceAutoParam{}}
+	}
+}
+
 // convertJSONSchema converts domain JSON schema to API format
 func (c *Client) convertJSONSchema(schema *entities.JSONSchema) anthropic.ToolInputSchemaParam {
 	if schema == nil {
@@ -358,12 +490,13 @@ func (c *Client) convertResponse(msg *anthropic.Message) *services.ClaudeRespons
 	}
 
 	return &services.ClaudeResponse{
-		ID:         msg.ID,
-		Type:       string(msg.Type),
-		Role:       vo.RoleAssistant,
-		Content:    content,
-		Model:      string(msg.Model),
-		StopReason: string(msg.StopReason),
+		ID:           msg.ID,
+		Type:         string(msg.Type),
+		Role:         vo.RoleAssistant,
+		Content:      content,
+		Model:        string(msg.Model),
+		StopReason:   string(msg.StopReason),
+		StopSequence: msg.StopSequence,
 		Usage: &services.ClaudeUsage{
 			InputTokens:  int(msg.Usage.InputTokens),
 			OutputTokens: int(msg.Usage.OutputTokens),
@@ -382,6 +515,10 @@ func (c *Client) convertStreamEvent(event anthropic.MessageStreamEventUnion) *se
 					ID:    event.Message.ID,
 					Model: string(event.Message.Model),
 					Role:  vo.RoleAssistant,
+					// Output tokens follow in message_delta
+					Usage: &services.ClaudeUsage{
+						InputTokens: int(event.Message.Usage.InputTokens),
+					},
 				},
 			}
 		}
@@ -416,16 +553,24 @@ func (c *Client) convertStreamEvent(event anthropic.MessageStreamEventUnion) *se
 			Type:  event.Type,
 			Index: int(event.Index),
 			Delta: &services.ClaudeDelta{
-				Type: delta.Type,
-				Text: delta.Text,
+				Type:        delta.Type,
+				Text:        delta.Text,
+				PartialJSON: delta.PartialJSON,
 			},
 		}
 
+	case "content_block_stop":
+		return &services.ClaudeStreamEvent{
+			Type:  event.Type,
+			Index: int(event.Index),
+		}
+
 	case "message_delta":
 		return &services.ClaudeStreamEvent{
 			Type: event.Type,
 			Delta: &services.ClaudeDelta{
-				StopReason: string(event.Delta.StopReason),
+				StopReason:   string(event.Delta.StopReason),
+				StopSequence: event.Delta.StopSequence,
 			},
 			Usage: &services.ClaudeUsage{
 				OutputTokens: int(event.Usage.OutputTokens),
@@ -441,9 +586,56 @@ func (c *Client) convertStreamEvent(event anthropic.MessageStreamEventUnion) *se
 	return nil
 }
 
-// isRetryableError checks if an error is retryable
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isRetryableError checks if an error is retryable: rate limits, overloads and
+// server-side failures are, anything the API did not answer with a typed error is not
 func (c *Client) isRetryableError(err error) bool {
-	// Check for rate limiting or temporary errors
-	// This would need to inspect the actual error type from the SDK
-	return false
+	claudeErr, ok := services.AsClaudeError(err)
+	return ok && claudeErr.Retryable()
+}
+
+// retryDelay returns how long to wait before the given retry, honouring the
+// API's retry-after hint for rate limits
+func (c *Client) retryDelay(err error, retry int) time.Duration {
+	var rateLimited *services.RateLimitError
+	if errors.As(err, &rateLimited) && rateLimited.RetryAfter > 0 {
+		return rateLimited.RetryAfter
+	}
+	return c.config.RetryDelay * time.Duration(retry)
+}
+
+// logAPIError logs a failed API call with its error category
+func (c *Client) logAPIError(err error, attempt int) {
+	event := c.logger.Warn().Err(err).Int("attempt", attempt)
+	if claudeErr, ok := services.AsClaudeError(err); ok {
+		response := claudeErr.Response()
+		event = event.
+			Str("error_category", string(claudeErr.Category())).
+			Str("error_type", response.Type).
+			Int("status", response.StatusCode).
+			Str("request_id", response.RequestID).
+			Bool("retryable", claudeErr.Retryable())
+	}
+	event.Msg("Claude API request failed")
+}
+
+// apiFailure wraps a final API error in the client's sentinel errors, keeping the
+// typed error in the chain
+func apiFailure(err error) error {
+	var rateLimited *services.RateLimitError
+	if errors.As(err, &rateLimited) {
+		return fmt.Errorf("%w: %w", ErrRateLimited, err)
+	}
+	return fmt.Errorf("%w: %w", ErrAPIError, err)
 }
@@ -0,0 +1,115 @@
+package claude
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+)
+
+// statusOverloaded is the non-standard status the API returns when it is overloaded
+const statusOverloaded = 529
+
+// apiErrorBody is the body of an Anthropic error response
+type apiErrorBody struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// classifyError converts an SDK error carrying an API error response into the
+// matching typed error from the services package. Other errors, such as network
+// failures, are returned unchanged.
+func classifyError(err error) error {
+	var apiErr *anthropic.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	var header http.Header
+	if apiErr.Response != nil {
+		header = apiErr.Response.Header
+	}
+	return newAPIError(apiErr.StatusCode, header, []byte(apiErr.RawJSON()))
+}
+
+// newAPIError builds the typed error for an error response. The error type in the
+// body decides the kind; the status code is the fallback when the body has none.
+func newAPIError(status int, header http.Header, body []byte) services.ClaudeError {
+	var parsed apiErrorBody
+	_ = json.Unmarshal(body, &parsed)
+
+	base := services.APIError{
+		StatusCode: status,
+		Type:       parsed.Error.Type,
+		Message:    parsed.Error.Message,
+		RequestID:  header.Get("request-id"),
+	}
+	if base.Message == "" {
+		base.Message = http.StatusText(status)
+	}
+
+	errorType := base.Type
+	if errorType == "" {
+		errorType = errorTypeForStatus(status)
+	}
+
+	switch errorType {
+	case "authentication_error", "permission_error":
+		return &services.AuthError{APIError: base}
+	case "rate_limit_error":
+		return &services.RateLimitError{APIError: base, RetryAfter: parseRetryAfter(header.Get("retry-after"))}
+	case "overloaded_error":
+		return &services.OverloadError{APIError: base}
+	case "invalid_request_error", "not_found_error", "request_too_large":
+		return &services.InvalidRequestError{APIError: base}
+	default:
+		return &base
+	}
+}
+
+// errorTypeForStatus returns the API error type documented for a status code
+func errorTypeForStatus(status int) string {
+	switch status {
+	case http.StatusUnauthorized:
+		return "authentication_error"
+	case http.StatusForbidden:
+		return "permission_error"
+	case http.StatusTooManyRequests:
+		return "rate_limit_error"
+	case statusOverloaded:
+		return "overloaded_error"
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return "invalid_request_error"
+	case http.StatusNotFound:
+		return "not_found_error"
+	case http.StatusRequestEntityTooLarge:
+		return "request_too_large"
+	default:
+		return "api_error"
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/logging"
+)
+
+// RedactedValue replaces sensitive values in configuration dumps
+const RedactedValue = "[REDACTED]"
+
+// Audit returns the effective configuration with secrets redacted, along with
+// the keys whose values were overridden by environment variables
+func (c *Config) Audit() map[string]interface{} {
+	return map[string]interface{}{
+		"config":        c.Redacted(),
+		"env_overrides": c.EnvOverrides(),
+	}
+}
+
+// Redacted returns the configuration as a map keyed by config keys with secrets redacted
+func (c *Config) Redacted() map[string]interface{} {
+	return structToMap(reflect.ValueOf(*c))
+}
+
+// EnvOverrides returns the config keys that were set from environment variables, sorted
+func (c *Config) EnvOverrides() []string {
+	overrides := make([]string, 0)
+	for _, key := range flattenKeys("", c.Redacted()) {
+		if envOverrideSet(key) {
+			overrides = append(overrides, key)
+		}
+	}
+	sort.Strings(overrides)
+	return overrides
+}
+
+// envOverrideSet reports whether an environment variable overrides the given key
+func envOverrideSet(key string) bool {
+	names := []string{envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))}
+	for _, binding := range envBindings {
+		if binding.key == key {
+			names = append(names, binding.envs...)
+		}
+	}
+	for _, name := range names {
+		if _, ok := os.LookupEnv(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// structToMap converts a config struct to a map using its mapstructure tags
+func structToMap(v reflect.Value) map[string]interface{} {
+	result := make(map[string]interface{})
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		if logging.IsSensitiveField(key, logging.DefaultSensitiveFields) {
+			if !v.Field(i).IsZero() {
+				result[key] = RedactedValue
+			} else {
+				result[key] = v.Field(i).Interface()
+			}
+			continue
+		}
+
+		switch value := v.Field(i).Interface().(type) {
+		case time.Duration:
+			result[key] = value.String()
+		default:
+			if field.Type.Kind() == reflect.Struct {
+				result[key] = structToMap(v.Field(i))
+			} else {
+				result[key] = value
+			}
+		}
+	}
+	return result
+}
+
+// flattenKeys returns the dotted keys of all leaf values in a nested map
+func flattenKeys(prefix string, m map[string]interface{}) []string {
+	var keys []string
+	for key, value := range m {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			keys = append(keys, flattenKeys(fullKey, nested)...)
+			continue
+		}
+		keys = append(keys, fullKey)
+	}
+	return keys
+}
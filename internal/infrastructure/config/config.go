@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
 )
 
 // Config holds all configuration for the MCP server
@@ -30,6 +32,15 @@ type Config struct {
 
 	// Security configuration
 	Security SecurityConfig `mapstructure:"security"`
+
+	// Queue configuration
+	Queue QueueConfig `mapstructure:"queue"`
+
+	// Database configuration
+	Database DatabaseConfig `mapstructure:"database"`
+
+	// Persistence configuration
+	Persistence PersistenceConfig `mapstructure:"persistence"`
 }
 
 // ServerConfig holds server-related configuration
@@ -42,28 +53,69 @@ type ServerConfig struct {
 	// Transport type: "stdio", "sse", "websocket"
 	Transport string `mapstructure:"transport"`
 
-	// Timeouts
+	// Timeouts. In-flight work still running ShutdownTimeout after shutdown starts
+	// is terminated (0 waits for it indefinitely).
 	ReadTimeout     time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 
+	// Upper bound for a client-requested deadline in a request's _meta.timeoutMs (0 means no cap)
+	MaxRequestTimeout time.Duration `mapstructure:"max_request_timeout"`
+
+	// Maximum number of non-closed sessions (0 means unlimited)
+	MaxSessions int `mapstructure:"max_sessions"`
+
+	// Requests handled concurrently (1 handles requests serially in arrival order)
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+
+	// Requests that may wait for a worker before new ones are rejected as busy
+	RequestQueueDepth int `mapstructure:"request_queue_depth"`
+
+	// Expose the redacted effective configuration as a resource
+	ExposeConfig bool `mapstructure:"expose_config"`
+
+	// Enable admin-scoped methods such as admin/setToolEnabled, tools/update and session/reset,
+	// and honour the scopes that tools such as list_processes require. The stdio client holds
+	// the admin scope; SSE and WebSocket clients hold the scopes of the issued API key they
+	// connected with.
+	EnableAdminMethods bool `mapstructure:"enable_admin_methods"`
+
+	// Safe mode disables every tool not annotated read-only, such as write_file and
+	// execute_command, and rejects calls to them, for demos and untrusted clients
+	SafeMode bool `mapstructure:"safe_mode"`
+
 	// Debug mode
 	Debug bool `mapstructure:"debug"`
 }
 
 // ClaudeConfig holds Claude API configuration
 type ClaudeConfig struct {
-	APIKey         string        `mapstructure:"api_key"`
-	BaseURL        string        `mapstructure:"base_url"`
-	DefaultModel   string        `mapstructure:"default_model"`
-	MaxTokens      int           `mapstructure:"max_tokens"`
-	Temperature    float64       `mapstructure:"temperature"`
-	TopP           float64       `mapstructure:"top_p"`
-	TopK           int           `mapstructure:"top_k"`
-	Timeout        time.Duration `mapstructure:"timeout"`
-	MaxRetries     int           `mapstructure:"max_retries"`
-	RetryDelay     time.Duration `mapstructure:"retry_delay"`
-	EnableBatching bool          `mapstructure:"enable_batching"`
+	APIKey            string        `mapstructure:"api_key"`
+	BaseURL           string        `mapstructure:"base_url"`
+	DefaultModel      string        `mapstructure:"default_model"`
+	MaxTokens         int           `mapstructure:"max_tokens"`
+	MaxTokensLimit    int           `mapstructure:"max_tokens_limit"`
+	ClampMaxTokens    bool          `mapstructure:"clamp_max_tokens"`
+	MaxResponseChars  int           `mapstructure:"max_response_chars"`
+	MaxToolIterations int           `mapstructure:"max_tool_iterations"`
+	Temperature       float64       `mapstructure:"temperature"`
+	TopP              float64       `mapstructure:"top_p"`
+	TopK              int           `mapstructure:"top_k"`
+	Timeout           time.Duration `mapstructure:"timeout"`
+	MaxRetries        int           `mapstructure:"max_retries"`
+	RetryDelay        time.Duration `mapstructure:"retry_delay"`
+	EnableBatching    bool          `mapstructure:"enable_batching"`
+
+	// Bytes of streamed tool output kept in a conversation's tool_result (0 disables the cap)
+	MaxStreamedToolOutput int `mapstructure:"max_streamed_tool_output"`
+
+	// Stop sequences a request may carry, and the characters in each
+	MaxStopSequences      int `mapstructure:"max_stop_sequences"`
+	MaxStopSequenceLength int `mapstructure:"max_stop_sequence_length"`
+
+	// Stream claude_conversation replies from the API, forwarding the text as progress
+	// notifications when the caller sent a progress token; a call's stream input overrides it
+	StreamResponses bool `mapstructure:"stream_responses"`
 }
 
 // MCPConfig holds MCP protocol configuration
@@ -86,6 +138,50 @@ type MCPConfig struct {
 
 	// Tool execution
 	ToolTimeout time.Duration `mapstructure:"tool_timeout"`
+
+	// Kill execute_command processes that write no output for this long (0 disables)
+	ExecIdleTimeout time.Duration `mapstructure:"exec_idle_timeout"`
+
+	// Most bytes a read_file call returns before truncating (0 disables the cap)
+	ReadFileMaxBytes int64 `mapstructure:"read_file_max_bytes"`
+
+	// Manifest files declaring exec/http tools registered alongside the built-ins
+	ToolManifests []string `mapstructure:"tool_manifests"`
+
+	// Commands manifest exec tools may run, matched exactly as the manifest names them
+	// (empty rejects every exec tool)
+	ManifestCommands []string `mapstructure:"manifest_commands"`
+
+	// Built-in tools registered disabled. When EnabledTools is not empty, only the
+	// built-ins it names are enabled.
+	EnabledTools  []string `mapstructure:"enabled_tools"`
+	DisabledTools []string `mapstructure:"disabled_tools"`
+
+	// Window for coalescing notifications/resources/updated per URI (0 sends each update)
+	ResourceUpdateDebounce time.Duration `mapstructure:"resource_update_debounce"`
+
+	// Batch tool calls (tools/callBatch)
+	MaxBatchSize     int `mapstructure:"max_batch_size"`
+	BatchConcurrency int `mapstructure:"batch_concurrency"`
+
+	// Most recent domain events kept for the status://events resource (0 keeps every event)
+	EventLogSize int `mapstructure:"event_log_size"`
+
+	// Largest JSON-RPC response sent to a client; larger results are replaced with an error (0 disables)
+	MaxResponseBytes int `mapstructure:"max_response_bytes"`
+
+	// File-backed resources larger than ResourceStreamThreshold bytes are streamed as
+	// progress notifications of ResourceStreamChunkSize bytes on transports that support
+	// it, when the client sends a progress token (0 disables streaming)
+	ResourceStreamThreshold int64 `mapstructure:"resource_stream_threshold"`
+	ResourceStreamChunkSize int   `mapstructure:"resource_stream_chunk_size"`
+
+	// Handling of notification methods sent with an id: "request", "error", or "ignore"
+	NotificationIDPolicy string `mapstructure:"notification_id_policy"`
+
+	// File the experimental/capabilitiesDiff fingerprint history is saved to, so it
+	// survives restarts (empty keeps it in memory)
+	CapabilityHistoryFile string `mapstructure:"capability_history_file"`
 }
 
 // LoggingConfig holds logging configuration
@@ -111,6 +207,13 @@ type TelemetryConfig struct {
 	// Metrics
 	MetricsEnabled  bool          `mapstructure:"metrics_enabled"`
 	MetricsInterval time.Duration `mapstructure:"metrics_interval"`
+
+	// Where telemetry goes: "otlp", "file" or "both". The file modes write JSON
+	// lines to ExportFile, rotated at ExportFileMaxBytes keeping ExportFileMaxBackups.
+	ExportMode           string `mapstructure:"export_mode"`
+	ExportFile           string `mapstructure:"export_file"`
+	ExportFileMaxBytes   int64  `mapstructure:"export_file_max_bytes"`
+	ExportFileMaxBackups int    `mapstructure:"export_file_max_backups"`
 }
 
 // SecurityConfig holds security configuration
@@ -123,50 +226,155 @@ type SecurityConfig struct {
 	RateLimitEnabled   bool `mapstructure:"rate_limit_enabled"`
 	RateLimitPerMinute int  `mapstructure:"rate_limit_per_minute"`
 
-	// CORS (for SSE transport)
+	// Per-session limits on calls to individual tools, as calls per window by tool name
+	ToolRateLimits      map[string]int `mapstructure:"tool_rate_limits"`
+	ToolRateLimitWindow time.Duration  `mapstructure:"tool_rate_limit_window"`
+
+	// Origins browser clients of the SSE and WebSocket transports may connect from,
+	// besides the server's own ("*" is ignored)
 	CORSEnabled        bool     `mapstructure:"cors_enabled"`
 	CORSAllowedOrigins []string `mapstructure:"cors_allowed_origins"`
+
+	// Resource read MIME type filtering
+	AllowedMimeTypes []string `mapstructure:"allowed_mime_types"`
+	DeniedMimeTypes  []string `mapstructure:"denied_mime_types"`
+
+	// Expose the list_processes tool; calling it also needs server.enable_admin_methods
+	EnableProcessList bool `mapstructure:"enable_process_list"`
+
+	// Directories file tools and file resource reads may access, narrowed further by
+	// the client's roots (empty allows all)
+	AllowedPaths []string `mapstructure:"allowed_paths"`
+
+	// Symlink handling for file tools: "follow", "deny", or "resolve-and-check"
+	SymlinkPolicy string `mapstructure:"symlink_policy"`
+
+	// Expose the read-only db_query tool; needs database.enabled, and calling it
+	// also needs server.enable_admin_methods and a caller holding the admin scope, as
+	// the tables hold every client's conversations
+	EnableDBQuery bool `mapstructure:"enable_db_query"`
+
+	// Tables db_query may read (empty uses the built-in list), and its row and time limits
+	DBQueryTables  []string      `mapstructure:"db_query_tables"`
+	DBQueryMaxRows int           `mapstructure:"db_query_max_rows"`
+	DBQueryTimeout time.Duration `mapstructure:"db_query_timeout"`
+
+	// Key signing the resume tokens claude_conversation returns (empty uses a random
+	// key, so tokens stop working on restart), and how long a token stays valid
+	ResumeTokenSecret string        `mapstructure:"resume_token_secret"`
+	ResumeTokenTTL    time.Duration `mapstructure:"resume_token_ttl"`
+
+	// How long past its expiry a resume token is still accepted, allowing for replica
+	// clocks that disagree
+	ClockSkewTolerance time.Duration `mapstructure:"clock_skew_tolerance"`
+}
+
+// QueueConfig holds NATS queue configuration
+type QueueConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	URL     string `mapstructure:"url"`
+
+	// Required fails startup when NATS is unreachable instead of running degraded
+	Required bool `mapstructure:"required"`
+
+	// MaxDeliver is the maximum number of delivery attempts per task
+	MaxDeliver int `mapstructure:"max_deliver"`
+
+	// UpdateStreams overwrites existing streams whose configuration differs
+	// instead of failing startup on incompatible differences
+	UpdateStreams bool `mapstructure:"update_streams"`
+
+	// LockSkewTolerance is how long past its expiry a distributed lock is still
+	// treated as held, allowing for replica clocks that disagree
+	LockSkewTolerance time.Duration `mapstructure:"lock_skew_tolerance"`
+}
+
+// DatabaseConfig holds PostgreSQL configuration
+type DatabaseConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	Database string `mapstructure:"database"`
+	SSLMode  string `mapstructure:"ssl_mode"`
+
+	// Connection pool
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	// Logging level: "silent", "error", "warn", or "info"
+	LogLevel string `mapstructure:"log_level"`
+}
+
+// PersistenceConfig selects where sessions, conversations and tools are stored
+type PersistenceConfig struct {
+	// Driver is "memory", which keeps them for the life of the process, or
+	// "postgres", which stores them in the database configured under database
+	Driver string `mapstructure:"driver"`
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Name:            "TelemetryFlow-MCP",
-			Version:         "1.1.2",
-			Host:            "localhost",
-			Port:            8080,
-			Transport:       "stdio",
-			ReadTimeout:     30 * time.Second,
-			WriteTimeout:    30 * time.Second,
-			ShutdownTimeout: 10 * time.Second,
-			Debug:           false,
+			Name:              "TelemetryFlow-MCP",
+			Version:           "1.1.2",
+			Host:              "localhost",
+			Port:              8080,
+			Transport:         "stdio",
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			ShutdownTimeout:   10 * time.Second,
+			MaxRequestTimeout: 5 * time.Minute,
+			MaxSessions:       100,
+			Debug:             false,
+
+			MaxConcurrentRequests: 1,
+			RequestQueueDepth:     64,
 		},
 		Claude: ClaudeConfig{
-			BaseURL:        "https://api.anthropic.com",
-			DefaultModel:   "claude-sonnet-4-20250514",
-			MaxTokens:      4096,
-			Temperature:    1.0,
-			TopP:           1.0,
-			TopK:           0,
-			Timeout:        120 * time.Second,
-			MaxRetries:     3,
-			RetryDelay:     1 * time.Second,
-			EnableBatching: false,
+			BaseURL:               "https://api.anthropic.com",
+			DefaultModel:          "claude-sonnet-4-20250514",
+			MaxTokens:             4096,
+			MaxResponseChars:      100000,
+			MaxToolIterations:     25,
+			MaxStreamedToolOutput: 64 * 1024,
+			MaxStopSequences:      8,
+			MaxStopSequenceLength: 128,
+			StreamResponses:       true,
+			Temperature:           1.0,
+			TopP:                  1.0,
+			TopK:                  0,
+			Timeout:               120 * time.Second,
+			MaxRetries:            3,
+			RetryDelay:            1 * time.Second,
+			EnableBatching:        false,
 		},
 		MCP: MCPConfig{
-			ProtocolVersion:        "2024-11-05",
-			EnableTools:            true,
-			EnableResources:        true,
-			EnablePrompts:          true,
-			EnableLogging:          true,
-			EnableSampling:         false,
-			MaxToolsPerSession:     100,
-			MaxResourcesPerSession: 100,
-			MaxPromptsPerSession:   50,
-			MaxConversations:       10,
-			MaxMessagesPerConv:     1000,
-			ToolTimeout:            30 * time.Second,
+			ProtocolVersion:         "2024-11-05",
+			EnableTools:             true,
+			EnableResources:         true,
+			EnablePrompts:           true,
+			EnableLogging:           true,
+			EnableSampling:          false,
+			MaxToolsPerSession:      100,
+			MaxResourcesPerSession:  100,
+			MaxPromptsPerSession:    50,
+			MaxConversations:        10,
+			MaxMessagesPerConv:      1000,
+			ToolTimeout:             30 * time.Second,
+			ReadFileMaxBytes:        1 << 20,
+			ResourceUpdateDebounce:  250 * time.Millisecond,
+			MaxBatchSize:            20,
+			BatchConcurrency:        4,
+			EventLogSize:            1000,
+			MaxResponseBytes:        10 << 20,
+			ResourceStreamThreshold: 1 << 20,
+			ResourceStreamChunkSize: 256 << 10,
+			NotificationIDPolicy:    "request",
 		},
 		Logging: LoggingConfig{
 			Level:      "info",
@@ -184,50 +392,83 @@ func DefaultConfig() *Config {
 			TraceSampleRate: 1.0,
 			MetricsEnabled:  true,
 			MetricsInterval: 30 * time.Second,
+			ExportMode:      telemetry.ExportModeOTLP,
 		},
 		Security: SecurityConfig{
-			RequireAPIKey:      false,
-			RateLimitEnabled:   true,
-			RateLimitPerMinute: 100,
-			CORSEnabled:        true,
-			CORSAllowedOrigins: []string{"*"},
+			RequireAPIKey:       false,
+			RateLimitEnabled:    true,
+			RateLimitPerMinute:  100,
+			ToolRateLimitWindow: time.Minute,
+			CORSEnabled:         true,
+			CORSAllowedOrigins:  []string{"*"},
+			SymlinkPolicy:       "resolve-and-check",
+			DBQueryMaxRows:      100,
+			DBQueryTimeout:      5 * time.Second,
+			ResumeTokenTTL:      24 * time.Hour,
+			ClockSkewTolerance:  5 * time.Second,
+		},
+		Queue: QueueConfig{
+			Enabled:           false,
+			URL:               "nats://localhost:4222",
+			Required:          false,
+			MaxDeliver:        3,
+			LockSkewTolerance: time.Second,
+		},
+		Database: DatabaseConfig{
+			Enabled:         false,
+			Host:            "localhost",
+			Port:            5432,
+			User:            "telemetryflow",
+			Database:        "telemetryflow_mcp",
+			SSLMode:         "disable",
+			MaxIdleConns:    10,
+			MaxOpenConns:    100,
+			ConnMaxLifetime: time.Hour,
+			ConnMaxIdleTime: 10 * time.Minute,
+			LogLevel:        "warn",
+		},
+		Persistence: PersistenceConfig{
+			Driver: "memory",
 		},
 	}
 }
 
-// Load loads configuration from files and environment
+// Load loads configuration from files and environment. The file format is taken
+// from the file extension.
 func Load(configPath string) (*Config, error) {
+	return LoadWithFormat(configPath, "")
+}
+
+// LoadWithFormat loads configuration like Load, reading the config file as format
+// (yaml, json or toml) when it is not empty. Without a path, config.yaml, config.yml,
+// config.json or config.toml is looked up in the standard locations.
+func LoadWithFormat(configPath, format string) (*Config, error) {
 	config := DefaultConfig()
 
 	v := viper.New()
-	v.SetConfigType("yaml")
 
-	// Set config file if provided
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-	} else {
-		// Look for config in standard locations
-		v.SetConfigName("config")
-		v.AddConfigPath(".")
-		v.AddConfigPath("./configs")
-		v.AddConfigPath("/etc/telemetryflow-go-mcp")
-		v.AddConfigPath("$HOME/.telemetryflow-go-mcp")
+	// Look for config in standard locations
+	if configPath == "" {
+		configPath = findConfigFile()
 	}
 
 	// Environment variable settings
-	v.SetEnvPrefix("TELEMETRYFLOW_MCP")
+	v.SetEnvPrefix(envPrefix)
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
 
 	// Bind specific environment variables
 	bindEnvVars(v)
 
-	// Read config file
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("error reading config file: %w", err)
+	// Read config file; without one, use defaults and env vars
+	if configPath != "" {
+		resolved, err := ResolveFormat(configPath, format)
+		if err != nil {
+			return nil, err
+		}
+		if err := readConfigFile(v, configPath, resolved); err != nil {
+			return nil, err
 		}
-		// Config file not found, use defaults and env vars
 	}
 
 	// Unmarshal config
@@ -251,27 +492,52 @@ func Load(configPath string) (*Config, error) {
 	return config, nil
 }
 
-// bindEnvVars binds environment variables to config keys
-func bindEnvVars(v *viper.Viper) {
-	// Claude API (errors ignored as BindEnv only fails on empty key names)
-	_ = v.BindEnv("claude.api_key", "ANTHROPIC_API_KEY", "TELEMETRYFLOW_MCP_CLAUDE_API_KEY")
-	_ = v.BindEnv("claude.base_url", "TELEMETRYFLOW_MCP_CLAUDE_BASE_URL")
-	_ = v.BindEnv("claude.default_model", "TELEMETRYFLOW_MCP_CLAUDE_DEFAULT_MODEL")
+// envPrefix is the prefix for automatic environment variable overrides
+const envPrefix = "TELEMETRYFLOW_MCP"
+
+// envBinding maps a config key to the environment variables that override it
+type envBinding struct {
+	key  string
+	envs []string
+}
+
+// envBindings lists the explicit environment variable bindings
+var envBindings = []envBinding{
+	// Claude API
+	{"claude.api_key", []string{"ANTHROPIC_API_KEY", "TELEMETRYFLOW_MCP_CLAUDE_API_KEY"}},
+	{"claude.base_url", []string{"TELEMETRYFLOW_MCP_CLAUDE_BASE_URL"}},
+	{"claude.default_model", []string{"TELEMETRYFLOW_MCP_CLAUDE_DEFAULT_MODEL"}},
 
 	// Server
-	_ = v.BindEnv("server.host", "TELEMETRYFLOW_MCP_SERVER_HOST")
-	_ = v.BindEnv("server.port", "TELEMETRYFLOW_MCP_SERVER_PORT")
-	_ = v.BindEnv("server.transport", "TELEMETRYFLOW_MCP_SERVER_TRANSPORT")
-	_ = v.BindEnv("server.debug", "TELEMETRYFLOW_MCP_DEBUG")
+	{"server.host", []string{"TELEMETRYFLOW_MCP_SERVER_HOST"}},
+	{"server.port", []string{"TELEMETRYFLOW_MCP_SERVER_PORT"}},
+	{"server.transport", []string{"TELEMETRYFLOW_MCP_SERVER_TRANSPORT"}},
+	{"server.debug", []string{"TELEMETRYFLOW_MCP_DEBUG"}},
 
 	// Logging
-	_ = v.BindEnv("logging.level", "TELEMETRYFLOW_MCP_LOG_LEVEL")
-	_ = v.BindEnv("logging.format", "TELEMETRYFLOW_MCP_LOG_FORMAT")
+	{"logging.level", []string{"TELEMETRYFLOW_MCP_LOG_LEVEL"}},
+	{"logging.format", []string{"TELEMETRYFLOW_MCP_LOG_FORMAT"}},
 
 	// Telemetry
-	_ = v.BindEnv("telemetry.enabled", "TELEMETRYFLOW_MCP_TELEMETRY_ENABLED")
-	_ = v.BindEnv("telemetry.otlp_endpoint", "TELEMETRYFLOW_ENDPOINT", "TELEMETRYFLOW_MCP_OTLP_ENDPOINT")
-	_ = v.BindEnv("telemetry.service_name", "TELEMETRYFLOW_SERVICE_NAME", "TELEMETRYFLOW_MCP_SERVICE_NAME")
+	{"telemetry.enabled", []string{"TELEMETRYFLOW_MCP_TELEMETRY_ENABLED"}},
+	{"telemetry.otlp_endpoint", []string{"TELEMETRYFLOW_ENDPOINT", "TELEMETRYFLOW_MCP_OTLP_ENDPOINT"}},
+	{"telemetry.service_name", []string{"TELEMETRYFLOW_SERVICE_NAME", "TELEMETRYFLOW_MCP_SERVICE_NAME"}},
+	{"telemetry.export_mode", []string{telemetry.EnvExportMode}},
+	{"telemetry.export_file", []string{telemetry.EnvExportFile}},
+
+	// Security
+	{"security.resume_token_secret", []string{"TELEMETRYFLOW_MCP_RESUME_TOKEN_SECRET"}},
+
+	// Persistence
+	{"persistence.driver", []string{"TELEMETRYFLOW_MCP_PERSISTENCE_DRIVER"}},
+}
+
+// bindEnvVars binds environment variables to config keys
+func bindEnvVars(v *viper.Viper) {
+	for _, binding := range envBindings {
+		// Errors ignored as BindEnv only fails on empty key names
+		_ = v.BindEnv(append([]string{binding.key}, binding.envs...)...)
+	}
 }
 
 // Validate validates the configuration
@@ -289,18 +555,165 @@ func (c *Config) Validate() error {
 		return errors.New("server.transport must be 'stdio', 'sse', or 'websocket'")
 	}
 
+	if c.Server.MaxRequestTimeout < 0 {
+		return errors.New("server.max_request_timeout must not be negative")
+	}
+
+	if c.Server.MaxSessions < 0 {
+		return errors.New("server.max_sessions must not be negative")
+	}
+
+	if c.Server.MaxConcurrentRequests < 1 {
+		return errors.New("server.max_concurrent_requests must be positive")
+	}
+
+	if c.Server.RequestQueueDepth < 0 {
+		return errors.New("server.request_queue_depth must not be negative")
+	}
+
 	if c.Claude.MaxTokens < 1 {
 		return errors.New("claude.max_tokens must be positive")
 	}
 
+	if c.Claude.MaxTokensLimit < 0 {
+		return errors.New("claude.max_tokens_limit must not be negative")
+	}
+
+	if c.Claude.MaxResponseChars < 0 {
+		return errors.New("claude.max_response_chars must not be negative")
+	}
+
+	if c.Claude.MaxToolIterations < 1 {
+		return errors.New("claude.max_tool_iterations must be positive")
+	}
+
+	if c.Claude.MaxStreamedToolOutput < 0 {
+		return errors.New("claude.max_streamed_tool_output must not be negative")
+	}
+
+	if c.Claude.MaxStopSequences < 1 {
+		return errors.New("claude.max_stop_sequences must be positive")
+	}
+
+	if c.Claude.MaxStopSequenceLength < 1 {
+		return errors.New("claude.max_stop_sequence_length must be positive")
+	}
+
 	if c.Claude.Temperature < 0 || c.Claude.Temperature > 2 {
 		return errors.New("claude.temperature must be between 0 and 2")
 	}
 
+	if c.MCP.ResourceUpdateDebounce < 0 {
+		return errors.New("mcp.resource_update_debounce must not be negative")
+	}
+
+	if c.MCP.ExecIdleTimeout < 0 {
+		return errors.New("mcp.exec_idle_timeout must not be negative")
+	}
+
+	if c.MCP.ReadFileMaxBytes < 0 {
+		return errors.New("mcp.read_file_max_bytes must not be negative")
+	}
+
+	if c.MCP.EventLogSize < 0 {
+		return errors.New("mcp.event_log_size must not be negative")
+	}
+
+	if c.MCP.MaxResponseBytes < 0 {
+		return errors.New("mcp.max_response_bytes must not be negative")
+	}
+
+	if c.MCP.ResourceStreamThreshold < 0 {
+		return errors.New("mcp.resource_stream_threshold must not be negative")
+	}
+
+	if c.MCP.ResourceStreamChunkSize < 1024 {
+		return errors.New("mcp.resource_stream_chunk_size must be at least 1024")
+	}
+
+	enabledTools := make(map[string]bool, len(c.MCP.EnabledTools))
+	for _, name := range c.MCP.EnabledTools {
+		enabledTools[name] = true
+	}
+	for _, name := range c.MCP.DisabledTools {
+		if enabledTools[name] {
+			return fmt.Errorf("mcp.enabled_tools and mcp.disabled_tools both list %s", name)
+		}
+	}
+
+	validNotificationIDPolicies := map[string]bool{"request": true, "error": true, "ignore": true}
+	if !validNotificationIDPolicies[c.MCP.NotificationIDPolicy] {
+		return errors.New("mcp.notification_id_policy must be 'request', 'error', or 'ignore'")
+	}
+
+	validSymlinkPolicies := map[string]bool{"follow": true, "deny": true, "resolve-and-check": true}
+	if !validSymlinkPolicies[c.Security.SymlinkPolicy] {
+		return errors.New("security.symlink_policy must be 'follow', 'deny', or 'resolve-and-check'")
+	}
+
+	for name, limit := range c.Security.ToolRateLimits {
+		if limit < 0 {
+			return fmt.Errorf("security.tool_rate_limits.%s must not be negative", name)
+		}
+	}
+
+	if c.Security.ToolRateLimitWindow <= 0 {
+		return errors.New("security.tool_rate_limit_window must be positive")
+	}
+
+	if c.Security.EnableDBQuery && !c.Database.Enabled {
+		return errors.New("security.enable_db_query requires database.enabled")
+	}
+
+	validDrivers := map[string]bool{"memory": true, "postgres": true}
+	if !validDrivers[c.Persistence.Driver] {
+		return errors.New("persistence.driver must be 'memory' or 'postgres'")
+	}
+
+	if c.Persistence.Driver == "postgres" && !c.Database.Enabled {
+		return errors.New("persistence.driver postgres requires database.enabled")
+	}
+
+	if c.Security.DBQueryMaxRows < 1 || c.Security.DBQueryMaxRows > 1000 {
+		return errors.New("security.db_query_max_rows must be between 1 and 1000")
+	}
+
+	if c.Security.DBQueryTimeout <= 0 {
+		return errors.New("security.db_query_timeout must be positive")
+	}
+
+	if c.Security.ResumeTokenSecret != "" && len(c.Security.ResumeTokenSecret) < 32 {
+		return errors.New("security.resume_token_secret must be at least 32 characters")
+	}
+
+	if c.Security.ResumeTokenTTL <= 0 {
+		return errors.New("security.resume_token_ttl must be positive")
+	}
+
+	if c.Security.ClockSkewTolerance < 0 {
+		return errors.New("security.clock_skew_tolerance must not be negative")
+	}
+
+	if c.Queue.LockSkewTolerance < 0 {
+		return errors.New("queue.lock_skew_tolerance must not be negative")
+	}
+
+	if c.Queue.MaxDeliver < 1 {
+		return errors.New("queue.max_deliver must be at least 1")
+	}
+
 	if c.Telemetry.TraceSampleRate < 0 || c.Telemetry.TraceSampleRate > 1 {
 		return errors.New("telemetry.trace_sample_rate must be between 0 and 1")
 	}
 
+	exportMode, err := telemetry.ParseExportMode(c.Telemetry.ExportMode)
+	if err != nil {
+		return fmt.Errorf("telemetry.export_mode: %w", err)
+	}
+	if telemetry.ExportsToFile(exportMode) && c.Telemetry.ExportFile == "" {
+		return fmt.Errorf("telemetry.export_file is required with telemetry.export_mode %s", exportMode)
+	}
+
 	return nil
 }
 
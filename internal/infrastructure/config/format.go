@@ -0,0 +1,149 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/viper"
+)
+
+// Config file formats
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+	FormatTOML = "toml"
+)
+
+// ErrUnsupportedFormat is returned for a config file whose format is not supported
+var ErrUnsupportedFormat = errors.New("unsupported config format")
+
+// formatExtensions maps config file extensions to their format, in search order
+var formatExtensions = []struct {
+	ext    string
+	format string
+}{
+	{".yaml", FormatYAML},
+	{".yml", FormatYAML},
+	{".json", FormatJSON},
+	{".toml", FormatTOML},
+}
+
+// configSearchPaths are the directories searched for a config file when none is given
+var configSearchPaths = []string{".", "./configs", "/etc/telemetryflow-go-mcp", "$HOME/.telemetryflow-go-mcp"}
+
+// ParseError reports a config file that is not valid in its format
+type ParseError struct {
+	Path   string
+	Format string
+	Line   int // 1-based line of the error, zero when the decoder does not report it
+	Column int // 1-based column of the error, zero when unknown
+	Err    error
+}
+
+// Error implements the error interface
+func (e *ParseError) Error() string {
+	position := ""
+	if e.Line > 0 {
+		position = fmt.Sprintf(" at line %d", e.Line)
+		if e.Column > 0 {
+			position += fmt.Sprintf(", column %d", e.Column)
+		}
+	}
+	return fmt.Sprintf("invalid %s in %s%s: %v", strings.ToUpper(e.Format), e.Path, position, e.Err)
+}
+
+// Unwrap returns the decoder error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ResolveFormat returns the format of the config file at path. An explicit format
+// wins; otherwise the extension decides, and extensionless files are read as YAML.
+func ResolveFormat(path, format string) (string, error) {
+	if format != "" {
+		format = strings.ToLower(format)
+		switch format {
+		case FormatYAML, "yml":
+			return FormatYAML, nil
+		case FormatJSON, FormatTOML:
+			return format, nil
+		}
+		return "", fmt.Errorf("%w: %q (use yaml, json or toml)", ErrUnsupportedFormat, format)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" {
+		return FormatYAML, nil
+	}
+	for _, candidate := range formatExtensions {
+		if candidate.ext == ext {
+			return candidate.format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s has extension %s; set the format explicitly for other extensions", ErrUnsupportedFormat, path, ext)
+}
+
+// findConfigFile returns the first config.<ext> file in the search paths, or "" if there is none
+func findConfigFile() string {
+	for _, dir := range configSearchPaths {
+		dir = os.ExpandEnv(dir)
+		for _, candidate := range formatExtensions {
+			path := filepath.Join(dir, "config"+candidate.ext)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// readConfigFile reads the config file at path into v, parsed as format
+func readConfigFile(v *viper.Viper, path, format string) error {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: the path is chosen by the operator
+	if err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+
+	v.SetConfigType(format)
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		var parseErr viper.ConfigParseError
+		if errors.As(err, &parseErr) {
+			err = parseErr.Unwrap()
+		}
+		return newParseError(path, format, data, err)
+	}
+	return nil
+}
+
+// newParseError wraps a decoder error, recovering its position where the decoder reports one
+func newParseError(path, format string, data []byte, err error) *ParseError {
+	parseErr := &ParseError{Path: path, Format: format, Err: err}
+
+	var tomlErr *toml.DecodeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &tomlErr):
+		parseErr.Line, parseErr.Column = tomlErr.Position()
+	case errors.As(err, &syntaxErr):
+		// The offset counts the bytes read, including the offending one
+		parseErr.Line, parseErr.Column = offsetPosition(data, syntaxErr.Offset-1)
+	}
+	return parseErr
+}
+
+// offsetPosition returns the 1-based line and column of the byte at offset in data
+func offsetPosition(data []byte, offset int64) (int, int) {
+	if offset < 0 || offset >= int64(len(data)) {
+		return 0, 0
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
@@ -111,7 +111,7 @@ func DefaultConfig() *Config {
 			LogResponseBody:      false,
 			MaxBodySize:          4096,
 			SlowRequestThreshold: 5 * time.Second,
-			SensitiveFields:      []string{"api_key", "apiKey", "password", "secret", "token", "authorization"},
+			SensitiveFields:      append([]string(nil), DefaultSensitiveFields...),
 			IncludeTraceInfo:     true,
 		},
 	}
@@ -7,9 +7,12 @@ package logging
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
 )
 
 // RequestLogger provides request/response logging for MCP operations.
@@ -35,6 +38,28 @@ type RequestLoggerConfig struct {
 	IncludeTraceInfo bool
 }
 
+// DefaultSensitiveFields are the field names redacted from logs, configuration dumps
+// and events by default.
+var DefaultSensitiveFields = []string{"api_key", "api_keys", "password", "secret", "token", "authorization"}
+
+// IsSensitiveField reports whether key names a secret: it is one of fields or ends
+// with one, ignoring case, underscores and dashes. So apiKey, X-API-Key and
+// resume_token_secret all match.
+func IsSensitiveField(key string, fields []string) bool {
+	normalized := normalizeFieldName(key)
+	for _, field := range fields {
+		if field := normalizeFieldName(field); field != "" && strings.HasSuffix(normalized, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeFieldName lowercases name and drops underscores and dashes
+func normalizeFieldName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
 // DefaultRequestLoggerConfig returns default configuration.
 func DefaultRequestLoggerConfig() *RequestLoggerConfig {
 	return &RequestLoggerConfig{
@@ -42,7 +67,7 @@ func DefaultRequestLoggerConfig() *RequestLoggerConfig {
 		LogResponseBody:      false,
 		MaxBodySize:          4096,
 		SlowRequestThreshold: 5 * time.Second,
-		SensitiveFields:      []string{"api_key", "apiKey", "password", "secret", "token"},
+		SensitiveFields:      append([]string(nil), DefaultSensitiveFields...),
 		IncludeTraceInfo:     true,
 	}
 }
@@ -87,6 +112,9 @@ func (l *RequestLogger) LogRequest(ctx context.Context, info *RequestInfo) {
 		Str("method", info.Method).
 		Str("session_id", info.SessionID).
 		Time("start_time", info.StartTime)
+	if client, ok := telemetry.ClientInfoFromContext(ctx); ok {
+		event = event.Str("client_name", client.Name).Str("client_version", client.Version)
+	}
 
 	if l.config.IncludeTraceInfo {
 		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
@@ -123,6 +151,9 @@ func (l *RequestLogger) LogResponse(ctx context.Context, info *ResponseInfo) {
 		Dur("duration", info.Duration).
 		Time("start_time", info.StartTime).
 		Time("end_time", info.EndTime)
+	if client, ok := telemetry.ClientInfoFromContext(ctx); ok {
+		event = event.Str("client_name", client.Name).Str("client_version", client.Version)
+	}
 
 	if l.config.IncludeTraceInfo {
 		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
@@ -288,11 +319,8 @@ func (l *RequestLogger) sanitizeBody(body interface{}) interface{} {
 func (l *RequestLogger) redactSensitiveFields(data map[string]interface{}) {
 	for key, value := range data {
 		// Check if this is a sensitive field
-		for _, sensitiveField := range l.config.SensitiveFields {
-			if key == sensitiveField {
-				data[key] = "[REDACTED]"
-				break
-			}
+		if IsSensitiveField(key, l.config.SensitiveFields) {
+			data[key] = "[REDACTED]"
 		}
 
 		// Recursively check nested maps
@@ -7,11 +7,14 @@ package logging
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/telemetryflow/telemetryflow-go-sdk/pkg/telemetryflow"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
 )
 
 // TFOAdapter wraps the TelemetryFlow Go SDK client for MCP server observability.
@@ -19,6 +22,7 @@ import (
 // all telemetry through the TFO platform pipeline.
 type TFOAdapter struct {
 	client         *telemetryflow.Client
+	file           *telemetry.FileExporter // Set when telemetry is also written to a local file
 	fallbackLogger *Logger
 	serviceName    string
 	serviceVersion string
@@ -61,16 +65,34 @@ type TFOAdapterConfig struct {
 	Timeout time.Duration `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
 	// FallbackToLocal enables local zerolog fallback when SDK is unavailable
 	FallbackToLocal bool `mapstructure:"fallback_to_local" yaml:"fallback_to_local" json:"fallback_to_local"`
+	// FallbackFormat is the output format of the local fallback logger (json or console)
+	FallbackFormat string `mapstructure:"fallback_format" yaml:"fallback_format" json:"fallback_format"`
+	// ExportMode sends telemetry to the collector (otlp, default), to ExportFile instead (file), or to both
+	ExportMode string `mapstructure:"export_mode" yaml:"export_mode" json:"export_mode"`
+	// ExportFile is the JSON lines file written in the file and both modes
+	ExportFile string `mapstructure:"export_file" yaml:"export_file" json:"export_file"`
+	// ExportFileMaxBytes is the size at which the export file is rotated
+	ExportFileMaxBytes int64 `mapstructure:"export_file_max_bytes" yaml:"export_file_max_bytes" json:"export_file_max_bytes"`
+	// ExportFileMaxBackups is the number of rotated export files kept
+	ExportFileMaxBackups int `mapstructure:"export_file_max_backups" yaml:"export_file_max_backups" json:"export_file_max_backups"`
+}
+
+// DefaultTFOAdapterConfig returns the default configuration for the environment
+// reported by telemetry.DetectEnvironment.
+func DefaultTFOAdapterConfig() *TFOAdapterConfig {
+	return DefaultTFOAdapterConfigFor(telemetry.DetectEnvironment())
 }
 
-// DefaultTFOAdapterConfig returns default configuration.
-func DefaultTFOAdapterConfig() *TFOAdapterConfig {
-	return &TFOAdapterConfig{
+// DefaultTFOAdapterConfigFor returns the default configuration for env. A development
+// environment exports to a local collector without TLS and falls back to console
+// logs; every other environment keeps the strict production defaults.
+func DefaultTFOAdapterConfigFor(env string) *TFOAdapterConfig {
+	cfg := &TFOAdapterConfig{
 		Endpoint:         "api.telemetryflow.id:4317",
 		ServiceName:      "telemetryflow-go-mcp",
 		ServiceVersion:   "0.1.0",
 		ServiceNamespace: "telemetryflow",
-		Environment:      "production",
+		Environment:      env,
 		EnableMetrics:    true,
 		EnableLogs:       true,
 		EnableTraces:     true,
@@ -78,7 +100,15 @@ func DefaultTFOAdapterConfig() *TFOAdapterConfig {
 		Insecure:         false,
 		Timeout:          30 * time.Second,
 		FallbackToLocal:  true,
+		FallbackFormat:   "json",
+		ExportMode:       telemetry.ExportModeOTLP,
+	}
+	if telemetry.IsDevelopmentEnvironment(env) {
+		cfg.Endpoint = telemetry.LocalCollectorEndpoint
+		cfg.Insecure = true
+		cfg.FallbackFormat = "console"
 	}
+	return cfg
 }
 
 // NewTFOAdapter creates a new TFO SDK adapter.
@@ -99,9 +129,30 @@ func NewTFOAdapter(cfg *TFOAdapterConfig) (*TFOAdapter, error) {
 			WithServiceName(cfg.ServiceName),
 			WithVersion(cfg.ServiceVersion),
 			WithLevel(InfoLevel),
+			WithPrettyPrint(cfg.FallbackFormat == "console"),
 		)
 	}
 
+	// Write telemetry to a local file in the file and both export modes
+	mode, err := telemetry.ParseExportMode(cfg.ExportMode)
+	if err != nil {
+		return nil, err
+	}
+	if telemetry.ExportsToFile(mode) {
+		adapter.file, err = telemetry.NewFileExporter(telemetry.FileExporterConfig{
+			Path:        cfg.ExportFile,
+			ServiceName: cfg.ServiceName,
+			MaxBytes:    cfg.ExportFileMaxBytes,
+			MaxBackups:  cfg.ExportFileMaxBackups,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+	}
+	if mode == telemetry.ExportModeFile {
+		return adapter, nil
+	}
+
 	// Build TFO SDK client
 	builder := telemetryflow.NewBuilder().
 		WithAPIKey(cfg.APIKeyID, cfg.APIKeySecret).
@@ -139,6 +190,9 @@ func NewTFOAdapter(cfg *TFOAdapterConfig) (*TFOAdapter, error) {
 				Msg("Failed to create TFO SDK client, using local fallback")
 			return adapter, nil
 		}
+		if adapter.file != nil {
+			_ = adapter.file.Close()
+		}
 		return nil, fmt.Errorf("failed to create TFO SDK client: %w", err)
 	}
 
@@ -146,7 +200,8 @@ func NewTFOAdapter(cfg *TFOAdapterConfig) (*TFOAdapter, error) {
 	return adapter, nil
 }
 
-// NewTFOAdapterFromEnv creates a TFO adapter using environment variables.
+// NewTFOAdapterFromEnv creates a TFO adapter using environment variables. The export
+// mode and file are read from telemetry.EnvExportMode and telemetry.EnvExportFile.
 func NewTFOAdapterFromEnv() (*TFOAdapter, error) {
 	cfg := DefaultTFOAdapterConfig()
 	cfg.FallbackToLocal = true
@@ -159,9 +214,27 @@ func NewTFOAdapterFromEnv() (*TFOAdapter, error) {
 			WithServiceName(cfg.ServiceName),
 			WithVersion(cfg.ServiceVersion),
 			WithLevel(InfoLevel),
+			WithPrettyPrint(cfg.FallbackFormat == "console"),
 		),
 	}
 
+	mode, err := telemetry.ParseExportMode(os.Getenv(telemetry.EnvExportMode))
+	if err != nil {
+		return nil, err
+	}
+	if telemetry.ExportsToFile(mode) {
+		adapter.file, err = telemetry.NewFileExporter(telemetry.FileExporterConfig{
+			Path:        os.Getenv(telemetry.EnvExportFile),
+			ServiceName: cfg.ServiceName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+	}
+	if mode == telemetry.ExportModeFile {
+		return adapter, nil
+	}
+
 	// Try to create client from env
 	client, err := telemetryflow.NewFromEnv()
 	if err != nil {
@@ -185,7 +258,11 @@ func (a *TFOAdapter) Initialize(ctx context.Context) error {
 	}
 
 	if a.client == nil {
-		a.log(ctx, "info", "TFO SDK not available, using local fallback", nil)
+		if a.file != nil {
+			a.log(ctx, "info", "Exporting telemetry to file", map[string]interface{}{"path": a.file.Path()})
+		} else {
+			a.log(ctx, "info", "TFO SDK not available, using local fallback", nil)
+		}
 		a.initialized = true
 		return nil
 	}
@@ -216,6 +293,13 @@ func (a *TFOAdapter) Shutdown(ctx context.Context) error {
 		return nil
 	}
 
+	if a.file != nil {
+		if err := a.file.Close(); err != nil {
+			a.logLocal(zerolog.ErrorLevel, "Failed to close export file", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
 	if a.client != nil {
 		if err := a.client.Shutdown(ctx); err != nil {
 			a.logLocal(zerolog.ErrorLevel, "Failed to shutdown TFO SDK", map[string]interface{}{
@@ -230,6 +314,11 @@ func (a *TFOAdapter) Shutdown(ctx context.Context) error {
 
 // Flush forces a flush of pending telemetry.
 func (a *TFOAdapter) Flush(ctx context.Context) error {
+	if a.file != nil {
+		if err := a.file.Sync(); err != nil {
+			return err
+		}
+	}
 	if a.client != nil && a.isInitialized() {
 		return a.client.Flush(ctx)
 	}
@@ -265,6 +354,13 @@ func (a *TFOAdapter) Error(ctx context.Context, message string, attributes map[s
 
 // log is the internal logging method that routes to SDK or fallback.
 func (a *TFOAdapter) log(ctx context.Context, severity string, message string, attributes map[string]interface{}) {
+	if a.file != nil {
+		_ = a.file.ExportLog(severity, message, attributes)
+		if a.client == nil {
+			return
+		}
+	}
+
 	if a.client != nil && a.isInitialized() {
 		if err := a.client.Log(ctx, severity, message, attributes); err != nil {
 			// Fallback to local on error
@@ -307,9 +403,15 @@ func (a *TFOAdapter) logLocal(level zerolog.Level, message string, attributes ma
 
 // RecordMetric records a generic metric.
 func (a *TFOAdapter) RecordMetric(ctx context.Context, name string, value float64, unit string, attributes map[string]interface{}) error {
+	if a.file != nil {
+		_ = a.file.ExportMetric("metric", name, value, unit, attributes)
+	}
 	if a.client != nil && a.isInitialized() {
 		return a.client.RecordMetric(ctx, name, value, unit, attributes)
 	}
+	if a.file != nil {
+		return nil
+	}
 	// Log locally as fallback
 	a.logLocal(zerolog.DebugLevel, "metric", map[string]interface{}{
 		"name":  name,
@@ -321,6 +423,9 @@ func (a *TFOAdapter) RecordMetric(ctx context.Context, name string, value float6
 
 // IncrementCounter increments a counter metric.
 func (a *TFOAdapter) IncrementCounter(ctx context.Context, name string, value int64, attributes map[string]interface{}) error {
+	if a.file != nil {
+		_ = a.file.ExportMetric("counter", name, float64(value), "", attributes)
+	}
 	if a.client != nil && a.isInitialized() {
 		return a.client.IncrementCounter(ctx, name, value, attributes)
 	}
@@ -329,6 +434,9 @@ func (a *TFOAdapter) IncrementCounter(ctx context.Context, name string, value in
 
 // RecordGauge records a gauge metric.
 func (a *TFOAdapter) RecordGauge(ctx context.Context, name string, value float64, attributes map[string]interface{}) error {
+	if a.file != nil {
+		_ = a.file.ExportMetric("gauge", name, value, "", attributes)
+	}
 	if a.client != nil && a.isInitialized() {
 		return a.client.RecordGauge(ctx, name, value, attributes)
 	}
@@ -337,6 +445,9 @@ func (a *TFOAdapter) RecordGauge(ctx context.Context, name string, value float64
 
 // RecordHistogram records a histogram measurement.
 func (a *TFOAdapter) RecordHistogram(ctx context.Context, name string, value float64, unit string, attributes map[string]interface{}) error {
+	if a.file != nil {
+		_ = a.file.ExportMetric("histogram", name, value, unit, attributes)
+	}
 	if a.client != nil && a.isInitialized() {
 		return a.client.RecordHistogram(ctx, name, value, unit, attributes)
 	}
@@ -348,7 +459,14 @@ func (a *TFOAdapter) RecordHistogram(ctx context.Context, name string, value flo
 // StartSpan starts a new trace span.
 func (a *TFOAdapter) StartSpan(ctx context.Context, name string, kind string, attributes map[string]interface{}) (string, error) {
 	if a.client != nil && a.isInitialized() {
-		return a.client.StartSpan(ctx, name, kind, attributes)
+		spanID, err := a.client.StartSpan(ctx, name, kind, attributes)
+		if a.file != nil && err == nil {
+			a.file.StartSpan(spanID, name, kind, attributes)
+		}
+		return spanID, err
+	}
+	if a.file != nil {
+		return a.file.StartSpan("", name, kind, attributes), nil
 	}
 	// Return empty span ID for fallback
 	return "", nil
@@ -356,6 +474,9 @@ func (a *TFOAdapter) StartSpan(ctx context.Context, name string, kind string, at
 
 // EndSpan ends an active span.
 func (a *TFOAdapter) EndSpan(ctx context.Context, spanID string, err error) error {
+	if a.file != nil && spanID != "" {
+		_ = a.file.EndSpan(spanID, err)
+	}
 	if a.client != nil && a.isInitialized() && spanID != "" {
 		return a.client.EndSpan(ctx, spanID, err)
 	}
@@ -364,6 +485,9 @@ func (a *TFOAdapter) EndSpan(ctx context.Context, spanID string, err error) erro
 
 // AddSpanEvent adds an event to an active span.
 func (a *TFOAdapter) AddSpanEvent(ctx context.Context, spanID string, name string, attributes map[string]interface{}) error {
+	if a.file != nil && spanID != "" {
+		a.file.AddSpanEvent(spanID, name, attributes)
+	}
 	if a.client != nil && a.isInitialized() && spanID != "" {
 		return a.client.AddSpanEvent(ctx, spanID, name, attributes)
 	}
@@ -374,44 +498,44 @@ func (a *TFOAdapter) AddSpanEvent(ctx context.Context, spanID string, name strin
 
 // LogMCPRequest logs an MCP request.
 func (a *TFOAdapter) LogMCPRequest(ctx context.Context, requestID, method, sessionID string) {
-	a.Info(ctx, "MCP request received", map[string]interface{}{
+	a.Info(ctx, "MCP request received", telemetry.AddClientFields(ctx, map[string]interface{}{
 		"request_id": requestID,
 		"method":     method,
 		"session_id": sessionID,
 		"type":       "mcp.request",
-	})
+	}))
 
-	_ = a.IncrementCounter(ctx, "mcp.requests.total", 1, map[string]interface{}{
+	_ = a.IncrementCounter(ctx, "mcp.requests.total", 1, telemetry.AddClientFields(ctx, map[string]interface{}{
 		"method": method,
-	})
+	}))
 }
 
 // LogMCPResponse logs an MCP response.
 func (a *TFOAdapter) LogMCPResponse(ctx context.Context, requestID, method, sessionID string, duration time.Duration, err error) {
-	attrs := map[string]interface{}{
+	attrs := telemetry.AddClientFields(ctx, map[string]interface{}{
 		"request_id":  requestID,
 		"method":      method,
 		"session_id":  sessionID,
 		"duration_ms": duration.Milliseconds(),
 		"type":        "mcp.response",
-	}
+	})
 
 	if err != nil {
 		attrs["error"] = err.Error()
 		attrs["success"] = false
 		a.Error(ctx, "MCP request failed", attrs)
 
-		_ = a.IncrementCounter(ctx, "mcp.requests.errors", 1, map[string]interface{}{
+		_ = a.IncrementCounter(ctx, "mcp.requests.errors", 1, telemetry.AddClientFields(ctx, map[string]interface{}{
 			"method": method,
-		})
+		}))
 	} else {
 		attrs["success"] = true
 		a.Info(ctx, "MCP request completed", attrs)
 	}
 
-	_ = a.RecordHistogram(ctx, "mcp.request.duration", float64(duration.Milliseconds()), "ms", map[string]interface{}{
+	_ = a.RecordHistogram(ctx, "mcp.request.duration", float64(duration.Milliseconds()), "ms", telemetry.AddClientFields(ctx, map[string]interface{}{
 		"method": method,
-	})
+	}))
 }
 
 // LogToolCall logs a tool execution.
@@ -524,6 +648,11 @@ func (a *TFOAdapter) Client() *telemetryflow.Client {
 	return a.client
 }
 
+// FileExporter returns the local file exporter, or nil when telemetry is not written to a file.
+func (a *TFOAdapter) FileExporter() *telemetry.FileExporter {
+	return a.file
+}
+
 // FallbackLogger returns the local fallback logger.
 func (a *TFOAdapter) FallbackLogger() *Logger {
 	return a.fallbackLogger
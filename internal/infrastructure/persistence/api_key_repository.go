@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence/models"
+)
+
+// APIKeyRepository looks up issued API keys in the api_keys table
+type APIKeyRepository struct {
+	db *Database
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository
+func NewAPIKeyRepository(db *Database) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// LookupAPIKey returns the scopes of an active, unexpired key. Keys are stored hashed,
+// so the key itself is never compared against the table.
+func (r *APIKeyRepository) LookupAPIKey(ctx context.Context, key string) ([]string, bool, error) {
+	var apiKey models.APIKey
+	err := r.db.WithContext(ctx).
+		Where("key_hash = ? AND is_active = ?", hashAPIKey(key), true).
+		First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(time.Now()) {
+		return nil, false, nil
+	}
+	return apiKey.Scopes, true, nil
+}
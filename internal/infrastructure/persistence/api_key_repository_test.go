@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAPIKeyRepository_LookupByHash(t *testing.T) {
+	db, statements := newDryRunDatabase(t)
+	repo := NewAPIKeyRepository(db)
+
+	if _, _, err := repo.LookupAPIKey(context.Background(), "secret-key"); err != nil {
+		t.Fatalf("LookupAPIKey() error = %v", err)
+	}
+
+	// Only active keys match, by hash rather than by the key itself
+	want := `SELECT * FROM "api_keys" WHERE key_hash = $1 AND is_active = $2`
+	if len(*statements) != 1 || !strings.HasPrefix((*statements)[0], want) {
+		t.Errorf("statements = %q, want one starting with %q", *statements, want)
+	}
+}
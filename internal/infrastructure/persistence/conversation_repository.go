@@ -3,11 +3,16 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
 )
 
 // ConversationRepository handles conversation persistence
@@ -110,16 +115,23 @@ func (r *ConversationRepository) Close(ctx context.Context, id string) error {
 	return nil
 }
 
-// Delete soft-deletes a conversation
+// Delete soft-deletes a conversation and deletes its messages in one transaction. A
+// soft delete does not fire the messages foreign key cascade, so the messages are
+// removed explicitly.
 func (r *ConversationRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&ConversationModel{}, "id = ?", id)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return ErrConversationNotFound
-	}
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("conversation_id = ?", id).Delete(&MessageModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&ConversationModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConversationNotFound
+		}
+		return nil
+	})
 }
 
 // ListBySession lists conversations for a session
@@ -179,6 +191,102 @@ func (r *ConversationRepository) ListActive(ctx context.Context) ([]Conversation
 	return conversations, err
 }
 
+// ListByMetadata lists summaries of conversations whose metadata key holds value. It uses
+// jsonb containment so the GIN index on metadata serves the lookup.
+func (r *ConversationRepository) ListByMetadata(ctx context.Context, key string, value interface{}, opts *ListOptions) (*repositories.ConversationSummaryPage, error) {
+	filter, err := json.Marshal(map[string]interface{}{key: value})
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata value: %w", err)
+	}
+
+	query := r.db.WithContext(ctx).Model(&ConversationModel{}).Where("metadata @> ?::jsonb", string(filter))
+	if opts != nil && opts.State != "" {
+		query = query.Where("status = ?", opts.State)
+	}
+
+	// Count on a separate session so the count query does not leak into the page query
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	page := &repositories.ConversationSummaryPage{Total: int(total)}
+	if opts != nil {
+		page.Offset, page.Limit = opts.Offset, opts.Limit
+		if opts.Limit > 0 {
+			query = query.Limit(opts.Limit)
+		}
+		if opts.Offset > 0 {
+			query = query.Offset(opts.Offset)
+		}
+	}
+
+	var conversations []ConversationModel
+	if err := query.Order("created_at DESC, id").Find(&conversations).Error; err != nil {
+		return nil, err
+	}
+
+	counts, err := r.messageCounts(ctx, conversations)
+	if err != nil {
+		return nil, err
+	}
+
+	page.Summaries = make([]repositories.ConversationSummary, 0, len(conversations))
+	for _, conversation := range conversations {
+		id, err := vo.NewConversationID(conversation.ID)
+		if err != nil {
+			return nil, err
+		}
+		sessionID, err := vo.NewSessionID(conversation.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		page.Summaries = append(page.Summaries, repositories.ConversationSummary{
+			ID:           id,
+			SessionID:    sessionID,
+			Model:        conversation.Model,
+			Status:       conversation.Status,
+			MessageCount: int(counts[conversation.ID]),
+			Metadata:     conversation.Metadata,
+			CreatedAt:    conversation.CreatedAt,
+			UpdatedAt:    conversation.UpdatedAt,
+		})
+	}
+	return page, nil
+}
+
+// messageCounts returns the number of messages in each of the given conversations
+func (r *ConversationRepository) messageCounts(ctx context.Context, conversations []ConversationModel) (map[string]int64, error) {
+	counts := make(map[string]int64, len(conversations))
+	if len(conversations) == 0 {
+		return counts, nil
+	}
+
+	ids := make([]string, len(conversations))
+	for i, conversation := range conversations {
+		ids[i] = conversation.ID
+	}
+
+	var results []struct {
+		ConversationID string
+		Count          int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&MessageModel{}).
+		Select("conversation_id, COUNT(*) as count").
+		Where("conversation_id IN ?", ids).
+		Group("conversation_id").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		counts[result.ConversationID] = result.Count
+	}
+	return counts, nil
+}
+
 // CountByModel counts conversations by model
 func (r *ConversationRepository) CountByModel(ctx context.Context) (map[string]int64, error) {
 	type result struct {
@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingConnPool is a connection pool that records the statements it executes and
+// the transactions it commits or rolls back. Execs matching failOn fail.
+type recordingConnPool struct {
+	statements []string
+	failOn     string
+}
+
+func (p *recordingConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *recordingConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	p.statements = append(p.statements, query)
+	if p.failOn != "" && strings.Contains(query, p.failOn) {
+		return nil, errors.New("exec failed")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (p *recordingConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *recordingConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p *recordingConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.statements = append(p.statements, "BEGIN")
+	return &recordingTx{p}, nil
+}
+
+type recordingTx struct{ *recordingConnPool }
+
+func (tx *recordingTx) Commit() error {
+	tx.statements = append(tx.statements, "COMMIT")
+	return nil
+}
+
+func (tx *recordingTx) Rollback() error {
+	tx.statements = append(tx.statements, "ROLLBACK")
+	return nil
+}
+
+func TestGormConversationRepository_DeleteIsTransactional(t *testing.T) {
+	conversation := aggregates.NewConversation(aggregates.NewSession().ID(), "")
+
+	tests := []struct {
+		name   string
+		failOn string
+		want   []string
+	}{
+		{
+			name: "messages and conversation deleted together",
+			want: []string{"BEGIN", `DELETE FROM "messages"`, `UPDATE "conversations"`, "COMMIT"},
+		},
+		{
+			name:   "failed conversation delete keeps the messages",
+			failOn: `UPDATE "conversations"`,
+			want:   []string{"BEGIN", `DELETE FROM "messages"`, `UPDATE "conversations"`, "ROLLBACK"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &recordingConnPool{failOn: tt.failOn}
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				Logger:                 logger.Discard,
+			})
+			if err != nil {
+				t.Fatalf("gorm.Open() error = %v", err)
+			}
+			repo := NewGormConversationRepository(&Database{db: db}, NewGormToolRepository(&Database{db: db}))
+
+			err = repo.Delete(context.Background(), conversation.ID())
+			if (err != nil) != (tt.failOn != "") {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if len(pool.statements) != len(tt.want) {
+				t.Fatalf("statements = %q, want %d", pool.statements, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(pool.statements[i], want) {
+					t.Errorf("statement %d = %q, want prefix %q", i, pool.statements[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestConversationRepository_ListByMetadataSQL(t *testing.T) {
+	db, statements := newDryRunDatabase(t)
+	repo := NewConversationRepository(db)
+
+	page, err := repo.ListByMetadata(context.Background(), "project", "foo", &ListOptions{Limit: 10, Offset: 20})
+	if err != nil {
+		t.Fatalf("ListByMetadata() error = %v", err)
+	}
+	if page.Offset != 20 || page.Limit != 10 {
+		t.Errorf("page offset/limit = %d/%d, want 20/10", page.Offset, page.Limit)
+	}
+
+	want := []string{
+		`SELECT count(*) FROM "conversations" WHERE metadata @> $1::jsonb AND "conversations"."deleted_at" IS NULL`,
+		`SELECT * FROM "conversations" WHERE metadata @> $1::jsonb AND "conversations"."deleted_at" IS NULL ORDER BY created_at DESC, id LIMIT $2 OFFSET $3`,
+	}
+	if len(*statements) != len(want) {
+		t.Fatalf("statements = %q, want %d", *statements, len(want))
+	}
+	for i, w := range want {
+		if (*statements)[i] != w {
+			t.Errorf("statement %d = %q, want %q", i, (*statements)[i], w)
+		}
+	}
+}
+
+func TestJSONContains(t *testing.T) {
+	stored := map[string]interface{}{
+		"project": "foo",
+		"tags":    []interface{}{"a", "b"},
+		"owner":   map[string]interface{}{"team": "core", "id": float64(7)},
+	}
+
+	tests := []struct {
+		name string
+		want interface{}
+		ok   bool
+	}{
+		{"matching string", map[string]interface{}{"project": "foo"}, true},
+		{"different string", map[string]interface{}{"project": "bar"}, false},
+		{"array element", map[string]interface{}{"tags": []interface{}{"b"}}, true},
+		{"scalar against array", map[string]interface{}{"tags": "b"}, false},
+		{"nested subset", map[string]interface{}{"owner": map[string]interface{}{"id": float64(7)}}, true},
+		{"missing key", map[string]interface{}{"team": "core"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonContains(stored, tt.want); got != tt.ok {
+				t.Errorf("jsonContains() = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
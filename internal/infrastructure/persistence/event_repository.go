@@ -0,0 +1,122 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/events"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+)
+
+// InMemoryEventRepository keeps the most recent domain events in publish order.
+// Handlers republish an aggregate's pending events, so an event already stored
+// under the same ID is ignored.
+type InMemoryEventRepository struct {
+	mu       sync.RWMutex
+	events   []interface{}
+	ids      map[string]struct{}
+	capacity int
+}
+
+// NewInMemoryEventRepository creates a repository keeping at most capacity events,
+// dropping the oldest first (0 keeps every event)
+func NewInMemoryEventRepository(capacity int) *InMemoryEventRepository {
+	return &InMemoryEventRepository{
+		ids:      make(map[string]struct{}),
+		capacity: capacity,
+	}
+}
+
+// Publish stores the event, so the repository can serve as the handlers' event publisher
+func (r *InMemoryEventRepository) Publish(ctx context.Context, event interface{}) error {
+	return r.Store(ctx, event)
+}
+
+// Store stores a domain event
+func (r *InMemoryEventRepository) Store(ctx context.Context, event interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if domainEvent, ok := event.(events.DomainEvent); ok {
+		if _, seen := r.ids[domainEvent.EventID()]; seen {
+			return nil
+		}
+		r.ids[domainEvent.EventID()] = struct{}{}
+	}
+
+	r.events = append(r.events, event)
+	if r.capacity > 0 && len(r.events) > r.capacity {
+		dropped := len(r.events) - r.capacity
+		for _, old := range r.events[:dropped] {
+			if domainEvent, ok := old.(events.DomainEvent); ok {
+				delete(r.ids, domainEvent.EventID())
+			}
+		}
+		r.events = append([]interface{}(nil), r.events[dropped:]...)
+	}
+	return nil
+}
+
+// StoreAll stores multiple domain events
+func (r *InMemoryEventRepository) StoreAll(ctx context.Context, events []interface{}) error {
+	for _, event := range events {
+		if err := r.Store(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByAggregateID retrieves events by aggregate ID
+func (r *InMemoryEventRepository) FindByAggregateID(ctx context.Context, aggregateID string) ([]interface{}, error) {
+	return r.filter(func(event events.DomainEvent) bool {
+		return event.AggregateID() == aggregateID
+	}), nil
+}
+
+// FindByEventType retrieves events by type
+func (r *InMemoryEventRepository) FindByEventType(ctx context.Context, eventType string) ([]interface{}, error) {
+	return r.filter(func(event events.DomainEvent) bool {
+		return event.EventType() == eventType
+	}), nil
+}
+
+// FindAll retrieves events oldest first, skipping offset events and returning at most
+// limit (0 returns the rest)
+func (r *InMemoryEventRepository) FindAll(ctx context.Context, offset, limit int) ([]interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if offset >= len(r.events) {
+		return []interface{}{}, nil
+	}
+	end := len(r.events)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return append([]interface{}(nil), r.events[offset:end]...), nil
+}
+
+// Count returns the number of stored events
+func (r *InMemoryEventRepository) Count(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.events), nil
+}
+
+// filter returns the stored domain events matching match, oldest first
+func (r *InMemoryEventRepository) filter(match func(events.DomainEvent) bool) []interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]interface{}, 0)
+	for _, event := range r.events {
+		if domainEvent, ok := event.(events.DomainEvent); ok && match(domainEvent) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// Ensure interface compliance
+var _ repositories.IEventRepository = (*InMemoryEventRepository)(nil)
@@ -0,0 +1,351 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence/models"
+)
+
+// Aggregates are mapped to rows through their snapshots, the same state the in-memory
+// store writes to disk, and restored with the snapshot Restore functions.
+
+// sessionToModel returns the row of a session
+func sessionToModel(session *aggregates.Session) (*SessionModel, error) {
+	snapshot := session.Snapshot()
+	model := &SessionModel{
+		ID:              snapshot.ID,
+		ProtocolVersion: snapshot.ProtocolVersion,
+		State:           string(snapshot.State),
+		LogLevel:        string(snapshot.LogLevel),
+		Metadata:        JSONB(snapshot.Metadata),
+		Tools:           models.StringArray(snapshot.Tools),
+		Subscriptions:   models.StringArray(snapshot.Subscriptions),
+		CreatedAt:       snapshot.CreatedAt,
+		UpdatedAt:       snapshot.UpdatedAt,
+		ClosedAt:        snapshot.ClosedAt,
+	}
+	if snapshot.ClientInfo != nil {
+		model.ClientName = snapshot.ClientInfo.Name
+		model.ClientVersion = snapshot.ClientInfo.Version
+	}
+	if snapshot.ServerInfo != nil {
+		model.ServerName = snapshot.ServerInfo.Name
+		model.ServerVersion = snapshot.ServerInfo.Version
+	}
+	if snapshot.Store != nil {
+		model.Store = make(JSONB, len(snapshot.Store))
+		for key, value := range snapshot.Store {
+			model.Store[key] = value
+		}
+	}
+
+	var err error
+	if model.Capabilities, err = toJSONB(snapshot.Capabilities); err != nil {
+		return nil, fmt.Errorf("session %s: capabilities: %w", snapshot.ID, err)
+	}
+	return model, nil
+}
+
+// sessionFromModel restores a session from its row. Tools that are no longer
+// registered are dropped from the session.
+func sessionFromModel(model *SessionModel, tools map[string]*entities.Tool, conversations []*aggregates.Conversation) (*aggregates.Session, error) {
+	snapshot := aggregates.SessionSnapshot{
+		ID:              model.ID,
+		ProtocolVersion: model.ProtocolVersion,
+		State:           aggregates.SessionState(model.State),
+		ServerInfo:      &aggregates.ServerInfo{Name: model.ServerName, Version: model.ServerVersion},
+		Tools:           registeredToolNames(model.Tools, tools),
+		Subscriptions:   model.Subscriptions,
+		LogLevel:        vo.MCPLogLevel(model.LogLevel),
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+		ClosedAt:        model.ClosedAt,
+		Metadata:        model.Metadata,
+	}
+	if model.ClientName != "" || model.ClientVersion != "" {
+		snapshot.ClientInfo = &aggregates.ClientInfo{Name: model.ClientName, Version: model.ClientVersion}
+	}
+	if model.Capabilities != nil {
+		snapshot.Capabilities = &aggregates.SessionCapabilities{}
+		if err := convertJSON(model.Capabilities, snapshot.Capabilities); err != nil {
+			return nil, fmt.Errorf("%w: session %s: capabilities: %v", aggregates.ErrInvalidSnapshot, model.ID, err)
+		}
+	}
+	if model.Store != nil {
+		snapshot.Store = make(map[string]string, len(model.Store))
+		for key, value := range model.Store {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: session %s: store key %s is not a string", aggregates.ErrInvalidSnapshot, model.ID, key)
+			}
+			snapshot.Store[key] = s
+		}
+	}
+	return aggregates.RestoreSession(snapshot, tools, conversations)
+}
+
+// conversationToModel returns the row of a conversation and the rows of its messages
+func conversationToModel(conversation *aggregates.Conversation) (*ConversationModel, []MessageModel, error) {
+	snapshot := conversation.Snapshot()
+	model := &ConversationModel{
+		ID:            snapshot.ID,
+		SessionID:     snapshot.SessionID,
+		Model:         string(snapshot.Model),
+		SystemPrompt:  snapshot.SystemPrompt,
+		Status:        string(snapshot.Status),
+		MaxTokens:     snapshot.MaxTokens,
+		Temperature:   snapshot.Temperature,
+		TopP:          snapshot.TopP,
+		TopK:          snapshot.TopK,
+		StopSequences: models.StringArray(snapshot.StopSequences),
+		Tools:         models.StringArray(snapshot.Tools),
+		Metadata:      JSONB(snapshot.Metadata),
+		CreatedAt:     snapshot.CreatedAt,
+		UpdatedAt:     snapshot.UpdatedAt,
+		ClosedAt:      snapshot.ClosedAt,
+	}
+
+	var err error
+	if model.ToolUse, err = toJSONB(snapshot.ToolUse); err != nil {
+		return nil, nil, fmt.Errorf("conversation %s: tool use: %w", snapshot.ID, err)
+	}
+
+	messages := make([]MessageModel, len(snapshot.Messages))
+	for i, message := range snapshot.Messages {
+		content := JSONBArray{}
+		if err := convertJSON(message.Content, &content); err != nil {
+			return nil, nil, fmt.Errorf("message %s: content: %w", message.ID, err)
+		}
+		if content == nil {
+			content = JSONBArray{}
+		}
+		messages[i] = MessageModel{
+			ID:             message.ID,
+			ConversationID: snapshot.ID,
+			Position:       i,
+			Role:           string(message.Role),
+			Content:        content,
+			Metadata:       JSONB(message.Metadata),
+			CreatedAt:      message.CreatedAt,
+		}
+	}
+	return model, messages, nil
+}
+
+// conversationFromModel restores a conversation from its row and the rows of its
+// messages in order. Tools that are no longer registered are dropped from the
+// conversation.
+func conversationFromModel(model *ConversationModel, messages []MessageModel, tools map[string]*entities.Tool) (*aggregates.Conversation, error) {
+	snapshot := aggregates.ConversationSnapshot{
+		ID:            model.ID,
+		SessionID:     model.SessionID,
+		Model:         vo.Model(model.Model),
+		SystemPrompt:  model.SystemPrompt,
+		Messages:      make([]entities.MessageSnapshot, len(messages)),
+		Status:        aggregates.ConversationStatus(model.Status),
+		MaxTokens:     model.MaxTokens,
+		Temperature:   model.Temperature,
+		TopP:          model.TopP,
+		TopK:          model.TopK,
+		StopSequences: model.StopSequences,
+		Tools:         registeredToolNames(model.Tools, tools),
+		ToolUse:       vo.DefaultToolUseSettings(),
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+		ClosedAt:      model.ClosedAt,
+		Metadata:      model.Metadata,
+	}
+	if model.ToolUse != nil {
+		if err := convertJSON(model.ToolUse, &snapshot.ToolUse); err != nil {
+			return nil, fmt.Errorf("%w: conversation %s: tool use: %v", aggregates.ErrInvalidSnapshot, model.ID, err)
+		}
+	}
+	for i, message := range messages {
+		snapshot.Messages[i] = entities.MessageSnapshot{
+			ID:        message.ID,
+			Role:      vo.Role(message.Role),
+			CreatedAt: message.CreatedAt,
+			Metadata:  message.Metadata,
+		}
+		if err := convertJSON(message.Content, &snapshot.Messages[i].Content); err != nil {
+			return nil, fmt.Errorf("%w: message %s: content: %v", aggregates.ErrInvalidSnapshot, message.ID, err)
+		}
+	}
+	return aggregates.RestoreConversation(snapshot, tools)
+}
+
+// toolToModel returns the row of a tool. The ID is only used when the row is created;
+// a tool registered again keeps the ID of its row.
+func toolToModel(tool *entities.Tool) (*ToolModel, error) {
+	snapshot := tool.Snapshot()
+	model := &ToolModel{
+		ID:          uuid.New().String(),
+		Name:        snapshot.Name,
+		Description: snapshot.Description,
+		Category:    snapshot.Category,
+		Tags:        models.StringArray(snapshot.Tags),
+		IsEnabled:   snapshot.Enabled,
+		Cacheable:   snapshot.Cacheable,
+		Timeout:     int(snapshot.Timeout / time.Second),
+		Metadata:    JSONB(snapshot.Metadata),
+		CreatedAt:   snapshot.CreatedAt,
+		UpdatedAt:   snapshot.UpdatedAt,
+	}
+
+	var err error
+	if model.InputSchema, err = toJSONB(snapshot.InputSchema); err != nil {
+		return nil, fmt.Errorf("tool %s: input schema: %w", snapshot.Name, err)
+	}
+	if model.RateLimit, err = toJSONB(snapshot.RateLimit); err != nil {
+		return nil, fmt.Errorf("tool %s: rate limit: %w", snapshot.Name, err)
+	}
+	if model.Annotations, err = toJSONB(snapshot.Annotations); err != nil {
+		return nil, fmt.Errorf("tool %s: annotations: %w", snapshot.Name, err)
+	}
+	if snapshot.Examples != nil {
+		if err := convertJSON(snapshot.Examples, &model.Examples); err != nil {
+			return nil, fmt.Errorf("tool %s: examples: %w", snapshot.Name, err)
+		}
+	}
+	return model, nil
+}
+
+// resourceFromModel returns the resource of a row, with its audience and priority
+// columns as annotations
+func resourceFromModel(model *models.Resource) (*entities.Resource, error) {
+	var resource *entities.Resource
+	if model.IsTemplate {
+		template, err := entities.NewResourceTemplate(model.URITemplate, model.Name, model.Description)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", model.URI, err)
+		}
+		resource = template
+	} else {
+		uri, err := vo.NewResourceURI(model.URI)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", model.URI, err)
+		}
+		if resource, err = entities.NewResource(uri, model.Name); err != nil {
+			return nil, fmt.Errorf("resource %s: %w", model.URI, err)
+		}
+		resource.SetDescription(model.Description)
+	}
+	if model.MimeType != "" {
+		mimeType, err := vo.NewMimeType(model.MimeType)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", model.URI, err)
+		}
+		resource.SetMimeType(mimeType)
+	}
+	if len(model.Audience) > 0 || model.Priority != nil {
+		annotations := &entities.ResourceAnnotations{Audience: append([]string(nil), model.Audience...)}
+		if model.Priority != nil {
+			priority := *model.Priority
+			annotations.Priority = &priority
+		}
+		if err := resource.SetAnnotations(annotations); err != nil {
+			return nil, fmt.Errorf("resource %s: %w", model.URI, err)
+		}
+	}
+	for key, value := range model.Metadata {
+		resource.SetMetadata(key, value)
+	}
+	return resource, nil
+}
+
+// promptDefinitionFromModel returns the prompt definition of a row
+func promptDefinitionFromModel(model *models.Prompt) (PromptDefinition, error) {
+	definition := PromptDefinition{
+		Name:        model.Name,
+		Description: model.Description,
+		Template:    model.Template,
+	}
+	if err := convertJSON(model.Arguments, &definition.Arguments); err != nil {
+		return PromptDefinition{}, fmt.Errorf("prompt %s: arguments: %w", model.Name, err)
+	}
+	return definition, nil
+}
+
+// conversationTemplateFromModel returns the conversation template of a row. Nullable
+// sampling settings stay unset so conversations keep their defaults.
+func conversationTemplateFromModel(model *models.ConversationTemplate) (*entities.ConversationTemplate, error) {
+	name, err := vo.NewToolName(model.Name)
+	if err != nil {
+		return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+	}
+	template, err := entities.NewConversationTemplate(name, vo.Model(model.Model))
+	if err != nil {
+		return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+	}
+	template.SetDescription(model.Description)
+	if model.SystemPrompt != "" {
+		systemPrompt, err := vo.NewSystemPrompt(model.SystemPrompt)
+		if err != nil {
+			return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+		}
+		template.SetSystemPrompt(systemPrompt)
+	}
+	for _, tool := range model.Tools {
+		template.AddTool(tool)
+	}
+	if err := template.SetMaxTokens(model.MaxTokens); err != nil {
+		return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+	}
+	if model.Temperature != nil {
+		if err := template.SetTemperature(*model.Temperature); err != nil {
+			return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+		}
+	}
+	if model.TopP != nil {
+		if err := template.SetTopP(*model.TopP); err != nil {
+			return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+		}
+	}
+	if model.TopK != nil {
+		if err := template.SetTopK(*model.TopK); err != nil {
+			return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+		}
+	}
+	if len(model.StopSequences) > 0 {
+		if err := template.SetStopSequences(model.StopSequences); err != nil {
+			return nil, fmt.Errorf("conversation template %s: %w", model.Name, err)
+		}
+	}
+	return template, nil
+}
+
+// registeredToolNames returns the names that have a tool in tools
+func registeredToolNames(names []string, tools map[string]*entities.Tool) []string {
+	registered := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := tools[name]; ok {
+			registered = append(registered, name)
+		}
+	}
+	return registered
+}
+
+// toJSONB returns the JSON object value encodes to, or nil for a nil value
+func toJSONB(value interface{}) (JSONB, error) {
+	var object JSONB
+	if err := convertJSON(value, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// convertJSON decodes the JSON encoding of from into to
+func convertJSON(from, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
@@ -0,0 +1,603 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// The GORM repositories store aggregates in PostgreSQL. Every Save writes the rows
+// through, and the aggregates a repository has loaded or saved are kept, so callers
+// holding one and callers looking it up share a single instance as they do with the
+// in-memory repositories. That assumes one server process per database. Closed
+// sessions are not kept, so the loaded sessions stay bounded by the open ones.
+
+// GormSessionRepository implements ISessionRepository using GORM
+type GormSessionRepository struct {
+	db            *Database
+	sessions      *SessionRepository
+	conversations *GormConversationRepository
+	tools         *GormToolRepository
+
+	mu     sync.Mutex
+	loaded map[string]*aggregates.Session
+}
+
+// NewGormSessionRepository creates a session repository. Sessions are loaded with
+// their conversations from conversations and their tools from tools.
+func NewGormSessionRepository(db *Database, conversations *GormConversationRepository, tools *GormToolRepository) *GormSessionRepository {
+	return &GormSessionRepository{
+		db:            db,
+		sessions:      NewSessionRepository(db),
+		conversations: conversations,
+		tools:         tools,
+		loaded:        make(map[string]*aggregates.Session),
+	}
+}
+
+func (r *GormSessionRepository) Save(ctx context.Context, session *aggregates.Session) error {
+	model, err := sessionToModel(session)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).
+		Select("*").Omit(clause.Associations).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, UpdateAll: true}).
+		Create(model).Error
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	if session.IsClosed() {
+		delete(r.loaded, session.ID().String())
+	} else {
+		r.loaded[session.ID().String()] = session
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *GormSessionRepository) FindByID(ctx context.Context, id vo.SessionID) (*aggregates.Session, error) {
+	r.mu.Lock()
+	session, ok := r.loaded[id.String()]
+	r.mu.Unlock()
+	if ok {
+		return session, nil
+	}
+
+	var model SessionModel
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id.String()).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := r.restore(ctx, []SessionModel{model})
+	if err != nil {
+		return nil, err
+	}
+	return sessions[0], nil
+}
+
+func (r *GormSessionRepository) FindAll(ctx context.Context) ([]*aggregates.Session, error) {
+	var models []SessionModel
+	if err := r.db.WithContext(ctx).Order("created_at DESC, id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return r.restore(ctx, models)
+}
+
+func (r *GormSessionRepository) FindActive(ctx context.Context) ([]*aggregates.Session, error) {
+	var models []SessionModel
+	err := r.db.WithContext(ctx).
+		Where("state = ?", string(aggregates.SessionStateReady)).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.restore(ctx, models)
+}
+
+func (r *GormSessionRepository) Delete(ctx context.Context, id vo.SessionID) error {
+	if err := r.sessions.Delete(ctx, id.String()); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
+	}
+	r.mu.Lock()
+	delete(r.loaded, id.String())
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *GormSessionRepository) Exists(ctx context.Context, id vo.SessionID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&SessionModel{}).Where("id = ?", id.String()).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormSessionRepository) Count(ctx context.Context) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&SessionModel{}).Count(&count).Error
+	return int(count), err
+}
+
+func (r *GormSessionRepository) CountOpen(ctx context.Context) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&SessionModel{}).
+		Where("state <> ?", string(aggregates.SessionStateClosed)).
+		Count(&count).Error
+	return int(count), err
+}
+
+// Query retrieves a page of the sessions matching filter
+func (r *GormSessionRepository) Query(ctx context.Context, filter repositories.SessionFilter) (*repositories.SessionPage, error) {
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	models, total, err := r.sessions.Query(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := r.restore(ctx, models)
+	if err != nil {
+		return nil, err
+	}
+	return &repositories.SessionPage{Sessions: sessions, Total: int(total), Offset: filter.Offset, Limit: filter.Limit}, nil
+}
+
+// CloseAbandoned closes the sessions an earlier run left open and returns how many it
+// closed. A session ends with its connection, so none of them can be continued, and
+// closing them keeps them from counting against the session limit. Their resource
+// subscriptions are removed too. Call it before the server accepts connections.
+func (r *GormSessionRepository) CloseAbandoned(ctx context.Context) (int64, error) {
+	var closed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		result := tx.Model(&SessionModel{}).
+			Where("state <> ?", string(aggregates.SessionStateClosed)).
+			Updates(map[string]interface{}{
+				"state":      string(aggregates.SessionStateClosed),
+				"closed_at":  now,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		closed = result.RowsAffected
+
+		closedSessions := tx.Model(&SessionModel{}).Select("id").Where("state = ?", string(aggregates.SessionStateClosed))
+		return tx.Where("session_id IN (?)", closedSessions).Delete(&ResourceSubscriptionModel{}).Error
+	})
+	return closed, err
+}
+
+// restore returns the sessions of models, in order, reusing the ones already loaded
+func (r *GormSessionRepository) restore(ctx context.Context, models []SessionModel) ([]*aggregates.Session, error) {
+	r.mu.Lock()
+	missing := make([]string, 0)
+	for _, model := range models {
+		if _, ok := r.loaded[model.ID]; !ok {
+			missing = append(missing, model.ID)
+		}
+	}
+	r.mu.Unlock()
+
+	var conversations map[string][]*aggregates.Conversation
+	if len(missing) > 0 {
+		var err error
+		if conversations, err = r.conversations.findBySessionIDs(ctx, missing); err != nil {
+			return nil, err
+		}
+	}
+	tools := r.tools.registeredTools()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sessions := make([]*aggregates.Session, 0, len(models))
+	for i := range models {
+		session, ok := r.loaded[models[i].ID]
+		if !ok {
+			var err error
+			if session, err = sessionFromModel(&models[i], tools, conversations[models[i].ID]); err != nil {
+				return nil, err
+			}
+			if !session.IsClosed() {
+				r.loaded[models[i].ID] = session
+			}
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+var _ repositories.ISessionRepository = (*GormSessionRepository)(nil)
+
+// GormConversationRepository implements IConversationRepository using GORM
+type GormConversationRepository struct {
+	db            *Database
+	conversations *ConversationRepository
+	tools         *GormToolRepository
+
+	mu     sync.Mutex
+	loaded map[string]*aggregates.Conversation
+}
+
+// NewGormConversationRepository creates a conversation repository. Conversations are
+// loaded with their tools from tools.
+func NewGormConversationRepository(db *Database, tools *GormToolRepository) *GormConversationRepository {
+	return &GormConversationRepository{
+		db:            db,
+		conversations: NewConversationRepository(db),
+		tools:         tools,
+		loaded:        make(map[string]*aggregates.Conversation),
+	}
+}
+
+// Save writes the conversation and replaces its messages in one transaction
+func (r *GormConversationRepository) Save(ctx context.Context, conversation *aggregates.Conversation) error {
+	model, messages, err := conversationToModel(conversation)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Select("*").Omit(clause.Associations).
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, UpdateAll: true}).
+			Create(model).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("conversation_id = ?", model.ID).Delete(&MessageModel{}).Error; err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		return tx.Select("*").Omit(clause.Associations).CreateInBatches(messages, 100).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.loaded[conversation.ID().String()] = conversation
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *GormConversationRepository) FindByID(ctx context.Context, id vo.ConversationID) (*aggregates.Conversation, error) {
+	r.mu.Lock()
+	conversation, ok := r.loaded[id.String()]
+	r.mu.Unlock()
+	if ok {
+		return conversation, nil
+	}
+
+	var model ConversationModel
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id.String()).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	conversations, err := r.restore(ctx, []ConversationModel{model})
+	if err != nil {
+		return nil, err
+	}
+	return conversations[0], nil
+}
+
+func (r *GormConversationRepository) FindBySessionID(ctx context.Context, sessionID vo.SessionID) ([]*aggregates.Conversation, error) {
+	var models []ConversationModel
+	err := r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID.String()).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.restore(ctx, models)
+}
+
+func (r *GormConversationRepository) FindActive(ctx context.Context) ([]*aggregates.Conversation, error) {
+	var models []ConversationModel
+	err := r.db.WithContext(ctx).
+		Where("status = ?", string(aggregates.ConversationStatusActive)).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.restore(ctx, models)
+}
+
+func (r *GormConversationRepository) Delete(ctx context.Context, id vo.ConversationID) error {
+	if err := r.conversations.Delete(ctx, id.String()); err != nil {
+		if errors.Is(err, ErrConversationNotFound) {
+			return aggregates.ErrConversationNotFound
+		}
+		return err
+	}
+	r.mu.Lock()
+	delete(r.loaded, id.String())
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *GormConversationRepository) Exists(ctx context.Context, id vo.ConversationID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ConversationModel{}).Where("id = ?", id.String()).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormConversationRepository) Count(ctx context.Context) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ConversationModel{}).Count(&count).Error
+	return int(count), err
+}
+
+func (r *GormConversationRepository) CountBySessionID(ctx context.Context, sessionID vo.SessionID) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ConversationModel{}).Where("session_id = ?", sessionID.String()).Count(&count).Error
+	return int(count), err
+}
+
+// FindByMetadata retrieves a page of summaries of conversations whose metadata key holds value
+func (r *GormConversationRepository) FindByMetadata(ctx context.Context, key string, value interface{}, offset, limit int) (*repositories.ConversationSummaryPage, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	return r.conversations.ListByMetadata(ctx, key, value, &ListOptions{Offset: offset, Limit: limit})
+}
+
+// findBySessionIDs returns the conversations of each of the given sessions
+func (r *GormConversationRepository) findBySessionIDs(ctx context.Context, sessionIDs []string) (map[string][]*aggregates.Conversation, error) {
+	var models []ConversationModel
+	err := r.db.WithContext(ctx).
+		Where("session_id IN ?", sessionIDs).
+		Order("created_at DESC, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	conversations, err := r.restore(ctx, models)
+	if err != nil {
+		return nil, err
+	}
+
+	bySession := make(map[string][]*aggregates.Conversation, len(sessionIDs))
+	for i, conversation := range conversations {
+		bySession[models[i].SessionID] = append(bySession[models[i].SessionID], conversation)
+	}
+	return bySession, nil
+}
+
+// restore returns the conversations of models, in order, reusing the ones already
+// loaded. The messages of the others are read in one query.
+func (r *GormConversationRepository) restore(ctx context.Context, models []ConversationModel) ([]*aggregates.Conversation, error) {
+	r.mu.Lock()
+	missing := make([]string, 0)
+	for _, model := range models {
+		if _, ok := r.loaded[model.ID]; !ok {
+			missing = append(missing, model.ID)
+		}
+	}
+	r.mu.Unlock()
+
+	messages := make(map[string][]MessageModel, len(missing))
+	if len(missing) > 0 {
+		var rows []MessageModel
+		err := r.db.WithContext(ctx).
+			Where("conversation_id IN ?", missing).
+			Order("conversation_id, position").
+			Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			messages[row.ConversationID] = append(messages[row.ConversationID], row)
+		}
+	}
+	tools := r.tools.registeredTools()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conversations := make([]*aggregates.Conversation, 0, len(models))
+	for i := range models {
+		conversation, ok := r.loaded[models[i].ID]
+		if !ok {
+			var err error
+			if conversation, err = conversationFromModel(&models[i], messages[models[i].ID], tools); err != nil {
+				return nil, err
+			}
+			r.loaded[models[i].ID] = conversation
+		}
+		conversations = append(conversations, conversation)
+	}
+	return conversations, nil
+}
+
+var _ repositories.IConversationRepository = (*GormConversationRepository)(nil)
+
+// GormToolRepository implements IToolRepository using GORM. A tool is code as much as
+// data: the rows hold the definitions, while the handlers live in the tools this
+// process registered. Lookups return those registered tools, and rows an earlier run
+// wrote for tools this process did not register are not returned, since they could
+// not be called.
+type GormToolRepository struct {
+	db     *Database
+	logger zerolog.Logger
+
+	mu         sync.RWMutex
+	registered map[string]*entities.Tool
+}
+
+// NewGormToolRepository creates a tool repository
+func NewGormToolRepository(db *Database) *GormToolRepository {
+	return &GormToolRepository{
+		db:         db,
+		logger:     log.Logger,
+		registered: make(map[string]*entities.Tool),
+	}
+}
+
+// SetLogger sets the logger used to report tool overrides
+func (r *GormToolRepository) SetLogger(logger zerolog.Logger) {
+	r.logger = logger
+}
+
+// Register registers a tool, failing if this process registered one with the same
+// name. A row left by an earlier run is overwritten, as tools are registered anew at
+// every start.
+func (r *GormToolRepository) Register(ctx context.Context, tool *entities.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.registered[tool.Name().String()]; exists {
+		return fmt.Errorf("%w: %s", aggregates.ErrToolAlreadyRegistered, tool.Name())
+	}
+	if err := tool.ValidateExamples(); err != nil {
+		return err
+	}
+	return r.write(ctx, tool)
+}
+
+func (r *GormToolRepository) Replace(ctx context.Context, tool *entities.Tool) (bool, error) {
+	if err := tool.ValidateExamples(); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, replaced := r.registered[tool.Name().String()]
+	if replaced {
+		r.logger.Warn().Str("tool", tool.Name().String()).Msg("Overriding registered tool")
+	}
+	return replaced, r.write(ctx, tool)
+}
+
+func (r *GormToolRepository) Update(ctx context.Context, tool *entities.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.registered[tool.Name().String()]; !exists {
+		return fmt.Errorf("%w: %s", aggregates.ErrToolNotRegistered, tool.Name())
+	}
+	return r.write(ctx, tool)
+}
+
+// write upserts the tool's row by name and records the tool. The caller holds r.mu.
+func (r *GormToolRepository) write(ctx context.Context, tool *entities.Tool) error {
+	model, err := toolToModel(tool)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).
+		Select("*").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"description", "input_schema", "category", "tags", "is_enabled", "rate_limit",
+				"annotations", "examples", "cacheable", "timeout", "metadata", "updated_at", "deleted_at",
+			}),
+		}).
+		Create(model).Error
+	if err != nil {
+		return err
+	}
+	r.registered[tool.Name().String()] = tool
+	return nil
+}
+
+func (r *GormToolRepository) Unregister(ctx context.Context, name vo.ToolName) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Delete the row for good so the name can be registered again
+	if err := r.db.WithContext(ctx).Unscoped().Where("name = ?", name.String()).Delete(&ToolModel{}).Error; err != nil {
+		return err
+	}
+	delete(r.registered, name.String())
+	return nil
+}
+
+// FindByName returns a tool registered by this process. Every registered tool has a
+// row, since registering writes it, so no query is needed.
+func (r *GormToolRepository) FindByName(ctx context.Context, name vo.ToolName) (*entities.Tool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.registered[name.String()], nil
+}
+
+func (r *GormToolRepository) FindAll(ctx context.Context) ([]*entities.Tool, error) {
+	return r.find(ctx, r.db.WithContext(ctx))
+}
+
+func (r *GormToolRepository) FindByCategory(ctx context.Context, category string) ([]*entities.Tool, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("category = ?", category))
+}
+
+func (r *GormToolRepository) FindByTag(ctx context.Context, tag string) ([]*entities.Tool, error) {
+	filter, err := json.Marshal([]string{tag})
+	if err != nil {
+		return nil, err
+	}
+	return r.find(ctx, r.db.WithContext(ctx).Where("tags @> ?::jsonb", string(filter)))
+}
+
+func (r *GormToolRepository) FindEnabled(ctx context.Context) ([]*entities.Tool, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("is_enabled = ?", true))
+}
+
+func (r *GormToolRepository) Exists(ctx context.Context, name vo.ToolName) (bool, error) {
+	tool, err := r.FindByName(ctx, name)
+	return tool != nil, err
+}
+
+func (r *GormToolRepository) Count(ctx context.Context) (int, error) {
+	tools, err := r.FindAll(ctx)
+	return len(tools), err
+}
+
+// find returns the registered tools whose rows match query, ordered by name
+func (r *GormToolRepository) find(ctx context.Context, query *gorm.DB) ([]*entities.Tool, error) {
+	var names []string
+	if err := query.Model(&ToolModel{}).Order("name").Pluck("name", &names).Error; err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tools := make([]*entities.Tool, 0, len(names))
+	for _, name := range names {
+		if tool, ok := r.registered[name]; ok {
+			tools = append(tools, tool)
+		}
+	}
+	return tools, nil
+}
+
+// registeredTools returns the tools registered by this process by name, for resolving
+// the tool names stored with sessions and conversations
+func (r *GormToolRepository) registeredTools() map[string]*entities.Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tools := make(map[string]*entities.Tool, len(r.registered))
+	for name, tool := range r.registered {
+		tools[name] = tool
+	}
+	return tools
+}
+
+var _ repositories.IToolRepository = (*GormToolRepository)(nil)
@@ -0,0 +1,323 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence/models"
+	"gorm.io/gorm"
+)
+
+func newSearchTool(t *testing.T) *entities.Tool {
+	t.Helper()
+	name, _ := vo.NewToolName("search")
+	desc, _ := vo.NewToolDescription("Search the notes")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{
+		Type:       "object",
+		Properties: map[string]*entities.JSONSchema{"query": {Type: "string"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetTags([]string{"notes"})
+	return tool
+}
+
+// snapshotJSON returns the JSON encoding of a snapshot, to compare aggregates
+func snapshotJSON(t *testing.T, snapshot interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestGormMapping_RoundTrip(t *testing.T) {
+	tool := newSearchTool(t)
+	tools := map[string]*entities.Tool{"search": tool}
+
+	session := aggregates.NewSession()
+	if err := session.Initialize(&aggregates.ClientInfo{Name: "claude-desktop", Version: "1.0.0"}, "2025-03-26"); err != nil {
+		t.Fatal(err)
+	}
+	session.MarkReady()
+	if err := session.RegisterTool(tool); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.SubscribeResource("status://health"); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Store().Set("cursor", "42"); err != nil {
+		t.Fatal(err)
+	}
+	session.SetMetadata("team", "observability")
+
+	conv, err := session.CreateConversation(vo.ModelClaude4Sonnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv.SetTemperature(0)
+	conv.AddTool(tool)
+	if _, err := conv.AddUserMessage("Find my notes"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conv.AddAssistantMessage([]entities.ContentBlock{{
+		Type:  vo.ContentTypeToolUse,
+		ID:    "toolu_1",
+		Name:  "search",
+		Input: map[string]interface{}{"query": "notes"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	convModel, messages, err := conversationToModel(conv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || messages[1].Position != 1 {
+		t.Fatalf("messages = %+v, want 2 in order", messages)
+	}
+	restoredConv, err := conversationFromModel(convModel, messages, tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := snapshotJSON(t, restoredConv.Snapshot()), snapshotJSON(t, conv.Snapshot()); got != want {
+		t.Errorf("restored conversation = %s\nwant %s", got, want)
+	}
+
+	sessionModel, err := sessionToModel(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := sessionFromModel(sessionModel, tools, []*aggregates.Conversation{restoredConv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := snapshotJSON(t, restored.Snapshot()), snapshotJSON(t, session.Snapshot()); got != want {
+		t.Errorf("restored session = %s\nwant %s", got, want)
+	}
+	if _, ok := restored.GetConversation(conv.ID()); !ok {
+		t.Error("expected the restored session to hold its conversation")
+	}
+
+	// A tool that is no longer registered is dropped rather than failing the load
+	restored, err = sessionFromModel(sessionModel, map[string]*entities.Tool{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := restored.Snapshot().Tools; len(names) != 0 {
+		t.Errorf("restored session tools = %v, want none", names)
+	}
+}
+
+func TestGormMapping_ResourceAnnotations(t *testing.T) {
+	priority := 0.4
+	resource, err := resourceFromModel(&models.Resource{
+		URI:         "docs://guide",
+		Name:        "Guide",
+		Description: "The user guide",
+		MimeType:    "text/markdown",
+		Audience:    models.StringArray{"assistant"},
+		Priority:    &priority,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations := resource.Annotations()
+	if annotations == nil || len(annotations.Audience) != 1 || annotations.Audience[0] != "assistant" ||
+		annotations.Priority == nil || *annotations.Priority != 0.4 {
+		t.Errorf("annotations = %+v, want the audience and priority columns", annotations)
+	}
+	if resource.Description() != "The user guide" || resource.MimeType().String() != "text/markdown" {
+		t.Errorf("description = %q mime type = %s", resource.Description(), resource.MimeType())
+	}
+
+	// Rows without annotation columns, such as the file template, have no annotations
+	template, err := resourceFromModel(&models.Resource{URI: "file:///{path}", URITemplate: "file:///{path}", Name: "File Resource", IsTemplate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !template.IsTemplate() || template.Annotations() != nil {
+		t.Errorf("template = %v annotations = %+v", template.IsTemplate(), template.Annotations())
+	}
+
+	// An out of range priority stored in the database is rejected
+	invalid := 1.5
+	if _, err := resourceFromModel(&models.Resource{URI: "docs://bad", Name: "Bad", Priority: &invalid}); !errors.Is(err, entities.ErrInvalidResourcePriority) {
+		t.Errorf("resourceFromModel() error = %v, want ErrInvalidResourcePriority", err)
+	}
+}
+
+func TestGormRepositories_SQL(t *testing.T) {
+	ctx := context.Background()
+	db, statements := newDryRunDatabase(t)
+	tools := NewGormToolRepository(db)
+	sessions := NewGormSessionRepository(db, NewGormConversationRepository(db, tools), tools)
+
+	disabled := newSearchTool(t)
+	disabled.Disable()
+
+	steps := []struct {
+		name     string
+		run      func() error
+		prefix   string
+		contains []string
+	}{
+		{
+			name:   "save session",
+			run:    func() error { return sessions.Save(ctx, aggregates.NewSession()) },
+			prefix: `INSERT INTO "sessions"`,
+			// Zero values are written too, rather than replaced by column defaults
+			contains: []string{`"client_name"`, `"store"`, `ON CONFLICT ("id") DO UPDATE SET`},
+		},
+		{
+			name: "count open sessions",
+			run: func() error {
+				_, err := sessions.CountOpen(ctx)
+				return err
+			},
+			prefix: `SELECT count(*) FROM "sessions" WHERE state <> $1 AND "sessions"."deleted_at" IS NULL`,
+		},
+		{
+			name:     "register tool",
+			run:      func() error { return tools.Register(ctx, disabled) },
+			prefix:   `INSERT INTO "tools"`,
+			contains: []string{`"is_enabled"`, `"cacheable"`, `ON CONFLICT ("name") DO UPDATE SET`},
+		},
+		{
+			name: "find tools by tag",
+			run: func() error {
+				_, err := tools.FindByTag(ctx, "notes")
+				return err
+			},
+			prefix: `SELECT "name" FROM "tools" WHERE tags @> $1::jsonb AND "tools"."deleted_at" IS NULL ORDER BY name`,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			*statements = nil
+			if err := step.run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(*statements) != 1 {
+				t.Fatalf("statements = %q, want 1", *statements)
+			}
+			statement := (*statements)[0]
+			if !strings.HasPrefix(statement, step.prefix) {
+				t.Errorf("statement = %q, want prefix %q", statement, step.prefix)
+			}
+			for _, want := range step.contains {
+				if !strings.Contains(statement, want) {
+					t.Errorf("statement = %q, want it to contain %q", statement, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGormSessionRepository_DoesNotKeepClosedSessions(t *testing.T) {
+	ctx := context.Background()
+	db, _ := newDryRunDatabase(t)
+	tools := NewGormToolRepository(db)
+	sessions := NewGormSessionRepository(db, NewGormConversationRepository(db, tools), tools)
+
+	session := aggregates.NewSession()
+	if err := sessions.Save(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions.loaded) != 1 {
+		t.Fatalf("loaded = %d sessions, want the open session", len(sessions.loaded))
+	}
+
+	session.Close()
+	if err := sessions.Save(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions.loaded) != 0 {
+		t.Errorf("loaded = %d sessions, want the closed session dropped", len(sessions.loaded))
+	}
+}
+
+func TestHandleSetToolEnabled_PersistsToGormRepository(t *testing.T) {
+	ctx := context.Background()
+	db, statements := newDryRunDatabase(t)
+	tools := NewGormToolRepository(db)
+	sessions := NewGormSessionRepository(db, NewGormConversationRepository(db, tools), tools)
+	handler := handlers.NewToolHandler(sessions, tools, nil)
+
+	if err := tools.Register(ctx, newSearchTool(t)); err != nil {
+		t.Fatal(err)
+	}
+	*statements = nil
+
+	tool, err := handler.HandleSetToolEnabled(ctx, &commands.SetToolEnabledCommand{Name: "search", Enabled: false})
+	if err != nil {
+		t.Fatalf("HandleSetToolEnabled() error = %v", err)
+	}
+	if tool.IsEnabled() {
+		t.Error("expected the tool to be disabled")
+	}
+	if len(*statements) != 1 || !strings.HasPrefix((*statements)[0], `INSERT INTO "tools"`) ||
+		!strings.Contains((*statements)[0], `"is_enabled"="excluded"."is_enabled"`) {
+		t.Errorf("statements = %q, want the tool row written", *statements)
+	}
+}
+
+func TestContainsPattern_EscapesWildcards(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "claude", want: "%claude%"},
+		{input: "100%", want: `%100\%%`},
+		{input: "my_client", want: `%my\_client%`},
+		{input: `C:\tools`, want: `%C:\\tools%`},
+	}
+
+	for _, tt := range tests {
+		if got := containsPattern(tt.input); got != tt.want {
+			t.Errorf("containsPattern(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSeedTools_WritesDisabledTools(t *testing.T) {
+	db, _ := newDryRunDatabase(t)
+
+	// A dry run inserts nothing, so report each insert as done
+	created := func(tx *gorm.DB) { tx.RowsAffected = 1 }
+	if err := db.db.Callback().Create().After("gorm:create").Register("test:created", created); err != nil {
+		t.Fatal(err)
+	}
+	disabled := make(map[string]bool)
+	record := func(tx *gorm.DB) {
+		tool, ok := tx.Statement.Model.(*models.Tool)
+		values, isMap := tx.Statement.Dest.(map[string]interface{})
+		if ok && isMap && values["is_enabled"] == false {
+			disabled[tool.Name] = true
+		}
+	}
+	if err := db.db.Callback().Update().After("gorm:update").Register("test:disabled", record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SeedTools(context.Background(), db.db); err != nil {
+		t.Fatalf("SeedTools() error = %v", err)
+	}
+	if !disabled["list_processes"] {
+		t.Error("list_processes should be seeded disabled")
+	}
+	if disabled["echo"] {
+		t.Error("echo should be seeded enabled")
+	}
+}
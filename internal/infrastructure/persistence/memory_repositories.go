@@ -3,8 +3,16 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
 
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
@@ -83,6 +91,116 @@ func (r *InMemorySessionRepository) Count(ctx context.Context) (int, error) {
 	return len(r.sessions), nil
 }
 
+func (r *InMemorySessionRepository) CountOpen(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	count := 0
+	for _, session := range r.sessions {
+		if !session.IsClosed() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Query retrieves a page of the sessions matching filter
+func (r *InMemorySessionRepository) Query(ctx context.Context, filter repositories.SessionFilter) (*repositories.SessionPage, error) {
+	less, err := sessionOrder(filter.SortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	matches := make([]*aggregates.Session, 0)
+	for _, session := range r.sessions {
+		if matchesSessionFilter(session, filter) {
+			matches = append(matches, session)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if !filter.Ascending {
+			a, b = b, a
+		}
+		if less(a, b) != less(b, a) {
+			return less(a, b)
+		}
+		return matches[i].ID().String() < matches[j].ID().String()
+	})
+
+	page := &repositories.SessionPage{Total: len(matches), Offset: filter.Offset, Limit: filter.Limit}
+	offset := filter.Offset
+	if offset < 0 {
+		page.Offset, offset = 0, 0
+	}
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	matches = matches[offset:]
+	if filter.Limit > 0 && filter.Limit < len(matches) {
+		matches = matches[:filter.Limit]
+	}
+	page.Sessions = matches
+	return page, nil
+}
+
+// matchesSessionFilter reports whether a session meets every criterion of filter
+func matchesSessionFilter(session *aggregates.Session, filter repositories.SessionFilter) bool {
+	if len(filter.States) > 0 {
+		state := session.State()
+		found := false
+		for _, want := range filter.States {
+			if state == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.ClientName != "" && !strings.Contains(strings.ToLower(sessionClientName(session)), strings.ToLower(filter.ClientName)) {
+		return false
+	}
+	if filter.ProtocolVersion != "" && session.ProtocolVersion().String() != filter.ProtocolVersion {
+		return false
+	}
+	if !filter.CreatedAfter.IsZero() && session.CreatedAt().Before(filter.CreatedAfter) {
+		return false
+	}
+	if !filter.CreatedBefore.IsZero() && session.CreatedAt().After(filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// sessionOrder returns the ascending order of sessions by field
+func sessionOrder(field repositories.SessionSortField) (func(a, b *aggregates.Session) bool, error) {
+	switch field {
+	case "", repositories.SessionSortCreatedAt:
+		return func(a, b *aggregates.Session) bool { return a.CreatedAt().Before(b.CreatedAt()) }, nil
+	case repositories.SessionSortUpdatedAt:
+		return func(a, b *aggregates.Session) bool { return a.UpdatedAt().Before(b.UpdatedAt()) }, nil
+	case repositories.SessionSortClientName:
+		return func(a, b *aggregates.Session) bool {
+			return strings.ToLower(sessionClientName(a)) < strings.ToLower(sessionClientName(b))
+		}, nil
+	case repositories.SessionSortState:
+		return func(a, b *aggregates.Session) bool { return a.State() < b.State() }, nil
+	}
+	return nil, fmt.Errorf("unknown session sort field: %s", field)
+}
+
+// sessionClientName returns the name of the client that initialized the session, if any
+func sessionClientName(session *aggregates.Session) string {
+	if info := session.ClientInfo(); info != nil {
+		return info.Name
+	}
+	return ""
+}
+
 // Ensure interface compliance
 var _ repositories.ISessionRepository = (*InMemorySessionRepository)(nil)
 
@@ -143,6 +261,9 @@ func (r *InMemoryConversationRepository) FindActive(ctx context.Context) ([]*agg
 func (r *InMemoryConversationRepository) Delete(ctx context.Context, id vo.ConversationID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if _, ok := r.conversations[id.String()]; !ok {
+		return aggregates.ErrConversationNotFound
+	}
 	delete(r.conversations, id.String())
 	return nil
 }
@@ -172,24 +293,165 @@ func (r *InMemoryConversationRepository) CountBySessionID(ctx context.Context, s
 	return count, nil
 }
 
+// FindByMetadata retrieves a page of summaries of conversations whose metadata key holds value,
+// matching the JSON containment the persisted backend uses
+func (r *InMemoryConversationRepository) FindByMetadata(ctx context.Context, key string, value interface{}, offset, limit int) (*repositories.ConversationSummaryPage, error) {
+	want, err := normalizeJSON(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata value: %w", err)
+	}
+
+	r.mu.RLock()
+	matches := make([]*aggregates.Conversation, 0)
+	for _, conv := range r.conversations {
+		stored, ok := conv.GetMetadata(key)
+		if !ok {
+			continue
+		}
+		if got, err := normalizeJSON(stored); err == nil && jsonContains(got, want) {
+			matches = append(matches, conv)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt().Equal(matches[j].CreatedAt()) {
+			return matches[i].CreatedAt().After(matches[j].CreatedAt())
+		}
+		return matches[i].ID().String() < matches[j].ID().String()
+	})
+
+	page := &repositories.ConversationSummaryPage{Total: len(matches), Offset: offset, Limit: limit}
+	if offset < 0 {
+		page.Offset, offset = 0, 0
+	}
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	matches = matches[offset:]
+	if limit > 0 && limit < len(matches) {
+		matches = matches[:limit]
+	}
+
+	page.Summaries = make([]repositories.ConversationSummary, 0, len(matches))
+	for _, conv := range matches {
+		page.Summaries = append(page.Summaries, repositories.ConversationSummary{
+			ID:           conv.ID(),
+			SessionID:    conv.SessionID(),
+			Model:        conv.Model().String(),
+			Status:       string(conv.Status()),
+			MessageCount: conv.MessageCount(),
+			Metadata:     conv.Metadata(),
+			CreatedAt:    conv.CreatedAt(),
+			UpdatedAt:    conv.UpdatedAt(),
+		})
+	}
+	return page, nil
+}
+
 var _ repositories.IConversationRepository = (*InMemoryConversationRepository)(nil)
 
+// normalizeJSON converts a value to the form encoding/json decodes it into
+func normalizeJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	err = json.Unmarshal(data, &normalized)
+	return normalized, err
+}
+
+// jsonContains reports whether got contains want, following PostgreSQL's jsonb @> rules
+func jsonContains(got, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok || !jsonContains(gv, wv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, wv := range w {
+			found := false
+			for _, gv := range g {
+				if jsonContains(gv, wv) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
+
 // InMemoryToolRepository implements IToolRepository using in-memory storage
 type InMemoryToolRepository struct {
-	mu    sync.RWMutex
-	tools map[string]*entities.Tool
+	mu     sync.RWMutex
+	tools  map[string]*entities.Tool
+	logger zerolog.Logger
 }
 
 // NewInMemoryToolRepository creates a new in-memory tool repository
 func NewInMemoryToolRepository() *InMemoryToolRepository {
 	return &InMemoryToolRepository{
-		tools: make(map[string]*entities.Tool),
+		tools:  make(map[string]*entities.Tool),
+		logger: log.Logger,
 	}
 }
 
+// SetLogger sets the logger used to report tool overrides
+func (r *InMemoryToolRepository) SetLogger(logger zerolog.Logger) {
+	r.logger = logger
+}
+
 func (r *InMemoryToolRepository) Register(ctx context.Context, tool *entities.Tool) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if _, exists := r.tools[tool.Name().String()]; exists {
+		return fmt.Errorf("%w: %s", aggregates.ErrToolAlreadyRegistered, tool.Name())
+	}
+	if err := tool.ValidateExamples(); err != nil {
+		return err
+	}
+	r.tools[tool.Name().String()] = tool
+	return nil
+}
+
+func (r *InMemoryToolRepository) Replace(ctx context.Context, tool *entities.Tool) (bool, error) {
+	if err := tool.ValidateExamples(); err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, replaced := r.tools[tool.Name().String()]
+	if replaced {
+		r.logger.Warn().Str("tool", tool.Name().String()).Msg("Overriding registered tool")
+	}
+	r.tools[tool.Name().String()] = tool
+	return replaced, nil
+}
+
+func (r *InMemoryToolRepository) Update(ctx context.Context, tool *entities.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.tools[tool.Name().String()]; !exists {
+		return fmt.Errorf("%w: %s", aggregates.ErrToolNotRegistered, tool.Name())
+	}
 	r.tools[tool.Name().String()] = tool
 	return nil
 }
@@ -414,3 +676,77 @@ func (r *InMemoryPromptRepository) Count(ctx context.Context) (int, error) {
 }
 
 var _ repositories.IPromptRepository = (*InMemoryPromptRepository)(nil)
+
+// InMemoryResourceSubscriptionRepository implements IResourceSubscriptionRepository using in-memory storage
+type InMemoryResourceSubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[string]map[string]bool // Session ID -> resource URIs
+}
+
+// NewInMemoryResourceSubscriptionRepository creates a new in-memory resource subscription repository
+func NewInMemoryResourceSubscriptionRepository() *InMemoryResourceSubscriptionRepository {
+	return &InMemoryResourceSubscriptionRepository{
+		subscriptions: make(map[string]map[string]bool),
+	}
+}
+
+func (r *InMemoryResourceSubscriptionRepository) Subscribe(ctx context.Context, sessionID vo.SessionID, uri string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	uris, ok := r.subscriptions[sessionID.String()]
+	if !ok {
+		uris = make(map[string]bool)
+		r.subscriptions[sessionID.String()] = uris
+	}
+	uris[uri] = true
+	return nil
+}
+
+func (r *InMemoryResourceSubscriptionRepository) Unsubscribe(ctx context.Context, sessionID vo.SessionID, uri string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if uris, ok := r.subscriptions[sessionID.String()]; ok {
+		delete(uris, uri)
+		if len(uris) == 0 {
+			delete(r.subscriptions, sessionID.String())
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryResourceSubscriptionRepository) FindBySessionID(ctx context.Context, sessionID vo.SessionID) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	uris := make([]string, 0, len(r.subscriptions[sessionID.String()]))
+	for uri := range r.subscriptions[sessionID.String()] {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris, nil
+}
+
+func (r *InMemoryResourceSubscriptionRepository) FindSubscribers(ctx context.Context, uri string) ([]vo.SessionID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var sessionIDs []vo.SessionID
+	for id, uris := range r.subscriptions {
+		if !uris[uri] {
+			continue
+		}
+		sessionID, err := vo.NewSessionID(id)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	return sessionIDs, nil
+}
+
+func (r *InMemoryResourceSubscriptionRepository) DeleteBySessionID(ctx context.Context, sessionID vo.SessionID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, sessionID.String())
+	return nil
+}
+
+var _ repositories.IResourceSubscriptionRepository = (*InMemoryResourceSubscriptionRepository)(nil)
@@ -0,0 +1,170 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+// memorySnapshotVersion is the version of the snapshot format written by Snapshot
+const memorySnapshotVersion = 1
+
+// memorySnapshot is the JSON document written by InMemoryStore.Snapshot
+type memorySnapshot struct {
+	Version       int                               `json:"version"`
+	CreatedAt     time.Time                         `json:"created_at"`
+	Tools         []entities.ToolSnapshot           `json:"tools"`
+	Sessions      []aggregates.SessionSnapshot      `json:"sessions"`
+	Conversations []aggregates.ConversationSnapshot `json:"conversations"`
+}
+
+// InMemoryStore snapshots and restores the state of the in-memory session,
+// conversation, tool and resource subscription repositories together, so a dev server
+// or test can resume where it left off without a database
+type InMemoryStore struct {
+	sessions      *InMemorySessionRepository
+	conversations *InMemoryConversationRepository
+	tools         *InMemoryToolRepository
+	subscriptions *InMemoryResourceSubscriptionRepository
+}
+
+// NewInMemoryStore creates a store over the given repositories
+func NewInMemoryStore(sessions *InMemorySessionRepository, conversations *InMemoryConversationRepository, tools *InMemoryToolRepository, subscriptions *InMemoryResourceSubscriptionRepository) *InMemoryStore {
+	return &InMemoryStore{sessions: sessions, conversations: conversations, tools: tools, subscriptions: subscriptions}
+}
+
+// Snapshot writes the sessions, conversations and tools held by the repositories to w as JSON
+func (s *InMemoryStore) Snapshot(w io.Writer) error {
+	s.sessions.mu.RLock()
+	s.conversations.mu.RLock()
+	s.tools.mu.RLock()
+	snapshot := memorySnapshot{
+		Version:       memorySnapshotVersion,
+		CreatedAt:     time.Now().UTC(),
+		Tools:         make([]entities.ToolSnapshot, 0, len(s.tools.tools)),
+		Sessions:      make([]aggregates.SessionSnapshot, 0, len(s.sessions.sessions)),
+		Conversations: make([]aggregates.ConversationSnapshot, 0, len(s.conversations.conversations)),
+	}
+	for _, tool := range s.tools.tools {
+		snapshot.Tools = append(snapshot.Tools, tool.Snapshot())
+	}
+	for _, session := range s.sessions.sessions {
+		snapshot.Sessions = append(snapshot.Sessions, session.Snapshot())
+	}
+	for _, conv := range s.conversations.conversations {
+		snapshot.Conversations = append(snapshot.Conversations, conv.Snapshot())
+	}
+	s.tools.mu.RUnlock()
+	s.conversations.mu.RUnlock()
+	s.sessions.mu.RUnlock()
+
+	// Stable output so snapshots of the same state can be diffed
+	sort.Slice(snapshot.Tools, func(i, j int) bool { return snapshot.Tools[i].Name < snapshot.Tools[j].Name })
+	sort.Slice(snapshot.Sessions, func(i, j int) bool { return snapshot.Sessions[i].ID < snapshot.Sessions[j].ID })
+	sort.Slice(snapshot.Conversations, func(i, j int) bool { return snapshot.Conversations[i].ID < snapshot.Conversations[j].ID })
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot)
+}
+
+// Restore replaces the contents of the repositories with the snapshot read from r.
+// The whole snapshot is decoded and rebuilt before anything is replaced, so an
+// invalid or truncated snapshot leaves the repositories as they were. Restored tools
+// keep the handler of the tool currently registered under the same name, and tools
+// registered since the snapshot was taken stay registered. Resource subscriptions are
+// rebuilt from the subscriptions of the restored sessions.
+func (s *InMemoryStore) Restore(r io.Reader) error {
+	var snapshot memorySnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return fmt.Errorf("%w: %v", aggregates.ErrInvalidSnapshot, err)
+	}
+	if snapshot.Version != memorySnapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", aggregates.ErrInvalidSnapshot, snapshot.Version)
+	}
+
+	s.tools.mu.RLock()
+	registered := make(map[string]*entities.Tool, len(s.tools.tools))
+	for name, tool := range s.tools.tools {
+		registered[name] = tool
+	}
+	s.tools.mu.RUnlock()
+
+	tools := make(map[string]*entities.Tool, len(registered)+len(snapshot.Tools))
+	for name, tool := range registered {
+		tools[name] = tool
+	}
+	restored := make(map[string]bool, len(snapshot.Tools))
+	for _, ts := range snapshot.Tools {
+		tool, err := entities.RestoreTool(ts)
+		if err != nil {
+			return fmt.Errorf("%w: %v", aggregates.ErrInvalidSnapshot, err)
+		}
+		if restored[ts.Name] {
+			return fmt.Errorf("%w: duplicate tool %s", aggregates.ErrInvalidSnapshot, ts.Name)
+		}
+		restored[ts.Name] = true
+		if current, ok := registered[ts.Name]; ok {
+			tool.CopyHandlerFrom(current)
+		}
+		tools[ts.Name] = tool
+	}
+
+	conversations := make(map[string]*aggregates.Conversation, len(snapshot.Conversations))
+	bySession := make(map[string][]*aggregates.Conversation)
+	for _, cs := range snapshot.Conversations {
+		conv, err := aggregates.RestoreConversation(cs, tools)
+		if err != nil {
+			return err
+		}
+		if _, dup := conversations[cs.ID]; dup {
+			return fmt.Errorf("%w: duplicate conversation %s", aggregates.ErrInvalidSnapshot, cs.ID)
+		}
+		conversations[cs.ID] = conv
+		bySession[cs.SessionID] = append(bySession[cs.SessionID], conv)
+	}
+
+	sessions := make(map[string]*aggregates.Session, len(snapshot.Sessions))
+	subscriptions := make(map[string]map[string]bool)
+	for _, ss := range snapshot.Sessions {
+		session, err := aggregates.RestoreSession(ss, tools, bySession[ss.ID])
+		if err != nil {
+			return err
+		}
+		if _, dup := sessions[ss.ID]; dup {
+			return fmt.Errorf("%w: duplicate session %s", aggregates.ErrInvalidSnapshot, ss.ID)
+		}
+		sessions[ss.ID] = session
+		if len(ss.Subscriptions) > 0 {
+			uris := make(map[string]bool, len(ss.Subscriptions))
+			for _, uri := range ss.Subscriptions {
+				uris[uri] = true
+			}
+			subscriptions[ss.ID] = uris
+		}
+	}
+	for sessionID := range bySession {
+		if _, ok := sessions[sessionID]; !ok {
+			return fmt.Errorf("%w: conversation refers to missing session %s", aggregates.ErrInvalidSnapshot, sessionID)
+		}
+	}
+
+	s.sessions.mu.Lock()
+	s.conversations.mu.Lock()
+	s.tools.mu.Lock()
+	s.subscriptions.mu.Lock()
+	s.sessions.sessions = sessions
+	s.conversations.conversations = conversations
+	s.tools.tools = tools
+	s.subscriptions.subscriptions = subscriptions
+	s.subscriptions.mu.Unlock()
+	s.tools.mu.Unlock()
+	s.conversations.mu.Unlock()
+	s.sessions.mu.Unlock()
+	return nil
+}
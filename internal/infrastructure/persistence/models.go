@@ -8,24 +8,29 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence/models"
 )
 
 // SessionModel represents a session in the database
 type SessionModel struct {
-	ID              string         `gorm:"type:uuid;primaryKey"`
-	ProtocolVersion string         `gorm:"type:varchar(50);not null;default:'2024-11-05'"`
-	State           string         `gorm:"type:varchar(50);not null;index"`
-	ClientName      string         `gorm:"type:varchar(255)"`
-	ClientVersion   string         `gorm:"type:varchar(50)"`
-	ServerName      string         `gorm:"type:varchar(255);not null;default:'TelemetryFlow-MCP'"`
-	ServerVersion   string         `gorm:"type:varchar(50);not null;default:'1.1.2'"`
-	Capabilities    JSONB          `gorm:"type:jsonb"`
-	LogLevel        string         `gorm:"type:varchar(50);default:'info'"`
-	Metadata        JSONB          `gorm:"type:jsonb"`
-	CreatedAt       time.Time      `gorm:"not null;index"`
-	UpdatedAt       time.Time      `gorm:"not null"`
-	ClosedAt        *time.Time     `gorm:"index"`
-	DeletedAt       gorm.DeletedAt `gorm:"index"`
+	ID              string             `gorm:"type:uuid;primaryKey"`
+	ProtocolVersion string             `gorm:"type:varchar(50);not null;default:'2024-11-05'"`
+	State           string             `gorm:"type:varchar(50);not null;index"`
+	ClientName      string             `gorm:"type:varchar(255)"`
+	ClientVersion   string             `gorm:"type:varchar(50)"`
+	ServerName      string             `gorm:"type:varchar(255);not null;default:'TelemetryFlow-MCP'"`
+	ServerVersion   string             `gorm:"type:varchar(50);not null;default:'1.1.2'"`
+	Capabilities    JSONB              `gorm:"type:jsonb"`
+	LogLevel        string             `gorm:"type:varchar(50);default:'info'"`
+	Metadata        JSONB              `gorm:"type:jsonb"`
+	Tools           models.StringArray `gorm:"type:jsonb"` // Names of the tools registered in the session
+	Subscriptions   models.StringArray `gorm:"type:jsonb"` // Subscribed resource URIs
+	Store           JSONB              `gorm:"type:jsonb"`
+	CreatedAt       time.Time          `gorm:"not null;index"`
+	UpdatedAt       time.Time          `gorm:"not null"`
+	ClosedAt        *time.Time         `gorm:"index"`
+	DeletedAt       gorm.DeletedAt     `gorm:"index"`
 }
 
 // TableName returns the table name for SessionModel
@@ -35,21 +40,23 @@ func (SessionModel) TableName() string {
 
 // ConversationModel represents a conversation in the database
 type ConversationModel struct {
-	ID            string         `gorm:"type:uuid;primaryKey"`
-	SessionID     string         `gorm:"type:uuid;not null;index"`
-	Model         string         `gorm:"type:varchar(100);not null;index"`
-	SystemPrompt  string         `gorm:"type:text"`
-	Status        string         `gorm:"type:varchar(50);not null;index"`
-	MaxTokens     int            `gorm:"not null;default:4096"`
-	Temperature   float64        `gorm:"not null;default:1.0"`
-	TopP          float64        `gorm:"not null;default:1.0"`
-	TopK          int            `gorm:"default:0"`
-	StopSequences JSONB          `gorm:"type:jsonb"`
-	Metadata      JSONB          `gorm:"type:jsonb"`
-	CreatedAt     time.Time      `gorm:"not null;index"`
-	UpdatedAt     time.Time      `gorm:"not null"`
-	ClosedAt      *time.Time     `gorm:"index"`
-	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	ID            string             `gorm:"type:uuid;primaryKey"`
+	SessionID     string             `gorm:"type:uuid;not null;index"`
+	Model         string             `gorm:"type:varchar(100);not null;index"`
+	SystemPrompt  string             `gorm:"type:text"`
+	Status        string             `gorm:"type:varchar(50);not null;index"`
+	MaxTokens     int                `gorm:"not null;default:4096"`
+	Temperature   float64            `gorm:"not null;default:1.0"`
+	TopP          float64            `gorm:"not null;default:1.0"`
+	TopK          int                `gorm:"default:0"`
+	StopSequences models.StringArray `gorm:"type:jsonb"`
+	Tools         models.StringArray `gorm:"type:jsonb"` // Names of the tools offered to Claude
+	ToolUse       JSONB              `gorm:"type:jsonb"`
+	Metadata      JSONB              `gorm:"type:jsonb"`
+	CreatedAt     time.Time          `gorm:"not null;index"`
+	UpdatedAt     time.Time          `gorm:"not null"`
+	ClosedAt      *time.Time         `gorm:"index"`
+	DeletedAt     gorm.DeletedAt     `gorm:"index"`
 
 	// Relations
 	Session  *SessionModel  `gorm:"foreignKey:SessionID;references:ID"`
@@ -63,12 +70,14 @@ func (ConversationModel) TableName() string {
 
 // MessageModel represents a message in the database
 type MessageModel struct {
-	ID             string    `gorm:"type:uuid;primaryKey"`
-	ConversationID string    `gorm:"type:uuid;not null;index"`
-	Role           string    `gorm:"type:varchar(50);not null;index"`
-	Content        JSONB     `gorm:"type:jsonb;not null"`
-	TokenCount     int       `gorm:"default:0"`
-	CreatedAt      time.Time `gorm:"not null;index"`
+	ID             string     `gorm:"type:uuid;primaryKey"`
+	ConversationID string     `gorm:"type:uuid;not null;index"`
+	Position       int        `gorm:"not null;default:0;index"` // Order of the message in its conversation
+	Role           string     `gorm:"type:varchar(50);not null;index"`
+	Content        JSONBArray `gorm:"type:jsonb;not null"`
+	TokenCount     int        `gorm:"default:0"`
+	Metadata       JSONB      `gorm:"type:jsonb"`
+	CreatedAt      time.Time  `gorm:"not null;index"`
 
 	// Relations
 	Conversation *ConversationModel `gorm:"foreignKey:ConversationID;references:ID"`
@@ -81,19 +90,22 @@ func (MessageModel) TableName() string {
 
 // ToolModel represents a tool definition in the database
 type ToolModel struct {
-	ID          string         `gorm:"type:uuid;primaryKey"`
-	Name        string         `gorm:"type:varchar(255);uniqueIndex;not null"`
-	Description string         `gorm:"type:text"`
-	InputSchema JSONB          `gorm:"type:jsonb"`
-	Category    string         `gorm:"type:varchar(100);index"`
-	Tags        JSONB          `gorm:"type:jsonb"`
-	IsEnabled   bool           `gorm:"not null;default:true;index"`
-	RateLimit   JSONB          `gorm:"type:jsonb"`
-	Timeout     int            `gorm:"default:30"` // in seconds
-	Metadata    JSONB          `gorm:"type:jsonb"`
-	CreatedAt   time.Time      `gorm:"not null"`
-	UpdatedAt   time.Time      `gorm:"not null"`
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID          string             `gorm:"type:uuid;primaryKey"`
+	Name        string             `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Description string             `gorm:"type:text"`
+	InputSchema JSONB              `gorm:"type:jsonb"`
+	Category    string             `gorm:"type:varchar(100);index"`
+	Tags        models.StringArray `gorm:"type:jsonb"`
+	IsEnabled   bool               `gorm:"not null;default:true;index"`
+	RateLimit   JSONB              `gorm:"type:jsonb"`
+	Annotations JSONB              `gorm:"type:jsonb"`
+	Examples    JSONBArray         `gorm:"type:jsonb"`
+	Cacheable   bool               `gorm:"not null;default:false"`
+	Timeout     int                `gorm:"default:30"` // in seconds
+	Metadata    JSONB              `gorm:"type:jsonb"`
+	CreatedAt   time.Time          `gorm:"not null"`
+	UpdatedAt   time.Time          `gorm:"not null"`
+	DeletedAt   gorm.DeletedAt     `gorm:"index"`
 }
 
 // TableName returns the table name for ToolModel
@@ -101,6 +113,22 @@ func (ToolModel) TableName() string {
 	return "tools"
 }
 
+// ResourceSubscriptionModel represents a session's subscription to a resource in the database
+type ResourceSubscriptionModel struct {
+	ID           string    `gorm:"type:uuid;primaryKey"`
+	SessionID    string    `gorm:"type:uuid;not null;index"`
+	ResourceURI  string    `gorm:"type:varchar(2048);not null;index"`
+	SubscribedAt time.Time `gorm:"autoCreateTime"`
+
+	// Relations
+	Session *SessionModel `gorm:"foreignKey:SessionID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for ResourceSubscriptionModel
+func (ResourceSubscriptionModel) TableName() string {
+	return "resource_subscriptions"
+}
+
 // ResourceModel represents a resource definition in the database
 type ResourceModel struct {
 	ID          string         `gorm:"type:uuid;primaryKey"`
@@ -276,6 +304,7 @@ func AllModels() []interface{} {
 		&ConversationModel{},
 		&MessageModel{},
 		&ToolModel{},
+		&ResourceSubscriptionModel{},
 		&ResourceModel{},
 		&PromptModel{},
 		&ToolCallModel{},
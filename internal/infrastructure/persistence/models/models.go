@@ -74,17 +74,20 @@ func (s StringArray) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
-// Scan decodes a JSON value from the database driver
+// Scan decodes a JSON value from the database driver, which may hand it over as
+// bytes or as text
 func (s *StringArray) Scan(value interface{}) error {
-	if value == nil {
+	switch v := value.(type) {
+	case nil:
 		*s = nil
 		return nil
-	}
-	bytes, ok := value.([]byte)
-	if !ok {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
 		return errors.New("type assertion to []byte failed")
 	}
-	return json.Unmarshal(bytes, s)
 }
 
 // ============================================================================
@@ -252,16 +255,18 @@ func (t *Tool) BeforeCreate(tx *gorm.DB) error {
 
 // Resource represents a resource in the database
 type Resource struct {
-	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	URI         string    `gorm:"type:varchar(2048);not null;uniqueIndex" json:"uri"`
-	URITemplate string    `gorm:"type:varchar(2048)" json:"uriTemplate,omitempty"`
-	Name        string    `gorm:"type:varchar(255);not null" json:"name"`
-	Description string    `gorm:"type:text" json:"description,omitempty"`
-	MimeType    string    `gorm:"type:varchar(255)" json:"mimeType,omitempty"`
-	IsTemplate  bool      `gorm:"not null;default:false" json:"isTemplate"`
-	Metadata    JSONB     `gorm:"type:jsonb;not null;default:'{}'" json:"metadata"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+	ID          uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	URI         string      `gorm:"type:varchar(2048);not null;uniqueIndex" json:"uri"`
+	URITemplate string      `gorm:"type:varchar(2048)" json:"uriTemplate,omitempty"`
+	Name        string      `gorm:"type:varchar(255);not null" json:"name"`
+	Description string      `gorm:"type:text" json:"description,omitempty"`
+	MimeType    string      `gorm:"type:varchar(255)" json:"mimeType,omitempty"`
+	IsTemplate  bool        `gorm:"not null;default:false" json:"isTemplate"`
+	Audience    StringArray `gorm:"type:jsonb;not null;default:'[]'" json:"audience"`
+	Priority    *float64    `gorm:"type:decimal(3,2)" json:"priority,omitempty"`
+	Metadata    JSONB       `gorm:"type:jsonb;not null;default:'{}'" json:"metadata"`
+	CreatedAt   time.Time   `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time   `gorm:"autoUpdateTime" json:"updatedAt"`
 }
 
 // TableName returns the table name for Resource
@@ -306,6 +311,42 @@ func (p *Prompt) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// ============================================================================
+// ConversationTemplate Model
+// ============================================================================
+
+// ConversationTemplate represents a reusable conversation setup in the database.
+// Nullable sampling settings keep the conversation defaults.
+type ConversationTemplate struct {
+	ID            uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Name          string      `gorm:"type:varchar(255);not null;uniqueIndex" json:"name"`
+	Description   string      `gorm:"type:text" json:"description,omitempty"`
+	SystemPrompt  string      `gorm:"type:text" json:"systemPrompt,omitempty"`
+	Tools         StringArray `gorm:"type:jsonb;not null;default:'[]'" json:"tools"`
+	Model         string      `gorm:"type:varchar(100);not null" json:"model"`
+	MaxTokens     int         `gorm:"not null;default:0" json:"maxTokens"`
+	Temperature   *float64    `gorm:"type:decimal(3,2)" json:"temperature,omitempty"`
+	TopP          *float64    `gorm:"type:decimal(3,2)" json:"topP,omitempty"`
+	TopK          *int        `json:"topK,omitempty"`
+	StopSequences StringArray `gorm:"type:jsonb;not null;default:'[]'" json:"stopSequences"`
+	Metadata      JSONB       `gorm:"type:jsonb;not null;default:'{}'" json:"metadata"`
+	CreatedAt     time.Time   `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time   `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for ConversationTemplate
+func (ConversationTemplate) TableName() string {
+	return "conversation_templates"
+}
+
+// BeforeCreate generates a UUID if not set
+func (t *ConversationTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
 // ============================================================================
 // ResourceSubscription Model
 // ============================================================================
@@ -426,6 +467,7 @@ func AllModels() []interface{} {
 		&Tool{},
 		&Resource{},
 		&Prompt{},
+		&ConversationTemplate{},
 		&ResourceSubscription{},
 		&ToolExecution{},
 		&APIKey{},
@@ -5,12 +5,18 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence/models"
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -42,8 +48,9 @@ type SeederResult struct {
 
 // DatabaseSeeder handles database seeding
 type DatabaseSeeder struct {
-	db      *gorm.DB
-	seeders []Seeder
+	db          *gorm.DB
+	seeders     []Seeder
+	environment string
 }
 
 // NewDatabaseSeeder creates a new DatabaseSeeder instance
@@ -59,8 +66,24 @@ func (s *DatabaseSeeder) Register(name string, fn SeederFunc) {
 	s.seeders = append(s.seeders, Seeder{Name: name, Fn: fn})
 }
 
+// SetEnvironment sets the deployment environment, normally config
+// telemetry.environment, that the development seeders are checked against. When it
+// is not set they fall back to telemetry.DetectEnvironment.
+func (s *DatabaseSeeder) SetEnvironment(env string) {
+	s.environment = env
+}
+
+// seedContext returns ctx carrying the seeder's environment, if one is set
+func (s *DatabaseSeeder) seedContext(ctx context.Context) context.Context {
+	if s.environment == "" {
+		return ctx
+	}
+	return ContextWithSeedEnvironment(ctx, s.environment)
+}
+
 // Run executes all registered seeders
 func (s *DatabaseSeeder) Run(ctx context.Context) (*SeederResult, error) {
+	ctx = s.seedContext(ctx)
 	start := time.Now()
 	result := &SeederResult{
 		Executed: make([]string, 0),
@@ -89,6 +112,7 @@ func (s *DatabaseSeeder) Run(ctx context.Context) (*SeederResult, error) {
 
 // RunSeeder executes a specific seeder by name
 func (s *DatabaseSeeder) RunSeeder(ctx context.Context, name string) error {
+	ctx = s.seedContext(ctx)
 	for _, seeder := range s.seeders {
 		if seeder.Name == name {
 			return seeder.Fn(ctx, s.db)
@@ -97,6 +121,52 @@ func (s *DatabaseSeeder) RunSeeder(ctx context.Context, name string) error {
 	return fmt.Errorf("seeder %s not found", name)
 }
 
+// ============================================================================
+// Environment Guard
+// ============================================================================
+
+// EnvAllowDevSeed is the environment variable that, set to true, lets the development
+// seeders run outside development
+const EnvAllowDevSeed = "TELEMETRYFLOW_MCP_ALLOW_DEV_SEED"
+
+// ErrDevSeedInProduction is returned when a development seeder runs outside a
+// development environment
+var ErrDevSeedInProduction = errors.New("development seeder refused outside development")
+
+type seedEnvironmentKey struct{}
+
+// ContextWithSeedEnvironment returns a context whose development seeders check env
+// instead of the environment detected from environment variables
+func ContextWithSeedEnvironment(ctx context.Context, env string) context.Context {
+	return context.WithValue(ctx, seedEnvironmentKey{}, env)
+}
+
+// seedEnvironment returns the environment carried by ctx or, failing that, the one
+// detected by telemetry.DetectEnvironment
+func seedEnvironment(ctx context.Context) string {
+	if env, ok := ctx.Value(seedEnvironmentKey{}).(string); ok && strings.TrimSpace(env) != "" {
+		return env
+	}
+	return telemetry.DetectEnvironment()
+}
+
+// CheckDevSeedAllowed returns ErrDevSeedInProduction unless the environment carried by
+// ctx, or detected by telemetry.DetectEnvironment, is a development one, or
+// TELEMETRYFLOW_MCP_ALLOW_DEV_SEED is true. Staging, misspelled and unset
+// environments are all refused. It keeps the well-known development API key and demo
+// data out of shared databases.
+func CheckDevSeedAllowed(ctx context.Context) error {
+	env := seedEnvironment(ctx)
+	if telemetry.IsDevelopmentEnvironment(env) {
+		return nil
+	}
+	if allow, err := strconv.ParseBool(os.Getenv(EnvAllowDevSeed)); err == nil && allow {
+		log.Warn().Str("environment", env).Msg("Running development seeder outside development: override set")
+		return nil
+	}
+	return fmt.Errorf("%w: environment %q: set %s=true to override", ErrDevSeedInProduction, env, EnvAllowDevSeed)
+}
+
 // ============================================================================
 // Default Seeders
 // ============================================================================
@@ -106,6 +176,7 @@ func (s *DatabaseSeeder) RegisterDefaultSeeders() {
 	s.Register("tools", SeedTools)
 	s.Register("resources", SeedResources)
 	s.Register("prompts", SeedPrompts)
+	s.Register("conversation_templates", SeedConversationTemplates)
 	s.Register("api_keys", SeedAPIKeys)
 	s.Register("demo_session", SeedDemoSession)
 }
@@ -135,7 +206,7 @@ func SeedTools(ctx context.Context, db *gorm.DB) error {
 		{
 			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000002"),
 			Name:        "read_file",
-			Description: "Reads the contents of a file from the filesystem. Returns the file contents as text.",
+			Description: "Reads the contents of a file from the filesystem. Returns the file contents as text, capped per call, with size and truncation metadata.",
 			InputSchema: models.JSONB{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -143,6 +214,11 @@ func SeedTools(ctx context.Context, db *gorm.DB) error {
 						"type":        "string",
 						"description": "The path to the file to read",
 					},
+					"offset": map[string]interface{}{
+						"type":        "integer",
+						"description": "Byte offset to start reading from, e.g. to continue a truncated read (default: 0)",
+						"minimum":     0,
+					},
 				},
 				"required": []string{"path"},
 			},
@@ -272,6 +348,16 @@ func SeedTools(ctx context.Context, db *gorm.DB) error {
 						"type":        "string",
 						"description": "Optional system prompt for new conversations",
 					},
+					"tools": map[string]interface{}{
+						"type":        "array",
+						"description": "Optional names of tools Claude may use in a new conversation",
+						"items":       map[string]interface{}{"type": "string"},
+					},
+					"attachments": map[string]interface{}{
+						"type":        "array",
+						"description": "Optional resource URIs whose content is inlined into the message when it is sent",
+						"items":       map[string]interface{}{"type": "string"},
+					},
 				},
 				"required": []string{"message"},
 			},
@@ -280,22 +366,282 @@ func SeedTools(ctx context.Context, db *gorm.DB) error {
 			IsEnabled:      true,
 			TimeoutSeconds: 120,
 		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000009"),
+			Name:        "list_processes",
+			Description: "Lists running processes (PID, name, CPU, memory) with the highest resource usage. Read-only; requires the admin scope.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of processes to return (default: 10)",
+						"minimum":     1,
+						"maximum":     500,
+					},
+					"sort_by": map[string]interface{}{
+						"type":        "string",
+						"description": "Sort processes by cpu or memory usage (default: cpu)",
+						"enum":        []string{"cpu", "memory"},
+					},
+				},
+			},
+			Category: "system",
+			Tags:     models.StringArray{"system", "process", "diagnostics"},
+			// Off like security.enable_process_list, until an operator turns it on
+			IsEnabled:      false,
+			TimeoutSeconds: 10,
+			Metadata:       models.JSONB{"required_scope": "admin"},
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			Name:        "directory_tree",
+			Description: "Renders a directory tree as ASCII text or nested JSON, with file counts and total size per directory.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "The path to the root directory",
+					},
+					"max_depth": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum depth to descend (default: 3)",
+						"minimum":     1,
+						"maximum":     10,
+					},
+					"max_nodes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of entries to include (default: 1000)",
+						"minimum":     1,
+						"maximum":     10000,
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Output format (default: text)",
+						"enum":        []string{"text", "json"},
+					},
+				},
+				"required": []string{"path"},
+			},
+			Category:       "filesystem",
+			Tags:           models.StringArray{"directory", "tree", "io"},
+			IsEnabled:      true,
+			TimeoutSeconds: 30,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000011"),
+			Name:        "set_working_dir",
+			Description: "Sets the working directory used by execute_command for the rest of the session.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "The directory to use as the session working directory",
+					},
+				},
+				"required": []string{"path"},
+			},
+			Category:       "system",
+			Tags:           models.StringArray{"session", "directory"},
+			IsEnabled:      true,
+			TimeoutSeconds: 30,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000012"),
+			Name:        "get_working_dir",
+			Description: "Gets the session working directory used by execute_command.",
+			InputSchema: models.JSONB{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+			Category:       "system",
+			Tags:           models.StringArray{"session", "directory"},
+			IsEnabled:      true,
+			TimeoutSeconds: 30,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000013"),
+			Name:        "watch_file",
+			Description: "Watches a file and notifies the session when it changes, for a bounded duration or until unwatched.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "The path to the file to watch",
+					},
+					"duration_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "How long to watch the file (default: 300)",
+						"minimum":     1,
+						"maximum":     3600,
+					},
+				},
+				"required": []string{"path"},
+			},
+			Category:       "filesystem",
+			Tags:           models.StringArray{"file", "watch", "notification"},
+			IsEnabled:      true,
+			TimeoutSeconds: 30,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000014"),
+			Name:        "unwatch_file",
+			Description: "Stops watching a file previously watched with watch_file.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "The path to the watched file",
+					},
+				},
+				"required": []string{"path"},
+			},
+			Category:       "filesystem",
+			Tags:           models.StringArray{"file", "watch", "notification"},
+			IsEnabled:      true,
+			TimeoutSeconds: 30,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000015"),
+			Name:        "move_file",
+			Description: "Moves or renames a file or directory within the allowed directories.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "The file or directory to move",
+					},
+					"destination": map[string]interface{}{
+						"type":        "string",
+						"description": "The new path, including the file or directory name",
+					},
+					"overwrite": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Replace an existing file at the destination (default: false)",
+					},
+				},
+				"required": []string{"source", "destination"},
+			},
+			Category:       "filesystem",
+			Tags:           models.StringArray{"file", "move", "rename"},
+			IsEnabled:      true,
+			TimeoutSeconds: 60,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000016"),
+			Name:        "copy_file",
+			Description: "Copies a file, or a directory with recursive set, within the allowed directories.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "The file or directory to copy",
+					},
+					"destination": map[string]interface{}{
+						"type":        "string",
+						"description": "The path of the copy, including the file or directory name",
+					},
+					"overwrite": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Replace an existing file at the destination (default: false)",
+					},
+					"recursive": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Required to copy a directory and its contents (default: false)",
+					},
+				},
+				"required": []string{"source", "destination"},
+			},
+			Category:       "filesystem",
+			Tags:           models.StringArray{"file", "copy"},
+			IsEnabled:      true,
+			TimeoutSeconds: 60,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000017"),
+			Name:        "delete_file",
+			Description: "Deletes a file, or a directory with recursive set, within the allowed directories. Requires confirm to be true.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "The file or directory to delete",
+					},
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Must be true to perform the deletion",
+					},
+					"recursive": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Required to delete a directory and everything in it (default: false)",
+					},
+				},
+				"required": []string{"path", "confirm"},
+			},
+			Category:       "filesystem",
+			Tags:           models.StringArray{"file", "delete"},
+			IsEnabled:      true,
+			TimeoutSeconds: 60,
+		},
+		{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000018"),
+			Name:        "read_config",
+			Description: "Reads a YAML, TOML, INI or .env config file and returns its contents as JSON. The format is detected from the file extension unless given.",
+			InputSchema: models.JSONB{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "The path to the config file to read",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "The file format (default: detected from the file extension)",
+						"enum":        []string{"yaml", "toml", "ini", "env"},
+					},
+				},
+				"required": []string{"path"},
+			},
+			Category:       "filesystem",
+			Tags:           models.StringArray{"file", "read", "config"},
+			IsEnabled:      true,
+			TimeoutSeconds: 30,
+		},
 	}
 
 	for _, tool := range tools {
+		enabled := tool.IsEnabled
 		result := db.WithContext(ctx).Where("name = ?", tool.Name).FirstOrCreate(&tool)
 		if result.Error != nil {
 			return fmt.Errorf("failed to seed tool %s: %w", tool.Name, result.Error)
 		}
+		// Inserting false writes the is_enabled column default instead, so a tool seeded
+		// disabled is switched off once created. Existing rows keep their setting.
+		if result.RowsAffected > 0 && !enabled {
+			if err := db.WithContext(ctx).Model(&tool).Update("is_enabled", false).Error; err != nil {
+				return fmt.Errorf("failed to seed tool %s: %w", tool.Name, err)
+			}
+		}
 	}
 
 	log.Info().Int("count", len(tools)).Msg("Seeded tools")
 	return nil
 }
 
-// SeedResources seeds default resources into the database
-func SeedResources(ctx context.Context, db *gorm.DB) error {
-	resources := []models.Resource{
+// defaultResources returns the built-in resources. They are seeded into the database
+// and, through DefaultResources, give the server's own resources their annotations.
+func defaultResources() []models.Resource {
+	configPriority := 0.3
+	healthPriority := 0.8
+
+	return []models.Resource{
 		{
 			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000101"),
 			URI:         "config://server",
@@ -303,6 +649,8 @@ func SeedResources(ctx context.Context, db *gorm.DB) error {
 			Description: "Current server configuration settings",
 			MimeType:    "application/json",
 			IsTemplate:  false,
+			Audience:    models.StringArray{"user"},
+			Priority:    &configPriority,
 		},
 		{
 			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000102"),
@@ -311,6 +659,8 @@ func SeedResources(ctx context.Context, db *gorm.DB) error {
 			Description: "Server health and status information",
 			MimeType:    "application/json",
 			IsTemplate:  false,
+			Audience:    models.StringArray{"user", "assistant"},
+			Priority:    &healthPriority,
 		},
 		{
 			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000103"),
@@ -322,7 +672,25 @@ func SeedResources(ctx context.Context, db *gorm.DB) error {
 			IsTemplate:  true,
 		},
 	}
+}
 
+// DefaultResources returns new instances of the built-in resources, without readers
+func DefaultResources() ([]*entities.Resource, error) {
+	rows := defaultResources()
+	resources := make([]*entities.Resource, 0, len(rows))
+	for i := range rows {
+		resource, err := resourceFromModel(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// SeedResources seeds default resources into the database
+func SeedResources(ctx context.Context, db *gorm.DB) error {
+	resources := defaultResources()
 	for _, resource := range resources {
 		result := db.WithContext(ctx).Where("uri = ?", resource.URI).FirstOrCreate(&resource)
 		if result.Error != nil {
@@ -334,9 +702,18 @@ func SeedResources(ctx context.Context, db *gorm.DB) error {
 	return nil
 }
 
-// SeedPrompts seeds default prompts into the database
-func SeedPrompts(ctx context.Context, db *gorm.DB) error {
-	prompts := []models.Prompt{
+// PromptDefinition is a built-in prompt: its arguments and the template it renders
+type PromptDefinition struct {
+	Name        string
+	Description string
+	Arguments   []*entities.PromptArgument
+	Template    string
+}
+
+// defaultPrompts returns the built-in prompts. They are seeded into the database and,
+// through DefaultPrompts, registered with every session.
+func defaultPrompts() []models.Prompt {
+	return []models.Prompt{
 		{
 			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000201"),
 			Name:        "code_review",
@@ -387,7 +764,25 @@ func SeedPrompts(ctx context.Context, db *gorm.DB) error {
 			Template: "I need help debugging the following issue:\n\nError: {{error}}\n\n{{#if context}}\nContext: {{context}}\n{{/if}}\n\nPlease help me:\n1. Understand what's causing this error\n2. Identify potential solutions\n3. Suggest steps to fix it",
 		},
 	}
+}
+
+// DefaultPrompts returns the definitions of the built-in prompts
+func DefaultPrompts() ([]PromptDefinition, error) {
+	rows := defaultPrompts()
+	definitions := make([]PromptDefinition, 0, len(rows))
+	for i := range rows {
+		definition, err := promptDefinitionFromModel(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
 
+// SeedPrompts seeds default prompts into the database
+func SeedPrompts(ctx context.Context, db *gorm.DB) error {
+	prompts := defaultPrompts()
 	for _, prompt := range prompts {
 		result := db.WithContext(ctx).Where("name = ?", prompt.Name).FirstOrCreate(&prompt)
 		if result.Error != nil {
@@ -399,14 +794,78 @@ func SeedPrompts(ctx context.Context, db *gorm.DB) error {
 	return nil
 }
 
+// defaultConversationTemplates returns the built-in conversation templates. They are
+// seeded into the database and, through DefaultConversationTemplates, registered with
+// every session.
+func defaultConversationTemplates() []models.ConversationTemplate {
+	codeTemperature := 0.2
+	inspectorTemperature := 0.0
+
+	return []models.ConversationTemplate{
+		{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000501"),
+			Name:         "code_assistant",
+			Description:  "Reads and searches the workspace to answer questions about code",
+			SystemPrompt: "You are a careful software engineer. Read the relevant files before answering, cite the files you rely on, and keep suggested changes minimal.",
+			Tools:        models.StringArray{"read_file", "list_directory", "search_files"},
+			Model:        "claude-sonnet-4-20250514",
+			MaxTokens:    8192,
+			Temperature:  &codeTemperature,
+		},
+		{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000502"),
+			Name:         "system_inspector",
+			Description:  "Inspects the host system and running processes",
+			SystemPrompt: "You help operators understand the state of a host. Gather facts with the available tools before drawing conclusions and never guess at values you can look up.",
+			Tools:        models.StringArray{"system_info", "read_file"},
+			Model:        "claude-sonnet-4-20250514",
+			MaxTokens:    4096,
+			Temperature:  &inspectorTemperature,
+		},
+	}
+}
+
+// DefaultConversationTemplates returns new instances of the built-in conversation templates
+func DefaultConversationTemplates() ([]*entities.ConversationTemplate, error) {
+	rows := defaultConversationTemplates()
+	templates := make([]*entities.ConversationTemplate, 0, len(rows))
+	for i := range rows {
+		template, err := conversationTemplateFromModel(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// SeedConversationTemplates seeds default conversation templates into the database
+func SeedConversationTemplates(ctx context.Context, db *gorm.DB) error {
+	templates := defaultConversationTemplates()
+	for _, template := range templates {
+		result := db.WithContext(ctx).Where("name = ?", template.Name).FirstOrCreate(&template)
+		if result.Error != nil {
+			return fmt.Errorf("failed to seed conversation template %s: %w", template.Name, result.Error)
+		}
+	}
+
+	log.Info().Int("count", len(templates)).Msg("Seeded conversation templates")
+	return nil
+}
+
 // hashAPIKey creates a SHA-256 hash of an API key
 func hashAPIKey(key string) string {
 	hash := sha256.Sum256([]byte(key))
 	return hex.EncodeToString(hash[:])
 }
 
-// SeedAPIKeys seeds default API keys into the database
+// SeedAPIKeys seeds the development API key into the database. It refuses to run
+// outside a development environment; see CheckDevSeedAllowed.
 func SeedAPIKeys(ctx context.Context, db *gorm.DB) error {
+	if err := CheckDevSeedAllowed(ctx); err != nil {
+		return err
+	}
+
 	// Development API key (only for development/testing)
 	devKey := "tfm_dev_" + "0123456789abcdef0123456789abcdef"
 
@@ -435,8 +894,13 @@ func SeedAPIKeys(ctx context.Context, db *gorm.DB) error {
 	return nil
 }
 
-// SeedDemoSession seeds a demo session for testing
+// SeedDemoSession seeds a demo session for testing. It refuses to run outside a
+// development environment; see CheckDevSeedAllowed.
 func SeedDemoSession(ctx context.Context, db *gorm.DB) error {
+	if err := CheckDevSeedAllowed(ctx); err != nil {
+		return err
+	}
+
 	session := models.Session{
 		ID:              uuid.MustParse("00000000-0000-0000-0000-000000000401"),
 		ProtocolVersion: "2024-11-05",
@@ -521,7 +985,8 @@ func SeedDemoSession(ctx context.Context, db *gorm.DB) error {
 // Utility Functions
 // ============================================================================
 
-// SeedAll runs all default seeders
+// SeedAll runs all default seeders. In production the API key and demo session
+// seeders fail unless explicitly allowed; use SeedProduction there.
 func SeedAll(ctx context.Context, db *gorm.DB) (*SeederResult, error) {
 	seeder := NewDatabaseSeeder(db)
 	seeder.RegisterDefaultSeeders()
@@ -534,5 +999,6 @@ func SeedProduction(ctx context.Context, db *gorm.DB) (*SeederResult, error) {
 	seeder.Register("tools", SeedTools)
 	seeder.Register("resources", SeedResources)
 	seeder.Register("prompts", SeedPrompts)
+	seeder.Register("conversation_templates", SeedConversationTemplates)
 	return seeder.Run(ctx)
 }
@@ -4,10 +4,14 @@ package persistence
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
 )
 
 // Common repository errors
@@ -125,7 +129,7 @@ func (r *SessionRepository) List(ctx context.Context, opts *ListOptions) ([]Sess
 			query = query.Where("state = ?", opts.State)
 		}
 		if opts.ClientName != "" {
-			query = query.Where("client_name ILIKE ?", "%"+opts.ClientName+"%")
+			query = query.Where(clientNameMatch, containsPattern(opts.ClientName))
 		}
 		if !opts.Since.IsZero() {
 			query = query.Where("created_at >= ?", opts.Since)
@@ -197,6 +201,65 @@ func (r *SessionRepository) CountByState(ctx context.Context) (map[string]int64,
 	return counts, nil
 }
 
+// Query lists the sessions matching a filter with pagination, mirroring
+// ISessionRepository.Query for the database backend
+func (r *SessionRepository) Query(ctx context.Context, filter repositories.SessionFilter) ([]SessionModel, int64, error) {
+	column := string(filter.SortBy)
+	switch filter.SortBy {
+	case "":
+		column = string(repositories.SessionSortCreatedAt)
+	case repositories.SessionSortClientName:
+		column = "LOWER(client_name)"
+	case repositories.SessionSortCreatedAt, repositories.SessionSortUpdatedAt, repositories.SessionSortState:
+	default:
+		return nil, 0, fmt.Errorf("unknown session sort field: %s", filter.SortBy)
+	}
+
+	query := r.db.WithContext(ctx).Model(&SessionModel{})
+	if len(filter.States) > 0 {
+		states := make([]string, len(filter.States))
+		for i, state := range filter.States {
+			states[i] = string(state)
+		}
+		query = query.Where("state IN ?", states)
+	}
+	if filter.ClientName != "" {
+		query = query.Where(clientNameMatch, containsPattern(filter.ClientName))
+	}
+	if filter.ProtocolVersion != "" {
+		query = query.Where("protocol_version = ?", filter.ProtocolVersion)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at <= ?", filter.CreatedBefore)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	direction := " DESC"
+	if filter.Ascending {
+		direction = " ASC"
+	}
+	query = query.Order(column + direction).Order("id")
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var sessions []SessionModel
+	if err := query.Find(&sessions).Error; err != nil {
+		return nil, 0, err
+	}
+	return sessions, total, nil
+}
+
 // CleanupOldSessions deletes sessions older than the specified duration
 func (r *SessionRepository) CleanupOldSessions(ctx context.Context, olderThan time.Duration) (int64, error) {
 	cutoff := time.Now().UTC().Add(-olderThan)
@@ -206,6 +269,18 @@ func (r *SessionRepository) CleanupOldSessions(ctx context.Context, olderThan ti
 	return result.RowsAffected, result.Error
 }
 
+// clientNameMatch matches client names case-insensitively against a pattern from
+// containsPattern
+const clientNameMatch = `client_name ILIKE ? ESCAPE '\'`
+
+// likeEscaper escapes the LIKE wildcards, and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns a LIKE pattern matching values that contain s literally
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
 // ListOptions holds list query options
 type ListOptions struct {
 	Limit      int
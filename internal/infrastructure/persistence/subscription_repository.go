@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ResourceSubscriptionRepository implements IResourceSubscriptionRepository using GORM
+type ResourceSubscriptionRepository struct {
+	db *Database
+}
+
+// NewResourceSubscriptionRepository creates a new ResourceSubscriptionRepository
+func NewResourceSubscriptionRepository(db *Database) *ResourceSubscriptionRepository {
+	return &ResourceSubscriptionRepository{db: db}
+}
+
+// Subscribe records a subscription, ignoring duplicates
+func (r *ResourceSubscriptionRepository) Subscribe(ctx context.Context, sessionID vo.SessionID, uri string) error {
+	if _, err := uuid.Parse(sessionID.String()); err != nil {
+		return err
+	}
+
+	subscription := ResourceSubscriptionModel{SessionID: sessionID.String(), ResourceURI: uri}
+	return r.db.WithContext(ctx).
+		Where("session_id = ? AND resource_uri = ?", sessionID.String(), uri).
+		Attrs(ResourceSubscriptionModel{ID: uuid.New().String()}).
+		FirstOrCreate(&subscription).Error
+}
+
+// Unsubscribe removes a subscription
+func (r *ResourceSubscriptionRepository) Unsubscribe(ctx context.Context, sessionID vo.SessionID, uri string) error {
+	return r.db.WithContext(ctx).
+		Where("session_id = ? AND resource_uri = ?", sessionID.String(), uri).
+		Delete(&ResourceSubscriptionModel{}).Error
+}
+
+// FindBySessionID retrieves the resource URIs a session is subscribed to
+func (r *ResourceSubscriptionRepository) FindBySessionID(ctx context.Context, sessionID vo.SessionID) ([]string, error) {
+	var uris []string
+	err := r.db.WithContext(ctx).Model(&ResourceSubscriptionModel{}).
+		Where("session_id = ?", sessionID.String()).
+		Order("resource_uri").
+		Pluck("resource_uri", &uris).Error
+	return uris, err
+}
+
+// FindSubscribers retrieves the sessions subscribed to a resource URI
+func (r *ResourceSubscriptionRepository) FindSubscribers(ctx context.Context, uri string) ([]vo.SessionID, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&ResourceSubscriptionModel{}).
+		Where("resource_uri = ?", uri).
+		Distinct().
+		Pluck("session_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessionIDs := make([]vo.SessionID, 0, len(ids))
+	for _, id := range ids {
+		sessionID, err := vo.NewSessionID(id)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	return sessionIDs, nil
+}
+
+// DeleteBySessionID removes all subscriptions of a session
+func (r *ResourceSubscriptionRepository) DeleteBySessionID(ctx context.Context, sessionID vo.SessionID) error {
+	return r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID.String()).
+		Delete(&ResourceSubscriptionModel{}).Error
+}
+
+var _ repositories.IResourceSubscriptionRepository = (*ResourceSubscriptionRepository)(nil)
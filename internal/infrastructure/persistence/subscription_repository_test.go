@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// newDryRunDatabase returns a Database that records generated SQL instead of executing it
+func newDryRunDatabase(t *testing.T) (*Database, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 sslmode=disable"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	var statements []string
+	record := func(db *gorm.DB) { statements = append(statements, db.Statement.SQL.String()) }
+	if err := db.Callback().Create().After("gorm:create").Register("test:record", record); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("test:record", record); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("test:record", record); err != nil {
+		t.Fatal(err)
+	}
+	return &Database{db: db}, &statements
+}
+
+func TestResourceSubscriptionRepository_SQL(t *testing.T) {
+	ctx := context.Background()
+	db, statements := newDryRunDatabase(t)
+	repo := NewResourceSubscriptionRepository(db)
+	sessionID := vo.GenerateSessionID()
+
+	steps := []struct {
+		name string
+		run  func() error
+		want []string
+	}{
+		{
+			name: "subscribe",
+			run:  func() error { return repo.Subscribe(ctx, sessionID, "file:///notes.txt") },
+			want: []string{
+				`SELECT * FROM "resource_subscriptions" WHERE session_id = $1 AND resource_uri = $2`,
+				`INSERT INTO "resource_subscriptions" ("id","session_id","resource_uri","subscribed_at")`,
+			},
+		},
+		{
+			name: "find by session",
+			run: func() error {
+				_, err := repo.FindBySessionID(ctx, sessionID)
+				return err
+			},
+			want: []string{`SELECT "resource_uri" FROM "resource_subscriptions" WHERE session_id = $1 ORDER BY resource_uri`},
+		},
+		{
+			name: "find subscribers",
+			run: func() error {
+				_, err := repo.FindSubscribers(ctx, "file:///notes.txt")
+				return err
+			},
+			want: []string{`SELECT DISTINCT "session_id" FROM "resource_subscriptions" WHERE resource_uri = $1`},
+		},
+		{
+			name: "unsubscribe",
+			run:  func() error { return repo.Unsubscribe(ctx, sessionID, "file:///notes.txt") },
+			want: []string{`DELETE FROM "resource_subscriptions" WHERE session_id = $1 AND resource_uri = $2`},
+		},
+		{
+			name: "delete by session",
+			run:  func() error { return repo.DeleteBySessionID(ctx, sessionID) },
+			want: []string{`DELETE FROM "resource_subscriptions" WHERE session_id = $1`},
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			*statements = nil
+			if err := step.run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(*statements) != len(step.want) {
+				t.Fatalf("statements = %q, want %d", *statements, len(step.want))
+			}
+			for i, want := range step.want {
+				if !strings.HasPrefix((*statements)[i], want) {
+					t.Errorf("statement %d = %q, want prefix %q", i, (*statements)[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// startTaskConsumer starts a consumer on the tasks stream for a single task type
+func startTaskConsumer(t *testing.T, q *NATSQueue, consumerName, taskType string) {
+	t.Helper()
+	if err := q.StartConsumer(context.Background(), StreamTasks, consumerName, q.TaskSubject(taskType)); err != nil {
+		t.Fatalf("StartConsumer(%s) error = %v", consumerName, err)
+	}
+}
+
+func publishTask(t *testing.T, q *NATSQueue, taskType string) {
+	t.Helper()
+	if _, err := q.Publish(context.Background(), &Task{Type: taskType}); err != nil {
+		t.Fatalf("Publish(%s) error = %v", taskType, err)
+	}
+}
+
+func TestNATSQueue_StopConsumerFinishesInFlightTask(t *testing.T) {
+	q := newReplica(t, runJetStreamServer(t))
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	handlerErr := make(chan error, 1)
+	q.RegisterHandler("export", func(ctx context.Context, task *Task) error {
+		started <- struct{}{}
+		<-release
+		handlerErr <- ctx.Err()
+		return nil
+	})
+	imported := make(chan string, 8)
+	q.RegisterHandler("import", func(ctx context.Context, task *Task) error {
+		imported <- task.ID
+		return nil
+	})
+
+	startTaskConsumer(t, q, "exporters", "export")
+	startTaskConsumer(t, q, "importers", "import")
+
+	publishTask(t, q, "export")
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("export task was not picked up")
+	}
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- q.StopConsumer(context.Background(), "exporters") }()
+
+	// The stop waits for the in-flight task, while the other consumer keeps working
+	publishTask(t, q, "import")
+	select {
+	case <-imported:
+	case <-time.After(5 * time.Second):
+		t.Fatal("importers stopped processing while exporters was stopping")
+	}
+	select {
+	case err := <-stopped:
+		t.Fatalf("StopConsumer() returned before the in-flight task finished: %v", err)
+	default:
+	}
+
+	close(release)
+	if err := <-stopped; err != nil {
+		t.Fatalf("StopConsumer() error = %v", err)
+	}
+	if err := <-handlerErr; err != nil {
+		t.Errorf("in-flight task context was cancelled: %v", err)
+	}
+
+	// The stopped consumer pulls nothing more; publishing and the other consumer still work
+	publishTask(t, q, "export")
+	publishTask(t, q, "import")
+	select {
+	case <-imported:
+	case <-time.After(5 * time.Second):
+		t.Fatal("importers stopped processing after exporters was stopped")
+	}
+	select {
+	case <-started:
+		t.Fatal("stopped consumer processed a new task")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	if _, err := q.GetConsumerInfo(context.Background(), "exporters"); !errors.Is(err, ErrConsumerNotFound) {
+		t.Errorf("GetConsumerInfo(exporters) error = %v, want ErrConsumerNotFound", err)
+	}
+	if !q.IsRunning() {
+		t.Error("queue reports no running consumers while importers is running")
+	}
+}
+
+func TestNATSQueue_StopConsumerGracePeriod(t *testing.T) {
+	q := newReplica(t, runJetStreamServer(t))
+
+	started := make(chan struct{}, 1)
+	cancelled := make(chan struct{})
+	q.RegisterHandler("export", func(ctx context.Context, task *Task) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+	startTaskConsumer(t, q, "exporters", "export")
+	publishTask(t, q, "export")
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := q.StopConsumer(ctx, "exporters"); !errors.Is(err, ErrConsumerStopTimeout) {
+		t.Fatalf("StopConsumer() error = %v, want ErrConsumerStopTimeout", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight task was not cancelled after the grace period")
+	}
+	if q.IsRunning() {
+		t.Error("queue reports running consumers after the only one was stopped")
+	}
+}
+
+func TestNATSQueue_StopConsumerUnknown(t *testing.T) {
+	q := newReplica(t, runJetStreamServer(t))
+	if err := q.StopConsumer(context.Background(), "nobody"); !errors.Is(err, ErrConsumerNotFound) {
+		t.Errorf("StopConsumer() error = %v, want ErrConsumerNotFound", err)
+	}
+}
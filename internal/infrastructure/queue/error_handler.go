@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// TaskErrorAction tells the queue how to settle a message whose task handler failed.
+type TaskErrorAction int
+
+const (
+	// TaskErrorDefault applies the queue's retry policy: redeliver until the task's
+	// attempts are exhausted, then terminate.
+	TaskErrorDefault TaskErrorAction = iota
+	// TaskErrorNak redelivers the task. JetStream still stops at the consumer's MaxDeliver.
+	TaskErrorNak
+	// TaskErrorTerm stops redelivering the task.
+	TaskErrorTerm
+	// TaskErrorAck settles the task as handled, e.g. after routing it to a dead-letter subject.
+	TaskErrorAck
+)
+
+// TaskErrorHandler decides how to settle a task whose handler returned err on its
+// attempt-th delivery (1-based). It may publish the task elsewhere, such as a
+// dead-letter subject, before returning.
+type TaskErrorHandler func(ctx context.Context, task *Task, err error, attempt int) TaskErrorAction
+
+// SetConsumerErrorHandler sets the handler deciding how failed tasks delivered by
+// consumerName are settled. A nil handler restores the default retry policy.
+func (q *NATSQueue) SetConsumerErrorHandler(consumerName string, handler TaskErrorHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if handler == nil {
+		delete(q.errorHandlers, consumerName)
+		return
+	}
+	q.errorHandlers[consumerName] = handler
+}
+
+// settleFailure settles a message whose task handler failed, deferring to the
+// consumer's error handler when one is set.
+func (q *NATSQueue) settleFailure(ctx context.Context, consumerName string, msg jetstream.Msg, task *Task, err error, attempt int) {
+	q.mu.RLock()
+	errorHandler := q.errorHandlers[consumerName]
+	q.mu.RUnlock()
+
+	action := TaskErrorDefault
+	if errorHandler != nil {
+		action = errorHandler(ctx, task, err, attempt)
+	}
+
+	switch action {
+	case TaskErrorNak:
+		q.logger.Warn().Err(err).Str("task_id", task.ID).Str("consumer", consumerName).Msg("Task failed, error handler requested retry")
+		_ = msg.Nak()
+	case TaskErrorTerm:
+		q.logger.Error().Err(err).Str("task_id", task.ID).Str("consumer", consumerName).Msg("Task failed, error handler terminated it")
+		_ = msg.Term()
+	case TaskErrorAck:
+		q.logger.Warn().Err(err).Str("task_id", task.ID).Str("consumer", consumerName).Msg("Task failed, error handler acknowledged it")
+		_ = msg.Ack()
+	default:
+		if attempt >= q.maxAttempts(task) {
+			q.logger.Error().Err(err).Str("task_id", task.ID).Int("retries", task.Retries).Msg("Task failed, retries exhausted")
+			_ = msg.Term()
+		} else {
+			q.logger.Warn().Err(err).Str("task_id", task.ID).Msg("Task failed, will retry")
+			_ = msg.Nak()
+		}
+	}
+}
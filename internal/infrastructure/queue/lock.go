@@ -0,0 +1,299 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+)
+
+// Lock errors
+var (
+	ErrLockHeld                 = errors.New("lock is held by another owner")
+	ErrLockLost                 = errors.New("lock was lost")
+	ErrInvalidLockTTL           = errors.New("lock ttl must be positive")
+	ErrInvalidLockSkewTolerance = errors.New("lock_skew_tolerance must not be negative")
+)
+
+// DefaultLockBucket is the KV bucket distributed locks are kept in.
+const DefaultLockBucket = "MCP_LOCKS"
+
+// lockRecord is the value stored under the key of a held lock.
+type lockRecord struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Lock is a distributed lock held by this process. It is renewed in the
+// background until released, so it only expires when the holder stops
+// renewing it, e.g. after a crash.
+type Lock struct {
+	key   string
+	owner string
+	ttl   time.Duration
+	kv    jetstream.KeyValue
+	clock services.Clock
+
+	mu       sync.Mutex
+	revision uint64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	renewed  chan struct{}
+	lost     chan struct{}
+}
+
+// Key returns the locked key.
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Lost is closed when the lock could not be renewed and may now be held by
+// another owner.
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// AcquireLock takes the lock under key for ttl, failing with ErrLockHeld when
+// another owner holds it. An expired lock is taken over, so a crashed holder
+// blocks others for at most ttl plus the lock skew tolerance. Expiry is judged by
+// the acquiring replica's clock against the holder's, so their disagreement must
+// stay within the tolerance, or the lock may be taken over while still held.
+func (q *NATSQueue) AcquireLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if !q.isReady() {
+		return nil, ErrQueueDisabled
+	}
+	if ttl <= 0 {
+		return nil, ErrInvalidLockTTL
+	}
+
+	kv, err := q.lockBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := uuid.New().String()
+	expiry := q.lockExpiry()
+	revision, err := takeLock(ctx, kv, key, owner, ttl, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lock{
+		key:      key,
+		owner:    owner,
+		ttl:      ttl,
+		kv:       kv,
+		clock:    expiry,
+		revision: revision,
+		stop:     make(chan struct{}),
+		renewed:  make(chan struct{}),
+		lost:     make(chan struct{}),
+	}
+	go q.renewLock(lock)
+
+	q.logger.Debug().Str("key", key).Dur("ttl", ttl).Msg("Lock acquired")
+	return lock, nil
+}
+
+// ReleaseLock stops renewing the lock and frees it for other owners. It returns
+// ErrLockLost when the lock expired and was taken over in the meantime.
+func (q *NATSQueue) ReleaseLock(ctx context.Context, lock *Lock) error {
+	lock.stopRenewal()
+
+	lock.mu.Lock()
+	revision := lock.revision
+	lock.mu.Unlock()
+
+	if err := lock.kv.Delete(ctx, lock.key, jetstream.LastRevision(revision)); err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return ErrLockLost
+		}
+		return fmt.Errorf("failed to release lock %s: %w", lock.key, err)
+	}
+
+	q.logger.Debug().Str("key", lock.key).Msg("Lock released")
+	return nil
+}
+
+// RunSingleton runs job while this process holds the lock under key, so only one
+// replica runs it at a time. Until the lock is free it retries every half ttl.
+// The job's context is cancelled if the lock is lost, after which RunSingleton
+// competes for the lock again. It returns when job returns on its own or ctx is done.
+func (q *NATSQueue) RunSingleton(ctx context.Context, key string, ttl time.Duration, job func(ctx context.Context)) {
+	for {
+		lock, err := q.AcquireLock(ctx, key, ttl)
+		if err == nil {
+			if lost := q.runLocked(ctx, lock, job); !lost {
+				return
+			}
+			q.logger.Warn().Str("key", key).Msg("Lock lost, singleton job stopped")
+		} else if !errors.Is(err, ErrLockHeld) {
+			q.logger.Debug().Err(err).Str("key", key).Msg("Failed to acquire lock")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ttl / 2):
+		}
+	}
+}
+
+// runLocked runs job until it returns or the lock is lost, then releases the
+// lock. It reports whether the job was stopped because the lock was lost.
+func (q *NATSQueue) runLocked(ctx context.Context, lock *Lock, job func(ctx context.Context)) bool {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lost := make(chan bool, 1)
+	go func() {
+		select {
+		case <-lock.Lost():
+			cancel()
+			lost <- true
+		case <-jobCtx.Done():
+			lost <- false
+		}
+	}()
+
+	job(jobCtx)
+	cancel()
+	if err := q.ReleaseLock(context.Background(), lock); err != nil && !errors.Is(err, ErrLockLost) {
+		q.logger.Warn().Err(err).Str("key", lock.key).Msg("Failed to release lock")
+	}
+	return <-lost && ctx.Err() == nil
+}
+
+// SetClock sets the clock lock expiry times are written and checked with. Without
+// one, the system clock is used.
+func (q *NATSQueue) SetClock(clock services.Clock) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.clock = clock
+}
+
+// lockExpiry returns the checker judging whether locks held by others have expired.
+func (q *NATSQueue) lockExpiry() services.ExpiryChecker {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return services.NewExpiryChecker(q.clock, q.config.LockSkewTolerance)
+}
+
+// lockBucket returns the KV bucket holding locks, creating it if needed.
+func (q *NATSQueue) lockBucket(ctx context.Context) (jetstream.KeyValue, error) {
+	q.mu.RLock()
+	js := q.js
+	bucket := q.config.LockBucket
+	q.mu.RUnlock()
+
+	if bucket == "" {
+		bucket = DefaultLockBucket
+	}
+	kv, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:      bucket,
+		Description: "Distributed locks for singleton tasks",
+		History:     1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock bucket: %w", err)
+	}
+	return kv, nil
+}
+
+// takeLock writes a lock record under key if the key is free or its lock has
+// expired, returning the record's revision.
+func takeLock(ctx context.Context, kv jetstream.KeyValue, key, owner string, ttl time.Duration, expiry services.ExpiryChecker) (uint64, error) {
+	data, err := json.Marshal(lockRecord{Owner: owner, ExpiresAt: expiry.Now().Add(ttl)})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrSerializeFailed, err)
+	}
+
+	revision, err := kv.Create(ctx, key, data)
+	if err == nil {
+		return revision, nil
+	}
+	if !errors.Is(err, jetstream.ErrKeyExists) {
+		return 0, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+
+	entry, err := kv.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			// Released between the two calls; the caller may retry
+			return 0, ErrLockHeld
+		}
+		return 0, fmt.Errorf("failed to read lock %s: %w", key, err)
+	}
+	var held lockRecord
+	if err := json.Unmarshal(entry.Value(), &held); err == nil && !expiry.Expired(held.ExpiresAt) {
+		return 0, ErrLockHeld
+	}
+
+	// Take over the expired lock unless another owner got there first
+	revision, err = kv.Update(ctx, key, data, entry.Revision())
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return 0, ErrLockHeld
+		}
+		return 0, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	return revision, nil
+}
+
+// renewLock extends the lock every third of its ttl until it is released,
+// closing lost if an extension fails.
+func (q *NATSQueue) renewLock(lock *Lock) {
+	defer close(lock.renewed)
+
+	ticker := time.NewTicker(lock.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lock.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if err := lock.renew(); err != nil {
+			q.logger.Warn().Err(err).Str("key", lock.key).Msg("Failed to renew lock")
+			close(lock.lost)
+			return
+		}
+	}
+}
+
+// renew extends the lock by its ttl, failing if its record changed since the
+// last renewal.
+func (l *Lock) renew() error {
+	data, err := json.Marshal(lockRecord{Owner: l.owner, ExpiresAt: l.clock.Now().Add(l.ttl)})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+	defer cancel()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	revision, err := l.kv.Update(ctx, l.key, data, l.revision)
+	if err != nil {
+		return err
+	}
+	l.revision = revision
+	return nil
+}
+
+// stopRenewal stops the background renewal and waits for it to finish.
+func (l *Lock) stopRenewal() {
+	l.stopOnce.Do(func() { close(l.stop) })
+	<-l.renewed
+}
@@ -0,0 +1,66 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func TestLock_SkewToleranceDelaysTakeover(t *testing.T) {
+	ctx := context.Background()
+	url := runJetStreamServer(t)
+	holder, acquirer := newReplica(t, url), newReplica(t, url)
+
+	acquiredAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	const ttl = time.Hour
+	const tolerance = 2 * time.Second
+	expiresAt := acquiredAt.Add(ttl)
+
+	holder.SetClock(mocks.NewFakeClock(acquiredAt))
+	acquirerClock := mocks.NewFakeClock(time.Time{})
+	acquirer.SetClock(acquirerClock)
+	acquirer.config.LockSkewTolerance = tolerance
+
+	lock, err := holder.AcquireLock(ctx, "reaper", ttl)
+	if err != nil {
+		t.Fatalf("AcquireLock() error = %v", err)
+	}
+	// Simulate a crashed holder that never releases the lock
+	lock.stopRenewal()
+
+	for _, tt := range []struct {
+		name string
+		now  time.Time
+	}{
+		{"just before expiry", expiresAt.Add(-time.Millisecond)},
+		{"just after expiry", expiresAt.Add(time.Millisecond)},
+		{"just before the end of the tolerance", expiresAt.Add(tolerance - time.Millisecond)},
+	} {
+		acquirerClock.Set(tt.now)
+		if _, err := acquirer.AcquireLock(ctx, "reaper", ttl); !errors.Is(err, ErrLockHeld) {
+			t.Fatalf("AcquireLock() %s error = %v, want ErrLockHeld", tt.name, err)
+		}
+	}
+
+	acquirerClock.Set(expiresAt.Add(tolerance + time.Millisecond))
+	taken, err := acquirer.AcquireLock(ctx, "reaper", ttl)
+	if err != nil {
+		t.Fatalf("AcquireLock() after the tolerance error = %v", err)
+	}
+	defer func() { _ = acquirer.ReleaseLock(ctx, taken) }()
+
+	if err := holder.ReleaseLock(ctx, lock); !errors.Is(err, ErrLockLost) {
+		t.Errorf("ReleaseLock() of a taken-over lock error = %v, want ErrLockLost", err)
+	}
+}
+
+func TestNATSConfig_RejectsNegativeLockSkewTolerance(t *testing.T) {
+	cfg := DefaultNATSConfig()
+	cfg.LockSkewTolerance = -time.Second
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidLockSkewTolerance) {
+		t.Errorf("Validate() error = %v, want ErrInvalidLockSkewTolerance", err)
+	}
+}
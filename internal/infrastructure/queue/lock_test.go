@@ -0,0 +1,167 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/rs/zerolog"
+)
+
+// runJetStreamServer starts an embedded NATS server with JetStream and returns its URL
+func runJetStreamServer(t *testing.T) string {
+	t.Helper()
+	ns, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create NATS server: %v", err)
+	}
+	go ns.Start()
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server not ready")
+	}
+	t.Cleanup(ns.Shutdown)
+	return ns.ClientURL()
+}
+
+// newReplica connects a queue to the server at url, as one replica of the service
+func newReplica(t *testing.T, url string) *NATSQueue {
+	t.Helper()
+	cfg := DefaultNATSConfig()
+	cfg.URL = url
+	cfg.Required = true
+	cfg.StreamMaxBytes = 1024 * 1024
+
+	q, err := NewNATSQueue(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Initialize(context.Background()); err != nil {
+		t.Fatalf("failed to initialize queue: %v", err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+	return q
+}
+
+func TestLock_MutualExclusion(t *testing.T) {
+	ctx := context.Background()
+	url := runJetStreamServer(t)
+	a, b := newReplica(t, url), newReplica(t, url)
+
+	lock, err := a.AcquireLock(ctx, "reaper", time.Second)
+	if err != nil {
+		t.Fatalf("AcquireLock() error = %v", err)
+	}
+	if _, err := b.AcquireLock(ctx, "reaper", time.Second); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("second AcquireLock() error = %v, want ErrLockHeld", err)
+	}
+	other, err := a.AcquireLock(ctx, "scheduler", time.Second)
+	if err != nil {
+		t.Fatalf("locks on other keys must be independent, got %v", err)
+	}
+	defer func() { _ = a.ReleaseLock(ctx, other) }()
+
+	if err := a.ReleaseLock(ctx, lock); err != nil {
+		t.Fatalf("ReleaseLock() error = %v", err)
+	}
+	lock, err = b.AcquireLock(ctx, "reaper", time.Second)
+	if err != nil {
+		t.Fatalf("AcquireLock() after release error = %v", err)
+	}
+	_ = b.ReleaseLock(ctx, lock)
+}
+
+func TestLock_RenewedWhileHeld(t *testing.T) {
+	ctx := context.Background()
+	url := runJetStreamServer(t)
+	a, b := newReplica(t, url), newReplica(t, url)
+
+	lock, err := a.AcquireLock(ctx, "reaper", 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireLock() error = %v", err)
+	}
+	defer func() { _ = a.ReleaseLock(ctx, lock) }()
+
+	time.Sleep(time.Second)
+	if _, err := b.AcquireLock(ctx, "reaper", 300*time.Millisecond); !errors.Is(err, ErrLockHeld) {
+		t.Errorf("AcquireLock() past the ttl of a renewed lock error = %v, want ErrLockHeld", err)
+	}
+	select {
+	case <-lock.Lost():
+		t.Error("renewed lock reported lost")
+	default:
+	}
+}
+
+func TestLock_ExpiresWhenHolderStopsRenewing(t *testing.T) {
+	ctx := context.Background()
+	url := runJetStreamServer(t)
+	a, b := newReplica(t, url), newReplica(t, url)
+
+	lock, err := a.AcquireLock(ctx, "reaper", 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireLock() error = %v", err)
+	}
+	// Simulate a crashed holder that never releases the lock
+	lock.stopRenewal()
+
+	if _, err := b.AcquireLock(ctx, "reaper", 300*time.Millisecond); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("AcquireLock() before expiry error = %v, want ErrLockHeld", err)
+	}
+
+	time.Sleep(400 * time.Millisecond)
+	taken, err := b.AcquireLock(ctx, "reaper", 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireLock() after expiry error = %v", err)
+	}
+	defer func() { _ = b.ReleaseLock(ctx, taken) }()
+
+	if err := a.ReleaseLock(ctx, lock); !errors.Is(err, ErrLockLost) {
+		t.Errorf("ReleaseLock() of a taken-over lock error = %v, want ErrLockLost", err)
+	}
+}
+
+func TestRunSingleton_OneReplicaAtATime(t *testing.T) {
+	url := runJetStreamServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	var running, maxRunning, runs int32
+	job := func(ctx context.Context) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&runs, 1)
+		for {
+			current := atomic.LoadInt32(&maxRunning)
+			if n <= current || atomic.CompareAndSwapInt32(&maxRunning, current, n) {
+				break
+			}
+		}
+		<-ctx.Done()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		q := newReplica(t, url)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.RunSingleton(ctx, "reaper", 300*time.Millisecond, job)
+		}()
+	}
+	wg.Wait()
+
+	if runs != 1 || maxRunning != 1 {
+		t.Errorf("job ran %d times with up to %d at once, want once on a single replica", runs, maxRunning)
+	}
+}
@@ -0,0 +1,40 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// PublishMetrics records the outcome of messages published to JetStream
+type PublishMetrics interface {
+	RecordQueuePublish(ctx context.Context, stream, subject string, duration time.Duration, err error)
+}
+
+// SetMetrics sets the recorder for publish latency and failures. Without one,
+// publishing records nothing.
+func (q *NATSQueue) SetMetrics(metrics PublishMetrics) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.metrics = metrics
+}
+
+// publish publishes data under subject and records how long JetStream took to ack
+// it. stream is the stream the subject is expected to land in; the ack's stream is
+// reported instead when there is one.
+func (q *NATSQueue) publish(ctx context.Context, stream, subject string, data []byte) (*jetstream.PubAck, error) {
+	q.mu.RLock()
+	js, metrics := q.js, q.metrics
+	q.mu.RUnlock()
+
+	start := time.Now()
+	ack, err := js.Publish(ctx, subject, data)
+	if metrics != nil {
+		if ack != nil && ack.Stream != "" {
+			stream = ack.Stream
+		}
+		metrics.RecordQueuePublish(ctx, stream, subject, time.Since(start), err)
+	}
+	return ack, err
+}
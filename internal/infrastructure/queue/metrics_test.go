@@ -0,0 +1,77 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type publishRecord struct {
+	stream   string
+	subject  string
+	duration time.Duration
+	err      error
+}
+
+// recordingMetrics captures the publishes a queue records
+type recordingMetrics struct {
+	mu      sync.Mutex
+	records []publishRecord
+}
+
+func (m *recordingMetrics) RecordQueuePublish(ctx context.Context, stream, subject string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, publishRecord{stream, subject, duration, err})
+}
+
+func (m *recordingMetrics) take() []publishRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	records := m.records
+	m.records = nil
+	return records
+}
+
+func TestNATSQueue_PublishRecordsMetrics(t *testing.T) {
+	ctx := context.Background()
+	q := newReplica(t, runJetStreamServer(t))
+	metrics := &recordingMetrics{}
+	q.SetMetrics(metrics)
+
+	if _, err := q.Publish(ctx, &Task{Type: TaskTypeSessionCleanup}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := q.PublishEvent(ctx, "session.created", map[string]interface{}{"id": "1"}); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+	if err := q.PublishTelemetry(ctx, "span", map[string]interface{}{"name": "x"}); err != nil {
+		t.Fatalf("PublishTelemetry() error = %v", err)
+	}
+
+	records := metrics.take()
+	want := []struct{ stream, subject string }{
+		{StreamTasks, "tasks." + TaskTypeSessionCleanup},
+		{StreamEvents, "events.session.created"},
+		{StreamTelemetry, "telemetry.span"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("recorded %d publishes, want %d: %+v", len(records), len(want), records)
+	}
+	for i, w := range want {
+		got := records[i]
+		if got.stream != w.stream || got.subject != w.subject || got.err != nil || got.duration <= 0 {
+			t.Errorf("publish %d recorded %+v, want a successful publish to %s under %s", i, got, w.stream, w.subject)
+		}
+	}
+
+	// No stream captures the subject, so JetStream never acks it
+	if _, err := q.Publish(ctx, &Task{Type: "orphan", Subject: "nowhere.orphan"}); err == nil {
+		t.Fatal("Publish() to a subject without a stream should fail")
+	}
+	records = metrics.take()
+	if len(records) != 1 || records[0].err == nil || records[0].subject != "nowhere.orphan" {
+		t.Errorf("failed publish recorded %+v, want one failure", records)
+	}
+}
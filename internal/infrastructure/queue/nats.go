@@ -9,22 +9,28 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
 )
 
 // Common errors
 var (
-	ErrQueueDisabled     = errors.New("queue is disabled")
-	ErrInvalidTask       = errors.New("invalid task")
-	ErrTaskNotFound      = errors.New("task not found")
-	ErrSerializeFailed   = errors.New("failed to serialize payload")
-	ErrDeserializeFailed = errors.New("failed to deserialize payload")
-	ErrStreamNotFound    = errors.New("stream not found")
-	ErrConsumerNotFound  = errors.New("consumer not found")
+	ErrQueueDisabled       = errors.New("queue is disabled")
+	ErrInvalidTask         = errors.New("invalid task")
+	ErrTaskNotFound        = errors.New("task not found")
+	ErrSerializeFailed     = errors.New("failed to serialize payload")
+	ErrDeserializeFailed   = errors.New("failed to deserialize payload")
+	ErrStreamNotFound      = errors.New("stream not found")
+	ErrConsumerNotFound    = errors.New("consumer not found")
+	ErrConsumerStopTimeout = errors.New("consumer did not finish its in-flight task in time")
+	ErrInvalidMaxDeliver   = errors.New("max_deliver must be at least 1")
 )
 
 // TaskState represents the state of a task.
@@ -72,8 +78,8 @@ type Task struct {
 	Payload   map[string]interface{} `json:"payload"`
 	Subject   string                 `json:"subject"`
 	Priority  TaskPriority           `json:"priority"`
-	MaxRetry  int                    `json:"max_retry"`
-	Retries   int                    `json:"retries"`
+	MaxRetry  int                    `json:"max_retry"` // Retries after the first attempt; 0 uses the queue's MaxDeliver
+	Retries   int                    `json:"retries"`   // Set on delivery to the number of previous attempts
 	Timeout   time.Duration          `json:"timeout"`
 	Deadline  time.Time              `json:"deadline,omitempty"`
 	CreatedAt time.Time              `json:"created_at"`
@@ -91,6 +97,9 @@ type TaskResult struct {
 	Timestamp time.Time              `json:"timestamp"`
 }
 
+// maxStoredResults bounds how many task results are kept for GetResult.
+const maxStoredResults = 1000
+
 // NATSConfig configures the NATS queue.
 type NATSConfig struct {
 	// URL is the NATS server URL
@@ -125,6 +134,17 @@ type NATSConfig struct {
 	AckWait time.Duration `mapstructure:"ack_wait" yaml:"ack_wait" json:"ack_wait"`
 	// MaxDeliver is the maximum number of delivery attempts
 	MaxDeliver int `mapstructure:"max_deliver" yaml:"max_deliver" json:"max_deliver"`
+	// Required fails startup when the server is unreachable; otherwise the
+	// queue runs degraded and reconnects in the background
+	Required bool `mapstructure:"required" yaml:"required" json:"required"`
+	// LockBucket is the KV bucket holding distributed locks
+	LockBucket string `mapstructure:"lock_bucket" yaml:"lock_bucket" json:"lock_bucket"`
+	// LockSkewTolerance is how long past its expiry a lock is still treated as held,
+	// allowing for replica clocks that disagree; zero trusts the clocks to agree
+	LockSkewTolerance time.Duration `mapstructure:"lock_skew_tolerance" yaml:"lock_skew_tolerance" json:"lock_skew_tolerance"`
+	// UpdateStreams lets the queue overwrite existing streams whose configuration
+	// differs from the expected one instead of failing on incompatible differences
+	UpdateStreams bool `mapstructure:"update_streams" yaml:"update_streams" json:"update_streams"`
 }
 
 // DefaultNATSConfig returns default configuration.
@@ -142,49 +162,127 @@ func DefaultNATSConfig() *NATSConfig {
 		StreamMaxBytes:  1024 * 1024 * 1024, // 1GB
 		AckWait:         30 * time.Second,
 		MaxDeliver:      3,
+		LockBucket:      DefaultLockBucket,
+	}
+}
+
+// Validate checks the configuration.
+func (c *NATSConfig) Validate() error {
+	if c.MaxDeliver < 1 {
+		return fmt.Errorf("%w, got %d", ErrInvalidMaxDeliver, c.MaxDeliver)
+	}
+	if c.LockSkewTolerance < 0 {
+		return ErrInvalidLockSkewTolerance
 	}
+	return nil
 }
 
+// QueueState represents the connection state of the queue.
+type QueueState string
+
+const (
+	QueueStateDisabled    QueueState = "disabled"
+	QueueStateReady       QueueState = "ready"
+	QueueStateDegraded    QueueState = "degraded"
+	QueueStateUnavailable QueueState = "unavailable"
+)
+
 // NATSQueue provides NATS JetStream-based job queue functionality.
 type NATSQueue struct {
-	conn        *nats.Conn
-	js          jetstream.JetStream
-	config      *NATSConfig
-	handlers    map[string]TaskHandler
-	consumers   map[string]jetstream.Consumer
-	streams     map[string]jetstream.Stream
-	enabled     bool
-	running     bool
-	mu          sync.RWMutex
-	initialized bool
-	cancelFuncs []context.CancelFunc
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	config        *NATSConfig
+	logger        zerolog.Logger
+	handlers      map[string]TaskHandler
+	errorHandlers map[string]TaskErrorHandler
+	consumers     map[string]jetstream.Consumer
+	streams       map[string]jetstream.Stream
+	enabled       bool
+	running       bool
+	mu            sync.RWMutex
+	initialized   bool
+	consumerRuns  map[string]*consumerRun
+	consumerSpecs map[string]consumerSpec
+	taskRoutes    map[string]TaskRoute
+	metrics       PublishMetrics
+	clock         services.Clock
+	degraded      bool
+	lastErr       error
+	stopReconnect chan struct{}
+
+	// Latest result per task, evicted oldest first beyond maxStoredResults
+	results     map[string]*TaskResult
+	resultOrder []string
+}
+
+// consumerSpec records a started consumer so it can be restarted after a reconnect.
+type consumerSpec struct {
+	ctx           context.Context
+	streamName    string
+	consumerName  string
+	filterSubject string
+}
+
+// consumerRun is the goroutine processing a consumer's messages. Stopping it ends
+// the pull loop; the in-flight handler runs under a separate context so it can
+// finish its message.
+type consumerRun struct {
+	stop       context.CancelFunc // stops pulling messages
+	cancelWork context.CancelFunc // cancels the in-flight handler
+	done       chan struct{}      // closed once the goroutine has returned
+}
+
+// cancel stops the consumer and its in-flight handler
+func (r *consumerRun) cancel() {
+	r.stop()
+	r.cancelWork()
 }
 
 // NewNATSQueue creates a new NATS-based queue.
-func NewNATSQueue(cfg *NATSConfig) (*NATSQueue, error) {
+func NewNATSQueue(cfg *NATSConfig, logger zerolog.Logger) (*NATSQueue, error) {
 	if cfg == nil {
 		cfg = DefaultNATSConfig()
 	}
 
 	if !cfg.Enabled {
 		return &NATSQueue{
-			enabled:   false,
-			handlers:  make(map[string]TaskHandler),
-			consumers: make(map[string]jetstream.Consumer),
-			streams:   make(map[string]jetstream.Stream),
+			enabled:       false,
+			logger:        logger,
+			handlers:      make(map[string]TaskHandler),
+			errorHandlers: make(map[string]TaskErrorHandler),
+			consumers:     make(map[string]jetstream.Consumer),
+			streams:       make(map[string]jetstream.Stream),
+			consumerRuns:  make(map[string]*consumerRun),
+			consumerSpecs: make(map[string]consumerSpec),
+			taskRoutes:    make(map[string]TaskRoute),
+			results:       make(map[string]*TaskResult),
 		}, nil
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &NATSQueue{
-		config:    cfg,
-		handlers:  make(map[string]TaskHandler),
-		consumers: make(map[string]jetstream.Consumer),
-		streams:   make(map[string]jetstream.Stream),
-		enabled:   true,
+		config:        cfg,
+		logger:        logger,
+		handlers:      make(map[string]TaskHandler),
+		errorHandlers: make(map[string]TaskErrorHandler),
+		consumers:     make(map[string]jetstream.Consumer),
+		streams:       make(map[string]jetstream.Stream),
+		consumerRuns:  make(map[string]*consumerRun),
+		consumerSpecs: make(map[string]consumerSpec),
+		taskRoutes:    make(map[string]TaskRoute),
+		results:       make(map[string]*TaskResult),
+		enabled:       true,
 	}, nil
 }
 
 // Initialize initializes the NATS connection and JetStream.
+//
+// When the server is unreachable and the queue is not required, Initialize
+// logs a warning, leaves the queue in degraded mode and keeps retrying in the
+// background. Publish calls return ErrQueueDisabled until it reconnects.
 func (q *NATSQueue) Initialize(ctx context.Context) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -197,6 +295,27 @@ func (q *NATSQueue) Initialize(ctx context.Context) error {
 		return nil
 	}
 
+	conn, js, streams, err := q.connect(ctx, q.streamConfigs())
+	if err != nil {
+		// A mismatched stream will not fix itself by reconnecting
+		if q.config.Required || errors.Is(err, ErrStreamConfigMismatch) {
+			return err
+		}
+		q.degraded = true
+		q.lastErr = err
+		if q.stopReconnect == nil {
+			q.stopReconnect = make(chan struct{})
+			go q.reconnectLoop(q.stopReconnect)
+		}
+		return nil
+	}
+
+	q.setConnectedLocked(conn, js, streams)
+	return nil
+}
+
+// connect dials NATS and prepares JetStream and the given streams.
+func (q *NATSQueue) connect(ctx context.Context, configs []jetstream.StreamConfig) (*nats.Conn, jetstream.JetStream, map[string]jetstream.Stream, error) {
 	// Build connection options
 	opts := []nats.Option{
 		nats.Name(q.config.Name),
@@ -205,14 +324,16 @@ func (q *NATSQueue) Initialize(ctx context.Context) error {
 		nats.ReconnectWait(q.config.ReconnectWait),
 		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
 			if err != nil {
-				fmt.Printf("NATS disconnected: %v\n", err)
+				q.logger.Warn().Err(err).Msg("NATS disconnected")
 			}
 		}),
 		nats.ReconnectHandler(func(nc *nats.Conn) {
-			fmt.Printf("NATS reconnected to %s\n", nc.ConnectedUrl())
+			q.logger.Info().Str("url", nc.ConnectedUrl()).Msg("NATS reconnected")
+			// Don't block the NATS callback goroutine
+			go q.handleReconnect()
 		}),
 		nats.ClosedHandler(func(nc *nats.Conn) {
-			fmt.Println("NATS connection closed")
+			q.logger.Debug().Msg("NATS connection closed")
 		}),
 	}
 
@@ -224,35 +345,95 @@ func (q *NATSQueue) Initialize(ctx context.Context) error {
 	}
 
 	// Connect to NATS
+	var conn *nats.Conn
 	var err error
 	if len(q.config.URLs) > 0 {
-		q.conn, err = nats.Connect(q.config.URLs[0], opts...)
+		conn, err = nats.Connect(q.config.URLs[0], opts...)
 	} else {
-		q.conn, err = nats.Connect(q.config.URL, opts...)
+		conn, err = nats.Connect(q.config.URL, opts...)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to connect to NATS: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to connect to NATS: %w", err)
 	}
 
 	// Create JetStream context
-	q.js, err = jetstream.New(q.conn)
+	js, err := jetstream.New(conn)
 	if err != nil {
-		q.conn.Close()
-		return fmt.Errorf("failed to create JetStream context: %w", err)
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("failed to create JetStream context: %w", err)
 	}
 
-	// Create default streams
-	if err := q.createDefaultStreams(ctx); err != nil {
-		q.conn.Close()
-		return fmt.Errorf("failed to create streams: %w", err)
+	// Create default and routed streams
+	streams, err := q.createStreams(ctx, js, configs)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("failed to create streams: %w", err)
 	}
 
+	return conn, js, streams, nil
+}
+
+// setConnectedLocked installs a live connection. Caller must hold q.mu.
+func (q *NATSQueue) setConnectedLocked(conn *nats.Conn, js jetstream.JetStream, streams map[string]jetstream.Stream) {
+	q.conn = conn
+	q.js = js
+	for name, stream := range streams {
+		q.streams[name] = stream
+	}
 	q.initialized = true
-	return nil
+	q.degraded = false
+	q.lastErr = nil
 }
 
-// createDefaultStreams creates the default JetStream streams.
-func (q *NATSQueue) createDefaultStreams(ctx context.Context) error {
+// reconnectLoop retries the initial connection until it succeeds or the queue is closed.
+func (q *NATSQueue) reconnectLoop(stop <-chan struct{}) {
+	wait := q.config.ReconnectWait
+	if wait <= 0 {
+		wait = 2 * time.Second
+	}
+	ticker := time.NewTicker(wait)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			q.mu.RLock()
+			configs := q.streamConfigs()
+			q.mu.RUnlock()
+
+			ctx, cancel := context.WithTimeout(context.Background(), q.config.Timeout)
+			conn, js, streams, err := q.connect(ctx, configs)
+			cancel()
+
+			q.mu.Lock()
+			select {
+			case <-stop:
+				// Closed while connecting
+				q.mu.Unlock()
+				if conn != nil {
+					conn.Close()
+				}
+				return
+			default:
+			}
+			if err != nil {
+				q.lastErr = err
+				q.mu.Unlock()
+				continue
+			}
+			q.setConnectedLocked(conn, js, streams)
+			q.stopReconnect = nil
+			q.mu.Unlock()
+			q.logger.Info().Msg("NATS connection established, queue leaving degraded mode")
+			return
+		}
+	}
+}
+
+// defaultStreamConfigs returns the configuration of the default JetStream streams.
+func (q *NATSQueue) defaultStreamConfigs() []jetstream.StreamConfig {
 	streams := []struct {
 		name     string
 		subjects []string
@@ -262,8 +443,9 @@ func (q *NATSQueue) createDefaultStreams(ctx context.Context) error {
 		{StreamTelemetry, []string{SubjectTelemetryPrefix + ".>"}},
 	}
 
+	configs := make([]jetstream.StreamConfig, 0, len(streams))
 	for _, s := range streams {
-		cfg := jetstream.StreamConfig{
+		configs = append(configs, jetstream.StreamConfig{
 			Name:        s.name,
 			Description: fmt.Sprintf("TFO-GO-MCP %s stream", s.name),
 			Subjects:    s.subjects,
@@ -274,16 +456,75 @@ func (q *NATSQueue) createDefaultStreams(ctx context.Context) error {
 			Storage:     jetstream.FileStorage,
 			Replicas:    1,
 			Discard:     jetstream.DiscardOld,
+		})
+	}
+	return configs
+}
+
+// createStreams creates the given JetStream streams, verifying any that already exist.
+func (q *NATSQueue) createStreams(ctx context.Context, js jetstream.JetStream, configs []jetstream.StreamConfig) (map[string]jetstream.Stream, error) {
+	created := make(map[string]jetstream.Stream, len(configs))
+	for _, cfg := range configs {
+		stream, err := q.ensureStream(ctx, js, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stream %s: %w", cfg.Name, err)
 		}
+		created[cfg.Name] = stream
+	}
+
+	return created, nil
+}
+
+// handleReconnect re-discovers streams and restarts consumers after NATS reconnects.
+func (q *NATSQueue) handleReconnect() {
+	timeout := q.config.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := q.resync(ctx); err != nil {
+		q.logger.Error().Err(err).Msg("Failed to restore JetStream state after reconnect")
+		q.mu.Lock()
+		q.lastErr = err
+		q.mu.Unlock()
+	}
+}
+
+// resync re-validates the default and routed streams, recreating any the server lost,
+// and restarts every registered consumer against the refreshed handles.
+func (q *NATSQueue) resync(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.js == nil {
+		return ErrQueueDisabled
+	}
 
-		stream, err := q.js.CreateOrUpdateStream(ctx, cfg)
+	for _, cfg := range q.streamConfigs() {
+		stream, err := q.js.Stream(ctx, cfg.Name)
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
+			q.logger.Warn().Str("stream", cfg.Name).Msg("Stream missing after reconnect, recreating")
+			stream, err = q.js.CreateOrUpdateStream(ctx, cfg)
+		}
 		if err != nil {
-			return fmt.Errorf("failed to create stream %s: %w", s.name, err)
+			return fmt.Errorf("failed to restore stream %s: %w", cfg.Name, err)
 		}
-		q.streams[s.name] = stream
+		q.streams[cfg.Name] = stream
 	}
 
-	return nil
+	var errs []error
+	for _, spec := range q.consumerSpecs {
+		if spec.ctx.Err() != nil {
+			// Stopped by its owner, don't resurrect it
+			continue
+		}
+		if err := q.startConsumerLocked(spec); err != nil {
+			errs = append(errs, fmt.Errorf("consumer %s: %w", spec.consumerName, err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // Close closes the NATS connection.
@@ -291,14 +532,22 @@ func (q *NATSQueue) Close() error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	// Stop any background reconnection attempt
+	if q.stopReconnect != nil {
+		close(q.stopReconnect)
+		q.stopReconnect = nil
+	}
+
 	if !q.enabled || q.conn == nil {
 		return nil
 	}
 
 	// Cancel all consumer contexts
-	for _, cancel := range q.cancelFuncs {
-		cancel()
+	for _, run := range q.consumerRuns {
+		run.cancel()
 	}
+	q.consumerRuns = make(map[string]*consumerRun)
+	q.consumerSpecs = make(map[string]consumerSpec)
 
 	// Drain and close connection
 	if err := q.conn.Drain(); err != nil {
@@ -318,6 +567,7 @@ func (q *NATSQueue) RegisterHandler(taskType string, handler TaskHandler) {
 }
 
 // StartConsumer starts a consumer for processing tasks.
+// The consumer is restarted automatically after NATS reconnects.
 func (q *NATSQueue) StartConsumer(ctx context.Context, streamName, consumerName, filterSubject string) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -326,47 +576,122 @@ func (q *NATSQueue) StartConsumer(ctx context.Context, streamName, consumerName,
 		return ErrQueueDisabled
 	}
 
-	stream, ok := q.streams[streamName]
+	spec := consumerSpec{
+		ctx:           ctx,
+		streamName:    streamName,
+		consumerName:  consumerName,
+		filterSubject: filterSubject,
+	}
+	if err := q.startConsumerLocked(spec); err != nil {
+		return err
+	}
+	q.consumerSpecs[consumerName] = spec
+	return nil
+}
+
+// DefaultConsumerStopGrace bounds how long StopConsumer waits for an in-flight task
+// when its context has no deadline.
+const DefaultConsumerStopGrace = 30 * time.Second
+
+// StopConsumer stops the named consumer from pulling new messages and waits for the
+// task it is processing to finish, leaving the connection, publishing and the other
+// consumers running. The wait ends with ctx, or after DefaultConsumerStopGrace when
+// ctx has no deadline; the task's context is then cancelled and ErrConsumerStopTimeout
+// returned. The durable consumer is kept on the server, so messages it had fetched but
+// not acknowledged are redelivered, and it can be started again.
+func (q *NATSQueue) StopConsumer(ctx context.Context, consumerName string) error {
+	q.mu.Lock()
+	run, ok := q.consumerRuns[consumerName]
+	if !ok {
+		q.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrConsumerNotFound, consumerName)
+	}
+	delete(q.consumerRuns, consumerName)
+	delete(q.consumerSpecs, consumerName)
+	delete(q.consumers, consumerName)
+	q.running = len(q.consumerRuns) > 0
+	q.mu.Unlock()
+
+	run.stop()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultConsumerStopGrace)
+		defer cancel()
+	}
+
+	select {
+	case <-run.done:
+		run.cancelWork()
+		q.logger.Info().Str("consumer", consumerName).Msg("Consumer stopped")
+		return nil
+	case <-ctx.Done():
+		run.cancelWork()
+		q.logger.Warn().Str("consumer", consumerName).Msg("Consumer stop grace period ended, cancelling in-flight task")
+		return fmt.Errorf("%w: %s: %w", ErrConsumerStopTimeout, consumerName, ctx.Err())
+	}
+}
+
+// startConsumerLocked creates or updates a consumer and starts processing its
+// messages, stopping any previous goroutine for it. Caller must hold q.mu.
+func (q *NATSQueue) startConsumerLocked(spec consumerSpec) error {
+	stream, ok := q.streams[spec.streamName]
 	if !ok {
 		return ErrStreamNotFound
 	}
 
 	// Create consumer configuration
 	cfg := jetstream.ConsumerConfig{
-		Name:          consumerName,
-		Durable:       consumerName,
-		FilterSubject: filterSubject,
+		Name:          spec.consumerName,
+		Durable:       spec.consumerName,
+		FilterSubject: spec.filterSubject,
 		AckPolicy:     jetstream.AckExplicitPolicy,
 		AckWait:       q.config.AckWait,
 		MaxDeliver:    q.config.MaxDeliver,
 		DeliverPolicy: jetstream.DeliverAllPolicy,
 	}
 
-	consumer, err := stream.CreateOrUpdateConsumer(ctx, cfg)
+	consumer, err := stream.CreateOrUpdateConsumer(spec.ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create consumer: %w", err)
 	}
-	q.consumers[consumerName] = consumer
+	q.consumers[spec.consumerName] = consumer
+
+	if run, ok := q.consumerRuns[spec.consumerName]; ok {
+		run.cancel()
+	}
 
 	// Start consuming in a goroutine
-	consumerCtx, cancel := context.WithCancel(ctx)
-	q.cancelFuncs = append(q.cancelFuncs, cancel)
+	workCtx, cancelWork := context.WithCancel(spec.ctx)
+	pullCtx, stop := context.WithCancel(workCtx)
+	run := &consumerRun{stop: stop, cancelWork: cancelWork, done: make(chan struct{})}
+	q.consumerRuns[spec.consumerName] = run
 
-	go q.consumeMessages(consumerCtx, consumer)
+	go func() {
+		defer close(run.done)
+		q.consumeMessages(pullCtx, workCtx, spec.consumerName, consumer)
+	}()
 
 	q.running = true
 	return nil
 }
 
-// consumeMessages processes messages from a consumer.
-func (q *NATSQueue) consumeMessages(ctx context.Context, consumer jetstream.Consumer) {
+// consumeMessages processes messages from a consumer until ctx ends. Handlers run
+// under workCtx, so a message being processed when ctx ends is still finished.
+func (q *NATSQueue) consumeMessages(ctx, workCtx context.Context, consumerName string, consumer jetstream.Consumer) {
 	iter, err := consumer.Messages()
 	if err != nil {
-		fmt.Printf("Failed to get message iterator: %v\n", err)
+		q.logger.Error().Err(err).Msg("Failed to get message iterator")
 		return
 	}
 	defer iter.Stop()
 
+	// Unblock Next when the consumer is stopped or restarted
+	go func() {
+		<-ctx.Done()
+		iter.Stop()
+	}()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -374,23 +699,23 @@ func (q *NATSQueue) consumeMessages(ctx context.Context, consumer jetstream.Cons
 		default:
 			msg, err := iter.Next()
 			if err != nil {
-				if errors.Is(err, context.Canceled) {
+				if errors.Is(err, context.Canceled) || errors.Is(err, jetstream.ErrMsgIteratorClosed) {
 					return
 				}
-				fmt.Printf("Error getting message: %v\n", err)
+				q.logger.Warn().Err(err).Msg("Error getting message")
 				continue
 			}
 
-			q.processMessage(ctx, msg)
+			q.processMessage(workCtx, consumerName, msg)
 		}
 	}
 }
 
-// processMessage processes a single message.
-func (q *NATSQueue) processMessage(ctx context.Context, msg jetstream.Msg) {
+// processMessage processes a single message delivered by the named consumer.
+func (q *NATSQueue) processMessage(ctx context.Context, consumerName string, msg jetstream.Msg) {
 	var task Task
 	if err := json.Unmarshal(msg.Data(), &task); err != nil {
-		fmt.Printf("Failed to unmarshal task: %v\n", err)
+		q.logger.Error().Err(err).Msg("Failed to unmarshal task")
 		_ = msg.Term() // Terminal failure, don't retry
 		return
 	}
@@ -400,11 +725,15 @@ func (q *NATSQueue) processMessage(ctx context.Context, msg jetstream.Msg) {
 	q.mu.RUnlock()
 
 	if !ok {
-		fmt.Printf("No handler for task type: %s\n", task.Type)
+		q.logger.Error().Str("task_type", task.Type).Msg("No handler for task type")
 		_ = msg.Term()
 		return
 	}
 
+	metadata, _ := msg.Metadata()
+	attempt := deliveryAttempt(metadata)
+	task.Retries = attempt - 1
+
 	// Execute handler with timeout
 	execCtx := ctx
 	if task.Timeout > 0 {
@@ -417,23 +746,79 @@ func (q *NATSQueue) processMessage(ctx context.Context, msg jetstream.Msg) {
 	err := handler(execCtx, &task)
 	duration := time.Since(startTime)
 
+	result := &TaskResult{
+		TaskID:    task.ID,
+		Success:   err == nil,
+		Duration:  duration,
+		Retries:   task.Retries,
+		Timestamp: time.Now(),
+	}
 	if err != nil {
-		metadata, _ := msg.Metadata()
-		if metadata != nil && metadata.NumDelivered >= uint64(q.config.MaxDeliver) { //nolint:gosec // MaxDeliver is always positive
-			fmt.Printf("Task %s failed after max retries: %v\n", task.ID, err)
-			_ = msg.Term()
-		} else {
-			fmt.Printf("Task %s failed, will retry: %v\n", task.ID, err)
-			_ = msg.Nak()
-		}
+		result.Error = err.Error()
+	}
+	q.recordResult(result)
+
+	if err != nil {
+		q.settleFailure(ctx, consumerName, msg, &task, err, attempt)
 		return
 	}
 
-	fmt.Printf("Task %s completed in %v\n", task.ID, duration)
+	q.logger.Debug().Str("task_id", task.ID).Dur("duration", duration).Int("retries", task.Retries).Msg("Task completed")
 	_ = msg.Ack()
 }
 
-// Publish publishes a task to the queue.
+// recordResult stores the result of a task's latest delivery attempt.
+func (q *NATSQueue) recordResult(result *TaskResult) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.results[result.TaskID]; !ok {
+		q.resultOrder = append(q.resultOrder, result.TaskID)
+		if len(q.resultOrder) > maxStoredResults {
+			delete(q.results, q.resultOrder[0])
+			q.resultOrder = q.resultOrder[1:]
+		}
+	}
+	q.results[result.TaskID] = result
+}
+
+// GetResult returns the result of the latest attempt at a task processed by this queue.
+func (q *NATSQueue) GetResult(taskID string) (*TaskResult, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	result, ok := q.results[taskID]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	copied := *result
+	return &copied, nil
+}
+
+// deliveryAttempt returns the 1-based delivery attempt of a message.
+func deliveryAttempt(metadata *jetstream.MsgMetadata) int {
+	if metadata == nil || metadata.NumDelivered == 0 {
+		return 1
+	}
+	if metadata.NumDelivered > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(metadata.NumDelivered)
+}
+
+// maxAttempts returns how many times a task may be delivered. A task's MaxRetry can
+// lower the consumer's MaxDeliver but not raise it, since JetStream stops
+// redelivering at MaxDeliver regardless.
+func (q *NATSQueue) maxAttempts(task *Task) int {
+	attempts := q.config.MaxDeliver
+	if task.MaxRetry > 0 && task.MaxRetry+1 < attempts {
+		attempts = task.MaxRetry + 1
+	}
+	return attempts
+}
+
+// Publish publishes a task to the queue, under the subject of its task type's
+// route when one is registered and tasks.<type> on the shared stream otherwise.
 func (q *NATSQueue) Publish(ctx context.Context, task *Task) (string, error) {
 	if !q.isReady() {
 		return "", ErrQueueDisabled
@@ -453,7 +838,7 @@ func (q *NATSQueue) Publish(ctx context.Context, task *Task) (string, error) {
 		task.CreatedAt = time.Now()
 	}
 	if task.Subject == "" {
-		task.Subject = fmt.Sprintf("%s.%s", SubjectTaskPrefix, task.Type)
+		task.Subject = q.TaskSubject(task.Type)
 	}
 
 	// Serialize task
@@ -463,7 +848,7 @@ func (q *NATSQueue) Publish(ctx context.Context, task *Task) (string, error) {
 	}
 
 	// Publish to JetStream
-	ack, err := q.js.Publish(ctx, task.Subject, data)
+	ack, err := q.publish(ctx, q.TaskStream(task.Type), task.Subject, data)
 	if err != nil {
 		return "", fmt.Errorf("failed to publish task: %w", err)
 	}
@@ -513,7 +898,7 @@ func (q *NATSQueue) PublishEvent(ctx context.Context, eventType string, payload
 	}
 
 	subject := fmt.Sprintf("%s.%s", SubjectEventPrefix, eventType)
-	_, err = q.js.Publish(ctx, subject, data)
+	_, err = q.publish(ctx, StreamEvents, subject, data)
 	return err
 }
 
@@ -535,7 +920,7 @@ func (q *NATSQueue) PublishTelemetry(ctx context.Context, telemetryType string,
 	}
 
 	subject := fmt.Sprintf("%s.%s", SubjectTelemetryPrefix, telemetryType)
-	_, err = q.js.Publish(ctx, subject, payload)
+	_, err = q.publish(ctx, StreamTelemetry, subject, payload)
 	return err
 }
 
@@ -622,6 +1007,42 @@ func (q *NATSQueue) isReadyLocked() bool {
 	return q.enabled && q.initialized && q.conn != nil && q.conn.IsConnected()
 }
 
+// State returns the current connection state of the queue.
+func (q *NATSQueue) State() QueueState {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	switch {
+	case !q.enabled:
+		return QueueStateDisabled
+	case q.degraded:
+		return QueueStateDegraded
+	case q.isReadyLocked():
+		return QueueStateReady
+	default:
+		return QueueStateUnavailable
+	}
+}
+
+// Health returns a summary of the queue state for health reporting.
+func (q *NATSQueue) Health() map[string]interface{} {
+	state := q.State()
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	health := map[string]interface{}{
+		"state": string(state),
+	}
+	if q.config != nil {
+		health["required"] = q.config.Required
+	}
+	if q.lastErr != nil {
+		health["error"] = q.lastErr.Error()
+	}
+	return health
+}
+
 // Stats returns queue statistics.
 func (q *NATSQueue) Stats(ctx context.Context) (map[string]interface{}, error) {
 	if !q.isReady() {
@@ -0,0 +1,229 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog"
+)
+
+// fakeJetStream simulates the JetStream state held by a NATS server
+type fakeJetStream struct {
+	jetstream.JetStream
+	mu             sync.Mutex
+	streams        map[string]*fakeStream
+	streamsCreated int
+}
+
+func newFakeJetStream() *fakeJetStream {
+	return &fakeJetStream{streams: make(map[string]*fakeStream)}
+}
+
+func (js *fakeJetStream) Stream(ctx context.Context, name string) (jetstream.Stream, error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	stream, ok := js.streams[name]
+	if !ok {
+		return nil, jetstream.ErrStreamNotFound
+	}
+	return stream, nil
+}
+
+func (js *fakeJetStream) CreateOrUpdateStream(ctx context.Context, cfg jetstream.StreamConfig) (jetstream.Stream, error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	stream, ok := js.streams[cfg.Name]
+	if !ok {
+		stream = &fakeStream{consumers: make(map[string]*fakeConsumer)}
+		js.streams[cfg.Name] = stream
+		js.streamsCreated++
+	}
+	return stream, nil
+}
+
+// restart simulates a server bounce that loses all JetStream state
+func (js *fakeJetStream) restart() {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	for _, stream := range js.streams {
+		for _, consumer := range stream.consumers {
+			consumer.delete()
+		}
+	}
+	js.streams = make(map[string]*fakeStream)
+}
+
+func (js *fakeJetStream) consumer(streamName, consumerName string) *fakeConsumer {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	stream, ok := js.streams[streamName]
+	if !ok {
+		return nil
+	}
+	return stream.consumers[consumerName]
+}
+
+type fakeStream struct {
+	jetstream.Stream
+	consumers map[string]*fakeConsumer
+}
+
+func (s *fakeStream) CreateOrUpdateConsumer(ctx context.Context, cfg jetstream.ConsumerConfig) (jetstream.Consumer, error) {
+	consumer, ok := s.consumers[cfg.Name]
+	if !ok {
+		consumer = &fakeConsumer{msgs: make(chan jetstream.Msg, 1), gone: make(chan struct{})}
+		s.consumers[cfg.Name] = consumer
+	}
+	return consumer, nil
+}
+
+type fakeConsumer struct {
+	jetstream.Consumer
+	msgs     chan jetstream.Msg
+	gone     chan struct{}
+	goneOnce sync.Once
+}
+
+func (c *fakeConsumer) Messages(opts ...jetstream.PullMessagesOpt) (jetstream.MessagesContext, error) {
+	return &fakeIterator{consumer: c, stopped: make(chan struct{})}, nil
+}
+
+func (c *fakeConsumer) delete() {
+	c.goneOnce.Do(func() { close(c.gone) })
+}
+
+type fakeIterator struct {
+	consumer *fakeConsumer
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (it *fakeIterator) Next() (jetstream.Msg, error) {
+	select {
+	case msg := <-it.consumer.msgs:
+		return msg, nil
+	case <-it.consumer.gone:
+		return nil, jetstream.ErrMsgIteratorClosed
+	case <-it.stopped:
+		return nil, jetstream.ErrMsgIteratorClosed
+	}
+}
+
+func (it *fakeIterator) Stop()  { it.stopOnce.Do(func() { close(it.stopped) }) }
+func (it *fakeIterator) Drain() { it.Stop() }
+
+type fakeMsg struct {
+	jetstream.Msg
+	data []byte
+}
+
+func (m *fakeMsg) Data() []byte { return m.data }
+func (m *fakeMsg) Ack() error   { return nil }
+func (m *fakeMsg) Nak() error   { return nil }
+func (m *fakeMsg) Term() error  { return nil }
+func (m *fakeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{NumDelivered: 1}, nil
+}
+
+func deliverTask(t *testing.T, js *fakeJetStream, consumerName, taskID string) {
+	t.Helper()
+	consumer := js.consumer(StreamTasks, consumerName)
+	if consumer == nil {
+		t.Fatalf("consumer %s does not exist on the server", consumerName)
+	}
+	data, _ := json.Marshal(&Task{ID: taskID, Type: "test"})
+	consumer.msgs <- &fakeMsg{data: data}
+}
+
+func waitForTask(t *testing.T, processed <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-processed:
+		if got != want {
+			t.Errorf("processed task %s, want %s", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("task %s was not processed", want)
+	}
+}
+
+func TestNATSQueue_ResyncAfterServerRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q, err := NewNATSQueue(DefaultNATSConfig(), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+
+	processed := make(chan string, 1)
+	q.RegisterHandler("test", func(ctx context.Context, task *Task) error {
+		processed <- task.ID
+		return nil
+	})
+
+	js := newFakeJetStream()
+	streams, err := q.createStreams(ctx, js, q.defaultStreamConfigs())
+	if err != nil {
+		t.Fatalf("createStreams() error = %v", err)
+	}
+
+	q.mu.Lock()
+	q.js = js
+	q.streams = streams
+	spec := consumerSpec{ctx: ctx, streamName: StreamTasks, consumerName: "workers", filterSubject: "tasks.>"}
+	if err := q.startConsumerLocked(spec); err != nil {
+		q.mu.Unlock()
+		t.Fatalf("startConsumerLocked() error = %v", err)
+	}
+	q.consumerSpecs[spec.consumerName] = spec
+	q.mu.Unlock()
+
+	deliverTask(t, js, "workers", "before")
+	waitForTask(t, processed, "before")
+
+	// The server comes back without its streams or consumers
+	js.restart()
+	if js.consumer(StreamTasks, "workers") != nil {
+		t.Fatal("restart should drop consumers")
+	}
+
+	if err := q.resync(ctx); err != nil {
+		t.Fatalf("resync() error = %v", err)
+	}
+
+	if got, want := js.streamsCreated, 2*len(q.defaultStreamConfigs()); got != want {
+		t.Errorf("streams created = %d, want %d", got, want)
+	}
+	if q.streams[StreamTasks] != jetstream.Stream(js.streams[StreamTasks]) {
+		t.Error("stream handle should be refreshed after resync")
+	}
+
+	deliverTask(t, js, "workers", "after")
+	waitForTask(t, processed, "after")
+}
+
+func TestNATSQueue_ResyncKeepsExistingStreams(t *testing.T) {
+	ctx := context.Background()
+	q, _ := NewNATSQueue(DefaultNATSConfig(), zerolog.Nop())
+
+	js := newFakeJetStream()
+	streams, err := q.createStreams(ctx, js, q.defaultStreamConfigs())
+	if err != nil {
+		t.Fatalf("createStreams() error = %v", err)
+	}
+	q.js = js
+	q.streams = streams
+
+	// A plain network blip leaves server state intact
+	if err := q.resync(ctx); err != nil {
+		t.Fatalf("resync() error = %v", err)
+	}
+	if got, want := js.streamsCreated, len(q.defaultStreamConfigs()); got != want {
+		t.Errorf("streams created = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,257 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog"
+)
+
+// outcomeMsg records how a delivered message was settled
+type outcomeMsg struct {
+	jetstream.Msg
+	data         []byte
+	numDelivered uint64
+	outcome      string
+}
+
+func (m *outcomeMsg) Data() []byte { return m.data }
+func (m *outcomeMsg) Ack() error   { m.outcome = "ack"; return nil }
+func (m *outcomeMsg) Nak() error   { m.outcome = "nak"; return nil }
+func (m *outcomeMsg) Term() error  { m.outcome = "term"; return nil }
+func (m *outcomeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{NumDelivered: m.numDelivered}, nil
+}
+
+func TestNATSConfig_ValidateMaxDeliver(t *testing.T) {
+	for _, maxDeliver := range []int{-1, 0} {
+		cfg := DefaultNATSConfig()
+		cfg.MaxDeliver = maxDeliver
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidMaxDeliver) {
+			t.Errorf("MaxDeliver %d: Validate() error = %v, want %v", maxDeliver, err, ErrInvalidMaxDeliver)
+		}
+		if _, err := NewNATSQueue(cfg, zerolog.Nop()); !errors.Is(err, ErrInvalidMaxDeliver) {
+			t.Errorf("MaxDeliver %d: NewNATSQueue() error = %v, want %v", maxDeliver, err, ErrInvalidMaxDeliver)
+		}
+	}
+
+	cfg := DefaultNATSConfig()
+	cfg.MaxDeliver = 1
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("MaxDeliver 1: Validate() error = %v", err)
+	}
+}
+
+func TestNATSQueue_ProcessMessageRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxDeliver   int
+		maxRetry     int
+		numDelivered uint64
+		want         string
+	}{
+		{"single delivery terminates on first failure", 1, 0, 1, "term"},
+		{"retries below MaxDeliver", 3, 0, 2, "nak"},
+		{"terminates at MaxDeliver", 3, 0, 3, "term"},
+		{"task MaxRetry lowers the limit", 5, 1, 2, "term"},
+		{"retries within task MaxRetry", 5, 2, 2, "nak"},
+		{"task MaxRetry cannot exceed MaxDeliver", 3, 10, 3, "term"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultNATSConfig()
+			cfg.MaxDeliver = tt.maxDeliver
+			q, err := NewNATSQueue(cfg, zerolog.Nop())
+			if err != nil {
+				t.Fatalf("NewNATSQueue() error = %v", err)
+			}
+
+			var retries int
+			q.RegisterHandler("test", func(ctx context.Context, task *Task) error {
+				retries = task.Retries
+				return errors.New("boom")
+			})
+
+			data, _ := json.Marshal(&Task{ID: "task-1", Type: "test", MaxRetry: tt.maxRetry})
+			msg := &outcomeMsg{data: data, numDelivered: tt.numDelivered}
+			q.processMessage(context.Background(), "workers", msg)
+
+			if msg.outcome != tt.want {
+				t.Errorf("outcome = %s, want %s", msg.outcome, tt.want)
+			}
+			if want := int(tt.numDelivered) - 1; retries != want {
+				t.Errorf("task.Retries = %d, want %d", retries, want)
+			}
+		})
+	}
+}
+
+func TestNATSQueue_ProcessMessageSuccess(t *testing.T) {
+	q, err := NewNATSQueue(DefaultNATSConfig(), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+	q.RegisterHandler("test", func(ctx context.Context, task *Task) error { return nil })
+
+	data, _ := json.Marshal(&Task{ID: "task-1", Type: "test"})
+	msg := &outcomeMsg{data: data, numDelivered: 2}
+	q.processMessage(context.Background(), "workers", msg)
+
+	if msg.outcome != "ack" {
+		t.Errorf("outcome = %s, want ack", msg.outcome)
+	}
+}
+
+func TestNATSQueue_ProcessMessageRecordsResult(t *testing.T) {
+	q, err := NewNATSQueue(DefaultNATSConfig(), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+
+	calls := 0
+	q.RegisterHandler("test", func(ctx context.Context, task *Task) error {
+		calls++
+		if calls == 1 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	if _, err := q.GetResult("task-1"); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("GetResult() before processing error = %v, want %v", err, ErrTaskNotFound)
+	}
+
+	data, _ := json.Marshal(&Task{ID: "task-1", Type: "test"})
+	first := &outcomeMsg{data: data, numDelivered: 1}
+	q.processMessage(context.Background(), "workers", first)
+
+	result, err := q.GetResult("task-1")
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if first.outcome != "nak" || result.Success || result.Error != "transient" || result.Retries != 0 {
+		t.Errorf("after failed attempt: outcome = %s, result = %+v", first.outcome, result)
+	}
+
+	second := &outcomeMsg{data: data, numDelivered: 2}
+	q.processMessage(context.Background(), "workers", second)
+
+	result, err = q.GetResult("task-1")
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if second.outcome != "ack" || !result.Success || result.Error != "" {
+		t.Errorf("after retry: outcome = %s, result = %+v", second.outcome, result)
+	}
+	if result.Retries != 1 {
+		t.Errorf("Retries = %d, want 1", result.Retries)
+	}
+	if result.TaskID != "task-1" || result.Timestamp.IsZero() || result.Duration < 0 {
+		t.Errorf("incomplete result metadata: %+v", result)
+	}
+}
+
+func TestNATSQueue_RecordResultEvictsOldest(t *testing.T) {
+	q, err := NewNATSQueue(DefaultNATSConfig(), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+
+	for i := 0; i <= maxStoredResults; i++ {
+		q.recordResult(&TaskResult{TaskID: fmt.Sprintf("task-%d", i)})
+	}
+
+	if _, err := q.GetResult("task-0"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("oldest result should be evicted, got error %v", err)
+	}
+	if _, err := q.GetResult(fmt.Sprintf("task-%d", maxStoredResults)); err != nil {
+		t.Errorf("newest result missing: %v", err)
+	}
+}
+
+func TestNATSQueue_ConsumerErrorHandlerDecidesOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		action TaskErrorAction
+		want   string
+	}{
+		{"default keeps the retry policy", TaskErrorDefault, "nak"},
+		{"nak", TaskErrorNak, "nak"},
+		{"term", TaskErrorTerm, "term"},
+		{"ack", TaskErrorAck, "ack"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewNATSQueue(DefaultNATSConfig(), zerolog.Nop())
+			if err != nil {
+				t.Fatalf("NewNATSQueue() error = %v", err)
+			}
+			q.RegisterHandler("test", func(ctx context.Context, task *Task) error {
+				return errors.New("boom")
+			})
+
+			var gotTask *Task
+			var gotErr error
+			var gotAttempt int
+			q.SetConsumerErrorHandler("alerts", func(ctx context.Context, task *Task, err error, attempt int) TaskErrorAction {
+				gotTask, gotErr, gotAttempt = task, err, attempt
+				return tt.action
+			})
+
+			data, _ := json.Marshal(&Task{ID: "task-1", Type: "test"})
+			msg := &outcomeMsg{data: data, numDelivered: 2}
+			q.processMessage(context.Background(), "alerts", msg)
+
+			if msg.outcome != tt.want {
+				t.Errorf("outcome = %s, want %s", msg.outcome, tt.want)
+			}
+			if gotTask == nil || gotTask.ID != "task-1" || gotErr == nil || gotErr.Error() != "boom" || gotAttempt != 2 {
+				t.Errorf("error handler got task = %+v, err = %v, attempt = %d", gotTask, gotErr, gotAttempt)
+			}
+		})
+	}
+}
+
+func TestNATSQueue_ConsumerErrorHandlerScopedToConsumer(t *testing.T) {
+	cfg := DefaultNATSConfig()
+	cfg.MaxDeliver = 1
+	q, err := NewNATSQueue(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+	q.RegisterHandler("test", func(ctx context.Context, task *Task) error {
+		return errors.New("boom")
+	})
+
+	var deadLettered []string
+	q.SetConsumerErrorHandler("alerts", func(ctx context.Context, task *Task, err error, attempt int) TaskErrorAction {
+		deadLettered = append(deadLettered, task.ID)
+		return TaskErrorAck
+	})
+
+	data, _ := json.Marshal(&Task{ID: "task-1", Type: "test"})
+	routed := &outcomeMsg{data: data, numDelivered: 1}
+	q.processMessage(context.Background(), "alerts", routed)
+	other := &outcomeMsg{data: data, numDelivered: 1}
+	q.processMessage(context.Background(), "workers", other)
+
+	if routed.outcome != "ack" || len(deadLettered) != 1 {
+		t.Errorf("consumer with an error handler: outcome = %s, handler calls = %d", routed.outcome, len(deadLettered))
+	}
+	if other.outcome != "term" {
+		t.Errorf("consumer without an error handler: outcome = %s, want the default term", other.outcome)
+	}
+
+	q.SetConsumerErrorHandler("alerts", nil)
+	cleared := &outcomeMsg{data: data, numDelivered: 1}
+	q.processMessage(context.Background(), "alerts", cleared)
+	if cleared.outcome != "term" || len(deadLettered) != 1 {
+		t.Errorf("cleared error handler: outcome = %s, handler calls = %d", cleared.outcome, len(deadLettered))
+	}
+}
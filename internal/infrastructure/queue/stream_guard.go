@@ -0,0 +1,118 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// ErrStreamConfigMismatch is returned when an existing stream's configuration is
+// incompatible with the one the queue expects and updates are not allowed.
+var ErrStreamConfigMismatch = errors.New("existing stream configuration does not match")
+
+// StreamConfigDiff is one field where an existing stream differs from the
+// expected configuration.
+type StreamConfigDiff struct {
+	Field    string
+	Existing string
+	Expected string
+	// Incompatible differences change which messages the stream holds or how
+	// they are kept, and would break other consumers of the stream if updated.
+	Incompatible bool
+}
+
+func (d StreamConfigDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Field, d.Existing, d.Expected)
+}
+
+// DiffStreamConfig compares an existing stream configuration against the
+// expected one. Subjects, retention, storage and discard policy are
+// incompatible differences; description, limits and replicas are benign.
+func DiffStreamConfig(existing, expected jetstream.StreamConfig) []StreamConfigDiff {
+	var diffs []StreamConfigDiff
+	add := func(field string, existing, expected interface{}, incompatible bool) {
+		diffs = append(diffs, StreamConfigDiff{
+			Field:        field,
+			Existing:     fmt.Sprint(existing),
+			Expected:     fmt.Sprint(expected),
+			Incompatible: incompatible,
+		})
+	}
+
+	existingSubjects := slices.Sorted(slices.Values(existing.Subjects))
+	expectedSubjects := slices.Sorted(slices.Values(expected.Subjects))
+	if !slices.Equal(existingSubjects, expectedSubjects) {
+		add("subjects", existingSubjects, expectedSubjects, true)
+	}
+	if existing.Retention != expected.Retention {
+		add("retention", existing.Retention, expected.Retention, true)
+	}
+	if existing.Storage != expected.Storage {
+		add("storage", existing.Storage, expected.Storage, true)
+	}
+	if existing.Discard != expected.Discard {
+		add("discard", existing.Discard, expected.Discard, true)
+	}
+
+	if existing.Description != expected.Description {
+		add("description", existing.Description, expected.Description, false)
+	}
+	if existing.MaxAge != expected.MaxAge {
+		add("max_age", existing.MaxAge, expected.MaxAge, false)
+	}
+	if existing.MaxMsgs != expected.MaxMsgs {
+		add("max_msgs", existing.MaxMsgs, expected.MaxMsgs, false)
+	}
+	if existing.MaxBytes != expected.MaxBytes {
+		add("max_bytes", existing.MaxBytes, expected.MaxBytes, false)
+	}
+	if existing.Replicas != expected.Replicas {
+		add("replicas", existing.Replicas, expected.Replicas, false)
+	}
+	return diffs
+}
+
+// ensureStream returns the stream described by cfg, creating it if missing.
+// An existing stream is checked against cfg rather than overwritten, since it
+// may be shared with other services on the same account: incompatible
+// differences fail with ErrStreamConfigMismatch and benign ones leave the
+// stream as it is, unless UpdateStreams allows updating it to cfg.
+func (q *NATSQueue) ensureStream(ctx context.Context, js jetstream.JetStream, cfg jetstream.StreamConfig) (jetstream.Stream, error) {
+	stream, err := js.Stream(ctx, cfg.Name)
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		return js.CreateOrUpdateStream(ctx, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := DiffStreamConfig(stream.CachedInfo().Config, cfg)
+	if len(diffs) == 0 {
+		return stream, nil
+	}
+
+	incompatible := slices.ContainsFunc(diffs, func(d StreamConfigDiff) bool { return d.Incompatible })
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = d.String()
+	}
+	q.logger.Warn().
+		Str("stream", cfg.Name).
+		Strs("diff", lines).
+		Bool("incompatible", incompatible).
+		Bool("update", q.config.UpdateStreams).
+		Msg("Existing stream configuration differs from expected")
+
+	if q.config.UpdateStreams {
+		return js.UpdateStream(ctx, cfg)
+	}
+	if incompatible {
+		return nil, fmt.Errorf("%w: stream %s: %s (set update_streams to overwrite it)",
+			ErrStreamConfigMismatch, cfg.Name, strings.Join(lines, "; "))
+	}
+	return stream, nil
+}
@@ -0,0 +1,139 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog"
+)
+
+// preexistingStream creates the tasks stream on the server at url as another
+// service sharing the account would, with cfg modified by change
+func preexistingStream(t *testing.T, url string, change func(cfg *jetstream.StreamConfig)) jetstream.JetStream {
+	t.Helper()
+	conn, err := nats.Connect(url)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := &NATSQueue{config: streamGuardConfig(url)}
+	cfg := q.defaultStreamConfigs()[0]
+	change(&cfg)
+	if _, err := js.CreateStream(context.Background(), cfg); err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+	return js
+}
+
+func streamGuardConfig(url string) *NATSConfig {
+	cfg := DefaultNATSConfig()
+	cfg.URL = url
+	cfg.Required = true
+	cfg.StreamMaxBytes = 1024 * 1024
+	return cfg
+}
+
+// initQueue initializes a queue against url, returning its Initialize error
+func initQueue(t *testing.T, cfg *NATSConfig) error {
+	t.Helper()
+	q, err := NewNATSQueue(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+	return q.Initialize(context.Background())
+}
+
+func streamConfig(t *testing.T, js jetstream.JetStream) jetstream.StreamConfig {
+	t.Helper()
+	stream, err := js.Stream(context.Background(), StreamTasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stream.CachedInfo().Config
+}
+
+func TestEnsureStream_MatchingStream(t *testing.T) {
+	url := runJetStreamServer(t)
+	preexistingStream(t, url, func(cfg *jetstream.StreamConfig) {})
+
+	if err := initQueue(t, streamGuardConfig(url)); err != nil {
+		t.Fatalf("Initialize() with a matching stream error = %v", err)
+	}
+}
+
+func TestEnsureStream_BenignDifferenceKeepsStream(t *testing.T) {
+	url := runJetStreamServer(t)
+	js := preexistingStream(t, url, func(cfg *jetstream.StreamConfig) {
+		cfg.MaxAge = time.Hour
+		cfg.Description = "shared tasks"
+	})
+
+	if err := initQueue(t, streamGuardConfig(url)); err != nil {
+		t.Fatalf("Initialize() with a benign difference error = %v", err)
+	}
+	if got := streamConfig(t, js); got.MaxAge != time.Hour || got.Description != "shared tasks" {
+		t.Errorf("stream was updated to max_age=%v description=%q, want it left as is", got.MaxAge, got.Description)
+	}
+}
+
+func TestEnsureStream_IncompatibleDifference(t *testing.T) {
+	url := runJetStreamServer(t)
+	js := preexistingStream(t, url, func(cfg *jetstream.StreamConfig) {
+		cfg.Subjects = append(cfg.Subjects, "billing.>")
+	})
+
+	cfg := streamGuardConfig(url)
+	cfg.Required = false
+	if err := initQueue(t, cfg); !errors.Is(err, ErrStreamConfigMismatch) {
+		t.Fatalf("Initialize() error = %v, want ErrStreamConfigMismatch even when not required", err)
+	}
+	if got := streamConfig(t, js); len(got.Subjects) != 2 {
+		t.Errorf("stream subjects = %v, want the shared subjects kept", got.Subjects)
+	}
+
+	cfg.UpdateStreams = true
+	if err := initQueue(t, cfg); err != nil {
+		t.Fatalf("Initialize() with update_streams error = %v", err)
+	}
+	if got := streamConfig(t, js); len(got.Subjects) != 1 || got.Subjects[0] != SubjectTaskPrefix+".>" {
+		t.Errorf("stream subjects = %v after forced update, want only %s.>", got.Subjects, SubjectTaskPrefix)
+	}
+}
+
+func TestDiffStreamConfig(t *testing.T) {
+	expected := jetstream.StreamConfig{
+		Name:      StreamTasks,
+		Subjects:  []string{"a.>", "b.>"},
+		Retention: jetstream.LimitsPolicy,
+		Storage:   jetstream.FileStorage,
+		MaxMsgs:   10,
+	}
+
+	existing := expected
+	existing.Subjects = []string{"b.>", "a.>"}
+	if diffs := DiffStreamConfig(existing, expected); len(diffs) != 0 {
+		t.Errorf("subject order must not matter, got %v", diffs)
+	}
+
+	existing.MaxMsgs = 20
+	existing.Retention = jetstream.WorkQueuePolicy
+	diffs := DiffStreamConfig(existing, expected)
+	if len(diffs) != 2 {
+		t.Fatalf("DiffStreamConfig() = %v, want 2 differences", diffs)
+	}
+	for _, d := range diffs {
+		if want := d.Field == "retention"; d.Incompatible != want {
+			t.Errorf("%s incompatible = %v, want %v", d.Field, d.Incompatible, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// ErrInvalidTaskRoute is returned when a task route cannot be registered.
+var ErrInvalidTaskRoute = errors.New("invalid task route")
+
+// TaskRoute isolates a task type in its own stream. Tasks of the type are published
+// under SubjectPrefix.<type> into Stream instead of the shared TASKS stream, so they
+// get their own retention limits and consumers. Zero limits use the queue's
+// configured stream limits.
+type TaskRoute struct {
+	// Stream is the JetStream stream holding the task type
+	Stream string `mapstructure:"stream" yaml:"stream" json:"stream"`
+	// SubjectPrefix is the stream's subject namespace; the stream captures SubjectPrefix.>
+	SubjectPrefix string `mapstructure:"subject_prefix" yaml:"subject_prefix" json:"subject_prefix"`
+	// MaxAge is the maximum age of messages in the stream
+	MaxAge time.Duration `mapstructure:"max_age" yaml:"max_age" json:"max_age"`
+	// MaxMsgs is the maximum number of messages in the stream
+	MaxMsgs int64 `mapstructure:"max_msgs" yaml:"max_msgs" json:"max_msgs"`
+	// MaxBytes is the maximum size of the stream in bytes
+	MaxBytes int64 `mapstructure:"max_bytes" yaml:"max_bytes" json:"max_bytes"`
+}
+
+// reservedSubjectPrefixes are the namespaces captured by the default streams.
+// JetStream rejects streams with overlapping subjects, so routes must stay outside them.
+var reservedSubjectPrefixes = []string{SubjectTaskPrefix, SubjectEventPrefix, SubjectTelemetryPrefix}
+
+// validate checks that route can hold tasks of taskType.
+func (r TaskRoute) validate(taskType string) error {
+	if taskType == "" {
+		return fmt.Errorf("%w: task type is required", ErrInvalidTaskRoute)
+	}
+	if r.Stream == "" || strings.ContainsAny(r.Stream, " .*>") {
+		return fmt.Errorf("%w: stream name %q", ErrInvalidTaskRoute, r.Stream)
+	}
+	switch r.Stream {
+	case StreamTasks, StreamEvents, StreamTelemetry:
+		return fmt.Errorf("%w: stream %s is a default stream", ErrInvalidTaskRoute, r.Stream)
+	}
+	if r.SubjectPrefix == "" || strings.ContainsAny(r.SubjectPrefix, " *>") ||
+		strings.HasPrefix(r.SubjectPrefix, ".") || strings.HasSuffix(r.SubjectPrefix, ".") {
+		return fmt.Errorf("%w: subject prefix %q", ErrInvalidTaskRoute, r.SubjectPrefix)
+	}
+	for _, reserved := range reservedSubjectPrefixes {
+		if prefixesOverlap(r.SubjectPrefix, reserved) {
+			return fmt.Errorf("%w: subject prefix %s overlaps the %s.> namespace", ErrInvalidTaskRoute, r.SubjectPrefix, reserved)
+		}
+	}
+	if r.MaxAge < 0 || r.MaxMsgs < 0 || r.MaxBytes < 0 {
+		return fmt.Errorf("%w: stream limits must not be negative", ErrInvalidTaskRoute)
+	}
+	return nil
+}
+
+// prefixesOverlap reports whether the streams capturing a.> and b.> would share
+// subjects, which is when one prefix equals the other or lies below it.
+func prefixesOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
+
+// RegisterTaskRoute routes tasks of taskType to their own stream. Several task types
+// may share a stream as long as they register identical routes for it. Registering
+// after Initialize creates the stream right away; otherwise it is created with the
+// default streams. Task types without a route stay on the shared TASKS stream.
+func (q *NATSQueue) RegisterTaskRoute(ctx context.Context, taskType string, route TaskRoute) error {
+	if err := route.validate(taskType); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for otherType, other := range q.taskRoutes {
+		if otherType == taskType {
+			continue
+		}
+		if other.Stream == route.Stream && other != route {
+			return fmt.Errorf("%w: stream %s is already registered with a different route by task type %s",
+				ErrInvalidTaskRoute, route.Stream, otherType)
+		}
+		if other.Stream != route.Stream && prefixesOverlap(other.SubjectPrefix, route.SubjectPrefix) {
+			return fmt.Errorf("%w: subject prefix %s overlaps %s used by stream %s",
+				ErrInvalidTaskRoute, route.SubjectPrefix, other.SubjectPrefix, other.Stream)
+		}
+	}
+
+	if q.js != nil {
+		stream, err := q.ensureStream(ctx, q.js, q.routeStreamConfig(route))
+		if err != nil {
+			return fmt.Errorf("failed to create stream %s: %w", route.Stream, err)
+		}
+		q.streams[route.Stream] = stream
+	}
+	q.taskRoutes[taskType] = route
+	return nil
+}
+
+// TaskStream returns the stream tasks of taskType are published to.
+func (q *NATSQueue) TaskStream(taskType string) string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if route, ok := q.taskRoutes[taskType]; ok {
+		return route.Stream
+	}
+	return StreamTasks
+}
+
+// TaskSubject returns the subject tasks of taskType are published under, for use as
+// a consumer's filter subject.
+func (q *NATSQueue) TaskSubject(taskType string) string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.taskSubjectLocked(taskType)
+}
+
+func (q *NATSQueue) taskSubjectLocked(taskType string) string {
+	prefix := SubjectTaskPrefix
+	if route, ok := q.taskRoutes[taskType]; ok {
+		prefix = route.SubjectPrefix
+	}
+	return fmt.Sprintf("%s.%s", prefix, taskType)
+}
+
+// routeStreamConfig returns the stream configuration of a task route, falling back
+// to the queue's stream limits.
+func (q *NATSQueue) routeStreamConfig(route TaskRoute) jetstream.StreamConfig {
+	cfg := jetstream.StreamConfig{
+		Name:        route.Stream,
+		Description: fmt.Sprintf("TFO-GO-MCP %s stream", route.Stream),
+		Subjects:    []string{route.SubjectPrefix + ".>"},
+		Retention:   jetstream.LimitsPolicy,
+		MaxAge:      route.MaxAge,
+		MaxMsgs:     route.MaxMsgs,
+		MaxBytes:    route.MaxBytes,
+		Storage:     jetstream.FileStorage,
+		Replicas:    1,
+		Discard:     jetstream.DiscardOld,
+	}
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = q.config.StreamMaxAge
+	}
+	if cfg.MaxMsgs == 0 {
+		cfg.MaxMsgs = q.config.StreamMaxMsgs
+	}
+	if cfg.MaxBytes == 0 {
+		cfg.MaxBytes = q.config.StreamMaxBytes
+	}
+	return cfg
+}
+
+// streamConfigs returns the configuration of the default streams followed by one
+// per routed stream. Caller must hold q.mu.
+func (q *NATSQueue) streamConfigs() []jetstream.StreamConfig {
+	configs := q.defaultStreamConfigs()
+	seen := make(map[string]bool)
+	for _, route := range q.taskRoutes {
+		if seen[route.Stream] {
+			continue
+		}
+		seen[route.Stream] = true
+		configs = append(configs, q.routeStreamConfig(route))
+	}
+	return configs
+}
@@ -0,0 +1,171 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func streamMessages(t *testing.T, q *NATSQueue, streamName string) uint64 {
+	t.Helper()
+	info, err := q.GetStreamInfo(context.Background(), streamName)
+	if err != nil {
+		t.Fatalf("GetStreamInfo(%s) error = %v", streamName, err)
+	}
+	return info.State.Msgs
+}
+
+func TestNATSQueue_TaskRoutesIsolateStreams(t *testing.T) {
+	ctx := context.Background()
+	q, err := NewNATSQueue(streamGuardConfig(runJetStreamServer(t)), zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = q.Close() })
+
+	// Registered before Initialize, the stream is created on connect
+	bulk := TaskRoute{Stream: "BULK_TASKS", SubjectPrefix: "bulk", MaxMsgs: 10}
+	if err := q.RegisterTaskRoute(ctx, "bulk_export", bulk); err != nil {
+		t.Fatalf("RegisterTaskRoute() error = %v", err)
+	}
+	if err := q.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	// Registered afterwards, the stream is created right away
+	audit := TaskRoute{Stream: "AUDIT_TASKS", SubjectPrefix: "audit", MaxAge: time.Hour}
+	if err := q.RegisterTaskRoute(ctx, "audit_log", audit); err != nil {
+		t.Fatalf("RegisterTaskRoute() after Initialize error = %v", err)
+	}
+
+	for taskType, wantStream := range map[string]string{
+		"bulk_export":               "BULK_TASKS",
+		"audit_log":                 "AUDIT_TASKS",
+		TaskTypeSessionCleanup:      StreamTasks,
+		TaskTypeConversationArchive: StreamTasks,
+	} {
+		id, err := q.Publish(ctx, &Task{Type: taskType})
+		if err != nil {
+			t.Fatalf("Publish(%s) error = %v", taskType, err)
+		}
+		if !strings.HasPrefix(id, wantStream+":") {
+			t.Errorf("Publish(%s) went to %s, want stream %s", taskType, id, wantStream)
+		}
+		if got := q.TaskStream(taskType); got != wantStream {
+			t.Errorf("TaskStream(%s) = %s, want %s", taskType, got, wantStream)
+		}
+	}
+
+	if got := streamMessages(t, q, StreamTasks); got != 2 {
+		t.Errorf("%s holds %d messages, want only the 2 unrouted tasks", StreamTasks, got)
+	}
+	if got := streamMessages(t, q, "BULK_TASKS"); got != 1 {
+		t.Errorf("BULK_TASKS holds %d messages, want 1", got)
+	}
+	if got := streamMessages(t, q, "AUDIT_TASKS"); got != 1 {
+		t.Errorf("AUDIT_TASKS holds %d messages, want 1", got)
+	}
+
+	info, err := q.GetStreamInfo(ctx, "BULK_TASKS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Config.MaxMsgs != 10 || info.Config.MaxAge != q.config.StreamMaxAge {
+		t.Errorf("BULK_TASKS limits = %d msgs, %v, want the route's msgs and the default age", info.Config.MaxMsgs, info.Config.MaxAge)
+	}
+	if got := q.TaskSubject("bulk_export"); got != "bulk.bulk_export" {
+		t.Errorf("TaskSubject(bulk_export) = %s", got)
+	}
+}
+
+func TestNATSQueue_TaskRouteConsumersOnlySeeTheirStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := newReplica(t, runJetStreamServer(t))
+
+	if err := q.RegisterTaskRoute(ctx, "bulk_export", TaskRoute{Stream: "BULK_TASKS", SubjectPrefix: "bulk"}); err != nil {
+		t.Fatal(err)
+	}
+
+	processed := make(chan string, 4)
+	for _, taskType := range []string{"bulk_export", TaskTypeSessionCleanup} {
+		q.RegisterHandler(taskType, func(ctx context.Context, task *Task) error {
+			processed <- task.Type
+			return nil
+		})
+	}
+	// Only the routed stream has a consumer, so shared-stream tasks stay queued
+	if err := q.StartConsumer(ctx, q.TaskStream("bulk_export"), "bulk-workers", q.TaskSubject("bulk_export")); err != nil {
+		t.Fatalf("StartConsumer() error = %v", err)
+	}
+
+	for _, taskType := range []string{TaskTypeSessionCleanup, "bulk_export"} {
+		if _, err := q.Publish(ctx, &Task{Type: taskType}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case got := <-processed:
+		if got != "bulk_export" {
+			t.Fatalf("bulk consumer processed a %s task", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("routed task was not processed")
+	}
+	select {
+	case got := <-processed:
+		t.Fatalf("unexpected %s task processed", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNATSQueue_RegisterTaskRouteValidation(t *testing.T) {
+	ctx := context.Background()
+	q, err := NewNATSQueue(DefaultNATSConfig(), zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.RegisterTaskRoute(ctx, "bulk_export", TaskRoute{Stream: "BULK", SubjectPrefix: "bulk"}); err != nil {
+		t.Fatalf("RegisterTaskRoute() error = %v", err)
+	}
+	if err := q.RegisterTaskRoute(ctx, "audit", TaskRoute{Stream: "AUDIT", SubjectPrefix: "tfo.audit"}); err != nil {
+		t.Fatalf("RegisterTaskRoute() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		taskType string
+		route    TaskRoute
+	}{
+		{"missing task type", "", TaskRoute{Stream: "OTHER", SubjectPrefix: "other"}},
+		{"missing stream", "other", TaskRoute{SubjectPrefix: "other"}},
+		{"default stream", "other", TaskRoute{Stream: StreamTasks, SubjectPrefix: "other"}},
+		{"wildcard prefix", "other", TaskRoute{Stream: "OTHER", SubjectPrefix: "other.*"}},
+		{"overlaps tasks namespace", "other", TaskRoute{Stream: "OTHER", SubjectPrefix: "tasks.other"}},
+		{"overlaps events namespace", "other", TaskRoute{Stream: "OTHER", SubjectPrefix: "events"}},
+		{"negative limit", "other", TaskRoute{Stream: "OTHER", SubjectPrefix: "other", MaxMsgs: -1}},
+		{"prefix used by another stream", "other", TaskRoute{Stream: "OTHER", SubjectPrefix: "bulk"}},
+		{"prefix below another stream's", "other", TaskRoute{Stream: "OTHER", SubjectPrefix: "bulk.other"}},
+		{"prefix above another stream's", "other", TaskRoute{Stream: "OTHER", SubjectPrefix: "tfo"}},
+		{"stream registered differently", "other", TaskRoute{Stream: "BULK", SubjectPrefix: "bulk", MaxMsgs: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := q.RegisterTaskRoute(ctx, tt.taskType, tt.route); !errors.Is(err, ErrInvalidTaskRoute) {
+				t.Errorf("RegisterTaskRoute() error = %v, want ErrInvalidTaskRoute", err)
+			}
+		})
+	}
+
+	// Task types may share a stream with an identical route
+	if err := q.RegisterTaskRoute(ctx, "bulk_import", TaskRoute{Stream: "BULK", SubjectPrefix: "bulk"}); err != nil {
+		t.Errorf("sharing a routed stream error = %v", err)
+	}
+	if got := len(q.streamConfigs()); got != len(q.defaultStreamConfigs())+2 {
+		t.Errorf("stream configs = %d, want the defaults plus the audit and one shared routed stream", got)
+	}
+}
@@ -276,7 +276,7 @@ func RegisterDefaultHandlers(q *NATSQueue) {
 			return err
 		}
 		// TODO: Implement Claude API call
-		fmt.Printf("Processing Claude request for session %s\n", payload.SessionID)
+		q.logger.Debug().Str("session_id", payload.SessionID).Msg("Processing Claude request")
 		return nil
 	})
 
@@ -288,7 +288,7 @@ func RegisterDefaultHandlers(q *NATSQueue) {
 			return err
 		}
 		// TODO: Implement tool execution
-		fmt.Printf("Executing tool %s for session %s\n", payload.ToolName, payload.SessionID)
+		q.logger.Debug().Str("tool", payload.ToolName).Str("session_id", payload.SessionID).Msg("Executing tool")
 		return nil
 	})
 
@@ -300,7 +300,7 @@ func RegisterDefaultHandlers(q *NATSQueue) {
 			return err
 		}
 		// TODO: Implement telemetry export
-		fmt.Printf("Exporting telemetry for service %s to %s\n", payload.ServiceName, payload.Destination)
+		q.logger.Debug().Str("service", payload.ServiceName).Str("destination", payload.Destination).Msg("Exporting telemetry")
 		return nil
 	})
 
@@ -312,7 +312,7 @@ func RegisterDefaultHandlers(q *NATSQueue) {
 			return err
 		}
 		// TODO: Implement session cleanup
-		fmt.Printf("Cleaning up session %s\n", payload.SessionID)
+		q.logger.Debug().Str("session_id", payload.SessionID).Msg("Cleaning up session")
 		return nil
 	})
 
@@ -324,7 +324,7 @@ func RegisterDefaultHandlers(q *NATSQueue) {
 			return err
 		}
 		// TODO: Implement cache invalidation
-		fmt.Printf("Invalidating cache pattern: %s\n", payload.Pattern)
+		q.logger.Debug().Str("pattern", payload.Pattern).Msg("Invalidating cache pattern")
 		return nil
 	})
 
@@ -336,7 +336,7 @@ func RegisterDefaultHandlers(q *NATSQueue) {
 			return err
 		}
 		// TODO: Implement webhook delivery
-		fmt.Printf("Delivering webhook to %s\n", payload.URL)
+		q.logger.Debug().Str("url", payload.URL).Msg("Delivering webhook")
 		return nil
 	})
 }
@@ -0,0 +1,93 @@
+// Package prompts contains built-in MCP prompts for TelemetryFlow
+package prompts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+var (
+	// conditionalPattern matches {{#if name}}...{{/if}} blocks
+	conditionalPattern = regexp.MustCompile(`(?s)\{\{#if (\w+)\}\}(.*?)\{\{/if\}\}`)
+	// variablePattern matches {{name}} placeholders
+	variablePattern = regexp.MustCompile(`\{\{(\w+)\}\}`)
+)
+
+// BuiltinPrompts returns new instances of the built-in prompts, the ones seeded into
+// the database, rendering their templates
+func BuiltinPrompts() ([]*entities.Prompt, error) {
+	definitions, err := persistence.DefaultPrompts()
+	if err != nil {
+		return nil, err
+	}
+	prompts := make([]*entities.Prompt, 0, len(definitions))
+	for _, def := range definitions {
+		name, err := vo.NewToolName(def.Name)
+		if err != nil {
+			return nil, fmt.Errorf("prompt %s: %w", def.Name, err)
+		}
+		prompt, err := entities.NewPrompt(name, def.Description)
+		if err != nil {
+			return nil, fmt.Errorf("prompt %s: %w", def.Name, err)
+		}
+		for _, arg := range def.Arguments {
+			prompt.AddArgument(arg)
+		}
+		prompt.SetGenerator(templateGenerator(prompt, def.Template))
+		prompt.SetPreviewGenerator(templatePreviewer(prompt, def.Template))
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// templateGenerator renders template as a single user message after checking required arguments
+func templateGenerator(prompt *entities.Prompt, template string) entities.PromptGenerator {
+	return func(args map[string]string) (*entities.PromptMessages, error) {
+		if err := prompt.ValidateArguments(args); err != nil {
+			return nil, err
+		}
+		return &entities.PromptMessages{
+			Description: prompt.Description(),
+			Messages: []entities.PromptMessage{
+				{Role: "user", Content: entities.PromptContent{Type: "text", Text: render(template, args, false)}},
+			},
+		}, nil
+	}
+}
+
+// templatePreviewer renders template with the arguments supplied so far, keeping the
+// {{name}} placeholder of each missing one so it stays visible in the preview
+func templatePreviewer(prompt *entities.Prompt, template string) entities.PromptGenerator {
+	return func(args map[string]string) (*entities.PromptMessages, error) {
+		return &entities.PromptMessages{
+			Description: prompt.Description(),
+			Messages: []entities.PromptMessage{
+				{Role: "user", Content: entities.PromptContent{Type: "text", Text: render(template, args, true)}},
+			},
+		}, nil
+	}
+}
+
+// render expands {{#if name}} blocks and {{name}} placeholders. A placeholder without
+// an argument renders empty, or is kept as is when keepMissing is set.
+func render(template string, args map[string]string, keepMissing bool) string {
+	text := conditionalPattern.ReplaceAllStringFunc(template, func(block string) string {
+		match := conditionalPattern.FindStringSubmatch(block)
+		if strings.TrimSpace(args[match[1]]) == "" {
+			return ""
+		}
+		return match[2]
+	})
+	return variablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		value, ok := args[variablePattern.FindStringSubmatch(placeholder)[1]]
+		if !ok && keepMissing {
+			return placeholder
+		}
+		return value
+	})
+}
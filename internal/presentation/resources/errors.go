@@ -5,8 +5,9 @@ import "errors"
 
 // Resource errors
 var (
-	ErrResourceNotFound = errors.New("resource not found")
-	ErrPathNotAllowed   = errors.New("path not allowed")
-	ErrFileTooLarge     = errors.New("file too large")
-	ErrInvalidURI       = errors.New("invalid resource URI")
+	ErrResourceNotFound   = errors.New("resource not found")
+	ErrPathNotAllowed     = errors.New("path not allowed")
+	ErrFileTooLarge       = errors.New("file too large")
+	ErrInvalidURI         = errors.New("invalid resource URI")
+	ErrMimeTypeNotAllowed = errors.New("MIME type not allowed")
 )
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// NewFileResource creates a resource backed by the file at path. Reads return the
+// whole file, as text when it is valid UTF-8 and as a blob otherwise.
+func NewFileResource(uri vo.ResourceURI, name, path string) (*entities.Resource, error) {
+	resource, err := entities.NewResource(uri, name)
+	if err != nil {
+		return nil, err
+	}
+	resource.SetFilePath(path)
+	resource.SetReader(func(string) (*entities.ResourceContent, error) {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: the path is fixed by whoever registered the resource
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		content := &entities.ResourceContent{URI: uri.String(), MimeType: SniffMimeType(path, "", data)}
+		if utf8.Valid(data) {
+			content.Text = string(data)
+		} else {
+			content.Blob = base64.StdEncoding.EncodeToString(data)
+		}
+		return content, nil
+	})
+	return resource, nil
+}
+
+// NewFileTemplateResource creates the file:///{path} template, exposing the files read
+// allows. Reads are backed by the file their URI names, so they can be cached and
+// streamed.
+func NewFileTemplateResource(read entities.ResourceReader) (*entities.Resource, error) {
+	resource, err := entities.NewResourceTemplate(FileURITemplate, "File Resource", "Access files from the filesystem")
+	if err != nil {
+		return nil, err
+	}
+	resource.SetReader(read)
+	resource.SetFilePathResolver(func(uri string) (string, bool) {
+		path, err := FilePathFromURI(uri)
+		return path, err == nil
+	})
+	return resource, nil
+}
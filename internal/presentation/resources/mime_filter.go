@@ -0,0 +1,119 @@
+package resources
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+// MimeTypeFilter decides which MIME types resource reads may return.
+// Entries may use a wildcard subtype such as "text/*".
+type MimeTypeFilter struct {
+	allowed []string
+	denied  []string
+}
+
+// NewMimeTypeFilter creates a filter. An empty allow list permits every type
+// that is not explicitly denied.
+func NewMimeTypeFilter(allowed, denied []string) *MimeTypeFilter {
+	return &MimeTypeFilter{allowed: allowed, denied: denied}
+}
+
+// Check checks the declared type against the allow and deny lists, and the type
+// sniffed from data against the deny list only, so a renamed binary is still
+// caught while text formats the sniffer cannot tell apart, such as JSON, pass.
+// A nil filter allows everything.
+func (f *MimeTypeFilter) Check(declared string, data []byte) error {
+	if f == nil || (len(f.allowed) == 0 && len(f.denied) == 0) {
+		return nil
+	}
+
+	declared = normalizeMimeType(declared)
+	if f.isDenied(declared) || !f.isAllowed(declared) {
+		return fmt.Errorf("%w: %s", ErrMimeTypeNotAllowed, declared)
+	}
+	if sniffed := sniffMimeType(data); f.isDenied(sniffed) {
+		return fmt.Errorf("%w: %s", ErrMimeTypeNotAllowed, sniffed)
+	}
+	return nil
+}
+
+// CheckContent checks read resource content, falling back to the URI's extension
+// when the content does not declare a type
+func (f *MimeTypeFilter) CheckContent(content *entities.ResourceContent) error {
+	if f == nil {
+		return nil
+	}
+
+	declared := content.MimeType
+	if declared == "" {
+		declared = detectMimeType(content.URI)
+	}
+
+	data, err := contentData(content)
+	if err != nil {
+		return err
+	}
+	return f.Check(declared, data)
+}
+
+// contentData returns the bytes of read resource content, decoding a blob
+func contentData(content *entities.ResourceContent) ([]byte, error) {
+	if content.Blob == "" {
+		return []byte(content.Text), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(content.Blob)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob encoding: %w", err)
+	}
+	return decoded, nil
+}
+
+func (f *MimeTypeFilter) isDenied(mimeType string) bool {
+	for _, denied := range f.denied {
+		if matchMimeType(denied, mimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *MimeTypeFilter) isAllowed(mimeType string) bool {
+	if len(f.allowed) == 0 {
+		return true
+	}
+	for _, allowed := range f.allowed {
+		if matchMimeType(allowed, mimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMimeType matches a MIME type against a pattern such as "text/*"
+func matchMimeType(pattern, mimeType string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "*" || pattern == "*/*" {
+		return true
+	}
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mimeType, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == mimeType
+}
+
+// sniffMimeType detects the MIME type from the file content
+func sniffMimeType(data []byte) string {
+	return normalizeMimeType(http.DetectContentType(data))
+}
+
+// normalizeMimeType strips parameters such as charset and lowercases the type
+func normalizeMimeType(mimeType string) string {
+	if idx := strings.Index(mimeType, ";"); idx >= 0 {
+		mimeType = mimeType[:idx]
+	}
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
@@ -9,16 +9,27 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
 	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
 )
 
+// PathChecker checks an absolute path against the directories a call may access,
+// such as the tools path jail
+type PathChecker interface {
+	CheckPath(ctx context.Context, absPath string) error
+}
+
 // ResourceHandler handles MCP resource operations
 type ResourceHandler struct {
 	allowedPaths []string
 	maxFileSize  int64
+	mimeFilter   *MimeTypeFilter
+	pathChecker  PathChecker
+	sessions     repositories.ISessionRepository
 }
 
-// NewResourceHandler creates a new ResourceHandler
+// NewResourceHandler creates a new ResourceHandler. A maxFileSize of 0 disables the size limit.
 func NewResourceHandler(allowedPaths []string, maxFileSize int64) *ResourceHandler {
 	return &ResourceHandler{
 		allowedPaths: allowedPaths,
@@ -26,6 +37,25 @@ func NewResourceHandler(allowedPaths []string, maxFileSize int64) *ResourceHandl
 	}
 }
 
+// SetMimeTypeFilter sets the MIME types allowed and denied for reads.
+// Entries may use a wildcard subtype such as "text/*". An empty allow list
+// permits every type that is not explicitly denied.
+func (h *ResourceHandler) SetMimeTypeFilter(allowed, denied []string) {
+	h.mimeFilter = NewMimeTypeFilter(allowed, denied)
+}
+
+// SetPathChecker checks reads with checker instead of the allowed paths alone, so they
+// honour the symlink policy and the client roots of the session
+func (h *ResourceHandler) SetPathChecker(checker PathChecker) {
+	h.pathChecker = checker
+}
+
+// SetSessionRepository sets the repository ResolveResource loads the client roots of
+// the reading session from
+func (h *ResourceHandler) SetSessionRepository(sessions repositories.ISessionRepository) {
+	h.sessions = sessions
+}
+
 // ResourceContent represents the content of a resource
 type ResourceContent struct {
 	URI      string
@@ -34,18 +64,31 @@ type ResourceContent struct {
 	Blob     []byte
 }
 
-// ReadResource reads a resource by URI
-func (h *ResourceHandler) ReadResource(ctx context.Context, uri string) (*ResourceContent, error) {
-	// Parse URI
+// FileURITemplate is the URI template of the resource exposing files by path
+const FileURITemplate = "file:///{path}"
+
+// FilePathFromURI returns the absolute path a file:// URI names
+func FilePathFromURI(uri string) (string, error) {
 	if !strings.HasPrefix(uri, "file://") {
-		return nil, fmt.Errorf("unsupported URI scheme: %s", uri)
+		return "", fmt.Errorf("unsupported URI scheme: %s", uri)
+	}
+	path, err := filepath.Abs(strings.TrimPrefix(uri, "file://"))
+	if err != nil {
+		return "", ErrPathNotAllowed
 	}
+	return path, nil
+}
 
-	path := strings.TrimPrefix(uri, "file://")
+// ReadResource reads a resource by URI
+func (h *ResourceHandler) ReadResource(ctx context.Context, uri string) (*ResourceContent, error) {
+	path, err := FilePathFromURI(uri)
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate path is allowed
-	if !h.isPathAllowed(path) {
-		return nil, ErrPathNotAllowed
+	if err := h.checkPath(ctx, path); err != nil {
+		return nil, err
 	}
 
 	// Check if file exists
@@ -58,7 +101,7 @@ func (h *ResourceHandler) ReadResource(ctx context.Context, uri string) (*Resour
 	}
 
 	// Check file size
-	if info.Size() > h.maxFileSize {
+	if h.maxFileSize > 0 && info.Size() > h.maxFileSize {
 		return nil, ErrFileTooLarge
 	}
 
@@ -74,8 +117,11 @@ func (h *ResourceHandler) ReadResource(ctx context.Context, uri string) (*Resour
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Determine MIME type
-	mimeType := h.detectMimeType(path)
+	// Determine MIME type and check it, and the sniffed content, against the filter
+	mimeType := SniffMimeType(path, "", data)
+	if err := h.mimeFilter.Check(mimeType, data); err != nil {
+		return nil, err
+	}
 
 	return &ResourceContent{
 		URI:      uri,
@@ -84,6 +130,59 @@ func (h *ResourceHandler) ReadResource(ctx context.Context, uri string) (*Resour
 	}, nil
 }
 
+// ResolveResource reads the resource a message attachment references, subject to the
+// allowed paths, the client roots of the session, size limit and MIME filter
+func (h *ResourceHandler) ResolveResource(ctx context.Context, sessionID vo.SessionID, uri string) (*entities.ResourceContent, error) {
+	ctx, err := h.sessionContext(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := h.ReadResource(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ResourceContent{
+		URI:      content.URI,
+		MimeType: content.MimeType,
+		Text:     content.Text,
+	}, nil
+}
+
+// ResolveResourcePath returns the file a file:// URI names when the session may read it,
+// checked as ResolveResource checks reads, for callers that read the file themselves
+func (h *ResourceHandler) ResolveResourcePath(ctx context.Context, sessionID vo.SessionID, uri string) (string, error) {
+	ctx, err := h.sessionContext(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	path, err := FilePathFromURI(uri)
+	if err != nil {
+		return "", err
+	}
+	if err := h.checkPath(ctx, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// sessionContext carries the client roots of the session in ctx, unless it has them
+func (h *ResourceHandler) sessionContext(ctx context.Context, sessionID vo.SessionID) (context.Context, error) {
+	if _, ok := entities.RootsFromContext(ctx); ok || h.sessions == nil {
+		return ctx, nil
+	}
+	session, err := h.sessions.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil {
+		if roots, ok := session.Roots(); ok {
+			ctx = entities.ContextWithRoots(ctx, roots)
+		}
+	}
+	return ctx, nil
+}
+
 // ListResources lists available resources
 func (h *ResourceHandler) ListResources(ctx context.Context) ([]ResourceInfo, error) {
 	var resources []ResourceInfo
@@ -101,7 +200,7 @@ func (h *ResourceHandler) ListResources(ctx context.Context) ([]ResourceInfo, er
 				URI:         "file://" + path,
 				Name:        info.Name(),
 				Description: fmt.Sprintf("File: %s", path),
-				MimeType:    h.detectMimeType(path),
+				MimeType:    detectMimeType(path),
 			})
 			return nil
 		})
@@ -121,50 +220,64 @@ type ResourceInfo struct {
 	MimeType    string `json:"mimeType,omitempty"`
 }
 
-// isPathAllowed checks if a path is within allowed directories
-func (h *ResourceHandler) isPathAllowed(path string) bool {
-	absPath, err := filepath.Abs(path)
-	if err != nil {
-		return false
+// checkPath checks an absolute path with the path checker, or else against the allowed
+// directories
+func (h *ResourceHandler) checkPath(ctx context.Context, absPath string) error {
+	if h.pathChecker != nil {
+		if err := h.pathChecker.CheckPath(ctx, absPath); err != nil {
+			return fmt.Errorf("%w: %v", ErrPathNotAllowed, err)
+		}
+		return nil
+	}
+	if !h.isPathAllowed(absPath) {
+		return ErrPathNotAllowed
 	}
+	return nil
+}
 
+// isPathAllowed checks if an absolute path is one of the allowed directories or lies below one
+func (h *ResourceHandler) isPathAllowed(absPath string) bool {
 	for _, allowed := range h.allowedPaths {
 		allowedAbs, err := filepath.Abs(allowed)
 		if err != nil {
 			continue
 		}
-		if strings.HasPrefix(absPath, allowedAbs) {
+		if absPath == allowedAbs || strings.HasPrefix(absPath, strings.TrimSuffix(allowedAbs, string(filepath.Separator))+string(filepath.Separator)) {
 			return true
 		}
 	}
 	return false
 }
 
+// mimeTypesByExtension maps file extensions to the MIME type they imply
+var mimeTypesByExtension = map[string]string{
+	".txt":  vo.MimeTypePlainText,
+	".md":   vo.MimeTypeMarkdown,
+	".json": vo.MimeTypeJSON,
+	".html": vo.MimeTypeHTML,
+	".xml":  vo.MimeTypeXML,
+	".go":   "text/x-go",
+	".py":   "text/x-python",
+	".js":   "text/javascript",
+	".ts":   "text/typescript",
+	".yaml": "text/yaml",
+	".yml":  "text/yaml",
+	".png":  vo.MimeTypePNG,
+	".jpg":  vo.MimeTypeJPEG,
+	".jpeg": vo.MimeTypeJPEG,
+	".gif":  vo.MimeTypeGIF,
+	".webp": vo.MimeTypeWebP,
+	".pdf":  vo.MimeTypePDF,
+	".exe":  "application/vnd.microsoft.portable-executable",
+	".dll":  "application/vnd.microsoft.portable-executable",
+	".so":   MimeTypeOctetStream,
+	".bin":  MimeTypeOctetStream,
+	".sh":   "application/x-sh",
+}
+
 // detectMimeType detects the MIME type based on file extension
-func (h *ResourceHandler) detectMimeType(path string) string {
-	ext := strings.ToLower(filepath.Ext(path))
-
-	mimeTypes := map[string]string{
-		".txt":  vo.MimeTypePlainText,
-		".md":   vo.MimeTypeMarkdown,
-		".json": vo.MimeTypeJSON,
-		".html": vo.MimeTypeHTML,
-		".xml":  vo.MimeTypeXML,
-		".go":   "text/x-go",
-		".py":   "text/x-python",
-		".js":   "text/javascript",
-		".ts":   "text/typescript",
-		".yaml": "text/yaml",
-		".yml":  "text/yaml",
-		".png":  vo.MimeTypePNG,
-		".jpg":  vo.MimeTypeJPEG,
-		".jpeg": vo.MimeTypeJPEG,
-		".gif":  vo.MimeTypeGIF,
-		".webp": vo.MimeTypeWebP,
-		".pdf":  vo.MimeTypePDF,
-	}
-
-	if mimeType, ok := mimeTypes[ext]; ok {
+func detectMimeType(path string) string {
+	if mimeType, ok := mimeTypesByExtension[strings.ToLower(filepath.Ext(path))]; ok {
 		return mimeType
 	}
 	return vo.MimeTypePlainText
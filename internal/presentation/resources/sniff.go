@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// MimeTypeOctetStream is the generic type of binary data, which sniffing may refine
+const MimeTypeOctetStream = "application/octet-stream"
+
+// SniffMimeType returns the MIME type to advertise for content read from name. A
+// specific declared type is authoritative; a missing or generic one is replaced by
+// the type the name's extension implies or, failing that, the type sniffed from data.
+func SniffMimeType(name, declared string, data []byte) string {
+	if !isGenericMimeType(declared) {
+		return declared
+	}
+	if mimeType, ok := mimeTypesByExtension[strings.ToLower(filepath.Ext(name))]; ok && !isGenericMimeType(mimeType) {
+		return mimeType
+	}
+
+	sniffed := sniffMimeType(data)
+	// The sniffer reports JSON as plain text
+	if sniffed == vo.MimeTypePlainText && looksLikeJSON(data) {
+		return vo.MimeTypeJSON
+	}
+	return sniffed
+}
+
+// SniffContentMimeType refines the MIME type of read resource content that declares
+// none or a generic one, judging by its URI and data
+func SniffContentMimeType(content *entities.ResourceContent) error {
+	if !isGenericMimeType(content.MimeType) {
+		return nil
+	}
+	data, err := contentData(content)
+	if err != nil {
+		return err
+	}
+	content.MimeType = SniffMimeType(content.URI, content.MimeType, data)
+	return nil
+}
+
+// isGenericMimeType reports whether a MIME type says nothing specific about the content
+func isGenericMimeType(mimeType string) bool {
+	mimeType = normalizeMimeType(mimeType)
+	return mimeType == "" || mimeType == MimeTypeOctetStream
+}
+
+// looksLikeJSON reports whether data is a JSON object or array
+func looksLikeJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	return json.Valid(trimmed)
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TransportAccess controls who may connect over the HTTP transports. Browsers apply
+// no CORS checks to WebSocket upgrades or to the simple requests SSE clients make,
+// so the Origin check here is the only thing that stops an arbitrary web page, or
+// one reaching the server through DNS rebinding, from connecting on behalf of
+// whoever visits it.
+type TransportAccess struct {
+	// Origins besides the server's own that browser clients may connect from. "*" is
+	// ignored: allowing every origin would let any page drive the server's tools.
+	AllowedOrigins []string
+
+	// When set, every request must carry one of APIKeys, either as a bearer token in
+	// the Authorization header or in the api_key query parameter, which is all a
+	// browser client can set. No key is accepted when APIKeys is empty.
+	RequireAPIKey bool
+	APIKeys       []string
+
+	// Keys issued with scopes, checked before APIKeys (nil when there are none). A
+	// connection made with one of them holds its scopes.
+	Keys APIKeyStore
+}
+
+// APIKeyStore looks up issued API keys
+type APIKeyStore interface {
+	// LookupAPIKey returns the scopes of an active key, and false for unknown,
+	// inactive or expired keys
+	LookupAPIKey(ctx context.Context, key string) ([]string, bool, error)
+}
+
+// originAllowed reports whether the Origin of a request is the request's own host or
+// one of allowed. Requests without an Origin do not come from a browser page.
+func originAllowed(r *http.Request, allowed []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, o := range allowed {
+		if o != "*" && strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// authenticate reports whether a request carries a key access accepts, or needs none,
+// and returns the scopes of the key. Only issued keys carry scopes.
+func (a TransportAccess) authenticate(r *http.Request) ([]string, bool) {
+	key := r.URL.Query().Get("api_key")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key == "" {
+		return nil, !a.RequireAPIKey
+	}
+	if a.Keys != nil {
+		if scopes, ok, err := a.Keys.LookupAPIKey(r.Context(), key); err == nil && ok {
+			return scopes, true
+		}
+	}
+	for _, allowed := range a.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(allowed)) == 1 {
+			return nil, true
+		}
+	}
+	return nil, !a.RequireAPIKey
+}
+
+// allow answers a request access refuses and reports whether it may proceed, along
+// with the scopes of its key
+func (a TransportAccess) allow(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	scopes, ok := a.authenticate(r)
+	if !ok {
+		http.Error(w, "invalid or missing API key", http.StatusUnauthorized)
+		return nil, false
+	}
+	if !originAllowed(r, a.AllowedOrigins) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return nil, false
+	}
+	return scopes, true
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// SetToolEnabledParams represents admin/setToolEnabled request parameters
+type SetToolEnabledParams struct {
+	Name    string `json:"name"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// handleAdminSetToolEnabled handles admin/setToolEnabled request
+func (s *Server) handleAdminSetToolEnabled(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if !s.config.Server.EnableAdminMethods {
+		return nil, &MCPError{Code: vo.ErrorCodeMethodNotFound, Message: "Method not found"}
+	}
+
+	var p SetToolEnabledParams
+	if err := json.Unmarshal(params, &p); err != nil || p.Name == "" || p.Enabled == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: name and enabled are required"}
+	}
+
+	tool, err := s.toolHandler.HandleSetToolEnabled(ctx, &commands.SetToolEnabledCommand{Name: p.Name, Enabled: *p.Enabled})
+	if err != nil {
+		return nil, adminToolError(p.Name, err)
+	}
+
+	s.logger.Info().Str("tool", p.Name).Bool("enabled", tool.IsEnabled()).Msg("Tool enablement changed")
+
+	return map[string]interface{}{
+		"name":    tool.Name().String(),
+		"enabled": tool.IsEnabled(),
+	}, nil
+}
+
+// ToolUpdateParams represents tools/update request parameters: the tool to update and
+// the fields to change. Omitted fields are left unchanged.
+type ToolUpdateParams struct {
+	Name  string          `json:"name"`
+	Patch json.RawMessage `json:"patch"`
+}
+
+// ToolPatch holds the mutable fields of a tool. Annotations are merged field by field.
+type ToolPatch struct {
+	Description *string                        `json:"description"`
+	TimeoutMs   *int64                         `json:"timeoutMs"`
+	Enabled     *bool                          `json:"enabled"`
+	Tags        []string                       `json:"tags"`
+	Category    *string                        `json:"category"`
+	Annotations *commands.ToolAnnotationsPatch `json:"annotations"`
+}
+
+// adminToolError converts an error from changing a tool into an MCP error: a malformed
+// name or patch is invalid params, a missing or protected tool is reported as
+// tools/call reports it, and anything else is an internal error
+func adminToolError(name string, err error) *MCPError {
+	switch {
+	case errors.Is(err, vo.ErrEmptyContent), errors.Is(err, vo.ErrInvalidToolID):
+		return &MCPError{Code: vo.ErrorCodeInvalidParams, Message: fmt.Sprintf("Invalid tool name: %s", name), Data: map[string]interface{}{"tool": name}}
+	case errors.Is(err, handlers.ErrInvalidToolUpdate):
+		return &MCPError{Code: vo.ErrorCodeInvalidParams, Message: err.Error(), Data: map[string]interface{}{"tool": name}}
+	case errors.Is(err, handlers.ErrToolNotFound), errors.Is(err, handlers.ErrToolSafeMode):
+		return toolCallError(name, err)
+	}
+	return AsMCPError(err)
+}
+
+// immutableToolFields are the tool fields clients rely on staying fixed for a session
+var immutableToolFields = []string{"name", "inputSchema"}
+
+// handleToolsUpdate handles tools/update request
+func (s *Server) handleToolsUpdate(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if !s.config.Server.EnableAdminMethods {
+		return nil, &MCPError{Code: vo.ErrorCodeMethodNotFound, Message: "Method not found"}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	var p ToolUpdateParams
+	if err := json.Unmarshal(params, &p); err != nil || p.Name == "" || len(p.Patch) == 0 {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: name and patch are required"}
+	}
+
+	// Renaming a tool or changing its schema would break clients mid-session
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(p.Patch, &fields); err != nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: patch must be an object"}
+	}
+	for _, field := range immutableToolFields {
+		if _, ok := fields[field]; ok {
+			return nil, &MCPError{
+				Code:    vo.ErrorCodeInvalidParams,
+				Message: fmt.Sprintf("Invalid params: %s cannot be changed at runtime", field),
+				Data:    map[string]interface{}{"tool": p.Name, "field": field},
+			}
+		}
+	}
+
+	var patch ToolPatch
+	decoder := json.NewDecoder(bytes.NewReader(p.Patch))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: fmt.Sprintf("Invalid params: %v", err)}
+	}
+
+	cmd := &commands.UpdateToolCommand{
+		SessionID:   session.ID(),
+		Name:        p.Name,
+		Description: patch.Description,
+		Enabled:     patch.Enabled,
+		Tags:        patch.Tags,
+		Category:    patch.Category,
+		Annotations: patch.Annotations,
+	}
+	if patch.TimeoutMs != nil {
+		timeout := time.Duration(*patch.TimeoutMs) * time.Millisecond
+		cmd.Timeout = &timeout
+	}
+
+	tool, err := s.toolHandler.HandleUpdateTool(ctx, cmd)
+	if err != nil {
+		return nil, adminToolError(p.Name, err)
+	}
+
+	if err := s.SendNotification(vo.MethodNotificationsToolsListChanged, nil); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to send tools list changed notification")
+	}
+	s.logger.Info().Str("tool", p.Name).Msg("Tool updated")
+
+	result := tool.ToMCPTool()
+	result["enabled"] = tool.IsEnabled()
+	result["timeoutMs"] = tool.Timeout().Milliseconds()
+	result["category"] = tool.Category()
+	result["tags"] = tool.Tags()
+	return result, nil
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ServerBusyRetryAfter is the retry hint returned when the request queue is full
+const ServerBusyRetryAfter = time.Second
+
+// RequestMetrics records request admission metrics
+type RequestMetrics interface {
+	RecordRequestRejectedOverload(ctx context.Context)
+}
+
+// SetMetrics sets the recorder for request admission metrics
+func (s *Server) SetMetrics(metrics RequestMetrics) {
+	s.metrics = metrics
+}
+
+// requestPool runs requests on a bounded set of workers with a bounded wait queue
+type requestPool struct {
+	workers    chan struct{}
+	queueDepth int
+	pending    int64 // executing plus waiting requests
+}
+
+func newRequestPool(workers, queueDepth int) *requestPool {
+	return &requestPool{
+		workers:    make(chan struct{}, workers),
+		queueDepth: queueDepth,
+	}
+}
+
+// tryAdmit reserves a slot for a request, failing once the queue passes its high-water mark
+func (p *requestPool) tryAdmit() bool {
+	limit := int64(cap(p.workers) + p.queueDepth)
+	if atomic.AddInt64(&p.pending, 1) > limit {
+		atomic.AddInt64(&p.pending, -1)
+		return false
+	}
+	return true
+}
+
+// run executes fn on a worker once one is free; the request must have been admitted
+func (p *requestPool) run(fn func()) {
+	go func() {
+		defer atomic.AddInt64(&p.pending, -1)
+
+		p.workers <- struct{}{}
+		defer func() { <-p.workers }()
+		fn()
+	}()
+}
+
+// poolable reports whether a request may run on the worker pool. Notifications,
+// initialize and ping run inline so the handshake stays ordered and liveness
+// checks are answered even when the server is busy, as do the admin methods that
+// list and cancel requests so stuck work can be cancelled when every worker is taken.
+func poolable(req *JSONRPCRequest) bool {
+	switch method := vo.MCPMethod(req.Method); {
+	case method.IsNotification(), method == vo.MethodInitialize, method == vo.MethodPing,
+		method == vo.MethodAdminListRequests, method == vo.MethodAdminCancelRequest:
+		return false
+	}
+	return true
+}
+
+// serveLine handles a single request line read from conn, dispatching it to the worker
+// pool when enabled
+func (s *Server) serveLine(ctx context.Context, conn *connection, data []byte) {
+	var req JSONRPCRequest
+	parsed := json.Unmarshal(data, &req) == nil
+
+	// Answers to the server's own requests go to the caller waiting for them
+	if parsed && req.Method == "" && s.handleClientResponse(conn, data) {
+		return
+	}
+
+	if s.pool != nil && parsed && poolable(&req) {
+		if !s.pool.tryAdmit() {
+			s.rejectOverload(ctx, &req)
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		op, done := s.inflight.begin(req.Method, req.ID, conn, cancel)
+		conn.pending.Add(1)
+		s.pool.run(func() {
+			defer conn.pending.Done()
+			defer done()
+			defer cancel()
+			s.serveRequest(ctx, data, op)
+		})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	op, done := s.inflight.begin(req.Method, req.ID, conn, cancel)
+	defer done()
+	s.serveRequest(ctx, data, op)
+}
+
+// serveRequest handles a request and writes its response. A request cancelled by the
+// client gets no response; one cancelled by an operator is answered with a cancelled error.
+func (s *Server) serveRequest(ctx context.Context, data []byte, op *inflightOp) {
+	response, err := s.handleRequest(ctx, data)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error handling request")
+		response = s.createErrorResponse(nil, vo.ErrorCodeInternalError, err.Error())
+	}
+
+	cancelled := s.inflight.cancellation(op)
+	if op.id != nil {
+		s.recordRequest(ctx, op.method, cancelled != "" || (response != nil && response.Error != nil))
+	}
+
+	switch cancelled {
+	case cancelledByClient:
+		s.logger.Debug().Str("method", op.method).Interface("id", op.id).Msg("Dropped response to cancelled request")
+		return
+	case cancelledByAdmin:
+		response = s.createMCPErrorResponse(op.id, &MCPError{
+			Code:    vo.ErrorCodeCancelled,
+			Message: "Request cancelled by an administrator",
+			Data:    map[string]interface{}{"method": op.method},
+		})
+	}
+
+	if response != nil {
+		if err := s.sendResponse(ctx, response); err != nil {
+			s.logger.Error().Err(err).Msg("Error sending response")
+		}
+	}
+}
+
+// rejectOverload answers a request with a retryable server busy error
+func (s *Server) rejectOverload(ctx context.Context, req *JSONRPCRequest) {
+	s.logger.Warn().Str("method", req.Method).Interface("id", req.ID).Msg("Rejected request: request queue full")
+	if s.metrics != nil {
+		s.metrics.RecordRequestRejectedOverload(ctx)
+	}
+
+	response := s.createMCPErrorResponse(req.ID, &MCPError{
+		Code:       vo.ErrorCodeServerBusy,
+		Message:    fmt.Sprintf("Server busy: request queue of %d is full, retry later", s.config.Server.RequestQueueDepth),
+		Data:       map[string]interface{}{"queueDepth": s.config.Server.RequestQueueDepth},
+		Retryable:  true,
+		RetryAfter: ServerBusyRetryAfter,
+	})
+	if err := s.sendResponse(ctx, response); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending response")
+	}
+}
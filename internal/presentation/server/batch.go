@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ToolCallBatchParams represents tools/callBatch request parameters
+type ToolCallBatchParams struct {
+	Calls []ToolCallParams `json:"calls"`
+}
+
+// ToolCallBatchItem represents the outcome of a single call in a batch
+type ToolCallBatchItem struct {
+	Name   string               `json:"name"`
+	Result *entities.ToolResult `json:"result,omitempty"`
+	Error  *JSONRPCError        `json:"error,omitempty"`
+}
+
+// ToolCallBatchResult represents the tools/callBatch result, in request order
+type ToolCallBatchResult struct {
+	Results []ToolCallBatchItem `json:"results"`
+}
+
+// handleToolsCallBatch handles the experimental tools/callBatch request.
+// Calls run concurrently up to the configured limit; failures are reported per item.
+func (s *Server) handleToolsCallBatch(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p ToolCallBatchParams
+	if err := json.Unmarshal(params, &p); err != nil || len(p.Calls) == 0 {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: calls must be a non-empty array"}
+	}
+
+	maxBatchSize := s.config.MCP.MaxBatchSize
+	if maxBatchSize > 0 && len(p.Calls) > maxBatchSize {
+		return nil, &MCPError{
+			Code:    vo.ErrorCodeInvalidParams,
+			Message: fmt.Sprintf("Invalid params: batch exceeds maximum of %d calls", maxBatchSize),
+		}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	concurrency := s.config.MCP.BatchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+
+	results := make([]ToolCallBatchItem, len(p.Calls))
+	var wg sync.WaitGroup
+	for i, call := range p.Calls {
+		wg.Add(1)
+		go func(i int, call ToolCallParams) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = s.executeBatchCall(ctx, session.ID(), call)
+		}(i, call)
+	}
+	wg.Wait()
+
+	return &ToolCallBatchResult{Results: results}, nil
+}
+
+// executeBatchCall executes a single call of a batch
+func (s *Server) executeBatchCall(ctx context.Context, sessionID vo.SessionID, call ToolCallParams) ToolCallBatchItem {
+	item := ToolCallBatchItem{Name: call.Name}
+	if call.Name == "" {
+		item.Error = &JSONRPCError{Code: int(vo.ErrorCodeInvalidParams), Message: "Tool name is required"}
+		return item
+	}
+
+	cmd := &commands.ExecuteToolCommand{
+		SessionID: sessionID,
+		Name:      call.Name,
+		Arguments: call.Arguments,
+	}
+
+	result, err := s.toolHandler.HandleExecuteTool(ctx, cmd)
+	if err != nil {
+		mcpErr := toolCallError(call.Name, err)
+		item.Error = &JSONRPCError{Code: int(mcpErr.Code), Message: mcpErr.Message, Data: mcpErr.ErrorData()}
+		return item
+	}
+
+	item.Result = result
+	return item
+}
@@ -0,0 +1,304 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// capabilitiesDiffCapability is the experimental capability advertising the fingerprint
+const capabilitiesDiffCapability = "capabilitiesDiff"
+
+// maxCapabilitySnapshots bounds how many past fingerprints a diff can be computed from
+const maxCapabilitySnapshots = 32
+
+// capabilitySnapshot maps each tool name, resource URI and prompt name a session is
+// offered to a digest of its full definition, so a changed schema or description
+// changes the fingerprint too
+type capabilitySnapshot struct {
+	Tools     map[string]string `json:"tools"`
+	Resources map[string]string `json:"resources"`
+	Prompts   map[string]string `json:"prompts"`
+}
+
+// fingerprint returns a stable hash of the snapshot
+func (c *capabilitySnapshot) fingerprint() string {
+	h := sha256.New()
+	for _, section := range []struct {
+		kind        string
+		definitions map[string]string
+	}{{"tool", c.Tools}, {"resource", c.Resources}, {"prompt", c.Prompts}} {
+		for _, name := range sortedKeys(section.definitions) {
+			h.Write([]byte(section.kind))
+			h.Write([]byte{0})
+			h.Write([]byte(name))
+			h.Write([]byte{0})
+			h.Write([]byte(section.definitions[name]))
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// definitionDigest hashes a definition as the list methods return it. Maps marshal
+// with sorted keys, so equal definitions always hash the same.
+func definitionDigest(definition map[string]interface{}) string {
+	data, err := json.Marshal(definition)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// capabilityHistoryFile is the JSON document a capability history is saved to
+type capabilityHistoryFile struct {
+	Order     []string                       `json:"order"`
+	Snapshots map[string]*capabilitySnapshot `json:"snapshots"`
+}
+
+// capabilityHistory remembers the most recent snapshots by fingerprint. When path is
+// set the history is saved there on every change, so fingerprints clients saw before
+// a restart can still be diffed.
+type capabilityHistory struct {
+	mu        sync.Mutex
+	limit     int
+	path      string
+	order     []string
+	snapshots map[string]*capabilitySnapshot
+}
+
+func newCapabilityHistory(limit int) *capabilityHistory {
+	return &capabilityHistory{limit: limit, snapshots: make(map[string]*capabilitySnapshot)}
+}
+
+// load reads the history saved at path, if any, and saves every later change there
+func (h *capabilityHistory) load(path string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var file capabilityHistoryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid capability history %s: %w", path, err)
+	}
+	for _, fingerprint := range file.Order {
+		if snapshot, ok := file.Snapshots[fingerprint]; ok && h.snapshots[fingerprint] == nil {
+			h.snapshots[fingerprint] = snapshot
+			h.order = append(h.order, fingerprint)
+		}
+	}
+	h.evict()
+	return nil
+}
+
+// evict drops the oldest snapshots beyond the limit; h.mu must be held
+func (h *capabilityHistory) evict() {
+	for len(h.order) > h.limit {
+		delete(h.snapshots, h.order[0])
+		h.order = h.order[1:]
+	}
+}
+
+// save writes the history to its path, replacing the previous file atomically;
+// h.mu must be held
+func (h *capabilityHistory) save() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(capabilityHistoryFile{Order: h.order, Snapshots: h.snapshots})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(h.path), "."+filepath.Base(h.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), h.path)
+}
+
+// record stores snapshot under its fingerprint, evicting the oldest beyond the limit.
+// The fingerprint is returned even when saving the history fails.
+func (h *capabilityHistory) record(snapshot *capabilitySnapshot) (string, error) {
+	fingerprint := snapshot.fingerprint()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.snapshots[fingerprint]; ok {
+		return fingerprint, nil
+	}
+	h.snapshots[fingerprint] = snapshot
+	h.order = append(h.order, fingerprint)
+	h.evict()
+	return fingerprint, h.save()
+}
+
+func (h *capabilityHistory) get(fingerprint string) (*capabilitySnapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	snapshot, ok := h.snapshots[fingerprint]
+	return snapshot, ok
+}
+
+// capabilitySnapshot captures what the session is currently offered: the enabled
+// tools, as tools/list returns them, and the session's resources and prompts
+func (s *Server) capabilitySnapshot(ctx context.Context, session *aggregates.Session) (*capabilitySnapshot, error) {
+	tools, err := s.toolHandler.HandleListTools(ctx, &queries.ListToolsQuery{SessionID: session.ID(), EnabledOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &capabilitySnapshot{
+		Tools:     make(map[string]string),
+		Resources: make(map[string]string),
+		Prompts:   make(map[string]string),
+	}
+	for _, tool := range tools.Tools {
+		snapshot.Tools[tool.Name().String()] = definitionDigest(tool.ToMCPTool())
+	}
+	for _, resource := range session.ListResources() {
+		key := resource.URI().String()
+		if resource.IsTemplate() {
+			key = resource.URITemplate()
+		}
+		snapshot.Resources[key] = definitionDigest(resource.ToMCPResource())
+	}
+	for _, prompt := range session.ListPrompts() {
+		snapshot.Prompts[prompt.Name().String()] = definitionDigest(prompt.ToMCPPrompt())
+	}
+	return snapshot, nil
+}
+
+// recordCapabilities snapshots what the session is offered and returns its fingerprint
+func (s *Server) recordCapabilities(ctx context.Context, session *aggregates.Session) (string, error) {
+	snapshot, err := s.capabilitySnapshot(ctx, session)
+	if err != nil {
+		return "", err
+	}
+	return s.recordSnapshot(snapshot), nil
+}
+
+// recordSnapshot records snapshot in the capability history. Failing to save the
+// history only costs clients a resync after a restart, so it is logged.
+func (s *Server) recordSnapshot(snapshot *capabilitySnapshot) string {
+	fingerprint, err := s.capabilities.record(snapshot)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to save capability history")
+	}
+	return fingerprint
+}
+
+// CapabilitiesDiffParams represents experimental/capabilitiesDiff request parameters
+type CapabilitiesDiffParams struct {
+	Since string `json:"since"`
+}
+
+// CapabilityChanges lists the names added to, removed from and redefined in one kind
+// of capability. Changed names are offered in both snapshots with a different
+// definition, such as a new input schema or description.
+type CapabilityChanges struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// CapabilitiesDiffResult represents the experimental/capabilitiesDiff result. Resync
+// is set when the since fingerprint is unknown, for example after a restart without
+// mcp.capability_history_file, and the client has to re-list everything.
+type CapabilitiesDiffResult struct {
+	Fingerprint string             `json:"fingerprint"`
+	Since       string             `json:"since"`
+	Changed     bool               `json:"changed"`
+	Resync      bool               `json:"resync,omitempty"`
+	Tools       *CapabilityChanges `json:"tools,omitempty"`
+	Resources   *CapabilityChanges `json:"resources,omitempty"`
+	Prompts     *CapabilityChanges `json:"prompts,omitempty"`
+}
+
+// handleCapabilitiesDiff handles the experimental/capabilitiesDiff request. It reports
+// the tools, resources and prompts added or removed since the fingerprint the client
+// last saw, so a reconnecting client can sync without re-listing everything.
+func (s *Server) handleCapabilitiesDiff(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p CapabilitiesDiffParams
+	if err := json.Unmarshal(params, &p); err != nil || p.Since == "" {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: since is required"}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	current, err := s.capabilitySnapshot(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	// Look up the old snapshot first so recording the current one cannot evict it
+	previous, ok := s.capabilities.get(p.Since)
+	result := &CapabilitiesDiffResult{Fingerprint: s.recordSnapshot(current), Since: p.Since}
+	if !ok {
+		result.Changed = true
+		result.Resync = true
+		return result, nil
+	}
+	result.Changed = result.Fingerprint != p.Since
+	result.Tools = diffDefinitions(previous.Tools, current.Tools)
+	result.Resources = diffDefinitions(previous.Resources, current.Resources)
+	result.Prompts = diffDefinitions(previous.Prompts, current.Prompts)
+	return result, nil
+}
+
+// diffDefinitions compares two snapshots of one kind of capability
+func diffDefinitions(before, after map[string]string) *CapabilityChanges {
+	changes := &CapabilityChanges{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for _, name := range sortedKeys(before) {
+		digest, ok := after[name]
+		switch {
+		case !ok:
+			changes.Removed = append(changes.Removed, name)
+		case digest != before[name]:
+			changes.Changed = append(changes.Changed, name)
+		}
+	}
+	for _, name := range sortedKeys(after) {
+		if _, ok := before[name]; !ok {
+			changes.Added = append(changes.Added, name)
+		}
+	}
+	return changes
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
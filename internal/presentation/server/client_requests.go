@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ClientRequestTimeout bounds how long the server waits for the client to answer a request
+const ClientRequestTimeout = 30 * time.Second
+
+// ErrClientRequestFailed is returned when the client answers a server request with an error
+var ErrClientRequestFailed = errors.New("client request failed")
+
+// clientResponse is the client's answer to a request sent by the server
+type clientResponse struct {
+	ID     interface{}     `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *JSONRPCError   `json:"error,omitempty"`
+}
+
+// clientRequests tracks the requests the server sent to the client that await an answer
+type clientRequests struct {
+	mu      sync.Mutex
+	nextID  int64
+	pending map[string]chan *clientResponse // request ID -> waiting caller
+}
+
+func newClientRequests() *clientRequests {
+	return &clientRequests{pending: make(map[string]chan *clientResponse)}
+}
+
+// begin allocates a request ID and the channel its response is delivered on
+func (c *clientRequests) begin() (string, chan *clientResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	id := "srv-" + strconv.FormatInt(c.nextID, 10)
+	ch := make(chan *clientResponse, 1)
+	c.pending[id] = ch
+	return id, ch
+}
+
+// end forgets a request whose caller stopped waiting
+func (c *clientRequests) end(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, id)
+}
+
+// deliver hands a response to the caller waiting for it, and reports whether one was
+func (c *clientRequests) deliver(resp *clientResponse) bool {
+	id := fmt.Sprint(resp.ID)
+	c.mu.Lock()
+	ch, ok := c.pending[id]
+	delete(c.pending, id)
+	c.mu.Unlock()
+	if ok {
+		ch <- resp
+	}
+	return ok
+}
+
+// requestClient sends a request to the client of the connection in ctx and waits for
+// its result. The answer arrives through the read loop, so requestClient must not be
+// called from it.
+func (s *Server) requestClient(ctx context.Context, method vo.MCPMethod, params interface{}) (json.RawMessage, error) {
+	conn := connectionFromContext(ctx)
+	if conn == nil {
+		return nil, ErrTransportClosed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ClientRequestTimeout)
+	defer cancel()
+
+	id, responses := conn.requests.begin()
+	defer conn.requests.end(id)
+
+	request := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  method.String(),
+	}
+	if params != nil {
+		request["params"] = params
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.writeMessage(conn, data); err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-responses:
+		if resp.Error != nil {
+			return nil, fmt.Errorf("%w: %s: %s (code %d)", ErrClientRequestFailed, method, resp.Error.Message, resp.Error.Code)
+		}
+		return resp.Result, nil
+	case <-s.done:
+		return nil, ErrServerClosed
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s: %w", method, ctx.Err())
+	}
+}
+
+// handleClientResponse routes a message answering a request the server sent over conn
+// to the caller waiting for it, and reports whether data was such a response
+func (s *Server) handleClientResponse(conn *connection, data []byte) bool {
+	var resp clientResponse
+	if err := json.Unmarshal(data, &resp); err != nil || resp.ID == nil || (resp.Result == nil && resp.Error == nil) {
+		return false
+	}
+	if !conn.requests.deliver(&resp) {
+		s.logger.Debug().Interface("id", resp.ID).Msg("Dropped response to an unknown server request")
+	}
+	return true
+}
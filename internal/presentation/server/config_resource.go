@@ -0,0 +1,40 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ConfigResourceURI is the URI of the effective configuration resource
+const ConfigResourceURI = "config://effective"
+
+// newConfigResource creates the resource exposing the redacted effective configuration
+func (s *Server) newConfigResource() (*entities.Resource, error) {
+	uri, err := vo.NewResourceURI(ConfigResourceURI)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := entities.NewResource(uri, "Effective Configuration")
+	if err != nil {
+		return nil, err
+	}
+	resource.SetDescription("Effective server configuration with secrets redacted")
+	mimeType, _ := vo.NewMimeType(vo.MimeTypeJSON)
+	resource.SetMimeType(mimeType)
+	resource.SetReader(func(uri string) (*entities.ResourceContent, error) {
+		data, err := json.MarshalIndent(s.config.Audit(), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &entities.ResourceContent{
+			URI:      uri,
+			MimeType: vo.MimeTypeJSON,
+			Text:     string(data),
+		}, nil
+	})
+
+	return resource, nil
+}
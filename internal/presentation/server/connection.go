@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// connection is the state of one client connection: the transport it talks over, the
+// session it initialized and the requests the server sent it. The stdio transport has
+// a single connection; the HTTP transports open one per client.
+type connection struct {
+	transport Transport
+	requests  *clientRequests
+	scopes    []string // Granted to the sessions initialized over the connection
+
+	// Requests of this connection running on the worker pool
+	pending sync.WaitGroup
+
+	mu             sync.RWMutex
+	session        *aggregates.Session
+	clientRoots    bool // The client advertised the roots capability
+	rootsRequested uint64
+	rootsApplied   uint64
+}
+
+func newConnection(transport Transport, scopes []string) *connection {
+	return &connection{transport: transport, requests: newClientRequests(), scopes: scopes}
+}
+
+// Session returns the session initialized over the connection, or nil before initialize
+func (c *connection) Session() *aggregates.Session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
+}
+
+// setSession records the session initialized over the connection
+func (c *connection) setSession(session *aggregates.Session, clientRoots bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = session
+	c.clientRoots = clientRoots
+}
+
+// supportsRoots reports whether the client advertised the roots capability
+func (c *connection) supportsRoots() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.clientRoots
+}
+
+// sessionID returns the ID of the connection's session, or "" before initialize
+func (c *connection) sessionID() string {
+	if session := c.Session(); session != nil {
+		return session.ID().String()
+	}
+	return ""
+}
+
+type connectionKey struct{}
+
+// contextWithConnection returns ctx carrying the connection a request arrived on
+func contextWithConnection(ctx context.Context, conn *connection) context.Context {
+	return context.WithValue(ctx, connectionKey{}, conn)
+}
+
+// connectionFromContext returns the connection a request arrived on, or nil
+func connectionFromContext(ctx context.Context) *connection {
+	conn, _ := ctx.Value(connectionKey{}).(*connection)
+	return conn
+}
+
+// openConnections returns the connections currently being served
+func (s *Server) openConnections() []*connection {
+	return s.sessions.all()
+}
+
+// connectionForSession returns the open connection that initialized the session, or nil
+func (s *Server) connectionForSession(id vo.SessionID) *connection {
+	return s.sessions.connection(id)
+}
+
+// sessionFor returns the session of the connection a request arrived on, or nil before
+// the connection is initialized
+func (s *Server) sessionFor(ctx context.Context) *aggregates.Session {
+	conn := connectionFromContext(ctx)
+	if conn == nil {
+		return nil
+	}
+	return conn.Session()
+}
+
+// serveConnection reads and handles messages from a connection until its client
+// disconnects or the server stops
+func (s *Server) serveConnection(ctx context.Context, conn *connection) error {
+	s.sessions.add(conn)
+	defer s.sessions.remove(conn)
+	defer conn.transport.Close()
+	// Let in-flight requests finish writing their responses before closing the transport
+	defer conn.pending.Wait()
+
+	ctx = contextWithConnection(ctx, conn)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.done:
+			return ErrServerClosed
+		default:
+			data, err := conn.transport.ReadMessage(ctx)
+			if err != nil {
+				if !errors.Is(err, io.EOF) && !errors.Is(err, ErrTransportClosed) {
+					s.logger.Error().Err(err).Msg("Transport read error")
+				}
+				return err
+			}
+
+			s.logger.Debug().Str("request", string(data)).Msg("Received request")
+
+			s.serveLine(ctx, conn, data)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ConversationTemplateInfo describes a conversation template in a
+// conversationTemplates/list result
+type ConversationTemplateInfo struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description,omitempty"`
+	Model         string   `json:"model"`
+	SystemPrompt  string   `json:"systemPrompt,omitempty"`
+	Tools         []string `json:"tools"`
+	MaxTokens     int      `json:"maxTokens,omitempty"`
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"topP,omitempty"`
+	TopK          *int     `json:"topK,omitempty"`
+	StopSequences []string `json:"stopSequences,omitempty"`
+}
+
+// handleConversationTemplatesList handles conversationTemplates/list request. A
+// template is used by naming it in the template input of claude_conversation.
+func (s *Server) handleConversationTemplatesList(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	templates := session.ListConversationTemplates()
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name().String() < templates[j].Name().String()
+	})
+
+	infos := make([]ConversationTemplateInfo, 0, len(templates))
+	for _, template := range templates {
+		infos = append(infos, conversationTemplateInfo(template))
+	}
+	return map[string]interface{}{"templates": infos}, nil
+}
+
+// conversationTemplateInfo converts a template, leaving unset sampling settings out
+func conversationTemplateInfo(template *entities.ConversationTemplate) ConversationTemplateInfo {
+	info := ConversationTemplateInfo{
+		Name:          template.Name().String(),
+		Description:   template.Description(),
+		Model:         template.Model().String(),
+		SystemPrompt:  template.SystemPrompt().String(),
+		Tools:         template.Tools(),
+		MaxTokens:     template.MaxTokens(),
+		StopSequences: template.StopSequences(),
+	}
+	if temperature, ok := template.Temperature(); ok {
+		info.Temperature = &temperature
+	}
+	if topP, ok := template.TopP(); ok {
+		info.TopP = &topP
+	}
+	if topK, ok := template.TopK(); ok {
+		info.TopK = &topK
+	}
+	return info
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// requestTimeout returns the deadline a client asked for in _meta.timeoutMs, capped by
+// the configured maximum. Requests without a positive timeout use the server's own limits.
+func (s *Server) requestTimeout(meta *entities.RequestMeta) (time.Duration, bool) {
+	if meta == nil || meta.TimeoutMs == nil || *meta.TimeoutMs <= 0 {
+		return 0, false
+	}
+
+	ms := *meta.TimeoutMs
+	if limit := s.config.Server.MaxRequestTimeout; limit > 0 && ms > float64(limit.Milliseconds()) {
+		return limit, true
+	}
+	if ms > float64(math.MaxInt64/int64(time.Millisecond)) {
+		return 0, false
+	}
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
+
+// requestTimeoutError reports that a request ran past its client-supplied deadline
+func requestTimeoutError(id interface{}, timeout time.Duration) *MCPError {
+	return &MCPError{
+		Code:      vo.ErrorCodeTimeout,
+		Message:   fmt.Sprintf("Request exceeded its deadline of %dms", timeout.Milliseconds()),
+		Data:      map[string]interface{}{"requestId": id, "timeoutMs": timeout.Milliseconds()},
+		Retryable: true,
+	}
+}
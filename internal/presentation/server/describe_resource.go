@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// DescribeResourceParams represents experimental/describeResource request parameters
+type DescribeResourceParams struct {
+	URI string `json:"uri"`
+}
+
+// handleDescribeResource handles the experimental/describeResource request. It returns
+// the resource as resources/list would, plus whether it is a template and whether the
+// calling session is subscribed to it.
+func (s *Server) handleDescribeResource(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p DescribeResourceParams
+	if err := json.Unmarshal(params, &p); err != nil || p.URI == "" {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: uri is required"}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	resource, ok := session.GetResource(p.URI)
+	if !ok {
+		return nil, &MCPError{Code: vo.ErrorCodeResourceNotFound, Message: "Resource not found"}
+	}
+
+	subscribed, err := s.isSubscribed(ctx, session, p.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	result := resource.ToMCPResource()
+	result["isTemplate"] = resource.IsTemplate()
+	result["subscribed"] = subscribed
+	return result, nil
+}
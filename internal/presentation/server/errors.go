@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/claude"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/middleware"
+)
+
+// RateLimitRetryAfter is the retry hint returned for rate-limited requests
+const RateLimitRetryAfter = time.Minute
+
+// ErrorData returns the JSON-RPC error data, including retry hints
+func (e *MCPError) ErrorData() map[string]interface{} {
+	data := map[string]interface{}{"retryable": e.Retryable}
+	if details, ok := e.Data.(map[string]interface{}); ok {
+		for key, value := range details {
+			data[key] = value
+		}
+	} else if e.Data != nil {
+		data["details"] = e.Data
+	}
+	if e.RetryAfter > 0 {
+		data["retryAfterSeconds"] = int(math.Ceil(e.RetryAfter.Seconds()))
+	}
+	return data
+}
+
+// AsMCPError converts an error into an MCPError, classifying whether it can be retried
+func AsMCPError(err error) *MCPError {
+	var mcpErr *MCPError
+	if errors.As(err, &mcpErr) {
+		return mcpErr
+	}
+
+	var rateLimited *services.RateLimitError
+	switch {
+	case errors.As(err, &rateLimited) && rateLimited.RetryAfter > 0:
+		return &MCPError{Code: vo.ErrorCodeRateLimited, Message: err.Error(), Retryable: true, RetryAfter: rateLimited.RetryAfter}
+	case errors.Is(err, middleware.ErrRateLimitExceeded), errors.Is(err, claude.ErrRateLimited), rateLimited != nil:
+		return &MCPError{Code: vo.ErrorCodeRateLimited, Message: err.Error(), Retryable: true, RetryAfter: RateLimitRetryAfter}
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, middleware.ErrRequestTimeout):
+		return &MCPError{Code: vo.ErrorCodeTimeout, Message: err.Error(), Retryable: true}
+	case errors.Is(err, context.Canceled):
+		return &MCPError{Code: vo.ErrorCodeCancelled, Message: err.Error()}
+	case errors.Is(err, handlers.ErrServerAtCapacity):
+		return &MCPError{Code: vo.ErrorCodeServerAtCapacity, Message: err.Error(), Retryable: true, RetryAfter: SessionCapacityRetryAfter}
+	}
+	if claudeErr, ok := services.AsClaudeError(err); ok {
+		return &MCPError{
+			Code:      vo.ErrorCodeInternalError,
+			Message:   err.Error(),
+			Data:      map[string]interface{}{"category": string(claudeErr.Category())},
+			Retryable: claudeErr.Retryable(),
+		}
+	}
+	return &MCPError{Code: vo.ErrorCodeInternalError, Message: err.Error()}
+}
+
+// toolCallError converts a tool execution error into an MCPError
+func toolCallError(name string, err error) *MCPError {
+	if errors.Is(err, handlers.ErrToolNotFound) {
+		return &MCPError{Code: vo.ErrorCodeToolNotFound, Message: fmt.Sprintf("Tool not found: %s", name), Data: map[string]interface{}{"tool": name}}
+	}
+	if errors.Is(err, handlers.ErrToolDisabled) {
+		return &MCPError{Code: vo.ErrorCodeToolDisabled, Message: fmt.Sprintf("Tool disabled: %s", name), Data: map[string]interface{}{"tool": name}}
+	}
+	if errors.Is(err, handlers.ErrToolSafeMode) {
+		return &MCPError{
+			Code:    vo.ErrorCodeToolDisabled,
+			Message: fmt.Sprintf("Tool unavailable in safe mode: %s is not read-only", name),
+			Data:    map[string]interface{}{"tool": name, "safeMode": true},
+		}
+	}
+	var throttled *handlers.ToolRateLimitError
+	if errors.As(err, &throttled) {
+		return &MCPError{Code: vo.ErrorCodeRateLimited, Message: err.Error(), Data: map[string]interface{}{"tool": name}, Retryable: true, RetryAfter: throttled.RetryAfter}
+	}
+	if errors.Is(err, handlers.ErrToolScopeRequired) {
+		return &MCPError{Code: vo.ErrorCodeUnauthorized, Message: fmt.Sprintf("Not authorized to call tool: %s", name), Data: map[string]interface{}{"tool": name}}
+	}
+
+	mcpErr := AsMCPError(err)
+	if mcpErr.Code == vo.ErrorCodeInternalError {
+		mcpErr.Code = vo.ErrorCodeToolExecutionError
+	}
+	return mcpErr
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/events"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/logging"
+)
+
+// EventsResourceURI is the URI of the recent domain events resource
+const EventsResourceURI = "status://events"
+
+// DefaultEventsLimit is the number of events status://events returns without a limit parameter
+const DefaultEventsLimit = 50
+
+// ErrEventsScopeRequiresAdmin is returned when all sessions' events are read without admin methods
+var ErrEventsScopeRequiresAdmin = errors.New("scope=all requires admin methods to be enabled")
+
+// SetEventRepository sets the domain events served by the status://events resource
+func (s *Server) SetEventRepository(repo repositories.IEventRepository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = repo
+}
+
+// eventsQuery holds the query parameters of a status://events read
+type eventsQuery struct {
+	eventType   string
+	limit       int
+	allSessions bool
+}
+
+// parseEventsQuery reads the type, limit and scope parameters of a status://events URI
+func parseEventsQuery(uri string) (eventsQuery, error) {
+	query := eventsQuery{limit: DefaultEventsLimit}
+	_, rawQuery, _ := strings.Cut(uri, "?")
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return query, fmt.Errorf("invalid query: %w", err)
+	}
+
+	query.eventType = values.Get("type")
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return query, fmt.Errorf("limit must be a positive integer")
+		}
+		query.limit = n
+	}
+	switch scope := values.Get("scope"); scope {
+	case "", "session":
+	case "all":
+		query.allSessions = true
+	default:
+		return query, fmt.Errorf("scope must be 'session' or 'all', got %q", scope)
+	}
+	return query, nil
+}
+
+// newEventsResource creates the resource listing the session's most recent domain
+// events. Admins may read every session's events with scope=all.
+func (s *Server) newEventsResource(session *aggregates.Session) (*entities.Resource, error) {
+	uri, err := vo.NewResourceURI(EventsResourceURI)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := entities.NewResource(uri, "Recent Events")
+	if err != nil {
+		return nil, err
+	}
+	resource.SetDescription("Most recent domain events of the current session, filterable with ?type=<event type>&limit=<n>")
+	mimeType, _ := vo.NewMimeType(vo.MimeTypeJSON)
+	resource.SetMimeType(mimeType)
+	resource.SetReader(func(uri string) (*entities.ResourceContent, error) {
+		query, err := parseEventsQuery(uri)
+		if err != nil {
+			return nil, err
+		}
+		if query.allSessions && !s.config.Server.EnableAdminMethods {
+			return nil, ErrEventsScopeRequiresAdmin
+		}
+
+		s.mu.RLock()
+		repo := s.events
+		s.mu.RUnlock()
+
+		stored, err := repo.FindAll(context.Background(), 0, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		sessionID := session.ID().String()
+		matched := make([]map[string]interface{}, 0)
+		for _, item := range stored {
+			event, ok := item.(events.DomainEvent)
+			if !ok {
+				continue
+			}
+			if query.eventType != "" && event.EventType() != query.eventType {
+				continue
+			}
+			if !query.allSessions && event.Payload()["sessionId"] != sessionID {
+				continue
+			}
+			matched = append(matched, eventToMap(event))
+		}
+		if len(matched) > query.limit {
+			matched = matched[len(matched)-query.limit:]
+		}
+
+		report := map[string]interface{}{"events": matched}
+		if !query.allSessions {
+			report["sessionId"] = sessionID
+		}
+		data, err := json.Marshal(report)
+		if err != nil {
+			return nil, err
+		}
+		return &entities.ResourceContent{
+			URI:      uri,
+			MimeType: vo.MimeTypeJSON,
+			Text:     string(data),
+		}, nil
+	})
+
+	return resource, nil
+}
+
+// eventToMap converts a domain event to its status://events form with sensitive payload fields redacted
+func eventToMap(event events.DomainEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            event.EventID(),
+		"type":          event.EventType(),
+		"aggregateId":   event.AggregateID(),
+		"aggregateType": event.AggregateType(),
+		"occurredAt":    event.OccurredAt().Format(time.RFC3339Nano),
+		"payload":       redactPayload(event.Payload()),
+	}
+}
+
+// redactPayload copies the payload, replacing the values of sensitive keys at any depth
+func redactPayload(payload map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(payload))
+	for key, value := range payload {
+		if logging.IsSensitiveField(key, logging.DefaultSensitiveFields) {
+			redacted[key] = config.RedactedValue
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = redactPayload(nested)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
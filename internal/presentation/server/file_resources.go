@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+)
+
+// FileResourceReader reads file:// resources for a session, subject to the paths and
+// client roots it may access. ResolveResourcePath applies the same checks for reads
+// the server streams itself.
+type FileResourceReader interface {
+	ResolveResource(ctx context.Context, sessionID vo.SessionID, uri string) (*entities.ResourceContent, error)
+	ResolveResourcePath(ctx context.Context, sessionID vo.SessionID, uri string) (string, error)
+}
+
+// SetFileResourceReader exposes files to sessions through the file:///{path} resource
+// template, read with reader
+func (s *Server) SetFileResourceReader(reader FileResourceReader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fileReader = reader
+}
+
+// newFileTemplateResource creates the session's file:///{path} template
+func newFileTemplateResource(session *aggregates.Session, reader FileResourceReader) (*entities.Resource, error) {
+	sessionID := session.ID()
+	return resources.NewFileTemplateResource(func(uri string) (*entities.ResourceContent, error) {
+		return reader.ResolveResource(context.Background(), sessionID, uri)
+	})
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+)
+
+// NotifyFileChanged tells the session that a watched file changed. The change is sent as
+// a notifications/message, honouring the session log level, and as
+// notifications/resources/updated when the client subscribed to the file's URI.
+func (s *Server) NotifyFileChanged(ctx context.Context, sessionID vo.SessionID, change tools.FileChange) {
+	// Resources backed by the file may have URIs other than its file:// URI
+	s.resourceCache.invalidatePath(change.Path)
+
+	conn := s.connectionForSession(sessionID)
+	if conn == nil {
+		return
+	}
+
+	if conn.Session().LogLevel().Severity() <= vo.LogLevelInfo.Severity() {
+		err := s.notifyConnection(conn, vo.MethodNotificationsMessage, map[string]interface{}{
+			"level":  vo.LogLevelInfo,
+			"logger": "watch_file",
+			"data": map[string]interface{}{
+				"event": "file_changed",
+				"path":  change.Path,
+				"uri":   change.URI,
+				"op":    change.Op,
+				"time":  change.Time,
+			},
+		})
+		if err != nil {
+			s.logger.Warn().Err(err).Str("path", change.Path).Msg("Failed to send file change notification")
+		}
+	}
+
+	s.resourceCache.invalidateURI(change.URI)
+	if err := s.notifyResourceUpdated(ctx, conn, change.URI); err != nil {
+		s.logger.Warn().Err(err).Str("uri", change.URI).Msg("Failed to send resource update notification")
+	}
+}
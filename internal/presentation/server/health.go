@@ -0,0 +1,106 @@
+// Package server contains the MCP server implementation
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+// HealthResourceURI is the URI of the built-in health resource
+const HealthResourceURI = "status://health"
+
+// Health statuses
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+)
+
+// HealthStatus represents the health of a single component
+type HealthStatus struct {
+	Status  string                 `json:"status"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// HealthCheck reports the health of a component
+type HealthCheck func() HealthStatus
+
+// RegisterHealthCheck registers a component health check reported by the health resource
+func (s *Server) RegisterHealthCheck(name string, check HealthCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.healthChecks == nil {
+		s.healthChecks = make(map[string]HealthCheck)
+	}
+	s.healthChecks[name] = check
+}
+
+// Health runs all registered health checks and returns the aggregated report
+func (s *Server) Health() map[string]interface{} {
+	s.mu.RLock()
+	names := make([]string, 0, len(s.healthChecks))
+	for name := range s.healthChecks {
+		names = append(names, name)
+	}
+	checks := make(map[string]HealthCheck, len(s.healthChecks))
+	for name, check := range s.healthChecks {
+		checks[name] = check
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(names)
+
+	overall := HealthStatusOK
+	components := make(map[string]HealthStatus, len(names))
+	for _, name := range names {
+		status := checks[name]()
+		if status.Status != HealthStatusOK {
+			overall = HealthStatusDegraded
+		}
+		components[name] = status
+	}
+
+	return map[string]interface{}{
+		"status":     overall,
+		"version":    s.config.Server.Version,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339),
+		"components": components,
+	}
+}
+
+// newHealthResource creates the health resource backed by the server's health checks.
+// Its name, description and annotations are those of the seeded resource.
+func (s *Server) newHealthResource() (*entities.Resource, error) {
+	defaults, err := persistence.DefaultResources()
+	if err != nil {
+		return nil, err
+	}
+	var resource *entities.Resource
+	for _, candidate := range defaults {
+		if !candidate.IsTemplate() && candidate.URI().String() == HealthResourceURI {
+			resource = candidate
+		}
+	}
+	if resource == nil {
+		return nil, fmt.Errorf("no built-in resource %s", HealthResourceURI)
+	}
+	resource.SetReader(func(uri string) (*entities.ResourceContent, error) {
+		data, err := json.Marshal(s.Health())
+		if err != nil {
+			return nil, err
+		}
+		return &entities.ResourceContent{
+			URI:      uri,
+			MimeType: vo.MimeTypeJSON,
+			Text:     string(data),
+		}, nil
+	})
+
+	return resource, nil
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// openListener opens the listener of the configured HTTP transport on server.host:server.port.
+// The caller holds s.mu.
+func (s *Server) openListener() (Listener, error) {
+	addr := net.JoinHostPort(s.config.Server.Host, strconv.Itoa(s.config.Server.Port))
+	readTimeout := s.config.Server.ReadTimeout
+
+	access := TransportAccess{
+		RequireAPIKey: s.config.Security.RequireAPIKey,
+		APIKeys:       s.config.Security.AllowedAPIKeys,
+		Keys:          s.apiKeys,
+	}
+	if s.config.Security.CORSEnabled {
+		access.AllowedOrigins = s.config.Security.CORSAllowedOrigins
+	}
+
+	if s.config.Server.Transport == "websocket" {
+		listener, err := ListenWebSocket(addr, readTimeout, access)
+		if err != nil {
+			return nil, err
+		}
+		s.logger.Info().Str("address", listener.Addr().String()).Msg("Listening for WebSocket connections")
+		return listener, nil
+	}
+
+	listener, err := ListenSSE(addr, readTimeout, access)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info().Str("address", listener.Addr().String()).Msg("Listening for SSE connections")
+	return listener, nil
+}
+
+// listenHTTP binds addr and serves handler on it in the background. Only the request
+// headers are bounded by readTimeout: the HTTP transports hold connections open for
+// as long as the client is connected, so no read or write timeout applies to them.
+func listenHTTP(addr string, handler http.Handler, readTimeout time.Duration) (*http.Server, net.Addr, error) {
+	netListener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	httpServer := &http.Server{Handler: handler, ReadHeaderTimeout: readTimeout}
+	go func() { _ = httpServer.Serve(netListener) }()
+	return httpServer, netListener.Addr(), nil
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// How an in-flight request was cancelled
+const (
+	cancelledByClient = "client"
+	cancelledByAdmin  = "admin"
+)
+
+// requestIDKey returns a comparable form of a JSON-RPC id, keeping the string "1"
+// and the number 1 distinct. Requests without an id have no key.
+func requestIDKey(id interface{}) (string, bool) {
+	if id == nil {
+		return "", false
+	}
+	data, err := json.Marshal(id)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// InflightRequest describes a request the server is still working on
+type InflightRequest struct {
+	ID        interface{} `json:"id"`
+	Method    string      `json:"method"`
+	SessionID string      `json:"sessionId,omitempty"`
+	StartedAt time.Time   `json:"startedAt"`
+	ElapsedMs int64       `json:"elapsedMs"`
+}
+
+// handleAdminListRequests handles admin/listRequests request
+func (s *Server) handleAdminListRequests(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if !s.config.Server.EnableAdminMethods {
+		return nil, &MCPError{Code: vo.ErrorCodeMethodNotFound, Message: "Method not found"}
+	}
+
+	now := time.Now()
+	requests := []InflightRequest{}
+	for _, op := range s.inflight.running() {
+		// Notifications cannot be cancelled and finish without a response
+		if op.id == nil {
+			continue
+		}
+		requests = append(requests, InflightRequest{
+			ID:        op.id,
+			Method:    op.method,
+			SessionID: op.sessionID,
+			StartedAt: op.started,
+			ElapsedMs: now.Sub(op.started).Milliseconds(),
+		})
+	}
+	return map[string]interface{}{"requests": requests}, nil
+}
+
+// CancelRequestParams represents admin/cancelRequest request parameters. Request ids
+// are only unique within a session, so SessionID picks the session the request
+// belongs to; it may be omitted when only one session has a request with the id.
+type CancelRequestParams struct {
+	ID        interface{} `json:"id"`
+	SessionID string      `json:"sessionId,omitempty"`
+}
+
+// handleAdminCancelRequest handles admin/cancelRequest request. The cancelled request
+// is answered with a cancelled error once its handler returns.
+func (s *Server) handleAdminCancelRequest(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if !s.config.Server.EnableAdminMethods {
+		return nil, &MCPError{Code: vo.ErrorCodeMethodNotFound, Message: "Method not found"}
+	}
+
+	var p CancelRequestParams
+	if err := json.Unmarshal(params, &p); err != nil || p.ID == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: id is required"}
+	}
+
+	in := func(op *inflightOp) bool { return p.SessionID == "" || op.sessionID == p.SessionID }
+	if p.SessionID == "" {
+		sessions := make(map[string]bool)
+		for _, op := range s.inflight.matching(p.ID, in) {
+			sessions[op.sessionID] = true
+		}
+		if len(sessions) > 1 {
+			return nil, &MCPError{
+				Code:    vo.ErrorCodeInvalidParams,
+				Message: fmt.Sprintf("Requests with id %v are running in %d sessions; give sessionId", p.ID, len(sessions)),
+				Data:    map[string]interface{}{"id": p.ID},
+			}
+		}
+	}
+
+	op, ok := s.inflight.cancel(p.ID, in, cancelledByAdmin)
+	if !ok {
+		return nil, &MCPError{
+			Code:    vo.ErrorCodeInvalidParams,
+			Message: fmt.Sprintf("No in-flight request with id %v", p.ID),
+			Data:    map[string]interface{}{"id": p.ID, "sessionId": p.SessionID},
+		}
+	}
+
+	s.logger.Info().
+		Str("method", op.method).
+		Interface("id", op.id).
+		Str("session_id", op.sessionID).
+		Dur("running", time.Since(op.started)).
+		Msg("In-flight request cancelled by administrator")
+
+	return map[string]interface{}{
+		"id":        op.id,
+		"sessionId": op.sessionID,
+		"method":    op.method,
+		"cancelled": true,
+	}, nil
+}
+
+// CancelledParams represents notifications/cancelled parameters
+type CancelledParams struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// handleCancelledNotification cancels the request the client gave up on. Only requests
+// sent over the same connection are considered, as other clients reuse the same ids.
+// Unknown or already finished requests are ignored, as the notification may race the
+// response.
+func (s *Server) handleCancelledNotification(ctx context.Context, params json.RawMessage) {
+	var p CancelledParams
+	if err := json.Unmarshal(params, &p); err != nil || p.RequestID == nil {
+		s.logger.Debug().Msg("Ignoring cancellation without a request id")
+		return
+	}
+
+	conn := connectionFromContext(ctx)
+	op, ok := s.inflight.cancel(p.RequestID, func(op *inflightOp) bool { return op.conn == conn }, cancelledByClient)
+	if !ok {
+		s.logger.Debug().Interface("id", p.RequestID).Msg("Cancellation for unknown or finished request")
+		return
+	}
+	s.logger.Debug().
+		Str("method", op.method).
+		Interface("id", op.id).
+		Str("reason", p.Reason).
+		Msg("Request cancelled")
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Policies for notification methods sent with an id
+const (
+	// NotificationIDPolicyRequest handles the notification and replies with an empty result
+	NotificationIDPolicyRequest = "request"
+	// NotificationIDPolicyError rejects the notification with an invalid request error
+	NotificationIDPolicyError = "error"
+	// NotificationIDPolicyIgnore handles the notification without replying, as the spec intends
+	NotificationIDPolicyIgnore = "ignore"
+)
+
+// handleNotificationWithID handles a notification method that carries an id, which
+// the spec forbids, according to the configured policy. Clients that send one may be
+// waiting for a reply, so by default they get one.
+func (s *Server) handleNotificationWithID(ctx context.Context, req *JSONRPCRequest, method vo.MCPMethod) *JSONRPCResponse {
+	policy := s.config.MCP.NotificationIDPolicy
+	s.logger.Warn().
+		Str("method", req.Method).
+		Interface("id", req.ID).
+		Str("policy", policy).
+		Msg("Protocol violation: notification sent with an id")
+
+	switch policy {
+	case NotificationIDPolicyError:
+		return s.createErrorResponse(req.ID, vo.ErrorCodeInvalidRequest,
+			fmt.Sprintf("Invalid Request: notification %s must not have an id", req.Method))
+	case NotificationIDPolicyIgnore:
+		s.handleNotification(ctx, method, req.Params)
+		return nil
+	default:
+		s.handleNotification(ctx, method, req.Params)
+		return &JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]interface{}{}}
+	}
+}
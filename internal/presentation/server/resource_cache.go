@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ResourceCacheMetrics records whether resource reads were served from the read cache
+type ResourceCacheMetrics interface {
+	RecordResourceRead(ctx context.Context, uri string, cacheHit bool)
+}
+
+// FileWatches reports the files a session watches for changes
+type FileWatches interface {
+	// WatchExpiry returns when the session's watch on path expires, and false if
+	// the path is not watched
+	WatchExpiry(sessionID vo.SessionID, path string) (time.Time, bool)
+}
+
+// SetFileWatches enables the resource read cache. Reads of a file-backed resource are
+// cached while the session is subscribed to it and watches its file, since every
+// change to the file is then detected and invalidates the cached content.
+func (s *Server) SetFileWatches(watches FileWatches) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fileWatches = watches
+}
+
+// SetResourceCacheMetrics sets the recorder for resource read cache hits and misses
+func (s *Server) SetResourceCacheMetrics(metrics ResourceCacheMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resourceCacheMetrics = metrics
+}
+
+// readResource reads uri from resource, serving it from the read cache when the
+// resource is known not to have changed since it was last read
+func (s *Server) readResource(ctx context.Context, session *aggregates.Session, resource *entities.Resource, uri string) (*entities.ResourceContent, error) {
+	s.mu.RLock()
+	watches := s.fileWatches
+	metrics := s.resourceCacheMetrics
+	s.mu.RUnlock()
+
+	var watchExpiry time.Time
+	cacheable := false
+	path := resource.FilePathFor(uri)
+	if path != "" {
+		path = filepath.Clean(path)
+	}
+	if watches != nil && path != "" {
+		watchExpiry, cacheable = watches.WatchExpiry(session.ID(), path)
+		if cacheable {
+			subscribed, err := s.isSubscribed(ctx, session, uri)
+			cacheable = err == nil && subscribed
+		}
+	}
+
+	if cacheable {
+		if content, ok := s.resourceCache.get(session.ID(), uri, watchExpiry); ok {
+			if metrics != nil {
+				metrics.RecordResourceRead(ctx, uri, true)
+			}
+			return content, nil
+		}
+	}
+
+	generation := s.resourceCache.generation()
+	content, err := resource.ReadURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if metrics != nil {
+		metrics.RecordResourceRead(ctx, uri, false)
+	}
+	if cacheable {
+		s.resourceCache.put(session.ID(), uri, path, watchExpiry, generation, content)
+	}
+	return content, nil
+}
+
+// resourceReadCache holds the content of resources read by each session. Entries are
+// dropped when a change to their URI or file is detected; an entry is only served
+// while the watch that was active when it was read is still the file's watch, so a
+// change made while the file was not watched is never hidden.
+type resourceReadCache struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]cachedResourceRead // Session ID -> URI -> content
+	// Bumped by every invalidation, so a read that raced with one is not cached
+	invalidations uint64
+}
+
+// cachedResourceRead is the content of a resource as last read
+type cachedResourceRead struct {
+	content     entities.ResourceContent
+	path        string
+	watchExpiry time.Time
+}
+
+func newResourceReadCache() *resourceReadCache {
+	return &resourceReadCache{sessions: make(map[string]map[string]cachedResourceRead)}
+}
+
+// get returns a copy of the cached content of uri read under the watch expiring at watchExpiry
+func (c *resourceReadCache) get(sessionID vo.SessionID, uri string, watchExpiry time.Time) (*entities.ResourceContent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := c.sessions[sessionID.String()]
+	entry, ok := entries[uri]
+	if !ok {
+		return nil, false
+	}
+	if !entry.watchExpiry.Equal(watchExpiry) {
+		delete(entries, uri)
+		return nil, false
+	}
+	content := entry.content
+	return &content, true
+}
+
+// generation returns the invalidation count to pass to put for a read starting now
+func (c *resourceReadCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.invalidations
+}
+
+// put caches content read from uri, unless an invalidation happened since generation
+func (c *resourceReadCache) put(sessionID vo.SessionID, uri, path string, watchExpiry time.Time, generation uint64, content *entities.ResourceContent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.invalidations != generation {
+		return
+	}
+	entries, ok := c.sessions[sessionID.String()]
+	if !ok {
+		entries = make(map[string]cachedResourceRead)
+		c.sessions[sessionID.String()] = entries
+	}
+	entries[uri] = cachedResourceRead{content: *content, path: path, watchExpiry: watchExpiry}
+}
+
+// dropSession forgets everything a session read
+func (c *resourceReadCache) dropSession(sessionID vo.SessionID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, sessionID.String())
+}
+
+// invalidateURI drops the cached content of uri
+func (c *resourceReadCache) invalidateURI(uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidations++
+	for _, entries := range c.sessions {
+		delete(entries, uri)
+	}
+}
+
+// invalidatePath drops the cached content of every resource backed by the file at path
+func (c *resourceReadCache) invalidatePath(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidations++
+	for _, entries := range c.sessions {
+		for uri, entry := range entries {
+			if entry.path == path {
+				delete(entries, uri)
+			}
+		}
+	}
+}
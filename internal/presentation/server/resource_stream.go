@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+)
+
+// resourceStreamChunk is the partial content carried by each progress notification of a
+// streamed resources/read. Chunks hold text, or a base64 blob where the bytes are not
+// valid UTF-8; concatenating their bytes in offset order gives the resource content.
+type resourceStreamChunk struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Offset   int64  `json:"offset"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// shouldStreamResource reports whether a resources/read of resource at uri should be
+// streamed, and from which file: the client asked for progress, the transport can
+// deliver a response as a sequence of messages, and the resource is a file larger than
+// the stream threshold. A template names a file per URI, which must pass the path and
+// root checks of fileReader; a read it refuses is left to the template's reader.
+func (s *Server) shouldStreamResource(ctx context.Context, session *aggregates.Session, resource *entities.Resource, fileReader FileResourceReader, uri string) (interface{}, string, bool) {
+	threshold := s.config.MCP.ResourceStreamThreshold
+	path := resource.FilePathFor(uri)
+	if threshold <= 0 || path == "" {
+		return nil, "", false
+	}
+	conn := connectionFromContext(ctx)
+	if conn == nil {
+		return nil, "", false
+	}
+	streaming, ok := conn.transport.(StreamingTransport)
+	if !ok || !streaming.SupportsStreaming() {
+		return nil, "", false
+	}
+	meta, ok := entities.RequestMetaFromContext(ctx)
+	if !ok || meta.ProgressToken == nil {
+		return nil, "", false
+	}
+	if resource.IsTemplate() {
+		if fileReader == nil {
+			return nil, "", false
+		}
+		checked, err := fileReader.ResolveResourcePath(ctx, session.ID(), uri)
+		if err != nil {
+			return nil, "", false
+		}
+		path = checked
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() <= threshold {
+		return nil, "", false
+	}
+	return meta.ProgressToken, path, true
+}
+
+// streamResource sends the file at path behind resource as notifications/progress
+// messages carrying its content in chunks, then returns the final resources/read result
+// for uri, which lists the resource without content
+func (s *Server) streamResource(ctx context.Context, resource *entities.Resource, path, uri string, progressToken interface{}, mimeFilter *resources.MimeTypeFilter) (interface{}, error) {
+	file, err := os.Open(path) //nolint:gosec // G304: the path is fixed by whoever registered the resource, or checked by the file reader
+	if err != nil {
+		return nil, &MCPError{Code: vo.ErrorCodeResourceReadError, Message: fmt.Sprintf("failed to open file: %v", err)}
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, &MCPError{Code: vo.ErrorCodeResourceReadError, Message: fmt.Sprintf("failed to stat file: %v", err)}
+	}
+	total := info.Size()
+
+	chunkSize := s.config.MCP.ResourceStreamChunkSize
+	buf := make([]byte, chunkSize)
+	var (
+		mimeType string
+		offset   int64
+		carry    int
+		chunks   int
+	)
+	for {
+		n, readErr := io.ReadFull(file, buf[carry:chunkSize])
+		n += carry
+		if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+			return nil, &MCPError{Code: vo.ErrorCodeResourceReadError, Message: fmt.Sprintf("failed to read file: %v", readErr)}
+		}
+		done := readErr != nil
+		if n == 0 {
+			break
+		}
+
+		// The first chunk decides the type and is checked against the MIME filter
+		if chunks == 0 {
+			mimeType = resources.SniffMimeType(path, resource.MimeType().String(), buf[:n])
+			if err := mimeFilter.Check(mimeType, buf[:n]); err != nil {
+				return nil, &MCPError{Code: vo.ErrorCodeResourceReadError, Message: err.Error()}
+			}
+		}
+
+		// Hold back a rune split across chunks so text chunks stay valid UTF-8
+		end := n
+		if !done {
+			if boundary := lastRuneBoundary(buf[:n]); boundary > 0 {
+				end = boundary
+			}
+		}
+		chunk := resourceStreamChunk{URI: uri, Offset: offset}
+		if chunks == 0 {
+			chunk.MimeType = mimeType
+		}
+		if utf8.Valid(buf[:end]) {
+			chunk.Text = string(buf[:end])
+		} else {
+			chunk.Blob = base64.StdEncoding.EncodeToString(buf[:end])
+		}
+		offset += int64(end)
+		chunks++
+
+		err := s.notify(ctx, vo.MethodNotificationsProgress, map[string]interface{}{
+			"progressToken": progressToken,
+			"progress":      offset,
+			"total":         total,
+			"content":       chunk,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		carry = copy(buf, buf[end:n])
+		if done && carry == 0 {
+			break
+		}
+	}
+
+	content := map[string]interface{}{"uri": uri}
+	if mimeType != "" {
+		content["mimeType"] = mimeType
+	}
+	if annotations := resource.Annotations(); annotations != nil {
+		content["annotations"] = annotations
+	}
+	return map[string]interface{}{
+		"contents": []interface{}{content},
+		"_meta": map[string]interface{}{
+			"streamed": true,
+			"chunks":   chunks,
+			"size":     offset,
+		},
+	}, nil
+}
+
+// lastRuneBoundary returns the length of data without a trailing incomplete UTF-8
+// sequence, or len(data) when the tail is not UTF-8 at all
+func lastRuneBoundary(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if utf8.FullRune(data[i:]) {
+			return len(data)
+		}
+		return i
+	}
+	return len(data)
+}
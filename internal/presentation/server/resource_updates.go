@@ -0,0 +1,93 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// resourceUpdateCoalescer batches resource updates per session and URI. The first
+// update of a session for a URI opens a window; further updates in the window only bump its count, and a single
+// notification is sent when the window closes. The window is not extended by later
+// updates, so a steady stream of changes still produces one notification per window
+// and the last change is always delivered.
+type resourceUpdateCoalescer struct {
+	window time.Duration
+	send   func(update pendingResourceUpdate)
+
+	mu      sync.Mutex
+	pending map[resourceUpdateKey]*pendingResourceUpdate
+}
+
+// resourceUpdateKey identifies the updates of one session to one resource
+type resourceUpdateKey struct {
+	sessionID string
+	uri       string
+}
+
+// pendingResourceUpdate is a resource update waiting for its window to close
+type pendingResourceUpdate struct {
+	uri       string
+	sessionID vo.SessionID
+	changes   int
+	timer     *time.Timer
+}
+
+func newResourceUpdateCoalescer(window time.Duration, send func(update pendingResourceUpdate)) *resourceUpdateCoalescer {
+	return &resourceUpdateCoalescer{
+		window:  window,
+		send:    send,
+		pending: make(map[resourceUpdateKey]*pendingResourceUpdate),
+	}
+}
+
+// key returns the key update is pending under
+func (u *pendingResourceUpdate) key() resourceUpdateKey {
+	return resourceUpdateKey{sessionID: u.sessionID.String(), uri: u.uri}
+}
+
+// add records an update to uri for a session, starting a window if none is open
+func (c *resourceUpdateCoalescer) add(sessionID vo.SessionID, uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := resourceUpdateKey{sessionID: sessionID.String(), uri: uri}
+	if update, ok := c.pending[key]; ok {
+		update.changes++
+		return
+	}
+
+	update := &pendingResourceUpdate{uri: uri, sessionID: sessionID, changes: 1}
+	update.timer = time.AfterFunc(c.window, func() { c.fire(update) })
+	c.pending[key] = update
+}
+
+// fire sends an update whose window closed, unless it was already flushed
+func (c *resourceUpdateCoalescer) fire(update *pendingResourceUpdate) {
+	c.mu.Lock()
+	if c.pending[update.key()] != update {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pending, update.key())
+	c.mu.Unlock()
+
+	c.send(*update)
+}
+
+// flush sends every pending update immediately
+func (c *resourceUpdateCoalescer) flush() {
+	c.mu.Lock()
+	updates := make([]*pendingResourceUpdate, 0, len(c.pending))
+	for _, update := range c.pending {
+		update.timer.Stop()
+		updates = append(updates, update)
+	}
+	c.pending = make(map[resourceUpdateKey]*pendingResourceUpdate)
+	c.mu.Unlock()
+
+	for _, update := range updates {
+		c.send(*update)
+	}
+}
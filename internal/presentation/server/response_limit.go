@@ -0,0 +1,21 @@
+package server
+
+import (
+	"fmt"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// responseTooLarge creates the error sent in place of a response of size bytes that
+// exceeds the configured limit
+func (s *Server) responseTooLarge(id interface{}, size, limit int) *JSONRPCResponse {
+	return s.createMCPErrorResponse(id, &MCPError{
+		Code: vo.ErrorCodeResponseTooLarge,
+		Message: fmt.Sprintf("Response too large: %d bytes exceeds the %d byte limit; request less data at once, e.g. with pagination or an offset",
+			size, limit),
+		Data: map[string]interface{}{
+			"responseBytes":    size,
+			"maxResponseBytes": limit,
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// rootsListResult is the client's answer to roots/list
+type rootsListResult struct {
+	Roots []entities.Root `json:"roots"`
+}
+
+// clientSupportsRoots reports whether the initialize capabilities include roots
+func clientSupportsRoots(capabilities map[string]interface{}) bool {
+	_, ok := capabilities[string(vo.CapabilityRoots)]
+	return ok
+}
+
+// refreshRoots fetches the client's roots in the background and stores them on the
+// session, where later tool calls pick them up. When refreshes overlap, only the
+// latest one is applied. A failed refresh leaves the session without roots, so file
+// tools are denied rather than falling back to the configured allowed paths.
+func (s *Server) refreshRoots(ctx context.Context, conn *connection, session *aggregates.Session) {
+	seq := atomic.AddUint64(&conn.rootsRequested, 1)
+
+	// The answer comes through the read loop, so the request must not block it
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		roots, err := s.listRoots(ctx)
+
+		conn.mu.Lock()
+		defer conn.mu.Unlock()
+		if seq < conn.rootsApplied {
+			return
+		}
+		conn.rootsApplied = seq
+		if err != nil {
+			session.SetRoots(nil)
+			s.logger.Warn().Err(err).Str("session_id", session.ID().String()).Msg("Failed to refresh roots, denying file access")
+			return
+		}
+		session.SetRoots(roots)
+		s.logger.Info().
+			Str("session_id", session.ID().String()).
+			Int("roots", len(roots)).
+			Msg("Client roots updated")
+	}()
+}
+
+// listRoots asks the client for its roots
+func (s *Server) listRoots(ctx context.Context) ([]entities.Root, error) {
+	data, err := s.requestClient(ctx, vo.MethodRootsList, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result rootsListResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid roots/list result: %w", err)
+	}
+	for _, root := range result.Roots {
+		if root.URI == "" {
+			return nil, fmt.Errorf("invalid roots/list result: root without a URI")
+		}
+	}
+	return result.Roots, nil
+}
+
+// handleRootsListChanged re-fetches the roots of the connection's session
+func (s *Server) handleRootsListChanged(ctx context.Context) {
+	session := s.sessionFor(ctx)
+	if session == nil {
+		s.logger.Warn().Msg("Received roots list_changed notification before initialize")
+		return
+	}
+	s.refreshRoots(ctx, connectionFromContext(ctx), session)
+}
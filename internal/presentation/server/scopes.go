@@ -0,0 +1,34 @@
+package server
+
+// SetLocalScopes sets the scopes held by the client of a server serving a single
+// transport, such as stdio, whose client runs as the local user
+func (s *Server) SetLocalScopes(scopes []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.localScopes = scopes
+}
+
+// SetAPIKeyStore sets the issued API keys clients of the SSE and WebSocket transports
+// may connect with. A connection made with one of them holds the key's scopes.
+func (s *Server) SetAPIKeyStore(store APIKeyStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKeys = store
+}
+
+// transportScopes returns the scopes granted to the client of an accepted transport
+func transportScopes(transport Transport) []string {
+	if authenticated, ok := transport.(AuthenticatedTransport); ok {
+		return authenticated.Scopes()
+	}
+	return nil
+}
+
+// sessionScopes returns the scopes a session initialized over conn holds. Scopes
+// unlock admin-only tools, so none are granted unless admin methods are enabled.
+func (s *Server) sessionScopes(conn *connection) []string {
+	if !s.config.Server.EnableAdminMethods {
+		return nil
+	}
+	return conn.scopes
+}
@@ -2,14 +2,16 @@
 package server
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -17,8 +19,12 @@ import (
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
 	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
 )
 
 // Server errors
@@ -28,6 +34,9 @@ var (
 	ErrSessionRequired  = errors.New("session required")
 )
 
+// SessionCapacityRetryAfter is the retry hint returned when the session limit is reached
+const SessionCapacityRetryAfter = 30 * time.Second
+
 // Server represents the MCP server
 type Server struct {
 	config *config.Config
@@ -37,16 +46,64 @@ type Server struct {
 	sessionHandler      *handlers.SessionHandler
 	toolHandler         *handlers.ToolHandler
 	conversationHandler *handlers.ConversationHandler
+	resourceHandler     *handlers.ResourceHandler
+
+	// Prompts offered to every session
+	prompts []*entities.Prompt
+
+	// Conversation templates offered to every session
+	templates []*entities.ConversationTemplate
+
+	// MIME types resources/read may return (nil allows everything)
+	mimeFilter *resources.MimeTypeFilter
+
+	// Domain events served by the status://events resource (nil leaves it out)
+	events repositories.IEventRepository
+
+	// State: the open connections, each with its own session
+	mu           sync.RWMutex
+	sessions     *sessionManager
+	running      bool
+	done         chan struct{}
+	healthChecks map[string]HealthCheck
+
+	// Shutdown: accepted requests, the cancel func of their context and the outcome
+	inflight        *inflightTracker
+	workCancel      context.CancelFunc
+	shutdownMetrics ShutdownMetrics
+	shutdownOnce    sync.Once
+	shutdownDone    chan struct{}
+	shutdownErr     error
+
+	// Request concurrency
+	pool    *requestPool
+	metrics RequestMetrics
 
-	// State
-	mu             sync.RWMutex
-	currentSession *aggregates.Session
-	running        bool
-	done           chan struct{}
+	// Capability snapshots by fingerprint, for experimental/capabilitiesDiff
+	capabilities *capabilityHistory
 
-	// I/O
-	reader io.Reader
-	writer io.Writer
+	// Coalesces notifications/resources/updated per URI (nil sends them immediately)
+	resourceUpdates *resourceUpdateCoalescer
+
+	// Reads file:// resources for the file:///{path} template (nil leaves it out)
+	fileReader FileResourceReader
+
+	// Task queue results for admin/taskResult (nil when the queue is disabled)
+	taskResults TaskResults
+
+	// Scopes of the local client of a single transport, and the issued API keys that
+	// grant scopes to clients of a listener
+	localScopes []string
+	apiKeys     APIKeyStore
+
+	// Resource read cache, used for watched files once fileWatches is set
+	resourceCache        *resourceReadCache
+	fileWatches          FileWatches
+	resourceCacheMetrics ResourceCacheMetrics
+
+	// I/O: a single connection, or a listener accepting many
+	transport Transport
+	listener  Listener
 }
 
 // NewServer creates a new MCP server
@@ -57,22 +114,71 @@ func NewServer(
 	toolHandler *handlers.ToolHandler,
 	conversationHandler *handlers.ConversationHandler,
 ) *Server {
-	return &Server{
+	s := &Server{
 		config:              cfg,
 		logger:              logger.With().Str("component", "mcp-server").Logger(),
 		sessionHandler:      sessionHandler,
 		toolHandler:         toolHandler,
 		conversationHandler: conversationHandler,
+		sessions:            newSessionManager(),
 		done:                make(chan struct{}),
-		reader:              os.Stdin,
-		writer:              os.Stdout,
+		inflight:            newInflightTracker(),
+		shutdownDone:        make(chan struct{}),
+		capabilities:        newCapabilityHistory(maxCapabilitySnapshots),
+		resourceCache:       newResourceReadCache(),
+	}
+	if cfg.Server.MaxConcurrentRequests > 1 {
+		s.pool = newRequestPool(cfg.Server.MaxConcurrentRequests, cfg.Server.RequestQueueDepth)
+	}
+	if cfg.MCP.ResourceUpdateDebounce > 0 {
+		s.resourceUpdates = newResourceUpdateCoalescer(cfg.MCP.ResourceUpdateDebounce, s.sendResourceUpdated)
 	}
+	if path := cfg.MCP.CapabilityHistoryFile; path != "" {
+		if err := s.capabilities.load(path); err != nil {
+			s.logger.Warn().Err(err).Str("path", path).Msg("Failed to load capability history, clients will resync")
+		}
+	}
+	return s
 }
 
-// SetIO sets custom I/O for the server (useful for testing)
+// SetIO serves the stdio transport over custom I/O (useful for testing)
 func (s *Server) SetIO(reader io.Reader, writer io.Writer) {
-	s.reader = reader
-	s.writer = writer
+	s.SetTransport(NewStdioTransport(reader, writer))
+}
+
+// SetTransport sets the transport used by Run, overriding the configured transport
+func (s *Server) SetTransport(transport Transport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transport = transport
+}
+
+// SetListener sets the listener Run accepts connections from, overriding the configured transport
+func (s *Server) SetListener(listener Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listener = listener
+}
+
+// SetPrompts sets the prompts registered with each new session
+func (s *Server) SetPrompts(prompts []*entities.Prompt) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prompts = prompts
+}
+
+// SetConversationTemplates sets the conversation templates registered with each new session
+func (s *Server) SetConversationTemplates(templates []*entities.ConversationTemplate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates = templates
+}
+
+// SetMimeTypeFilter sets the filter applied to resources/read content
+func (s *Server) SetMimeTypeFilter(filter *resources.MimeTypeFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mimeFilter = filter
 }
 
 // Run starts the MCP server
@@ -83,6 +189,24 @@ func (s *Server) Run(ctx context.Context) error {
 		return errors.New("server already running")
 	}
 	s.running = true
+	if s.transport == nil && s.listener == nil {
+		switch s.config.Server.Transport {
+		case "stdio":
+			s.transport = NewStdioTransport(os.Stdin, os.Stdout)
+		case "sse", "websocket":
+			listener, err := s.openListener()
+			if err != nil {
+				s.running = false
+				s.mu.Unlock()
+				return err
+			}
+			s.listener = listener
+		default:
+			s.mu.Unlock()
+			return ErrInvalidTransport
+		}
+	}
+	transport, listener := s.transport, s.listener
 	s.mu.Unlock()
 
 	s.logger.Info().
@@ -90,64 +214,99 @@ func (s *Server) Run(ctx context.Context) error {
 		Str("version", s.config.Server.Version).
 		Msg("Starting MCP server")
 
-	switch s.config.Server.Transport {
-	case "stdio":
-		return s.runStdio(ctx)
-	default:
-		return ErrInvalidTransport
+	// Serve in the background so a completed Shutdown ends Run even while a read or handler is stuck
+	served := make(chan error, 1)
+	go func() { served <- s.serve(ctx, transport, listener) }()
+
+	select {
+	case err := <-served:
+		return err
+	case <-s.shutdownDone:
+		if s.shutdownErr != nil {
+			return s.shutdownErr
+		}
+		return ErrServerClosed
 	}
 }
 
-// Stop stops the server
+// Stop notifies the client of the shutdown and stops the server
 func (s *Server) Stop() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.mu.Unlock()
 
-	if s.running {
-		s.running = false
-		close(s.done)
+	if s.resourceUpdates != nil {
+		s.resourceUpdates.flush()
 	}
+	s.notifyShutdown(s.openConnections())
+	close(s.done)
 }
 
-// runStdio runs the server using stdio transport
-func (s *Server) runStdio(ctx context.Context) error {
-	scanner := bufio.NewScanner(s.reader)
-	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024) // 10MB max message size
+// serve handles the single transport, or the connections accepted by the listener,
+// until they close or the server stops
+func (s *Server) serve(ctx context.Context, transport Transport, listener Listener) error {
+	// Requests run under a context that a forced shutdown cancels
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.mu.Lock()
+	s.workCancel = cancel
+	s.mu.Unlock()
 
-	for {
+	if listener != nil {
+		return s.acceptConnections(ctx, listener)
+	}
+	s.mu.RLock()
+	scopes := s.localScopes
+	s.mu.RUnlock()
+	return s.serveConnection(ctx, newConnection(transport, scopes))
+}
+
+// acceptConnections serves each client connecting through listener on its own
+// connection. Once the server stops, in-flight requests are drained before the
+// listener, and every connection with it, is closed.
+func (s *Server) acceptConnections(ctx context.Context, listener Listener) error {
+	acceptCtx, stopAccepting := context.WithCancel(ctx)
+	defer stopAccepting()
+	go func() {
 		select {
-		case <-ctx.Done():
-			return ctx.Err()
 		case <-s.done:
-			return ErrServerClosed
-		default:
-			if !scanner.Scan() {
-				if err := scanner.Err(); err != nil {
-					s.logger.Error().Err(err).Msg("Scanner error")
-					return err
-				}
-				return io.EOF
-			}
-
-			line := scanner.Text()
-			if line == "" {
-				continue
-			}
+			stopAccepting()
+		case <-acceptCtx.Done():
+		}
+	}()
 
-			s.logger.Debug().Str("request", line).Msg("Received request")
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer listener.Close()
 
-			response, err := s.handleRequest(ctx, []byte(line))
-			if err != nil {
-				s.logger.Error().Err(err).Msg("Error handling request")
-				response = s.createErrorResponse(nil, vo.ErrorCodeInternalError, err.Error())
+	for {
+		transport, err := listener.Accept(acceptCtx)
+		if err != nil {
+			select {
+			case <-s.done:
+				s.inflight.wait(ctx)
+				return ErrServerClosed
+			default:
 			}
-
-			if response != nil {
-				if err := s.sendResponse(response); err != nil {
-					s.logger.Error().Err(err).Msg("Error sending response")
-				}
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
+			return err
 		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn := newConnection(transport, transportScopes(transport))
+			err := s.serveConnection(ctx, conn)
+			s.logger.Debug().AnErr("reason", err).Msg("Connection closed")
+			// Sessions are not resumed over another connection, so the session ends with it
+			s.closeSession(conn.Session())
+		}()
 	}
 }
 
@@ -185,27 +344,60 @@ func (s *Server) handleRequest(ctx context.Context, data []byte) (*JSONRPCRespon
 		return s.createErrorResponse(req.ID, vo.ErrorCodeInvalidRequest, "Invalid JSON-RPC version"), nil
 	}
 
-	s.logger.Debug().
+	// Parse _meta once so handlers read progress tokens, deadlines and correlation IDs
+	// from the context instead of re-decoding the params
+	meta := entities.ParseRequestMeta(req.Params)
+	if meta != nil {
+		ctx = entities.ContextWithRequestMeta(ctx, meta)
+	}
+
+	// Attribute logs, metrics and spans to the client once the session is initialized
+	ctx = s.contextWithClientInfo(ctx)
+
+	logEvent := s.logger.Debug().
 		Str("method", req.Method).
-		Interface("id", req.ID).
-		Msg("Processing request")
+		Interface("id", req.ID)
+	if client, ok := telemetry.ClientInfoFromContext(ctx); ok {
+		logEvent = logEvent.Str("client_name", client.Name).Str("client_version", client.Version)
+	}
+	if meta != nil && meta.CorrelationID != "" {
+		logEvent = logEvent.Str("correlation_id", meta.CorrelationID)
+	}
+	logEvent.Msg("Processing request")
 
 	// Route to appropriate handler
 	method := vo.MCPMethod(req.Method)
 
 	// Handle notifications (no response expected)
 	if method.IsNotification() {
+		if req.ID != nil {
+			return s.handleNotificationWithID(ctx, &req, method), nil
+		}
 		s.handleNotification(ctx, method, req.Params)
 		return nil, nil
 	}
 
-	// Handle regular methods
+	// Reject calls that arrive before the session is ready
+	if err := s.checkSessionReady(ctx, method); err != nil {
+		return s.createMCPErrorResponse(req.ID, err), nil
+	}
+
+	// Bound the request by the deadline the client asked for, if any
+	timeout, hasDeadline := s.requestTimeout(meta)
+	if hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Handle regular methods. A result that completed as the deadline passed is still
+	// returned; only a failure is reported as the deadline being exceeded.
 	result, err := s.dispatchMethod(ctx, method, req.Params)
 	if err != nil {
-		if mcpErr, ok := err.(*MCPError); ok {
-			return s.createErrorResponse(req.ID, mcpErr.Code, mcpErr.Message), nil
+		if hasDeadline && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return s.createMCPErrorResponse(req.ID, requestTimeoutError(req.ID, timeout)), nil
 		}
-		return s.createErrorResponse(req.ID, vo.ErrorCodeInternalError, err.Error()), nil
+		return s.createMCPErrorResponse(req.ID, AsMCPError(err)), nil
 	}
 
 	return &JSONRPCResponse{
@@ -215,31 +407,74 @@ func (s *Server) handleRequest(ctx context.Context, data []byte) (*JSONRPCRespon
 	}, nil
 }
 
+// contextWithClientInfo returns ctx carrying the client info of the connection's session,
+// or ctx unchanged before the session is initialized
+func (s *Server) contextWithClientInfo(ctx context.Context) context.Context {
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return ctx
+	}
+	client := session.ClientInfo()
+	if client == nil || client.Name == "" {
+		return ctx
+	}
+	return telemetry.ContextWithClientInfo(ctx, telemetry.ClientInfo{Name: client.Name, Version: client.Version})
+}
+
 // MCPError represents an MCP-specific error
 type MCPError struct {
 	Code    vo.MCPErrorCode
 	Message string
+	Data    interface{}
+
+	// Retry hints surfaced to clients in the JSON-RPC error data
+	Retryable  bool
+	RetryAfter time.Duration
 }
 
 func (e *MCPError) Error() string {
 	return e.Message
 }
 
+// checkSessionReady ensures the session has completed the initialization handshake.
+// initialize and ping are always allowed; unknown methods fall through to dispatch.
+func (s *Server) checkSessionReady(ctx context.Context, method vo.MCPMethod) *MCPError {
+	if method == vo.MethodInitialize || method == vo.MethodPing || !method.IsValid() {
+		return nil
+	}
+
+	session := s.sessionFor(ctx)
+
+	if session == nil {
+		return &MCPError{Code: vo.ErrorCodeInvalidRequest, Message: "Session not initialized"}
+	}
+	if !session.IsReady() {
+		return &MCPError{Code: vo.ErrorCodeInvalidRequest, Message: "Session not ready: client must send notifications/initialized"}
+	}
+	return nil
+}
+
 // dispatchMethod dispatches a method to the appropriate handler
 func (s *Server) dispatchMethod(ctx context.Context, method vo.MCPMethod, params json.RawMessage) (interface{}, error) {
 	switch method {
 	case vo.MethodInitialize:
 		return s.handleInitialize(ctx, params)
 	case vo.MethodPing:
-		return s.handlePing(ctx)
+		return s.handlePing(ctx, params)
 	case vo.MethodToolsList:
 		return s.handleToolsList(ctx, params)
 	case vo.MethodToolsCall:
 		return s.handleToolsCall(ctx, params)
+	case vo.MethodToolsCallBatch:
+		return s.handleToolsCallBatch(ctx, params)
 	case vo.MethodResourcesList:
 		return s.handleResourcesList(ctx, params)
 	case vo.MethodResourcesRead:
 		return s.handleResourcesRead(ctx, params)
+	case vo.MethodResourcesSubscribe:
+		return s.handleResourcesSubscribe(ctx, params)
+	case vo.MethodResourcesUnsubscribe:
+		return s.handleResourcesUnsubscribe(ctx, params)
 	case vo.MethodPromptsList:
 		return s.handlePromptsList(ctx, params)
 	case vo.MethodPromptsGet:
@@ -248,6 +483,28 @@ func (s *Server) dispatchMethod(ctx context.Context, method vo.MCPMethod, params
 		return s.handleLoggingSetLevel(ctx, params)
 	case vo.MethodCompletionComplete:
 		return s.handleCompletionComplete(ctx, params)
+	case vo.MethodExperimentalDescribeTool:
+		return s.handleDescribeTool(ctx, params)
+	case vo.MethodExperimentalDescribeResource:
+		return s.handleDescribeResource(ctx, params)
+	case vo.MethodExperimentalCapabilitiesDiff:
+		return s.handleCapabilitiesDiff(ctx, params)
+	case vo.MethodConversationTemplatesList:
+		return s.handleConversationTemplatesList(ctx, params)
+	case vo.MethodSessionReset:
+		return s.handleSessionReset(ctx, params)
+	case vo.MethodSessionMetrics:
+		return s.handleSessionMetrics(ctx, params)
+	case vo.MethodAdminSetToolEnabled:
+		return s.handleAdminSetToolEnabled(ctx, params)
+	case vo.MethodAdminListRequests:
+		return s.handleAdminListRequests(ctx, params)
+	case vo.MethodAdminCancelRequest:
+		return s.handleAdminCancelRequest(ctx, params)
+	case vo.MethodAdminTaskResult:
+		return s.handleAdminTaskResult(ctx, params)
+	case vo.MethodToolsUpdate:
+		return s.handleToolsUpdate(ctx, params)
 	default:
 		return nil, &MCPError{Code: vo.ErrorCodeMethodNotFound, Message: "Method not found"}
 	}
@@ -257,14 +514,37 @@ func (s *Server) dispatchMethod(ctx context.Context, method vo.MCPMethod, params
 func (s *Server) handleNotification(ctx context.Context, method vo.MCPMethod, params json.RawMessage) {
 	switch method {
 	case vo.MethodInitialized:
-		s.logger.Info().Msg("Client initialized")
+		s.handleInitialized(ctx)
 	case vo.MethodNotificationsCancelled:
-		s.logger.Debug().Msg("Request cancelled")
+		s.handleCancelledNotification(ctx, params)
+	case vo.MethodNotificationsRootsListChanged:
+		s.handleRootsListChanged(ctx)
 	default:
 		s.logger.Debug().Str("method", method.String()).Msg("Unknown notification")
 	}
 }
 
+// handleInitialized transitions the connection's session to ready
+func (s *Server) handleInitialized(ctx context.Context) {
+	session := s.sessionFor(ctx)
+	if session == nil {
+		s.logger.Warn().Msg("Received initialized notification before initialize")
+		return
+	}
+
+	cmd := &commands.MarkSessionReadyCommand{SessionID: session.ID()}
+	if err := s.sessionHandler.HandleMarkSessionReady(ctx, cmd); err != nil {
+		s.logger.Warn().Err(err).Str("session_id", session.ID().String()).Msg("Failed to mark session ready")
+		return
+	}
+
+	s.logger.Info().Str("session_id", session.ID().String()).Msg("Client initialized")
+
+	if conn := connectionFromContext(ctx); conn.supportsRoots() {
+		s.refreshRoots(ctx, conn, session)
+	}
+}
+
 // InitializeParams represents initialize request parameters
 type InitializeParams struct {
 	ProtocolVersion string                 `json:"protocolVersion"`
@@ -285,21 +565,53 @@ func (s *Server) handleInitialize(ctx context.Context, params json.RawMessage) (
 		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params"}
 	}
 
+	conn := connectionFromContext(ctx)
+	if conn == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Initialize outside of a connection"}
+	}
+
 	cmd := &commands.InitializeSessionCommand{
 		ClientName:      p.ClientInfo.Name,
 		ClientVersion:   p.ClientInfo.Version,
 		ProtocolVersion: p.ProtocolVersion,
 		Capabilities:    p.Capabilities,
+		Scopes:          s.sessionScopes(conn),
 	}
 
 	session, err := s.sessionHandler.HandleInitializeSession(ctx, cmd)
+	if errors.Is(err, handlers.ErrServerAtCapacity) {
+		s.logger.Warn().Int("max_sessions", s.sessionHandler.MaxSessions()).Msg("Rejected session: server at capacity")
+		return nil, &MCPError{
+			Code:       vo.ErrorCodeServerAtCapacity,
+			Message:    fmt.Sprintf("Server at capacity: maximum of %d sessions reached, retry later", s.sessionHandler.MaxSessions()),
+			Data:       map[string]interface{}{"maxSessions": s.sessionHandler.MaxSessions()},
+			Retryable:  true,
+			RetryAfter: SessionCapacityRetryAfter,
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	s.mu.Lock()
-	s.currentSession = session
-	s.mu.Unlock()
+	s.seedSession(session)
+
+	// File tools of a client with roots stay denied until it has listed them
+	clientRoots := clientSupportsRoots(p.Capabilities)
+	if clientRoots {
+		session.SetRoots(nil)
+	}
+
+	// A client initializing again starts over: its previous session is closed
+	s.closeSession(s.sessions.bind(conn, session, clientRoots))
+
+	// Advertise the fingerprint clients pass to experimental/capabilitiesDiff on reconnect
+	fingerprint, err := s.recordCapabilities(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	session.SetExperimentalCapability(capabilitiesDiffCapability, map[string]interface{}{
+		"fingerprint": fingerprint,
+	})
 
 	s.logger.Info().
 		Str("session_id", session.ID().String()).
@@ -309,17 +621,83 @@ func (s *Server) handleInitialize(ctx context.Context, params json.RawMessage) (
 	return session.ToInitializeResult(), nil
 }
 
-// handlePing handles the ping request
-func (s *Server) handlePing(ctx context.Context) (interface{}, error) {
-	return map[string]interface{}{}, nil
+// seedSession registers the default resources, prompts and conversation templates
+// every session starts with
+func (s *Server) seedSession(session *aggregates.Session) {
+	// Expose the health and tool usage resources to the session
+	if healthResource, err := s.newHealthResource(); err == nil {
+		session.RegisterResource(healthResource)
+	}
+	if usageResource, err := newToolUsageResource(session); err == nil {
+		session.RegisterResource(usageResource)
+	}
+	if s.config.Server.ExposeConfig {
+		if configResource, err := s.newConfigResource(); err == nil {
+			session.RegisterResource(configResource)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.events != nil {
+		if eventsResource, err := s.newEventsResource(session); err == nil {
+			session.RegisterResource(eventsResource)
+		}
+	}
+	if s.fileReader != nil {
+		if fileResource, err := newFileTemplateResource(session, s.fileReader); err == nil {
+			session.RegisterResource(fileResource)
+		}
+	}
+	if s.config.MCP.EnablePrompts {
+		for _, prompt := range s.prompts {
+			session.RegisterPrompt(prompt)
+		}
+	}
+	for _, template := range s.templates {
+		session.RegisterConversationTemplate(template)
+	}
+}
+
+// PingParams represents optional ping parameters used for latency diagnostics
+type PingParams struct {
+	Nonce      string          `json:"nonce,omitempty"`
+	ClientTime json.RawMessage `json:"clientTime,omitempty"`
+}
+
+// PingResult echoes ping parameters along with the time the server received the ping
+type PingResult struct {
+	Nonce      string          `json:"nonce,omitempty"`
+	ClientTime json.RawMessage `json:"clientTime,omitempty"`
+	ServerTime string          `json:"serverTime"`
+}
+
+// handlePing handles the ping request. A ping without a nonce or client time
+// returns an empty result, as required by the spec.
+func (s *Server) handlePing(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	receivedAt := time.Now().UTC()
+
+	var p PingParams
+	if len(params) > 0 && string(params) != "null" {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params"}
+		}
+	}
+
+	if p.Nonce == "" && len(p.ClientTime) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	return &PingResult{
+		Nonce:      p.Nonce,
+		ClientTime: p.ClientTime,
+		ServerTime: receivedAt.Format(time.RFC3339Nano),
+	}, nil
 }
 
 // handleToolsList handles tools/list request
 func (s *Server) handleToolsList(ctx context.Context, params json.RawMessage) (interface{}, error) {
-	s.mu.RLock()
-	session := s.currentSession
-	s.mu.RUnlock()
-
+	session := s.sessionFor(ctx)
 	if session == nil {
 		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
 	}
@@ -350,10 +728,7 @@ func (s *Server) handleToolsCall(ctx context.Context, params json.RawMessage) (i
 		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params"}
 	}
 
-	s.mu.RLock()
-	session := s.currentSession
-	s.mu.RUnlock()
-
+	session := s.sessionFor(ctx)
 	if session == nil {
 		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
 	}
@@ -364,20 +739,83 @@ func (s *Server) handleToolsCall(ctx context.Context, params json.RawMessage) (i
 		Arguments: p.Arguments,
 	}
 
-	result, err := s.toolHandler.HandleExecuteTool(ctx, cmd)
+	result, err := s.toolHandler.HandleExecuteTool(s.withToolOutputProgress(ctx), cmd)
 	if err != nil {
-		return nil, &MCPError{Code: vo.ErrorCodeToolExecutionError, Message: err.Error()}
+		return nil, toolCallError(p.Name, err)
 	}
 
 	return result, nil
 }
 
+// withToolOutputProgress returns a context whose tools stream partial output to the
+// client as notifications/progress messages, when the client sent a progress token.
+// Each message carries a chunk and the number of bytes streamed so far.
+func (s *Server) withToolOutputProgress(ctx context.Context) context.Context {
+	meta, ok := entities.RequestMetaFromContext(ctx)
+	if !ok || meta.ProgressToken == nil {
+		return ctx
+	}
+
+	var (
+		mu       sync.Mutex
+		streamed int
+	)
+	return entities.ContextWithToolOutput(ctx, func(chunk string) {
+		mu.Lock()
+		defer mu.Unlock()
+		streamed += len(chunk)
+		err := s.notify(ctx, vo.MethodNotificationsProgress, map[string]interface{}{
+			"progressToken": meta.ProgressToken,
+			"progress":      streamed,
+			"message":       chunk,
+		})
+		if err != nil {
+			s.logger.Debug().Err(err).Msg("Failed to send tool output progress")
+		}
+	})
+}
+
+// DescribeToolParams represents experimental/describeTool request parameters
+type DescribeToolParams struct {
+	Name string `json:"name"`
+}
+
+// handleDescribeTool handles experimental/describeTool request
+func (s *Server) handleDescribeTool(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p DescribeToolParams
+	if err := json.Unmarshal(params, &p); err != nil || p.Name == "" {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params"}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	query := &queries.GetToolQuery{
+		SessionID: session.ID(),
+		Name:      p.Name,
+	}
+
+	tool, err := s.toolHandler.HandleGetTool(ctx, query)
+	if err != nil {
+		return nil, toolCallError(p.Name, err)
+	}
+
+	result := tool.ToMCPTool()
+	result["parameters"] = tool.InputSchema().Parameters()
+	if tool.Category() != "" {
+		result["category"] = tool.Category()
+	}
+	if len(tool.Tags()) > 0 {
+		result["tags"] = tool.Tags()
+	}
+	return result, nil
+}
+
 // handleResourcesList handles resources/list request
 func (s *Server) handleResourcesList(ctx context.Context, params json.RawMessage) (interface{}, error) {
-	s.mu.RLock()
-	session := s.currentSession
-	s.mu.RUnlock()
-
+	session := s.sessionFor(ctx)
 	if session == nil {
 		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
 	}
@@ -405,23 +843,45 @@ func (s *Server) handleResourcesRead(ctx context.Context, params json.RawMessage
 		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params"}
 	}
 
+	session := s.sessionFor(ctx)
 	s.mu.RLock()
-	session := s.currentSession
+	mimeFilter := s.mimeFilter
+	fileReader := s.fileReader
 	s.mu.RUnlock()
 
 	if session == nil {
 		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
 	}
 
-	resource, ok := session.GetResource(p.URI)
+	resource, ok := session.FindResource(p.URI)
+	if !ok {
+		// Query parameters, such as status://events?type=session.created, refine a read
+		if base, _, hasQuery := strings.Cut(p.URI, "?"); hasQuery {
+			resource, ok = session.FindResource(base)
+		}
+	}
 	if !ok {
 		return nil, &MCPError{Code: vo.ErrorCodeResourceNotFound, Message: "Resource not found"}
 	}
+	if progressToken, path, stream := s.shouldStreamResource(ctx, session, resource, fileReader, p.URI); stream {
+		return s.streamResource(ctx, resource, path, p.URI, progressToken, mimeFilter)
+	}
 
-	content, err := resource.Read()
+	content, err := s.readResource(ctx, session, resource, p.URI)
 	if err != nil {
 		return nil, &MCPError{Code: vo.ErrorCodeResourceReadError, Message: err.Error()}
 	}
+	if err := resources.SniffContentMimeType(content); err != nil {
+		return nil, &MCPError{Code: vo.ErrorCodeResourceReadError, Message: err.Error()}
+	}
+	if err := mimeFilter.CheckContent(content); err != nil {
+		return nil, &MCPError{Code: vo.ErrorCodeResourceReadError, Message: err.Error()}
+	}
+	if annotations := resource.Annotations(); annotations != nil && content.Annotations == nil {
+		annotated := *content
+		annotated.Annotations = annotations
+		content = &annotated
+	}
 
 	return map[string]interface{}{
 		"contents": []interface{}{content},
@@ -430,15 +890,13 @@ func (s *Server) handleResourcesRead(ctx context.Context, params json.RawMessage
 
 // handlePromptsList handles prompts/list request
 func (s *Server) handlePromptsList(ctx context.Context, params json.RawMessage) (interface{}, error) {
-	s.mu.RLock()
-	session := s.currentSession
-	s.mu.RUnlock()
-
+	session := s.sessionFor(ctx)
 	if session == nil {
 		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
 	}
 
 	prompts := session.ListPrompts()
+	sort.Slice(prompts, func(i, j int) bool { return prompts[i].Name().String() < prompts[j].Name().String() })
 	result := make([]map[string]interface{}, len(prompts))
 	for i, p := range prompts {
 		result[i] = p.ToMCPPrompt()
@@ -453,6 +911,7 @@ func (s *Server) handlePromptsList(ctx context.Context, params json.RawMessage)
 type PromptGetParams struct {
 	Name      string            `json:"name"`
 	Arguments map[string]string `json:"arguments,omitempty"`
+	Preview   bool              `json:"preview,omitempty"` // Render with missing arguments left as {{name}} markers
 }
 
 // handlePromptsGet handles prompts/get request
@@ -462,10 +921,7 @@ func (s *Server) handlePromptsGet(ctx context.Context, params json.RawMessage) (
 		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params"}
 	}
 
-	s.mu.RLock()
-	session := s.currentSession
-	s.mu.RUnlock()
-
+	session := s.sessionFor(ctx)
 	if session == nil {
 		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
 	}
@@ -475,6 +931,17 @@ func (s *Server) handlePromptsGet(ctx context.Context, params json.RawMessage) (
 		return nil, &MCPError{Code: vo.ErrorCodePromptNotFound, Message: "Prompt not found"}
 	}
 
+	if p.Preview {
+		messages, err := prompt.Preview(p.Arguments)
+		if errors.Is(err, entities.ErrPromptPreviewUnsupported) {
+			return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: fmt.Sprintf("Prompt %s does not support preview", p.Name)}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return messages, nil
+	}
+
 	messages, err := prompt.Generate(p.Arguments)
 	if err != nil {
 		return nil, err
@@ -495,10 +962,7 @@ func (s *Server) handleLoggingSetLevel(ctx context.Context, params json.RawMessa
 		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params"}
 	}
 
-	s.mu.RLock()
-	session := s.currentSession
-	s.mu.RUnlock()
-
+	session := s.sessionFor(ctx)
 	if session == nil {
 		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
 	}
@@ -538,21 +1002,74 @@ func (s *Server) createErrorResponse(id interface{}, code vo.MCPErrorCode, messa
 	}
 }
 
-// sendResponse sends a response
-func (s *Server) sendResponse(response *JSONRPCResponse) error {
+// createMCPErrorResponse creates an error response carrying the error's data and retry hints
+func (s *Server) createMCPErrorResponse(id interface{}, mcpErr *MCPError) *JSONRPCResponse {
+	response := s.createErrorResponse(id, mcpErr.Code, mcpErr.Message)
+	response.Error.Data = mcpErr.ErrorData()
+	return response
+}
+
+// sendResponse sends a response to the connection the request arrived on
+func (s *Server) sendResponse(ctx context.Context, response *JSONRPCResponse) error {
 	data, err := json.Marshal(response)
 	if err != nil {
 		return err
 	}
 
+	if limit := s.config.MCP.MaxResponseBytes; limit > 0 && len(data) > limit {
+		s.logger.Warn().
+			Interface("id", response.ID).
+			Int("bytes", len(data)).
+			Int("limit", limit).
+			Msg("Response exceeds the size limit, sending an error instead")
+		data, err = json.Marshal(s.responseTooLarge(response.ID, len(data), limit))
+		if err != nil {
+			return err
+		}
+	}
+
 	s.logger.Debug().Str("response", string(data)).Msg("Sending response")
 
-	_, err = fmt.Fprintf(s.writer, "%s\n", data)
-	return err
+	return s.writeMessage(connectionFromContext(ctx), data)
 }
 
-// SendNotification sends a notification to the client
+// SendNotification sends a notification to every connected client
 func (s *Server) SendNotification(method vo.MCPMethod, params interface{}) error {
+	conns := s.openConnections()
+	if len(conns) == 0 {
+		return ErrTransportClosed
+	}
+
+	data, err := marshalNotification(method, params)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, conn := range conns {
+		if err := s.writeMessage(conn, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notify sends a notification to the client of the connection a request arrived on
+func (s *Server) notify(ctx context.Context, method vo.MCPMethod, params interface{}) error {
+	return s.notifyConnection(connectionFromContext(ctx), method, params)
+}
+
+// notifyConnection sends a notification to the client of conn
+func (s *Server) notifyConnection(conn *connection, method vo.MCPMethod, params interface{}) error {
+	data, err := marshalNotification(method, params)
+	if err != nil {
+		return err
+	}
+	return s.writeMessage(conn, data)
+}
+
+// marshalNotification encodes a JSON-RPC notification
+func marshalNotification(method vo.MCPMethod, params interface{}) ([]byte, error) {
 	notification := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"method":  method.String(),
@@ -560,19 +1077,18 @@ func (s *Server) SendNotification(method vo.MCPMethod, params interface{}) error
 	if params != nil {
 		notification["params"] = params
 	}
+	return json.Marshal(notification)
+}
 
-	data, err := json.Marshal(notification)
-	if err != nil {
-		return err
+// writeMessage delivers a message through the transport of conn
+func (s *Server) writeMessage(conn *connection, data []byte) error {
+	if conn == nil {
+		return ErrTransportClosed
 	}
-
-	_, err = fmt.Fprintf(s.writer, "%s\n", data)
-	return err
+	return conn.transport.WriteMessage(context.Background(), data)
 }
 
-// Session returns the current session
+// Session returns the session initialized last, over any connection
 func (s *Server) Session() *aggregates.Session {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.currentSession
+	return s.sessions.latestSession()
 }
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// sessionManager tracks the open connections and the session each one initialized, so
+// a request is routed to the session of the connection it arrived on and server-side
+// events reach the connection of the session they concern
+type sessionManager struct {
+	mu          sync.RWMutex
+	connections map[*connection]struct{}
+	bySession   map[string]*connection // Session ID -> connection that initialized it
+	boundAt     map[*connection]uint64 // Order in which connections last initialized
+	bindCount   uint64
+	latest      *aggregates.Session
+}
+
+func newSessionManager() *sessionManager {
+	return &sessionManager{
+		connections: make(map[*connection]struct{}),
+		bySession:   make(map[string]*connection),
+		boundAt:     make(map[*connection]uint64),
+	}
+}
+
+// add registers a connection
+func (m *sessionManager) add(conn *connection) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connections[conn] = struct{}{}
+}
+
+// remove forgets a connection and returns the session it had initialized, or nil
+func (m *sessionManager) remove(conn *connection) *aggregates.Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.connections, conn)
+	delete(m.boundAt, conn)
+	session := conn.Session()
+	if session != nil && m.bySession[session.ID().String()] == conn {
+		delete(m.bySession, session.ID().String())
+	}
+	return session
+}
+
+// forgetLatest stops reporting a closed session as the latest. The latest becomes
+// the session of the open connection initialized most recently, or nil when none
+// is left.
+func (m *sessionManager) forgetLatest(session *aggregates.Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.latest == session {
+		m.latest = m.mostRecentSession()
+	}
+}
+
+// mostRecentSession returns the session of the open connection that initialized last
+func (m *sessionManager) mostRecentSession() *aggregates.Session {
+	var (
+		latest *aggregates.Session
+		at     uint64
+	)
+	for _, conn := range m.bySession {
+		if boundAt := m.boundAt[conn]; latest == nil || boundAt > at {
+			latest, at = conn.Session(), boundAt
+		}
+	}
+	return latest
+}
+
+// bind records the session initialized over conn and returns the session it replaces,
+// or nil on the connection's first initialize
+func (m *sessionManager) bind(conn *connection, session *aggregates.Session, clientRoots bool) *aggregates.Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous := conn.Session()
+	if previous != nil {
+		delete(m.bySession, previous.ID().String())
+	}
+	conn.setSession(session, clientRoots)
+	m.bySession[session.ID().String()] = conn
+	m.bindCount++
+	m.boundAt[conn] = m.bindCount
+	m.latest = session
+	return previous
+}
+
+// connection returns the open connection that initialized the session, or nil
+func (m *sessionManager) connection(id vo.SessionID) *connection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.bySession[id.String()]
+}
+
+// all returns the open connections
+func (m *sessionManager) all() []*connection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	conns := make([]*connection, 0, len(m.connections))
+	for conn := range m.connections {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// sessions returns the sessions of the open connections
+func (m *sessionManager) sessions() []*aggregates.Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sessions := make([]*aggregates.Session, 0, len(m.bySession))
+	for _, conn := range m.bySession {
+		sessions = append(sessions, conn.Session())
+	}
+	return sessions
+}
+
+// latestSession returns the session initialized last, over any connection
+func (m *sessionManager) latestSession() *aggregates.Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.latest
+}
+
+// Sessions returns the sessions of the connected clients
+func (s *Server) Sessions() []*aggregates.Session {
+	return s.sessions.sessions()
+}
+
+// SessionByID returns the session of a connected client, or nil when no open
+// connection initialized it
+func (s *Server) SessionByID(id vo.SessionID) *aggregates.Session {
+	if conn := s.sessions.connection(id); conn != nil {
+		return conn.Session()
+	}
+	return nil
+}
+
+// closeSession closes a session that no connection serves any more, so it stops
+// counting against the session limit and releases its subscriptions, watches and
+// cached reads
+func (s *Server) closeSession(session *aggregates.Session) {
+	if session == nil || session.IsClosed() {
+		return
+	}
+	s.resourceCache.dropSession(session.ID())
+	if err := s.sessionHandler.HandleCloseSession(context.Background(), &commands.CloseSessionCommand{
+		SessionID: session.ID(),
+	}); err != nil {
+		s.logger.Warn().Err(err).Str("session_id", session.ID().String()).Msg("Failed to close session")
+		return
+	}
+	s.sessions.forgetLatest(session)
+	s.logger.Debug().Str("session_id", session.ID().String()).Msg("Session closed")
+}
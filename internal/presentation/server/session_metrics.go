@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// unknownMethod is the method name requests to unsupported methods are counted under,
+// so clients cannot grow the per-method counters with arbitrary names
+const unknownMethod = "unknown"
+
+// recordRequest counts an answered request against the connection's session, if any
+func (s *Server) recordRequest(ctx context.Context, method string, failed bool) {
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return
+	}
+	if !vo.MCPMethod(method).IsValid() {
+		method = unknownMethod
+	}
+	session.RecordRequest(method, failed)
+}
+
+// SessionRequestMetrics holds the request counters of a session/metrics result
+type SessionRequestMetrics struct {
+	Total     int64                         `json:"total"`
+	Errors    int64                         `json:"errors"`
+	ErrorRate float64                       `json:"errorRate"`
+	ByMethod  []aggregates.MethodUsageStats `json:"byMethod"`
+}
+
+// SessionTokenMetrics holds the Claude token usage of a session/metrics result
+type SessionTokenMetrics struct {
+	Input  int64 `json:"input"`
+	Output int64 `json:"output"`
+	Total  int64 `json:"total"`
+}
+
+// SessionMetricsResult represents the session/metrics result
+type SessionMetricsResult struct {
+	SessionID string                      `json:"sessionId"`
+	StartedAt time.Time                   `json:"startedAt"`
+	UptimeMs  int64                       `json:"uptimeMs"`
+	Requests  SessionRequestMetrics       `json:"requests"`
+	Tools     *aggregates.ToolUsageReport `json:"tools"`
+	Tokens    SessionTokenMetrics         `json:"tokens"`
+}
+
+// handleSessionMetrics handles the experimental session/metrics request. It returns the
+// calling session's request counts by method, error rate, tool calls, Claude token
+// usage and uptime. Counters live in the session and are cleared when it closes or is
+// reset; a request is counted once answered, so the snapshot excludes the call itself.
+func (s *Server) handleSessionMetrics(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	usage := session.RequestUsage()
+	return &SessionMetricsResult{
+		SessionID: session.ID().String(),
+		StartedAt: session.CreatedAt(),
+		UptimeMs:  time.Since(session.CreatedAt()).Milliseconds(),
+		Requests: SessionRequestMetrics{
+			Total:     usage.TotalRequests,
+			Errors:    usage.TotalErrors,
+			ErrorRate: usage.ErrorRate,
+			ByMethod:  usage.Methods,
+		},
+		Tools: session.ToolUsage(),
+		Tokens: SessionTokenMetrics{
+			Input:  usage.InputTokens,
+			Output: usage.OutputTokens,
+			Total:  usage.InputTokens + usage.OutputTokens,
+		},
+	}, nil
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// handleSessionReset handles the experimental session/reset request. It clears the
+// calling session's collections, re-seeds the default resources and prompts and tells
+// the client to refetch its lists, keeping the session ID and negotiated capabilities.
+func (s *Server) handleSessionReset(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if !s.config.Server.EnableAdminMethods {
+		return nil, &MCPError{Code: vo.ErrorCodeMethodNotFound, Message: "Method not found"}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	summary, err := s.sessionHandler.HandleResetSession(ctx, &commands.ResetSessionCommand{SessionID: session.ID()})
+	if err != nil {
+		return nil, err
+	}
+	s.seedSession(session)
+
+	for _, method := range []vo.MCPMethod{
+		vo.MethodNotificationsToolsListChanged,
+		vo.MethodNotificationsResourcesListChanged,
+		vo.MethodNotificationsPromptsListChanged,
+	} {
+		if err := s.notify(ctx, method, nil); err != nil {
+			s.logger.Warn().Err(err).Str("method", method.String()).Msg("Failed to send list changed notification")
+		}
+	}
+
+	s.logger.Info().
+		Str("session_id", session.ID().String()).
+		Int("conversations", summary.Conversations).
+		Msg("Session reset")
+
+	return map[string]interface{}{
+		"sessionId": session.ID().String(),
+		"cleared":   summary,
+	}, nil
+}
@@ -0,0 +1,284 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ShutdownNotifyTimeout bounds how long shutdown waits for the shutdown notification to be written
+const ShutdownNotifyTimeout = 2 * time.Second
+
+// ShutdownEvent is the event name carried by the shutdown notification
+const ShutdownEvent = "server_shutdown"
+
+// shutdownNotifyTimeout returns the notification timeout, capped by the configured shutdown timeout
+func (s *Server) shutdownNotifyTimeout() time.Duration {
+	if t := s.config.Server.ShutdownTimeout; t > 0 && t < ShutdownNotifyTimeout {
+		return t
+	}
+	return ShutdownNotifyTimeout
+}
+
+// notifyShutdown tells the clients of conns the server is going away, all at once
+func (s *Server) notifyShutdown(conns []*connection) {
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn *connection) {
+			defer wg.Done()
+			s.notifyConnectionShutdown(conn)
+		}(conn)
+	}
+	wg.Wait()
+}
+
+// notifyConnectionShutdown tells the client the server is going away so it can stop
+// sending requests and reconnect later. The notification is sent regardless of the
+// session log level, and a stuck transport cannot hold up shutdown past the timeout.
+func (s *Server) notifyConnectionShutdown(conn *connection) {
+	session := conn.Session()
+	if session == nil || session.IsClosed() {
+		return
+	}
+
+	params := map[string]interface{}{
+		"level":  vo.LogLevelWarning,
+		"logger": "mcp-server",
+		"data": map[string]interface{}{
+			"event":     ShutdownEvent,
+			"message":   "Server is shutting down; stop sending requests and reconnect later",
+			"sessionId": session.ID().String(),
+		},
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- s.notifyConnection(conn, vo.MethodNotificationsMessage, params)
+	}()
+
+	select {
+	case err := <-sent:
+		if err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to send shutdown notification")
+		}
+	case <-time.After(s.shutdownNotifyTimeout()):
+		s.logger.Warn().Msg("Timed out sending shutdown notification")
+	}
+}
+
+// ErrForcedShutdown is returned when in-flight work outlives the shutdown deadline and is terminated
+var ErrForcedShutdown = errors.New("shutdown deadline exceeded: in-flight work terminated")
+
+// Shutdown outcomes reported in the exit log and metric
+const (
+	ShutdownOutcomeClean  = "clean"
+	ShutdownOutcomeForced = "forced"
+)
+
+// ShutdownMetrics records how the server shut down
+type ShutdownMetrics interface {
+	RecordShutdown(ctx context.Context, outcome string)
+}
+
+// SetShutdownMetrics sets the recorder for the shutdown outcome
+func (s *Server) SetShutdownMetrics(metrics ShutdownMetrics) {
+	s.shutdownMetrics = metrics
+}
+
+// Shutdown stops the server and waits for in-flight requests until ctx ends. Work still
+// running then is terminated: request contexts are cancelled, the transports are closed,
+// the operations are logged and ErrForcedShutdown is returned. Run returns once
+// Shutdown completes, even if a handler never returns.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.Stop()
+
+	outcome, err := ShutdownOutcomeClean, error(nil)
+	if !s.inflight.wait(ctx) {
+		outcome, err = ShutdownOutcomeForced, ErrForcedShutdown
+		for _, op := range s.inflight.running() {
+			s.logger.Warn().
+				Str("method", op.method).
+				Interface("id", op.id).
+				Dur("running", time.Since(op.started)).
+				Msg("Terminating in-flight operation at shutdown deadline")
+		}
+		s.cancelWork()
+		s.closeTransports()
+	}
+
+	if s.shutdownMetrics != nil {
+		s.shutdownMetrics.RecordShutdown(context.Background(), outcome)
+	}
+	event := s.logger.Info()
+	if err != nil {
+		event = s.logger.Warn()
+	}
+	event.Str("outcome", outcome).Msg("Server shut down")
+
+	s.shutdownOnce.Do(func() {
+		s.shutdownErr = err
+		close(s.shutdownDone)
+	})
+	return err
+}
+
+// closeTransports closes the listener and the transport of every open connection
+func (s *Server) closeTransports() {
+	s.mu.RLock()
+	listener := s.listener
+	s.mu.RUnlock()
+	if listener != nil {
+		_ = listener.Close()
+	}
+	for _, conn := range s.openConnections() {
+		_ = conn.transport.Close()
+	}
+}
+
+// cancelWork cancels the context the running requests were started with
+func (s *Server) cancelWork() {
+	s.mu.RLock()
+	cancel := s.workCancel
+	s.mu.RUnlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// inflightOp is a request the server has accepted and not yet answered
+type inflightOp struct {
+	key       uint64
+	method    string
+	id        interface{}
+	sessionID string
+	conn      *connection // The connection the request arrived on
+	started   time.Time
+	cancel    context.CancelFunc
+	cancelled string // how the request was cancelled, empty while it runs
+}
+
+// inflightTracker records accepted requests so shutdown can drain and report them
+// and operators can list and cancel them
+type inflightTracker struct {
+	mu   sync.Mutex
+	next uint64
+	ops  map[uint64]*inflightOp
+	wg   sync.WaitGroup
+}
+
+func newInflightTracker() *inflightTracker {
+	return &inflightTracker{ops: make(map[uint64]*inflightOp)}
+}
+
+// begin records a request that arrived on conn and returns the func that marks it
+// complete. cancel, when set, cancels the request's context.
+func (t *inflightTracker) begin(method string, id interface{}, conn *connection, cancel context.CancelFunc) (*inflightOp, func()) {
+	sessionID := ""
+	if conn != nil {
+		sessionID = conn.sessionID()
+	}
+	t.mu.Lock()
+	t.next++
+	op := &inflightOp{key: t.next, method: method, id: id, sessionID: sessionID, conn: conn, started: time.Now(), cancel: cancel}
+	t.ops[op.key] = op
+	t.wg.Add(1)
+	t.mu.Unlock()
+
+	return op, func() {
+		t.mu.Lock()
+		delete(t.ops, op.key)
+		t.mu.Unlock()
+		t.wg.Done()
+	}
+}
+
+// wait blocks until every request has completed, reporting false if ctx ends first
+func (t *inflightTracker) wait(ctx context.Context) bool {
+	drained := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// running returns a copy of the requests still in flight, oldest first
+func (t *inflightTracker) running() []inflightOp {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ops := make([]inflightOp, 0, len(t.ops))
+	for _, op := range t.ops {
+		ops = append(ops, *op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].key < ops[j].key })
+	return ops
+}
+
+// matching returns the running requests with the given JSON-RPC id that in selects
+// and that can still be cancelled, oldest first. JSON-RPC ids are only unique within
+// a connection, so in narrows the search to a connection or session.
+func (t *inflightTracker) matching(id interface{}, in func(*inflightOp) bool) []inflightOp {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	matches := t.matchingLocked(id, in)
+	ops := make([]inflightOp, len(matches))
+	for i, op := range matches {
+		ops[i] = *op
+	}
+	return ops
+}
+
+// cancel cancels the oldest running request with the given JSON-RPC id that in
+// selects, recording how it was cancelled. It reports false when no such request is
+// running.
+func (t *inflightTracker) cancel(id interface{}, in func(*inflightOp) bool, how string) (inflightOp, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	matches := t.matchingLocked(id, in)
+	if len(matches) == 0 {
+		return inflightOp{}, false
+	}
+	match := matches[0]
+	match.cancelled = how
+	match.cancel()
+	return *match, true
+}
+
+// matchingLocked returns the requests matching would, oldest first. The caller holds t.mu.
+func (t *inflightTracker) matchingLocked(id interface{}, in func(*inflightOp) bool) []*inflightOp {
+	want, ok := requestIDKey(id)
+	if !ok {
+		return nil
+	}
+	var matches []*inflightOp
+	for _, op := range t.ops {
+		if key, ok := requestIDKey(op.id); !ok || key != want || op.cancel == nil || op.cancelled != "" {
+			continue
+		}
+		if in(op) {
+			matches = append(matches, op)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].key < matches[j].key })
+	return matches
+}
+
+// cancellation reports how op was cancelled, or "" if it was not
+func (t *inflightTracker) cancellation(op *inflightOp) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return op.cancelled
+}
@@ -0,0 +1,348 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SSE transport paths, relative to where the listener is mounted
+const (
+	SSEStreamPath  = "/sse"
+	SSEMessagePath = "/message"
+)
+
+// SSEKeepAliveInterval is how often an idle event stream gets a comment, so proxies
+// between the server and the client keep it open
+const SSEKeepAliveInterval = 30 * time.Second
+
+// sseBufferSize is the number of messages queued in each direction of a connection
+// before the sender waits
+const sseBufferSize = 16
+
+// SSEListener serves the MCP HTTP transport. A client opens an event stream with
+// GET /sse, learns the URL to post its messages to from the first "endpoint" event,
+// and receives responses and notifications as "message" events on the stream. Each
+// stream is a connection with its own session.
+type SSEListener struct {
+	access    TransportAccess
+	accepted  chan *SSETransport
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// Set when the listener serves its own HTTP server
+	httpServer *http.Server
+	addr       net.Addr
+
+	mu         sync.Mutex
+	transports map[string]*SSETransport // connection ID -> open stream
+}
+
+// NewSSEListener creates a listener to be mounted as an http.Handler, serving the
+// requests access allows
+func NewSSEListener(access TransportAccess) *SSEListener {
+	return &SSEListener{
+		access:     access,
+		accepted:   make(chan *SSETransport),
+		closed:     make(chan struct{}),
+		transports: make(map[string]*SSETransport),
+	}
+}
+
+// ListenSSE creates a listener serving its own HTTP server on addr, with request
+// headers bounded by readTimeout
+func ListenSSE(addr string, readTimeout time.Duration, access TransportAccess) (*SSEListener, error) {
+	l := NewSSEListener(access)
+	httpServer, boundAddr, err := listenHTTP(addr, l, readTimeout)
+	if err != nil {
+		return nil, err
+	}
+	l.httpServer, l.addr = httpServer, boundAddr
+	return l, nil
+}
+
+// Addr returns the address the listener's HTTP server is bound to, or nil when the
+// listener is mounted on another server
+func (l *SSEListener) Addr() net.Addr {
+	return l.addr
+}
+
+// Accept waits for the next client to open an event stream
+func (l *SSEListener) Accept(ctx context.Context) (Transport, error) {
+	select {
+	case t := <-l.accepted:
+		return t, nil
+	case <-l.closed:
+		return nil, ErrTransportClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops accepting streams, closes the open ones and the listener's HTTP server
+func (l *SSEListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.closed)
+
+		l.mu.Lock()
+		for _, t := range l.transports {
+			_ = t.Close()
+		}
+		l.mu.Unlock()
+
+		if l.httpServer != nil {
+			err = l.httpServer.Close()
+		}
+	})
+	return err
+}
+
+// ServeHTTP routes event stream and message requests the listener's access allows
+func (l *SSEListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, SSEStreamPath):
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if scopes, ok := l.access.allow(w, r); ok {
+			l.serveStream(w, r, scopes)
+		}
+	case strings.HasSuffix(r.URL.Path, SSEMessagePath):
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		// Messages join the stream's connection, which holds the stream's scopes
+		if _, ok := l.access.allow(w, r); ok {
+			l.serveMessage(w, r)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveStream hands a new connection, holding scopes, to the server and streams its
+// messages to the client until either side goes away
+func (l *SSEListener) serveStream(w http.ResponseWriter, r *http.Request, scopes []string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	t := newSSETransport(uuid.New().String(), scopes)
+	l.mu.Lock()
+	l.transports[t.id] = t
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		delete(l.transports, t.id)
+		l.mu.Unlock()
+		t.disconnect()
+	}()
+
+	// The server takes the connection before the client learns where to post, so no
+	// message arrives for a connection nobody reads
+	select {
+	case l.accepted <- t:
+	case <-l.closed:
+		http.Error(w, "server closed", http.StatusServiceUnavailable)
+		return
+	case <-r.Context().Done():
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	endpoint := strings.TrimSuffix(r.URL.Path, SSEStreamPath) + SSEMessagePath + "?sessionId=" + url.QueryEscape(t.id)
+	if err := writeSSEEvent(w, "endpoint", []byte(endpoint)); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(SSEKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case data := <-t.outgoing:
+			if err := writeSSEEvent(w, "message", data); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-t.done:
+			// Deliver what was written before the server closed the transport
+			for {
+				select {
+				case data := <-t.outgoing:
+					if err := writeSSEEvent(w, "message", data); err != nil {
+						return
+					}
+				default:
+					flusher.Flush()
+					return
+				}
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// serveMessage delivers a posted message to the connection named by the sessionId
+// query parameter. The answer goes out on the connection's event stream, so the
+// request itself is only acknowledged.
+func (l *SSEListener) serveMessage(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	t, ok := l.transports[r.URL.Query().Get("sessionId")]
+	l.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxMessageSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "message too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read message", http.StatusBadRequest)
+		return
+	}
+	if !json.Valid(data) {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case t.incoming <- data:
+		w.WriteHeader(http.StatusAccepted)
+	case <-t.done:
+		http.Error(w, "session closed", http.StatusGone)
+	case <-r.Context().Done():
+	}
+}
+
+// writeSSEEvent writes one event, splitting data over as many data lines as it has lines
+func writeSSEEvent(w io.Writer, event string, data []byte) error {
+	var buf bytes.Buffer
+	buf.WriteString("event: ")
+	buf.WriteString(event)
+	buf.WriteByte('\n')
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// SSETransport is one client's connection over the SSE listener: messages posted by
+// the client are read from it, and messages written to it go out on the event stream
+type SSETransport struct {
+	id       string
+	incoming chan []byte
+	outgoing chan []byte
+	scopes   []string
+
+	done         chan struct{}
+	closeOnce    sync.Once
+	disconnected int32 // The client went away rather than the server closing the transport
+}
+
+func newSSETransport(id string, scopes []string) *SSETransport {
+	return &SSETransport{
+		id:       id,
+		scopes:   scopes,
+		incoming: make(chan []byte, sseBufferSize),
+		outgoing: make(chan []byte, sseBufferSize),
+		done:     make(chan struct{}),
+	}
+}
+
+// ID returns the connection ID clients pass as the sessionId of posted messages
+func (t *SSETransport) ID() string {
+	return t.id
+}
+
+// Scopes returns the scopes of the API key the client opened the stream with
+func (t *SSETransport) Scopes() []string {
+	return t.scopes
+}
+
+// ReadMessage returns the next message posted by the client
+func (t *SSETransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-t.incoming:
+		return data, nil
+	case <-t.done:
+		if atomic.LoadInt32(&t.disconnected) == 1 {
+			return nil, io.EOF
+		}
+		return nil, ErrTransportClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WriteMessage queues data for the event stream
+func (t *SSETransport) WriteMessage(ctx context.Context, data []byte) error {
+	select {
+	case <-t.done:
+		return ErrTransportClosed
+	default:
+	}
+
+	select {
+	case t.outgoing <- data:
+		return nil
+	case <-t.done:
+		return ErrTransportClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SupportsStreaming reports true: results can be streamed as events on the stream
+func (t *SSETransport) SupportsStreaming() bool {
+	return true
+}
+
+// Close ends the event stream
+func (t *SSETransport) Close() error {
+	t.closeOnce.Do(func() { close(t.done) })
+	return nil
+}
+
+// disconnect closes the transport after the client went away
+func (t *SSETransport) disconnect() {
+	t.closeOnce.Do(func() {
+		atomic.StoreInt32(&t.disconnected, 1)
+		close(t.done)
+	})
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ResourceSubscribeParams represents resources/subscribe and resources/unsubscribe parameters
+type ResourceSubscribeParams struct {
+	URI string `json:"uri"`
+}
+
+// SetResourceHandler sets the handler that persists resource subscriptions
+func (s *Server) SetResourceHandler(resourceHandler *handlers.ResourceHandler) {
+	s.resourceHandler = resourceHandler
+}
+
+// handleResourcesSubscribe handles resources/subscribe request
+func (s *Server) handleResourcesSubscribe(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p ResourceSubscribeParams
+	if err := json.Unmarshal(params, &p); err != nil || p.URI == "" {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: uri is required"}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	if s.resourceHandler == nil {
+		if err := session.SubscribeResource(p.URI); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{}, nil
+	}
+
+	cmd := &commands.SubscribeResourceCommand{SessionID: session.ID(), URI: p.URI}
+	if err := s.resourceHandler.HandleSubscribeResource(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{}, nil
+}
+
+// handleResourcesUnsubscribe handles resources/unsubscribe request
+func (s *Server) handleResourcesUnsubscribe(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p ResourceSubscribeParams
+	if err := json.Unmarshal(params, &p); err != nil || p.URI == "" {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: uri is required"}
+	}
+
+	session := s.sessionFor(ctx)
+	if session == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: "Session not initialized"}
+	}
+
+	// Without a subscription, changes to the resource no longer reach the cache
+	s.resourceCache.invalidateURI(p.URI)
+
+	if s.resourceHandler == nil {
+		session.UnsubscribeResource(p.URI)
+		return map[string]interface{}{}, nil
+	}
+
+	cmd := &commands.UnsubscribeResourceCommand{SessionID: session.ID(), URI: p.URI}
+	if err := s.resourceHandler.HandleUnsubscribeResource(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{}, nil
+}
+
+// NotifyResourceUpdated sends notifications/resources/updated to every connected
+// session subscribed to the resource. Subscribers are looked up in the subscription
+// repository when one is configured, so subscriptions restored from storage apply.
+// With a debounce window configured, updates to the same URI within the window are
+// sent as one notification whose _meta.changes holds the number of updates. The
+// cached content of the resource is dropped, so the next read sees the change.
+func (s *Server) NotifyResourceUpdated(ctx context.Context, uri string) error {
+	s.resourceCache.invalidateURI(uri)
+
+	var errs []error
+	for _, conn := range s.openConnections() {
+		if err := s.notifyResourceUpdated(ctx, conn, uri); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifyResourceUpdated sends notifications/resources/updated to the session of conn
+// if it is subscribed to the resource
+func (s *Server) notifyResourceUpdated(ctx context.Context, conn *connection, uri string) error {
+	session := conn.Session()
+	if session == nil {
+		return nil
+	}
+
+	subscribed, err := s.isSubscribed(ctx, session, uri)
+	if err != nil || !subscribed {
+		return err
+	}
+	if s.resourceUpdates != nil {
+		s.resourceUpdates.add(session.ID(), uri)
+		return nil
+	}
+	return s.writeResourceUpdated(conn, uri, 1)
+}
+
+// isSubscribed reports whether session is subscribed to uri, consulting the
+// subscription repository when one is configured
+func (s *Server) isSubscribed(ctx context.Context, session *aggregates.Session, uri string) (bool, error) {
+	if s.resourceHandler == nil {
+		return session.IsSubscribed(uri), nil
+	}
+	subscribers, err := s.resourceHandler.HandleListResourceSubscribers(ctx, &queries.ListResourceSubscribersQuery{URI: uri})
+	if err != nil {
+		return false, err
+	}
+	for _, id := range subscribers {
+		if id.Equals(session.ID()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// sendResourceUpdated sends a coalesced update if its session is still connected
+func (s *Server) sendResourceUpdated(update pendingResourceUpdate) {
+	conn := s.connectionForSession(update.sessionID)
+	if conn == nil {
+		return
+	}
+	if err := s.writeResourceUpdated(conn, update.uri, update.changes); err != nil {
+		s.logger.Warn().Err(err).Str("uri", update.uri).Msg("Failed to send resource update notification")
+	}
+}
+
+func (s *Server) writeResourceUpdated(conn *connection, uri string, changes int) error {
+	return s.notifyConnection(conn, vo.MethodNotificationsResourcesUpdated, map[string]interface{}{
+		"uri":   uri,
+		"_meta": map[string]interface{}{"changes": changes},
+	})
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/queue"
+)
+
+// TaskResults looks up the latest result of a task processed by the task queue
+type TaskResults interface {
+	GetResult(taskID string) (*queue.TaskResult, error)
+}
+
+// SetTaskResults exposes task results, including how often a task was retried,
+// through admin/taskResult
+func (s *Server) SetTaskResults(results TaskResults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taskResults = results
+}
+
+// TaskResultParams represents admin/taskResult request parameters
+type TaskResultParams struct {
+	TaskID string `json:"taskId"`
+}
+
+// handleAdminTaskResult handles admin/taskResult request
+func (s *Server) handleAdminTaskResult(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if !s.config.Server.EnableAdminMethods {
+		return nil, &MCPError{Code: vo.ErrorCodeMethodNotFound, Message: "Method not found"}
+	}
+
+	var p TaskResultParams
+	if err := json.Unmarshal(params, &p); err != nil || p.TaskID == "" {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidParams, Message: "Invalid params: taskId is required"}
+	}
+
+	s.mu.RLock()
+	results := s.taskResults
+	s.mu.RUnlock()
+	if results == nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInvalidRequest, Message: "Task queue is not enabled"}
+	}
+
+	result, err := results.GetResult(p.TaskID)
+	if errors.Is(err, queue.ErrTaskNotFound) {
+		return nil, &MCPError{
+			Code:    vo.ErrorCodeInvalidParams,
+			Message: fmt.Sprintf("No result for task %s", p.TaskID),
+			Data:    map[string]interface{}{"taskId": p.TaskID},
+		}
+	}
+	if err != nil {
+		return nil, &MCPError{Code: vo.ErrorCodeInternalError, Message: err.Error()}
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// ToolUsageResourceURI is the URI of the per-session tool usage resource
+const ToolUsageResourceURI = "status://session/tools"
+
+// newToolUsageResource creates the resource exposing the session's tool usage counters
+func newToolUsageResource(session *aggregates.Session) (*entities.Resource, error) {
+	uri, err := vo.NewResourceURI(ToolUsageResourceURI)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := entities.NewResource(uri, "Session Tool Usage")
+	if err != nil {
+		return nil, err
+	}
+	resource.SetDescription("Tool execution counts and failure rates for the current session")
+	mimeType, _ := vo.NewMimeType(vo.MimeTypeJSON)
+	resource.SetMimeType(mimeType)
+	resource.SetReader(func(uri string) (*entities.ResourceContent, error) {
+		report := session.ToolUsage()
+		data, err := json.Marshal(map[string]interface{}{
+			"sessionId":      session.ID().String(),
+			"totalCalls":     report.TotalCalls,
+			"totalFailures":  report.TotalFailures,
+			"untrackedCalls": report.UntrackedCalls,
+			"tools":          report.Tools,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &entities.ResourceContent{
+			URI:      uri,
+			MimeType: vo.MimeTypeJSON,
+			Text:     string(data),
+		}, nil
+	})
+
+	return resource, nil
+}
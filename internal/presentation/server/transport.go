@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Transport errors
+var (
+	ErrTransportClosed = errors.New("transport closed")
+)
+
+// MaxMessageSize is the largest message the stdio transport accepts
+const MaxMessageSize = 10 * 1024 * 1024
+
+// Transport carries JSON-RPC messages between the server and a client.
+// Responses and server notifications are both delivered through WriteMessage.
+type Transport interface {
+	// ReadMessage blocks until the next message arrives; it returns io.EOF once the client disconnects
+	ReadMessage(ctx context.Context) ([]byte, error)
+	// WriteMessage delivers a single message to the client; it must be safe for concurrent use
+	WriteMessage(ctx context.Context, data []byte) error
+	// Close releases the transport; subsequent reads and writes fail with ErrTransportClosed
+	Close() error
+}
+
+// StreamingTransport is implemented by transports, such as SSE, that can deliver a
+// response as a sequence of messages, letting the server stream large results as
+// progress notifications instead of one huge response
+type StreamingTransport interface {
+	Transport
+	// SupportsStreaming reports whether the connection accepts streamed results
+	SupportsStreaming() bool
+}
+
+// AuthenticatedTransport is implemented by transports whose client connected with an
+// API key. The connection's session holds the scopes the key grants.
+type AuthenticatedTransport interface {
+	Transport
+	// Scopes returns the scopes granted to the client
+	Scopes() []string
+}
+
+// Listener accepts client connections for transports, such as SSE and WebSocket, that
+// serve many clients at once. Each accepted transport is one connection with its own
+// session.
+type Listener interface {
+	// Accept blocks until a client connects; it returns ErrTransportClosed once the listener is closed
+	Accept(ctx context.Context) (Transport, error)
+	// Close stops accepting connections and closes the open ones
+	Close() error
+}
+
+// StdioTransport exchanges newline-delimited messages over a reader and writer
+type StdioTransport struct {
+	scanner *bufio.Scanner
+	writer  io.Writer
+
+	writeMu sync.Mutex
+	closed  int32
+}
+
+// NewStdioTransport creates a transport reading from reader and writing to writer
+func NewStdioTransport(reader io.Reader, writer io.Writer) *StdioTransport {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 1024*1024), MaxMessageSize)
+	return &StdioTransport{scanner: scanner, writer: writer}
+}
+
+// ReadMessage returns the next non-empty line
+func (t *StdioTransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	for {
+		if atomic.LoadInt32(&t.closed) == 1 {
+			return nil, ErrTransportClosed
+		}
+		if !t.scanner.Scan() {
+			if err := t.scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		if line := t.scanner.Bytes(); len(line) > 0 {
+			// The scanner reuses its buffer, so hand out a copy
+			return append([]byte(nil), line...), nil
+		}
+	}
+}
+
+// WriteMessage writes data followed by a newline
+func (t *StdioTransport) WriteMessage(ctx context.Context, data []byte) error {
+	if atomic.LoadInt32(&t.closed) == 1 {
+		return ErrTransportClosed
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_, err := t.writer.Write(append(append(make([]byte, 0, len(data)+1), data...), '\n'))
+	return err
+}
+
+// Close marks the transport closed. The underlying streams belong to the caller and stay open.
+func (t *StdioTransport) Close() error {
+	atomic.StoreInt32(&t.closed, 1)
+	return nil
+}
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketPath is the path clients open WebSocket connections on, relative to where
+// the listener is mounted
+const WebSocketPath = "/ws"
+
+// WebSocketPingInterval is how often an idle connection is pinged; a connection whose
+// client answers neither a ping nor with a message for twice as long is closed
+const WebSocketPingInterval = 30 * time.Second
+
+// webSocketWriteTimeout bounds writing a single frame to a client
+const webSocketWriteTimeout = 10 * time.Second
+
+// WebSocketListener serves MCP over WebSocket. Each upgraded HTTP connection is a
+// connection with its own session, over which JSON-RPC messages flow as text frames
+// in both directions, so server notifications reach the client as soon as they happen.
+type WebSocketListener struct {
+	upgrader  websocket.Upgrader
+	access    TransportAccess
+	accepted  chan *WebSocketTransport
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// Set when the listener serves its own HTTP server
+	httpServer *http.Server
+	addr       net.Addr
+
+	mu         sync.Mutex
+	transports map[*WebSocketTransport]struct{}
+}
+
+// NewWebSocketListener creates a listener to be mounted as an http.Handler, accepting
+// the connections access allows
+func NewWebSocketListener(access TransportAccess) *WebSocketListener {
+	l := &WebSocketListener{
+		accepted:   make(chan *WebSocketTransport),
+		closed:     make(chan struct{}),
+		transports: make(map[*WebSocketTransport]struct{}),
+		access:     access,
+	}
+	l.upgrader = websocket.Upgrader{
+		ReadBufferSize:  4096,
+		WriteBufferSize: 4096,
+		CheckOrigin:     func(r *http.Request) bool { return originAllowed(r, access.AllowedOrigins) },
+	}
+	return l
+}
+
+// ListenWebSocket creates a listener serving its own HTTP server on addr, with request
+// headers bounded by readTimeout
+func ListenWebSocket(addr string, readTimeout time.Duration, access TransportAccess) (*WebSocketListener, error) {
+	l := NewWebSocketListener(access)
+	httpServer, boundAddr, err := listenHTTP(addr, l, readTimeout)
+	if err != nil {
+		return nil, err
+	}
+	l.httpServer, l.addr = httpServer, boundAddr
+	return l, nil
+}
+
+// Addr returns the address the listener's HTTP server is bound to, or nil when the
+// listener is mounted on another server
+func (l *WebSocketListener) Addr() net.Addr {
+	return l.addr
+}
+
+// Accept waits for the next client to open a WebSocket connection
+func (l *WebSocketListener) Accept(ctx context.Context) (Transport, error) {
+	select {
+	case t := <-l.accepted:
+		return t, nil
+	case <-l.closed:
+		return nil, ErrTransportClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops accepting connections, closes the open ones and the listener's HTTP server
+func (l *WebSocketListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.closed)
+
+		l.mu.Lock()
+		for t := range l.transports {
+			_ = t.Close()
+		}
+		l.mu.Unlock()
+
+		if l.httpServer != nil {
+			err = l.httpServer.Close()
+		}
+	})
+	return err
+}
+
+// ServeHTTP upgrades requests to the WebSocket path and hands the connection to the server
+func (l *WebSocketListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, WebSocketPath) {
+		http.NotFound(w, r)
+		return
+	}
+	select {
+	case <-l.closed:
+		http.Error(w, "server closed", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
+	scopes, ok := l.access.authenticate(r)
+	if !ok {
+		http.Error(w, "invalid or missing API key", http.StatusUnauthorized)
+		return
+	}
+
+	// The upgrader answers failed handshakes itself
+	conn, err := l.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	t := newWebSocketTransport(conn, scopes)
+	l.mu.Lock()
+	l.transports[t] = struct{}{}
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		delete(l.transports, t)
+		l.mu.Unlock()
+	}()
+
+	select {
+	case l.accepted <- t:
+	case <-l.closed:
+		_ = t.Close()
+		return
+	case <-r.Context().Done():
+		_ = t.Close()
+		return
+	}
+	t.run()
+}
+
+// WebSocketTransport is one client's WebSocket connection
+type WebSocketTransport struct {
+	conn     *websocket.Conn
+	incoming chan []byte
+	scopes   []string
+
+	writeMu sync.Mutex
+
+	done         chan struct{}
+	closeOnce    sync.Once
+	disconnected int32 // The client went away rather than the server closing the transport
+}
+
+func newWebSocketTransport(conn *websocket.Conn, scopes []string) *WebSocketTransport {
+	conn.SetReadLimit(MaxMessageSize)
+	return &WebSocketTransport{
+		conn:     conn,
+		incoming: make(chan []byte),
+		scopes:   scopes,
+		done:     make(chan struct{}),
+	}
+}
+
+// run reads frames until the connection ends, pinging the client while it is idle
+func (t *WebSocketTransport) run() {
+	pongWait := 2 * WebSocketPingInterval
+	_ = t.conn.SetReadDeadline(time.Now().Add(pongWait))
+	t.conn.SetPongHandler(func(string) error {
+		return t.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	go t.ping()
+	defer t.disconnect()
+	for {
+		_, data, err := t.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = t.conn.SetReadDeadline(time.Now().Add(pongWait))
+		select {
+		case t.incoming <- data:
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// ping pings the client every WebSocketPingInterval until the transport closes
+func (t *WebSocketTransport) ping() {
+	ticker := time.NewTicker(WebSocketPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.writeMu.Lock()
+			err := t.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteTimeout))
+			t.writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// ReadMessage returns the next message sent by the client
+func (t *WebSocketTransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-t.incoming:
+		return data, nil
+	case <-t.done:
+		if atomic.LoadInt32(&t.disconnected) == 1 {
+			return nil, io.EOF
+		}
+		return nil, ErrTransportClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WriteMessage sends data as a text frame
+func (t *WebSocketTransport) WriteMessage(ctx context.Context, data []byte) error {
+	select {
+	case <-t.done:
+		return ErrTransportClosed
+	default:
+	}
+
+	deadline := time.Now().Add(webSocketWriteTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_ = t.conn.SetWriteDeadline(deadline)
+	return t.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// SupportsStreaming reports true: results can be streamed as frames on the connection
+func (t *WebSocketTransport) SupportsStreaming() bool {
+	return true
+}
+
+// Scopes returns the scopes of the API key the client connected with
+func (t *WebSocketTransport) Scopes() []string {
+	return t.scopes
+}
+
+// Close sends a close frame and closes the connection
+func (t *WebSocketTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.done)
+		t.writeMu.Lock()
+		_ = t.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server closed"),
+			time.Now().Add(webSocketWriteTimeout))
+		t.writeMu.Unlock()
+		err = t.conn.Close()
+	})
+	return err
+}
+
+// disconnect closes the transport after the client went away
+func (t *WebSocketTransport) disconnect() {
+	t.closeOnce.Do(func() {
+		atomic.StoreInt32(&t.disconnected, 1)
+		close(t.done)
+		_ = t.conn.Close()
+	})
+}
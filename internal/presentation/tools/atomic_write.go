@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// atomicWriteChunk is how much data is written between cancellation checks
+const atomicWriteChunk = 64 * 1024
+
+// writeFileAtomic writes data to a temporary file in the directory of path and renames
+// it over path once the data is synced, so a failed or cancelled write leaves any
+// existing file untouched. An existing file keeps its permissions and, when path is a
+// symlink, the file it points to is replaced. It returns the path written and whether
+// it replaced an existing file.
+func writeFileAtomic(ctx context.Context, path string, data []byte) (string, bool, error) {
+	// Write through symlinks, as os.WriteFile would, instead of replacing the link
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", false, err
+	}
+
+	perm := fs.FileMode(0600)
+	existed := false
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return "", false, ErrOverwriteDirectory
+		}
+		perm, existed = info.Mode().Perm(), true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", false, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", false, err
+	}
+	// Until the rename succeeds the temporary file is ours to clean up
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	for offset := 0; ; offset += atomicWriteChunk {
+		if err := ctx.Err(); err != nil {
+			return "", false, err
+		}
+		end := min(offset+atomicWriteChunk, len(data))
+		if _, err := tmp.Write(data[offset:end]); err != nil {
+			return "", false, err
+		}
+		if end == len(data) {
+			break
+		}
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return "", false, err
+	}
+	if err := tmp.Sync(); err != nil {
+		return "", false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", false, err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", false, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", false, err
+	}
+	committed = true
+	return path, existed, nil
+}
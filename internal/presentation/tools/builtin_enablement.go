@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownBuiltinTool is returned when tool enablement names a tool that is not built in
+var ErrUnknownBuiltinTool = errors.New("unknown built-in tool")
+
+// ConfigureBuiltinTools disables the built-in tools named in disabled and, when enabled
+// is not empty, every built-in tool it does not name. Disabled tools stay registered, so
+// they can be re-enabled at runtime, but tools/list leaves them out and calls to them
+// fail. Tools added after construction, such as manifest tools, are not affected.
+func (r *ToolRegistry) ConfigureBuiltinTools(enabled, disabled []string) error {
+	allowed := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		if !r.builtins[name] {
+			return fmt.Errorf("%w: %s", ErrUnknownBuiltinTool, name)
+		}
+		allowed[name] = true
+	}
+	for _, name := range disabled {
+		if !r.builtins[name] {
+			return fmt.Errorf("%w: %s", ErrUnknownBuiltinTool, name)
+		}
+	}
+
+	for name := range r.builtins {
+		if len(enabled) > 0 && !allowed[name] {
+			r.tools[name].Disable()
+		}
+	}
+	for _, name := range disabled {
+		r.tools[name].Disable()
+	}
+	return nil
+}
+
+// DisableMutatingTools disables every registered tool not annotated read-only, for
+// safe mode, and returns their names sorted. Tools without annotations, such as
+// manifest tools that do not declare readOnlyHint, count as mutating.
+func (r *ToolRegistry) DisableMutatingTools() []string {
+	var disabled []string
+	for name, tool := range r.tools {
+		if !tool.IsReadOnly() {
+			tool.Disable()
+			disabled = append(disabled, name)
+		}
+	}
+	sort.Strings(disabled)
+	return disabled
+}
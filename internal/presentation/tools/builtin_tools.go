@@ -3,14 +3,15 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
 	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
@@ -19,7 +20,25 @@ import (
 // ToolRegistry manages built-in tools
 type ToolRegistry struct {
 	claudeService services.IClaudeService
+	conversations *handlers.ConversationHandler
 	tools         map[string]*entities.Tool
+	builtins      map[string]bool // Names of the built-in tools, including the optional ones enabled
+	allowedPaths  []string
+	symlinkPolicy SymlinkPolicy
+	maxTokens     MaxTokensPolicy
+
+	maxResponseChars int
+	readFileMaxBytes int64
+	watcher          *FileWatcher
+
+	// Streams claude_conversation replies to callers that accept streamed output
+	streamResponses bool
+
+	// Kills execute_command and manifest exec commands that stay silent this long (0 disables)
+	execIdleTimeout time.Duration
+
+	// Commands manifest exec tools may run
+	manifestCommands map[string]bool
 }
 
 // NewToolRegistry creates a new tool registry
@@ -27,10 +46,22 @@ func NewToolRegistry(claudeService services.IClaudeService) *ToolRegistry {
 	registry := &ToolRegistry{
 		claudeService: claudeService,
 		tools:         make(map[string]*entities.Tool),
+		symlinkPolicy: SymlinkResolveAndCheck,
+		maxTokens:     MaxTokensPolicy{Default: DefaultMaxTokens},
+
+		maxResponseChars: DefaultMaxResponseChars,
+		readFileMaxBytes: DefaultReadFileMaxBytes,
+		watcher:          NewFileWatcher(),
+
+		streamResponses: true,
 	}
 
 	// Register built-in tools
 	registry.registerBuiltinTools()
+	registry.builtins = make(map[string]bool, len(registry.tools))
+	for name := range registry.tools {
+		registry.builtins[name] = true
+	}
 
 	return registry
 }
@@ -57,11 +88,20 @@ func (r *ToolRegistry) registerBuiltinTools() {
 
 	// File tools
 	r.registerReadFile()
+	r.registerReadConfig()
 	r.registerWriteFile()
 	r.registerListDirectory()
-
-	// Shell tool
+	r.registerDirectoryTree()
+	r.registerWatchFile()
+	r.registerUnwatchFile()
+	r.registerMoveFile()
+	r.registerCopyFile()
+	r.registerDeleteFile()
+
+	// Shell tools
 	r.registerExecuteCommand()
+	r.registerSetWorkingDir()
+	r.registerGetWorkingDir()
 
 	// Search tool
 	r.registerSearchFiles()
@@ -96,7 +136,37 @@ func (r *ToolRegistry) registerClaudeConversation() {
 			},
 			"max_tokens": {
 				Type:        "integer",
-				Description: "Maximum tokens in the response (default: 4096)",
+				Description: "Maximum tokens in the response (default: 4096, bounded by the model's output limit)",
+			},
+			"conversation_id": {
+				Type:        "string",
+				Description: "Optional: continue this conversation instead of starting a new one",
+			},
+			"resume_token": {
+				Type:        "string",
+				Description: "Optional: continue the conversation named by a resumeToken returned from an earlier call, including from another session; a conversation_id given with it must name the same conversation",
+			},
+			"template": {
+				Type:        "string",
+				Description: "Optional: start the new conversation from this conversation template, listed by conversationTemplates/list; model, system_prompt, max_tokens and tools given alongside override it",
+			},
+			"tools": {
+				Type:        "array",
+				Description: "Optional: names of tools Claude may use while answering a new conversation",
+				Items:       &entities.JSONSchema{Type: "string"},
+			},
+			"attachments": {
+				Type:        "array",
+				Description: "Optional: resource URIs, such as file:///path, whose content is inlined into the message when it is sent; the conversation history keeps only the references",
+				Items:       &entities.JSONSchema{Type: "string"},
+			},
+			"stream": {
+				Type:        "boolean",
+				Description: "Optional: stream the reply as progress notifications while it is generated, when the request carries a progress token (default: server setting)",
+			},
+			"edit_last_message": {
+				Type:        "boolean",
+				Description: "Optional: replace the last user message of the conversation given by conversation_id or resume_token with message and answer it again, such as after a failed request",
 			},
 		},
 		Required: []string{"message"},
@@ -105,14 +175,22 @@ func (r *ToolRegistry) registerClaudeConversation() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("ai")
 	tool.SetTags([]string{"claude", "conversation", "ai"})
-	tool.SetHandler(r.handleClaudeConversation)
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Claude Conversation", OpenWorldHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"message": "Summarize the key points of the MCP specification"},
+		{"message": "Review this function for bugs", "system_prompt": "You are a careful code reviewer", "max_tokens": 1024},
+	})
+	tool.SetContextHandler(r.handleClaudeConversation)
 	tool.SetTimeout(120 * time.Second)
 
 	r.tools["claude_conversation"] = tool
 }
 
-// handleClaudeConversation handles Claude conversation requests
-func (r *ToolRegistry) handleClaudeConversation(input map[string]interface{}) (*entities.ToolResult, error) {
+// handleClaudeConversation handles Claude conversation requests. The request context is
+// passed to the Claude client so cancelling the tool call aborts the upstream request.
+// Within a session and with a conversation handler set, the message runs as an agentic
+// turn and the full reply is kept in the conversation history.
+func (r *ToolRegistry) handleClaudeConversation(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
 	message, ok := input["message"].(string)
 	if !ok || message == "" {
 		return entities.NewErrorToolResult(fmt.Errorf("message is required")), nil
@@ -124,9 +202,16 @@ func (r *ToolRegistry) handleClaudeConversation(input map[string]interface{}) (*
 		model = vo.Model(m)
 	}
 
-	maxTokens := 4096
+	var requested int
 	if mt, ok := input["max_tokens"].(float64); ok {
-		maxTokens = int(mt)
+		if mt != float64(int(mt)) || mt <= 0 {
+			return entities.NewErrorToolResult(ErrInvalidMaxTokens), nil
+		}
+		requested = int(mt)
+	}
+	maxTokens, err := r.maxTokens.Resolve(model, requested)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
 	}
 
 	var systemPrompt vo.SystemPrompt
@@ -134,29 +219,72 @@ func (r *ToolRegistry) handleClaudeConversation(input map[string]interface{}) (*
 		systemPrompt, _ = vo.NewSystemPrompt(sp)
 	}
 
-	request := &services.ClaudeRequest{
-		Model:        model,
-		SystemPrompt: systemPrompt,
-		Messages: []services.ClaudeMessage{
-			{
-				Role: vo.RoleUser,
-				Content: []entities.ContentBlock{
-					{Type: vo.ContentTypeText, Text: message},
-				},
-			},
-		},
-		MaxTokens: maxTokens,
-	}
-
 	// Call Claude API
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
 	defer cancel()
 
-	response, err := r.claudeService.CreateMessage(ctx, request)
+	var (
+		response       *services.ClaudeResponse
+		turn           *handlers.TurnResult
+		conversationID vo.ConversationID
+	)
+	attachments, err := stringList(input, "attachments")
 	if err != nil {
 		return entities.NewErrorToolResult(err), nil
 	}
 
+	stream := r.responseStream(ctx, input)
+	editLast, _ := input["edit_last_message"].(bool)
+
+	if sessionID, ok := entities.SessionIDFromContext(ctx); ok && r.conversations != nil {
+		turn, conversationID, err = r.runConversationTurn(ctx, sessionID, input, conversationTurnRequest{
+			message:      message,
+			model:        model,
+			systemPrompt: systemPrompt,
+			maxTokens:    maxTokens,
+			attachments:  attachments,
+			stream:       stream,
+			editLast:     editLast,
+		})
+		if err != nil {
+			// The message stays in the conversation, so the caller can edit and resend it
+			result := claudeErrorResult(err)
+			if !conversationID.IsEmpty() {
+				result.SetMeta("conversationId", conversationID.String())
+			}
+			return result, nil
+		}
+		response = turn.Response
+	} else if len(attachments) > 0 {
+		return entities.NewErrorToolResult(ErrAttachmentsNeedConversation), nil
+	} else if editLast {
+		return entities.NewErrorToolResult(ErrEditNeedsConversation), nil
+	} else if template, _ := input["template"].(string); template != "" {
+		return entities.NewErrorToolResult(ErrTemplateNeedsConversation), nil
+	} else {
+		request := &services.ClaudeRequest{
+			Model:        model,
+			SystemPrompt: systemPrompt,
+			Messages: []services.ClaudeMessage{
+				{
+					Role: vo.RoleUser,
+					Content: []entities.ContentBlock{
+						{Type: vo.ContentTypeText, Text: message},
+					},
+				},
+			},
+			MaxTokens: maxTokens,
+		}
+		if stream {
+			response, err = handlers.StreamMessage(ctx, r.claudeService, request)
+		} else {
+			response, err = r.claudeService.CreateMessage(ctx, request)
+		}
+		if err != nil {
+			return claudeErrorResult(err), nil
+		}
+	}
+
 	// Extract text content
 	var text string
 	for _, block := range response.Content {
@@ -165,7 +293,54 @@ func (r *ToolRegistry) handleClaudeConversation(input map[string]interface{}) (*
 		}
 	}
 
-	return entities.NewTextToolResult(text), nil
+	// Only the returned text is capped; a turn still keeps the full reply in the
+	// conversation history
+	returned, truncated := truncateResponse(text, r.maxResponseChars)
+
+	result := entities.NewTextToolResult(returned)
+	result.SetMeta("model", response.Model)
+	if truncated {
+		result.SetMeta("truncated", true)
+		result.SetMeta("fullLength", utf8.RuneCountInString(text))
+	}
+	if requested > maxTokens {
+		result.SetMeta("maxTokensClamped", maxTokens)
+	}
+	if response.StopReason != "" {
+		result.SetMeta("stopReason", response.StopReason)
+	}
+	if response.StopSequence != "" {
+		result.SetMeta("stopSequence", response.StopSequence)
+	}
+	if response.Usage != nil {
+		result.SetMeta("usage", response.Usage)
+	}
+	if turn != nil {
+		setTurnMeta(result, conversationID, turn)
+		if token, err := r.conversations.IssueResumeToken(conversationID); err == nil {
+			result.SetMeta("resumeToken", token)
+		}
+	}
+
+	return result, nil
+}
+
+// claudeErrorResult returns the error result for a failed Claude call. Errors from
+// the Claude API carry their category, such as rate_limit or authentication, and
+// whether retrying can help, so callers can tell a bad key from a busy API.
+func claudeErrorResult(err error) *entities.ToolResult {
+	result := entities.NewErrorToolResult(err)
+	claudeErr, ok := services.AsClaudeError(err)
+	if !ok {
+		return result
+	}
+	result.SetMeta("errorCategory", string(claudeErr.Category()))
+	result.SetMeta("retryable", claudeErr.Retryable())
+	var rateLimited *services.RateLimitError
+	if errors.As(err, &rateLimited) && rateLimited.RetryAfter > 0 {
+		result.SetMeta("retryAfterMs", rateLimited.RetryAfter.Milliseconds())
+	}
+	return result
 }
 
 // registerReadFile registers the read file tool
@@ -173,6 +348,7 @@ func (r *ToolRegistry) registerReadFile() {
 	name, _ := vo.NewToolName("read_file")
 	desc, _ := vo.NewToolDescription("Read the contents of a file at the specified path")
 
+	minOffset := 0.0
 	schema := &entities.JSONSchema{
 		Type: "object",
 		Properties: map[string]*entities.JSONSchema{
@@ -184,6 +360,11 @@ func (r *ToolRegistry) registerReadFile() {
 				Type:        "string",
 				Description: "The encoding to use (default: utf-8)",
 			},
+			"offset": {
+				Type:        "integer",
+				Description: "Byte offset to start reading from, e.g. to continue a truncated read (default: 0)",
+				Minimum:     &minOffset,
+			},
 		},
 		Required: []string{"path"},
 	}
@@ -191,35 +372,45 @@ func (r *ToolRegistry) registerReadFile() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("file")
 	tool.SetTags([]string{"file", "read"})
-	tool.SetHandler(handleReadFile)
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Read File", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": "README.md"},
+	})
+	tool.SetCacheable(true)
+	tool.SetContextHandler(r.handleReadFile)
 
 	r.tools["read_file"] = tool
 }
 
-func handleReadFile(input map[string]interface{}) (*entities.ToolResult, error) {
-	path, ok := input["path"].(string)
-	if !ok || path == "" {
-		return entities.NewErrorToolResult(fmt.Errorf("path is required")), nil
-	}
-
-	// Security: Prevent path traversal
-	absPath, err := filepath.Abs(path)
+func (r *ToolRegistry) handleReadFile(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	path, _ := input["path"].(string)
+	absPath, err := r.resolveToolPath(ctx, path)
 	if err != nil {
 		return entities.NewErrorToolResult(err), nil
 	}
 
-	content, err := os.ReadFile(absPath) //nolint:gosec // G304: path is sanitized via filepath.Abs
+	var offset int64
+	if o, ok := input["offset"].(float64); ok {
+		offset = int64(o)
+	}
+
+	read, err := readFileRange(absPath, offset, r.readFileMaxBytes)
 	if err != nil {
 		return entities.NewErrorToolResult(err), nil
 	}
 
-	return entities.NewTextToolResult(string(content)), nil
+	result := entities.NewTextToolResult(string(read.content))
+	result.SetMeta("totalBytes", read.totalSize)
+	result.SetMeta("bytesReturned", len(read.content))
+	result.SetMeta("offset", read.offset)
+	result.SetMeta("truncated", read.truncated)
+	return result, nil
 }
 
 // registerWriteFile registers the write file tool
 func (r *ToolRegistry) registerWriteFile() {
 	name, _ := vo.NewToolName("write_file")
-	desc, _ := vo.NewToolDescription("Write content to a file at the specified path")
+	desc, _ := vo.NewToolDescription("Write content to a file at the specified path, replacing it atomically so a failed write leaves the original intact")
 
 	schema := &entities.JSONSchema{
 		Type: "object",
@@ -243,14 +434,18 @@ func (r *ToolRegistry) registerWriteFile() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("file")
 	tool.SetTags([]string{"file", "write"})
-	tool.SetHandler(handleWriteFile)
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Write File", DestructiveHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": "notes/todo.txt", "content": "- write tests\n", "create_dirs": true},
+	})
+	tool.SetContextHandler(r.handleWriteFile)
 
 	r.tools["write_file"] = tool
 }
 
-func handleWriteFile(input map[string]interface{}) (*entities.ToolResult, error) {
-	path, ok := input["path"].(string)
-	if !ok || path == "" {
+func (r *ToolRegistry) handleWriteFile(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	path, _ := input["path"].(string)
+	if path == "" {
 		return entities.NewErrorToolResult(fmt.Errorf("path is required")), nil
 	}
 
@@ -259,7 +454,7 @@ func handleWriteFile(input map[string]interface{}) (*entities.ToolResult, error)
 		return entities.NewErrorToolResult(fmt.Errorf("content is required")), nil
 	}
 
-	absPath, err := filepath.Abs(path)
+	absPath, err := r.resolveToolPath(ctx, path)
 	if err != nil {
 		return entities.NewErrorToolResult(err), nil
 	}
@@ -272,11 +467,19 @@ func handleWriteFile(input map[string]interface{}) (*entities.ToolResult, error)
 		}
 	}
 
-	if err := os.WriteFile(absPath, []byte(content), 0600); err != nil {
+	written, overwrote, err := writeFileAtomic(ctx, absPath, []byte(content))
+	if err != nil {
 		return entities.NewErrorToolResult(err), nil
 	}
 
-	return entities.NewTextToolResult(fmt.Sprintf("Successfully wrote %d bytes to %s", len(content), absPath)), nil
+	return entities.NewJSONToolResult(&FileOperation{
+		Operation: "write",
+		Path:      written,
+		Type:      "file",
+		Files:     1,
+		Bytes:     int64(len(content)),
+		Overwrote: overwrote,
+	}), nil
 }
 
 // registerListDirectory registers the list directory tool
@@ -302,18 +505,20 @@ func (r *ToolRegistry) registerListDirectory() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("file")
 	tool.SetTags([]string{"file", "directory", "list"})
-	tool.SetHandler(handleListDirectory)
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "List Directory", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": "."},
+		{"path": "internal", "recursive": true},
+	})
+	tool.SetCacheable(true)
+	tool.SetContextHandler(r.handleListDirectory)
 
 	r.tools["list_directory"] = tool
 }
 
-func handleListDirectory(input map[string]interface{}) (*entities.ToolResult, error) {
-	path, ok := input["path"].(string)
-	if !ok || path == "" {
-		return entities.NewErrorToolResult(fmt.Errorf("path is required")), nil
-	}
-
-	absPath, err := filepath.Abs(path)
+func (r *ToolRegistry) handleListDirectory(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	path, _ := input["path"].(string)
+	absPath, err := r.resolveToolPath(ctx, path)
 	if err != nil {
 		return entities.NewErrorToolResult(err), nil
 	}
@@ -349,7 +554,7 @@ func (r *ToolRegistry) registerExecuteCommand() {
 			},
 			"working_dir": {
 				Type:        "string",
-				Description: "The working directory for the command",
+				Description: "The working directory for the command (default: the session working directory)",
 			},
 			"timeout": {
 				Type:        "integer",
@@ -362,13 +567,18 @@ func (r *ToolRegistry) registerExecuteCommand() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("system")
 	tool.SetTags([]string{"command", "shell", "execute"})
-	tool.SetHandler(handleExecuteCommand)
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Execute Command", DestructiveHint: true, OpenWorldHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"command": "go version"},
+		{"command": "ls -la", "working_dir": "/tmp", "timeout": 10},
+	})
+	tool.SetContextHandler(r.handleExecuteCommand)
 	tool.SetTimeout(60 * time.Second)
 
 	r.tools["execute_command"] = tool
 }
 
-func handleExecuteCommand(input map[string]interface{}) (*entities.ToolResult, error) {
+func (r *ToolRegistry) handleExecuteCommand(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
 	command, ok := input["command"].(string)
 	if !ok || command == "" {
 		return entities.NewErrorToolResult(fmt.Errorf("command is required")), nil
@@ -379,17 +589,19 @@ func handleExecuteCommand(input map[string]interface{}) (*entities.ToolResult, e
 		timeout = int(t)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // G204: command execution is intentional for shell tool
-
-	if workingDir, ok := input["working_dir"].(string); ok && workingDir != "" {
-		cmd.Dir = workingDir
+	workingDir, _ := input["working_dir"].(string)
+	if workingDir == "" {
+		workingDir = sessionWorkingDir(ctx)
 	}
 
-	output, err := cmd.CombinedOutput()
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	output, err := runCommand(ctx, r.execIdleTimeout, workingDir, "sh", "-c", command)
 	if err != nil {
+		if errors.Is(err, ErrCommandIdle) {
+			return entities.NewErrorToolResult(fmt.Errorf("%w\nOutput: %s", err, output)), nil
+		}
 		if ctx.Err() == context.DeadlineExceeded {
 			return entities.NewErrorToolResult(fmt.Errorf("command timed out after %d seconds", timeout)), nil
 		}
@@ -426,14 +638,20 @@ func (r *ToolRegistry) registerSearchFiles() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("file")
 	tool.SetTags([]string{"file", "search", "find"})
-	tool.SetHandler(handleSearchFiles)
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Search Files", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": ".", "pattern": "*.go"},
+		{"path": "internal", "pattern": "*.go", "content_pattern": "TODO"},
+	})
+	tool.SetCacheable(true)
+	tool.SetContextHandler(r.handleSearchFiles)
 
 	r.tools["search_files"] = tool
 }
 
-func handleSearchFiles(input map[string]interface{}) (*entities.ToolResult, error) {
-	path, ok := input["path"].(string)
-	if !ok || path == "" {
+func (r *ToolRegistry) handleSearchFiles(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	path, _ := input["path"].(string)
+	if path == "" {
 		return entities.NewErrorToolResult(fmt.Errorf("path is required")), nil
 	}
 
@@ -442,7 +660,7 @@ func handleSearchFiles(input map[string]interface{}) (*entities.ToolResult, erro
 		return entities.NewErrorToolResult(fmt.Errorf("pattern is required")), nil
 	}
 
-	absPath, err := filepath.Abs(path)
+	absPath, err := r.resolveToolPath(ctx, path)
 	if err != nil {
 		return entities.NewErrorToolResult(err), nil
 	}
@@ -488,6 +706,7 @@ func (r *ToolRegistry) registerSystemInfo() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("system")
 	tool.SetTags([]string{"system", "info"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "System Info", ReadOnlyHint: true, IdempotentHint: true})
 	tool.SetHandler(handleSystemInfo)
 
 	r.tools["system_info"] = tool
@@ -508,8 +727,7 @@ func handleSystemInfo(input map[string]interface{}) (*entities.ToolResult, error
 		"time":        time.Now().Format(time.RFC3339),
 	}
 
-	data, _ := json.MarshalIndent(info, "", "  ")
-	return entities.NewTextToolResult(string(data)), nil
+	return entities.NewJSONToolResult(info), nil
 }
 
 // registerEcho registers the echo tool (for testing)
@@ -531,6 +749,10 @@ func (r *ToolRegistry) registerEcho() {
 	tool, _ := entities.NewTool(name, desc, schema)
 	tool.SetCategory("utility")
 	tool.SetTags([]string{"test", "echo"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Echo", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"message": "Hello, MCP!"},
+	})
 	tool.SetHandler(handleEcho)
 
 	r.tools["echo"] = tool
@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+// SetStreamResponses sets whether claude_conversation streams replies by default.
+// Calls can override it with the stream input.
+func (r *ToolRegistry) SetStreamResponses(enabled bool) {
+	r.streamResponses = enabled
+}
+
+// responseStream decides whether a claude_conversation call streams its reply. Only a
+// caller that accepts streamed output, such as a tools/call carrying a progress token,
+// has anyone to stream to.
+func (r *ToolRegistry) responseStream(ctx context.Context, input map[string]interface{}) bool {
+	if _, ok := entities.ToolOutputFromContext(ctx); !ok {
+		return false
+	}
+	stream := r.streamResponses
+	if requested, ok := input["stream"].(bool); ok {
+		stream = requested
+	}
+	return stream
+}
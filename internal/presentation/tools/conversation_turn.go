@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Conversation turn errors
+var (
+	ErrConversationSessionMismatch = errors.New("conversation belongs to another session")
+	ErrRecursiveConversationTool   = errors.New("claude_conversation cannot offer itself as a tool")
+	ErrAttachmentsNeedConversation = errors.New("attachments are only supported for messages sent within a session conversation")
+	ErrTemplateNeedsConversation   = errors.New("templates are only supported for messages sent within a session conversation")
+	ErrEditNeedsConversation       = errors.New("edit_last_message needs the conversation_id or resume_token of the conversation to edit")
+	ErrEditWithAttachments         = errors.New("attachments cannot be given with edit_last_message")
+	ErrResumeTokenMismatch         = errors.New("resume_token and conversation_id name different conversations")
+)
+
+// SetConversationHandler routes claude_conversation through the agentic loop of the
+// calling session's conversations, so Claude can use tools and every reply is kept in
+// the conversation history. Without one, each message is a single stateless request.
+func (r *ToolRegistry) SetConversationHandler(handler *handlers.ConversationHandler) {
+	r.conversations = handler
+}
+
+// conversationTurnRequest holds the claude_conversation inputs used to start a turn
+type conversationTurnRequest struct {
+	message      string
+	model        vo.Model
+	systemPrompt vo.SystemPrompt
+	maxTokens    int
+	attachments  []string
+	stream       bool
+	editLast     bool
+}
+
+// runConversationTurn runs the message as a turn in the conversation named by the
+// conversation_id input, or in a new conversation of the calling session. With
+// edit_last_message, the message replaces the last user message of an existing
+// conversation and Claude answers it again.
+func (r *ToolRegistry) runConversationTurn(ctx context.Context, sessionID vo.SessionID, input map[string]interface{}, req conversationTurnRequest) (*handlers.TurnResult, vo.ConversationID, error) {
+	if req.editLast {
+		token, _ := input["resume_token"].(string)
+		id, _ := input["conversation_id"].(string)
+		if token == "" && id == "" {
+			return nil, vo.ConversationID{}, ErrEditNeedsConversation
+		}
+		if len(req.attachments) > 0 {
+			return nil, vo.ConversationID{}, ErrEditWithAttachments
+		}
+	}
+
+	conversation, err := r.turnConversation(ctx, sessionID, input, req)
+	if err != nil {
+		return nil, vo.ConversationID{}, err
+	}
+
+	turn, err := r.conversations.HandleRunTurn(ctx, &commands.RunTurnCommand{
+		ConversationID:  conversation.ID(),
+		Content:         req.message,
+		Attachments:     req.attachments,
+		Stream:          req.stream,
+		EditLastMessage: req.editLast,
+	})
+	if err != nil {
+		return nil, conversation.ID(), err
+	}
+	return turn, conversation.ID(), nil
+}
+
+// turnConversation loads the conversation being continued or creates one. Template,
+// model, system prompt, max_tokens and tools only apply to new conversations; with a
+// template, only the model and max_tokens given explicitly override the template's. A resume
+// token may continue a conversation of another session, as stateless clients get a
+// new session per request; a conversation_id given with it must name the same one.
+func (r *ToolRegistry) turnConversation(ctx context.Context, sessionID vo.SessionID, input map[string]interface{}, req conversationTurnRequest) (*aggregates.Conversation, error) {
+	if token, ok := input["resume_token"].(string); ok && token != "" {
+		conversation, err := r.conversations.HandleResumeConversation(ctx, &queries.ResumeConversationQuery{Token: token})
+		if err != nil {
+			return nil, err
+		}
+		if id, ok := input["conversation_id"].(string); ok && id != "" && id != conversation.ID().String() {
+			return nil, ErrResumeTokenMismatch
+		}
+		return conversation, nil
+	}
+	if id, ok := input["conversation_id"].(string); ok && id != "" {
+		conversationID, err := vo.NewConversationID(id)
+		if err != nil {
+			return nil, err
+		}
+		conversation, err := r.conversations.HandleGetConversation(ctx, &queries.GetConversationQuery{ConversationID: conversationID})
+		if err != nil {
+			return nil, err
+		}
+		if !conversation.SessionID().Equals(sessionID) {
+			return nil, ErrConversationSessionMismatch
+		}
+		return conversation, nil
+	}
+
+	cmd := &commands.CreateConversationCommand{
+		SessionID:    sessionID,
+		Model:        req.model,
+		SystemPrompt: req.systemPrompt.String(),
+		MaxTokens:    req.maxTokens,
+	}
+	if template, ok := input["template"].(string); ok && template != "" {
+		cmd.Template = template
+		if _, ok := input["model"].(string); !ok {
+			cmd.Model = ""
+		}
+		if _, ok := input["max_tokens"].(float64); !ok {
+			cmd.MaxTokens = 0
+		}
+	}
+
+	toolNames, err := stringList(input, "tools")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range toolNames {
+		if name == "claude_conversation" {
+			return nil, ErrRecursiveConversationTool
+		}
+	}
+	if len(toolNames) > 0 {
+		settings := vo.DefaultToolUseSettings()
+		settings.AllowedTools = toolNames
+		cmd.ToolUse = &settings
+	}
+
+	return r.conversations.HandleCreateConversation(ctx, cmd)
+}
+
+// stringList returns the named input as a list of non-empty strings, such as the tools
+// Claude may use during the turn or the resource URIs attached to the message
+func stringList(input map[string]interface{}, key string) ([]string, error) {
+	raw, ok := input[key].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	values := make([]string, 0, len(raw))
+	for _, item := range raw {
+		value, ok := item.(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%s must be a list of non-empty strings", key)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// setTurnMeta records how the agentic turn behind a claude_conversation result ran
+func setTurnMeta(result *entities.ToolResult, conversationID vo.ConversationID, turn *handlers.TurnResult) {
+	result.SetMeta("conversationId", conversationID.String())
+	result.SetMeta("iterations", turn.Iterations)
+	if len(turn.ToolsInvoked) > 0 {
+		result.SetMeta("toolsInvoked", turn.ToolsInvoked)
+	}
+	if turn.IterationLimitReached {
+		result.SetMeta("iterationLimitReached", true)
+	}
+}
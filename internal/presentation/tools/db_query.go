@@ -0,0 +1,184 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// DBQueryScope is the scope required to call the db_query tool. The tables it reads
+// hold every client's conversations, so the scope must come from the caller's own
+// session rather than be granted server-wide.
+const DBQueryScope = "admin"
+
+// Database query limits
+const (
+	DefaultDBQueryMaxRows = 100
+	MaxDBQueryRows        = 1000
+	DefaultDBQueryTimeout = 5 * time.Second
+)
+
+// DefaultDBQueryTables are the tables of the server's own data that db_query may read
+var DefaultDBQueryTables = []string{"sessions", "conversations", "messages", "tools", "tool_executions"}
+
+// DBQueryOptions configures the db_query tool
+type DBQueryOptions struct {
+	// Tables the query may read (default: DefaultDBQueryTables)
+	Tables []string
+
+	// MaxRows caps the rows returned (default: DefaultDBQueryMaxRows)
+	MaxRows int
+
+	// Timeout bounds each query (default: DefaultDBQueryTimeout)
+	Timeout time.Duration
+}
+
+// DBQueryResult is the output of the db_query tool
+type DBQueryResult struct {
+	Columns   []string                 `json:"columns"`
+	Rows      []map[string]interface{} `json:"rows"`
+	RowCount  int                      `json:"row_count"`
+	Truncated bool                     `json:"truncated"`
+}
+
+// EnableDBQuery registers the db_query tool over db as a built-in, so tool enablement
+// can name it. Call it before ConfigureBuiltinTools.
+// It is not registered by default because it exposes the server's stored data.
+func (r *ToolRegistry) EnableDBQuery(db *sql.DB, opts DBQueryOptions) {
+	if len(opts.Tables) == 0 {
+		opts.Tables = DefaultDBQueryTables
+	}
+	if opts.MaxRows <= 0 {
+		opts.MaxRows = DefaultDBQueryMaxRows
+	}
+	if opts.MaxRows > MaxDBQueryRows {
+		opts.MaxRows = MaxDBQueryRows
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultDBQueryTimeout
+	}
+	r.registerDBQuery(db, opts)
+	r.builtins["db_query"] = true
+}
+
+// registerDBQuery registers the database query tool
+func (r *ToolRegistry) registerDBQuery(db *sql.DB, opts DBQueryOptions) {
+	name, _ := vo.NewToolName("db_query")
+	desc, _ := vo.NewToolDescription(fmt.Sprintf(
+		"Run a read-only SELECT query against the server's database. Only these tables may be read: %v", opts.Tables))
+
+	minRows, maxRows := 1.0, float64(opts.MaxRows)
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"query": {
+				Type:        "string",
+				Description: "A single SELECT (or WITH ... SELECT) statement",
+			},
+			"max_rows": {
+				Type:        "integer",
+				Description: fmt.Sprintf("Maximum number of rows to return (default: %d)", opts.MaxRows),
+				Minimum:     &minRows,
+				Maximum:     &maxRows,
+			},
+		},
+		Required: []string{"query"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("database")
+	tool.SetTags([]string{"database", "sql", "diagnostics"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Query Database", ReadOnlyHint: true})
+	tool.SetRequiredScope(DBQueryScope)
+	tool.SetContextHandler(func(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+		return handleDBQuery(ctx, db, opts, input)
+	})
+	tool.SetTimeout(opts.Timeout + 5*time.Second)
+
+	r.tools["db_query"] = tool
+}
+
+func handleDBQuery(ctx context.Context, db *sql.DB, opts DBQueryOptions, input map[string]interface{}) (*entities.ToolResult, error) {
+	query, ok := input["query"].(string)
+	if !ok || query == "" {
+		return entities.NewErrorToolResult(fmt.Errorf("query is required")), nil
+	}
+	limit := opts.MaxRows
+	if l, ok := input["max_rows"].(float64); ok {
+		limit = int(l)
+	}
+	if limit < 1 || limit > opts.MaxRows {
+		return entities.NewErrorToolResult(fmt.Errorf("max_rows must be between 1 and %d", opts.MaxRows)), nil
+	}
+
+	statement, err := ValidateReadOnlyQuery(query, opts.Tables)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// The read-only transaction backs up the query validation; it is never committed
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return entities.NewErrorToolResult(fmt.Errorf("failed to start read-only transaction: %w", err)), nil
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// One row past the limit tells whether the result was truncated
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT * FROM (\n%s\n) AS db_query LIMIT %d", statement, limit+1))
+	if err != nil {
+		return entities.NewErrorToolResult(dbQueryError(ctx, err)), nil
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	result := DBQueryResult{Columns: columns, Rows: make([]map[string]interface{}, 0)}
+	for rows.Next() {
+		if len(result.Rows) == limit {
+			result.Truncated = true
+			break
+		}
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return entities.NewErrorToolResult(err), nil
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			// Text columns may be scanned as bytes, which would marshal as base64
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return entities.NewErrorToolResult(dbQueryError(ctx, err)), nil
+	}
+	result.RowCount = len(result.Rows)
+
+	return entities.NewJSONToolResult(result), nil
+}
+
+// dbQueryError reports a query that ran out of time as such
+func dbQueryError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("query timed out: %w", err)
+	}
+	return err
+}
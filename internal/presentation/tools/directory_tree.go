@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Directory tree limits
+const (
+	DefaultTreeDepth = 3
+	MaxTreeDepth     = 10
+	DefaultTreeNodes = 1000
+	MaxTreeNodes     = 10000
+)
+
+// ErrPathNotAllowed is returned when a path is outside the allowed directories
+var ErrPathNotAllowed = errors.New("path is outside the allowed directories")
+
+// TreeNode is a file or directory in a directory tree.
+// File counts and total sizes only cover the nodes included in the tree.
+type TreeNode struct {
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	Size      int64       `json:"size,omitempty"`
+	FileCount int         `json:"file_count,omitempty"`
+	TotalSize int64       `json:"total_size,omitempty"`
+	Children  []*TreeNode `json:"children,omitempty"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// DirectoryTree is the result of the directory_tree tool
+type DirectoryTree struct {
+	Root      *TreeNode `json:"root"`
+	Nodes     int       `json:"nodes"`
+	Truncated bool      `json:"truncated"`
+}
+
+// SetAllowedPaths restricts the directories file tools may browse.
+// An empty list allows every path the client's roots allow.
+func (r *ToolRegistry) SetAllowedPaths(paths []string) {
+	r.allowedPaths = paths
+}
+
+// registerDirectoryTree registers the directory tree tool
+func (r *ToolRegistry) registerDirectoryTree() {
+	name, _ := vo.NewToolName("directory_tree")
+	desc, _ := vo.NewToolDescription("Render a directory tree with file counts and total size per directory")
+
+	minDepth, maxDepth := 1.0, float64(MaxTreeDepth)
+	minNodes, maxNodes := 1.0, float64(MaxTreeNodes)
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"path": {
+				Type:        "string",
+				Description: "The path to the root directory",
+			},
+			"max_depth": {
+				Type:        "integer",
+				Description: fmt.Sprintf("Maximum depth to descend (default: %d)", DefaultTreeDepth),
+				Minimum:     &minDepth,
+				Maximum:     &maxDepth,
+			},
+			"max_nodes": {
+				Type:        "integer",
+				Description: fmt.Sprintf("Maximum number of entries to include (default: %d)", DefaultTreeNodes),
+				Minimum:     &minNodes,
+				Maximum:     &maxNodes,
+			},
+			"format": {
+				Type:        "string",
+				Description: "Output format: text for an ASCII tree, json for a nested structure (default: text)",
+				Enum:        []interface{}{"text", "json"},
+			},
+		},
+		Required: []string{"path"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("file")
+	tool.SetTags([]string{"file", "directory", "tree"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Directory Tree", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": ".", "max_depth": 2},
+	})
+	tool.SetCacheable(true)
+	tool.SetContextHandler(r.handleDirectoryTree)
+	tool.SetTimeout(30 * time.Second)
+
+	r.tools["directory_tree"] = tool
+}
+
+func (r *ToolRegistry) handleDirectoryTree(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	path, ok := input["path"].(string)
+	if !ok || path == "" {
+		return entities.NewErrorToolResult(fmt.Errorf("path is required")), nil
+	}
+
+	maxDepth := DefaultTreeDepth
+	if d, ok := input["max_depth"].(float64); ok {
+		maxDepth = int(d)
+	}
+	if maxDepth < 1 || maxDepth > MaxTreeDepth {
+		return entities.NewErrorToolResult(fmt.Errorf("max_depth must be between 1 and %d", MaxTreeDepth)), nil
+	}
+
+	maxNodes := DefaultTreeNodes
+	if n, ok := input["max_nodes"].(float64); ok {
+		maxNodes = int(n)
+	}
+	if maxNodes < 1 || maxNodes > MaxTreeNodes {
+		return entities.NewErrorToolResult(fmt.Errorf("max_nodes must be between 1 and %d", MaxTreeNodes)), nil
+	}
+
+	format, _ := input["format"].(string)
+	if format == "" {
+		format = "text"
+	}
+	if format != "text" && format != "json" {
+		return entities.NewErrorToolResult(fmt.Errorf("format must be text or json")), nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if err := r.CheckPath(ctx, absPath); err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	tree, err := BuildDirectoryTree(absPath, maxDepth, maxNodes)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	if format == "json" {
+		return entities.NewJSONToolResult(tree), nil
+	}
+	return entities.NewTextToolResult(RenderDirectoryTree(tree)), nil
+}
+
+// BuildDirectoryTree walks a directory up to maxDepth levels, including at most maxNodes entries.
+// Symbolic links are listed but not followed.
+func BuildDirectoryTree(root string, maxDepth, maxNodes int) (*DirectoryTree, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	tree := &DirectoryTree{Root: &TreeNode{Name: filepath.Base(root), Type: "directory"}}
+	walker := &treeWalker{tree: tree, maxDepth: maxDepth, maxNodes: maxNodes}
+	walker.walk(root, tree.Root, 1)
+	return tree, nil
+}
+
+type treeWalker struct {
+	tree     *DirectoryTree
+	maxDepth int
+	maxNodes int
+}
+
+// walk adds the entries of dir to node, rolling file counts and sizes up into it
+func (w *treeWalker) walk(dir string, node *TreeNode, depth int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		node.Truncated = true
+		return
+	}
+
+	for _, entry := range entries {
+		if w.tree.Nodes >= w.maxNodes {
+			node.Truncated = true
+			w.tree.Truncated = true
+			return
+		}
+		w.tree.Nodes++
+
+		if entry.IsDir() {
+			child := &TreeNode{Name: entry.Name(), Type: "directory"}
+			if depth < w.maxDepth {
+				w.walk(filepath.Join(dir, entry.Name()), child, depth+1)
+			} else {
+				child.Truncated = true
+			}
+			node.FileCount += child.FileCount
+			node.TotalSize += child.TotalSize
+			node.Children = append(node.Children, child)
+			continue
+		}
+
+		child := &TreeNode{Name: entry.Name(), Type: "file"}
+		if info, err := entry.Info(); err == nil {
+			child.Size = info.Size()
+		}
+		node.FileCount++
+		node.TotalSize += child.Size
+		node.Children = append(node.Children, child)
+	}
+}
+
+// RenderDirectoryTree renders a directory tree as ASCII text, like the tree command
+func RenderDirectoryTree(tree *DirectoryTree) string {
+	var b strings.Builder
+	b.WriteString(treeNodeLabel(tree.Root))
+	b.WriteString("\n")
+	renderTreeChildren(&b, tree.Root, "")
+	if tree.Truncated {
+		fmt.Fprintf(&b, "\n(truncated after %d entries)\n", tree.Nodes)
+	}
+	return b.String()
+}
+
+func renderTreeChildren(b *strings.Builder, node *TreeNode, prefix string) {
+	for i, child := range node.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		b.WriteString(prefix + branch + treeNodeLabel(child) + "\n")
+		renderTreeChildren(b, child, prefix+indent)
+	}
+}
+
+func treeNodeLabel(node *TreeNode) string {
+	if node.Type != "directory" {
+		return fmt.Sprintf("%s (%d B)", node.Name, node.Size)
+	}
+	label := fmt.Sprintf("%s/ (%d files, %d B)", node.Name, node.FileCount, node.TotalSize)
+	if node.Truncated {
+		label += " [truncated]"
+	}
+	return label
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+// ErrCommandIdle is returned when a command produces no output for the idle timeout
+var ErrCommandIdle = errors.New("command produced no output within the idle timeout")
+
+// commandWaitDelay bounds how long Wait blocks on output pipes held open by
+// descendants that escaped the process group kill
+const commandWaitDelay = time.Second
+
+// SetExecIdleTimeout sets how long a command may run without writing to stdout or
+// stderr before it is killed (0 disables the idle timeout)
+func (r *ToolRegistry) SetExecIdleTimeout(timeout time.Duration) {
+	r.execIdleTimeout = timeout
+}
+
+// activityBuffer collects combined output, records when it was last written and,
+// with emit set, streams the output line by line as it arrives
+type activityBuffer struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	lastWrite time.Time
+	emit      func(chunk string)
+	pending   []byte
+}
+
+func (b *activityBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(p) > 0 {
+		b.lastWrite = time.Now()
+	}
+	if b.emit != nil {
+		b.pending = append(b.pending, p...)
+		if i := bytes.LastIndexByte(b.pending, '\n'); i >= 0 {
+			b.emit(string(b.pending[:i+1]))
+			b.pending = append(b.pending[:0], b.pending[i+1:]...)
+		}
+	}
+	return b.buf.Write(p)
+}
+
+// flush streams output left without a trailing newline
+func (b *activityBuffer) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.emit != nil && len(b.pending) > 0 {
+		b.emit(string(b.pending))
+		b.pending = nil
+	}
+}
+
+func (b *activityBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+// runCommand runs a command in its own process group and returns its combined output,
+// which is also streamed through entities.EmitToolOutput as complete lines when ctx
+// carries a receiver. The whole group is killed when ctx ends or, with idle > 0, when
+// the command writes nothing for idle, in which case the error wraps ErrCommandIdle.
+func runCommand(ctx context.Context, idle time.Duration, dir, name string, args ...string) ([]byte, error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, name, args...) // #nosec G204 -- callers decide what may be run
+	cmd.Dir = dir
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error { return killProcessGroup(cmd) }
+	cmd.WaitDelay = commandWaitDelay
+
+	output := &activityBuffer{}
+	if _, ok := entities.ToolOutputFromContext(ctx); ok {
+		output.emit = func(chunk string) { entities.EmitToolOutput(ctx, chunk) }
+	}
+	cmd.Stdout, cmd.Stderr = output, output
+
+	// The timer decides under the output lock, so output written as it fires keeps the
+	// command alive instead of racing the kill
+	var idled atomic.Bool
+	if idle > 0 {
+		var timer *time.Timer
+		output.mu.Lock()
+		output.lastWrite = time.Now()
+		timer = time.AfterFunc(idle, func() {
+			output.mu.Lock()
+			defer output.mu.Unlock()
+			if quiet := time.Since(output.lastWrite); quiet < idle {
+				timer.Reset(idle - quiet)
+				return
+			}
+			idled.Store(true)
+			cancel()
+		})
+		output.mu.Unlock()
+		defer timer.Stop()
+	}
+
+	err := cmd.Run()
+	output.flush()
+	if idled.Load() {
+		return output.Bytes(), fmt.Errorf("%w (%s)", ErrCommandIdle, idle)
+	}
+	return output.Bytes(), err
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package tools
+
+import "os/exec"
+
+// setProcessGroup is a no-op where process groups are not supported
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the command; its children are not tracked on this platform
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,422 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// File operation errors
+var (
+	ErrDeleteNotConfirmed  = errors.New("delete requires confirm to be true")
+	ErrRecursiveRequired   = errors.New("path is a directory; set recursive to true")
+	ErrDestinationExists   = errors.New("destination already exists; set overwrite to true to replace it")
+	ErrProtectedPath       = errors.New("refusing to modify an allowed root or the filesystem root")
+	ErrOverwriteDirectory  = errors.New("refusing to overwrite a directory")
+	ErrDestinationInSource = errors.New("destination is inside the source directory")
+)
+
+// FileOperation describes what a move, copy or delete affected
+type FileOperation struct {
+	Operation   string `json:"operation"`
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Type        string `json:"type"`
+	Files       int    `json:"files"`
+	Bytes       int64  `json:"bytes"`
+	Overwrote   bool   `json:"overwrote,omitempty"`
+}
+
+// resolveToolPath resolves a path against the session working directory and checks the allowlist
+func (r *ToolRegistry) resolveToolPath(ctx context.Context, path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(sessionWorkingDir(ctx), path)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if err := r.CheckPath(ctx, absPath); err != nil {
+		return "", err
+	}
+	return absPath, nil
+}
+
+// isProtectedPath reports whether path is the filesystem root or one of the allowed roots
+func (r *ToolRegistry) isProtectedPath(ctx context.Context, absPath string) bool {
+	if absPath == filepath.Dir(absPath) {
+		return true
+	}
+	for _, dir := range r.allowlist(ctx).dirs {
+		if absPath == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// transferPaths resolves the source and destination of a move or copy
+func (r *ToolRegistry) transferPaths(ctx context.Context, input map[string]interface{}) (string, string, error) {
+	source, _ := input["source"].(string)
+	destination, _ := input["destination"].(string)
+	if source == "" || destination == "" {
+		return "", "", fmt.Errorf("source and destination are required")
+	}
+
+	src, err := r.resolveToolPath(ctx, source)
+	if err != nil {
+		return "", "", err
+	}
+	dst, err := r.resolveToolPath(ctx, destination)
+	if err != nil {
+		return "", "", err
+	}
+	if src == dst {
+		return "", "", fmt.Errorf("source and destination are the same path")
+	}
+	if strings.HasPrefix(dst, src+string(filepath.Separator)) {
+		return "", "", ErrDestinationInSource
+	}
+	return src, dst, nil
+}
+
+// prepareDestination checks whether dst may be written and removes a file being overwritten
+func prepareDestination(dst string, overwrite bool) (bool, error) {
+	info, err := os.Lstat(dst)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !overwrite {
+		return false, fmt.Errorf("%w: %s", ErrDestinationExists, dst)
+	}
+	if info.IsDir() {
+		return false, fmt.Errorf("%w: %s", ErrOverwriteDirectory, dst)
+	}
+	return true, os.Remove(dst)
+}
+
+// registerMoveFile registers the move file tool
+func (r *ToolRegistry) registerMoveFile() {
+	name, _ := vo.NewToolName("move_file")
+	desc, _ := vo.NewToolDescription("Move or rename a file or directory within the allowed directories")
+
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"source": {
+				Type:        "string",
+				Description: "The file or directory to move",
+			},
+			"destination": {
+				Type:        "string",
+				Description: "The new path, including the file or directory name",
+			},
+			"overwrite": {
+				Type:        "boolean",
+				Description: "Replace an existing file at the destination (default: false)",
+			},
+		},
+		Required: []string{"source", "destination"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("file")
+	tool.SetTags([]string{"file", "move", "rename"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Move File", DestructiveHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"source": "draft.md", "destination": "docs/guide.md"},
+	})
+	tool.SetContextHandler(r.handleMoveFile)
+
+	r.tools["move_file"] = tool
+}
+
+func (r *ToolRegistry) handleMoveFile(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	src, dst, err := r.transferPaths(ctx, input)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if r.isProtectedPath(ctx, src) {
+		return entities.NewErrorToolResult(ErrProtectedPath), nil
+	}
+
+	op, err := measurePath(src)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	overwrite, _ := input["overwrite"].(bool)
+	if op.Overwrote, err = prepareDestination(dst, overwrite); err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	if err := os.Rename(src, dst); err != nil {
+		// Renames cannot cross filesystems, so fall back to copying and removing the source
+		if !errors.Is(err, syscall.EXDEV) {
+			return entities.NewErrorToolResult(err), nil
+		}
+		if _, err := copyPath(src, dst); err != nil {
+			return entities.NewErrorToolResult(err), nil
+		}
+		if err := os.RemoveAll(src); err != nil {
+			return entities.NewErrorToolResult(err), nil
+		}
+	}
+
+	op.Operation, op.Source, op.Destination = "move", src, dst
+	return entities.NewJSONToolResult(op), nil
+}
+
+// registerCopyFile registers the copy file tool
+func (r *ToolRegistry) registerCopyFile() {
+	name, _ := vo.NewToolName("copy_file")
+	desc, _ := vo.NewToolDescription("Copy a file, or a directory with recursive set, within the allowed directories")
+
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"source": {
+				Type:        "string",
+				Description: "The file or directory to copy",
+			},
+			"destination": {
+				Type:        "string",
+				Description: "The path of the copy, including the file or directory name",
+			},
+			"overwrite": {
+				Type:        "boolean",
+				Description: "Replace an existing file at the destination (default: false)",
+			},
+			"recursive": {
+				Type:        "boolean",
+				Description: "Required to copy a directory and its contents (default: false)",
+			},
+		},
+		Required: []string{"source", "destination"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("file")
+	tool.SetTags([]string{"file", "copy"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Copy File", DestructiveHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"source": "config.yaml", "destination": "config.yaml.bak"},
+		{"source": "templates", "destination": "backup/templates", "recursive": true},
+	})
+	tool.SetContextHandler(r.handleCopyFile)
+
+	r.tools["copy_file"] = tool
+}
+
+func (r *ToolRegistry) handleCopyFile(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	src, dst, err := r.transferPaths(ctx, input)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	info, err := os.Lstat(src)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if recursive, _ := input["recursive"].(bool); info.IsDir() && !recursive {
+		return entities.NewErrorToolResult(ErrRecursiveRequired), nil
+	}
+
+	overwrite, _ := input["overwrite"].(bool)
+	overwrote, err := prepareDestination(dst, overwrite)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	op, err := copyPath(src, dst)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	op.Operation, op.Source, op.Destination, op.Overwrote = "copy", src, dst, overwrote
+	return entities.NewJSONToolResult(op), nil
+}
+
+// registerDeleteFile registers the delete file tool
+func (r *ToolRegistry) registerDeleteFile() {
+	name, _ := vo.NewToolName("delete_file")
+	desc, _ := vo.NewToolDescription("Delete a file, or a directory with recursive set, within the allowed directories. Requires confirm to be true.")
+
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"path": {
+				Type:        "string",
+				Description: "The file or directory to delete",
+			},
+			"confirm": {
+				Type:        "boolean",
+				Description: "Must be true to perform the deletion",
+			},
+			"recursive": {
+				Type:        "boolean",
+				Description: "Required to delete a directory and everything in it (default: false)",
+			},
+		},
+		Required: []string{"path", "confirm"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("file")
+	tool.SetTags([]string{"file", "delete"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Delete File", DestructiveHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": "build/output.log", "confirm": true},
+		{"path": "build/cache", "confirm": true, "recursive": true},
+	})
+	tool.SetContextHandler(r.handleDeleteFile)
+
+	r.tools["delete_file"] = tool
+}
+
+func (r *ToolRegistry) handleDeleteFile(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	if confirm, _ := input["confirm"].(bool); !confirm {
+		return entities.NewErrorToolResult(ErrDeleteNotConfirmed), nil
+	}
+
+	path, _ := input["path"].(string)
+	absPath, err := r.resolveToolPath(ctx, path)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if r.isProtectedPath(ctx, absPath) {
+		return entities.NewErrorToolResult(ErrProtectedPath), nil
+	}
+
+	op, err := measurePath(absPath)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if recursive, _ := input["recursive"].(bool); op.Type == "directory" && !recursive {
+		return entities.NewErrorToolResult(ErrRecursiveRequired), nil
+	}
+
+	if op.Type == "directory" {
+		err = os.RemoveAll(absPath)
+	} else {
+		err = os.Remove(absPath)
+	}
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	op.Operation, op.Path = "delete", absPath
+	return entities.NewJSONToolResult(op), nil
+}
+
+// measurePath counts the files and bytes under path without following symbolic links
+func measurePath(path string) (*FileOperation, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return &FileOperation{Type: "file", Files: 1, Bytes: info.Size()}, nil
+	}
+
+	op := &FileOperation{Type: "directory"}
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		op.Files++
+		if info, err := d.Info(); err == nil {
+			op.Bytes += info.Size()
+		}
+		return nil
+	})
+	return op, err
+}
+
+// copyPath copies a file or directory tree, recreating symbolic links rather than following them
+func copyPath(src, dst string) (*FileOperation, error) {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		n, err := copyEntry(src, dst, info)
+		return &FileOperation{Type: "file", Files: 1, Bytes: n}, err
+	}
+
+	op := &FileOperation{Type: "directory"}
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+		n, err := copyEntry(path, target, info)
+		if err != nil {
+			return err
+		}
+		op.Files++
+		op.Bytes += n
+		return nil
+	})
+	return op, err
+}
+
+// copyEntry copies a single file or symbolic link
+func copyEntry(src, dst string, info fs.FileInfo) (int64, error) {
+	if info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(src)
+		if err != nil {
+			return 0, err
+		}
+		return 0, os.Symlink(link, dst)
+	}
+	if !info.Mode().IsRegular() {
+		return 0, fmt.Errorf("cannot copy %s: not a regular file", src)
+	}
+
+	in, err := os.Open(src) // #nosec G304 -- path checked against the allowlist
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm()) // #nosec G304 -- path checked against the allowlist
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
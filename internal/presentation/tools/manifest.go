@@ -0,0 +1,462 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Manifest errors
+var (
+	ErrInvalidManifest = errors.New("invalid tool manifest")
+	ErrUnsafeAction    = errors.New("unsafe manifest action")
+)
+
+const (
+	// DefaultManifestToolTimeout applies to manifest tools that do not set a timeout
+	DefaultManifestToolTimeout = 30 * time.Second
+	// MaxManifestToolTimeout is the longest timeout a manifest tool may request
+	MaxManifestToolTimeout = 5 * time.Minute
+	// maxHTTPResponseBytes caps how much of an HTTP action's response is read
+	maxHTTPResponseBytes = 1 << 20
+	// maxHTTPRedirects caps how many same-host redirects an HTTP action follows
+	maxHTTPRedirects = 10
+)
+
+// placeholderPattern matches {{name}} placeholders in action templates
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// ToolManifest declares tools that are registered alongside the built-ins
+type ToolManifest struct {
+	Tools []ManifestTool `json:"tools"`
+}
+
+// ManifestTool describes a single declarative tool. Exactly one of Exec or HTTP is set.
+type ManifestTool struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Category    string               `json:"category,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Timeout     string               `json:"timeout,omitempty"`
+	InputSchema *entities.JSONSchema `json:"input_schema,omitempty"`
+	Exec        *ExecAction          `json:"exec,omitempty"`
+	HTTP        *HTTPAction          `json:"http,omitempty"`
+}
+
+// ExecAction runs a fixed command with an argv template; no shell is involved
+type ExecAction struct {
+	Command    string   `json:"command"`
+	Args       []string `json:"args,omitempty"`
+	WorkingDir string   `json:"working_dir,omitempty"`
+	// PathParams names inputs that are file paths and must be inside the allowed directories
+	PathParams []string `json:"path_params,omitempty"`
+}
+
+// HTTPAction sends a request built from a template to a fixed scheme and host
+type HTTPAction struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// ParseToolManifest parses a YAML or JSON manifest
+func ParseToolManifest(data []byte) (*ToolManifest, error) {
+	// Decode through YAML (a superset of JSON) and re-encode so the json tags,
+	// including those on JSONSchema, drive the field mapping
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+
+	var manifest ToolManifest
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	return &manifest, nil
+}
+
+// SetManifestCommands sets the commands manifest exec tools may run, matched exactly as
+// the manifest names them, so "git" does not admit "/tmp/git". Without any, manifests
+// may only declare http tools.
+func (r *ToolRegistry) SetManifestCommands(commands []string) {
+	r.manifestCommands = make(map[string]bool, len(commands))
+	for _, command := range commands {
+		r.manifestCommands[command] = true
+	}
+}
+
+// LoadManifest reads a manifest file and registers its tools. Nothing is registered
+// unless every tool in the manifest is valid.
+func (r *ToolRegistry) LoadManifest(path string) ([]*entities.Tool, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- manifest path comes from operator configuration
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := ParseToolManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	tools, err := r.RegisterManifest(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tools, nil
+}
+
+// RegisterManifest validates a manifest and registers its tools
+func (r *ToolRegistry) RegisterManifest(manifest *ToolManifest) ([]*entities.Tool, error) {
+	tools := make([]*entities.Tool, 0, len(manifest.Tools))
+	seen := make(map[string]bool, len(manifest.Tools))
+	for i := range manifest.Tools {
+		def := &manifest.Tools[i]
+		if _, exists := r.tools[def.Name]; exists || seen[def.Name] {
+			return nil, fmt.Errorf("%w: %s", aggregates.ErrToolAlreadyRegistered, def.Name)
+		}
+		seen[def.Name] = true
+
+		tool, err := r.buildManifestTool(def)
+		if err != nil {
+			return nil, fmt.Errorf("tool %q: %w", def.Name, err)
+		}
+		tools = append(tools, tool)
+	}
+
+	for _, tool := range tools {
+		r.tools[tool.Name().String()] = tool
+	}
+	return tools, nil
+}
+
+// buildManifestTool validates a manifest tool and turns it into a tool entity
+func (r *ToolRegistry) buildManifestTool(def *ManifestTool) (*entities.Tool, error) {
+	name, err := vo.NewToolName(def.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	desc, err := vo.NewToolDescription(def.Description)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+
+	schema := def.InputSchema
+	if schema == nil {
+		schema = &entities.JSONSchema{Type: "object", Properties: map[string]*entities.JSONSchema{}}
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("%w: input_schema must be an object schema", ErrInvalidManifest)
+	}
+
+	timeout := DefaultManifestToolTimeout
+	if def.Timeout != "" {
+		if timeout, err = time.ParseDuration(def.Timeout); err != nil || timeout <= 0 || timeout > MaxManifestToolTimeout {
+			return nil, fmt.Errorf("%w: timeout must be a duration between 0 and %s", ErrInvalidManifest, MaxManifestToolTimeout)
+		}
+	}
+
+	var handler entities.ContextToolHandler
+	annotations := &entities.ToolAnnotations{Title: def.Name}
+	switch {
+	case def.Exec != nil && def.HTTP != nil:
+		return nil, fmt.Errorf("%w: set exactly one of exec or http", ErrInvalidManifest)
+	case def.Exec != nil:
+		if err := r.validateExecAction(def.Exec, schema); err != nil {
+			return nil, err
+		}
+		handler = r.execActionHandler(def.Exec)
+		annotations.DestructiveHint = true
+	case def.HTTP != nil:
+		if err := validateHTTPAction(def.HTTP, schema); err != nil {
+			return nil, err
+		}
+		handler = httpActionHandler(def.HTTP)
+		annotations.OpenWorldHint = true
+	default:
+		return nil, fmt.Errorf("%w: set exactly one of exec or http", ErrInvalidManifest)
+	}
+
+	tool, err := entities.NewTool(name, desc, schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	category := def.Category
+	if category == "" {
+		category = "manifest"
+	}
+	tool.SetCategory(category)
+	tool.SetTags(def.Tags)
+	tool.SetAnnotations(annotations)
+	tool.SetTimeout(timeout)
+	tool.SetMetadata("source", "manifest")
+	tool.SetContextHandler(func(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+		if err := schema.Validate(input); err != nil {
+			return entities.NewErrorToolResult(err), nil
+		}
+		return handler(ctx, input)
+	})
+	return tool, nil
+}
+
+// validatePlaceholders checks that every placeholder in template names a scalar schema property
+func validatePlaceholders(template string, schema *entities.JSONSchema) error {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+		prop, ok := schema.Properties[match[1]]
+		if !ok {
+			return fmt.Errorf("%w: placeholder {{%s}} is not an input_schema property", ErrInvalidManifest, match[1])
+		}
+		switch prop.Type {
+		case "string", "number", "integer", "boolean":
+		default:
+			return fmt.Errorf("%w: placeholder {{%s}} must be a string, number, integer or boolean property", ErrInvalidManifest, match[1])
+		}
+	}
+	return nil
+}
+
+// validateExecAction checks an exec action. Its command must be one of the manifest
+// commands, exactly as written there, since any interpreter could turn a fixed argv
+// back into arbitrary code.
+func (r *ToolRegistry) validateExecAction(action *ExecAction, schema *entities.JSONSchema) error {
+	if action.Command == "" {
+		return fmt.Errorf("%w: exec.command is required", ErrInvalidManifest)
+	}
+	if placeholderPattern.MatchString(action.Command) {
+		return fmt.Errorf("%w: exec.command must be fixed", ErrUnsafeAction)
+	}
+	if !r.manifestCommands[action.Command] {
+		return fmt.Errorf("%w: %s is not an allowed manifest command", ErrUnsafeAction, action.Command)
+	}
+	for _, arg := range action.Args {
+		if err := validatePlaceholders(arg, schema); err != nil {
+			return err
+		}
+	}
+	if err := validatePlaceholders(action.WorkingDir, schema); err != nil {
+		return err
+	}
+	for _, param := range action.PathParams {
+		if prop, ok := schema.Properties[param]; !ok || prop.Type != "string" {
+			return fmt.Errorf("%w: path param %q must be a string input_schema property", ErrInvalidManifest, param)
+		}
+	}
+	return nil
+}
+
+func validateHTTPAction(action *HTTPAction, schema *entities.JSONSchema) error {
+	switch action.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead:
+	default:
+		return fmt.Errorf("%w: unsupported http.method %q", ErrInvalidManifest, action.Method)
+	}
+
+	// The scheme and host are fixed; placeholders may only appear in the path and query
+	u, err := url.Parse(placeholderPattern.ReplaceAllString(action.URL, "x"))
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: http.url must be an absolute URL", ErrInvalidManifest)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: http.url scheme must be http or https", ErrUnsafeAction)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: http.url must not embed credentials", ErrUnsafeAction)
+	}
+	_, rest, _ := strings.Cut(action.URL, "://")
+	authority := rest
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		authority = rest[:i]
+	}
+	if placeholderPattern.MatchString(authority) {
+		return fmt.Errorf("%w: http.url scheme and host must be fixed", ErrUnsafeAction)
+	}
+
+	templates := []string{action.URL, action.Body}
+	for name, value := range action.Headers {
+		if placeholderPattern.MatchString(name) {
+			return fmt.Errorf("%w: header names must be fixed", ErrUnsafeAction)
+		}
+		templates = append(templates, value)
+	}
+	for _, template := range templates {
+		if err := validatePlaceholders(template, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// placeholderValue formats an input for substitution into a template
+func placeholderValue(input map[string]interface{}, name string) string {
+	switch v := input[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// expandTemplate replaces placeholders with escaped input values
+func expandTemplate(template string, input map[string]interface{}, escape func(string) string) string {
+	return placeholderPattern.ReplaceAllStringFunc(template, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		return escape(placeholderValue(input, name))
+	})
+}
+
+func noEscape(s string) string { return s }
+
+// execActionHandler returns the handler for an exec action
+func (r *ToolRegistry) execActionHandler(action *ExecAction) entities.ContextToolHandler {
+	return func(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+		// The command gets the checked absolute paths, since it may run in a directory
+		// other than the one relative paths were checked against
+		values := make(map[string]interface{}, len(input))
+		for name, value := range input {
+			values[name] = value
+		}
+		for _, param := range action.PathParams {
+			if path, _ := input[param].(string); path != "" {
+				resolved, err := r.resolveToolPath(ctx, path)
+				if err != nil {
+					return entities.NewErrorToolResult(fmt.Errorf("%s: %w", param, err)), nil
+				}
+				values[param] = resolved
+			}
+		}
+
+		args := make([]string, 0, len(action.Args))
+		for _, template := range action.Args {
+			arg := expandTemplate(template, values, noEscape)
+			// A value filling a whole argument must not be read as an option
+			if arg != template && placeholderPattern.FindString(template) == strings.TrimSpace(template) && strings.HasPrefix(arg, "-") {
+				return entities.NewErrorToolResult(fmt.Errorf("%w: argument %q looks like an option", ErrUnsafeAction, arg)), nil
+			}
+			args = append(args, arg)
+		}
+
+		workingDir := sessionWorkingDir(ctx)
+		if action.WorkingDir != "" {
+			dir, err := r.resolveToolPath(ctx, expandTemplate(action.WorkingDir, values, noEscape))
+			if err != nil {
+				return entities.NewErrorToolResult(err), nil
+			}
+			workingDir = dir
+		}
+
+		output, err := runCommand(ctx, r.execIdleTimeout, workingDir, action.Command, args...)
+		if err != nil {
+			if errors.Is(err, ErrCommandIdle) {
+				return entities.NewErrorToolResult(fmt.Errorf("%w\nOutput: %s", err, output)), nil
+			}
+			if ctx.Err() != nil {
+				return entities.NewErrorToolResult(fmt.Errorf("command %s: %w", action.Command, ctx.Err())), nil
+			}
+			return entities.NewErrorToolResult(fmt.Errorf("command failed: %v\nOutput: %s", err, output)), nil
+		}
+		return entities.NewTextToolResult(string(output)), nil
+	}
+}
+
+// httpActionClient returns a client that only follows redirects to the action's own
+// scheme and host, so a redirect cannot carry the request somewhere the manifest did not name
+func httpActionClient(action *HTTPAction) *http.Client {
+	target, _ := url.Parse(placeholderPattern.ReplaceAllString(action.URL, "x"))
+	return &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxHTTPRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxHTTPRedirects)
+			}
+			if req.URL.Scheme != target.Scheme || req.URL.Host != target.Host {
+				return fmt.Errorf("%w: redirect to %s://%s", ErrUnsafeAction, req.URL.Scheme, req.URL.Host)
+			}
+			return nil
+		},
+	}
+}
+
+// httpActionHandler returns the handler for an HTTP action
+func httpActionHandler(action *HTTPAction) entities.ContextToolHandler {
+	client := httpActionClient(action)
+	return func(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+		target := expandURLTemplate(action.URL, input)
+
+		bodyEscape := noEscape
+		for name, value := range action.Headers {
+			if strings.EqualFold(name, "Content-Type") && strings.Contains(value, "json") {
+				bodyEscape = jsonStringEscape
+			}
+		}
+		var body io.Reader
+		if action.Body != "" {
+			body = strings.NewReader(expandTemplate(action.Body, input, bodyEscape))
+		}
+
+		req, err := http.NewRequestWithContext(ctx, action.Method, target, body)
+		if err != nil {
+			return entities.NewErrorToolResult(err), nil
+		}
+		for name, template := range action.Headers {
+			value := expandTemplate(template, input, noEscape)
+			if strings.ContainsAny(value, "\r\n") {
+				return entities.NewErrorToolResult(fmt.Errorf("%w: header %s contains a line break", ErrUnsafeAction, name)), nil
+			}
+			req.Header.Set(name, value)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return entities.NewErrorToolResult(err), nil
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseBytes))
+		if err != nil {
+			return entities.NewErrorToolResult(err), nil
+		}
+		if resp.StatusCode >= 400 {
+			return entities.NewErrorToolResult(fmt.Errorf("HTTP %d: %s", resp.StatusCode, data)), nil
+		}
+		return entities.NewTextToolResult(string(data)), nil
+	}
+}
+
+// expandURLTemplate substitutes placeholders, escaping them for the path or the query
+func expandURLTemplate(template string, input map[string]interface{}) string {
+	path, query, hasQuery := strings.Cut(template, "?")
+	expanded := expandTemplate(path, input, url.PathEscape)
+	if hasQuery {
+		expanded += "?" + expandTemplate(query, input, url.QueryEscape)
+	}
+	return expanded
+}
+
+// jsonStringEscape escapes s for use inside a JSON string literal
+func jsonStringEscape(s string) string {
+	encoded, _ := json.Marshal(s)
+	return string(encoded[1 : len(encoded)-1])
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// DefaultMaxTokens is used when neither the request nor the policy sets max_tokens
+const DefaultMaxTokens = 4096
+
+// Max tokens errors
+var (
+	ErrInvalidMaxTokens  = errors.New("max_tokens must be a positive integer")
+	ErrMaxTokensExceeded = errors.New("max_tokens exceeds the allowed maximum")
+)
+
+// MaxTokensPolicy bounds the max_tokens requested from Claude
+type MaxTokensPolicy struct {
+	// Default is used when a request does not set max_tokens
+	Default int
+	// Limit is the server-wide maximum (0 means only the model cap applies)
+	Limit int
+	// Clamp lowers values above the maximum instead of rejecting them
+	Clamp bool
+}
+
+// Max returns the effective maximum for a model, or 0 if unbounded
+func (p MaxTokensPolicy) Max(model vo.Model) int {
+	limit := model.MaxOutputTokens()
+	if p.Limit > 0 && (limit == 0 || p.Limit < limit) {
+		limit = p.Limit
+	}
+	return limit
+}
+
+// Resolve validates a requested max_tokens value for a model. A requested value
+// of 0 selects the default, which is always lowered to fit the maximum.
+func (p MaxTokensPolicy) Resolve(model vo.Model, requested int) (int, error) {
+	limit := p.Max(model)
+
+	if requested == 0 {
+		requested = p.Default
+		if requested <= 0 {
+			requested = DefaultMaxTokens
+		}
+		if limit > 0 && requested > limit {
+			requested = limit
+		}
+		return requested, nil
+	}
+
+	if requested < 0 {
+		return 0, ErrInvalidMaxTokens
+	}
+	if limit > 0 && requested > limit {
+		if p.Clamp {
+			return limit, nil
+		}
+		return 0, fmt.Errorf("%w: requested %d, maximum for %s is %d", ErrMaxTokensExceeded, requested, model, limit)
+	}
+	return requested, nil
+}
+
+// SetMaxTokensPolicy sets the policy applied to max_tokens in Claude requests
+func (r *ToolRegistry) SetMaxTokensPolicy(policy MaxTokensPolicy) {
+	r.maxTokens = policy
+}
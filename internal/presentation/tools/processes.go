@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Process listing limits
+const (
+	DefaultProcessLimit = 10
+	MaxProcessLimit     = 500
+)
+
+// ProcessListScope is the scope required to call the list_processes tool
+const ProcessListScope = "admin"
+
+// ErrProcessListUnsupported is returned when process listing is not available on this platform
+var ErrProcessListUnsupported = errors.New("process listing is not supported on this platform")
+
+// ProcessInfo describes a running process
+type ProcessInfo struct {
+	PID         int     `json:"pid"`
+	Name        string  `json:"name"`
+	CPUSeconds  float64 `json:"cpu_seconds"`
+	MemoryBytes uint64  `json:"memory_bytes"`
+}
+
+// EnableProcessListing registers the list_processes tool as a built-in, so tool
+// enablement can name it. Call it before ConfigureBuiltinTools.
+// It is not registered by default because it exposes host process information.
+func (r *ToolRegistry) EnableProcessListing() {
+	r.registerListProcesses()
+	r.builtins["list_processes"] = true
+}
+
+// registerListProcesses registers the list processes tool
+func (r *ToolRegistry) registerListProcesses() {
+	name, _ := vo.NewToolName("list_processes")
+	desc, _ := vo.NewToolDescription("List running processes with the highest CPU or memory usage")
+
+	minLimit, maxLimit := 1.0, float64(MaxProcessLimit)
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"limit": {
+				Type:        "integer",
+				Description: fmt.Sprintf("Maximum number of processes to return (default: %d)", DefaultProcessLimit),
+				Minimum:     &minLimit,
+				Maximum:     &maxLimit,
+			},
+			"sort_by": {
+				Type:        "string",
+				Description: "Sort processes by cpu or memory usage (default: cpu)",
+				Enum:        []interface{}{"cpu", "memory"},
+			},
+		},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("system")
+	tool.SetTags([]string{"system", "process", "diagnostics"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "List Processes", ReadOnlyHint: true})
+	tool.SetRequiredScope(ProcessListScope)
+	tool.SetHandler(handleListProcesses)
+	tool.SetTimeout(10 * time.Second)
+
+	r.tools["list_processes"] = tool
+}
+
+func handleListProcesses(input map[string]interface{}) (*entities.ToolResult, error) {
+	limit := DefaultProcessLimit
+	if l, ok := input["limit"].(float64); ok {
+		limit = int(l)
+	}
+	if limit < 1 || limit > MaxProcessLimit {
+		return entities.NewErrorToolResult(fmt.Errorf("limit must be between 1 and %d", MaxProcessLimit)), nil
+	}
+
+	sortBy := "cpu"
+	if s, ok := input["sort_by"].(string); ok && s != "" {
+		sortBy = s
+	}
+	if sortBy != "cpu" && sortBy != "memory" {
+		return entities.NewErrorToolResult(fmt.Errorf("sort_by must be cpu or memory")), nil
+	}
+
+	processes, err := listProcesses()
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	sort.SliceStable(processes, func(i, j int) bool {
+		if sortBy == "memory" {
+			return processes[i].MemoryBytes > processes[j].MemoryBytes
+		}
+		return processes[i].CPUSeconds > processes[j].CPUSeconds
+	})
+	if len(processes) > limit {
+		processes = processes[:limit]
+	}
+
+	return entities.NewJSONToolResult(processes), nil
+}
@@ -0,0 +1,73 @@
+//go:build linux
+
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// clockTicksPerSecond is USER_HZ, which is 100 on all supported Linux architectures
+const clockTicksPerSecond = 100
+
+// listProcesses reads process information from /proc
+func listProcesses() ([]ProcessInfo, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := uint64(os.Getpagesize())
+	processes := make([]ProcessInfo, 0, len(entries))
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		// Processes may exit while we are reading, so skip unreadable entries
+		data, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		info, ok := parseProcStat(pid, string(data), pageSize)
+		if !ok {
+			continue
+		}
+		processes = append(processes, info)
+	}
+
+	return processes, nil
+}
+
+// parseProcStat parses the contents of /proc/<pid>/stat
+func parseProcStat(pid int, stat string, pageSize uint64) (ProcessInfo, bool) {
+	// The command name is wrapped in parentheses and may itself contain spaces or parentheses
+	start := strings.IndexByte(stat, '(')
+	end := strings.LastIndexByte(stat, ')')
+	if start < 0 || end < start {
+		return ProcessInfo{}, false
+	}
+
+	// Fields after the command name start at field 3 (state)
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 22 {
+		return ProcessInfo{}, false
+	}
+
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	rss, _ := strconv.ParseInt(fields[21], 10, 64)
+	if rss < 0 {
+		rss = 0
+	}
+
+	return ProcessInfo{
+		PID:         pid,
+		Name:        stat[start+1 : end],
+		CPUSeconds:  float64(utime+stime) / clockTicksPerSecond,
+		MemoryBytes: uint64(rss) * pageSize,
+	}, true
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package tools
+
+// listProcesses is only implemented on Linux
+func listProcesses() ([]ProcessInfo, error) {
+	return nil, ErrProcessListUnsupported
+}
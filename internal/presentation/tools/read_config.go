@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/subosito/gotenv"
+	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// Config file formats understood by read_config
+const (
+	ConfigFormatYAML = "yaml"
+	ConfigFormatTOML = "toml"
+	ConfigFormatINI  = "ini"
+	ConfigFormatEnv  = "env"
+)
+
+// ErrUnknownConfigFormat is returned when a config file's format cannot be detected
+var ErrUnknownConfigFormat = errors.New("cannot detect config format from the file extension; pass format")
+
+// configFormatsByExtension maps file extensions to the config format they hold
+var configFormatsByExtension = map[string]string{
+	".yaml": ConfigFormatYAML,
+	".yml":  ConfigFormatYAML,
+	".toml": ConfigFormatTOML,
+	".ini":  ConfigFormatINI,
+	".cfg":  ConfigFormatINI,
+	".conf": ConfigFormatINI,
+	".env":  ConfigFormatEnv,
+}
+
+// registerReadConfig registers the read config tool
+func (r *ToolRegistry) registerReadConfig() {
+	name, _ := vo.NewToolName("read_config")
+	desc, _ := vo.NewToolDescription("Read a YAML, TOML, INI or .env config file and return its contents as JSON")
+
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"path": {
+				Type:        "string",
+				Description: "The path to the config file to read",
+			},
+			"format": {
+				Type:        "string",
+				Description: "The file format (default: detected from the file extension)",
+				Enum:        []interface{}{ConfigFormatYAML, ConfigFormatTOML, ConfigFormatINI, ConfigFormatEnv},
+			},
+		},
+		Required: []string{"path"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("file")
+	tool.SetTags([]string{"file", "read", "config"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Read Config", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": "configs/tfo-mcp.yaml"},
+		{"path": ".env.local", "format": "env"},
+	})
+	tool.SetCacheable(true)
+	tool.SetContextHandler(r.handleReadConfig)
+
+	r.tools["read_config"] = tool
+}
+
+func (r *ToolRegistry) handleReadConfig(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	path, _ := input["path"].(string)
+	absPath, err := r.resolveToolPath(ctx, path)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	format, _ := input["format"].(string)
+	if format == "" {
+		if format = DetectConfigFormat(absPath); format == "" {
+			return entities.NewErrorToolResult(ErrUnknownConfigFormat), nil
+		}
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if info.IsDir() {
+		return entities.NewErrorToolResult(fmt.Errorf("%s is a directory", path)), nil
+	}
+	if r.readFileMaxBytes > 0 && info.Size() > r.readFileMaxBytes {
+		return entities.NewErrorToolResult(fmt.Errorf("%s is %d bytes, larger than the %d byte read limit; use read_file to page through it",
+			path, info.Size(), r.readFileMaxBytes)), nil
+	}
+
+	data, err := os.ReadFile(absPath) //nolint:gosec // G304: path is checked by resolveToolPath
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	parsed, err := ParseConfig(data, format)
+	if err != nil {
+		return entities.NewErrorToolResult(fmt.Errorf("failed to parse %s as %s: %w", path, format, err)), nil
+	}
+
+	result := entities.NewJSONToolResult(parsed)
+	result.SetMeta("format", format)
+	return result, nil
+}
+
+// DetectConfigFormat returns the config format of path judged by its extension, or ""
+// when it is not a known config file. Dotenv variants such as .env.local count as env.
+func DetectConfigFormat(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return ConfigFormatEnv
+	}
+	return configFormatsByExtension[filepath.Ext(base)]
+}
+
+// ParseConfig parses config data in the given format into JSON-compatible values.
+// INI keys outside any section are kept at the top level and each section becomes an
+// object; INI and .env values are always strings.
+func ParseConfig(data []byte, format string) (interface{}, error) {
+	switch format {
+	case ConfigFormatYAML:
+		var value interface{}
+		if err := yaml.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return normalizeConfigValue(value), nil
+
+	case ConfigFormatTOML:
+		value := map[string]interface{}{}
+		if err := toml.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+
+	case ConfigFormatINI:
+		file, err := ini.Load(data)
+		if err != nil {
+			return nil, err
+		}
+		value := map[string]interface{}{}
+		for _, section := range file.Sections() {
+			keys := section.KeysHash()
+			if section.Name() == ini.DefaultSection {
+				for key, v := range keys {
+					value[key] = v
+				}
+				continue
+			}
+			value[section.Name()] = keys
+		}
+		return value, nil
+
+	case ConfigFormatEnv:
+		env, err := gotenv.StrictParse(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string(env), nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// normalizeConfigValue converts YAML maps with non-string keys into JSON objects
+func normalizeConfigValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeConfigValue(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = normalizeConfigValue(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeConfigValue(item)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// DefaultReadFileMaxBytes caps how much of a file a single read_file call returns
+const DefaultReadFileMaxBytes = 1 << 20
+
+// SetReadFileMaxBytes sets the most bytes read_file returns per call (0 disables the cap)
+func (r *ToolRegistry) SetReadFileMaxBytes(limit int64) {
+	r.readFileMaxBytes = limit
+}
+
+// fileRange is a window of a file returned by read_file
+type fileRange struct {
+	content   []byte
+	totalSize int64
+	offset    int64
+	truncated bool
+}
+
+// readFileRange reads up to limit bytes of path starting at offset. The window is
+// cut back to a UTF-8 boundary so text is never split mid-character; a limit of 0
+// or less reads to the end of the file.
+func readFileRange(path string, offset, limit int64) (*fileRange, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is checked by resolveToolPath
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	size := info.Size()
+	if offset < 0 || offset > size {
+		return nil, fmt.Errorf("offset %d is outside the file (size %d bytes)", offset, size)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	want := size - offset
+	truncated := limit > 0 && want > limit
+	if truncated {
+		want = limit
+	}
+	content := make([]byte, want)
+	n, err := io.ReadFull(f, content)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	content = content[:n]
+
+	if truncated {
+		content = trimPartialRune(content)
+	}
+	return &fileRange{content: content, totalSize: size, offset: offset, truncated: truncated}, nil
+}
+
+// trimPartialRune drops a multi-byte UTF-8 sequence cut off at the end of data.
+// Data that is not valid UTF-8 anyway is returned unchanged.
+func trimPartialRune(data []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				return data[:len(data)-i]
+			}
+			break
+		}
+	}
+	return data
+}
@@ -0,0 +1,400 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Errors returned by ValidateReadOnlyQuery
+var (
+	ErrQueryNotReadOnly     = errors.New("only a single SELECT statement is allowed")
+	ErrQueryTableNotAllowed = errors.New("table is not allowed")
+	ErrQuerySyntax          = errors.New("query could not be parsed")
+)
+
+// sqlTokenKind classifies a SQL token
+type sqlTokenKind int
+
+const (
+	sqlWord   sqlTokenKind = iota // keyword or unquoted identifier, lower-cased
+	sqlQuoted                     // "quoted" identifier
+	sqlString                     // 'string' literal
+	sqlNumber                     // numeric literal
+	sqlSymbol                     // punctuation or operator character
+)
+
+// sqlToken is a token of a SQL query
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	pos  int // rune offset in the query
+}
+
+// is reports whether the token is the given word or symbol
+func (t sqlToken) is(text string) bool {
+	return (t.kind == sqlWord || t.kind == sqlSymbol) && t.text == text
+}
+
+// sqlDeniedKeywords are keywords of statements that write data or change the schema
+// or session, plus UESCAPE which can disguise identifiers and TABLE, whose short form
+// of SELECT * would read a table outside the FROM lists checked below. A query
+// containing one is rejected outright.
+var sqlDeniedKeywords = map[string]bool{
+	"insert": true, "update": true, "delete": true, "merge": true, "upsert": true,
+	"create": true, "drop": true, "alter": true, "truncate": true, "rename": true,
+	"grant": true, "revoke": true, "copy": true, "call": true, "do": true,
+	"execute": true, "prepare": true, "deallocate": true, "lock": true,
+	"vacuum": true, "reindex": true, "cluster": true, "refresh": true,
+	"attach": true, "detach": true, "pragma": true, "into": true, "set": true,
+	"begin": true, "commit": true, "rollback": true, "savepoint": true,
+	"listen": true, "notify": true, "uescape": true, "table": true,
+}
+
+// sqlAllowedFunctions are the functions a query may call. Functions such as
+// query_to_xml, table_to_xml or pg_read_file take a table or query by name and read
+// it where the FROM checks cannot see, so anything not listed here is rejected.
+var sqlAllowedFunctions = map[string]bool{
+	// Aggregates and window functions
+	"count": true, "sum": true, "avg": true, "min": true, "max": true,
+	"bool_and": true, "bool_or": true, "every": true, "string_agg": true, "array_agg": true,
+	"json_agg": true, "jsonb_agg": true, "json_object_agg": true, "jsonb_object_agg": true,
+	"stddev": true, "stddev_pop": true, "stddev_samp": true, "variance": true,
+	"var_pop": true, "var_samp": true, "percentile_cont": true, "percentile_disc": true, "mode": true,
+	"row_number": true, "rank": true, "dense_rank": true, "percent_rank": true, "cume_dist": true,
+	"ntile": true, "lag": true, "lead": true, "first_value": true, "last_value": true, "nth_value": true,
+	// Conditionals and conversions
+	"coalesce": true, "nullif": true, "greatest": true, "least": true, "cast": true,
+	"to_char": true, "to_number": true, "to_date": true, "to_timestamp": true,
+	// Strings
+	"lower": true, "upper": true, "length": true, "char_length": true, "character_length": true,
+	"octet_length": true, "substring": true, "substr": true, "trim": true, "btrim": true,
+	"ltrim": true, "rtrim": true, "position": true, "strpos": true, "replace": true,
+	"concat": true, "concat_ws": true, "left": true, "right": true, "lpad": true, "rpad": true,
+	"split_part": true, "starts_with": true, "initcap": true, "reverse": true, "repeat": true,
+	"overlay": true, "md5": true, "regexp_replace": true, "regexp_match": true,
+	// Numbers
+	"abs": true, "ceil": true, "ceiling": true, "floor": true, "round": true, "trunc": true,
+	"mod": true, "power": true, "sqrt": true, "exp": true, "ln": true, "log": true, "sign": true,
+	// Dates and times
+	"now": true, "date_trunc": true, "date_part": true, "extract": true, "age": true,
+	"make_date": true, "make_interval": true, "make_timestamp": true,
+	// JSON and arrays
+	"json_build_object": true, "jsonb_build_object": true, "json_build_array": true,
+	"jsonb_build_array": true, "json_extract_path": true, "json_extract_path_text": true,
+	"jsonb_extract_path": true, "jsonb_extract_path_text": true, "json_array_length": true,
+	"jsonb_array_length": true, "json_typeof": true, "jsonb_typeof": true, "to_json": true,
+	"to_jsonb": true, "array_length": true, "array_position": true, "array_to_string": true,
+	"cardinality": true, "unnest": true, "generate_series": true, "row": true, "array": true,
+	// Type modifiers, as in varchar(20) or numeric(10, 2)
+	"varchar": true, "varying": true, "char": true, "character": true, "numeric": true,
+	"decimal": true, "timestamp": true, "time": true, "interval": true, "bit": true,
+}
+
+// sqlParenKeywords are keywords a parenthesis may follow without being a function call
+var sqlParenKeywords = map[string]bool{
+	"select": true, "with": true, "as": true, "from": true, "join": true, "lateral": true,
+	"on": true, "using": true, "where": true, "having": true, "by": true, "over": true,
+	"filter": true, "group": true, "in": true, "exists": true, "any": true, "all": true,
+	"some": true, "not": true, "and": true, "or": true, "is": true, "between": true,
+	"like": true, "ilike": true, "when": true, "then": true, "else": true, "case": true,
+	"distinct": true, "values": true, "limit": true, "offset": true, "union": true,
+	"intersect": true, "except": true,
+}
+
+// sqlClauseKeywords end a FROM item, so they are never mistaken for a table alias
+var sqlClauseKeywords = map[string]bool{
+	"where": true, "join": true, "inner": true, "left": true, "right": true, "full": true,
+	"outer": true, "cross": true, "natural": true, "on": true, "using": true, "group": true,
+	"order": true, "having": true, "limit": true, "offset": true, "fetch": true, "for": true,
+	"window": true, "union": true, "intersect": true, "except": true, "lateral": true,
+}
+
+// sqlFromFunctions use FROM inside their argument list, e.g. extract(year FROM t)
+var sqlFromFunctions = map[string]bool{
+	"extract": true, "substring": true, "trim": true, "overlay": true, "position": true,
+}
+
+// sqlPublicSchemas are the schemas a table may be qualified with
+var sqlPublicSchemas = map[string]bool{"public": true, "main": true}
+
+// ValidateReadOnlyQuery checks that query is a single SELECT statement, optionally
+// preceded by WITH, that only reads from allowedTables (or its own CTEs). It returns
+// the statement without its trailing semicolon. Tables may be qualified with the
+// public schema; table functions, write keywords and functions outside an allowlist
+// are rejected.
+func ValidateReadOnlyQuery(query string, allowedTables []string) (string, error) {
+	tokens, err := tokenizeSQL(query)
+	if err != nil {
+		return "", err
+	}
+	statement := query
+	for len(tokens) > 0 && tokens[len(tokens)-1].is(";") {
+		statement = string([]rune(query)[:tokens[len(tokens)-1].pos])
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return "", fmt.Errorf("%w: query is empty", ErrQueryNotReadOnly)
+	}
+	if !tokens[0].is("select") && !tokens[0].is("with") {
+		return "", fmt.Errorf("%w: query starts with %s", ErrQueryNotReadOnly, strings.ToUpper(tokens[0].text))
+	}
+
+	// Unquoted identifiers fold to lower case, as in PostgreSQL
+	allowed := make(map[string]bool, len(allowedTables))
+	for _, table := range allowedTables {
+		allowed[strings.ToLower(table)] = true
+	}
+	ctes := make(map[string]bool)
+
+	for i, tok := range tokens {
+		switch {
+		case tok.is(";"):
+			return "", fmt.Errorf("%w: multiple statements", ErrQueryNotReadOnly)
+		case tok.kind != sqlWord && tok.kind != sqlQuoted:
+			continue
+		case i+1 < len(tokens) && tokens[i+1].is("(") && !sqlCallAllowed(tokens, i):
+			return "", fmt.Errorf("%w: function %s is not allowed", ErrQueryNotReadOnly, tok.text)
+		case tok.kind != sqlWord:
+			continue
+		case sqlDeniedKeywords[tok.text]:
+			return "", fmt.Errorf("%w: %s is not allowed", ErrQueryNotReadOnly, strings.ToUpper(tok.text))
+		case i+2 < len(tokens) && tokens[i+1].is("as") && tokens[i+2].is("("),
+			i+1 < len(tokens) && tokens[i+1].is("(") && isCTEColumnList(tokens, i):
+			// name AS ( ... ) introduces a common table expression
+			ctes[tok.text] = true
+		}
+	}
+
+	// callers tracks, per open parenthesis, the word before it, or fromSubquery when
+	// the parenthesis opens a subquery in a FROM list
+	var callers []string
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.is("("):
+			caller := ""
+			if i > 0 && tokens[i-1].kind == sqlWord {
+				caller = tokens[i-1].text
+			}
+			callers = append(callers, caller)
+		case tok.is(")"):
+			if len(callers) == 0 {
+				return "", fmt.Errorf("%w: unbalanced parentheses", ErrQuerySyntax)
+			}
+			caller := callers[len(callers)-1]
+			callers = callers[:len(callers)-1]
+			if caller != fromSubquery {
+				continue
+			}
+			// The FROM list may go on after the subquery and its alias
+			next := skipFromAlias(tokens, i+1)
+			if next < len(tokens) && tokens[next].is(",") {
+				next, err = checkFromItems(tokens, next+1, allowed, ctes)
+				if err != nil {
+					return "", err
+				}
+			}
+			i = next - 1
+		case tok.is("from") && len(callers) > 0 && sqlFromFunctions[callers[len(callers)-1]]:
+			// extract(... FROM column) and friends
+		case tok.is("from") || tok.is("join"):
+			next, err := checkFromItems(tokens, i+1, allowed, ctes)
+			if err != nil {
+				return "", err
+			}
+			if next < len(tokens) && tokens[next].is("(") {
+				callers = append(callers, fromSubquery)
+				i = next
+				continue
+			}
+			i = next - 1
+		}
+	}
+	if len(callers) != 0 {
+		return "", fmt.Errorf("%w: unbalanced parentheses", ErrQuerySyntax)
+	}
+	return strings.TrimSpace(statement), nil
+}
+
+// fromSubquery marks a parenthesis opening a subquery in a FROM list
+const fromSubquery = "<from>"
+
+// checkFromItems checks the comma-separated FROM items starting at tokens[i] and
+// returns the index of the first token after them, or of the parenthesis opening
+// a subquery item, which the caller walks into
+func checkFromItems(tokens []sqlToken, i int, allowed, ctes map[string]bool) (int, error) {
+	for {
+		if i < len(tokens) && tokens[i].is("lateral") {
+			i++
+		}
+		if i >= len(tokens) {
+			return i, fmt.Errorf("%w: missing table after FROM", ErrQuerySyntax)
+		}
+		if tokens[i].is("(") {
+			return i, nil
+		}
+		if tokens[i].kind != sqlWord && tokens[i].kind != sqlQuoted {
+			return i, fmt.Errorf("%w: unexpected %q after FROM", ErrQuerySyntax, tokens[i].text)
+		}
+
+		// Unquoted names are already lower-cased; quoted ones must match exactly
+		schema, table := "", tokens[i].text
+		i++
+		if i+1 < len(tokens) && tokens[i].is(".") && (tokens[i+1].kind == sqlWord || tokens[i+1].kind == sqlQuoted) {
+			schema, table = table, tokens[i+1].text
+			i += 2
+		}
+		if i < len(tokens) && tokens[i].is("(") {
+			return i, fmt.Errorf("%w: table function %s", ErrQueryTableNotAllowed, table)
+		}
+		if schema != "" && !sqlPublicSchemas[schema] {
+			return i, fmt.Errorf("%w: %s.%s", ErrQueryTableNotAllowed, schema, table)
+		}
+		if !allowed[table] && !(schema == "" && ctes[table]) {
+			return i, fmt.Errorf("%w: %s", ErrQueryTableNotAllowed, table)
+		}
+
+		i = skipFromAlias(tokens, i)
+		if i < len(tokens) && tokens[i].is(",") {
+			i++
+			continue
+		}
+		return i, nil
+	}
+}
+
+// skipFromAlias returns the index after the optional alias of a FROM item starting
+// at tokens[i], including a column alias list
+func skipFromAlias(tokens []sqlToken, i int) int {
+	if i < len(tokens) && tokens[i].is("as") {
+		i++
+	}
+	if i < len(tokens) && (tokens[i].kind == sqlQuoted || (tokens[i].kind == sqlWord && !sqlClauseKeywords[tokens[i].text])) {
+		i++
+		if i < len(tokens) && tokens[i].is("(") {
+			for depth := 0; i < len(tokens); i++ {
+				if tokens[i].is("(") {
+					depth++
+				} else if tokens[i].is(")") {
+					depth--
+					if depth == 0 {
+						return i + 1
+					}
+				}
+			}
+		}
+	}
+	return i
+}
+
+// sqlCallAllowed reports whether tokens[i], a name followed by a parenthesis, is
+// something the db_query tool may use: an allowed function, a keyword, or the column
+// list or type modifier of an alias, cast or common table expression
+func sqlCallAllowed(tokens []sqlToken, i int) bool {
+	name := strings.ToLower(tokens[i].text)
+	if sqlAllowedFunctions[name] || (tokens[i].kind == sqlWord && sqlParenKeywords[name]) {
+		return true
+	}
+	// AS alias(columns), (subquery) alias(columns) and value::type(modifiers)
+	if i > 0 && (tokens[i-1].is("as") || tokens[i-1].is(")") || tokens[i-1].is(":")) {
+		return true
+	}
+	return isCTEColumnList(tokens, i)
+}
+
+// isCTEColumnList reports whether tokens[i] names a common table expression with a
+// column list: name(columns) AS ( ... )
+func isCTEColumnList(tokens []sqlToken, i int) bool {
+	depth := 0
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].is("(") {
+			depth++
+		} else if tokens[j].is(")") {
+			if depth--; depth == 0 {
+				return j+2 < len(tokens) && tokens[j+1].is("as") && tokens[j+2].is("(")
+			}
+		}
+	}
+	return false
+}
+
+// tokenizeSQL splits query into tokens, dropping whitespace and comments
+func tokenizeSQL(query string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := i + 2
+			for end+1 < len(runes) && !(runes[end] == '*' && runes[end+1] == '/') {
+				end++
+			}
+			if end+1 >= len(runes) {
+				return nil, fmt.Errorf("%w: unterminated comment", ErrQuerySyntax)
+			}
+			i = end + 2
+		case r == '\'' || r == '"':
+			text, next, err := scanQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			kind := sqlString
+			if r == '"' {
+				kind = sqlQuoted
+			}
+			tokens = append(tokens, sqlToken{kind: kind, text: text, pos: i})
+			i = next
+		case r == '$' && i+1 < len(runes) && (runes[i+1] == '$' || unicode.IsLetter(runes[i+1]) || runes[i+1] == '_'):
+			// Dollar quoting would let a body hide from the checks above
+			return nil, fmt.Errorf("%w: dollar-quoted strings are not supported", ErrQuerySyntax)
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlWord, text: strings.ToLower(string(runes[start:i])), pos: start})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlNumber, text: string(runes[start:i]), pos: start})
+		default:
+			tokens = append(tokens, sqlToken{kind: sqlSymbol, text: string(r), pos: i})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// scanQuoted reads the quoted literal or identifier starting at runes[start], where a
+// doubled quote character escapes itself. It returns the unquoted text and the index
+// after the closing quote. Backslashes are rejected, since escape strings and Unicode
+// identifiers give them a meaning that would hide text from the checks.
+func scanQuoted(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var b strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\':
+			return "", i, fmt.Errorf("%w: backslashes in quoted text are not supported", ErrQuerySyntax)
+		case runes[i] != quote:
+			b.WriteRune(runes[i])
+		case i+1 < len(runes) && runes[i+1] == quote:
+			b.WriteRune(quote)
+			i++
+		default:
+			return b.String(), i + 1, nil
+		}
+	}
+	return "", len(runes), fmt.Errorf("%w: unterminated %c", ErrQuerySyntax, quote)
+}
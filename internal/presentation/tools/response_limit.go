@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// DefaultMaxResponseChars caps the text returned by claude_conversation
+const DefaultMaxResponseChars = 100000
+
+// truncateResponse cuts text to at most limit characters and appends a marker
+// naming the full length. A limit of 0 or less disables truncation.
+func truncateResponse(text string, limit int) (string, bool) {
+	if limit <= 0 {
+		return text, false
+	}
+	total := utf8.RuneCountInString(text)
+	if total <= limit {
+		return text, false
+	}
+
+	// Cut on a rune boundary so multi-byte characters are never split
+	cut, n := 0, 0
+	for i := range text {
+		if n == limit {
+			cut = i
+			break
+		}
+		n++
+	}
+	return text[:cut] + fmt.Sprintf("\n\n[response truncated: showing %d of %d characters]", limit, total), true
+}
+
+// SetMaxResponseChars sets the character cap for claude_conversation responses (0 disables it)
+func (r *ToolRegistry) SetMaxResponseChars(limit int) {
+	r.maxResponseChars = limit
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+// allowlist is the set of directories file tools may access during a call
+type allowlist struct {
+	dirs []string // Absolute paths
+	all  bool     // Every path is allowed
+}
+
+// allowlist returns the directories a tool call may access: the configured allowed
+// paths, narrowed to the roots of the calling session once its client has reported
+// them. The roots come from ctx, so a call keeps the allowlist it started with when
+// the client changes its roots meanwhile.
+func (r *ToolRegistry) allowlist(ctx context.Context) allowlist {
+	configured := make([]string, 0, len(r.allowedPaths))
+	for _, allowed := range r.allowedPaths {
+		if allowedAbs, err := filepath.Abs(allowed); err == nil {
+			configured = append(configured, allowedAbs)
+		}
+	}
+
+	roots, ok := entities.RootsFromContext(ctx)
+	if !ok {
+		return allowlist{dirs: configured, all: len(r.allowedPaths) == 0}
+	}
+
+	var dirs []string
+	for _, root := range roots {
+		rootPath, ok := root.Path()
+		if !ok {
+			continue
+		}
+		if len(r.allowedPaths) == 0 {
+			dirs = append(dirs, rootPath)
+			continue
+		}
+		// Keep the part of the root that the configuration allows
+		for _, allowed := range configured {
+			switch {
+			case isWithin(rootPath, allowed):
+				dirs = append(dirs, rootPath)
+			case isWithin(allowed, rootPath):
+				dirs = append(dirs, allowed)
+			}
+		}
+	}
+	return allowlist{dirs: dirs}
+}
+
+// contains reports whether absPath is within an allowed directory
+func (a allowlist) contains(absPath string) bool {
+	if a.all {
+		return true
+	}
+	for _, dir := range a.dirs {
+		if isWithin(absPath, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// root returns the allowed directory containing absPath, or the filesystem root without an allowlist
+func (a allowlist) root(absPath string) string {
+	for _, dir := range a.dirs {
+		if isWithin(absPath, dir) {
+			return dir
+		}
+	}
+	return filepath.VolumeName(absPath) + string(filepath.Separator)
+}
+
+// isWithin reports whether path is dir or lies below it
+func isWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
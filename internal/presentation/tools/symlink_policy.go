@@ -0,0 +1,164 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkPolicy controls how file tools treat symbolic links in requested paths
+type SymlinkPolicy string
+
+// Symlink policies
+const (
+	// SymlinkFollow follows symlinks and only checks the requested path against the allowlist
+	SymlinkFollow SymlinkPolicy = "follow"
+	// SymlinkDeny rejects any path that passes through a symlink
+	SymlinkDeny SymlinkPolicy = "deny"
+	// SymlinkResolveAndCheck resolves symlinks and requires the target to stay within the allowlist
+	SymlinkResolveAndCheck SymlinkPolicy = "resolve-and-check"
+)
+
+// Symlink policy errors
+var (
+	ErrInvalidSymlinkPolicy = errors.New("symlink policy must be 'follow', 'deny', or 'resolve-and-check'")
+	ErrSymlinkNotAllowed    = errors.New("path contains a symbolic link")
+)
+
+// ParseSymlinkPolicy parses a symlink policy name; an empty name selects resolve-and-check
+func ParseSymlinkPolicy(name string) (SymlinkPolicy, error) {
+	switch policy := SymlinkPolicy(name); policy {
+	case "":
+		return SymlinkResolveAndCheck, nil
+	case SymlinkFollow, SymlinkDeny, SymlinkResolveAndCheck:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidSymlinkPolicy, name)
+	}
+}
+
+// SetSymlinkPolicy sets how file tools treat symlinks in requested paths
+func (r *ToolRegistry) SetSymlinkPolicy(policy SymlinkPolicy) {
+	r.symlinkPolicy = policy
+}
+
+// CheckPath checks an absolute path against the call's allowlist, narrowed to the
+// client roots carried by ctx, and the symlink policy. Resource reads share it with
+// the file tools.
+func (r *ToolRegistry) CheckPath(ctx context.Context, absPath string) error {
+	allowed := r.allowlist(ctx)
+	if !allowed.contains(absPath) {
+		return ErrPathNotAllowed
+	}
+
+	switch r.symlinkPolicy {
+	case SymlinkFollow:
+		return nil
+	case SymlinkDeny:
+		return checkNoSymlinks(allowed, absPath)
+	default:
+		return checkResolvedPath(allowed, absPath)
+	}
+}
+
+// checkNoSymlinks rejects absPath if any existing component below its allowed root is a symlink.
+// Without an allowlist every component is checked.
+func checkNoSymlinks(allowed allowlist, absPath string) error {
+	current := allowed.root(absPath)
+	rest := strings.TrimPrefix(absPath, current)
+
+	for _, part := range strings.Split(rest, string(filepath.Separator)) {
+		if part == "" {
+			continue
+		}
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%w: %s", ErrSymlinkNotAllowed, current)
+		}
+	}
+	return nil
+}
+
+// checkResolvedPath resolves symlinks in absPath and checks the target against the resolved allowlist
+func checkResolvedPath(allowed allowlist, absPath string) error {
+	if allowed.all {
+		return nil
+	}
+
+	resolved, err := resolveExistingPath(absPath)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range allowed.dirs {
+		// Allowed roots may themselves sit behind a symlink, such as /tmp on macOS
+		if dirResolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = dirResolved
+		}
+		if isWithin(resolved, dir) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s resolves to %s", ErrPathNotAllowed, absPath, resolved)
+}
+
+// maxSymlinkHops bounds how many dangling symlinks resolveExistingPath follows
+const maxSymlinkHops = 40
+
+// resolveExistingPath resolves symlinks in the longest existing prefix of absPath and
+// appends the components that do not exist yet. Dangling symlinks are followed to
+// where their target would be created.
+func resolveExistingPath(absPath string) (string, error) {
+	for hops := 0; hops < maxSymlinkHops; hops++ {
+		next, done, err := resolveOnce(absPath)
+		if err != nil || done {
+			return next, err
+		}
+		absPath = next
+	}
+	return "", fmt.Errorf("too many symbolic links in %s", absPath)
+}
+
+// resolveOnce resolves absPath up to the first dangling symlink, returning the path with
+// that link replaced by its target and done set to false, or the resolved path and true
+func resolveOnce(absPath string) (string, bool, error) {
+	existing, missing := absPath, ""
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			return filepath.Join(resolved, missing), true, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", false, err
+		}
+
+		if info, err := os.Lstat(existing); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(existing)
+			if err != nil {
+				return "", false, err
+			}
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(existing), target)
+			}
+			return filepath.Join(target, missing), false, nil
+		}
+
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return absPath, true, nil
+		}
+		missing = filepath.Join(filepath.Base(existing), missing)
+		existing = parent
+	}
+}
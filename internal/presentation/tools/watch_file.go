@@ -0,0 +1,379 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// File watch limits
+const (
+	DefaultWatchDuration = 5 * time.Minute
+	MaxWatchDuration     = time.Hour
+	MaxWatchesPerSession = 16
+
+	// watchDebounce coalesces the bursts of events a single save usually produces
+	watchDebounce = 100 * time.Millisecond
+)
+
+// File watch errors
+var (
+	ErrWatchLimitReached = errors.New("file watch limit reached for session")
+	ErrWatchNotFound     = errors.New("file is not being watched")
+	ErrSessionRequired   = errors.New("tool requires a session")
+)
+
+// FileChange describes a change to a watched file
+type FileChange struct {
+	Path string    `json:"path"`
+	URI  string    `json:"uri"`
+	Op   string    `json:"op"`
+	Time time.Time `json:"time"`
+}
+
+// FileChangeNotifier delivers file change notifications to a session
+type FileChangeNotifier interface {
+	NotifyFileChanged(ctx context.Context, sessionID vo.SessionID, change FileChange)
+}
+
+// FileWatcher tracks per-session file watches
+type FileWatcher struct {
+	mu       sync.Mutex
+	notifier FileChangeNotifier
+	watches  map[string]map[string]*fileWatch // session ID -> path -> watch
+}
+
+// fileWatch watches a single file until stopped or expired
+type fileWatch struct {
+	path     string
+	expires  time.Time
+	watcher  *fsnotify.Watcher
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewFileWatcher creates a FileWatcher
+func NewFileWatcher() *FileWatcher {
+	return &FileWatcher{watches: make(map[string]map[string]*fileWatch)}
+}
+
+// SetNotifier sets the notifier that receives file changes
+func (w *FileWatcher) SetNotifier(notifier FileChangeNotifier) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.notifier = notifier
+}
+
+// Watch starts watching path for the session, replacing any existing watch on it
+func (w *FileWatcher) Watch(sessionID vo.SessionID, path string, duration time.Duration) (time.Time, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	sessionWatches := w.watches[sessionID.String()]
+	if existing, ok := sessionWatches[path]; ok {
+		existing.close()
+		delete(sessionWatches, path)
+	} else if len(sessionWatches) >= MaxWatchesPerSession {
+		return time.Time{}, fmt.Errorf("%w (%d)", ErrWatchLimitReached, MaxWatchesPerSession)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return time.Time{}, err
+	}
+	// Watch the parent directory so editors that save by renaming are still seen
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return time.Time{}, err
+	}
+
+	watch := &fileWatch{
+		path:    path,
+		expires: time.Now().Add(duration),
+		watcher: watcher,
+		stop:    make(chan struct{}),
+	}
+	if sessionWatches == nil {
+		sessionWatches = make(map[string]*fileWatch)
+		w.watches[sessionID.String()] = sessionWatches
+	}
+	sessionWatches[path] = watch
+
+	go w.run(sessionID, watch, duration)
+	return watch.expires, nil
+}
+
+// Unwatch stops watching path for the session
+func (w *FileWatcher) Unwatch(sessionID vo.SessionID, path string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	watch, ok := w.watches[sessionID.String()][path]
+	if !ok {
+		return ErrWatchNotFound
+	}
+	w.removeLocked(sessionID, watch)
+	return nil
+}
+
+// UnwatchSession stops all watches held by the session
+func (w *FileWatcher) UnwatchSession(sessionID vo.SessionID) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, watch := range w.watches[sessionID.String()] {
+		watch.close()
+	}
+	delete(w.watches, sessionID.String())
+}
+
+// Watches returns the paths watched by the session
+func (w *FileWatcher) Watches(sessionID vo.SessionID) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	paths := make([]string, 0, len(w.watches[sessionID.String()]))
+	for path := range w.watches[sessionID.String()] {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// WatchExpiry returns when the session's watch on path expires, and false if the path
+// is not watched or its watch has expired. A new watch on the same path has a new expiry.
+func (w *FileWatcher) WatchExpiry(sessionID vo.SessionID, path string) (time.Time, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	watch, ok := w.watches[sessionID.String()][path]
+	if !ok {
+		return time.Time{}, false
+	}
+	// The watch goroutine may not have removed an expired watch yet
+	if !time.Now().Before(watch.expires) {
+		w.removeLocked(sessionID, watch)
+		return time.Time{}, false
+	}
+	return watch.expires, true
+}
+
+// Close stops every watch
+func (w *FileWatcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sessionWatches := range w.watches {
+		for _, watch := range sessionWatches {
+			watch.close()
+		}
+	}
+	w.watches = make(map[string]map[string]*fileWatch)
+}
+
+// removeLocked stops a watch and forgets it if it is still the session's watch on its path
+func (w *FileWatcher) removeLocked(sessionID vo.SessionID, watch *fileWatch) {
+	watch.close()
+	sessionWatches := w.watches[sessionID.String()]
+	if sessionWatches[watch.path] == watch {
+		delete(sessionWatches, watch.path)
+	}
+	if len(sessionWatches) == 0 {
+		delete(w.watches, sessionID.String())
+	}
+}
+
+// run forwards debounced changes to the watched file until the watch stops or expires
+func (w *FileWatcher) run(sessionID vo.SessionID, watch *fileWatch, duration time.Duration) {
+	expired := time.NewTimer(duration)
+	defer expired.Stop()
+
+	var debounce <-chan time.Time
+	var pending fsnotify.Op
+
+	for {
+		select {
+		case <-watch.stop:
+			return
+		case <-expired.C:
+			w.mu.Lock()
+			w.removeLocked(sessionID, watch)
+			w.mu.Unlock()
+			return
+		case event, ok := <-watch.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != watch.path || event.Op == fsnotify.Chmod {
+				continue
+			}
+			pending |= event.Op
+			debounce = time.After(watchDebounce)
+		case <-debounce:
+			w.notify(sessionID, FileChange{
+				Path: watch.path,
+				URI:  "file://" + watch.path,
+				Op:   strings.ToLower(pending.String()),
+				Time: time.Now().UTC(),
+			})
+			pending, debounce = 0, nil
+		case _, ok := <-watch.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (w *FileWatcher) notify(sessionID vo.SessionID, change FileChange) {
+	w.mu.Lock()
+	notifier := w.notifier
+	w.mu.Unlock()
+
+	if notifier != nil {
+		notifier.NotifyFileChanged(context.Background(), sessionID, change)
+	}
+}
+
+func (f *fileWatch) close() {
+	f.stopOnce.Do(func() {
+		close(f.stop)
+		_ = f.watcher.Close()
+	})
+}
+
+// FileWatcher returns the watcher backing the watch_file tool
+func (r *ToolRegistry) FileWatcher() *FileWatcher {
+	return r.watcher
+}
+
+// registerWatchFile registers the watch file tool
+func (r *ToolRegistry) registerWatchFile() {
+	name, _ := vo.NewToolName("watch_file")
+	desc, _ := vo.NewToolDescription("Watch a file and receive notifications when it changes, for a bounded duration or until unwatched")
+
+	minSeconds, maxSeconds := 1.0, MaxWatchDuration.Seconds()
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"path": {
+				Type:        "string",
+				Description: "The path to the file to watch",
+			},
+			"duration_seconds": {
+				Type:        "integer",
+				Description: fmt.Sprintf("How long to watch the file (default: %d)", int(DefaultWatchDuration.Seconds())),
+				Minimum:     &minSeconds,
+				Maximum:     &maxSeconds,
+			},
+		},
+		Required: []string{"path"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("file")
+	tool.SetTags([]string{"file", "watch", "notification"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Watch File", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetExamples([]map[string]interface{}{
+		{"path": "config.yaml"},
+		{"path": "build/output.log", "duration_seconds": 600},
+	})
+	tool.SetContextHandler(r.handleWatchFile)
+
+	r.tools["watch_file"] = tool
+}
+
+func (r *ToolRegistry) handleWatchFile(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	sessionID, ok := entities.SessionIDFromContext(ctx)
+	if !ok {
+		return entities.NewErrorToolResult(ErrSessionRequired), nil
+	}
+
+	absPath, err := r.resolveWatchPath(ctx, input)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if info.IsDir() {
+		return entities.NewErrorToolResult(fmt.Errorf("%s is a directory", absPath)), nil
+	}
+
+	duration := DefaultWatchDuration
+	if v, ok := input["duration_seconds"].(float64); ok {
+		if v != float64(int(v)) || v < 1 || v > MaxWatchDuration.Seconds() {
+			return entities.NewErrorToolResult(fmt.Errorf("duration_seconds must be an integer between 1 and %d", int(MaxWatchDuration.Seconds()))), nil
+		}
+		duration = time.Duration(v) * time.Second
+	}
+
+	expires, err := r.watcher.Watch(sessionID, absPath, duration)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	result := entities.NewTextToolResult(fmt.Sprintf("Watching %s until %s", absPath, expires.UTC().Format(time.RFC3339)))
+	result.SetMeta("uri", "file://"+absPath)
+	result.SetMeta("expiresAt", expires.UTC())
+	return result, nil
+}
+
+// registerUnwatchFile registers the unwatch file tool
+func (r *ToolRegistry) registerUnwatchFile() {
+	name, _ := vo.NewToolName("unwatch_file")
+	desc, _ := vo.NewToolDescription("Stop watching a file previously watched with watch_file")
+
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"path": {
+				Type:        "string",
+				Description: "The path to the watched file",
+			},
+		},
+		Required: []string{"path"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("file")
+	tool.SetTags([]string{"file", "watch", "notification"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Unwatch File", IdempotentHint: true})
+	tool.SetContextHandler(r.handleUnwatchFile)
+
+	r.tools["unwatch_file"] = tool
+}
+
+func (r *ToolRegistry) handleUnwatchFile(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	sessionID, ok := entities.SessionIDFromContext(ctx)
+	if !ok {
+		return entities.NewErrorToolResult(ErrSessionRequired), nil
+	}
+
+	absPath, err := r.resolveWatchPath(ctx, input)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if err := r.watcher.Unwatch(sessionID, absPath); err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	return entities.NewTextToolResult(fmt.Sprintf("Stopped watching %s", absPath)), nil
+}
+
+// resolveWatchPath resolves the path input against the session working directory and the allowlist
+func (r *ToolRegistry) resolveWatchPath(ctx context.Context, input map[string]interface{}) (string, error) {
+	path, _ := input["path"].(string)
+	return r.resolveToolPath(ctx, path)
+}
@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// WorkingDirKey is the session store key holding the session working directory
+const WorkingDirKey = "working_dir"
+
+// registerSetWorkingDir registers the set working directory tool
+func (r *ToolRegistry) registerSetWorkingDir() {
+	name, _ := vo.NewToolName("set_working_dir")
+	desc, _ := vo.NewToolDescription("Set the working directory used by execute_command for the rest of the session")
+
+	schema := &entities.JSONSchema{
+		Type: "object",
+		Properties: map[string]*entities.JSONSchema{
+			"path": {
+				Type:        "string",
+				Description: "The directory to use as the session working directory",
+			},
+		},
+		Required: []string{"path"},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("system")
+	tool.SetTags([]string{"session", "directory"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Set Working Directory", IdempotentHint: true})
+	tool.SetContextHandler(r.handleSetWorkingDir)
+
+	r.tools["set_working_dir"] = tool
+}
+
+func (r *ToolRegistry) handleSetWorkingDir(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	store, ok := entities.SessionStoreFromContext(ctx)
+	if !ok {
+		return entities.NewErrorToolResult(entities.ErrSessionStoreNotAvailable), nil
+	}
+
+	path, ok := input["path"].(string)
+	if !ok || path == "" {
+		return entities.NewErrorToolResult(fmt.Errorf("path is required")), nil
+	}
+
+	// Relative paths resolve against the current session working directory
+	if !filepath.IsAbs(path) {
+		if cwd, ok := store.Get(WorkingDirKey); ok {
+			path = filepath.Join(cwd, path)
+		}
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if err := r.CheckPath(ctx, absPath); err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	if !info.IsDir() {
+		return entities.NewErrorToolResult(fmt.Errorf("%s is not a directory", absPath)), nil
+	}
+
+	if err := store.Set(WorkingDirKey, absPath); err != nil {
+		return entities.NewErrorToolResult(err), nil
+	}
+	return entities.NewTextToolResult(absPath), nil
+}
+
+// registerGetWorkingDir registers the get working directory tool
+func (r *ToolRegistry) registerGetWorkingDir() {
+	name, _ := vo.NewToolName("get_working_dir")
+	desc, _ := vo.NewToolDescription("Get the session working directory used by execute_command")
+
+	schema := &entities.JSONSchema{
+		Type:       "object",
+		Properties: map[string]*entities.JSONSchema{},
+	}
+
+	tool, _ := entities.NewTool(name, desc, schema)
+	tool.SetCategory("system")
+	tool.SetTags([]string{"session", "directory"})
+	tool.SetAnnotations(&entities.ToolAnnotations{Title: "Get Working Directory", ReadOnlyHint: true, IdempotentHint: true})
+	tool.SetContextHandler(handleGetWorkingDir)
+
+	r.tools["get_working_dir"] = tool
+}
+
+func handleGetWorkingDir(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+	return entities.NewTextToolResult(sessionWorkingDir(ctx)), nil
+}
+
+// sessionWorkingDir returns the session working directory, falling back to the process's
+func sessionWorkingDir(ctx context.Context) string {
+	if store, ok := entities.SessionStoreFromContext(ctx); ok {
+		if cwd, ok := store.Get(WorkingDirKey); ok {
+			return cwd
+		}
+	}
+	wd, _ := os.Getwd()
+	return wd
+}
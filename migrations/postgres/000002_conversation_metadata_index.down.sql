@@ -0,0 +1,7 @@
+-- ============================================================================
+-- TelemetryFlow GO MCP - Conversation Metadata Index (Rollback)
+-- Version: 000002
+-- Description: Drops the conversation metadata index
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_conversations_metadata;
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- TelemetryFlow GO MCP - Conversation Metadata Index
+-- Version: 000002
+-- Description: Indexes conversation metadata for key/value lookups
+-- ============================================================================
+
+-- jsonb_path_ops serves the @> containment queries used to find conversations
+-- by metadata and keeps the index smaller than the default operator class.
+CREATE INDEX IF NOT EXISTS idx_conversations_metadata ON conversations USING GIN (metadata jsonb_path_ops);
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- TelemetryFlow GO MCP - Conversation Templates (Rollback)
+-- Version: 000003
+-- Description: Drops the conversation templates table
+-- ============================================================================
+
+DROP TRIGGER IF EXISTS update_conversation_templates_updated_at ON conversation_templates;
+DROP TABLE IF EXISTS conversation_templates;
@@ -0,0 +1,30 @@
+-- ============================================================================
+-- TelemetryFlow GO MCP - Conversation Templates
+-- Version: 000003
+-- Description: Stores reusable conversation setups (system prompt, tools,
+--              model and sampling defaults)
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS conversation_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    system_prompt TEXT,
+    tools JSONB NOT NULL DEFAULT '[]',
+    model VARCHAR(100) NOT NULL,
+    max_tokens INTEGER NOT NULL DEFAULT 0 CHECK (max_tokens >= 0),
+    -- NULL sampling settings keep the conversation defaults
+    temperature DECIMAL(3,2) CHECK (temperature >= 0 AND temperature <= 2),
+    top_p DECIMAL(3,2) CHECK (top_p >= 0 AND top_p <= 1),
+    top_k INTEGER CHECK (top_k >= 0),
+    stop_sequences JSONB NOT NULL DEFAULT '[]',
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_templates_name ON conversation_templates(name);
+
+CREATE TRIGGER update_conversation_templates_updated_at
+    BEFORE UPDATE ON conversation_templates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- TelemetryFlow GO MCP - Resource Annotations (Rollback)
+-- Version: 000004
+-- Description: Drops the resource annotation columns
+-- ============================================================================
+
+ALTER TABLE resources DROP CONSTRAINT IF EXISTS resources_priority_check;
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS audience;
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- TelemetryFlow GO MCP - Resource Annotations
+-- Version: 000004
+-- Description: Adds the MCP audience and priority annotations to resources
+-- ============================================================================
+
+ALTER TABLE resources
+    ADD COLUMN IF NOT EXISTS audience JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS priority DECIMAL(3,2);
+
+-- NULL priority leaves the importance of a resource unspecified
+ALTER TABLE resources
+    ADD CONSTRAINT resources_priority_check CHECK (priority IS NULL OR (priority >= 0 AND priority <= 1));
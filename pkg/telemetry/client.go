@@ -0,0 +1,112 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Client names and versions come from the client, so the values recorded as
+// attributes are bounded
+const (
+	// MaxClientAttributeLength is the longest client name or version recorded, in runes
+	MaxClientAttributeLength = 64
+	// MaxMetricClients is how many distinct clients metrics are broken down by; later
+	// clients are recorded as OtherClient
+	MaxMetricClients = 50
+	// OtherClient is the client name and version recorded for clients past MaxMetricClients
+	OtherClient = "other"
+)
+
+// ClientInfo identifies the MCP client driving a request, as sent in initialize
+type ClientInfo struct {
+	Name    string
+	Version string
+}
+
+type clientInfoKey struct{}
+
+// ContextWithClientInfo returns a context carrying the client behind the request, so
+// logs, metrics and spans recorded while handling it can be broken down by client
+func ContextWithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext returns the client carried by ctx. It reports false before
+// the session is initialized, when no client is known.
+func ClientInfoFromContext(ctx context.Context) (ClientInfo, bool) {
+	info, ok := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info, ok && info.Name != ""
+}
+
+// ClientAttributes returns the client name and version carried by ctx as attributes,
+// clamped to MaxClientAttributeLength, or none when no client is known
+func ClientAttributes(ctx context.Context) []attribute.KeyValue {
+	info, ok := ClientInfoFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return info.clamped().attributes()
+}
+
+// clamped returns info with its name and version cut to MaxClientAttributeLength
+func (info ClientInfo) clamped() ClientInfo {
+	return ClientInfo{Name: clampAttribute(info.Name), Version: clampAttribute(info.Version)}
+}
+
+func (info ClientInfo) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(AttrClientName, info.Name),
+		attribute.String(AttrClientVersion, info.Version),
+	}
+}
+
+func clampAttribute(value string) string {
+	if utf8.RuneCountInString(value) <= MaxClientAttributeLength {
+		return value
+	}
+	return string([]rune(value)[:MaxClientAttributeLength])
+}
+
+// clientBuckets bounds the clients metrics are broken down by: the first
+// MaxMetricClients distinct clients keep their own series and the rest share one
+type clientBuckets struct {
+	mu   sync.Mutex
+	seen map[ClientInfo]bool
+}
+
+func newClientBuckets() *clientBuckets {
+	return &clientBuckets{seen: make(map[ClientInfo]bool)}
+}
+
+// attributes returns the client attributes carried by ctx for a metric, or none when
+// no client is known
+func (b *clientBuckets) attributes(ctx context.Context) []attribute.KeyValue {
+	info, ok := ClientInfoFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info = info.clamped()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.seen[info] {
+		if len(b.seen) >= MaxMetricClients {
+			return ClientInfo{Name: OtherClient, Version: OtherClient}.attributes()
+		}
+		b.seen[info] = true
+	}
+	return info.attributes()
+}
+
+// AddClientFields adds the client name and version carried by ctx to fields, keyed as
+// in the MCP request logs, and returns fields
+func AddClientFields(ctx context.Context, fields map[string]interface{}) map[string]interface{} {
+	if info, ok := ClientInfoFromContext(ctx); ok {
+		fields["client_name"] = info.Name
+		fields["client_version"] = info.Version
+	}
+	return fields
+}
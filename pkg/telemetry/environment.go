@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"os"
+	"strings"
+)
+
+// Environment variables consulted, in order, to detect the deployment environment.
+// The first is the one the config loader maps to telemetry.environment.
+const (
+	EnvTelemetryEnvironment = "TELEMETRYFLOW_MCP_TELEMETRY_ENVIRONMENT"
+	EnvEnvironment          = "TELEMETRYFLOW_ENVIRONMENT"
+)
+
+// Deployment environments
+const (
+	EnvironmentProduction  = "production"
+	EnvironmentDevelopment = "development"
+)
+
+// LocalCollectorEndpoint is the collector endpoint assumed in development
+const LocalCollectorEndpoint = "localhost:4317"
+
+// DetectEnvironment returns the deployment environment named by
+// TELEMETRYFLOW_MCP_TELEMETRY_ENVIRONMENT or, failing that, TELEMETRYFLOW_ENVIRONMENT.
+// It returns production when neither is set, so defaults stay strict unless a
+// developer opts out.
+func DetectEnvironment() string {
+	for _, key := range []string{EnvTelemetryEnvironment, EnvEnvironment} {
+		if env := strings.TrimSpace(os.Getenv(key)); env != "" {
+			return env
+		}
+	}
+	return EnvironmentProduction
+}
+
+// IsDevelopmentEnvironment reports whether env names a developer machine: dev,
+// development or local, in any case
+func IsDevelopmentEnvironment(env string) bool {
+	switch strings.ToLower(strings.TrimSpace(env)) {
+	case "dev", EnvironmentDevelopment, "local":
+		return true
+	}
+	return false
+}
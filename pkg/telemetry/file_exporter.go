@@ -0,0 +1,345 @@
+// Package telemetry provides a local file exporter for offline debugging.
+//
+// TelemetryFlow GO MCP Server - Model Context Protocol Server
+// Copyright (c) 2024-2026 TelemetryFlow. All rights reserved.
+package telemetry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Export modes select where logs, metrics and spans are sent
+const (
+	// ExportModeOTLP sends telemetry to the TelemetryFlow collector (default)
+	ExportModeOTLP = "otlp"
+	// ExportModeFile writes telemetry to a local file instead of the collector
+	ExportModeFile = "file"
+	// ExportModeBoth sends telemetry to the collector and writes it to a local file
+	ExportModeBoth = "both"
+)
+
+// File exporter defaults
+const (
+	DefaultExportFileMaxBytes   = 50 * 1024 * 1024
+	DefaultExportFileMaxBackups = 3
+
+	// maxOpenFileSpans bounds the spans tracked between StartSpan and EndSpan
+	maxOpenFileSpans = 10000
+)
+
+// ErrInvalidExportMode is returned for an export mode other than otlp, file or both
+var ErrInvalidExportMode = errors.New("export mode must be 'otlp', 'file' or 'both'")
+
+// ParseExportMode parses an export mode name; an empty name selects otlp
+func ParseExportMode(mode string) (string, error) {
+	switch mode = strings.ToLower(mode); mode {
+	case "":
+		return ExportModeOTLP, nil
+	case ExportModeOTLP, ExportModeFile, ExportModeBoth:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidExportMode, mode)
+	}
+}
+
+// Environment variables the FromEnv constructors read the export settings from. They
+// are the ones the config loader maps to telemetry.export_mode and telemetry.export_file.
+const (
+	EnvExportMode = "TELEMETRYFLOW_MCP_TELEMETRY_EXPORT_MODE"
+	EnvExportFile = "TELEMETRYFLOW_MCP_TELEMETRY_EXPORT_FILE"
+)
+
+// ExportsToFile reports whether mode writes telemetry to a file
+func ExportsToFile(mode string) bool {
+	return mode == ExportModeFile || mode == ExportModeBoth
+}
+
+// FileExporterConfig configures a FileExporter
+type FileExporterConfig struct {
+	// Path is the file telemetry is appended to
+	Path string
+	// ServiceName is recorded on every line
+	ServiceName string
+	// MaxBytes is the size at which the file is rotated (default DefaultExportFileMaxBytes)
+	MaxBytes int64
+	// MaxBackups is the number of rotated files kept as Path.1 … Path.N (default DefaultExportFileMaxBackups)
+	MaxBackups int
+}
+
+// FileRecord is a line of the export file. Signal is "log", "metric" or "span";
+// the other fields are set as they apply to the signal.
+type FileRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	Signal    string    `json:"signal"`
+	Service   string    `json:"service,omitempty"`
+
+	// Logs
+	Severity string `json:"severity,omitempty"`
+	Message  string `json:"message,omitempty"`
+
+	// Metrics and spans
+	Name string `json:"name,omitempty"`
+
+	// Metrics
+	MetricType string   `json:"metric_type,omitempty"` // metric, counter, gauge or histogram
+	Value      *float64 `json:"value,omitempty"`
+	Unit       string   `json:"unit,omitempty"`
+
+	// Spans
+	SpanID     string          `json:"span_id,omitempty"`
+	Kind       string          `json:"kind,omitempty"`
+	StartTime  *time.Time      `json:"start_time,omitempty"`
+	DurationMs *float64        `json:"duration_ms,omitempty"`
+	Status     string          `json:"status,omitempty"` // ok or error
+	Error      string          `json:"error,omitempty"`
+	Events     []FileSpanEvent `json:"events,omitempty"`
+
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// FileSpanEvent is an event recorded on a span
+type FileSpanEvent struct {
+	Timestamp  time.Time              `json:"timestamp"`
+	Name       string                 `json:"name"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// FileExporter writes logs, metrics and spans as JSON lines to a local file, for
+// debugging where the collector is unreachable. The file is rotated once it would
+// grow past its size limit. A FileExporter is safe for concurrent use.
+type FileExporter struct {
+	path       string
+	service    string
+	maxBytes   int64
+	maxBackups int
+
+	mu    sync.Mutex
+	file  *os.File
+	size  int64
+	spans map[string]*FileRecord // Spans started but not yet ended
+}
+
+// NewFileExporter opens the export file, creating it and its directory if needed
+func NewFileExporter(cfg FileExporterConfig) (*FileExporter, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("file exporter path is required")
+	}
+	e := &FileExporter{
+		path:       cfg.Path,
+		service:    cfg.ServiceName,
+		maxBytes:   cfg.MaxBytes,
+		maxBackups: cfg.MaxBackups,
+		spans:      make(map[string]*FileRecord),
+	}
+	if e.maxBytes <= 0 {
+		e.maxBytes = DefaultExportFileMaxBytes
+	}
+	if e.maxBackups <= 0 {
+		e.maxBackups = DefaultExportFileMaxBackups
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Path returns the path of the export file
+func (e *FileExporter) Path() string {
+	return e.path
+}
+
+// ExportLog writes a log entry
+func (e *FileExporter) ExportLog(severity, message string, attrs map[string]interface{}) error {
+	return e.write(&FileRecord{
+		Timestamp:  time.Now().UTC(),
+		Signal:     "log",
+		Severity:   severity,
+		Message:    message,
+		Attributes: attrs,
+	})
+}
+
+// ExportMetric writes a metric measurement of the given type
+func (e *FileExporter) ExportMetric(metricType, name string, value float64, unit string, attrs map[string]interface{}) error {
+	return e.write(&FileRecord{
+		Timestamp:  time.Now().UTC(),
+		Signal:     "metric",
+		Name:       name,
+		MetricType: metricType,
+		Value:      &value,
+		Unit:       unit,
+		Attributes: attrs,
+	})
+}
+
+// StartSpan starts tracking a span, which is written when it ends. An empty spanID
+// is replaced by a generated one; the span's ID is returned.
+func (e *FileExporter) StartSpan(spanID, name, kind string, attrs map[string]interface{}) string {
+	if spanID == "" {
+		spanID = newFileSpanID()
+	}
+	start := time.Now().UTC()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.spans) < maxOpenFileSpans {
+		e.spans[spanID] = &FileRecord{
+			Signal:     "span",
+			Name:       name,
+			SpanID:     spanID,
+			Kind:       kind,
+			StartTime:  &start,
+			Attributes: copyAttributes(attrs),
+		}
+	}
+	return spanID
+}
+
+// AddSpanEvent records an event on a started span
+func (e *FileExporter) AddSpanEvent(spanID, name string, attrs map[string]interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if span, ok := e.spans[spanID]; ok {
+		span.Events = append(span.Events, FileSpanEvent{Timestamp: time.Now().UTC(), Name: name, Attributes: copyAttributes(attrs)})
+	}
+}
+
+// EndSpan writes a started span with its duration and outcome
+func (e *FileExporter) EndSpan(spanID string, spanErr error) error {
+	e.mu.Lock()
+	span, ok := e.spans[spanID]
+	delete(e.spans, spanID)
+	e.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	span.Timestamp = time.Now().UTC()
+	duration := float64(span.Timestamp.Sub(*span.StartTime).Microseconds()) / 1000
+	span.DurationMs = &duration
+	span.Status = "ok"
+	if spanErr != nil {
+		span.Status = "error"
+		span.Error = spanErr.Error()
+	}
+	return e.write(span)
+}
+
+// Sync commits the export file to stable storage
+func (e *FileExporter) Sync() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	return e.file.Sync()
+}
+
+// Close closes the export file. Spans that have not ended are not written.
+func (e *FileExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
+
+// write appends a record to the file, rotating it first if the record would not fit
+func (e *FileExporter) write(record *FileRecord) error {
+	record.Service = e.service
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry record: %w", err)
+	}
+	data = append(data, '\n')
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return errors.New("file exporter is closed")
+	}
+	if e.size > 0 && e.size+int64(len(data)) > e.maxBytes {
+		if err := e.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := e.file.Write(data)
+	e.size += int64(n)
+	return err
+}
+
+// open opens the export file for appending
+func (e *FileExporter) open() error {
+	file, err := os.OpenFile(e.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	e.file = file
+	e.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups along, moves the file to Path.1 and starts a new one.
+// The caller holds e.mu.
+func (e *FileExporter) rotate() error {
+	if err := e.file.Close(); err != nil {
+		return fmt.Errorf("failed to rotate export file: %w", err)
+	}
+	e.file = nil
+
+	_ = os.Remove(e.backupPath(e.maxBackups))
+	for i := e.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(e.backupPath(i), e.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate export file: %w", err)
+		}
+	}
+	if err := os.Rename(e.path, e.backupPath(1)); err != nil {
+		return fmt.Errorf("failed to rotate export file: %w", err)
+	}
+	return e.open()
+}
+
+// backupPath returns the path of the nth rotated file
+func (e *FileExporter) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", e.path, n)
+}
+
+// copyAttributes copies attributes held until a span ends, so the caller may reuse its map
+func copyAttributes(attrs map[string]interface{}) map[string]interface{} {
+	if attrs == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(attrs))
+	for k, v := range attrs {
+		copied[k] = v
+	}
+	return copied
+}
+
+// newFileSpanID returns a random span ID
+func newFileSpanID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
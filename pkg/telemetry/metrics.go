@@ -6,22 +6,26 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
 
 // Metrics holds application metrics
 type Metrics struct {
-	meter metric.Meter
+	meter   metric.Meter
+	clients *clientBuckets
 
 	// Request metrics
 	RequestsTotal    metric.Int64Counter
 	RequestDuration  metric.Float64Histogram
 	RequestsInFlight metric.Int64UpDownCounter
+	RequestsRejected metric.Int64Counter
 
 	// Tool metrics
 	ToolCallsTotal   metric.Int64Counter
 	ToolCallDuration metric.Float64Histogram
 	ToolErrors       metric.Int64Counter
+	ToolThrottled    metric.Int64Counter
 
 	// Claude API metrics
 	ClaudeRequestsTotal metric.Int64Counter
@@ -30,20 +34,32 @@ type Metrics struct {
 	ClaudeLatency       metric.Float64Histogram
 	ClaudeErrors        metric.Int64Counter
 
+	// Agentic loop metrics
+	TurnIterationLimitReached metric.Int64Counter
+
 	// Session metrics
-	ActiveSessions  metric.Int64UpDownCounter
-	SessionDuration metric.Float64Histogram
+	ActiveSessions   metric.Int64UpDownCounter
+	SessionDuration  metric.Float64Histogram
+	SessionsOpen     metric.Int64Gauge
+	SessionsRejected metric.Int64Counter
+
+	// Server lifecycle metrics
+	Shutdowns metric.Int64Counter
 
 	// Resource metrics
 	ResourceReadsTotal  metric.Int64Counter
 	ResourceCacheHits   metric.Int64Counter
 	ResourceCacheMisses metric.Int64Counter
+
+	// Queue metrics
+	QueuePublishDuration metric.Float64Histogram
+	QueuePublishFailures metric.Int64Counter
 }
 
 // NewMetrics creates a new Metrics instance
 func NewMetrics(serviceName string) (*Metrics, error) {
 	meter := otel.Meter(serviceName)
-	m := &Metrics{meter: meter}
+	m := &Metrics{meter: meter, clients: newClientBuckets()}
 
 	var err error
 
@@ -75,6 +91,15 @@ func NewMetrics(serviceName string) (*Metrics, error) {
 		return nil, err
 	}
 
+	m.RequestsRejected, err = meter.Int64Counter(
+		"mcp.requests.rejected_overload",
+		metric.WithDescription("Number of requests rejected because the request queue was full"),
+		metric.WithUnit("{requests}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Tool metrics
 	m.ToolCallsTotal, err = meter.Int64Counter(
 		"mcp.tool.calls.total",
@@ -103,6 +128,15 @@ func NewMetrics(serviceName string) (*Metrics, error) {
 		return nil, err
 	}
 
+	m.ToolThrottled, err = meter.Int64Counter(
+		"mcp.tool.throttled.total",
+		metric.WithDescription("Number of tool calls refused by a per-session tool rate limit"),
+		metric.WithUnit("{calls}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Claude API metrics
 	m.ClaudeRequestsTotal, err = meter.Int64Counter(
 		"claude.requests.total",
@@ -149,6 +183,16 @@ func NewMetrics(serviceName string) (*Metrics, error) {
 		return nil, err
 	}
 
+	// Agentic loop metrics
+	m.TurnIterationLimitReached, err = meter.Int64Counter(
+		"mcp.turn.iteration_limit_reached",
+		metric.WithDescription("Number of agentic turns stopped at the iteration limit"),
+		metric.WithUnit("{turns}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Session metrics
 	m.ActiveSessions, err = meter.Int64UpDownCounter(
 		"mcp.sessions.active",
@@ -168,6 +212,24 @@ func NewMetrics(serviceName string) (*Metrics, error) {
 		return nil, err
 	}
 
+	m.SessionsOpen, err = meter.Int64Gauge(
+		"mcp.sessions.open",
+		metric.WithDescription("Number of sessions that have not been closed"),
+		metric.WithUnit("{sessions}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.SessionsRejected, err = meter.Int64Counter(
+		"mcp.sessions.rejected",
+		metric.WithDescription("Number of sessions rejected because the server was at capacity"),
+		metric.WithUnit("{sessions}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Resource metrics
 	m.ResourceReadsTotal, err = meter.Int64Counter(
 		"mcp.resource.reads.total",
@@ -196,19 +258,53 @@ func NewMetrics(serviceName string) (*Metrics, error) {
 		return nil, err
 	}
 
+	// Queue metrics
+	m.QueuePublishDuration, err = meter.Float64Histogram(
+		"queue.publish.duration",
+		metric.WithDescription("Time from publishing a message to its JetStream ack"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.QueuePublishFailures, err = meter.Int64Counter(
+		"queue.publish.failures",
+		metric.WithDescription("Number of messages JetStream did not acknowledge"),
+		metric.WithUnit("{messages}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Server lifecycle metrics
+	m.Shutdowns, err = meter.Int64Counter(
+		"mcp.server.shutdowns",
+		metric.WithDescription("Number of server shutdowns by outcome (clean or forced)"),
+		metric.WithUnit("{shutdowns}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
 
 // RecordRequest records a request metric
 func (m *Metrics) RecordRequest(ctx context.Context, method string, duration time.Duration, err error) {
-	attrs := metric.WithAttributes()
+	attrs := metric.WithAttributes(m.clients.attributes(ctx)...)
 	m.RequestsTotal.Add(ctx, 1, attrs)
 	m.RequestDuration.Record(ctx, duration.Seconds(), attrs)
 }
 
+// RecordRequestRejectedOverload records a request rejected because the server was busy
+func (m *Metrics) RecordRequestRejectedOverload(ctx context.Context) {
+	m.RequestsRejected.Add(ctx, 1)
+}
+
 // RecordToolCall records a tool call metric
 func (m *Metrics) RecordToolCall(ctx context.Context, toolName string, duration time.Duration, err error) {
-	attrs := metric.WithAttributes()
+	attrs := metric.WithAttributes(m.clients.attributes(ctx)...)
 	m.ToolCallsTotal.Add(ctx, 1, attrs)
 	m.ToolCallDuration.Record(ctx, duration.Seconds(), attrs)
 	if err != nil {
@@ -216,9 +312,14 @@ func (m *Metrics) RecordToolCall(ctx context.Context, toolName string, duration
 	}
 }
 
+// RecordToolThrottled records a tool call refused by a per-session rate limit
+func (m *Metrics) RecordToolThrottled(ctx context.Context, toolName string) {
+	m.ToolThrottled.Add(ctx, 1, metric.WithAttributes(attribute.String("tool", toolName)))
+}
+
 // RecordClaudeRequest records a Claude API request metric
 func (m *Metrics) RecordClaudeRequest(ctx context.Context, model string, inputTokens, outputTokens int, duration time.Duration, err error) {
-	attrs := metric.WithAttributes()
+	attrs := metric.WithAttributes(m.clients.attributes(ctx)...)
 	m.ClaudeRequestsTotal.Add(ctx, 1, attrs)
 	m.ClaudeTokensInput.Add(ctx, int64(inputTokens), attrs)
 	m.ClaudeTokensOutput.Add(ctx, int64(outputTokens), attrs)
@@ -228,6 +329,27 @@ func (m *Metrics) RecordClaudeRequest(ctx context.Context, model string, inputTo
 	}
 }
 
+// RecordTurnIterationLimitReached records an agentic turn stopped at the iteration limit
+func (m *Metrics) RecordTurnIterationLimitReached(ctx context.Context) {
+	m.TurnIterationLimitReached.Add(ctx, 1)
+}
+
+// RecordShutdown records a server shutdown and whether in-flight work had to be terminated
+func (m *Metrics) RecordShutdown(ctx context.Context, outcome string) {
+	m.Shutdowns.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
+
+// RecordQueuePublish records a message published to the queue: its ack latency when
+// JetStream acknowledged it and a failure otherwise
+func (m *Metrics) RecordQueuePublish(ctx context.Context, stream, subject string, duration time.Duration, err error) {
+	attrs := metric.WithAttributes(attribute.String("stream", stream), attribute.String("subject", subject))
+	if err != nil {
+		m.QueuePublishFailures.Add(ctx, 1, attrs)
+		return
+	}
+	m.QueuePublishDuration.Record(ctx, duration.Seconds(), attrs)
+}
+
 // IncrementActiveSessions increments active sessions counter
 func (m *Metrics) IncrementActiveSessions(ctx context.Context) {
 	m.ActiveSessions.Add(ctx, 1)
@@ -238,6 +360,16 @@ func (m *Metrics) DecrementActiveSessions(ctx context.Context) {
 	m.ActiveSessions.Add(ctx, -1)
 }
 
+// RecordSessionCount records the current number of open sessions
+func (m *Metrics) RecordSessionCount(ctx context.Context, count int) {
+	m.SessionsOpen.Record(ctx, int64(count))
+}
+
+// RecordSessionRejected records a session rejected at capacity
+func (m *Metrics) RecordSessionRejected(ctx context.Context) {
+	m.SessionsRejected.Add(ctx, 1)
+}
+
 // RecordSessionDuration records session duration
 func (m *Metrics) RecordSessionDuration(ctx context.Context, duration time.Duration) {
 	m.SessionDuration.Record(ctx, duration.Seconds())
@@ -7,6 +7,7 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 // single, easy-to-use facade.
 type Observability struct {
 	client         *telemetryflow.Client
+	file           *FileExporter // Set when telemetry is also written to a local file
 	tracer         *TFOTracer
 	serviceName    string
 	serviceVersion string
@@ -56,23 +58,47 @@ type ObservabilityConfig struct {
 	Insecure bool
 	// Timeout is the connection timeout
 	Timeout time.Duration
+	// ExportMode sends telemetry to the collector (otlp, default), to ExportFile
+	// instead (file), or to both
+	ExportMode string
+	// ExportFile is the JSON lines file written in the file and both modes
+	ExportFile string
+	// ExportFileMaxBytes is the size at which the export file is rotated
+	ExportFileMaxBytes int64
+	// ExportFileMaxBackups is the number of rotated export files kept
+	ExportFileMaxBackups int
+}
+
+// DefaultObservabilityConfig returns the default configuration for the environment
+// reported by DetectEnvironment.
+func DefaultObservabilityConfig() *ObservabilityConfig {
+	return DefaultObservabilityConfigFor(DetectEnvironment())
 }
 
-// DefaultObservabilityConfig returns default configuration.
-func DefaultObservabilityConfig() *ObservabilityConfig {
-	return &ObservabilityConfig{
+// DefaultObservabilityConfigFor returns the default configuration for env. Production
+// and other shared environments export over TLS to the TelemetryFlow platform; a
+// development environment (see IsDevelopmentEnvironment) exports to a local collector
+// without TLS.
+func DefaultObservabilityConfigFor(env string) *ObservabilityConfig {
+	cfg := &ObservabilityConfig{
 		Endpoint:         "api.telemetryflow.id:4317",
 		ServiceName:      "telemetryflow-go-mcp",
 		ServiceVersion:   "1.1.2",
 		ServiceNamespace: "telemetryflow",
-		Environment:      "production",
+		Environment:      env,
 		EnableMetrics:    true,
 		EnableLogs:       true,
 		EnableTraces:     true,
 		UseGRPC:          true,
 		Insecure:         false,
 		Timeout:          30 * time.Second,
+		ExportMode:       ExportModeOTLP,
+	}
+	if IsDevelopmentEnvironment(env) {
+		cfg.Endpoint = LocalCollectorEndpoint
+		cfg.Insecure = true
 	}
+	return cfg
 }
 
 // NewObservability creates a new observability facade.
@@ -87,6 +113,26 @@ func NewObservability(cfg *ObservabilityConfig) (*Observability, error) {
 		environment:    cfg.Environment,
 	}
 
+	mode, err := ParseExportMode(cfg.ExportMode)
+	if err != nil {
+		return nil, err
+	}
+	if ExportsToFile(mode) {
+		obs.file, err = NewFileExporter(FileExporterConfig{
+			Path:        cfg.ExportFile,
+			ServiceName: cfg.ServiceName,
+			MaxBytes:    cfg.ExportFileMaxBytes,
+			MaxBackups:  cfg.ExportFileMaxBackups,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+	}
+	if mode == ExportModeFile {
+		obs.tracer = newFileTracer(obs.file, cfg.ServiceName, cfg.ServiceVersion)
+		return obs, nil
+	}
+
 	// Build TFO SDK client
 	builder := telemetryflow.NewBuilder().
 		WithAPIKey(cfg.APIKeyID, cfg.APIKeySecret).
@@ -112,29 +158,55 @@ func NewObservability(cfg *ObservabilityConfig) (*Observability, error) {
 
 	client, err := builder.Build()
 	if err != nil {
+		if obs.file != nil {
+			_ = obs.file.Close()
+		}
 		return nil, fmt.Errorf("failed to create TFO SDK client: %w", err)
 	}
 
 	obs.client = client
 	obs.tracer = NewTFOTracer(client, cfg.ServiceName, cfg.ServiceVersion)
+	obs.tracer.file = obs.file
 
 	return obs, nil
 }
 
 // NewObservabilityFromEnv creates an observability facade using environment variables.
+// TELEMETRYFLOW_MCP_TELEMETRY_EXPORT_MODE and TELEMETRYFLOW_MCP_TELEMETRY_EXPORT_FILE
+// select the export mode and file as telemetry.export_mode and export_file do.
 func NewObservabilityFromEnv() (*Observability, error) {
-	client, err := telemetryflow.NewFromEnv()
+	mode, err := ParseExportMode(os.Getenv(EnvExportMode))
 	if err != nil {
 		return nil, err
 	}
 
-	return &Observability{
-		client:         client,
-		tracer:         NewTFOTracer(client, "telemetryflow-go-mcp", "1.1.2"),
+	obs := &Observability{
 		serviceName:    "telemetryflow-go-mcp",
 		serviceVersion: "1.1.2",
 		environment:    "production",
-	}, nil
+	}
+	if ExportsToFile(mode) {
+		obs.file, err = NewFileExporter(FileExporterConfig{Path: os.Getenv(EnvExportFile), ServiceName: obs.serviceName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+	}
+	if mode == ExportModeFile {
+		obs.tracer = newFileTracer(obs.file, obs.serviceName, obs.serviceVersion)
+		return obs, nil
+	}
+
+	client, err := telemetryflow.NewFromEnv()
+	if err != nil {
+		if obs.file != nil {
+			_ = obs.file.Close()
+		}
+		return nil, err
+	}
+	obs.client = client
+	obs.tracer = NewTFOTracer(client, obs.serviceName, obs.serviceVersion)
+	obs.tracer.file = obs.file
+	return obs, nil
 }
 
 // Initialize initializes the observability system.
@@ -146,22 +218,30 @@ func (o *Observability) Initialize(ctx context.Context) error {
 		return nil
 	}
 
-	if o.client == nil {
+	if o.client == nil && o.file == nil {
 		return fmt.Errorf("TFO SDK client not configured")
 	}
 
-	if err := o.client.Initialize(ctx); err != nil {
-		return fmt.Errorf("failed to initialize TFO SDK: %w", err)
+	if o.client != nil {
+		if err := o.client.Initialize(ctx); err != nil {
+			return fmt.Errorf("failed to initialize TFO SDK: %w", err)
+		}
 	}
 
 	o.initialized = true
 
 	// Log initialization
-	_ = o.client.LogInfo(ctx, "Observability initialized", map[string]interface{}{
+	attrs := map[string]interface{}{
 		"service":     o.serviceName,
 		"version":     o.serviceVersion,
 		"environment": o.environment,
-	})
+	}
+	if o.file != nil {
+		_ = o.file.ExportLog("info", "Observability initialized", attrs)
+	}
+	if o.client != nil {
+		_ = o.client.LogInfo(ctx, "Observability initialized", attrs)
+	}
 
 	return nil
 }
@@ -175,6 +255,11 @@ func (o *Observability) Shutdown(ctx context.Context) error {
 		return nil
 	}
 
+	if o.file != nil {
+		if err := o.file.Close(); err != nil {
+			return fmt.Errorf("failed to close export file: %w", err)
+		}
+	}
 	if o.client != nil {
 		if err := o.client.Shutdown(ctx); err != nil {
 			return fmt.Errorf("failed to shutdown TFO SDK: %w", err)
@@ -187,7 +272,15 @@ func (o *Observability) Shutdown(ctx context.Context) error {
 
 // Flush forces a flush of pending telemetry.
 func (o *Observability) Flush(ctx context.Context) error {
-	if o.client != nil && o.isInitialized() {
+	if !o.isInitialized() {
+		return nil
+	}
+	if o.file != nil {
+		if err := o.file.Sync(); err != nil {
+			return err
+		}
+	}
+	if o.client != nil {
 		return o.client.Flush(ctx)
 	}
 	return nil
@@ -200,6 +293,12 @@ func (o *Observability) Log(ctx context.Context, severity string, message string
 	if !o.isInitialized() {
 		return nil
 	}
+	if o.file != nil {
+		_ = o.file.ExportLog(severity, message, attrs)
+	}
+	if o.client == nil {
+		return nil
+	}
 	return o.client.Log(ctx, severity, message, attrs)
 }
 
@@ -230,6 +329,12 @@ func (o *Observability) RecordMetric(ctx context.Context, name string, value flo
 	if !o.isInitialized() {
 		return nil
 	}
+	if o.file != nil {
+		_ = o.file.ExportMetric("metric", name, value, unit, attrs)
+	}
+	if o.client == nil {
+		return nil
+	}
 	return o.client.RecordMetric(ctx, name, value, unit, attrs)
 }
 
@@ -238,6 +343,12 @@ func (o *Observability) IncrementCounter(ctx context.Context, name string, value
 	if !o.isInitialized() {
 		return nil
 	}
+	if o.file != nil {
+		_ = o.file.ExportMetric("counter", name, float64(value), "", attrs)
+	}
+	if o.client == nil {
+		return nil
+	}
 	return o.client.IncrementCounter(ctx, name, value, attrs)
 }
 
@@ -246,6 +357,12 @@ func (o *Observability) RecordGauge(ctx context.Context, name string, value floa
 	if !o.isInitialized() {
 		return nil
 	}
+	if o.file != nil {
+		_ = o.file.ExportMetric("gauge", name, value, "", attrs)
+	}
+	if o.client == nil {
+		return nil
+	}
 	return o.client.RecordGauge(ctx, name, value, attrs)
 }
 
@@ -254,6 +371,12 @@ func (o *Observability) RecordHistogram(ctx context.Context, name string, value
 	if !o.isInitialized() {
 		return nil
 	}
+	if o.file != nil {
+		_ = o.file.ExportMetric("histogram", name, value, unit, attrs)
+	}
+	if o.client == nil {
+		return nil
+	}
 	return o.client.RecordHistogram(ctx, name, value, unit, attrs)
 }
 
@@ -269,7 +392,13 @@ func (o *Observability) StartSpan(ctx context.Context, name string, kind string,
 	if !o.isInitialized() {
 		return ctx, "", nil
 	}
+	if o.client == nil {
+		return ctx, o.file.StartSpan("", name, kind, attrs), nil
+	}
 	spanID, err := o.client.StartSpan(ctx, name, kind, attrs)
+	if o.file != nil && err == nil {
+		o.file.StartSpan(spanID, name, kind, attrs)
+	}
 	return ctx, spanID, err
 }
 
@@ -278,6 +407,12 @@ func (o *Observability) EndSpan(ctx context.Context, spanID string, err error) e
 	if !o.isInitialized() || spanID == "" {
 		return nil
 	}
+	if o.file != nil {
+		_ = o.file.EndSpan(spanID, err)
+	}
+	if o.client == nil {
+		return nil
+	}
 	return o.client.EndSpan(ctx, spanID, err)
 }
 
@@ -286,6 +421,12 @@ func (o *Observability) AddSpanEvent(ctx context.Context, spanID string, name st
 	if !o.isInitialized() || spanID == "" {
 		return nil
 	}
+	if o.file != nil {
+		o.file.AddSpanEvent(spanID, name, attrs)
+	}
+	if o.client == nil {
+		return nil
+	}
 	return o.client.AddSpanEvent(ctx, spanID, name, attrs)
 }
 
@@ -293,44 +434,44 @@ func (o *Observability) AddSpanEvent(ctx context.Context, spanID string, name st
 
 // LogMCPRequest logs an MCP request with metrics.
 func (o *Observability) LogMCPRequest(ctx context.Context, requestID, method, sessionID string) {
-	o.Info(ctx, "MCP request received", map[string]interface{}{
+	o.Info(ctx, "MCP request received", AddClientFields(ctx, map[string]interface{}{
 		"request_id": requestID,
 		"method":     method,
 		"session_id": sessionID,
 		"type":       "mcp.request",
-	})
+	}))
 
-	_ = o.IncrementCounter(ctx, "mcp.requests.total", 1, map[string]interface{}{
+	_ = o.IncrementCounter(ctx, "mcp.requests.total", 1, AddClientFields(ctx, map[string]interface{}{
 		"method": method,
-	})
+	}))
 }
 
 // LogMCPResponse logs an MCP response with metrics.
 func (o *Observability) LogMCPResponse(ctx context.Context, requestID, method, sessionID string, duration time.Duration, err error) {
-	attrs := map[string]interface{}{
+	attrs := AddClientFields(ctx, map[string]interface{}{
 		"request_id":  requestID,
 		"method":      method,
 		"session_id":  sessionID,
 		"duration_ms": duration.Milliseconds(),
 		"type":        "mcp.response",
-	}
+	})
 
 	if err != nil {
 		attrs["error"] = err.Error()
 		attrs["success"] = false
 		o.Error(ctx, "MCP request failed", attrs)
 
-		_ = o.IncrementCounter(ctx, "mcp.requests.errors", 1, map[string]interface{}{
+		_ = o.IncrementCounter(ctx, "mcp.requests.errors", 1, AddClientFields(ctx, map[string]interface{}{
 			"method": method,
-		})
+		}))
 	} else {
 		attrs["success"] = true
 		o.Info(ctx, "MCP request completed", attrs)
 	}
 
-	_ = o.RecordHistogram(ctx, "mcp.request.duration", float64(duration.Milliseconds()), "ms", map[string]interface{}{
+	_ = o.RecordHistogram(ctx, "mcp.request.duration", float64(duration.Milliseconds()), "ms", AddClientFields(ctx, map[string]interface{}{
 		"method": method,
-	})
+	}))
 }
 
 // LogToolExecution logs a tool execution with metrics.
@@ -481,7 +622,7 @@ func TracedOperationWithResult[T any](o *Observability, ctx context.Context, spa
 func (o *Observability) isInitialized() bool {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
-	return o.initialized && o.client != nil
+	return o.initialized && (o.client != nil || o.file != nil)
 }
 
 // Client returns the underlying TFO SDK client.
@@ -489,6 +630,11 @@ func (o *Observability) Client() *telemetryflow.Client {
 	return o.client
 }
 
+// FileExporter returns the local file exporter, or nil when telemetry is not written to a file.
+func (o *Observability) FileExporter() *FileExporter {
+	return o.file
+}
+
 // IsAvailable returns true if the observability system is initialized.
 func (o *Observability) IsAvailable() bool {
 	return o.isInitialized()
@@ -138,6 +138,8 @@ const (
 	AttrResourceURI    = "resource.uri"
 	AttrPromptName     = "prompt.name"
 	AttrMCPMethod      = "mcp.method"
+	AttrClientName     = "mcp.client.name"
+	AttrClientVersion  = "mcp.client.version"
 	AttrClaudeModel    = "claude.model"
 	AttrTokensInput    = "claude.tokens.input"
 	AttrTokensOutput   = "claude.tokens.output"
@@ -16,6 +16,7 @@ import (
 // traces through the TelemetryFlow platform.
 type TFOTracer struct {
 	client         *telemetryflow.Client
+	file           *FileExporter // Also receives spans when export_mode writes to a file
 	serviceName    string
 	serviceVersion string
 	spans          map[string]*spanInfo
@@ -38,6 +39,18 @@ func NewTFOTracer(client *telemetryflow.Client, serviceName, serviceVersion stri
 	}
 }
 
+// newFileTracer creates a tracer that writes spans only to the export file
+func newFileTracer(file *FileExporter, serviceName, serviceVersion string) *TFOTracer {
+	tracer := NewTFOTracer(nil, serviceName, serviceVersion)
+	tracer.file = file
+	return tracer
+}
+
+// clientReady reports whether spans go to the TFO SDK client
+func (t *TFOTracer) clientReady() bool {
+	return t.client != nil && t.client.IsInitialized()
+}
+
 // StartSpan starts a new span with the given name and options.
 func (t *TFOTracer) StartSpan(ctx context.Context, spanName string, kind string, opts ...TraceOption) (context.Context, string, error) {
 	options := &traceOptions{}
@@ -75,16 +88,24 @@ func (t *TFOTracer) StartSpan(ctx context.Context, spanName string, kind string,
 		attrs[string(attr.Key)] = attr.Value.AsInterface()
 	}
 
-	// Start span using TFO SDK
-	if t.client == nil || !t.client.IsInitialized() {
+	// Start span using TFO SDK, or only in the export file in file-only mode
+	var spanID string
+	switch {
+	case t.clientReady():
+		id, err := t.client.StartSpan(ctx, spanName, kind, attrs)
+		if err != nil {
+			return ctx, "", err
+		}
+		spanID = id
+		if t.file != nil {
+			t.file.StartSpan(spanID, spanName, kind, attrs)
+		}
+	case t.file != nil:
+		spanID = t.file.StartSpan("", spanName, kind, attrs)
+	default:
 		return ctx, "", nil
 	}
 
-	spanID, err := t.client.StartSpan(ctx, spanName, kind, attrs)
-	if err != nil {
-		return ctx, "", err
-	}
-
 	// Track span info
 	t.mu.Lock()
 	t.spans[spanID] = &spanInfo{
@@ -98,7 +119,7 @@ func (t *TFOTracer) StartSpan(ctx context.Context, spanName string, kind string,
 
 // EndSpan ends an active span.
 func (t *TFOTracer) EndSpan(ctx context.Context, spanID string, err error) error {
-	if t.client == nil || !t.client.IsInitialized() || spanID == "" {
+	if !t.IsAvailable() || spanID == "" {
 		return nil
 	}
 
@@ -107,15 +128,27 @@ func (t *TFOTracer) EndSpan(ctx context.Context, spanID string, err error) error
 	delete(t.spans, spanID)
 	t.mu.Unlock()
 
+	if t.file != nil {
+		_ = t.file.EndSpan(spanID, err)
+	}
+	if !t.clientReady() {
+		return nil
+	}
 	return t.client.EndSpan(ctx, spanID, err)
 }
 
 // AddSpanEvent adds an event to an active span.
 func (t *TFOTracer) AddSpanEvent(ctx context.Context, spanID string, name string, attrs map[string]interface{}) error {
-	if t.client == nil || !t.client.IsInitialized() || spanID == "" {
+	if !t.IsAvailable() || spanID == "" {
 		return nil
 	}
 
+	if t.file != nil {
+		t.file.AddSpanEvent(spanID, name, attrs)
+	}
+	if !t.clientReady() {
+		return nil
+	}
 	return t.client.AddSpanEvent(ctx, spanID, name, attrs)
 }
 
@@ -301,7 +334,8 @@ func (t *TFOTracer) AddToolEvent(ctx context.Context, spanID string, eventType s
 	return t.AddSpanEvent(ctx, spanID, eventType, eventAttrs)
 }
 
-// IsAvailable returns true if the TFO SDK client is available and initialized.
+// IsAvailable returns true if the TFO SDK client is available and initialized, or
+// spans are written to an export file.
 func (t *TFOTracer) IsAvailable() bool {
-	return t.client != nil && t.client.IsInitialized()
+	return t.clientReady() || t.file != nil
 }
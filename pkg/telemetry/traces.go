@@ -141,6 +141,9 @@ func (t *Tracer) StartSpan(ctx context.Context, spanName string, opts ...TraceOp
 		attrs = append(attrs, attribute.String(AttrClaudeModel, options.model))
 	}
 
+	// Attribute the span to the client driving the request, once one is known
+	attrs = append(attrs, ClientAttributes(ctx)...)
+
 	// Add custom attributes
 	attrs = append(attrs, options.attributes...)
 
@@ -0,0 +1,42 @@
+// Package mocks provides mock implementations for testing.
+//
+// TelemetryFlow GO MCP Server - Model Context Protocol Server
+// Copyright (c) 2024-2026 TelemetryFlow. All rights reserved.
+package mocks
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a clock whose time only moves when the test moves it
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a fake clock reading now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake current time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to now
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -93,7 +93,7 @@ func MockSessionWithTools(toolCount int) *aggregates.Session {
 		toolName, _ := vo.NewToolName(sprintf("mock_tool_%d", i))
 		toolDesc, _ := vo.NewToolDescription(sprintf("Mock tool %d description", i))
 		tool := MockTool(toolName.String(), toolDesc.String())
-		session.RegisterTool(tool)
+		_ = session.RegisterTool(tool)
 	}
 	return session
 }
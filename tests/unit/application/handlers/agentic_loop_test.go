@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newAgenticFixture wires a conversation handler with a tool handler serving the given tools
+func newAgenticFixture(t *testing.T, claude services.IClaudeService, tools ...*entities.Tool) (*handlers.ConversationHandler, *aggregates.Conversation) {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	conversationRepo := persistence.NewInMemoryConversationRepository()
+	toolRepo := persistence.NewInMemoryToolRepository()
+
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	conversation, err := session.CreateConversation(vo.DefaultModel)
+	require.NoError(t, err)
+	require.NoError(t, conversationRepo.Save(ctx, conversation))
+
+	for _, tool := range tools {
+		require.NoError(t, toolRepo.Register(ctx, tool))
+	}
+
+	handler := handlers.NewConversationHandler(sessionRepo, conversationRepo, claude, nopPublisher{})
+	handler.SetToolHandler(handlers.NewToolHandler(sessionRepo, toolRepo, nopPublisher{}))
+	return handler, conversation
+}
+
+// newCountingTool creates a tool that counts its executions
+func newCountingTool(t *testing.T, name string, readOnly, cacheable bool, calls *int32) *entities.Tool {
+	t.Helper()
+	toolName, err := vo.NewToolName(name)
+	require.NoError(t, err)
+	desc, err := vo.NewToolDescription("counts executions")
+	require.NoError(t, err)
+
+	tool, err := entities.NewTool(toolName, desc, &entities.JSONSchema{Type: "object"})
+	require.NoError(t, err)
+	tool.SetAnnotations(&entities.ToolAnnotations{ReadOnlyHint: readOnly})
+	tool.SetCacheable(cacheable)
+	tool.SetHandler(func(input map[string]interface{}) (*entities.ToolResult, error) {
+		atomic.AddInt32(calls, 1)
+		return entities.NewTextToolResult("contents"), nil
+	})
+	return tool
+}
+
+func TestHandleRunTurn_CachesRepeatedToolCalls(t *testing.T) {
+	input := map[string]interface{}{"path": "/tmp/notes.txt"}
+
+	tests := []struct {
+		name      string
+		readOnly  bool
+		cacheable bool
+		wantCalls int32
+	}{
+		{"cacheable read-only tool executes once", true, true, 1},
+		{"tool without opt-in executes every time", true, false, 2},
+		{"opt-in ignored for non read-only tool", false, true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			tool := newCountingTool(t, "read_notes", tt.readOnly, tt.cacheable, &calls)
+
+			claude := mocks.NewMockClaudeService()
+			claude.On("CreateMessage", mock.Anything, mock.Anything).
+				Return(mocks.MockClaudeToolUseResponse("read_notes", "toolu_1", input), nil).Once()
+			claude.On("CreateMessage", mock.Anything, mock.Anything).
+				Return(mocks.MockClaudeToolUseResponse("read_notes", "toolu_2", input), nil).Once()
+			claude.On("CreateMessage", mock.Anything, mock.Anything).
+				Return(mocks.MockClaudeResponse("done"), nil).Once()
+
+			handler, conversation := newAgenticFixture(t, claude, tool)
+
+			result, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+				ConversationID: conversation.ID(),
+				Content:        "read my notes twice",
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+			assert.Equal(t, 3, result.Iterations)
+			assert.Equal(t, 2, result.ToolCalls)
+			assert.Equal(t, int(2-tt.wantCalls), result.CachedToolCalls)
+			assert.Equal(t, vo.StopReasonEndTurn, result.StopReason)
+			claude.AssertExpectations(t)
+
+			// Both tool_use blocks must be answered with the same result
+			var toolResults []entities.ContentBlock
+			for _, msg := range conversation.Messages() {
+				for _, block := range msg.Content() {
+					if block.Type == vo.ContentTypeToolResult {
+						toolResults = append(toolResults, block)
+					}
+				}
+			}
+			require.Len(t, toolResults, 2)
+			assert.Equal(t, "toolu_1", toolResults[0].ToolUseID)
+			assert.Equal(t, "toolu_2", toolResults[1].ToolUseID)
+			assert.Equal(t, "contents", toolResults[1].Content)
+		})
+	}
+}
+
+func TestHandleRunTurn_MultiBlockToolResult(t *testing.T) {
+	toolName, err := vo.NewToolName("screenshot")
+	require.NoError(t, err)
+	desc, err := vo.NewToolDescription("captures the screen")
+	require.NoError(t, err)
+	tool, err := entities.NewTool(toolName, desc, &entities.JSONSchema{Type: "object"})
+	require.NoError(t, err)
+	tool.SetHandler(func(input map[string]interface{}) (*entities.ToolResult, error) {
+		return entities.NewToolResult().
+			AddText("window: editor").
+			AddText("size: 800x600").
+			AddImage("iVBORw0KGgo=", "image/png"), nil
+	})
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("screenshot", "toolu_1", map[string]interface{}{}), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeResponse("done"), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude, tool)
+	_, err = handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "take a screenshot",
+	})
+	require.NoError(t, err)
+
+	var toolResult *entities.ContentBlock
+	for _, msg := range conversation.Messages() {
+		for _, block := range msg.Content() {
+			if block.Type == vo.ContentTypeToolResult {
+				block := block
+				toolResult = &block
+			}
+		}
+	}
+	require.NotNil(t, toolResult)
+	assert.Equal(t, "window: editor\nsize: 800x600", toolResult.Content)
+
+	// The blocks keep the order the tool produced them in
+	require.Len(t, toolResult.ResultContent, 3)
+	assert.Equal(t, entities.ContentBlock{Type: vo.ContentTypeText, Text: "window: editor"}, toolResult.ResultContent[0])
+	assert.Equal(t, entities.ContentBlock{Type: vo.ContentTypeText, Text: "size: 800x600"}, toolResult.ResultContent[1])
+	assert.Equal(t, vo.ContentTypeImage, toolResult.ResultContent[2].Type)
+	assert.Equal(t, &entities.ImageSource{Type: "base64", MediaType: "image/png", Data: "iVBORw0KGgo="}, toolResult.ResultContent[2].Source)
+
+	// The API form of the conversation sends the blocks as the tool_result content
+	var apiContent interface{}
+	for _, msg := range conversation.GetMessagesForAPI() {
+		blocks, _ := msg["content"].([]map[string]interface{})
+		for _, block := range blocks {
+			if block["type"] == string(vo.ContentTypeToolResult) {
+				apiContent = block["content"]
+			}
+		}
+	}
+	items, ok := apiContent.([]map[string]interface{})
+	require.True(t, ok, "tool_result content = %#v, want a block list", apiContent)
+	require.Len(t, items, 3)
+	assert.Equal(t, map[string]interface{}{"type": "text", "text": "size: 800x600"}, items[1])
+	assert.Equal(t, "image", items[2]["type"])
+}
+
+func TestHandleRunTurn_CacheClearedBetweenTurns(t *testing.T) {
+	var calls int32
+	tool := newCountingTool(t, "read_notes", true, true, &calls)
+	input := map[string]interface{}{"path": "/tmp/notes.txt"}
+
+	claude := mocks.NewMockClaudeService()
+	for i := 0; i < 2; i++ {
+		claude.On("CreateMessage", mock.Anything, mock.Anything).
+			Return(mocks.MockClaudeToolUseResponse("read_notes", "toolu_1", input), nil).Once()
+		claude.On("CreateMessage", mock.Anything, mock.Anything).
+			Return(mocks.MockClaudeResponse("done"), nil).Once()
+	}
+
+	handler, conversation := newAgenticFixture(t, claude, tool)
+
+	for i := 0; i < 2; i++ {
+		_, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+			ConversationID: conversation.ID(),
+			Content:        "read my notes",
+		})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHandleRunTurn_MutatingToolInvalidatesCache(t *testing.T) {
+	var reads, writes int32
+	readTool := newCountingTool(t, "read_file", true, true, &reads)
+	writeTool := newCountingTool(t, "write_file", false, false, &writes)
+	readInput := map[string]interface{}{"path": "/tmp/notes.txt"}
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("read_file", "toolu_1", readInput), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("write_file", "toolu_2", map[string]interface{}{"path": "/tmp/notes.txt"}), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("read_file", "toolu_3", readInput), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeResponse("done"), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude, readTool, writeTool)
+
+	result, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read, edit and re-read my notes",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&reads), "read after write must not be served from the cache")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&writes))
+	assert.Equal(t, 0, result.CachedToolCalls)
+}
+
+func TestHandleRunTurn_RequiresToolHandler(t *testing.T) {
+	handler, conversation := newConversationFixture(t, mocks.NewMockClaudeService())
+
+	_, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "hello",
+	})
+	assert.ErrorIs(t, err, handlers.ErrToolExecutorNotConfigured)
+}
+
+type fakeTurnMetrics struct {
+	limitReached int
+}
+
+func (m *fakeTurnMetrics) RecordTurnIterationLimitReached(ctx context.Context) { m.limitReached++ }
+
+func TestHandleRunTurn_StopsAtIterationLimit(t *testing.T) {
+	var calls int32
+	tool := newCountingTool(t, "read_notes", true, false, &calls)
+	input := map[string]interface{}{"path": "/tmp/notes.txt"}
+
+	// Claude keeps asking for the tool, so only the limit ends the turn
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("read_notes", "toolu_1", input), nil)
+
+	handler, conversation := newAgenticFixture(t, claude, tool)
+	metrics := &fakeTurnMetrics{}
+	handler.SetMaxIterations(3)
+	handler.SetMetrics(metrics)
+
+	result, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read my notes forever",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.IterationLimitReached)
+	assert.Equal(t, 3, result.Iterations)
+	assert.Equal(t, 2, result.ToolCalls)
+	assert.Equal(t, []string{"read_notes", "read_notes"}, result.ToolsInvoked)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, vo.StopReasonToolUse, result.StopReason)
+	assert.Equal(t, 1, metrics.limitReached)
+	claude.AssertNumberOfCalls(t, "CreateMessage", 3)
+
+	// The unexecuted tool_use is answered so the conversation can continue
+	messages := conversation.Messages()
+	last := messages[len(messages)-1]
+	assert.Equal(t, vo.RoleUser, last.Role())
+	require.Len(t, last.Content(), 1)
+	assert.Equal(t, vo.ContentTypeToolResult, last.Content()[0].Type)
+	assert.True(t, last.Content()[0].IsError)
+	assert.Contains(t, last.Content()[0].Content, "limit of 3 iterations")
+}
+
+func TestHandleRunTurn_UnderIterationLimit(t *testing.T) {
+	var calls int32
+	tool := newCountingTool(t, "read_notes", true, false, &calls)
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("read_notes", "toolu_1", map[string]interface{}{}), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeResponse("done"), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude, tool)
+	metrics := &fakeTurnMetrics{}
+	handler.SetMaxIterations(2)
+	handler.SetMetrics(metrics)
+
+	result, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read my notes",
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.IterationLimitReached)
+	assert.Equal(t, 2, result.Iterations)
+	assert.Equal(t, vo.StopReasonEndTurn, result.StopReason)
+	assert.Equal(t, 0, metrics.limitReached)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// writeAttachment writes a file into a new allowed directory and returns the directory and the file's URI
+func writeAttachment(t *testing.T, name, content string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return dir, "file://" + path
+}
+
+func TestHandleSendMessage_InlinesAttachedResource(t *testing.T) {
+	ctx := context.Background()
+	dir, uri := writeAttachment(t, "notes.txt", "remember the milk")
+
+	var request *services.ClaudeRequest
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { request = args.Get(1).(*services.ClaudeRequest) }).
+		Return(mocks.MockClaudeResponse("noted"), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude)
+	handler.SetResourceResolver(resources.NewResourceHandler([]string{dir}, 0))
+
+	_, err := handler.HandleSendMessage(ctx, &commands.SendMessageCommand{
+		ConversationID: conversation.ID(),
+		Content:        "summarize the attachment",
+		Attachments:    []string{uri},
+	})
+	require.NoError(t, err)
+
+	// Claude receives the file content in place of the reference
+	require.NotNil(t, request)
+	sent := request.Messages[0].Content
+	require.Len(t, sent, 2)
+	assert.Equal(t, vo.ContentTypeText, sent[1].Type)
+	assert.Contains(t, sent[1].Text, "remember the milk")
+	assert.Contains(t, sent[1].Text, uri)
+
+	// History keeps only the reference
+	messages, err := handler.HandleGetConversationMessages(ctx, &queries.GetConversationMessagesQuery{ConversationID: conversation.ID()})
+	require.NoError(t, err)
+	stored := messages[0].Content()
+	require.Len(t, stored, 2)
+	assert.Equal(t, vo.ContentTypeResource, stored[1].Type)
+	assert.Equal(t, uri, stored[1].URI)
+	assert.Empty(t, stored[1].Text)
+}
+
+func TestHandleSendMessage_AttachmentReadAtSendTime(t *testing.T) {
+	ctx := context.Background()
+	dir, uri := writeAttachment(t, "status.txt", "first version")
+
+	var requests []*services.ClaudeRequest
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { requests = append(requests, args.Get(1).(*services.ClaudeRequest)) }).
+		Return(mocks.MockClaudeResponse("ok"), nil).Twice()
+
+	handler, conversation := newAgenticFixture(t, claude)
+	handler.SetResourceResolver(resources.NewResourceHandler([]string{dir}, 0))
+
+	_, err := handler.HandleSendMessage(ctx, &commands.SendMessageCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read this",
+		Attachments:    []string{uri},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(uri[len("file://"):], []byte("second version"), 0644))
+	_, err = handler.HandleSendMessage(ctx, &commands.SendMessageCommand{ConversationID: conversation.ID(), Content: "and now?"})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	assert.Contains(t, requests[0].Messages[0].Content[1].Text, "first version")
+	assert.Contains(t, requests[1].Messages[0].Content[1].Text, "second version")
+}
+
+func TestHandleSendMessage_RejectsAttachmentOutsideAllowedPaths(t *testing.T) {
+	_, uri := writeAttachment(t, "secret.txt", "do not send")
+
+	claude := mocks.NewMockClaudeService()
+	handler, conversation := newAgenticFixture(t, claude)
+	handler.SetResourceResolver(resources.NewResourceHandler([]string{t.TempDir()}, 0))
+
+	_, err := handler.HandleSendMessage(context.Background(), &commands.SendMessageCommand{
+		ConversationID: conversation.ID(),
+		Content:        "leak this",
+		Attachments:    []string{uri},
+	})
+	require.ErrorIs(t, err, handlers.ErrAttachmentUnreadable)
+	claude.AssertNotCalled(t, "CreateMessage", mock.Anything, mock.Anything)
+}
+
+func TestHandleSendMessage_AttachmentsNeedResolver(t *testing.T) {
+	_, uri := writeAttachment(t, "notes.txt", "content")
+
+	handler, conversation := newAgenticFixture(t, mocks.NewMockClaudeService())
+	_, err := handler.HandleSendMessage(context.Background(), &commands.SendMessageCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read this",
+		Attachments:    []string{uri},
+	})
+	require.ErrorIs(t, err, handlers.ErrResourceResolverNotConfigured)
+}
@@ -0,0 +1,496 @@
+// Package handlers provides unit tests for application handlers
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/events"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(ctx context.Context, event interface{}) error { return nil }
+
+func newConversationFixture(t *testing.T, claude services.IClaudeService) (*handlers.ConversationHandler, *aggregates.Conversation) {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	conversationRepo := persistence.NewInMemoryConversationRepository()
+
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	conversation, err := session.CreateConversation(vo.DefaultModel)
+	require.NoError(t, err)
+	require.NoError(t, conversationRepo.Save(ctx, conversation))
+
+	handler := handlers.NewConversationHandler(sessionRepo, conversationRepo, claude, nopPublisher{})
+	return handler, conversation
+}
+
+func TestHandleSendMessage_StopReasons(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    *services.ClaudeResponse
+		stopReason  vo.StopReason
+		hasToolUse  bool
+		toolUseSize int
+	}{
+		{
+			name:       "end turn",
+			response:   mocks.MockClaudeResponse("done"),
+			stopReason: vo.StopReasonEndTurn,
+		},
+		{
+			name:        "tool use",
+			response:    mocks.MockClaudeToolUseResponse("echo", "toolu_1", map[string]interface{}{"message": "hi"}),
+			stopReason:  vo.StopReasonToolUse,
+			hasToolUse:  true,
+			toolUseSize: 1,
+		},
+		{
+			name: "max tokens",
+			response: func() *services.ClaudeResponse {
+				r := mocks.MockClaudeResponse("partial")
+				r.StopReason = "max_tokens"
+				return r
+			}(),
+			stopReason: vo.StopReasonMaxTokens,
+		},
+		{
+			name: "stop sequence",
+			response: func() *services.ClaudeResponse {
+				r := mocks.MockClaudeResponse("halted")
+				r.StopReason = "stop_sequence"
+				r.StopSequence = "###"
+				return r
+			}(),
+			stopReason: vo.StopReasonStopSequence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claude := mocks.NewMockClaudeService()
+			claude.On("CreateMessage", mock.Anything, mock.Anything).Return(tt.response, nil)
+
+			handler, conversation := newConversationFixture(t, claude)
+
+			result, err := handler.HandleSendMessage(context.Background(), &commands.SendMessageCommand{
+				ConversationID: conversation.ID(),
+				Content:        "hello",
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.stopReason, result.StopReason)
+			assert.Equal(t, tt.hasToolUse, result.HasToolUse)
+			assert.Len(t, result.ToolUses, tt.toolUseSize)
+
+			last := conversation.LastMessage()
+			require.NotNil(t, last)
+			reason, ok := last.GetMetadata("stop_reason")
+			assert.True(t, ok)
+			assert.Equal(t, tt.stopReason.String(), reason)
+
+			convReason, ok := conversation.GetMetadata("last_stop_reason")
+			assert.True(t, ok)
+			assert.Equal(t, tt.stopReason.String(), convReason)
+
+			if tt.response.StopSequence != "" {
+				seq, ok := last.GetMetadata("stop_sequence")
+				assert.True(t, ok)
+				assert.Equal(t, tt.response.StopSequence, seq)
+			}
+		})
+	}
+}
+
+func TestHandleSendMessage_StreamingStopReason(t *testing.T) {
+	stream := make(chan *services.ClaudeStreamEvent, 4)
+	stream <- &services.ClaudeStreamEvent{Type: "message_start", Message: &services.ClaudeResponse{ID: "msg_1", Role: vo.RoleAssistant}}
+	stream <- &services.ClaudeStreamEvent{Type: "content_block_start", ContentBlock: &entities.ContentBlock{Type: vo.ContentTypeText, Text: "streamed"}}
+	stream <- &services.ClaudeStreamEvent{
+		Type:  "message_delta",
+		Delta: &services.ClaudeDelta{StopReason: "max_tokens"},
+		Usage: &services.ClaudeUsage{OutputTokens: 42},
+	}
+	close(stream)
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).Return((<-chan *services.ClaudeStreamEvent)(stream), nil)
+
+	handler, conversation := newConversationFixture(t, claude)
+
+	result, err := handler.HandleSendMessage(context.Background(), &commands.SendMessageCommand{
+		ConversationID: conversation.ID(),
+		Content:        "hello",
+		Stream:         true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, vo.StopReasonMaxTokens, result.StopReason)
+	assert.True(t, result.StopReason.IsTruncated())
+	assert.False(t, result.HasToolUse)
+	require.NotNil(t, result.Response.Usage)
+	assert.Equal(t, 42, result.Response.Usage.OutputTokens)
+}
+
+func TestHandleSendMessage_StreamingForwardsText(t *testing.T) {
+	stream := make(chan *services.ClaudeStreamEvent, 5)
+	stream <- &services.ClaudeStreamEvent{Type: "message_start", Message: &services.ClaudeResponse{
+		ID: "msg_1", Role: vo.RoleAssistant, Usage: &services.ClaudeUsage{InputTokens: 7},
+	}}
+	stream <- &services.ClaudeStreamEvent{Type: "content_block_start", ContentBlock: &entities.ContentBlock{Type: vo.ContentTypeText}}
+	stream <- &services.ClaudeStreamEvent{Type: "content_block_delta", Delta: &services.ClaudeDelta{Type: "text_delta", Text: "stream"}}
+	stream <- &services.ClaudeStreamEvent{Type: "content_block_delta", Delta: &services.ClaudeDelta{Type: "text_delta", Text: "ed"}}
+	stream <- &services.ClaudeStreamEvent{
+		Type:  "message_delta",
+		Delta: &services.ClaudeDelta{StopReason: "end_turn"},
+		Usage: &services.ClaudeUsage{OutputTokens: 3},
+	}
+	close(stream)
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).Return((<-chan *services.ClaudeStreamEvent)(stream), nil)
+
+	handler, conversation := newConversationFixture(t, claude)
+
+	var forwarded []string
+	ctx := entities.ContextWithToolOutput(context.Background(), func(chunk string) {
+		forwarded = append(forwarded, chunk)
+	})
+	result, err := handler.HandleSendMessage(ctx, &commands.SendMessageCommand{
+		ConversationID: conversation.ID(),
+		Content:        "hello",
+		Stream:         true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"stream", "ed"}, forwarded)
+	require.Len(t, result.Response.Content, 1)
+	assert.Equal(t, "streamed", result.Response.Content[0].Text)
+	assert.Equal(t, &services.ClaudeUsage{InputTokens: 7, OutputTokens: 3}, result.Response.Usage)
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func intPtr(v int) *int { return &v }
+
+func TestHandleCreateConversation_SamplingValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     commands.CreateConversationCommand
+		wantErr error
+	}{
+		{"valid settings", commands.CreateConversationCommand{Temperature: floatPtr(0.7), TopP: floatPtr(0.9), TopK: intPtr(40)}, nil},
+		{"explicit zeros", commands.CreateConversationCommand{Temperature: floatPtr(0), TopP: floatPtr(0), TopK: intPtr(0)}, nil},
+		{"temperature too high", commands.CreateConversationCommand{Temperature: floatPtr(2.5)}, aggregates.ErrInvalidTemperature},
+		{"temperature negative", commands.CreateConversationCommand{Temperature: floatPtr(-0.5)}, aggregates.ErrInvalidTemperature},
+		{"top_p too high", commands.CreateConversationCommand{TopP: floatPtr(1.5)}, aggregates.ErrInvalidTopP},
+		{"top_p negative", commands.CreateConversationCommand{TopP: floatPtr(-0.1)}, aggregates.ErrInvalidTopP},
+		{"top_k negative", commands.CreateConversationCommand{TopK: intPtr(-1)}, aggregates.ErrInvalidTopK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			sessionRepo := persistence.NewInMemorySessionRepository()
+			session := aggregates.NewSession()
+			require.NoError(t, sessionRepo.Save(ctx, session))
+
+			handler := handlers.NewConversationHandler(sessionRepo, persistence.NewInMemoryConversationRepository(), mocks.NewMockClaudeService(), nopPublisher{})
+
+			cmd := tt.cmd
+			cmd.SessionID = session.ID()
+			conversation, err := handler.HandleCreateConversation(ctx, &cmd)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, conversation)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, *tt.cmd.Temperature, conversation.Temperature())
+			assert.Equal(t, *tt.cmd.TopP, conversation.TopP())
+			assert.Equal(t, *tt.cmd.TopK, conversation.TopK())
+		})
+	}
+}
+
+func TestHandleCreateConversation_SamplingDefaults(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	handler := handlers.NewConversationHandler(sessionRepo, persistence.NewInMemoryConversationRepository(), mocks.NewMockClaudeService(), nopPublisher{})
+	conversation, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID()})
+	require.NoError(t, err)
+
+	defaults := aggregates.NewConversation(session.ID(), vo.DefaultModel)
+	assert.Equal(t, defaults.Temperature(), conversation.Temperature())
+	assert.Equal(t, defaults.TopP(), conversation.TopP())
+	assert.Equal(t, defaults.TopK(), conversation.TopK())
+}
+
+func TestHandleCreateConversation_FromTemplate(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	session := aggregates.NewSession()
+	name, _ := vo.NewToolName("analyst")
+	template, err := entities.NewConversationTemplate(name, vo.ModelClaude35Haiku)
+	require.NoError(t, err)
+	systemPrompt, _ := vo.NewSystemPrompt("You analyse telemetry.")
+	template.SetSystemPrompt(systemPrompt)
+	require.NoError(t, template.SetTemperature(0.1))
+	session.RegisterConversationTemplate(template)
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	handler := handlers.NewConversationHandler(sessionRepo, persistence.NewInMemoryConversationRepository(), mocks.NewMockClaudeService(), nopPublisher{})
+
+	conversation, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID(), Template: "analyst"})
+	require.NoError(t, err)
+	assert.Equal(t, vo.ModelClaude35Haiku, conversation.Model())
+	assert.Equal(t, "You analyse telemetry.", conversation.SystemPrompt().String())
+	assert.Equal(t, 0.1, conversation.Temperature())
+
+	// Command fields override the template
+	conversation, err = handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{
+		SessionID:   session.ID(),
+		Template:    "analyst",
+		Model:       vo.ModelClaude4Sonnet,
+		Temperature: floatPtr(0.8),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, vo.ModelClaude4Sonnet, conversation.Model())
+	assert.Equal(t, "You analyse telemetry.", conversation.SystemPrompt().String())
+	assert.Equal(t, 0.8, conversation.Temperature())
+
+	_, err = handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID(), Template: "missing"})
+	assert.ErrorIs(t, err, aggregates.ErrTemplateNotFound)
+}
+
+func TestHandleCreateConversation_BuiltinTemplatesUseRegisteredTools(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	toolRepo := persistence.NewInMemoryToolRepository()
+	for _, tool := range tools.NewToolRegistry(mocks.NewMockClaudeService()).GetTools() {
+		require.NoError(t, toolRepo.Register(ctx, tool))
+	}
+
+	templates, err := persistence.DefaultConversationTemplates()
+	require.NoError(t, err)
+	require.NotEmpty(t, templates)
+	session := aggregates.NewSession()
+	for _, template := range templates {
+		session.RegisterConversationTemplate(template)
+	}
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	handler := handlers.NewConversationHandler(sessionRepo, persistence.NewInMemoryConversationRepository(), mocks.NewMockClaudeService(), nopPublisher{})
+	handler.SetToolHandler(handlers.NewToolHandler(sessionRepo, toolRepo, nopPublisher{}))
+
+	for _, template := range templates {
+		conversation, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID(), Template: template.Name().String()})
+		require.NoError(t, err, template.Name().String())
+		assert.Len(t, conversation.Tools(), len(template.Tools()), template.Name().String())
+	}
+
+	// Without a tool handler only the session's own tools are known
+	handler.SetToolHandler(nil)
+	_, err = handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID(), Template: "code_assistant"})
+	assert.ErrorIs(t, err, aggregates.ErrTemplateToolNotFound)
+}
+
+func TestHandleCreateConversation_StopSequences(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	conversationRepo := persistence.NewInMemoryConversationRepository()
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.MatchedBy(func(request *services.ClaudeRequest) bool {
+		return assert.ObjectsAreEqual([]string{"END", "###"}, request.StopSequences)
+	})).Return(mocks.MockClaudeResponse("done"), nil)
+	handler := handlers.NewConversationHandler(sessionRepo, conversationRepo, claude, nopPublisher{})
+
+	t.Run("valid set is passed to Claude", func(t *testing.T) {
+		conversation, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{
+			SessionID:     session.ID(),
+			StopSequences: []string{"END", "###"},
+		})
+		require.NoError(t, err)
+
+		_, err = handler.HandleSendMessage(ctx, &commands.SendMessageCommand{ConversationID: conversation.ID(), Content: "hello"})
+		require.NoError(t, err)
+		claude.AssertExpectations(t)
+	})
+
+	t.Run("invalid set is rejected", func(t *testing.T) {
+		conversation, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{
+			SessionID:     session.ID(),
+			StopSequences: make([]string, vo.MaxStopSequences+1),
+		})
+		assert.ErrorIs(t, err, vo.ErrTooManyStopSequences)
+		assert.Nil(t, conversation)
+	})
+}
+
+// recordingPublisher keeps the events it is asked to publish
+type recordingPublisher struct {
+	events []interface{}
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event interface{}) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestHandleDeleteConversation(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	conversationRepo := persistence.NewInMemoryConversationRepository()
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	publisher := &recordingPublisher{}
+	handler := handlers.NewConversationHandler(sessionRepo, conversationRepo, mocks.NewMockClaudeService(), publisher)
+
+	conversation, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID()})
+	require.NoError(t, err)
+	_, err = conversation.AddUserMessage("hello")
+	require.NoError(t, err)
+	_, err = conversation.AddAssistantMessage([]entities.ContentBlock{{Type: vo.ContentTypeText, Text: "hi"}})
+	require.NoError(t, err)
+	require.NoError(t, conversationRepo.Save(ctx, conversation))
+	kept, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID()})
+	require.NoError(t, err)
+	publisher.events = nil
+
+	require.NoError(t, handler.HandleDeleteConversation(ctx, &commands.DeleteConversationCommand{ConversationID: conversation.ID()}))
+
+	stored, err := conversationRepo.FindByID(ctx, conversation.ID())
+	require.NoError(t, err)
+	assert.Nil(t, stored, "conversation should be deleted from the repository")
+
+	storedSession, err := sessionRepo.FindByID(ctx, session.ID())
+	require.NoError(t, err)
+	_, ok := storedSession.GetConversation(conversation.ID())
+	assert.False(t, ok, "session should not reference the deleted conversation")
+	_, ok = storedSession.GetConversation(kept.ID())
+	assert.True(t, ok, "other conversations should be kept")
+
+	var deleted *events.ConversationDeletedEvent
+	for _, event := range publisher.events {
+		if e, ok := event.(*events.ConversationDeletedEvent); ok {
+			deleted = e
+		}
+	}
+	require.NotNil(t, deleted, "expected a ConversationDeletedEvent")
+	assert.Equal(t, 2, deleted.Payload()["messageCount"])
+	assert.Equal(t, session.ID().String(), deleted.Payload()["sessionId"])
+
+	err = handler.HandleDeleteConversation(ctx, &commands.DeleteConversationCommand{ConversationID: conversation.ID()})
+	assert.ErrorIs(t, err, handlers.ErrConversationNotFound)
+}
+
+// failingDeleteRepository is a conversation repository whose deletes fail
+type failingDeleteRepository struct {
+	*persistence.InMemoryConversationRepository
+}
+
+func (failingDeleteRepository) Delete(ctx context.Context, id vo.ConversationID) error {
+	return errors.New("database unavailable")
+}
+
+func TestHandleDeleteConversation_FailedDeleteKeepsSessionReference(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	conversationRepo := failingDeleteRepository{persistence.NewInMemoryConversationRepository()}
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	handler := handlers.NewConversationHandler(sessionRepo, conversationRepo, mocks.NewMockClaudeService(), &recordingPublisher{})
+	conversation, err := handler.HandleCreateConversation(ctx, &commands.CreateConversationCommand{SessionID: session.ID()})
+	require.NoError(t, err)
+
+	err = handler.HandleDeleteConversation(ctx, &commands.DeleteConversationCommand{ConversationID: conversation.ID()})
+	require.Error(t, err)
+
+	stored, err := conversationRepo.FindByID(ctx, conversation.ID())
+	require.NoError(t, err)
+	assert.NotNil(t, stored, "conversation should still be stored")
+	storedSession, err := sessionRepo.FindByID(ctx, session.ID())
+	require.NoError(t, err)
+	_, ok := storedSession.GetConversation(conversation.ID())
+	assert.True(t, ok, "session should still reference the conversation it failed to delete")
+}
+
+func TestHandleSendMessage_RecordsSessionTokenUsage(t *testing.T) {
+	ctx := context.Background()
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(mocks.MockClaudeResponse("one"), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(mocks.MockClaudeResponse("two"), nil).Once()
+
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	conversationRepo := persistence.NewInMemoryConversationRepository()
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+	conversation, err := session.CreateConversation(vo.DefaultModel)
+	require.NoError(t, err)
+	require.NoError(t, conversationRepo.Save(ctx, conversation))
+	handler := handlers.NewConversationHandler(sessionRepo, conversationRepo, claude, nopPublisher{})
+
+	for _, content := range []string{"first", "second"} {
+		_, err := handler.HandleSendMessage(ctx, &commands.SendMessageCommand{ConversationID: conversation.ID(), Content: content})
+		require.NoError(t, err)
+	}
+
+	usage := session.RequestUsage()
+	assert.Equal(t, int64(200), usage.InputTokens)
+	assert.Equal(t, int64(100), usage.OutputTokens)
+}
+
+func TestHandleEditLastUserMessage_RegeneratesWithEditedText(t *testing.T) {
+	ctx := context.Background()
+	claude := mocks.NewMockClaudeService()
+	// The first send fails, leaving the user message last in the conversation
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(nil, errors.New("overloaded")).Once()
+	claude.On("CreateMessage", mock.Anything, mock.MatchedBy(func(request *services.ClaudeRequest) bool {
+		return len(request.Messages) == 1 && request.Messages[0].Content[0].Text == "edited"
+	})).Return(mocks.MockClaudeResponse("answer"), nil).Once()
+	handler, conversation := newConversationFixture(t, claude)
+
+	_, err := handler.HandleSendMessage(ctx, &commands.SendMessageCommand{ConversationID: conversation.ID(), Content: "original"})
+	require.Error(t, err)
+
+	result, err := handler.HandleEditLastUserMessage(ctx, &commands.EditLastUserMessageCommand{ConversationID: conversation.ID(), Content: "edited"})
+	require.NoError(t, err)
+	assert.Equal(t, "answer", result.Response.Content[0].Text)
+	claude.AssertExpectations(t)
+
+	messages := conversation.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, "edited", messages[0].GetTextContent())
+	assert.Equal(t, vo.RoleAssistant, messages[1].Role())
+
+	// Once Claude has answered, the user message can no longer be edited
+	_, err = handler.HandleEditLastUserMessage(ctx, &commands.EditLastUserMessageCommand{ConversationID: conversation.ID(), Content: "again"})
+	assert.ErrorIs(t, err, aggregates.ErrLastMessageNotUser)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+const validImport = `{"role":"user","content":[{"type":"text","text":"What's in notes.txt?"}]}
+{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"read_file","input":{"path":"notes.txt"}}]}
+{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"buy milk"}]}
+{"role":"assistant","content":[{"type":"text","text":"It says to buy milk."}]}
+`
+
+func TestHandleImportConversation_ValidFile(t *testing.T) {
+	handler, existing := newConversationFixture(t, mocks.NewMockClaudeService())
+
+	result, err := handler.HandleImportConversation(context.Background(), &commands.ImportConversationCommand{
+		SessionID:    existing.SessionID(),
+		Model:        vo.DefaultModel,
+		SystemPrompt: "You are a helpful assistant.",
+		Reader:       strings.NewReader(validImport),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.Imported)
+	assert.Equal(t, 4, result.LastLine)
+	assert.Empty(t, result.Errors)
+
+	// The imported conversation is persisted
+	conversation, err := handler.HandleGetConversation(context.Background(), &queries.GetConversationQuery{
+		ConversationID: result.Conversation.ID(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, conversation.MessageCount())
+	assert.Equal(t, vo.RoleUser, conversation.Messages()[0].Role())
+	assert.Equal(t, "It says to buy milk.", conversation.LastMessage().GetTextContent())
+}
+
+func TestHandleImportConversation_MalformedLine(t *testing.T) {
+	handler, existing := newConversationFixture(t, mocks.NewMockClaudeService())
+
+	input := `{"role":"user","content":[{"type":"text","text":"hello"}]}
+{"role":"assistant","content":[{"type":"text","text":
+{"role":"assistant","content":[{"type":"text","text":"hi there"}]}
+{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_missing","content":"orphan"}]}
+`
+
+	result, err := handler.HandleImportConversation(context.Background(), &commands.ImportConversationCommand{
+		SessionID: existing.SessionID(),
+		Reader:    strings.NewReader(input),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 4, result.LastLine)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 2, result.Errors[0].Line)
+	assert.ErrorIs(t, result.Errors[0], handlers.ErrImportInvalidJSON)
+	assert.Equal(t, 4, result.Errors[1].Line)
+	assert.ErrorIs(t, result.Errors[1], handlers.ErrImportUnknownToolUse)
+	assert.Equal(t, 2, result.Conversation.MessageCount())
+}
+
+func TestHandleImportConversation_ResumeFromLine(t *testing.T) {
+	ctx := context.Background()
+	handler, existing := newConversationFixture(t, mocks.NewMockClaudeService())
+	lines := strings.SplitAfter(validImport, "\n")
+
+	// First attempt only gets the first two lines
+	first, err := handler.HandleImportConversation(ctx, &commands.ImportConversationCommand{
+		SessionID: existing.SessionID(),
+		Reader:    strings.NewReader(strings.Join(lines[:2], "")),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, first.LastLine)
+
+	// Resuming with the full stream skips lines already imported
+	resumed, err := handler.HandleImportConversation(ctx, &commands.ImportConversationCommand{
+		SessionID:      existing.SessionID(),
+		ConversationID: first.Conversation.ID(),
+		Reader:         strings.NewReader(validImport),
+		StartLine:      first.LastLine,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Conversation.ID(), resumed.Conversation.ID())
+	assert.Equal(t, 2, resumed.Imported)
+	assert.Equal(t, 4, resumed.LastLine)
+	assert.Equal(t, 4, resumed.Conversation.MessageCount())
+}
+
+func TestHandleImportConversation_FirstMessageMustBeUser(t *testing.T) {
+	handler, existing := newConversationFixture(t, mocks.NewMockClaudeService())
+
+	result, err := handler.HandleImportConversation(context.Background(), &commands.ImportConversationCommand{
+		SessionID: existing.SessionID(),
+		Reader:    strings.NewReader(`{"role":"assistant","content":[{"type":"text","text":"hi"}]}`),
+	})
+	require.NoError(t, err)
+
+	assert.Zero(t, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.ErrorIs(t, result.Errors[0], handlers.ErrImportFirstMessage)
+}
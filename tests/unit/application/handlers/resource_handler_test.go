@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+func TestResourceHandler_SubscriptionsPersistAndCleanUp(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	subscriptionRepo := persistence.NewInMemoryResourceSubscriptionRepository()
+
+	sessionHandler := handlers.NewSessionHandler(sessionRepo, nopPublisher{})
+	sessionHandler.SetSubscriptionRepository(subscriptionRepo)
+	resourceHandler := handlers.NewResourceHandler(sessionRepo, subscriptionRepo)
+
+	session, err := sessionHandler.HandleInitializeSession(ctx, initializeCommand())
+	require.NoError(t, err)
+
+	const uri = "file:///notes.txt"
+	require.NoError(t, resourceHandler.HandleSubscribeResource(ctx, &commands.SubscribeResourceCommand{SessionID: session.ID(), URI: uri}))
+
+	uris, err := subscriptionRepo.FindBySessionID(ctx, session.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []string{uri}, uris)
+	assert.True(t, session.IsSubscribed(uri))
+
+	subscribers, err := resourceHandler.HandleListResourceSubscribers(ctx, &queries.ListResourceSubscribersQuery{URI: uri})
+	require.NoError(t, err)
+	require.Len(t, subscribers, 1)
+	assert.True(t, subscribers[0].Equals(session.ID()))
+
+	// Closing the session removes its persisted subscriptions
+	require.NoError(t, sessionHandler.HandleCloseSession(ctx, &commands.CloseSessionCommand{SessionID: session.ID()}))
+	uris, err = subscriptionRepo.FindBySessionID(ctx, session.ID())
+	require.NoError(t, err)
+	assert.Empty(t, uris)
+	assert.False(t, session.IsSubscribed(uri))
+
+	err = resourceHandler.HandleSubscribeResource(ctx, &commands.SubscribeResourceCommand{SessionID: session.ID(), URI: uri})
+	assert.Error(t, err, "closed sessions cannot subscribe")
+}
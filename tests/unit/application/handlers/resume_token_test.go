@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+var resumeSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestHandleResumeConversation_ValidToken(t *testing.T) {
+	handler, conversation := newConversationFixture(t, mocks.NewMockClaudeService())
+	tokens, err := handlers.NewResumeTokens(resumeSecret, time.Hour)
+	require.NoError(t, err)
+	handler.SetResumeTokens(tokens)
+
+	token, err := handler.IssueResumeToken(conversation.ID())
+	require.NoError(t, err)
+
+	resumed, err := handler.HandleResumeConversation(context.Background(), &queries.ResumeConversationQuery{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, conversation.ID(), resumed.ID())
+
+	// Another issuer sharing the secret, such as a restarted server, accepts the token
+	other, err := handlers.NewResumeTokens(resumeSecret, time.Hour)
+	require.NoError(t, err)
+	id, err := other.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, conversation.ID(), id)
+}
+
+func TestHandleResumeConversation_TamperedToken(t *testing.T) {
+	handler, conversation := newConversationFixture(t, mocks.NewMockClaudeService())
+	tokens, err := handlers.NewResumeTokens(resumeSecret, time.Hour)
+	require.NoError(t, err)
+	handler.SetResumeTokens(tokens)
+
+	token, err := handler.IssueResumeToken(conversation.ID())
+	require.NoError(t, err)
+	payload, signature, ok := strings.Cut(token, ".")
+	require.True(t, ok)
+
+	// A token for another conversation signed with a different secret
+	forger, err := handlers.NewResumeTokens([]byte("fedcba9876543210fedcba9876543210"), time.Hour)
+	require.NoError(t, err)
+	forged := forger.Issue(conversation.ID())
+
+	flipped := []byte(payload)
+	flipped[len(flipped)/2] ^= 1
+	for name, tampered := range map[string]string{
+		"payload changed":   string(flipped) + "." + signature,
+		"signature dropped": payload,
+		"signature empty":   payload + ".",
+		"wrong secret":      forged,
+		"garbage":           "not-a-token",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := handler.HandleResumeConversation(context.Background(), &queries.ResumeConversationQuery{Token: tampered})
+			assert.ErrorIs(t, err, handlers.ErrInvalidResumeToken)
+		})
+	}
+}
+
+func TestHandleResumeConversation_ExpiredToken(t *testing.T) {
+	handler, conversation := newConversationFixture(t, mocks.NewMockClaudeService())
+	tokens, err := handlers.NewResumeTokens(resumeSecret, 10*time.Millisecond)
+	require.NoError(t, err)
+	handler.SetResumeTokens(tokens)
+
+	token, err := handler.IssueResumeToken(conversation.ID())
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	_, err = handler.HandleResumeConversation(context.Background(), &queries.ResumeConversationQuery{Token: token})
+	assert.ErrorIs(t, err, handlers.ErrResumeTokenExpired)
+}
+
+func TestHandleResumeConversation_NotConfigured(t *testing.T) {
+	handler, conversation := newConversationFixture(t, mocks.NewMockClaudeService())
+
+	_, err := handler.IssueResumeToken(conversation.ID())
+	assert.ErrorIs(t, err, handlers.ErrResumeTokensNotConfigured)
+	_, err = handler.HandleResumeConversation(context.Background(), &queries.ResumeConversationQuery{Token: "x.y"})
+	assert.ErrorIs(t, err, handlers.ErrResumeTokensNotConfigured)
+}
+
+func TestResumeTokens_SkewTolerance(t *testing.T) {
+	_, conversation := newConversationFixture(t, mocks.NewMockClaudeService())
+	issuedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := issuedAt.Add(time.Hour)
+
+	// Issued by a replica whose clock reads issuedAt
+	issuer, err := handlers.NewResumeTokens(resumeSecret, time.Hour)
+	require.NoError(t, err)
+	issuer.SetClock(mocks.NewFakeClock(issuedAt))
+	token := issuer.Issue(conversation.ID())
+
+	tests := []struct {
+		name    string
+		skew    time.Duration
+		now     time.Time
+		wantErr error
+	}{
+		{"just before expiry", 0, expiresAt.Add(-time.Millisecond), nil},
+		{"just after expiry", 0, expiresAt.Add(time.Millisecond), handlers.ErrResumeTokenExpired},
+		{"just after expiry within tolerance", 5 * time.Second, expiresAt.Add(time.Millisecond), nil},
+		{"just before the end of the tolerance", 5 * time.Second, expiresAt.Add(5*time.Second - time.Millisecond), nil},
+		{"just after the end of the tolerance", 5 * time.Second, expiresAt.Add(5*time.Second + time.Millisecond), handlers.ErrResumeTokenExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Verified by a replica whose clock may be ahead of the issuer's
+			verifier, err := handlers.NewResumeTokens(resumeSecret, time.Hour)
+			require.NoError(t, err)
+			verifier.SetClock(mocks.NewFakeClock(tt.now))
+			verifier.SetSkewTolerance(tt.skew)
+
+			id, err := verifier.Verify(token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, conversation.ID(), id)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+type fakeSessionMetrics struct {
+	count    int
+	rejected int
+}
+
+func (m *fakeSessionMetrics) RecordSessionCount(ctx context.Context, count int) { m.count = count }
+func (m *fakeSessionMetrics) RecordSessionRejected(ctx context.Context)         { m.rejected++ }
+
+func initializeCommand() *commands.InitializeSessionCommand {
+	return &commands.InitializeSessionCommand{
+		ClientName:      "test-client",
+		ClientVersion:   "1.0.0",
+		ProtocolVersion: "2024-11-05",
+	}
+}
+
+func TestHandleInitializeSession_MaxSessions(t *testing.T) {
+	ctx := context.Background()
+	metrics := &fakeSessionMetrics{}
+
+	handler := handlers.NewSessionHandler(persistence.NewInMemorySessionRepository(), nopPublisher{})
+	handler.SetMaxSessions(2)
+	handler.SetMetrics(metrics)
+
+	first, err := handler.HandleInitializeSession(ctx, initializeCommand())
+	require.NoError(t, err)
+	_, err = handler.HandleInitializeSession(ctx, initializeCommand())
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.count)
+
+	// Cap reached
+	_, err = handler.HandleInitializeSession(ctx, initializeCommand())
+	assert.ErrorIs(t, err, handlers.ErrServerAtCapacity)
+	assert.Equal(t, 1, metrics.rejected)
+
+	// Closing a session frees a slot
+	require.NoError(t, handler.HandleCloseSession(ctx, &commands.CloseSessionCommand{SessionID: first.ID()}))
+	assert.Equal(t, 1, metrics.count)
+
+	_, err = handler.HandleInitializeSession(ctx, initializeCommand())
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.count)
+	assert.Equal(t, 1, metrics.rejected)
+}
+
+func TestHandleInitializeSession_Unlimited(t *testing.T) {
+	ctx := context.Background()
+	handler := handlers.NewSessionHandler(persistence.NewInMemorySessionRepository(), nopPublisher{})
+
+	for i := 0; i < 5; i++ {
+		_, err := handler.HandleInitializeSession(ctx, initializeCommand())
+		require.NoError(t, err)
+	}
+}
+
+func TestHandleCloseSession_RunsCloseHooks(t *testing.T) {
+	ctx := context.Background()
+	handler := handlers.NewSessionHandler(persistence.NewInMemorySessionRepository(), nopPublisher{})
+
+	var closed []vo.SessionID
+	handler.OnSessionClosed(func(id vo.SessionID) { closed = append(closed, id) })
+
+	session, err := handler.HandleInitializeSession(ctx, initializeCommand())
+	require.NoError(t, err)
+	require.Empty(t, closed)
+
+	require.NoError(t, handler.HandleCloseSession(ctx, &commands.CloseSessionCommand{SessionID: session.ID()}))
+	require.Len(t, closed, 1)
+	assert.True(t, closed[0].Equals(session.ID()))
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// toolUseStreamEvents returns the events of a streamed response with text followed by a tool_use block
+func toolUseStreamEvents(toolName, toolUseID string, fragments ...string) []*services.ClaudeStreamEvent {
+	events := []*services.ClaudeStreamEvent{
+		{Type: "message_start", Message: &services.ClaudeResponse{ID: "msg_1", Role: vo.RoleAssistant}},
+		{Type: "content_block_start", Index: 0, ContentBlock: &entities.ContentBlock{Type: vo.ContentTypeText}},
+		{Type: "content_block_delta", Index: 0, Delta: &services.ClaudeDelta{Type: "text_delta", Text: "Let me "}},
+		{Type: "content_block_delta", Index: 0, Delta: &services.ClaudeDelta{Type: "text_delta", Text: "check."}},
+		{Type: "content_block_stop", Index: 0},
+		{Type: "content_block_start", Index: 1, ContentBlock: &entities.ContentBlock{Type: vo.ContentTypeToolUse, ID: toolUseID, Name: toolName}},
+	}
+	for _, fragment := range fragments {
+		events = append(events, &services.ClaudeStreamEvent{
+			Type:  "content_block_delta",
+			Index: 1,
+			Delta: &services.ClaudeDelta{Type: "input_json_delta", PartialJSON: fragment},
+		})
+	}
+	return append(events, &services.ClaudeStreamEvent{Type: "content_block_stop", Index: 1})
+}
+
+// textStream returns a closed stream holding a complete end_turn text response
+func textStream(text string) <-chan *services.ClaudeStreamEvent {
+	stream := make(chan *services.ClaudeStreamEvent, 4)
+	stream <- &services.ClaudeStreamEvent{Type: "message_start", Message: &services.ClaudeResponse{ID: "msg_2", Role: vo.RoleAssistant}}
+	stream <- &services.ClaudeStreamEvent{Type: "content_block_start", ContentBlock: &entities.ContentBlock{Type: vo.ContentTypeText, Text: text}}
+	stream <- &services.ClaudeStreamEvent{Type: "message_delta", Delta: &services.ClaudeDelta{StopReason: "end_turn"}}
+	close(stream)
+	return stream
+}
+
+func TestHandleRunTurn_StreamingExecutesToolBeforeStreamEnds(t *testing.T) {
+	var calls int32
+	executed := make(chan map[string]interface{}, 1)
+	tool := newCountingTool(t, "read_notes", true, false, &calls)
+	tool.SetHandler(func(input map[string]interface{}) (*entities.ToolResult, error) {
+		atomic.AddInt32(&calls, 1)
+		executed <- input
+		return entities.NewTextToolResult("contents"), nil
+	})
+
+	// The stream only finishes once the tool has run, so the turn would hang if
+	// tools were still executed after the response completed.
+	stream := make(chan *services.ClaudeStreamEvent)
+	go func() {
+		defer close(stream)
+		for _, event := range toolUseStreamEvents("read_notes", "toolu_1", `{"pa`, `th": "/tmp/`, `notes.txt"}`) {
+			stream <- event
+		}
+		select {
+		case <-executed:
+		case <-time.After(2 * time.Second):
+			return
+		}
+		stream <- &services.ClaudeStreamEvent{Type: "message_delta", Delta: &services.ClaudeDelta{StopReason: "tool_use"}}
+	}()
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).
+		Return((<-chan *services.ClaudeStreamEvent)(stream), nil).Once()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).
+		Return(textStream("done"), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude, tool)
+
+	result, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read my notes",
+		Stream:         true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, result.Iterations)
+	assert.Equal(t, 1, result.ToolCalls)
+	assert.Equal(t, vo.StopReasonEndTurn, result.StopReason)
+	claude.AssertExpectations(t)
+
+	messages := conversation.Messages()
+	require.GreaterOrEqual(t, len(messages), 3)
+
+	assistant := messages[1].Content()
+	require.Len(t, assistant, 2)
+	assert.Equal(t, "Let me check.", assistant[0].Text)
+	assert.Equal(t, "toolu_1", assistant[1].ID)
+	assert.Equal(t, map[string]interface{}{"path": "/tmp/notes.txt"}, assistant[1].Input)
+
+	toolResults := messages[2].Content()
+	require.Len(t, toolResults, 1)
+	assert.Equal(t, vo.ContentTypeToolResult, toolResults[0].Type)
+	assert.Equal(t, "toolu_1", toolResults[0].ToolUseID)
+	assert.Equal(t, "contents", toolResults[0].Content)
+}
+
+func TestHandleRunTurn_StreamingInvalidToolInput(t *testing.T) {
+	var calls int32
+	tool := newCountingTool(t, "read_notes", true, false, &calls)
+
+	events := toolUseStreamEvents("read_notes", "toolu_1", `{"path": `)
+	stream := make(chan *services.ClaudeStreamEvent, len(events)+1)
+	for _, event := range events {
+		stream <- event
+	}
+	stream <- &services.ClaudeStreamEvent{Type: "message_delta", Delta: &services.ClaudeDelta{StopReason: "tool_use"}}
+	close(stream)
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).
+		Return((<-chan *services.ClaudeStreamEvent)(stream), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude, tool)
+
+	_, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read my notes",
+		Stream:         true,
+	})
+	assert.ErrorIs(t, err, handlers.ErrInvalidToolInput)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestHandleSendMessage_StreamingAssemblesToolUse(t *testing.T) {
+	events := toolUseStreamEvents("read_notes", "toolu_1", `{"path": "/tmp/notes.txt", `, `"lines": [1, 2]}`)
+	stream := make(chan *services.ClaudeStreamEvent, len(events)+1)
+	for _, event := range events {
+		stream <- event
+	}
+	stream <- &services.ClaudeStreamEvent{Type: "message_delta", Delta: &services.ClaudeDelta{StopReason: "tool_use"}}
+	close(stream)
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).Return((<-chan *services.ClaudeStreamEvent)(stream), nil)
+
+	handler, conversation := newConversationFixture(t, claude)
+
+	result, err := handler.HandleSendMessage(context.Background(), &commands.SendMessageCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read my notes",
+		Stream:         true,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.HasToolUse)
+	require.Len(t, result.ToolUses, 1)
+	assert.Equal(t, "read_notes", result.ToolUses[0].Name)
+	assert.Equal(t, map[string]interface{}{
+		"path":  "/tmp/notes.txt",
+		"lines": []interface{}{float64(1), float64(2)},
+	}, result.ToolUses[0].Input)
+}
+
+func TestHandleRunTurn_StreamingTruncatedToolInput(t *testing.T) {
+	var calls int32
+	tool := newCountingTool(t, "read_notes", true, false, &calls)
+
+	events := toolUseStreamEvents("read_notes", "toolu_1", `{"path": "/tmp/no`)
+	stream := make(chan *services.ClaudeStreamEvent, len(events)+1)
+	for _, event := range events {
+		stream <- event
+	}
+	stream <- &services.ClaudeStreamEvent{Type: "message_delta", Delta: &services.ClaudeDelta{StopReason: "max_tokens"}}
+	close(stream)
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).
+		Return((<-chan *services.ClaudeStreamEvent)(stream), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude, tool)
+
+	result, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "read my notes",
+		Stream:         true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, vo.StopReasonMaxTokens, result.StopReason)
+	assert.False(t, result.HasToolUse)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+	// The cut-off tool_use block cannot be answered, so only the text is kept
+	messages := conversation.Messages()
+	require.Len(t, messages, 2)
+	assistant := messages[1].Content()
+	require.Len(t, assistant, 1)
+	assert.Equal(t, "Let me check.", assistant[0].Text)
+}
+
+func TestHandleRunTurn_StreamingDefersMutatingTools(t *testing.T) {
+	tests := []struct {
+		name       string
+		stopReason string
+		wantCalls  int32
+	}{
+		{"runs after tool_use is confirmed", "tool_use", 1},
+		{"never runs when cut short", "max_tokens", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls, callsBeforeStop int32
+			tool := newCountingTool(t, "write_notes", false, false, &calls)
+
+			stream := make(chan *services.ClaudeStreamEvent)
+			go func() {
+				defer close(stream)
+				for _, event := range toolUseStreamEvents("write_notes", "toolu_1", `{"path": "/tmp/notes.txt"}`) {
+					stream <- event
+				}
+				// Give an early start the chance to happen before stop_reason arrives
+				time.Sleep(50 * time.Millisecond)
+				atomic.StoreInt32(&callsBeforeStop, atomic.LoadInt32(&calls))
+				stream <- &services.ClaudeStreamEvent{Type: "message_delta", Delta: &services.ClaudeDelta{StopReason: tt.stopReason}}
+			}()
+
+			claude := mocks.NewMockClaudeService()
+			claude.On("CreateMessageStream", mock.Anything, mock.Anything).
+				Return((<-chan *services.ClaudeStreamEvent)(stream), nil).Once()
+			claude.On("CreateMessageStream", mock.Anything, mock.Anything).
+				Return(textStream("done"), nil).Maybe()
+
+			handler, conversation := newAgenticFixture(t, claude, tool)
+
+			_, err := handler.HandleRunTurn(context.Background(), &commands.RunTurnCommand{
+				ConversationID: conversation.ID(),
+				Content:        "write my notes",
+				Stream:         true,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, int32(0), atomic.LoadInt32(&callsBeforeStop))
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
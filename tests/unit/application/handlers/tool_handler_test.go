@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newWorkingDirFixture registers the working directory tools and two sessions
+func newWorkingDirFixture(t *testing.T) (*handlers.ToolHandler, *aggregates.Session, *aggregates.Session) {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	toolRepo := persistence.NewInMemoryToolRepository()
+
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	for _, name := range []string{"set_working_dir", "get_working_dir", "execute_command"} {
+		tool, ok := registry.GetTool(name)
+		require.True(t, ok, name)
+		require.NoError(t, toolRepo.Register(ctx, tool))
+	}
+
+	first := aggregates.NewSession()
+	second := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, first))
+	require.NoError(t, sessionRepo.Save(ctx, second))
+
+	return handlers.NewToolHandler(sessionRepo, toolRepo, nopPublisher{}), first, second
+}
+
+func executeTool(t *testing.T, handler *handlers.ToolHandler, session *aggregates.Session, name string, args map[string]interface{}) string {
+	t.Helper()
+	result, err := handler.HandleExecuteTool(context.Background(), &commands.ExecuteToolCommand{
+		SessionID: session.ID(),
+		Name:      name,
+		Arguments: args,
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].Text)
+	return result.Content[0].Text
+}
+
+func TestHandleExecuteTool_SessionWorkingDir(t *testing.T) {
+	handler, session, _ := newWorkingDirFixture(t)
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	executeTool(t, handler, session, "set_working_dir", map[string]interface{}{"path": dir})
+
+	assert.Equal(t, dir, executeTool(t, handler, session, "get_working_dir", nil))
+	assert.Contains(t, executeTool(t, handler, session, "execute_command", map[string]interface{}{"command": "pwd"}), dir)
+
+	// An explicit working_dir still wins over the session default
+	other, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	assert.Contains(t, executeTool(t, handler, session, "execute_command", map[string]interface{}{
+		"command":     "pwd",
+		"working_dir": other,
+	}), other)
+}
+
+func TestHandleExecuteTool_SessionStoreIsolation(t *testing.T) {
+	handler, first, second := newWorkingDirFixture(t)
+	dir := t.TempDir()
+
+	executeTool(t, handler, first, "set_working_dir", map[string]interface{}{"path": dir})
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, dir, executeTool(t, handler, first, "get_working_dir", nil))
+	assert.Equal(t, cwd, executeTool(t, handler, second, "get_working_dir", nil))
+
+	// Closing the session clears its scratch state
+	first.Close()
+	_, ok := first.Store().Get(tools.WorkingDirKey)
+	assert.False(t, ok)
+}
+
+func TestHandleExecuteTool_RequiredScope(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	toolRepo := persistence.NewInMemoryToolRepository()
+
+	var calls int32
+	tool := newCountingTool(t, "admin_only", true, false, &calls)
+	tool.SetRequiredScope("admin")
+	require.NoError(t, toolRepo.Register(ctx, tool))
+
+	session := aggregates.NewSession()
+	admin := aggregates.NewSession()
+	admin.GrantScopes([]string{"read", "admin"})
+	require.NoError(t, sessionRepo.Save(ctx, session))
+	require.NoError(t, sessionRepo.Save(ctx, admin))
+	handler := handlers.NewToolHandler(sessionRepo, toolRepo, nopPublisher{})
+
+	// Another session holding the scope does not let this one call the tool
+	_, err := handler.HandleExecuteTool(ctx, &commands.ExecuteToolCommand{SessionID: session.ID(), Name: "admin_only"})
+	assert.ErrorIs(t, err, handlers.ErrToolScopeRequired)
+	assert.Equal(t, int32(0), calls)
+
+	result, err := handler.HandleExecuteTool(ctx, &commands.ExecuteToolCommand{SessionID: admin.ID(), Name: "admin_only"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, int32(1), calls)
+}
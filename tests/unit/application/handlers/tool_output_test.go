@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newStreamingTool creates a tool that streams lines of output, then reports its exit status
+func newStreamingTool(t *testing.T, lines []string) *entities.Tool {
+	t.Helper()
+	name, err := vo.NewToolName("follow_log")
+	require.NoError(t, err)
+	desc, err := vo.NewToolDescription("streams log lines")
+	require.NoError(t, err)
+
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{Type: "object"})
+	require.NoError(t, err)
+	tool.SetContextHandler(func(ctx context.Context, _ map[string]interface{}) (*entities.ToolResult, error) {
+		for _, line := range lines {
+			entities.EmitToolOutput(ctx, line+"\n")
+		}
+		return entities.NewTextToolResult("exit status 0"), nil
+	})
+	return tool
+}
+
+// toolResultBlocks returns every tool_result block in the conversation history
+func toolResultBlocks(messages []*entities.Message) []entities.ContentBlock {
+	var blocks []entities.ContentBlock
+	for _, msg := range messages {
+		for _, block := range msg.Content() {
+			if block.Type == vo.ContentTypeToolResult {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+	return blocks
+}
+
+// runStreamingTurn runs a turn in which Claude calls the streaming tool once and
+// returns the tool_result recorded for it
+func runStreamingTurn(ctx context.Context, t *testing.T, lines []string, limit int) entities.ContentBlock {
+	t.Helper()
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("follow_log", "toolu_1", map[string]interface{}{}), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeResponse("done"), nil).Once()
+
+	handler, conversation := newAgenticFixture(t, claude, newStreamingTool(t, lines))
+	if limit != 0 {
+		handler.SetMaxStreamedToolOutput(limit)
+	}
+
+	_, err := handler.HandleRunTurn(ctx, &commands.RunTurnCommand{
+		ConversationID: conversation.ID(),
+		Content:        "follow the log",
+	})
+	require.NoError(t, err)
+
+	results := toolResultBlocks(conversation.Messages())
+	require.Len(t, results, 1, "streamed output must be recorded as one tool_result")
+	return results[0]
+}
+
+func TestHandleRunTurn_ConsolidatesStreamedToolOutput(t *testing.T) {
+	lines := []string{"starting", "listening on :8080", "ready"}
+
+	var (
+		mu     sync.Mutex
+		chunks []string
+	)
+	ctx := entities.ContextWithToolOutput(context.Background(), func(chunk string) {
+		mu.Lock()
+		defer mu.Unlock()
+		chunks = append(chunks, chunk)
+	})
+
+	result := runStreamingTurn(ctx, t, lines, 0)
+	assert.Equal(t, "toolu_1", result.ToolUseID)
+	assert.False(t, result.IsError)
+	assert.Equal(t, "starting\nlistening on :8080\nready\nexit status 0", result.Content)
+
+	// Each chunk still reaches the client as it is produced
+	assert.Equal(t, []string{"starting\n", "listening on :8080\n", "ready\n"}, chunks)
+}
+
+func TestHandleRunTurn_CapsStreamedToolOutput(t *testing.T) {
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %03d", i)
+	}
+
+	result := runStreamingTurn(context.Background(), t, lines, 45)
+	content := result.Content
+
+	// The most recent output is kept, with a note of what was dropped
+	assert.True(t, strings.HasPrefix(content, "[855 bytes of earlier output omitted]\n"), content)
+	assert.Contains(t, content, "line 099\nexit status 0")
+	assert.NotContains(t, content, "line 000")
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+// throttleRecorder records throttled tool calls
+type throttleRecorder struct {
+	mu    sync.Mutex
+	tools []string
+}
+
+func (r *throttleRecorder) RecordToolThrottled(_ context.Context, toolName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools = append(r.tools, toolName)
+}
+
+func callTool(handler *handlers.ToolHandler, session *aggregates.Session, name string) error {
+	_, err := handler.HandleExecuteTool(context.Background(), &commands.ExecuteToolCommand{
+		SessionID: session.ID(),
+		Name:      name,
+	})
+	return err
+}
+
+func TestHandleExecuteTool_PerSessionRateLimit(t *testing.T) {
+	handler, session, other := newWorkingDirFixture(t)
+	metrics := &throttleRecorder{}
+	handler.SetMetrics(metrics)
+	window := 200 * time.Millisecond
+	handler.SetToolRateLimitWindow(window)
+	handler.SetToolRateLimits(map[string]int{"get_working_dir": 2})
+
+	require.NoError(t, callTool(handler, session, "get_working_dir"))
+	require.NoError(t, callTool(handler, session, "get_working_dir"))
+
+	err := callTool(handler, session, "get_working_dir")
+	require.ErrorIs(t, err, handlers.ErrToolRateLimited)
+	var throttled *handlers.ToolRateLimitError
+	require.True(t, errors.As(err, &throttled))
+	assert.Equal(t, "get_working_dir", throttled.Tool)
+	assert.Equal(t, 2, throttled.Limit)
+	assert.True(t, throttled.RetryAfter > 0 && throttled.RetryAfter <= window, "retry after %s", throttled.RetryAfter)
+	assert.Equal(t, []string{"get_working_dir"}, metrics.tools)
+
+	// Other tools and other sessions have their own budgets
+	require.NoError(t, callTool(handler, session, "set_working_dir"))
+	require.NoError(t, callTool(handler, other, "get_working_dir"))
+
+	// Once the earliest call leaves the window the session may call again
+	time.Sleep(throttled.RetryAfter + 10*time.Millisecond)
+	require.NoError(t, callTool(handler, session, "get_working_dir"))
+}
+
+func TestHandleExecuteTool_RateLimitFallsBackToTool(t *testing.T) {
+	handler, session, _ := newWorkingDirFixture(t)
+	tool, err := handler.HandleGetTool(context.Background(), &queries.GetToolQuery{SessionID: session.ID(), Name: "get_working_dir"})
+	require.NoError(t, err)
+	tool.SetRateLimit(&entities.RateLimit{RequestsPerMinute: 1})
+	// The tool's own limit is per minute, whatever window configured limits use
+	handler.SetToolRateLimitWindow(10 * time.Millisecond)
+
+	require.NoError(t, callTool(handler, session, "get_working_dir"))
+	time.Sleep(20 * time.Millisecond)
+	err = callTool(handler, session, "get_working_dir")
+	require.ErrorIs(t, err, handlers.ErrToolRateLimited)
+	var throttled *handlers.ToolRateLimitError
+	require.True(t, errors.As(err, &throttled))
+	assert.Equal(t, time.Minute, throttled.Window)
+
+	// A configured limit of 0 overrides the tool's own limit
+	handler.SetToolRateLimits(map[string]int{"get_working_dir": 0})
+	require.NoError(t, callTool(handler, session, "get_working_dir"))
+}
+
+func TestHandleExecuteTool_ReleaseSessionRateLimits(t *testing.T) {
+	handler, session, _ := newWorkingDirFixture(t)
+	handler.SetToolRateLimits(map[string]int{"get_working_dir": 1})
+
+	require.NoError(t, callTool(handler, session, "get_working_dir"))
+	require.ErrorIs(t, callTool(handler, session, "get_working_dir"), handlers.ErrToolRateLimited)
+
+	handler.ReleaseSessionRateLimits(session.ID())
+	require.NoError(t, callTool(handler, session, "get_working_dir"))
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func newConversationTool(t *testing.T, name string) *entities.Tool {
+	t.Helper()
+	toolName, err := vo.NewToolName(name)
+	require.NoError(t, err)
+	desc, err := vo.NewToolDescription(name + " tool")
+	require.NoError(t, err)
+	tool, err := entities.NewTool(toolName, desc, &entities.JSONSchema{Type: "object"})
+	require.NoError(t, err)
+	return tool
+}
+
+func TestHandleSetToolUse_ClaudeRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  vo.ToolUseSettings
+		wantTools []string
+		wantTC    *services.ClaudeToolChoice
+	}{
+		{
+			name:      "auto",
+			settings:  vo.DefaultToolUseSettings(),
+			wantTools: []string{"echo", "search"},
+			wantTC:    &services.ClaudeToolChoice{Type: vo.ToolChoiceAuto},
+		},
+		{
+			name:      "forced tool",
+			settings:  vo.ToolUseSettings{Enabled: true, AllowedTools: []string{"search"}, Choice: vo.ToolChoiceTool, ToolName: "search"},
+			wantTools: []string{"search"},
+			wantTC:    &services.ClaudeToolChoice{Type: vo.ToolChoiceTool, Name: "search"},
+		},
+		{
+			name:     "disabled",
+			settings: vo.ToolUseSettings{Enabled: false, Choice: vo.ToolChoiceNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request *services.ClaudeRequest
+			claude := mocks.NewMockClaudeService()
+			claude.On("CreateMessage", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { request = args.Get(1).(*services.ClaudeRequest) }).
+				Return(mocks.MockClaudeResponse("done"), nil)
+
+			handler, conversation := newConversationFixture(t, claude)
+			conversation.AddTool(newConversationTool(t, "echo"))
+			conversation.AddTool(newConversationTool(t, "search"))
+
+			_, err := handler.HandleSetToolUse(context.Background(), &commands.SetToolUseCommand{
+				ConversationID: conversation.ID(),
+				Settings:       tt.settings,
+			})
+			require.NoError(t, err)
+
+			_, err = handler.HandleSendMessage(context.Background(), &commands.SendMessageCommand{
+				ConversationID: conversation.ID(),
+				Content:        "hello",
+			})
+			require.NoError(t, err)
+			require.NotNil(t, request)
+
+			var names []string
+			for _, tool := range request.Tools {
+				names = append(names, tool.Name)
+			}
+			assert.Equal(t, tt.wantTools, names)
+			assert.Equal(t, tt.wantTC, request.ToolChoice)
+		})
+	}
+}
+
+func TestHandleSetToolUse_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings vo.ToolUseSettings
+		wantErr  error
+	}{
+		{"unknown tool choice", vo.ToolUseSettings{Enabled: true, Choice: "sometimes"}, vo.ErrInvalidToolChoice},
+		{"forced without name", vo.ToolUseSettings{Enabled: true, Choice: vo.ToolChoiceTool}, vo.ErrToolChoiceNameRequired},
+		{"forced while disabled", vo.ToolUseSettings{Enabled: false, Choice: vo.ToolChoiceAny}, vo.ErrToolUseDisabled},
+		{"forced tool not allowed", vo.ToolUseSettings{Enabled: true, AllowedTools: []string{"echo"}, Choice: vo.ToolChoiceTool, ToolName: "search"}, vo.ErrToolNotAllowed},
+		{"unregistered tool", vo.ToolUseSettings{Enabled: true, AllowedTools: []string{"missing"}, Choice: vo.ToolChoiceAuto}, handlers.ErrToolNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, conversation := newConversationFixture(t, mocks.NewMockClaudeService())
+			conversation.AddTool(newConversationTool(t, "echo"))
+
+			_, err := handler.HandleSetToolUse(context.Background(), &commands.SetToolUseCommand{
+				ConversationID: conversation.ID(),
+				Settings:       tt.settings,
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, vo.DefaultToolUseSettings(), conversation.ToolUse())
+		})
+	}
+}
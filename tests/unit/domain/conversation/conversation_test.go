@@ -5,6 +5,8 @@
 package conversation_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -366,6 +368,151 @@ func TestConversationMessageContent(t *testing.T) {
 	})
 }
 
+func TestConversationEditLastUserMessage(t *testing.T) {
+	t.Run("should replace the text of the last user message", func(t *testing.T) {
+		conv := createTestConversation(t)
+		require.NoError(t, conv.AddMessage(createTextMessage(t, vo.RoleUser, "Hello")))
+		require.NoError(t, conv.AddMessage(createTextMessage(t, vo.RoleAssistant, "Hi there!")))
+		original, err := conv.AddUserMessage("What is the capital of Frnace?")
+		require.NoError(t, err)
+		original.AddContent(entities.NewResourceReferenceBlock("file:///notes.txt"))
+		conv.ClearEvents()
+
+		edited, err := conv.EditLastUserMessage("What is the capital of France?")
+		require.NoError(t, err)
+		assert.Equal(t, original.ID(), edited.ID())
+
+		messages := conv.Messages()
+		require.Len(t, messages, 3)
+		assert.Equal(t, "What is the capital of France?", messages[2].GetTextContent())
+		content := messages[2].Content()
+		require.Len(t, content, 2)
+		assert.Equal(t, vo.ContentTypeResource, content[1].Type)
+
+		domainEvents := conv.Events()
+		require.Len(t, domainEvents, 1)
+		assert.Equal(t, "message.edited", domainEvents[0].EventType())
+		assert.Equal(t, edited.ID().String(), domainEvents[0].Payload()["messageId"])
+	})
+
+	t.Run("should reject editing when the last message is from the assistant", func(t *testing.T) {
+		conv := createTestConversation(t)
+		require.NoError(t, conv.AddMessage(createTextMessage(t, vo.RoleUser, "Hello")))
+		require.NoError(t, conv.AddMessage(createTextMessage(t, vo.RoleAssistant, "Hi there!")))
+		conv.ClearEvents()
+
+		_, err := conv.EditLastUserMessage("Goodbye")
+		assert.ErrorIs(t, err, aggregates.ErrLastMessageNotUser)
+		assert.Equal(t, "Hello", conv.Messages()[0].GetTextContent())
+		assert.Empty(t, conv.Events())
+	})
+
+	t.Run("should reject editing a tool result", func(t *testing.T) {
+		conv := createTestConversation(t)
+		require.NoError(t, conv.AddMessage(createTextMessage(t, vo.RoleUser, "Hello")))
+		_, err := conv.AddAssistantMessage([]entities.ContentBlock{{Type: vo.ContentTypeToolUse, ID: "toolu_1", Name: "echo"}})
+		require.NoError(t, err)
+		toolResult, err := entities.NewMessage(vo.RoleUser, []entities.ContentBlock{{Type: vo.ContentTypeToolResult, ToolUseID: "toolu_1", Content: "ok"}})
+		require.NoError(t, err)
+		require.NoError(t, conv.AddMessage(toolResult))
+
+		_, err = conv.EditLastUserMessage("Goodbye")
+		assert.ErrorIs(t, err, aggregates.ErrLastMessageNotUser)
+	})
+
+	t.Run("should reject editing an empty conversation or with empty text", func(t *testing.T) {
+		conv := createTestConversation(t)
+		_, err := conv.EditLastUserMessage("Hello")
+		assert.ErrorIs(t, err, aggregates.ErrLastMessageNotUser)
+
+		require.NoError(t, conv.AddMessage(createTextMessage(t, vo.RoleUser, "Hello")))
+		_, err = conv.EditLastUserMessage("")
+		assert.ErrorIs(t, err, aggregates.ErrEmptyMessage)
+	})
+}
+
+// TestConversationConcurrentReadAndAppend is meant to run under -race: readers
+// iterate and modify the messages they were handed while a writer appends.
+func TestConversationConcurrentReadAndAppend(t *testing.T) {
+	conv := createTestConversation(t)
+	const appends = 200
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := 0; i < appends; i++ {
+			role := vo.RoleUser
+			var blocks []entities.ContentBlock
+			if i%2 == 0 {
+				blocks = []entities.ContentBlock{{Type: vo.ContentTypeText, Text: fmt.Sprintf("message %d", i)}}
+			} else {
+				role = vo.RoleAssistant
+				blocks = []entities.ContentBlock{{
+					Type:  vo.ContentTypeToolUse,
+					ID:    fmt.Sprintf("tool-%d", i),
+					Name:  "echo",
+					Input: map[string]interface{}{"args": []interface{}{"a", "b"}},
+				}}
+			}
+			msg, err := entities.NewMessage(role, blocks)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if err := conv.AddMessage(msg); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for _, msg := range conv.Messages() {
+					for _, block := range msg.Content() {
+						if block.Input != nil {
+							block.Input["args"].([]interface{})[0] = "changed"
+							block.Input["extra"] = true
+						}
+					}
+					msg.SetMetadata("seen", true)
+					msg.AddContent(entities.ContentBlock{Type: vo.ContentTypeText, Text: "appended by reader"})
+				}
+				for _, msg := range conv.GetMessagesForAPI() {
+					for _, block := range msg["content"].([]map[string]interface{}) {
+						if input, ok := block["input"].(map[string]interface{}); ok {
+							input["extra"] = true
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	messages := conv.Messages()
+	require.Len(t, messages, appends)
+	for _, msg := range messages {
+		require.Len(t, msg.Content(), 1, "reader changes must not reach the conversation")
+		_, seen := msg.GetMetadata("seen")
+		assert.False(t, seen)
+		if input := msg.Content()[0].Input; input != nil {
+			assert.Equal(t, map[string]interface{}{"args": []interface{}{"a", "b"}}, input)
+		}
+	}
+}
+
 // Helper functions
 
 func createTestConversation(t *testing.T) *aggregates.Conversation {
@@ -0,0 +1,67 @@
+package entities_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+func TestToolResult_OrderedContent(t *testing.T) {
+	result := entities.NewToolResult().
+		AddText("first").
+		AddText("second").
+		AddImage("iVBORw0KGgo=", "image/png")
+
+	want := []entities.ToolResultContent{
+		{Type: entities.ToolContentText, Text: "first"},
+		{Type: entities.ToolContentText, Text: "second"},
+		{Type: entities.ToolContentImage, Data: "iVBORw0KGgo=", MimeType: "image/png"},
+	}
+	if !reflect.DeepEqual(result.Content, want) {
+		t.Fatalf("Content = %+v, want %+v", result.Content, want)
+	}
+
+	// The result maps directly onto MCP tools/call content
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded struct {
+		Content []map[string]interface{} `json:"content"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	wantJSON := []map[string]interface{}{
+		{"type": "text", "text": "first"},
+		{"type": "text", "text": "second"},
+		{"type": "image", "data": "iVBORw0KGgo=", "mimeType": "image/png"},
+	}
+	if !reflect.DeepEqual(decoded.Content, wantJSON) {
+		t.Errorf("MCP content = %v, want %v", decoded.Content, wantJSON)
+	}
+}
+
+func TestToolResult_AddJSON(t *testing.T) {
+	result := entities.NewToolResult().AddText("summary")
+	if err := result.AddJSON(map[string]int{"count": 2}); err != nil {
+		t.Fatalf("AddJSON() error = %v", err)
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("len(Content) = %d, want 2", len(result.Content))
+	}
+	item := result.Content[1]
+	if item.Type != entities.ToolContentText || item.MimeType != entities.MimeTypeJSON || item.Text != "{\n  \"count\": 2\n}" {
+		t.Errorf("JSON item = %+v", item)
+	}
+
+	// A value that cannot be encoded leaves the result unchanged
+	if err := result.AddJSON(make(chan int)); err == nil {
+		t.Error("AddJSON(chan) error = nil, want an encoding error")
+	}
+	if len(result.Content) != 2 {
+		t.Errorf("len(Content) = %d after a failed AddJSON, want 2", len(result.Content))
+	}
+}
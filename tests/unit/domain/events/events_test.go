@@ -122,6 +122,23 @@ func TestNewMessageAddedEvent(t *testing.T) {
 	}
 }
 
+func TestNewMessageEditedEvent(t *testing.T) {
+	conversationID := vo.GenerateConversationID()
+	messageID := vo.GenerateMessageID()
+
+	event := events.NewMessageEditedEvent(conversationID, messageID)
+
+	if event.EventType() != "message.edited" {
+		t.Errorf("EventType() = %v, want message.edited", event.EventType())
+	}
+	if event.AggregateID() != conversationID.String() {
+		t.Errorf("AggregateID() = %v, want %v", event.AggregateID(), conversationID.String())
+	}
+	if event.Payload()["messageId"] != messageID.String() {
+		t.Errorf("Payload messageId = %v, want %v", event.Payload()["messageId"], messageID.String())
+	}
+}
+
 func TestNewToolRegisteredEvent(t *testing.T) {
 	sessionID := vo.GenerateSessionID()
 	toolName := "test_tool"
@@ -0,0 +1,41 @@
+// Package services_test contains unit tests for domain services
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func TestExpiryChecker_Boundaries(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		skew    time.Duration
+		now     time.Time
+		expired bool
+	}{
+		{"just before expiry", 0, expiresAt.Add(-time.Millisecond), false},
+		{"at expiry", 0, expiresAt, true},
+		{"within tolerance past expiry", 5 * time.Second, expiresAt.Add(5*time.Second - time.Millisecond), false},
+		{"at the end of the tolerance", 5 * time.Second, expiresAt.Add(5 * time.Second), true},
+		{"after the tolerance", 5 * time.Second, expiresAt.Add(6 * time.Second), true},
+		{"negative tolerance ignored", -5 * time.Second, expiresAt.Add(-time.Millisecond), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := services.NewExpiryChecker(mocks.NewFakeClock(tt.now), tt.skew)
+			assert.Equal(t, tt.expired, checker.Expired(expiresAt))
+		})
+	}
+}
+
+func TestExpiryChecker_ZeroValueUsesSystemClock(t *testing.T) {
+	var checker services.ExpiryChecker
+	assert.True(t, checker.Expired(time.Now().Add(-time.Second)))
+	assert.False(t, checker.Expired(time.Now().Add(time.Hour)))
+}
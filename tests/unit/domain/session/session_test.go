@@ -230,6 +230,27 @@ func TestSessionResourceManagement(t *testing.T) {
 		_, ok := session.GetResource("file:///non/existent")
 		assert.False(t, ok)
 	})
+
+	t.Run("should find the template a URI matches", func(t *testing.T) {
+		session := createReadySession(t)
+		files, err := entities.NewResourceTemplate("file:///{path}", "Files", "")
+		require.NoError(t, err)
+		logs, err := entities.NewResourceTemplate("file:///var/log/{name}", "Logs", "")
+		require.NoError(t, err)
+		session.RegisterResource(files)
+		session.RegisterResource(logs)
+
+		found, ok := session.FindResource("file:///etc/hosts")
+		require.True(t, ok)
+		assert.Equal(t, "Files", found.Name())
+		found, ok = session.FindResource("file:///var/log/app.log")
+		require.True(t, ok)
+		assert.Equal(t, "Logs", found.Name(), "the longest matching template should win")
+		_, ok = session.FindResource("file:///")
+		assert.False(t, ok, "an empty variable should not match")
+		_, ok = session.FindResource("status://health")
+		assert.False(t, ok)
+	})
 }
 
 func TestSessionPromptManagement(t *testing.T) {
@@ -293,6 +314,113 @@ func TestSessionConversations(t *testing.T) {
 	})
 }
 
+func TestSessionConversationTemplates(t *testing.T) {
+	t.Run("should create conversation inheriting template settings", func(t *testing.T) {
+		session := createReadySession(t)
+		session.RegisterTool(createTestTool(t, "read_file", "Reads a file"))
+		session.RegisterTool(createTestTool(t, "search_files", "Searches files"))
+		session.RegisterConversationTemplate(createTestTemplate(t, "reviewer", "read_file", "search_files"))
+
+		conv, err := session.CreateConversationFromTemplate("reviewer", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, vo.ModelClaude35Haiku, conv.Model())
+		assert.Equal(t, "You review code.", conv.SystemPrompt().String())
+		assert.Equal(t, 2048, conv.MaxTokens())
+		assert.Equal(t, 0.3, conv.Temperature())
+		assert.Equal(t, 0.9, conv.TopP())
+		assert.Equal(t, 40, conv.TopK())
+		assert.Equal(t, []string{"END"}, conv.StopSequences())
+		require.Len(t, conv.Tools(), 2)
+		assert.Equal(t, "read_file", conv.Tools()[0].Name().String())
+		assert.Equal(t, "search_files", conv.Tools()[1].Name().String())
+		assert.Equal(t, "reviewer", conv.Metadata()["template"])
+
+		retrieved, ok := session.GetConversation(conv.ID())
+		assert.True(t, ok)
+		assert.Equal(t, conv, retrieved)
+	})
+
+	t.Run("should keep conversation defaults for unset settings", func(t *testing.T) {
+		session := createReadySession(t)
+		name, _ := vo.NewToolName("minimal")
+		template, err := entities.NewConversationTemplate(name, vo.ModelClaude4Sonnet)
+		require.NoError(t, err)
+		session.RegisterConversationTemplate(template)
+
+		conv, err := session.CreateConversationFromTemplate("minimal", nil)
+		require.NoError(t, err)
+
+		defaults, err := session.CreateConversation(vo.ModelClaude4Sonnet)
+		require.NoError(t, err)
+		assert.Equal(t, defaults.MaxTokens(), conv.MaxTokens())
+		assert.Equal(t, defaults.Temperature(), conv.Temperature())
+		assert.Equal(t, defaults.TopP(), conv.TopP())
+		assert.Empty(t, conv.Tools())
+	})
+
+	t.Run("should reject template referencing unregistered tool", func(t *testing.T) {
+		session := createReadySession(t)
+		session.RegisterTool(createTestTool(t, "read_file", "Reads a file"))
+		session.RegisterConversationTemplate(createTestTemplate(t, "reviewer", "read_file", "missing_tool"))
+
+		_, err := session.CreateConversationFromTemplate("reviewer", nil)
+		require.ErrorIs(t, err, aggregates.ErrTemplateToolNotFound)
+		assert.Contains(t, err.Error(), "missing_tool")
+		assert.Empty(t, session.ListConversations())
+	})
+
+	t.Run("should look up tools the session does not have", func(t *testing.T) {
+		session := createReadySession(t)
+		session.RegisterTool(createTestTool(t, "read_file", "Reads a file"))
+		session.RegisterConversationTemplate(createTestTemplate(t, "reviewer", "read_file", "search_files"))
+		registry := map[string]*entities.Tool{
+			"read_file":    createTestTool(t, "read_file", "Registry copy"),
+			"search_files": createTestTool(t, "search_files", "Searches files"),
+		}
+
+		conv, err := session.CreateConversationFromTemplate("reviewer", func(name string) (*entities.Tool, bool) {
+			tool, ok := registry[name]
+			return tool, ok
+		})
+		require.NoError(t, err)
+
+		require.Len(t, conv.Tools(), 2)
+		assert.Equal(t, "Reads a file", conv.Tools()[0].Description().String(), "session tools take precedence")
+		assert.Equal(t, registry["search_files"], conv.Tools()[1])
+	})
+
+	t.Run("should reject unknown template", func(t *testing.T) {
+		session := createReadySession(t)
+		_, err := session.CreateConversationFromTemplate("unknown", nil)
+		assert.ErrorIs(t, err, aggregates.ErrTemplateNotFound)
+	})
+
+	t.Run("should reject closed session", func(t *testing.T) {
+		session := createReadySession(t)
+		session.RegisterConversationTemplate(createTestTemplate(t, "reviewer"))
+		session.Close()
+
+		_, err := session.CreateConversationFromTemplate("reviewer", nil)
+		assert.ErrorIs(t, err, aggregates.ErrSessionClosed)
+	})
+
+	t.Run("should create independent conversations from one template", func(t *testing.T) {
+		session := createReadySession(t)
+		session.RegisterConversationTemplate(createTestTemplate(t, "reviewer"))
+
+		first, err := session.CreateConversationFromTemplate("reviewer", nil)
+		require.NoError(t, err)
+		second, err := session.CreateConversationFromTemplate("reviewer", nil)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.ID(), second.ID())
+		first.SetStopSequences([]string{"changed"})
+		assert.Equal(t, []string{"END"}, second.StopSequences())
+		assert.Len(t, session.ListConversations(), 2)
+	})
+}
+
 func TestSessionCapabilities(t *testing.T) {
 	t.Run("should have default capabilities", func(t *testing.T) {
 		session := aggregates.NewSession()
@@ -329,6 +457,29 @@ func TestSessionServerInfo(t *testing.T) {
 	})
 }
 
+func TestSessionScopes(t *testing.T) {
+	t.Run("should hold no scopes until granted", func(t *testing.T) {
+		session := aggregates.NewSession()
+		assert.False(t, session.HasScope("admin"))
+	})
+
+	t.Run("should hold granted scopes", func(t *testing.T) {
+		session := aggregates.NewSession()
+		session.GrantScopes([]string{"read", "admin"})
+		assert.True(t, session.HasScope("admin"))
+		assert.True(t, session.HasScope("read"))
+		assert.False(t, session.HasScope("write"))
+	})
+
+	t.Run("should keep scopes across a reset", func(t *testing.T) {
+		session := aggregates.NewSession()
+		session.GrantScopes([]string{"admin"})
+		_, err := session.Reset()
+		require.NoError(t, err)
+		assert.True(t, session.HasScope("admin"))
+	})
+}
+
 func TestSessionCreatedAt(t *testing.T) {
 	t.Run("should set created time", func(t *testing.T) {
 		beforeCreate := time.Now()
@@ -367,6 +518,26 @@ func createTestTool(t *testing.T, name, description string) *entities.Tool {
 	return tool
 }
 
+func createTestTemplate(t *testing.T, name string, tools ...string) *entities.ConversationTemplate {
+	t.Helper()
+	templateName, err := vo.NewToolName(name)
+	require.NoError(t, err)
+	template, err := entities.NewConversationTemplate(templateName, vo.ModelClaude35Haiku)
+	require.NoError(t, err)
+	systemPrompt, err := vo.NewSystemPrompt("You review code.")
+	require.NoError(t, err)
+	template.SetSystemPrompt(systemPrompt)
+	for _, tool := range tools {
+		template.AddTool(tool)
+	}
+	require.NoError(t, template.SetMaxTokens(2048))
+	require.NoError(t, template.SetTemperature(0.3))
+	require.NoError(t, template.SetTopP(0.9))
+	require.NoError(t, template.SetTopK(40))
+	require.NoError(t, template.SetStopSequences([]string{"END"}))
+	return template
+}
+
 // Benchmarks
 
 func BenchmarkNewSession(b *testing.B) {
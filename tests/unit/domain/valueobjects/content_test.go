@@ -127,6 +127,38 @@ func TestModel_String(t *testing.T) {
 	}
 }
 
+func TestStopReason_IsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		stopReason vo.StopReason
+		want       bool
+	}{
+		{"end_turn is valid", vo.StopReasonEndTurn, true},
+		{"max_tokens is valid", vo.StopReasonMaxTokens, true},
+		{"tool_use is valid", vo.StopReasonToolUse, true},
+		{"stop_sequence is valid", vo.StopReasonStopSequence, true},
+		{"invalid stop reason", vo.StopReason("refusal_maybe"), false},
+		{"empty stop reason", vo.StopReason(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stopReason.IsValid(); got != tt.want {
+				t.Errorf("StopReason.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStopReason_IsTruncated(t *testing.T) {
+	if !vo.StopReasonMaxTokens.IsTruncated() {
+		t.Error("max_tokens should be truncated")
+	}
+	if vo.StopReasonEndTurn.IsTruncated() {
+		t.Error("end_turn should not be truncated")
+	}
+}
+
 func TestNewTextContent(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,126 @@
+package claude_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/claude"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+)
+
+// hangingAnthropic is a fake Anthropic API that never answers and reports when a
+// request's context is cancelled, which happens when the client aborts it
+type hangingAnthropic struct {
+	server    *httptest.Server
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+func newHangingAnthropic(t *testing.T) *hangingAnthropic {
+	t.Helper()
+	h := &hangingAnthropic{started: make(chan struct{}, 1), cancelled: make(chan struct{}, 1)}
+	h.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices a client disconnect once the body has been read
+		_, _ = io.Copy(io.Discard, r.Body)
+		h.started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+			h.cancelled <- struct{}{}
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	t.Cleanup(h.server.Close)
+	return h
+}
+
+func (h *hangingAnthropic) client(t *testing.T) *claude.Client {
+	t.Helper()
+	client, err := claude.NewClient(&config.ClaudeConfig{
+		APIKey:     "test-api-key",
+		BaseURL:    h.server.URL,
+		MaxRetries: 0,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	return client
+}
+
+// cancelOnceStarted cancels the caller once the request reaches the fake API
+// and asserts that the upstream request observes the cancellation
+func (h *hangingAnthropic) cancelOnceStarted(t *testing.T, cancel context.CancelFunc) {
+	t.Helper()
+	select {
+	case <-h.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request never reached the API")
+	}
+	cancel()
+	select {
+	case <-h.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream request was not aborted")
+	}
+}
+
+func TestClient_CreateMessageAbortsOnCancel(t *testing.T) {
+	api := newHangingAnthropic(t)
+	client := api.client(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.CreateMessage(ctx, &services.ClaudeRequest{
+			Model:     vo.ModelClaude4Sonnet,
+			MaxTokens: 100,
+			Messages: []services.ClaudeMessage{
+				{Role: vo.RoleUser, Content: []entities.ContentBlock{{Type: vo.ContentTypeText, Text: "hello"}}},
+			},
+		})
+		done <- err
+	}()
+
+	api.cancelOnceStarted(t, cancel)
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, claude.ErrContextCancelled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("CreateMessage did not return after cancellation")
+	}
+}
+
+func TestClaudeConversationTool_CancellationReachesAnthropic(t *testing.T) {
+	api := newHangingAnthropic(t)
+	registry := tools.NewToolRegistry(api.client(t))
+	tool, ok := registry.GetTool("claude_conversation")
+	require.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *entities.ToolResult, 1)
+	go func() {
+		result, _ := tool.ExecuteContext(ctx, map[string]interface{}{"message": "hello"})
+		done <- result
+	}()
+
+	api.cancelOnceStarted(t, cancel)
+
+	select {
+	case result := <-done:
+		require.NotNil(t, result)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].Text, claude.ErrContextCancelled.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool did not return after cancellation")
+	}
+}
@@ -103,6 +103,53 @@ func TestValidateRequest(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("should fail with too many stop sequences", func(t *testing.T) {
+		request := &services.ClaudeRequest{
+			Model:         vo.ModelClaude4Sonnet,
+			StopSequences: make([]string, vo.MaxStopSequences+1),
+			Messages: []services.ClaudeMessage{
+				{
+					Role: vo.RoleUser,
+					Content: []entities.ContentBlock{
+						{Type: vo.ContentTypeText, Text: "Hello"},
+					},
+				},
+			},
+		}
+
+		err := client.ValidateRequest(request)
+		assert.ErrorIs(t, err, claude.ErrInvalidRequest)
+		assert.ErrorIs(t, err, vo.ErrTooManyStopSequences)
+	})
+
+	t.Run("should apply configured stop sequence limits", func(t *testing.T) {
+		limited, _ := claude.NewClient(&config.ClaudeConfig{
+			APIKey:                "test-api-key",
+			MaxTokens:             4096,
+			MaxStopSequences:      vo.MaxStopSequences + 2,
+			MaxStopSequenceLength: 4,
+		}, logger)
+		request := &services.ClaudeRequest{
+			Model:         vo.ModelClaude4Sonnet,
+			StopSequences: []string{"STOP"},
+			Messages: []services.ClaudeMessage{
+				{
+					Role: vo.RoleUser,
+					Content: []entities.ContentBlock{
+						{Type: vo.ContentTypeText, Text: "Hello"},
+					},
+				},
+			},
+		}
+		for i := 0; i <= vo.MaxStopSequences; i++ {
+			request.StopSequences = append(request.StopSequences, "END")
+		}
+		assert.NoError(t, limited.ValidateRequest(request))
+
+		request.StopSequences = []string{"STOPPED"}
+		assert.ErrorIs(t, limited.ValidateRequest(request), vo.ErrStopSequenceTooLong)
+	})
+
 	t.Run("should use default max tokens when not set", func(t *testing.T) {
 		request := &services.ClaudeRequest{
 			Model:     vo.ModelClaude4Sonnet,
@@ -0,0 +1,236 @@
+package claude_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/claude"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+)
+
+const okMessage = `{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-20250514",` +
+	`"content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`
+
+// apiResponse is one canned response of the fake Anthropic API
+type apiResponse struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+// scriptedAnthropic serves responses in order, repeating the last one, and counts requests
+func scriptedAnthropic(t *testing.T, responses ...apiResponse) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		response := responses[min(n, len(responses)-1)]
+		for key, value := range response.header {
+			w.Header().Set(key, value)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(response.status)
+		_, _ = w.Write([]byte(response.body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func scriptedClient(t *testing.T, server *httptest.Server, maxRetries int) *claude.Client {
+	t.Helper()
+	client, err := claude.NewClient(&config.ClaudeConfig{
+		APIKey:     "test-api-key",
+		BaseURL:    server.URL,
+		MaxRetries: maxRetries,
+		RetryDelay: time.Millisecond,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	return client
+}
+
+func helloRequest() *services.ClaudeRequest {
+	return &services.ClaudeRequest{
+		Model:     vo.ModelClaude4Sonnet,
+		MaxTokens: 100,
+		Messages: []services.ClaudeMessage{
+			{Role: vo.RoleUser, Content: []entities.ContentBlock{{Type: vo.ContentTypeText, Text: "hello"}}},
+		},
+	}
+}
+
+func errorBody(errorType, message string) string {
+	return `{"type":"error","error":{"type":"` + errorType + `","message":"` + message + `"}}`
+}
+
+func TestClient_TypedAPIErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  apiResponse
+		check     func(t *testing.T, err error) bool
+		category  services.ClaudeErrorCategory
+		retryable bool
+		sentinel  error
+	}{
+		{
+			name:     "authentication",
+			response: apiResponse{status: 401, body: errorBody("authentication_error", "invalid x-api-key")},
+			check: func(t *testing.T, err error) bool {
+				var target *services.AuthError
+				return errors.As(err, &target)
+			},
+			category: services.ClaudeErrorAuth,
+			sentinel: claude.ErrAPIError,
+		},
+		{
+			name:     "permission",
+			response: apiResponse{status: 403, body: errorBody("permission_error", "key lacks access")},
+			check: func(t *testing.T, err error) bool {
+				var target *services.AuthError
+				return errors.As(err, &target)
+			},
+			category: services.ClaudeErrorAuth,
+			sentinel: claude.ErrAPIError,
+		},
+		{
+			name:     "rate limit",
+			response: apiResponse{status: 429, header: map[string]string{"retry-after": "0.01"}, body: errorBody("rate_limit_error", "slow down")},
+			check: func(t *testing.T, err error) bool {
+				var target *services.RateLimitError
+				return errors.As(err, &target) && assert.Equal(t, 10*time.Millisecond, target.RetryAfter)
+			},
+			category:  services.ClaudeErrorRateLimit,
+			retryable: true,
+			sentinel:  claude.ErrMaxRetriesExceeded,
+		},
+		{
+			name:     "overloaded",
+			response: apiResponse{status: 529, body: errorBody("overloaded_error", "Overloaded")},
+			check: func(t *testing.T, err error) bool {
+				var target *services.OverloadError
+				return errors.As(err, &target)
+			},
+			category:  services.ClaudeErrorOverloaded,
+			retryable: true,
+			sentinel:  claude.ErrMaxRetriesExceeded,
+		},
+		{
+			name:     "invalid request",
+			response: apiResponse{status: 400, body: errorBody("invalid_request_error", "max_tokens: field required")},
+			check: func(t *testing.T, err error) bool {
+				var target *services.InvalidRequestError
+				return errors.As(err, &target) && assert.Equal(t, "max_tokens: field required", target.Message)
+			},
+			category: services.ClaudeErrorInvalidRequest,
+			sentinel: claude.ErrAPIError,
+		},
+		{
+			name:     "request too large",
+			response: apiResponse{status: 413, body: errorBody("request_too_large", "too large")},
+			check: func(t *testing.T, err error) bool {
+				var target *services.InvalidRequestError
+				return errors.As(err, &target)
+			},
+			category: services.ClaudeErrorInvalidRequest,
+			sentinel: claude.ErrAPIError,
+		},
+		{
+			name:     "server error",
+			response: apiResponse{status: 500, header: map[string]string{"request-id": "req_123"}, body: errorBody("api_error", "Internal server error")},
+			check: func(t *testing.T, err error) bool {
+				var target *services.APIError
+				return errors.As(err, &target) && assert.Equal(t, "req_123", target.RequestID)
+			},
+			category:  services.ClaudeErrorAPI,
+			retryable: true,
+			sentinel:  claude.ErrMaxRetriesExceeded,
+		},
+		{
+			name:     "status without a body",
+			response: apiResponse{status: 429, body: `not json`},
+			check: func(t *testing.T, err error) bool {
+				var target *services.RateLimitError
+				return errors.As(err, &target) && assert.Zero(t, target.RetryAfter)
+			},
+			category:  services.ClaudeErrorRateLimit,
+			retryable: true,
+			sentinel:  claude.ErrMaxRetriesExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := scriptedAnthropic(t, tt.response)
+			client := scriptedClient(t, server, 1)
+
+			_, err := client.CreateMessage(context.Background(), helloRequest())
+			require.Error(t, err)
+			assert.True(t, tt.check(t, err), "unexpected error type: %v", err)
+			assert.ErrorIs(t, err, tt.sentinel)
+
+			claudeErr, ok := services.AsClaudeError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.category, claudeErr.Category())
+			assert.Equal(t, tt.retryable, claudeErr.Retryable())
+			assert.Equal(t, tt.response.status, claudeErr.Response().StatusCode)
+
+			// Retryable errors are retried once, the rest fail on the first attempt
+			wantCalls := int32(1)
+			if tt.retryable {
+				wantCalls = 2
+			}
+			assert.Equal(t, wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestClient_RetriesOverloadThenSucceeds(t *testing.T) {
+	server, calls := scriptedAnthropic(t,
+		apiResponse{status: 529, body: errorBody("overloaded_error", "Overloaded")},
+		apiResponse{status: 200, body: okMessage},
+	)
+	client := scriptedClient(t, server, 2)
+
+	response, err := client.CreateMessage(context.Background(), helloRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "msg_1", response.ID)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_RateLimitWithoutRetriesIsRateLimited(t *testing.T) {
+	server, _ := scriptedAnthropic(t, apiResponse{status: 429, body: errorBody("rate_limit_error", "slow down")})
+	client := scriptedClient(t, server, 0)
+
+	_, err := client.CreateMessage(context.Background(), helloRequest())
+	assert.ErrorIs(t, err, claude.ErrRateLimited)
+}
+
+func TestClaudeConversationTool_ReportsErrorCategory(t *testing.T) {
+	server, _ := scriptedAnthropic(t, apiResponse{
+		status: 429,
+		header: map[string]string{"retry-after": "2"},
+		body:   errorBody("rate_limit_error", "slow down"),
+	})
+	registry := tools.NewToolRegistry(scriptedClient(t, server, 0))
+	tool, ok := registry.GetTool("claude_conversation")
+	require.True(t, ok)
+
+	result, err := tool.ExecuteContext(context.Background(), map[string]interface{}{"message": "hello"})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "rate_limit", result.Meta["errorCategory"])
+	assert.Equal(t, true, result.Meta["retryable"])
+	assert.Equal(t, int64(2000), result.Meta["retryAfterMs"])
+}
@@ -0,0 +1,201 @@
+package claude_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/claude"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+)
+
+// streamingAnthropic is a fake Anthropic API that answers streaming requests with a
+// text reply split into deltas, after failing the first overloadedFirst requests
+type streamingAnthropic struct {
+	server          *httptest.Server
+	requests        int32
+	streamed        int32
+	overloadedFirst int32
+}
+
+func newStreamingAnthropic(t *testing.T, deltas ...string) *streamingAnthropic {
+	t.Helper()
+	a := &streamingAnthropic{}
+	a.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		n := atomic.AddInt32(&a.requests, 1)
+		if n <= atomic.LoadInt32(&a.overloadedFirst) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(529)
+			_, _ = io.WriteString(w, errorBody("overloaded_error", "Overloaded"))
+			return
+		}
+		if !strings.Contains(string(body), `"stream":true`) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-20250514",`+
+				`"content":[{"type":"text","text":"`+strings.Join(deltas, "")+`"}],"stop_reason":"end_turn",`+
+				`"usage":{"input_tokens":12,"output_tokens":5}}`)
+			return
+		}
+
+		atomic.AddInt32(&a.streamed, 1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeEvent := func(name, data string) {
+			_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+		}
+		writeEvent("message_start", `{"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant",`+
+			`"model":"claude-sonnet-4-20250514","content":[],"stop_reason":null,"usage":{"input_tokens":12,"output_tokens":1}}}`)
+		writeEvent("content_block_start", `{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`)
+		for _, delta := range deltas {
+			writeEvent("content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"`+delta+`"}}`)
+		}
+		writeEvent("content_block_stop", `{"type":"content_block_stop","index":0}`)
+		writeEvent("message_delta", `{"type":"message_delta","delta":{"stop_reason":"end_turn","stop_sequence":null},"usage":{"output_tokens":5}}`)
+		writeEvent("message_stop", `{"type":"message_stop"}`)
+	}))
+	t.Cleanup(a.server.Close)
+	return a
+}
+
+func (a *streamingAnthropic) client(t *testing.T, maxRetries int) *claude.Client {
+	t.Helper()
+	client, err := claude.NewClient(&config.ClaudeConfig{
+		APIKey:     "test-api-key",
+		BaseURL:    a.server.URL,
+		MaxRetries: maxRetries,
+		RetryDelay: time.Millisecond,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	return client
+}
+
+// collectStream drains a stream, returning its text and the error that ended it
+func collectStream(t *testing.T, events <-chan *services.ClaudeStreamEvent) (string, *services.ClaudeUsage, error) {
+	t.Helper()
+	var text strings.Builder
+	var usage *services.ClaudeUsage
+	for event := range events {
+		if event.Error != nil {
+			return text.String(), usage, event.Error
+		}
+		if event.Message != nil {
+			usage = event.Message.Usage
+		}
+		if event.Delta != nil {
+			text.WriteString(event.Delta.Text)
+		}
+	}
+	return text.String(), usage, nil
+}
+
+func TestClient_CreateMessageStream(t *testing.T) {
+	api := newStreamingAnthropic(t, "Hel", "lo")
+
+	events, err := api.client(t, 0).CreateMessageStream(context.Background(), helloRequest())
+	require.NoError(t, err)
+
+	text, usage, err := collectStream(t, events)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", text)
+	require.NotNil(t, usage)
+	assert.Equal(t, 12, usage.InputTokens)
+}
+
+func TestClient_CreateMessageStreamRetriesBeforeFirstEvent(t *testing.T) {
+	api := newStreamingAnthropic(t, "Hello")
+	api.overloadedFirst = 1
+
+	events, err := api.client(t, 1).CreateMessageStream(context.Background(), helloRequest())
+	require.NoError(t, err)
+
+	text, _, err := collectStream(t, events)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", text)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&api.requests))
+}
+
+func TestClient_CreateMessageStreamFailsWithoutRetries(t *testing.T) {
+	api := newStreamingAnthropic(t, "Hello")
+	api.overloadedFirst = 1
+
+	events, err := api.client(t, 0).CreateMessageStream(context.Background(), helloRequest())
+	require.NoError(t, err)
+
+	_, _, err = collectStream(t, events)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, claude.ErrMaxRetriesExceeded)
+	claudeErr, ok := services.AsClaudeError(err)
+	require.True(t, ok)
+	assert.True(t, claudeErr.Retryable())
+}
+
+func TestClaudeConversationTool_StreamsReplyAsOutput(t *testing.T) {
+	api := newStreamingAnthropic(t, "Hel", "lo, ", "world")
+	registry := tools.NewToolRegistry(api.client(t, 0))
+	tool, ok := registry.GetTool("claude_conversation")
+	require.True(t, ok)
+
+	var (
+		mu     sync.Mutex
+		chunks []string
+	)
+	ctx := entities.ContextWithToolOutput(context.Background(), func(chunk string) {
+		mu.Lock()
+		defer mu.Unlock()
+		chunks = append(chunks, chunk)
+	})
+
+	result, err := tool.ExecuteContext(ctx, map[string]interface{}{"message": "hello"})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "unexpected error result: %+v", result.Content)
+	assert.Equal(t, "Hello, world", result.Content[0].Text)
+	assert.Equal(t, []string{"Hel", "lo, ", "world"}, chunks)
+	assert.Equal(t, "end_turn", result.Meta["stopReason"])
+	assert.Equal(t, &services.ClaudeUsage{InputTokens: 12, OutputTokens: 5}, result.Meta["usage"])
+	assert.Equal(t, int32(1), atomic.LoadInt32(&api.streamed))
+}
+
+func TestClaudeConversationTool_StreamingCanBeDisabled(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		streamResponses bool
+		input           map[string]interface{}
+	}{
+		{name: "per call", streamResponses: true, input: map[string]interface{}{"message": "hello", "stream": false}},
+		{name: "by setting", streamResponses: false, input: map[string]interface{}{"message": "hello"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newStreamingAnthropic(t, "Hello")
+			registry := tools.NewToolRegistry(api.client(t, 0))
+			registry.SetStreamResponses(tc.streamResponses)
+			tool, ok := registry.GetTool("claude_conversation")
+			require.True(t, ok)
+
+			var streamed []string
+			ctx := entities.ContextWithToolOutput(context.Background(), func(chunk string) {
+				streamed = append(streamed, chunk)
+			})
+
+			result, err := tool.ExecuteContext(ctx, tc.input)
+			require.NoError(t, err)
+			require.False(t, result.IsError, "unexpected error result: %+v", result.Content)
+			assert.Equal(t, "Hello", result.Content[0].Text)
+			assert.Empty(t, streamed)
+			assert.Zero(t, atomic.LoadInt32(&api.streamed))
+		})
+	}
+}
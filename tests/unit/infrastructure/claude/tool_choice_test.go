@@ -0,0 +1,101 @@
+package claude_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/claude"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+// capturedRequest sends request through a client backed by a fake Anthropic API
+// and returns the JSON body the API received
+func capturedRequest(t *testing.T, request *services.ClaudeRequest) map[string]interface{} {
+	t.Helper()
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-20250514",` +
+			`"content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := claude.NewClient(&config.ClaudeConfig{APIKey: "test-api-key", BaseURL: server.URL}, zerolog.Nop())
+	require.NoError(t, err)
+	_, err = client.CreateMessage(context.Background(), request)
+	require.NoError(t, err)
+	return body
+}
+
+func toolChoiceRequest(choice *services.ClaudeToolChoice, tools ...string) *services.ClaudeRequest {
+	request := &services.ClaudeRequest{
+		Model:     vo.ModelClaude4Sonnet,
+		MaxTokens: 100,
+		Messages: []services.ClaudeMessage{
+			{Role: vo.RoleUser, Content: []entities.ContentBlock{{Type: vo.ContentTypeText, Text: "hello"}}},
+		},
+		ToolChoice: choice,
+	}
+	for _, name := range tools {
+		request.Tools = append(request.Tools, services.ClaudeTool{
+			Name:        name,
+			Description: name + " tool",
+			InputSchema: &entities.JSONSchema{Type: "object"},
+		})
+	}
+	return request
+}
+
+func TestClient_ToolChoiceMapping(t *testing.T) {
+	tests := []struct {
+		name   string
+		choice *services.ClaudeToolChoice
+		want   map[string]interface{}
+	}{
+		{
+			name:   "auto",
+			choice: &services.ClaudeToolChoice{Type: vo.ToolChoiceAuto},
+			want:   map[string]interface{}{"type": "auto"},
+		},
+		{
+			name:   "any",
+			choice: &services.ClaudeToolChoice{Type: vo.ToolChoiceAny},
+			want:   map[string]interface{}{"type": "any"},
+		},
+		{
+			name:   "forced tool",
+			choice: &services.ClaudeToolChoice{Type: vo.ToolChoiceTool, Name: "echo"},
+			want:   map[string]interface{}{"type": "tool", "name": "echo"},
+		},
+		{
+			name:   "disabled",
+			choice: &services.ClaudeToolChoice{Type: vo.ToolChoiceNone},
+			want:   map[string]interface{}{"type": "none"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := capturedRequest(t, toolChoiceRequest(tt.choice, "echo", "search"))
+			assert.Len(t, body["tools"], 2)
+			assert.Equal(t, tt.want, body["tool_choice"])
+		})
+	}
+
+	t.Run("omitted without tools", func(t *testing.T) {
+		body := capturedRequest(t, toolChoiceRequest(&services.ClaudeToolChoice{Type: vo.ToolChoiceAny}))
+		assert.NotContains(t, body, "tools")
+		assert.NotContains(t, body, "tool_choice")
+	})
+}
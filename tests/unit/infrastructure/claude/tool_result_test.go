@@ -0,0 +1,75 @@
+package claude_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// toolResultRequest returns a request answering a tool_use with result
+func toolResultRequest(result entities.ContentBlock) *services.ClaudeRequest {
+	return &services.ClaudeRequest{
+		Model:     vo.ModelClaude4Sonnet,
+		MaxTokens: 100,
+		Messages: []services.ClaudeMessage{
+			{Role: vo.RoleUser, Content: []entities.ContentBlock{{Type: vo.ContentTypeText, Text: "take a screenshot"}}},
+			{Role: vo.RoleAssistant, Content: []entities.ContentBlock{
+				{Type: vo.ContentTypeToolUse, ID: "toolu_1", Name: "screenshot", Input: map[string]interface{}{}},
+			}},
+			{Role: vo.RoleUser, Content: []entities.ContentBlock{result}},
+		},
+	}
+}
+
+// sentToolResult returns the tool_result block of the request body the API received
+func sentToolResult(t *testing.T, body map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	messages, _ := body["messages"].([]interface{})
+	require.Len(t, messages, 3)
+	content, _ := messages[2].(map[string]interface{})["content"].([]interface{})
+	require.Len(t, content, 1)
+	block, _ := content[0].(map[string]interface{})
+	require.Equal(t, "tool_result", block["type"])
+	return block
+}
+
+func TestClient_MultiBlockToolResult(t *testing.T) {
+	body := capturedRequest(t, toolResultRequest(entities.ContentBlock{
+		Type:      vo.ContentTypeToolResult,
+		ToolUseID: "toolu_1",
+		Content:   "window: editor\nsize: 800x600",
+		ResultContent: []entities.ContentBlock{
+			{Type: vo.ContentTypeText, Text: "window: editor"},
+			{Type: vo.ContentTypeText, Text: "size: 800x600"},
+			{Type: vo.ContentTypeImage, Source: &entities.ImageSource{Type: "base64", MediaType: "image/png", Data: "iVBORw0KGgo="}},
+		},
+	}))
+
+	block := sentToolResult(t, body)
+	assert.Equal(t, "toolu_1", block["tool_use_id"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "text", "text": "window: editor"},
+		map[string]interface{}{"type": "text", "text": "size: 800x600"},
+		map[string]interface{}{"type": "image", "source": map[string]interface{}{
+			"type": "base64", "media_type": "image/png", "data": "iVBORw0KGgo=",
+		}},
+	}, block["content"])
+}
+
+func TestClient_SingleTextToolResult(t *testing.T) {
+	body := capturedRequest(t, toolResultRequest(entities.ContentBlock{
+		Type:      vo.ContentTypeToolResult,
+		ToolUseID: "toolu_1",
+		Content:   "contents",
+	}))
+
+	block := sentToolResult(t, body)
+	content, _ := block["content"].([]interface{})
+	require.Len(t, content, 1)
+	assert.Equal(t, map[string]interface{}{"type": "text", "text": "contents"}, content[0])
+}
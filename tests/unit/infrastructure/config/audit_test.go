@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+func TestConfigAudit_RedactsSecrets(t *testing.T) {
+	const apiKey = "sk-ant-secret-from-env"
+	const allowedKey = "allowed-client-key"
+	const resumeSecret = "resume-token-secret-of-at-least-32-bytes"
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "security:\n  resume_token_secret: " + resumeSecret + "\n  allowed_api_keys:\n    - " + allowedKey + "\n"
+	if err := os.WriteFile(path, []byte(yaml), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	t.Setenv("TELEMETRYFLOW_MCP_CLAUDE_API_KEY", apiKey)
+	t.Setenv("TELEMETRYFLOW_MCP_LOG_LEVEL", "debug")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	data, err := json.Marshal(cfg.Audit())
+	if err != nil {
+		t.Fatalf("failed to marshal audit: %v", err)
+	}
+	dump := string(data)
+
+	for _, secret := range []string{apiKey, allowedKey, resumeSecret} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("config dump leaks secret %q", secret)
+		}
+	}
+
+	redacted := cfg.Redacted()
+	claude := redacted["claude"].(map[string]interface{})
+	if claude["api_key"] != config.RedactedValue {
+		t.Errorf("claude.api_key = %v, want %s", claude["api_key"], config.RedactedValue)
+	}
+	security := redacted["security"].(map[string]interface{})
+	if security["allowed_api_keys"] != config.RedactedValue {
+		t.Errorf("security.allowed_api_keys = %v, want %s", security["allowed_api_keys"], config.RedactedValue)
+	}
+	if security["resume_token_secret"] != config.RedactedValue {
+		t.Errorf("security.resume_token_secret = %v, want %s", security["resume_token_secret"], config.RedactedValue)
+	}
+	if security["resume_token_ttl"] == config.RedactedValue {
+		t.Error("security.resume_token_ttl should not be redacted")
+	}
+
+	// Non-secret values are kept
+	logging := redacted["logging"].(map[string]interface{})
+	if logging["level"] != "debug" {
+		t.Errorf("logging.level = %v, want debug", logging["level"])
+	}
+	if claude["max_tokens"] != 4096 {
+		t.Errorf("claude.max_tokens = %v, want 4096", claude["max_tokens"])
+	}
+
+	overrides := strings.Join(cfg.EnvOverrides(), ",")
+	for _, key := range []string{"claude.api_key", "logging.level"} {
+		if !strings.Contains(overrides, key) {
+			t.Errorf("env overrides %q missing %s", overrides, key)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+// Equivalent configurations in each supported format
+const (
+	yamlConfig = `
+server:
+  port: 9090
+  transport: stdio
+  shutdown_timeout: 45s
+  max_sessions: 4
+claude:
+  default_model: claude-sonnet-4-20250514
+  temperature: 0.5
+  timeout: 2m
+  enable_batching: true
+mcp:
+  read_file_max_bytes: 2048
+  disabled_tools:
+    - execute_command
+    - write_file
+logging:
+  level: debug
+`
+
+	jsonConfig = `{
+  "server": {"port": 9090, "transport": "stdio", "shutdown_timeout": "45s", "max_sessions": 4},
+  "claude": {"default_model": "claude-sonnet-4-20250514", "temperature": 0.5, "timeout": "2m", "enable_batching": true},
+  "mcp": {"read_file_max_bytes": 2048, "disabled_tools": ["execute_command", "write_file"]},
+  "logging": {"level": "debug"}
+}`
+
+	tomlConfig = `
+[server]
+port = 9090
+transport = "stdio"
+shutdown_timeout = "45s"
+max_sessions = 4
+
+[claude]
+default_model = "claude-sonnet-4-20250514"
+temperature = 0.5
+timeout = "2m"
+enable_batching = true
+
+[mcp]
+read_file_max_bytes = 2048
+disabled_tools = ["execute_command", "write_file"]
+
+[logging]
+level = "debug"
+`
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_EquivalentFormats(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-test")
+
+	want, err := config.Load(writeConfig(t, "config.yaml", yamlConfig))
+	if err != nil {
+		t.Fatalf("Load(yaml) error = %v", err)
+	}
+	if want.Server.Port != 9090 || want.Claude.Timeout.Minutes() != 2 || len(want.MCP.DisabledTools) != 2 {
+		t.Fatalf("YAML config not applied: %+v", want)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		format  string
+	}{
+		{"yml extension", "config.yml", yamlConfig, ""},
+		{"json", "config.json", jsonConfig, ""},
+		{"toml", "config.toml", tomlConfig, ""},
+		{"uppercase extension", "CONFIG.TOML", tomlConfig, ""},
+		{"extensionless yaml", "tfo-mcp", yamlConfig, ""},
+		{"extensionless json with override", "tfo-mcp", jsonConfig, "json"},
+		{"extensionless toml with override", "tfo-mcp", tomlConfig, "TOML"},
+		{"override wins over extension", "config.conf", tomlConfig, "toml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.LoadWithFormat(writeConfig(t, tt.file, tt.content), tt.format)
+			if err != nil {
+				t.Fatalf("LoadWithFormat() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("config differs from the YAML one:\n got  %+v\n want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoad_FormatParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+	}{
+		{"yaml", "config.yaml", "server:\n  port: 9090\n   transport: stdio\n", []string{"invalid YAML in", "line 3"}},
+		{"json", "config.json", "{\n  \"server\": {\"port\": 9090,}\n}", []string{"invalid JSON in", "at line 2, column 27"}},
+		{"toml", "config.toml", "[server]\nport = 9090\ntransport = stdio\n", []string{"invalid TOML in", "at line 3, column 13"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.file, tt.content)
+			_, err := config.Load(path)
+
+			var parseErr *config.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Load() error = %v, want a ParseError", err)
+			}
+			if parseErr.Path != path || parseErr.Format != tt.name {
+				t.Errorf("ParseError path/format = %s/%s", parseErr.Path, parseErr.Format)
+			}
+			for _, fragment := range tt.want {
+				if !strings.Contains(err.Error(), fragment) {
+					t.Errorf("error %q does not mention %q", err, fragment)
+				}
+			}
+		})
+	}
+
+	// A file parsed as the wrong format reports the format it was parsed as
+	_, err := config.LoadWithFormat(writeConfig(t, "tfo-mcp", tomlConfig), "json")
+	if err == nil || !strings.Contains(err.Error(), "invalid JSON") {
+		t.Errorf("LoadWithFormat(toml as json) error = %v", err)
+	}
+}
+
+func TestLoad_UnsupportedFormat(t *testing.T) {
+	if _, err := config.Load(writeConfig(t, "config.ini", "[server]\n")); !errors.Is(err, config.ErrUnsupportedFormat) {
+		t.Errorf("Load(.ini) error = %v, want ErrUnsupportedFormat", err)
+	}
+	if _, err := config.LoadWithFormat(writeConfig(t, "config.yaml", yamlConfig), "hcl"); !errors.Is(err, config.ErrUnsupportedFormat) {
+		t.Errorf("LoadWithFormat(hcl) error = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestLoad_TelemetryExportMode(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-test")
+	exportFile := filepath.Join(t.TempDir(), "telemetry.jsonl")
+
+	cfg, err := config.Load(writeConfig(t, "config.yaml", "telemetry:\n  export_mode: file\n  export_file: "+exportFile+"\n"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Telemetry.ExportMode != "file" || cfg.Telemetry.ExportFile != exportFile {
+		t.Errorf("export mode/file = %s/%s", cfg.Telemetry.ExportMode, cfg.Telemetry.ExportFile)
+	}
+
+	t.Setenv("TELEMETRYFLOW_MCP_TELEMETRY_EXPORT_MODE", "both")
+	cfg, err = config.Load(writeConfig(t, "config.yaml", "telemetry:\n  export_file: "+exportFile+"\n"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Telemetry.ExportMode != "both" {
+		t.Errorf("export mode = %s, want the env override", cfg.Telemetry.ExportMode)
+	}
+
+	t.Setenv("TELEMETRYFLOW_MCP_TELEMETRY_EXPORT_MODE", "")
+	for content, want := range map[string]string{
+		"telemetry:\n  export_mode: syslog\n": "telemetry.export_mode",
+		"telemetry:\n  export_mode: file\n":   "telemetry.export_file is required",
+	} {
+		if _, err := config.Load(writeConfig(t, "config.yaml", content)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Load(%q) error = %v, want %q", content, err, want)
+		}
+	}
+}
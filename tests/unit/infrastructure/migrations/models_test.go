@@ -480,7 +480,7 @@ func TestAllModelsFunction(t *testing.T) {
 	t.Run("returns correct number of models", func(t *testing.T) {
 		allModels := models.AllModels()
 
-		expectedModels := 10 // Session, Conversation, Message, Tool, Resource, Prompt, ResourceSubscription, ToolExecution, APIKey, SchemaMigration
+		expectedModels := 11 // Session, Conversation, Message, Tool, Resource, Prompt, ConversationTemplate, ResourceSubscription, ToolExecution, APIKey, SchemaMigration
 		if len(allModels) != expectedModels {
 			t.Errorf("expected %d models, got %d", expectedModels, len(allModels))
 		}
@@ -3,12 +3,14 @@ package migrations
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
 	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence/models"
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -68,6 +70,7 @@ func TestDefaultSeeders(t *testing.T) {
 		"tools",
 		"resources",
 		"prompts",
+		"conversation_templates",
 		"api_keys",
 		"demo_session",
 	}
@@ -101,6 +104,16 @@ func TestToolSeederData(t *testing.T) {
 		{"search_files", "filesystem", true},
 		{"system_info", "system", true},
 		{"claude_conversation", "ai", true},
+		{"list_processes", "system", true},
+		{"directory_tree", "filesystem", true},
+		{"set_working_dir", "system", true},
+		{"get_working_dir", "system", true},
+		{"watch_file", "filesystem", true},
+		{"unwatch_file", "filesystem", true},
+		{"move_file", "filesystem", true},
+		{"copy_file", "filesystem", true},
+		{"delete_file", "filesystem", true},
+		{"read_config", "filesystem", true},
 	}
 
 	t.Run("has all required tools", func(t *testing.T) {
@@ -514,3 +527,83 @@ func TestAllModels(t *testing.T) {
 		}
 	})
 }
+
+func TestDevSeedersEnvironmentGuard(t *testing.T) {
+	ctx := context.Background()
+	devSeeders := map[string]persistence.SeederFunc{
+		"api_keys":     persistence.SeedAPIKeys,
+		"demo_session": persistence.SeedDemoSession,
+	}
+
+	t.Run("blocked in production", func(t *testing.T) {
+		t.Setenv(telemetry.EnvTelemetryEnvironment, "production")
+		t.Setenv(persistence.EnvAllowDevSeed, "")
+
+		for name, seed := range devSeeders {
+			// The guard runs before the database is touched
+			err := seed(ctx, nil)
+			if !errors.Is(err, persistence.ErrDevSeedInProduction) {
+				t.Errorf("%s: error = %v, want ErrDevSeedInProduction", name, err)
+			}
+		}
+	})
+
+	t.Run("production is assumed when unset", func(t *testing.T) {
+		t.Setenv(telemetry.EnvTelemetryEnvironment, "")
+		t.Setenv(telemetry.EnvEnvironment, "")
+		t.Setenv(persistence.EnvAllowDevSeed, "")
+
+		if err := persistence.CheckDevSeedAllowed(ctx); !errors.Is(err, persistence.ErrDevSeedInProduction) {
+			t.Errorf("CheckDevSeedAllowed() = %v, want ErrDevSeedInProduction", err)
+		}
+	})
+
+	t.Run("allowed with override", func(t *testing.T) {
+		t.Setenv(telemetry.EnvTelemetryEnvironment, "production")
+		t.Setenv(persistence.EnvAllowDevSeed, "true")
+
+		if err := persistence.CheckDevSeedAllowed(ctx); err != nil {
+			t.Errorf("CheckDevSeedAllowed() = %v, want nil", err)
+		}
+	})
+
+	t.Run("allowed in development", func(t *testing.T) {
+		t.Setenv(telemetry.EnvTelemetryEnvironment, "development")
+		t.Setenv(persistence.EnvAllowDevSeed, "")
+
+		if err := persistence.CheckDevSeedAllowed(ctx); err != nil {
+			t.Errorf("CheckDevSeedAllowed() = %v, want nil", err)
+		}
+	})
+
+	t.Run("blocked outside development", func(t *testing.T) {
+		t.Setenv(persistence.EnvAllowDevSeed, "")
+
+		for _, env := range []string{"prod", "staging", "Production", "developmnet"} {
+			t.Setenv(telemetry.EnvTelemetryEnvironment, env)
+			if err := persistence.CheckDevSeedAllowed(ctx); !errors.Is(err, persistence.ErrDevSeedInProduction) {
+				t.Errorf("%s: CheckDevSeedAllowed() = %v, want ErrDevSeedInProduction", env, err)
+			}
+		}
+	})
+
+	t.Run("configured environment wins", func(t *testing.T) {
+		t.Setenv(telemetry.EnvTelemetryEnvironment, "development")
+		t.Setenv(persistence.EnvAllowDevSeed, "")
+
+		prodCtx := persistence.ContextWithSeedEnvironment(ctx, "staging")
+		if err := persistence.CheckDevSeedAllowed(prodCtx); !errors.Is(err, persistence.ErrDevSeedInProduction) {
+			t.Errorf("CheckDevSeedAllowed() = %v, want ErrDevSeedInProduction", err)
+		}
+
+		t.Setenv(telemetry.EnvTelemetryEnvironment, "production")
+		seeder := persistence.NewDatabaseSeeder(nil)
+		seeder.SetEnvironment("local")
+		seeder.Register("guard", func(ctx context.Context, db *gorm.DB) error {
+			return persistence.CheckDevSeedAllowed(ctx)
+		})
+		if _, err := seeder.Run(ctx); err != nil {
+			t.Errorf("Run() = %v, want nil", err)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+func TestInMemoryConversationRepository_FindByMetadata(t *testing.T) {
+	ctx := context.Background()
+	repo := persistence.NewInMemoryConversationRepository()
+	sessionID := vo.GenerateSessionID()
+
+	save := func(metadata map[string]interface{}) *aggregates.Conversation {
+		t.Helper()
+		conversation := aggregates.NewConversation(sessionID, vo.ModelClaude4Sonnet)
+		for key, value := range metadata {
+			conversation.SetMetadata(key, value)
+		}
+		if err := repo.Save(ctx, conversation); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		// Keep creation times distinct so the newest-first order is deterministic
+		time.Sleep(2 * time.Millisecond)
+		return conversation
+	}
+
+	oldest := save(map[string]interface{}{"project": "foo", "priority": 1})
+	save(map[string]interface{}{"project": "bar"})
+	middle := save(map[string]interface{}{"project": "foo", "tags": []string{"billing", "urgent"}})
+	if _, err := middle.AddUserMessage("hello"); err != nil {
+		t.Fatal(err)
+	}
+	newest := save(map[string]interface{}{"project": "foo"})
+	save(map[string]interface{}{"owner": "foo"})
+
+	t.Run("matches key and value", func(t *testing.T) {
+		page, err := repo.FindByMetadata(ctx, "project", "foo", 0, 0)
+		if err != nil {
+			t.Fatalf("FindByMetadata() error = %v", err)
+		}
+		if page.Total != 3 || len(page.Summaries) != 3 {
+			t.Fatalf("got %d of %d summaries, want 3 of 3", len(page.Summaries), page.Total)
+		}
+		want := []vo.ConversationID{newest.ID(), middle.ID(), oldest.ID()}
+		for i, id := range want {
+			if !page.Summaries[i].ID.Equals(id) {
+				t.Errorf("summary %d = %s, want %s", i, page.Summaries[i].ID, id)
+			}
+		}
+		if page.Summaries[1].MessageCount != 1 {
+			t.Errorf("MessageCount = %d, want 1", page.Summaries[1].MessageCount)
+		}
+		if page.Summaries[0].Metadata["project"] != "foo" {
+			t.Errorf("Metadata = %v", page.Summaries[0].Metadata)
+		}
+		if page.HasMore() {
+			t.Error("HasMore() should be false for a complete page")
+		}
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		first, err := repo.FindByMetadata(ctx, "project", "foo", 0, 2)
+		if err != nil {
+			t.Fatalf("FindByMetadata() error = %v", err)
+		}
+		if first.Total != 3 || len(first.Summaries) != 2 || !first.HasMore() {
+			t.Fatalf("first page = %d of %d (more=%v), want 2 of 3 with more", len(first.Summaries), first.Total, first.HasMore())
+		}
+
+		second, err := repo.FindByMetadata(ctx, "project", "foo", 2, 2)
+		if err != nil {
+			t.Fatalf("FindByMetadata() error = %v", err)
+		}
+		if len(second.Summaries) != 1 || !second.Summaries[0].ID.Equals(oldest.ID()) || second.HasMore() {
+			t.Errorf("second page = %+v, want only the oldest conversation", second.Summaries)
+		}
+
+		beyond, err := repo.FindByMetadata(ctx, "project", "foo", 10, 2)
+		if err != nil {
+			t.Fatalf("FindByMetadata() error = %v", err)
+		}
+		if len(beyond.Summaries) != 0 || beyond.Total != 3 {
+			t.Errorf("page beyond the end = %d of %d, want 0 of 3", len(beyond.Summaries), beyond.Total)
+		}
+	})
+
+	t.Run("compares values as JSON", func(t *testing.T) {
+		page, err := repo.FindByMetadata(ctx, "priority", float64(1), 0, 0)
+		if err != nil {
+			t.Fatalf("FindByMetadata() error = %v", err)
+		}
+		if page.Total != 1 || !page.Summaries[0].ID.Equals(oldest.ID()) {
+			t.Errorf("numeric match returned %d conversations", page.Total)
+		}
+
+		page, err = repo.FindByMetadata(ctx, "tags", []string{"urgent"}, 0, 0)
+		if err != nil {
+			t.Fatalf("FindByMetadata() error = %v", err)
+		}
+		if page.Total != 1 || !page.Summaries[0].ID.Equals(middle.ID()) {
+			t.Errorf("array containment returned %d conversations", page.Total)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		page, err := repo.FindByMetadata(ctx, "project", "missing", 0, 10)
+		if err != nil {
+			t.Fatalf("FindByMetadata() error = %v", err)
+		}
+		if page.Total != 0 || len(page.Summaries) != 0 {
+			t.Errorf("got %d summaries, want none", page.Total)
+		}
+	})
+}
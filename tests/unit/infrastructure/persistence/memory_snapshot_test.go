@@ -0,0 +1,297 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+type memoryRepos struct {
+	sessions      *persistence.InMemorySessionRepository
+	conversations *persistence.InMemoryConversationRepository
+	tools         *persistence.InMemoryToolRepository
+	subscriptions *persistence.InMemoryResourceSubscriptionRepository
+	store         *persistence.InMemoryStore
+}
+
+func newMemoryRepos() *memoryRepos {
+	repos := &memoryRepos{
+		sessions:      persistence.NewInMemorySessionRepository(),
+		conversations: persistence.NewInMemoryConversationRepository(),
+		tools:         persistence.NewInMemoryToolRepository(),
+		subscriptions: persistence.NewInMemoryResourceSubscriptionRepository(),
+	}
+	repos.store = persistence.NewInMemoryStore(repos.sessions, repos.conversations, repos.tools, repos.subscriptions)
+	return repos
+}
+
+func newEchoTool(t *testing.T) *entities.Tool {
+	t.Helper()
+	name, _ := vo.NewToolName("echo")
+	desc, _ := vo.NewToolDescription("Echo the message back")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{
+		Type:       "object",
+		Properties: map[string]*entities.JSONSchema{"message": {Type: "string"}},
+		Required:   []string{"message"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetCategory("utility")
+	tool.SetTags([]string{"test"})
+	tool.SetHandler(func(input map[string]interface{}) (*entities.ToolResult, error) {
+		return entities.NewTextToolResult(input["message"].(string)), nil
+	})
+	return tool
+}
+
+// populate saves a ready session with a tool, a subscription and a conversation
+// that used the tool
+func populate(t *testing.T, repos *memoryRepos) (*aggregates.Session, *aggregates.Conversation) {
+	t.Helper()
+	ctx := context.Background()
+
+	tool := newEchoTool(t)
+	if err := repos.tools.Register(ctx, tool); err != nil {
+		t.Fatal(err)
+	}
+
+	session := aggregates.NewSession()
+	if err := session.Initialize(&aggregates.ClientInfo{Name: "claude-desktop", Version: "1.0.0"}, "2025-03-26"); err != nil {
+		t.Fatal(err)
+	}
+	session.MarkReady()
+	if err := session.RegisterTool(tool); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.SubscribeResource("status://health"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos.subscriptions.Subscribe(ctx, session.ID(), "status://health"); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Store().Set("cursor", "42"); err != nil {
+		t.Fatal(err)
+	}
+	session.SetMetadata("team", "observability")
+
+	conv, err := session.CreateConversation(vo.ModelClaude4Sonnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prompt, _ := vo.NewSystemPrompt("You are terse.")
+	if err := conv.SetSystemPrompt(prompt); err != nil {
+		t.Fatal(err)
+	}
+	conv.SetTemperature(0.3)
+	conv.AddTool(tool)
+	if _, err := conv.AddUserMessage("Say hi"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conv.AddAssistantMessage([]entities.ContentBlock{{
+		Type:  vo.ContentTypeToolUse,
+		ID:    "toolu_1",
+		Name:  "echo",
+		Input: map[string]interface{}{"message": "hi"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	second, err := session.CreateConversation(vo.ModelClaude35Haiku)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []*aggregates.Conversation{conv, second} {
+		if err := repos.conversations.Save(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repos.sessions.Save(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	return session, conv
+}
+
+func TestInMemoryStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := newMemoryRepos()
+	session, conv := populate(t, source)
+
+	var buf bytes.Buffer
+	if err := source.store.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	// The restoring process registers its tools with handlers before restoring,
+	// including tools added since the snapshot was taken
+	target := newMemoryRepos()
+	if err := target.tools.Register(ctx, newEchoTool(t)); err != nil {
+		t.Fatal(err)
+	}
+	pingName, _ := vo.NewToolName("ping")
+	pingDesc, _ := vo.NewToolDescription("Reply with pong")
+	ping, err := entities.NewTool(pingName, pingDesc, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := target.tools.Register(ctx, ping); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.store.Restore(&buf); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	restored, err := target.sessions.FindByID(ctx, session.ID())
+	if err != nil || restored == nil {
+		t.Fatalf("restored session not found: %v", err)
+	}
+	if restored.State() != aggregates.SessionStateReady {
+		t.Errorf("state = %s, want ready", restored.State())
+	}
+	if restored.ClientInfo().Name != "claude-desktop" || restored.ProtocolVersion().String() != "2025-03-26" {
+		t.Errorf("client = %+v protocol = %s", restored.ClientInfo(), restored.ProtocolVersion())
+	}
+	if !restored.CreatedAt().Equal(session.CreatedAt()) || !restored.UpdatedAt().Equal(session.UpdatedAt()) {
+		t.Errorf("timestamps = %v/%v, want %v/%v", restored.CreatedAt(), restored.UpdatedAt(), session.CreatedAt(), session.UpdatedAt())
+	}
+	if !restored.IsSubscribed("status://health") {
+		t.Error("subscription was not restored")
+	}
+	if subscribers, _ := target.subscriptions.FindSubscribers(ctx, "status://health"); len(subscribers) != 1 || subscribers[0] != session.ID() {
+		t.Errorf("subscribers = %v, want the restored session", subscribers)
+	}
+	if registered, _ := target.tools.FindByName(ctx, pingName); registered != ping {
+		t.Error("a tool registered after the snapshot was dropped by the restore")
+	}
+	if value, _ := restored.Store().Get("cursor"); value != "42" {
+		t.Errorf("store cursor = %q, want 42", value)
+	}
+	if value, _ := restored.GetMetadata("team"); value != "observability" {
+		t.Errorf("metadata team = %v", value)
+	}
+	if len(restored.Events()) != 0 {
+		t.Errorf("restored session has %d pending events, want none", len(restored.Events()))
+	}
+	if len(restored.ListConversations()) != 2 {
+		t.Errorf("session has %d conversations, want 2", len(restored.ListConversations()))
+	}
+
+	tool, ok := restored.GetTool("echo")
+	if !ok {
+		t.Fatal("session tool was not restored")
+	}
+	if tool.Category() != "utility" || len(tool.Tags()) != 1 {
+		t.Errorf("tool category = %q tags = %v", tool.Category(), tool.Tags())
+	}
+	result, err := tool.Execute(map[string]interface{}{"message": "still works"})
+	if err != nil || result.Content[0].Text != "still works" {
+		t.Errorf("restored tool Execute() = %+v, %v; want the registered handler", result, err)
+	}
+	if repoTool, _ := target.tools.FindByName(ctx, tool.Name()); repoTool != tool {
+		t.Error("session and repository must share the restored tool")
+	}
+
+	restoredConv, err := target.conversations.FindByID(ctx, conv.ID())
+	if err != nil || restoredConv == nil {
+		t.Fatalf("restored conversation not found: %v", err)
+	}
+	if inSession, ok := restored.GetConversation(conv.ID()); !ok || inSession != restoredConv {
+		t.Error("session and repository must share the restored conversation")
+	}
+	if restoredConv.SystemPrompt().String() != "You are terse." || restoredConv.Temperature() != 0.3 {
+		t.Errorf("settings = %q %v", restoredConv.SystemPrompt(), restoredConv.Temperature())
+	}
+	if !restoredConv.CreatedAt().Equal(conv.CreatedAt()) {
+		t.Errorf("conversation createdAt = %v, want %v", restoredConv.CreatedAt(), conv.CreatedAt())
+	}
+	if restoredConv.GetTool(tool.Name()) == nil {
+		t.Error("conversation tool was not restored")
+	}
+
+	want, got := conv.Messages(), restoredConv.Messages()
+	if len(got) != len(want) {
+		t.Fatalf("restored %d messages, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].ID().Equals(want[i].ID()) || got[i].Role() != want[i].Role() || !got[i].CreatedAt().Equal(want[i].CreatedAt()) {
+			t.Errorf("message %d = %s/%s/%v, want %s/%s/%v", i,
+				got[i].ID(), got[i].Role(), got[i].CreatedAt(), want[i].ID(), want[i].Role(), want[i].CreatedAt())
+		}
+	}
+	if input := got[1].Content()[0].Input; input["message"] != "hi" {
+		t.Errorf("tool use input = %v", input)
+	}
+
+	// A restored conversation carries on where it left off
+	if _, err := restoredConv.AddUserMessage("Again"); err != nil {
+		t.Errorf("AddUserMessage() on restored conversation error = %v", err)
+	}
+}
+
+func TestInMemoryStore_RestoreIsAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	source := newMemoryRepos()
+	populate(t, source)
+	var buf bytes.Buffer
+	if err := source.store.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	full := buf.String()
+
+	tests := []struct {
+		name     string
+		snapshot string
+	}{
+		{"truncated", full[:len(full)/2]},
+		{"unknown version", strings.Replace(full, `"version": 1`, `"version": 99`, 1)},
+		{"conversation of a missing session", strings.Replace(full, `"sessions": [`, `"sessions": [], "_sessions": [`, 1)},
+		{"unknown tool", strings.ReplaceAll(full, "\"echo\"\n", "\"echo2\"\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := newMemoryRepos()
+			existing, _ := populate(t, target)
+
+			err := target.store.Restore(strings.NewReader(tt.snapshot))
+			if !errors.Is(err, aggregates.ErrInvalidSnapshot) {
+				t.Fatalf("Restore() error = %v, want ErrInvalidSnapshot", err)
+			}
+			if session, _ := target.sessions.FindByID(ctx, existing.ID()); session != existing {
+				t.Error("a failed restore replaced the existing sessions")
+			}
+			if count, _ := target.tools.Count(ctx); count != 1 {
+				t.Errorf("tools = %d after a failed restore, want 1", count)
+			}
+		})
+	}
+}
+
+func TestInMemoryStore_SnapshotIsStable(t *testing.T) {
+	repos := newMemoryRepos()
+	populate(t, repos)
+
+	var first, second bytes.Buffer
+	if err := repos.store.Snapshot(&first); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := repos.store.Snapshot(&second); err != nil {
+		t.Fatal(err)
+	}
+	// Only the snapshot time may differ
+	trim := func(s string) string { return s[strings.Index(s, `"tools"`):] }
+	if trim(first.String()) != trim(second.String()) {
+		t.Error("snapshots of the same state differ")
+	}
+}
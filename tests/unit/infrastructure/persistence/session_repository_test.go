@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/repositories"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+func sessionIDs(sessions []*aggregates.Session) []vo.SessionID {
+	ids := make([]vo.SessionID, len(sessions))
+	for i, session := range sessions {
+		ids[i] = session.ID()
+	}
+	return ids
+}
+
+func assertSessions(t *testing.T, got []*aggregates.Session, want ...*aggregates.Session) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got sessions %v, want %v", sessionIDs(got), sessionIDs(want))
+	}
+	for i := range want {
+		if !got[i].ID().Equals(want[i].ID()) {
+			t.Errorf("session %d = %s, want %s", i, got[i].ID(), want[i].ID())
+		}
+	}
+}
+
+func TestInMemorySessionRepository_Query(t *testing.T) {
+	ctx := context.Background()
+	repo := persistence.NewInMemorySessionRepository()
+
+	save := func(clientName string, state aggregates.SessionState) *aggregates.Session {
+		t.Helper()
+		session := aggregates.NewSession()
+		if state != aggregates.SessionStateCreated {
+			if err := session.Initialize(&aggregates.ClientInfo{Name: clientName, Version: "1.0.0"}, "2024-11-05"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		switch state {
+		case aggregates.SessionStateReady:
+			session.MarkReady()
+		case aggregates.SessionStateClosed:
+			session.Close()
+		}
+		if err := repo.Save(ctx, session); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		// Keep creation times distinct so the order is deterministic
+		time.Sleep(2 * time.Millisecond)
+		return session
+	}
+
+	uninitialized := save("", aggregates.SessionStateCreated)
+	desktopReady := save("Claude Desktop", aggregates.SessionStateReady)
+	cliClosed := save("mcp-cli", aggregates.SessionStateClosed)
+	desktopClosed := save("claude desktop beta", aggregates.SessionStateClosed)
+	inspector := save("Inspector", aggregates.SessionStateInitializing)
+
+	t.Run("no filter returns every session newest first", func(t *testing.T) {
+		page, err := repo.Query(ctx, repositories.SessionFilter{})
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, inspector, desktopClosed, cliClosed, desktopReady, uninitialized)
+		if page.Total != 5 || page.HasMore() {
+			t.Errorf("Total = %d, HasMore = %v", page.Total, page.HasMore())
+		}
+	})
+
+	t.Run("by state", func(t *testing.T) {
+		page, err := repo.Query(ctx, repositories.SessionFilter{States: []aggregates.SessionState{aggregates.SessionStateClosed}})
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, desktopClosed, cliClosed)
+
+		page, err = repo.Query(ctx, repositories.SessionFilter{
+			States: []aggregates.SessionState{aggregates.SessionStateCreated, aggregates.SessionStateInitializing},
+		})
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, inspector, uninitialized)
+	})
+
+	t.Run("by client name ignoring case", func(t *testing.T) {
+		page, err := repo.Query(ctx, repositories.SessionFilter{ClientName: "DESKTOP"})
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, desktopClosed, desktopReady)
+	})
+
+	t.Run("criteria combine", func(t *testing.T) {
+		page, err := repo.Query(ctx, repositories.SessionFilter{
+			ClientName: "desktop",
+			States:     []aggregates.SessionState{aggregates.SessionStateReady},
+		})
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, desktopReady)
+	})
+
+	t.Run("by protocol version and age", func(t *testing.T) {
+		// Sessions not yet initialized report the default protocol version
+		page, err := repo.Query(ctx, repositories.SessionFilter{
+			ProtocolVersion: "2024-11-05",
+			CreatedBefore:   desktopClosed.CreatedAt(),
+			Ascending:       true,
+		})
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, uninitialized, desktopReady, cliClosed, desktopClosed)
+	})
+
+	t.Run("sorted and paginated", func(t *testing.T) {
+		filter := repositories.SessionFilter{
+			ClientName: "c",
+			SortBy:     repositories.SessionSortClientName,
+			Ascending:  true,
+			Limit:      2,
+		}
+		page, err := repo.Query(ctx, filter)
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, desktopReady, desktopClosed)
+		if page.Total != 4 || !page.HasMore() {
+			t.Errorf("Total = %d, HasMore = %v", page.Total, page.HasMore())
+		}
+
+		filter.Offset = 2
+		page, err = repo.Query(ctx, filter)
+		if err != nil {
+			t.Fatalf("Query() error = %v", err)
+		}
+		assertSessions(t, page.Sessions, inspector, cliClosed)
+		if page.HasMore() {
+			t.Error("last page should not have more")
+		}
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		if _, err := repo.Query(ctx, repositories.SessionFilter{SortBy: "color"}); err == nil {
+			t.Error("expected an error for an unknown sort field")
+		}
+	})
+}
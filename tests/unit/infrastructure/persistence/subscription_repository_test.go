@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+func TestInMemoryResourceSubscriptionRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := persistence.NewInMemoryResourceSubscriptionRepository()
+	first := vo.GenerateSessionID()
+	second := vo.GenerateSessionID()
+
+	for _, uri := range []string{"file:///b.txt", "file:///a.txt", "file:///a.txt"} {
+		if err := repo.Subscribe(ctx, first, uri); err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+	}
+	if err := repo.Subscribe(ctx, second, "file:///a.txt"); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	uris, err := repo.FindBySessionID(ctx, first)
+	if err != nil {
+		t.Fatalf("FindBySessionID() error = %v", err)
+	}
+	if want := []string{"file:///a.txt", "file:///b.txt"}; !reflect.DeepEqual(uris, want) {
+		t.Errorf("FindBySessionID() = %v, want %v", uris, want)
+	}
+
+	subscribers, err := repo.FindSubscribers(ctx, "file:///a.txt")
+	if err != nil {
+		t.Fatalf("FindSubscribers() error = %v", err)
+	}
+	if len(subscribers) != 2 {
+		t.Errorf("FindSubscribers() returned %d sessions, want 2", len(subscribers))
+	}
+
+	if err := repo.Unsubscribe(ctx, first, "file:///b.txt"); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if subscribers, _ := repo.FindSubscribers(ctx, "file:///b.txt"); len(subscribers) != 0 {
+		t.Errorf("unsubscribed URI should have no subscribers, got %v", subscribers)
+	}
+
+	// Cleanup removes only the closed session's subscriptions
+	if err := repo.DeleteBySessionID(ctx, first); err != nil {
+		t.Fatalf("DeleteBySessionID() error = %v", err)
+	}
+	if uris, _ := repo.FindBySessionID(ctx, first); len(uris) != 0 {
+		t.Errorf("subscriptions should be removed, got %v", uris)
+	}
+	subscribers, _ = repo.FindSubscribers(ctx, "file:///a.txt")
+	if len(subscribers) != 1 || !subscribers[0].Equals(second) {
+		t.Errorf("other sessions should keep their subscriptions, got %v", subscribers)
+	}
+}
@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+func newNamedTool(t *testing.T, name, description string) *entities.Tool {
+	t.Helper()
+	toolName, err := vo.NewToolName(name)
+	if err != nil {
+		t.Fatalf("NewToolName() error = %v", err)
+	}
+	desc, err := vo.NewToolDescription(description)
+	if err != nil {
+		t.Fatalf("NewToolDescription() error = %v", err)
+	}
+	tool, err := entities.NewTool(toolName, desc, nil)
+	if err != nil {
+		t.Fatalf("NewTool() error = %v", err)
+	}
+	return tool
+}
+
+func TestInMemoryToolRepository_RejectsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	repo := persistence.NewInMemoryToolRepository()
+
+	original := newNamedTool(t, "read_file", "Built-in")
+	if err := repo.Register(ctx, original); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	err := repo.Register(ctx, newNamedTool(t, "read_file", "Plugin"))
+	if !errors.Is(err, aggregates.ErrToolAlreadyRegistered) {
+		t.Fatalf("Register() error = %v, want %v", err, aggregates.ErrToolAlreadyRegistered)
+	}
+
+	found, _ := repo.FindByName(ctx, original.Name())
+	if found != original {
+		t.Error("duplicate registration should not overwrite the original tool")
+	}
+}
+
+func TestInMemoryToolRepository_Replace(t *testing.T) {
+	ctx := context.Background()
+	var logs bytes.Buffer
+	repo := persistence.NewInMemoryToolRepository()
+	repo.SetLogger(zerolog.New(&logs))
+
+	// Replacing a new name registers it without a warning
+	replaced, err := repo.Replace(ctx, newNamedTool(t, "read_file", "Built-in"))
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if replaced {
+		t.Error("Replace() should not report a replacement for a new tool")
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected no warning for a new tool, got %q", logs.String())
+	}
+
+	override := newNamedTool(t, "read_file", "Plugin")
+	replaced, err = repo.Replace(ctx, override)
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if !replaced {
+		t.Error("Replace() should report that a tool was replaced")
+	}
+
+	found, _ := repo.FindByName(ctx, override.Name())
+	if found != override {
+		t.Error("Replace() should overwrite the existing tool")
+	}
+
+	// Overrides are logged at warn
+	out := logs.String()
+	if !strings.Contains(out, `"level":"warn"`) || !strings.Contains(out, `"tool":"read_file"`) {
+		t.Errorf("expected warning naming the overridden tool, got %q", out)
+	}
+}
+
+func TestInMemoryToolRepository_ValidatesExamples(t *testing.T) {
+	ctx := context.Background()
+	repo := persistence.NewInMemoryToolRepository()
+
+	name, _ := vo.NewToolName("greet")
+	desc, _ := vo.NewToolDescription("Greets someone")
+	schema := &entities.JSONSchema{
+		Type:       "object",
+		Properties: map[string]*entities.JSONSchema{"name": {Type: "string"}},
+		Required:   []string{"name"},
+	}
+
+	invalid, _ := entities.NewTool(name, desc, schema)
+	invalid.SetExamples([]map[string]interface{}{{"name": "Ada"}, {"name": 7}})
+	if err := repo.Register(ctx, invalid); !errors.Is(err, entities.ErrSchemaViolation) {
+		t.Fatalf("Register() error = %v, want %v", err, entities.ErrSchemaViolation)
+	}
+	if _, err := repo.Replace(ctx, invalid); !errors.Is(err, entities.ErrSchemaViolation) {
+		t.Fatalf("Replace() error = %v, want %v", err, entities.ErrSchemaViolation)
+	}
+
+	valid, _ := entities.NewTool(name, desc, schema)
+	valid.SetExamples([]map[string]interface{}{{"name": "Ada"}})
+	if err := repo.Register(ctx, valid); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+}
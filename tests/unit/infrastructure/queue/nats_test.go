@@ -0,0 +1,88 @@
+// Package queue provides unit tests for the NATS queue
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/queue"
+)
+
+// unreachableConfig returns a config pointing at a port nothing listens on
+func unreachableConfig(required bool) *queue.NATSConfig {
+	cfg := queue.DefaultNATSConfig()
+	cfg.URL = "nats://127.0.0.1:1"
+	cfg.Timeout = 200 * time.Millisecond
+	cfg.ReconnectWait = 50 * time.Millisecond
+	cfg.MaxReconnects = 0
+	cfg.Required = required
+	return cfg
+}
+
+func TestNATSQueue_UnreachableRequired(t *testing.T) {
+	q, err := queue.NewNATSQueue(unreachableConfig(true), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+	defer func() { _ = q.Close() }()
+
+	if err := q.Initialize(context.Background()); err == nil {
+		t.Fatal("Initialize() should fail when the queue is required")
+	}
+	if q.State() == queue.QueueStateDegraded {
+		t.Error("required queue should not enter degraded mode")
+	}
+}
+
+func TestNATSQueue_UnreachableOptional(t *testing.T) {
+	q, err := queue.NewNATSQueue(unreachableConfig(false), zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+	defer func() { _ = q.Close() }()
+
+	if err := q.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v, want degraded startup", err)
+	}
+
+	if got := q.State(); got != queue.QueueStateDegraded {
+		t.Errorf("State() = %v, want %v", got, queue.QueueStateDegraded)
+	}
+
+	_, err = q.Publish(context.Background(), &queue.Task{Type: "test"})
+	if !errors.Is(err, queue.ErrQueueDisabled) {
+		t.Errorf("Publish() error = %v, want ErrQueueDisabled", err)
+	}
+
+	health := q.Health()
+	if health["state"] != string(queue.QueueStateDegraded) {
+		t.Errorf("Health() state = %v, want degraded", health["state"])
+	}
+	if _, ok := health["error"]; !ok {
+		t.Error("Health() should report the connection error")
+	}
+
+	// Let the background reconnect loop run a few attempts before closing
+	time.Sleep(150 * time.Millisecond)
+	if err := q.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestNATSQueue_Disabled(t *testing.T) {
+	cfg := queue.DefaultNATSConfig()
+	cfg.Enabled = false
+
+	q, err := queue.NewNATSQueue(cfg, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("NewNATSQueue() error = %v", err)
+	}
+
+	if got := q.State(); got != queue.QueueStateDisabled {
+		t.Errorf("State() = %v, want %v", got, queue.QueueStateDisabled)
+	}
+}
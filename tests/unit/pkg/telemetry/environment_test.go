@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/logging"
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
+)
+
+func TestDetectEnvironment(t *testing.T) {
+	t.Setenv(telemetry.EnvTelemetryEnvironment, "")
+	t.Setenv(telemetry.EnvEnvironment, "")
+	assert.Equal(t, telemetry.EnvironmentProduction, telemetry.DetectEnvironment())
+
+	t.Setenv(telemetry.EnvEnvironment, "local")
+	assert.Equal(t, "local", telemetry.DetectEnvironment())
+
+	// The config loader's variable wins over the generic one
+	t.Setenv(telemetry.EnvTelemetryEnvironment, "staging")
+	assert.Equal(t, "staging", telemetry.DetectEnvironment())
+
+	for _, env := range []string{"dev", "Development", "local"} {
+		assert.True(t, telemetry.IsDevelopmentEnvironment(env), env)
+	}
+	for _, env := range []string{"", "production", "staging"} {
+		assert.False(t, telemetry.IsDevelopmentEnvironment(env), env)
+	}
+}
+
+func TestDefaultObservabilityConfig_EnvironmentAware(t *testing.T) {
+	prod := telemetry.DefaultObservabilityConfigFor("production")
+	assert.False(t, prod.Insecure)
+	assert.Equal(t, "api.telemetryflow.id:4317", prod.Endpoint)
+
+	dev := telemetry.DefaultObservabilityConfigFor("dev")
+	assert.True(t, dev.Insecure)
+	assert.Equal(t, telemetry.LocalCollectorEndpoint, dev.Endpoint)
+	assert.Equal(t, "dev", dev.Environment)
+
+	t.Setenv(telemetry.EnvTelemetryEnvironment, "")
+	t.Setenv(telemetry.EnvEnvironment, "local")
+	assert.True(t, telemetry.DefaultObservabilityConfig().Insecure)
+}
+
+func TestDefaultTFOAdapterConfig_EnvironmentAware(t *testing.T) {
+	prod := logging.DefaultTFOAdapterConfigFor("production")
+	assert.False(t, prod.Insecure)
+	assert.Equal(t, "json", prod.FallbackFormat)
+	assert.Equal(t, "production", prod.Environment)
+
+	dev := logging.DefaultTFOAdapterConfigFor("local")
+	assert.True(t, dev.Insecure)
+	assert.Equal(t, telemetry.LocalCollectorEndpoint, dev.Endpoint)
+	assert.Equal(t, "console", dev.FallbackFormat)
+
+	t.Setenv(telemetry.EnvTelemetryEnvironment, "")
+	t.Setenv(telemetry.EnvEnvironment, "")
+	assert.False(t, logging.DefaultTFOAdapterConfig().Insecure)
+}
@@ -0,0 +1,207 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
+)
+
+// readRecords decodes every line of an export file
+func readRecords(t *testing.T, path string) []telemetry.FileRecord {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []telemetry.FileRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record telemetry.FileRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "invalid line %q", scanner.Text())
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func findRecord(records []telemetry.FileRecord, signal, name string) *telemetry.FileRecord {
+	for i := range records {
+		if records[i].Signal == signal && records[i].Name == name {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+func TestObservability_FileExportMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	cfg := telemetry.DefaultObservabilityConfigFor(telemetry.EnvironmentProduction)
+	cfg.ExportMode = telemetry.ExportModeFile
+	cfg.ExportFile = path
+
+	obs, err := telemetry.NewObservability(cfg)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, obs.Initialize(ctx))
+
+	require.NoError(t, obs.RecordMetric(ctx, "mcp.test.latency", 12.5, "ms", map[string]interface{}{"tool": "echo"}))
+	_, spanID, err := obs.StartSpan(ctx, "mcp.test.call", "server", map[string]interface{}{"tool": "echo"})
+	require.NoError(t, err)
+	require.NotEmpty(t, spanID)
+	require.NoError(t, obs.AddSpanEvent(ctx, spanID, "retry", nil))
+	require.NoError(t, obs.EndSpan(ctx, spanID, errors.New("boom")))
+	require.NoError(t, obs.Shutdown(ctx))
+
+	records := readRecords(t, path)
+
+	metric := findRecord(records, "metric", "mcp.test.latency")
+	require.NotNil(t, metric, "metric not exported")
+	require.NotNil(t, metric.Value)
+	assert.Equal(t, 12.5, *metric.Value)
+	assert.Equal(t, "ms", metric.Unit)
+	assert.Equal(t, "echo", metric.Attributes["tool"])
+	assert.Equal(t, cfg.ServiceName, metric.Service)
+
+	span := findRecord(records, "span", "mcp.test.call")
+	require.NotNil(t, span, "span not exported")
+	assert.Equal(t, spanID, span.SpanID)
+	assert.Equal(t, "server", span.Kind)
+	assert.Equal(t, "error", span.Status)
+	assert.Equal(t, "boom", span.Error)
+	require.NotNil(t, span.DurationMs)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "retry", span.Events[0].Name)
+}
+
+func TestObservability_FileExportModeTracer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	cfg := telemetry.DefaultObservabilityConfigFor(telemetry.EnvironmentProduction)
+	cfg.ExportMode = telemetry.ExportModeFile
+	cfg.ExportFile = path
+
+	obs, err := telemetry.NewObservability(cfg)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, obs.Initialize(ctx))
+
+	tracer := obs.Tracer()
+	require.NotNil(t, tracer, "file-only mode has no tracer")
+	assert.True(t, tracer.IsAvailable())
+	_, spanID, err := tracer.StartToolCallSpan(ctx, "echo", "session-1")
+	require.NoError(t, err)
+	require.NotEmpty(t, spanID)
+	require.NoError(t, tracer.AddToolEvent(ctx, spanID, "tool.retry", "echo", nil))
+	require.NoError(t, tracer.EndSpan(ctx, spanID, nil))
+	require.NoError(t, obs.Shutdown(ctx))
+
+	span := findRecord(readRecords(t, path), "span", "mcp.tool.call")
+	require.NotNil(t, span, "tracer span not exported")
+	assert.Equal(t, spanID, span.SpanID)
+	assert.Equal(t, "echo", span.Attributes[telemetry.AttrToolName])
+	assert.Equal(t, "ok", span.Status)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "tool.retry", span.Events[0].Name)
+}
+
+func TestObservabilityFromEnv_FileExportMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	t.Setenv(telemetry.EnvExportMode, telemetry.ExportModeFile)
+	t.Setenv(telemetry.EnvExportFile, path)
+
+	obs, err := telemetry.NewObservabilityFromEnv()
+	require.NoError(t, err)
+	require.NotNil(t, obs.FileExporter())
+	assert.Nil(t, obs.Client(), "file-only mode created an SDK client")
+	ctx := context.Background()
+	require.NoError(t, obs.Initialize(ctx))
+	require.NoError(t, obs.Log(ctx, "info", "hello", nil))
+	require.NoError(t, obs.Shutdown(ctx))
+
+	assert.NotEmpty(t, readRecords(t, path))
+
+	t.Setenv(telemetry.EnvExportMode, "syslog")
+	_, err = telemetry.NewObservabilityFromEnv()
+	assert.ErrorIs(t, err, telemetry.ErrInvalidExportMode)
+}
+
+func TestFileExporter_RotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	exporter, err := telemetry.NewFileExporter(telemetry.FileExporterConfig{Path: path, MaxBytes: 512, MaxBackups: 2})
+	require.NoError(t, err)
+	for i := 0; i < 50; i++ {
+		require.NoError(t, exporter.ExportLog("info", fmt.Sprintf("entry %d", i), nil))
+	}
+	require.NoError(t, exporter.Close())
+
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(512), p)
+		assert.NotEmpty(t, readRecords(t, p), p)
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "more backups kept than configured")
+
+	// The newest entry is in the live file
+	records := readRecords(t, path)
+	assert.Equal(t, "entry 49", records[len(records)-1].Message)
+}
+
+func TestFileExporter_ConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	exporter, err := telemetry.NewFileExporter(telemetry.FileExporterConfig{Path: path, MaxBytes: 4096, MaxBackups: 100})
+	require.NoError(t, err)
+
+	const writers, perWriter = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				_ = exporter.ExportMetric("counter", "mcp.test.count", 1, "", map[string]interface{}{"writer": w})
+				spanID := exporter.StartSpan("", "mcp.test.span", "internal", nil)
+				_ = exporter.EndSpan(spanID, nil)
+			}
+		}(w)
+	}
+	wg.Wait()
+	require.NoError(t, exporter.Close())
+
+	matches, err := filepath.Glob(path + "*")
+	require.NoError(t, err)
+	total := 0
+	for _, p := range matches {
+		total += len(readRecords(t, p))
+	}
+	assert.Equal(t, writers*perWriter*2, total)
+}
+
+func TestParseExportMode(t *testing.T) {
+	mode, err := telemetry.ParseExportMode("")
+	require.NoError(t, err)
+	assert.Equal(t, telemetry.ExportModeOTLP, mode)
+
+	mode, err = telemetry.ParseExportMode("BOTH")
+	require.NoError(t, err)
+	assert.Equal(t, telemetry.ExportModeBoth, mode)
+
+	_, err = telemetry.ParseExportMode("stdout")
+	assert.ErrorIs(t, err, telemetry.ErrInvalidExportMode)
+
+	cfg := telemetry.DefaultObservabilityConfig()
+	cfg.ExportMode = "stdout"
+	_, err = telemetry.NewObservability(cfg)
+	assert.ErrorIs(t, err, telemetry.ErrInvalidExportMode)
+}
@@ -0,0 +1,205 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/queue"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
+)
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(ctx context.Context, event interface{}) error { return nil }
+
+// The recorders the server and handlers are wired with at startup
+var (
+	_ server.RequestMetrics       = (*telemetry.Metrics)(nil)
+	_ server.ResourceCacheMetrics = (*telemetry.Metrics)(nil)
+	_ handlers.SessionMetrics     = (*telemetry.Metrics)(nil)
+	_ handlers.TurnMetrics        = (*telemetry.Metrics)(nil)
+	_ queue.PublishMetrics        = (*telemetry.Metrics)(nil)
+)
+
+// newTestMetrics creates metrics backed by a manual reader installed as the global meter provider
+func newTestMetrics(t *testing.T) (*telemetry.Metrics, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	metrics, err := telemetry.NewMetrics("telemetryflow-go-mcp-test")
+	require.NoError(t, err)
+	return metrics, reader
+}
+
+// collect returns the latest value of each int64 metric by name
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	values := make(map[string]int64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					values[m.Name] += point.Value
+				}
+			case metricdata.Gauge[int64]:
+				for _, point := range data.DataPoints {
+					values[m.Name] = point.Value
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestMetrics_SessionCapacity(t *testing.T) {
+	ctx := context.Background()
+	metrics, reader := newTestMetrics(t)
+
+	handler := handlers.NewSessionHandler(persistence.NewInMemorySessionRepository(), nopPublisher{})
+	handler.SetMaxSessions(1)
+	handler.SetMetrics(metrics)
+
+	cmd := &commands.InitializeSessionCommand{ClientName: "test-client", ClientVersion: "1.0.0", ProtocolVersion: "2024-11-05"}
+	_, err := handler.HandleInitializeSession(ctx, cmd)
+	require.NoError(t, err)
+	_, err = handler.HandleInitializeSession(ctx, cmd)
+	require.ErrorIs(t, err, handlers.ErrServerAtCapacity)
+
+	values := collect(t, reader)
+	assert.Equal(t, int64(1), values["mcp.sessions.open"])
+	assert.Equal(t, int64(1), values["mcp.sessions.rejected"])
+}
+
+func TestMetrics_RejectionCounters(t *testing.T) {
+	ctx := context.Background()
+	metrics, reader := newTestMetrics(t)
+
+	metrics.RecordRequestRejectedOverload(ctx)
+	metrics.RecordRequestRejectedOverload(ctx)
+	metrics.RecordTurnIterationLimitReached(ctx)
+
+	values := collect(t, reader)
+	assert.Equal(t, int64(2), values["mcp.requests.rejected_overload"])
+	assert.Equal(t, int64(1), values["mcp.turn.iteration_limit_reached"])
+}
+
+func TestMetrics_ResourceCache(t *testing.T) {
+	ctx := context.Background()
+	metrics, reader := newTestMetrics(t)
+
+	metrics.RecordResourceRead(ctx, "file:///app.log", false)
+	metrics.RecordResourceRead(ctx, "file:///app.log", true)
+	metrics.RecordResourceRead(ctx, "file:///app.log", true)
+
+	values := collect(t, reader)
+	assert.Equal(t, int64(3), values["mcp.resource.reads.total"])
+	assert.Equal(t, int64(2), values["mcp.resource.cache.hits"])
+	assert.Equal(t, int64(1), values["mcp.resource.cache.misses"])
+}
+
+func TestMetrics_ClientAttributes(t *testing.T) {
+	metrics, reader := newTestMetrics(t)
+
+	metrics.RecordToolCall(context.Background(), "echo", 0, nil)
+	ctx := telemetry.ContextWithClientInfo(context.Background(), telemetry.ClientInfo{Name: "test-client", Version: "1.0.0"})
+	metrics.RecordToolCall(ctx, "echo", 0, nil)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	byClient := make(map[string]int64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "mcp.tool.calls.total" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				name, _ := point.Attributes.Value(telemetry.AttrClientName)
+				byClient[name.AsString()] += point.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"": 1, "test-client": 1}, byClient)
+}
+
+func TestMetrics_ClientAttributesBounded(t *testing.T) {
+	metrics, reader := newTestMetrics(t)
+
+	long := strings.Repeat("x", telemetry.MaxClientAttributeLength+10)
+	for i := 0; i < telemetry.MaxMetricClients+5; i++ {
+		name := fmt.Sprintf("client-%d", i)
+		if i == 0 {
+			name = long
+		}
+		ctx := telemetry.ContextWithClientInfo(context.Background(), telemetry.ClientInfo{Name: name, Version: "1.0.0"})
+		metrics.RecordToolCall(ctx, "echo", 0, nil)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	byClient := make(map[string]int64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "mcp.tool.calls.total" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				name, _ := point.Attributes.Value(telemetry.AttrClientName)
+				byClient[name.AsString()] += point.Value
+			}
+		}
+	}
+	assert.Len(t, byClient, telemetry.MaxMetricClients+1)
+	assert.Equal(t, int64(5), byClient[telemetry.OtherClient])
+	assert.Equal(t, int64(1), byClient[long[:telemetry.MaxClientAttributeLength]])
+}
+
+func TestMetrics_QueuePublish(t *testing.T) {
+	ctx := context.Background()
+	metrics, reader := newTestMetrics(t)
+
+	metrics.RecordQueuePublish(ctx, "TASKS", "tasks.session.cleanup", 3*time.Millisecond, nil)
+	metrics.RecordQueuePublish(ctx, "TASKS", "tasks.session.cleanup", 5*time.Millisecond, nil)
+	metrics.RecordQueuePublish(ctx, "EVENTS", "events.session.created", time.Millisecond, errors.New("no responders"))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	var latency *metricdata.HistogramDataPoint[float64]
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "queue.publish.duration" {
+				continue
+			}
+			points := m.Data.(metricdata.Histogram[float64]).DataPoints
+			require.Len(t, points, 1, "only successful publishes record latency")
+			latency = &points[0]
+		}
+	}
+	require.NotNil(t, latency, "queue.publish.duration was not recorded")
+	assert.Equal(t, uint64(2), latency.Count)
+	assert.InDelta(t, 0.008, latency.Sum, 1e-9)
+	stream, _ := latency.Attributes.Value("stream")
+	assert.Equal(t, "TASKS", stream.AsString())
+
+	assert.Equal(t, int64(1), collect(t, reader)["queue.publish.failures"])
+}
@@ -0,0 +1,180 @@
+// Package resources provides unit tests for MCP resource handling
+package resources
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestReadResource_MimeTypeFilter(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	textPath := writeFile(t, dir, "notes.txt", []byte("hello"))
+	jsonPath := writeFile(t, dir, "data.json", []byte(`{"key":"value"}`))
+	exePath := writeFile(t, dir, "tool.exe", []byte("MZ\x90\x00\x03\x00\x00\x00"))
+	elfPath := writeFile(t, dir, "disguised.txt", []byte("\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00"))
+
+	handler := resources.NewResourceHandler([]string{dir}, 1024*1024)
+	handler.SetMimeTypeFilter(
+		[]string{"text/*", "application/json"},
+		[]string{"application/octet-stream"},
+	)
+
+	tests := []struct {
+		name    string
+		path    string
+		allowed bool
+	}{
+		{"text file is allowed", textPath, true},
+		{"json file is allowed", jsonPath, true},
+		{"executable is denied", exePath, false},
+		{"binary with text extension is denied", elfPath, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := handler.ReadResource(ctx, "file://"+tt.path)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("ReadResource() error = %v", err)
+				}
+				if content.Text == "" {
+					t.Error("expected content to be returned")
+				}
+				return
+			}
+			if !errors.Is(err, resources.ErrMimeTypeNotAllowed) {
+				t.Errorf("ReadResource() error = %v, want ErrMimeTypeNotAllowed", err)
+			}
+		})
+	}
+}
+
+func TestReadResource_PathAllowlistStillApplies(t *testing.T) {
+	allowedDir := t.TempDir()
+	otherDir := t.TempDir()
+	path := writeFile(t, otherDir, "notes.txt", []byte("hello"))
+
+	handler := resources.NewResourceHandler([]string{allowedDir}, 1024*1024)
+	handler.SetMimeTypeFilter([]string{"text/*"}, nil)
+
+	_, err := handler.ReadResource(context.Background(), "file://"+path)
+	if !errors.Is(err, resources.ErrPathNotAllowed) {
+		t.Errorf("ReadResource() error = %v, want ErrPathNotAllowed", err)
+	}
+}
+
+func TestReadResource_AllowlistNeedsSeparatorBoundary(t *testing.T) {
+	base := t.TempDir()
+	allowedDir := filepath.Join(base, "data")
+	siblingDir := filepath.Join(base, "data-secret")
+	for _, dir := range []string{allowedDir, siblingDir} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	path := writeFile(t, siblingDir, "secret.txt", []byte("hidden"))
+
+	handler := resources.NewResourceHandler([]string{allowedDir}, 1024*1024)
+	_, err := handler.ReadResource(context.Background(), "file://"+path)
+	if !errors.Is(err, resources.ErrPathNotAllowed) {
+		t.Errorf("ReadResource() error = %v, want ErrPathNotAllowed", err)
+	}
+}
+
+func TestReadResource_PathCheckerAppliesSymlinkPolicy(t *testing.T) {
+	allowedDir := t.TempDir()
+	outside := writeFile(t, t.TempDir(), "secret.txt", []byte("hidden"))
+	link := filepath.Join(allowedDir, "link.txt")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{allowedDir})
+	handler := resources.NewResourceHandler([]string{allowedDir}, 1024*1024)
+	handler.SetPathChecker(registry)
+
+	_, err := handler.ReadResource(context.Background(), "file://"+link)
+	if !errors.Is(err, resources.ErrPathNotAllowed) {
+		t.Errorf("ReadResource() error = %v, want ErrPathNotAllowed for a link leaving the allowed directory", err)
+	}
+}
+
+func TestResolveResource_UsesSessionRoots(t *testing.T) {
+	ctx := context.Background()
+	allowedDir := t.TempDir()
+	rootDir := filepath.Join(allowedDir, "project")
+	if err := os.Mkdir(rootDir, 0700); err != nil {
+		t.Fatalf("failed to create root: %v", err)
+	}
+	inside := writeFile(t, rootDir, "inside.txt", []byte("inside"))
+	outside := writeFile(t, allowedDir, "outside.txt", []byte("outside"))
+
+	sessions := persistence.NewInMemorySessionRepository()
+	session := aggregates.NewSession()
+	session.SetRoots([]entities.Root{{URI: "file://" + rootDir}})
+	if err := sessions.Save(ctx, session); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{allowedDir})
+	handler := resources.NewResourceHandler([]string{allowedDir}, 1024*1024)
+	handler.SetPathChecker(registry)
+	handler.SetSessionRepository(sessions)
+
+	if _, err := handler.ResolveResource(ctx, session.ID(), "file://"+inside); err != nil {
+		t.Errorf("ResolveResource() inside the root error = %v", err)
+	}
+	if _, err := handler.ResolveResource(ctx, session.ID(), "file://"+outside); !errors.Is(err, resources.ErrPathNotAllowed) {
+		t.Errorf("ResolveResource() outside the roots error = %v, want ErrPathNotAllowed", err)
+	}
+}
+
+func TestReadResource_NoFilter(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "tool.exe", []byte("MZ\x90\x00"))
+
+	handler := resources.NewResourceHandler([]string{dir}, 1024*1024)
+
+	if _, err := handler.ReadResource(context.Background(), "file://"+path); err != nil {
+		t.Errorf("ReadResource() without filter error = %v", err)
+	}
+}
+
+func TestReadResource_AllowListUsesDeclaredType(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := writeFile(t, dir, "data.json", []byte(`{"key":"value"}`))
+	elfPath := writeFile(t, dir, "disguised.json", []byte("\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00"))
+
+	handler := resources.NewResourceHandler([]string{dir}, 1024*1024)
+	handler.SetMimeTypeFilter([]string{"application/json"}, []string{"application/octet-stream"})
+
+	if _, err := handler.ReadResource(context.Background(), "file://"+jsonPath); err != nil {
+		t.Errorf("ReadResource() JSON error = %v", err)
+	}
+	if _, err := handler.ReadResource(context.Background(), "file://"+elfPath); !errors.Is(err, resources.ErrMimeTypeNotAllowed) {
+		t.Errorf("ReadResource() disguised binary error = %v, want ErrMimeTypeNotAllowed", err)
+	}
+}
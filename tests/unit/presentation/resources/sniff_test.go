@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+)
+
+// pngHeader is the start of a PNG image, enough for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+func TestReadResource_SniffsMimeType(t *testing.T) {
+	dir := t.TempDir()
+	handler := resources.NewResourceHandler([]string{dir}, 1024*1024)
+
+	tests := []struct {
+		name string
+		file string
+		data []byte
+		want string
+	}{
+		{"JSON without an extension", "payload", []byte(`{"key": ["value"]}`), "application/json"},
+		{"PNG without an extension", "upload", pngHeader, "image/png"},
+		{"PNG with a generic binary extension", "image.bin", pngHeader, "image/png"},
+		{"plain text without an extension", "NOTES", []byte("just some notes\n"), "text/plain"},
+		{"text that is not JSON", "braces", []byte("{not json"), "text/plain"},
+		{"known extension wins", "data.json", []byte(`{"key":"value"}`), "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, tt.file, tt.data)
+			content, err := handler.ReadResource(context.Background(), "file://"+path)
+			if err != nil {
+				t.Fatalf("ReadResource() error = %v", err)
+			}
+			if content.MimeType != tt.want {
+				t.Errorf("MimeType = %q, want %q", content.MimeType, tt.want)
+			}
+		})
+	}
+}
+
+func TestSniffMimeType_ConfiguredTypeIsAuthoritative(t *testing.T) {
+	if got := resources.SniffMimeType("config", "text/yaml", []byte(`{"key":"value"}`)); got != "text/yaml" {
+		t.Errorf("SniffMimeType() = %q, want the configured text/yaml", got)
+	}
+	if got := resources.SniffMimeType("config", "application/octet-stream", []byte(`{"key":"value"}`)); got != "application/json" {
+		t.Errorf("SniffMimeType() = %q, want the generic type refined to application/json", got)
+	}
+}
+
+func TestSniffContentMimeType(t *testing.T) {
+	content := &entities.ResourceContent{
+		URI:      "file:///uploads/avatar",
+		MimeType: "application/octet-stream",
+		Blob:     base64.StdEncoding.EncodeToString(pngHeader),
+	}
+	if err := resources.SniffContentMimeType(content); err != nil {
+		t.Fatalf("SniffContentMimeType() error = %v", err)
+	}
+	if content.MimeType != "image/png" {
+		t.Errorf("MimeType = %q, want image/png", content.MimeType)
+	}
+
+	configured := &entities.ResourceContent{URI: "status://health", MimeType: "application/json", Text: "plain"}
+	if err := resources.SniffContentMimeType(configured); err != nil {
+		t.Fatalf("SniffContentMimeType() error = %v", err)
+	}
+	if configured.MimeType != "application/json" {
+		t.Errorf("configured MimeType changed to %q", configured.MimeType)
+	}
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+type fakeRequestMetrics struct {
+	rejected int32
+}
+
+func (m *fakeRequestMetrics) RecordRequestRejectedOverload(ctx context.Context) {
+	atomic.AddInt32(&m.rejected, 1)
+}
+
+// streamClient drives a running server over pipes, one request at a time
+type streamClient struct {
+	in        *io.PipeWriter
+	responses chan JSONRPCResponse
+	done      chan struct{}
+}
+
+func (ts *testServer) stream(t *testing.T) *streamClient {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	ts.srv.SetIO(inR, outW)
+
+	c := &streamClient{in: inW, responses: make(chan JSONRPCResponse, 16), done: make(chan struct{})}
+	go func() {
+		defer close(c.done)
+		_ = ts.srv.Run(context.Background())
+		_ = outW.Close()
+	}()
+	go func() {
+		dec := json.NewDecoder(outR)
+		for {
+			var resp JSONRPCResponse
+			if err := dec.Decode(&resp); err != nil {
+				close(c.responses)
+				return
+			}
+			c.responses <- resp
+		}
+	}()
+	t.Cleanup(func() {
+		_ = inW.Close()
+		<-c.done
+	})
+	return c
+}
+
+func (c *streamClient) send(t *testing.T, req map[string]interface{}) {
+	t.Helper()
+	req["jsonrpc"] = "2.0"
+	data, _ := json.Marshal(req)
+	if _, err := c.in.Write(append(data, '\n')); err != nil {
+		t.Fatalf("failed to write request: %v", err)
+	}
+}
+
+func (c *streamClient) receive(t *testing.T) JSONRPCResponse {
+	t.Helper()
+	select {
+	case resp := <-c.responses:
+		return resp
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for response")
+		return JSONRPCResponse{}
+	}
+}
+
+// newBlockingTool creates a tool that signals started and blocks until release is closed
+func newBlockingTool(t *testing.T, started chan<- struct{}, release <-chan struct{}) *entities.Tool {
+	t.Helper()
+	name, _ := vo.NewToolName("block")
+	desc, _ := vo.NewToolDescription("Blocks until released")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetHandler(func(input map[string]interface{}) (*entities.ToolResult, error) {
+		started <- struct{}{}
+		<-release
+		return entities.NewTextToolResult("done"), nil
+	})
+	return tool
+}
+
+func TestMCPServer_BusyWhenRequestQueueFull(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.MaxConcurrentRequests = 2
+		cfg.Server.RequestQueueDepth = 1
+	})
+	metrics := &fakeRequestMetrics{}
+	ts.srv.SetMetrics(metrics)
+
+	started := make(chan struct{}, 8)
+	release := make(chan struct{})
+	if err := ts.toolRepo.Register(context.Background(), newBlockingTool(t, started, release)); err != nil {
+		t.Fatal(err)
+	}
+
+	c := ts.stream(t)
+	c.send(t, initializeRequest(1))
+	if resp := c.receive(t); resp.Error != nil {
+		t.Fatalf("initialize failed: %+v", resp.Error)
+	}
+	c.send(t, initializedNotification())
+
+	// Saturate both workers, then fill the single queue slot
+	c.send(t, callToolRequest(2, "block"))
+	c.send(t, callToolRequest(3, "block"))
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatal("workers did not start")
+		}
+	}
+	c.send(t, callToolRequest(4, "block"))
+
+	// The queue is over its high-water mark: new requests are told to back off
+	c.send(t, callToolRequest(5, "block"))
+	busy := c.receive(t)
+	if busy.ID != float64(5) || busy.Error == nil {
+		t.Fatalf("expected busy error for request 5, got %+v", busy)
+	}
+	if busy.Error.Code != int(vo.ErrorCodeServerBusy) {
+		t.Errorf("error code = %d, want %d", busy.Error.Code, vo.ErrorCodeServerBusy)
+	}
+	data := busy.Error.Data.(map[string]interface{})
+	if data["retryable"] != true || data["retryAfterSeconds"] != float64(server.ServerBusyRetryAfter.Seconds()) {
+		t.Errorf("busy error should carry retry hints, got %v", data)
+	}
+	if got := atomic.LoadInt32(&metrics.rejected); got != 1 {
+		t.Errorf("rejected metric = %d, want 1", got)
+	}
+
+	// Ping is answered inline even while the pool is saturated
+	c.send(t, map[string]interface{}{"id": 6, "method": "ping"})
+	if resp := c.receive(t); resp.ID != float64(6) || resp.Error != nil {
+		t.Errorf("ping should succeed while busy, got %+v", resp)
+	}
+
+	// Draining the pool completes every admitted request
+	close(release)
+	seen := map[float64]bool{}
+	for i := 0; i < 3; i++ {
+		resp := c.receive(t)
+		if resp.Error != nil {
+			t.Errorf("request %v failed: %+v", resp.ID, resp.Error)
+		}
+		seen[resp.ID.(float64)] = true
+	}
+	for _, id := range []float64{2, 3, 4} {
+		if !seen[id] {
+			t.Errorf("missing response for request %v", id)
+		}
+	}
+
+	// Once recovered, new requests are accepted again
+	c.send(t, callToolRequest(7, "block"))
+	if resp := c.receive(t); resp.ID != float64(7) || resp.Error != nil {
+		t.Errorf("request after recovery should succeed, got %+v", resp)
+	}
+}
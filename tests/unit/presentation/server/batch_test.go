@@ -0,0 +1,105 @@
+package server
+
+import (
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+func TestMCPServer_ToolsCallBatch(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "tools/callBatch",
+			"params": map[string]interface{}{
+				"calls": []interface{}{
+					map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"message": "first"}},
+					map[string]interface{}{"name": "does_not_exist", "arguments": map[string]interface{}{}},
+					map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{}},
+					map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"message": "last"}},
+				},
+			},
+		},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("batch should not fail as a whole: %+v", responses[1].Error)
+	}
+
+	result := responses[1].Result.(map[string]interface{})
+	items := result["results"].([]interface{})
+	if len(items) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(items))
+	}
+
+	item := func(i int) map[string]interface{} { return items[i].(map[string]interface{}) }
+	text := func(i int) string {
+		res := item(i)["result"].(map[string]interface{})
+		return res["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	}
+
+	t.Run("results keep request order", func(t *testing.T) {
+		if text(0) != "first" || text(3) != "last" {
+			t.Errorf("unexpected order: %q, %q", text(0), text(3))
+		}
+	})
+
+	t.Run("unknown tool reported per item", func(t *testing.T) {
+		errObj, ok := item(1)["error"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected error for unknown tool, got %v", item(1))
+		}
+		if int(errObj["code"].(float64)) != int(vo.ErrorCodeToolNotFound) {
+			t.Errorf("error code = %v, want %d", errObj["code"], vo.ErrorCodeToolNotFound)
+		}
+	})
+
+	t.Run("tool error result reported per item", func(t *testing.T) {
+		res, ok := item(2)["result"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected tool result, got %v", item(2))
+		}
+		if res["isError"] != true {
+			t.Error("expected isError for echo without message")
+		}
+	})
+}
+
+func TestMCPServer_ToolsCallBatchLimits(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) {
+		cfg.MCP.MaxBatchSize = 2
+	})
+
+	call := map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"message": "hi"}}
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "tools/callBatch",
+			"params": map[string]interface{}{"calls": []interface{}{call, call, call}},
+		},
+		map[string]interface{}{
+			"id":     3,
+			"method": "tools/callBatch",
+			"params": map[string]interface{}{"calls": []interface{}{}},
+		},
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	for _, resp := range responses[1:] {
+		if resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeInvalidParams) {
+			t.Errorf("response %v: expected invalid params error, got %+v", resp.ID, resp.Error)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+func capabilitiesDiffRequest(id int, since string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "experimental/capabilitiesDiff",
+		"params": map[string]interface{}{"since": since},
+	}
+}
+
+// initializeFingerprint completes the handshake over transport and returns the
+// fingerprint advertised in the initialize result
+func initializeFingerprint(t *testing.T, transport *fakeTransport) string {
+	t.Helper()
+	transport.send(t, initializeRequest(1))
+	msg := transport.receive(t)
+	transport.send(t, initializedNotification())
+
+	result, _ := msg["result"].(map[string]interface{})
+	capabilities, _ := result["capabilities"].(map[string]interface{})
+	experimental, _ := capabilities["experimental"].(map[string]interface{})
+	diff, _ := experimental["capabilitiesDiff"].(map[string]interface{})
+	fingerprint, _ := diff["fingerprint"].(string)
+	if fingerprint == "" {
+		t.Fatalf("initialize result has no fingerprint: %v", result)
+	}
+	return fingerprint
+}
+
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i], _ = item.(string)
+	}
+	return names
+}
+
+func TestMCPServer_CapabilitiesDiffReportsAddedTool(t *testing.T) {
+	ts := newTestServer(t)
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	before := initializeFingerprint(t, transport)
+
+	transport.send(t, capabilitiesDiffRequest(2, before))
+	unchanged, _ := transport.receive(t)["result"].(map[string]interface{})
+	if unchanged["fingerprint"] != before || unchanged["changed"] != false {
+		t.Errorf("diff without changes = %v", unchanged)
+	}
+
+	name, _ := vo.NewToolName("added_tool")
+	description, _ := vo.NewToolDescription("Added after the client connected")
+	tool, err := entities.NewTool(name, description, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+
+	transport.send(t, capabilitiesDiffRequest(3, before))
+	msg := transport.receive(t)
+	if msg["error"] != nil {
+		t.Fatalf("capabilitiesDiff failed: %v", msg["error"])
+	}
+	result, _ := msg["result"].(map[string]interface{})
+	if result["fingerprint"] == before || result["changed"] != true || result["resync"] != nil {
+		t.Errorf("fingerprint should change when a tool is added: %v", result)
+	}
+	tools, _ := result["tools"].(map[string]interface{})
+	if added := stringList(tools["added"]); len(added) != 1 || added[0] != "added_tool" {
+		t.Errorf("tools.added = %v, want [added_tool]", added)
+	}
+	if removed := stringList(tools["removed"]); len(removed) != 0 {
+		t.Errorf("tools.removed = %v, want none", removed)
+	}
+	resources, _ := result["resources"].(map[string]interface{})
+	if added := stringList(resources["added"]); len(added) != 0 {
+		t.Errorf("resources.added = %v, want none", added)
+	}
+}
+
+func TestMCPServer_CapabilitiesDiffUnknownFingerprint(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		capabilitiesDiffRequest(2, "0000000000000000"),
+		map[string]interface{}{"id": 3, "method": "experimental/capabilitiesDiff", "params": map[string]interface{}{}},
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+
+	result, _ := responses[1].Result.(map[string]interface{})
+	if result["resync"] != true || result["tools"] != nil {
+		t.Errorf("unknown fingerprint should ask for a resync: %v", result)
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != int(vo.ErrorCodeInvalidParams) {
+		t.Errorf("missing since: error = %+v, want invalid params", responses[2].Error)
+	}
+}
+
+func TestMCPServer_CapabilitiesDiffReportsChangedSchema(t *testing.T) {
+	ts := newTestServer(t)
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	before := initializeFingerprint(t, transport)
+
+	name, _ := vo.NewToolName("echo")
+	description, _ := vo.NewToolDescription("Echo with a new schema")
+	tool, err := entities.NewTool(name, description, &entities.JSONSchema{
+		Type:       "object",
+		Properties: map[string]*entities.JSONSchema{"text": {Type: "string"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.toolRepo.Replace(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+
+	transport.send(t, capabilitiesDiffRequest(2, before))
+	result, _ := transport.receive(t)["result"].(map[string]interface{})
+	if result["fingerprint"] == before || result["changed"] != true {
+		t.Errorf("fingerprint should change when a schema changes: %v", result)
+	}
+	tools, _ := result["tools"].(map[string]interface{})
+	if changed := stringList(tools["changed"]); len(changed) != 1 || changed[0] != "echo" {
+		t.Errorf("tools.changed = %v, want [echo]", changed)
+	}
+	if added := stringList(tools["added"]); len(added) != 0 {
+		t.Errorf("tools.added = %v, want none", added)
+	}
+}
+
+func TestMCPServer_CapabilitiesDiffSurvivesRestart(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "capabilities.json")
+	withHistory := func(cfg *config.Config) { cfg.MCP.CapabilityHistoryFile = historyFile }
+
+	first := newTestServer(t, withHistory)
+	transport := newFakeTransport()
+	first.srv.SetTransport(transport)
+	go func() { _ = first.srv.Run(context.Background()) }()
+	fingerprint := initializeFingerprint(t, transport)
+	close(transport.incoming)
+
+	restarted := newTestServer(t, withHistory)
+	responses := restarted.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		capabilitiesDiffRequest(2, fingerprint),
+	)
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	result, _ := responses[1].Result.(map[string]interface{})
+	if result["resync"] != nil || result["changed"] != false {
+		t.Errorf("fingerprint saved before the restart should diff cleanly: %v", result)
+	}
+}
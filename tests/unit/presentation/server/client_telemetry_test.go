@@ -0,0 +1,45 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestMCPServer_RequestLogsCarryClientInfo(t *testing.T) {
+	var logs bytes.Buffer
+	ts := newLoggedTestServer(t, zerolog.New(&logs).Level(zerolog.DebugLevel))
+
+	responses := ts.call(t,
+		map[string]interface{}{"id": 1, "method": "ping"},
+		initializeRequest(2),
+		initializedNotification(),
+		map[string]interface{}{"id": 3, "method": "tools/list"},
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+
+	clients := make(map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry["message"] != "Processing request" {
+			continue
+		}
+		method, _ := entry["method"].(string)
+		clients[method] = entry["client_name"]
+		if method == "tools/list" && entry["client_version"] != "1.0.0" {
+			t.Errorf("client_version = %v, want 1.0.0", entry["client_version"])
+		}
+	}
+
+	if clients["tools/list"] != "test-client" {
+		t.Errorf("tools/list log client_name = %v, want test-client", clients["tools/list"])
+	}
+	if name, logged := clients["ping"]; !logged || name != nil {
+		t.Errorf("ping before initialize should be logged without a client, got %v (logged %v)", name, logged)
+	}
+}
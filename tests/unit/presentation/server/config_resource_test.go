@@ -0,0 +1,110 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func TestMCPServer_ConfigResourceDisabledByDefault(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "resources/read",
+			"params": map[string]interface{}{"uri": server.ConfigResourceURI},
+		},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error == nil {
+		t.Error("config resource should not be exposed unless enabled")
+	}
+}
+
+func TestMCPServer_ConfigResourceRedactsSecrets(t *testing.T) {
+	const apiKey = "sk-ant-do-not-leak"
+
+	ts := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.ExposeConfig = true
+		cfg.Claude.APIKey = apiKey
+	})
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "resources/read",
+			"params": map[string]interface{}{"uri": server.ConfigResourceURI},
+		},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("unexpected error: %+v", responses[1].Error)
+	}
+
+	result := responses[1].Result.(map[string]interface{})
+	contents := result["contents"].([]interface{})
+	text := contents[0].(map[string]interface{})["text"].(string)
+
+	if strings.Contains(text, apiKey) {
+		t.Error("config resource leaks the Claude API key")
+	}
+	if !strings.Contains(text, "[REDACTED]") {
+		t.Error("expected redacted marker in config resource")
+	}
+}
+
+func TestMCPServer_ResourcesReadAppliesMimeTypeFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *resources.MimeTypeFilter
+		allowed bool
+	}{
+		// JSON sniffs as text/plain, so the allow list must match the declared type
+		{"declared type allowed", resources.NewMimeTypeFilter([]string{"application/json"}, nil), true},
+		{"declared type not allowed", resources.NewMimeTypeFilter([]string{"text/markdown"}, nil), false},
+		{"declared type denied", resources.NewMimeTypeFilter(nil, []string{"application/*"}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, func(cfg *config.Config) {
+				cfg.Server.ExposeConfig = true
+			})
+			ts.srv.SetMimeTypeFilter(tt.filter)
+
+			responses := ts.call(t,
+				initializeRequest(1),
+				initializedNotification(),
+				map[string]interface{}{
+					"id":     2,
+					"method": "resources/read",
+					"params": map[string]interface{}{"uri": server.ConfigResourceURI},
+				},
+			)
+
+			if len(responses) != 2 {
+				t.Fatalf("expected 2 responses, got %d", len(responses))
+			}
+			if tt.allowed && responses[1].Error != nil {
+				t.Fatalf("unexpected error: %+v", responses[1].Error)
+			}
+			if !tt.allowed && (responses[1].Error == nil || !strings.Contains(responses[1].Error.Message, resources.ErrMimeTypeNotAllowed.Error())) {
+				t.Errorf("expected a MIME type error, got %+v", responses[1].Error)
+			}
+		})
+	}
+}
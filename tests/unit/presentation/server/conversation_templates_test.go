@@ -0,0 +1,38 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+)
+
+func TestMCPServer_ConversationTemplatesList(t *testing.T) {
+	ts := newTestServer(t)
+	templates, err := persistence.DefaultConversationTemplates()
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+	ts.srv.SetConversationTemplates(templates)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "conversationTemplates/list"},
+	)
+	if len(responses) != 2 || responses[1].Error != nil {
+		t.Fatalf("conversationTemplates/list failed: %+v", responses)
+	}
+
+	result, _ := responses[1].Result.(map[string]interface{})
+	listed, _ := result["templates"].([]interface{})
+	if len(listed) != len(templates) {
+		t.Fatalf("listed %d templates, want %d", len(listed), len(templates))
+	}
+	first, _ := listed[0].(map[string]interface{})
+	if first["name"] != "code_assistant" || first["model"] != "claude-sonnet-4-20250514" || first["temperature"] != 0.2 {
+		t.Errorf("first template = %v", first)
+	}
+	if tools, _ := first["tools"].([]interface{}); len(tools) != 3 || tools[0] != "read_file" {
+		t.Errorf("tools = %v, want read_file, list_directory and search_files", first["tools"])
+	}
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+// registerWaitTool registers a tool that runs until its context ends
+func registerWaitTool(t *testing.T, ts *testServer, toolTimeout time.Duration) {
+	t.Helper()
+	name, _ := vo.NewToolName("wait")
+	desc, _ := vo.NewToolDescription("Waits until cancelled")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetTimeout(toolTimeout)
+	tool.SetContextHandler(func(ctx context.Context, input map[string]interface{}) (*entities.ToolResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// callWithTimeout returns a tools/call request carrying a client deadline in _meta
+func callWithTimeout(id int, name string, timeoutMs int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "tools/call",
+		"params": map[string]interface{}{
+			"name":      name,
+			"arguments": map[string]interface{}{},
+			"_meta":     map[string]interface{}{"timeoutMs": timeoutMs},
+		},
+	}
+}
+
+func TestMCPServer_ClientDeadlineBindsToolCall(t *testing.T) {
+	ts := newTestServer(t)
+	registerWaitTool(t, ts, 10*time.Second)
+
+	start := time.Now()
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callWithTimeout(2, "wait", 100),
+	)
+	elapsed := time.Since(start)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	resp := responses[1]
+	if resp.ID != float64(2) || resp.Error == nil {
+		t.Fatalf("expected timeout error for request 2, got %+v", resp)
+	}
+	if resp.Error.Code != int(vo.ErrorCodeTimeout) {
+		t.Errorf("error code = %d, want %d", resp.Error.Code, vo.ErrorCodeTimeout)
+	}
+	data, _ := resp.Error.Data.(map[string]interface{})
+	if data["requestId"] != float64(2) || data["timeoutMs"] != float64(100) {
+		t.Errorf("error data should correlate the request and deadline, got %v", data)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("request took %v; the client deadline should have bound it", elapsed)
+	}
+}
+
+func TestMCPServer_ClientDeadlineCappedByServer(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.Server.MaxRequestTimeout = 100 * time.Millisecond })
+	registerWaitTool(t, ts, 10*time.Second)
+
+	start := time.Now()
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callWithTimeout(2, "wait", 60000),
+	)
+
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("server maximum should cap the client deadline")
+	}
+	resp := responses[1]
+	if resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeTimeout) {
+		t.Fatalf("expected timeout error, got %+v", resp)
+	}
+	if data, _ := resp.Error.Data.(map[string]interface{}); data["timeoutMs"] != float64(100) {
+		t.Errorf("timeoutMs = %v, want the capped 100", data["timeoutMs"])
+	}
+}
+
+func TestMCPServer_ToolTimeoutShorterThanClientDeadline(t *testing.T) {
+	ts := newTestServer(t)
+	registerWaitTool(t, ts, 50*time.Millisecond)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callWithTimeout(2, "wait", 10000),
+	)
+
+	// The tool's own timeout fires first and is reported as a retryable timeout
+	resp := responses[1]
+	if resp.Error == nil {
+		t.Fatalf("expected a timeout error, got result %v", resp.Result)
+	}
+	if resp.Error.Code != int(vo.ErrorCodeTimeout) {
+		t.Errorf("error code = %d, want %d", resp.Error.Code, vo.ErrorCodeTimeout)
+	}
+	if data, _ := resp.Error.Data.(map[string]interface{}); data["retryable"] != true {
+		t.Errorf("tool timeout should be retryable, got data %v", resp.Error.Data)
+	}
+}
+
+func TestMCPServer_ToolTimeoutWithoutClientDeadline(t *testing.T) {
+	ts := newTestServer(t)
+	registerWaitTool(t, ts, 50*time.Millisecond)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "tools/call",
+			"params": map[string]interface{}{"name": "wait", "arguments": map[string]interface{}{}},
+		},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	resp := responses[1]
+	if resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeTimeout) {
+		t.Fatalf("expected timeout error, got %+v", resp)
+	}
+	if data, _ := resp.Error.Data.(map[string]interface{}); data["retryable"] != true {
+		t.Errorf("unexpected error data %v", resp.Error.Data)
+	}
+}
+
+func TestMCPServer_ClientDeadlineNotReached(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "tools/call",
+			"params": map[string]interface{}{
+				"name":      "echo",
+				"arguments": map[string]interface{}{"message": "hi"},
+				"_meta":     map[string]interface{}{"timeoutMs": 5000},
+			},
+		},
+	)
+
+	if resp := responses[1]; resp.Error != nil {
+		t.Fatalf("unexpected error: %+v", resp.Error)
+	}
+}
+
+func TestMCPServer_ResultCompletedAtDeadlineWins(t *testing.T) {
+	fake := newFakeTransport()
+	defer close(fake.incoming)
+	ts := newTestServer(t)
+	ts.srv.SetTransport(fake)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	fake.send(t, initializeRequest(1))
+	fake.receive(t)
+	fake.send(t, initializedNotification())
+
+	// The reader does not watch the deadline and finishes just after it passes
+	uri, _ := vo.NewResourceURI("file:///slow.txt")
+	resource, _ := entities.NewResource(uri, "Slow")
+	resource.SetReader(func(uri string) (*entities.ResourceContent, error) {
+		time.Sleep(100 * time.Millisecond)
+		return &entities.ResourceContent{URI: uri, MimeType: "text/plain", Text: "done"}, nil
+	})
+	ts.srv.Session().RegisterResource(resource)
+
+	fake.send(t, map[string]interface{}{
+		"id":     2,
+		"method": "resources/read",
+		"params": map[string]interface{}{"uri": "file:///slow.txt", "_meta": map[string]interface{}{"timeoutMs": 20}},
+	})
+	if resp := fake.receive(t); resp["error"] != nil || resp["result"] == nil {
+		t.Fatalf("expected the completed result, got %v", resp)
+	}
+}
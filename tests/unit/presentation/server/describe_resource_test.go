@@ -0,0 +1,86 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func TestMCPServer_DescribeResource(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		subscribeRequest(2, "resources/subscribe", server.ToolUsageResourceURI),
+		subscribeRequest(3, "experimental/describeResource", server.HealthResourceURI),
+		subscribeRequest(4, "experimental/describeResource", server.ToolUsageResourceURI),
+		subscribeRequest(5, "experimental/describeResource", "status://missing"),
+		subscribeRequest(6, "experimental/describeResource", ""),
+	)
+
+	if len(responses) != 6 {
+		t.Fatalf("expected 6 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("unexpected subscribe error: %+v", responses[1].Error)
+	}
+
+	describe := func(t *testing.T, resp JSONRPCResponse) map[string]interface{} {
+		t.Helper()
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+		result, ok := resp.Result.(map[string]interface{})
+		if !ok {
+			t.Fatalf("unexpected result type %T", resp.Result)
+		}
+		return result
+	}
+
+	t.Run("concrete resource", func(t *testing.T) {
+		result := describe(t, responses[2])
+		if result["uri"] != server.HealthResourceURI {
+			t.Errorf("uri = %v, want %s", result["uri"], server.HealthResourceURI)
+		}
+		if result["name"] == nil || result["name"] == "" {
+			t.Error("name is missing")
+		}
+		if result["mimeType"] != "application/json" {
+			t.Errorf("mimeType = %v, want application/json", result["mimeType"])
+		}
+		if result["isTemplate"] != false {
+			t.Errorf("isTemplate = %v, want false", result["isTemplate"])
+		}
+		if result["subscribed"] != false {
+			t.Errorf("subscribed = %v, want false", result["subscribed"])
+		}
+	})
+
+	t.Run("subscribed resource", func(t *testing.T) {
+		result := describe(t, responses[3])
+		if result["uri"] != server.ToolUsageResourceURI {
+			t.Errorf("uri = %v, want %s", result["uri"], server.ToolUsageResourceURI)
+		}
+		if result["subscribed"] != true {
+			t.Errorf("subscribed = %v, want true", result["subscribed"])
+		}
+	})
+
+	t.Run("missing resource", func(t *testing.T) {
+		resp := responses[4]
+		if resp.Error == nil {
+			t.Fatal("expected error for missing resource")
+		}
+		if resp.Error.Code != -32002 {
+			t.Errorf("error code = %d, want -32002", resp.Error.Code)
+		}
+	})
+
+	t.Run("missing uri", func(t *testing.T) {
+		resp := responses[5]
+		if resp.Error == nil || resp.Error.Code != -32602 {
+			t.Errorf("error = %+v, want invalid params", resp.Error)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"testing"
+)
+
+func TestMCPServer_DescribeTool(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "experimental/describeTool",
+			"params": map[string]interface{}{"name": "read_file"},
+		},
+		map[string]interface{}{
+			"id":     3,
+			"method": "experimental/describeTool",
+			"params": map[string]interface{}{"name": "does_not_exist"},
+		},
+		map[string]interface{}{
+			"id":     4,
+			"method": "experimental/describeTool",
+			"params": map[string]interface{}{"name": "not a tool name!"},
+		},
+	)
+
+	if len(responses) != 4 {
+		t.Fatalf("expected 4 responses, got %d", len(responses))
+	}
+
+	t.Run("existing tool", func(t *testing.T) {
+		resp := responses[1]
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+		result, ok := resp.Result.(map[string]interface{})
+		if !ok {
+			t.Fatalf("unexpected result type %T", resp.Result)
+		}
+		if result["name"] != "read_file" {
+			t.Errorf("name = %v, want read_file", result["name"])
+		}
+		if result["category"] != "file" {
+			t.Errorf("category = %v, want file", result["category"])
+		}
+		schema, ok := result["inputSchema"].(map[string]interface{})
+		if !ok || schema["type"] != "object" {
+			t.Errorf("inputSchema = %v, want object schema", result["inputSchema"])
+		}
+		annotations, ok := result["annotations"].(map[string]interface{})
+		if !ok || annotations["readOnlyHint"] != true {
+			t.Errorf("annotations = %v, want readOnlyHint", result["annotations"])
+		}
+		params, ok := result["parameters"].([]interface{})
+		if !ok || len(params) != 3 {
+			t.Fatalf("parameters = %v, want path, encoding and offset", result["parameters"])
+		}
+		first, _ := params[0].(map[string]interface{})
+		if first["name"] != "path" || first["type"] != "string" || first["required"] != true {
+			t.Errorf("first parameter = %v, want required string path", first)
+		}
+		second, _ := params[1].(map[string]interface{})
+		if second["name"] != "encoding" || second["required"] != false {
+			t.Errorf("second parameter = %v, want optional encoding", second)
+		}
+		third, _ := params[2].(map[string]interface{})
+		if third["name"] != "offset" || third["type"] != "integer" || third["required"] != false {
+			t.Errorf("third parameter = %v, want optional integer offset", third)
+		}
+	})
+
+	t.Run("missing tool", func(t *testing.T) {
+		resp := responses[2]
+		if resp.Error == nil {
+			t.Fatal("expected error for missing tool")
+		}
+		if resp.Error.Code != -32001 {
+			t.Errorf("error code = %d, want -32001", resp.Error.Code)
+		}
+	})
+
+	t.Run("invalid name is not reported as missing", func(t *testing.T) {
+		resp := responses[3]
+		if resp.Error == nil {
+			t.Fatal("expected error for invalid tool name")
+		}
+		if resp.Error.Code == -32001 {
+			t.Errorf("error code = %d, want an error other than tool not found", resp.Error.Code)
+		}
+	})
+}
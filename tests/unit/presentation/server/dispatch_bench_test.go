@@ -0,0 +1,188 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+	"github.com/telemetryflow/telemetryflow-go-mcp/pkg/telemetry"
+)
+
+// Dispatch benchmarks drive a running server through an in-memory transport, one
+// request at a time, so each op covers decoding, dispatch, the handler and encoding
+// the response. Run them with:
+//
+//	go test ./tests/unit/presentation/server/ -run '^$' -bench Dispatch -benchmem
+//
+// Baseline (linux/amd64, go1.27, 1 vCPU Xeon):
+//
+//	BenchmarkDispatch/observability=off/ping          9450 ns/op      784 B/op     18 allocs/op
+//	BenchmarkDispatch/observability=off/tools/list  239418 ns/op    31936 B/op    477 allocs/op
+//	BenchmarkDispatch/observability=off/tools/call   29251 ns/op     4604 B/op     50 allocs/op
+//	BenchmarkDispatch/observability=on/ping          12022 ns/op     1016 B/op     22 allocs/op
+//	BenchmarkDispatch/observability=on/tools/list   294186 ns/op    32171 B/op    481 allocs/op
+//	BenchmarkDispatch/observability=on/tools/call    33770 ns/op     4836 B/op     54 allocs/op
+//
+// Absolute numbers vary by machine; compare runs on the same host and look for
+// jumps in allocs/op, which are stable across hosts.
+
+// benchTransport hands the server one request at a time and passes each message it
+// writes back to the benchmark
+type benchTransport struct {
+	requests chan []byte
+	messages chan []byte
+}
+
+func newBenchTransport() *benchTransport {
+	return &benchTransport{requests: make(chan []byte), messages: make(chan []byte, 16)}
+}
+
+func (t *benchTransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	select {
+	case data, ok := <-t.requests:
+		if !ok {
+			return nil, io.EOF
+		}
+		return data, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *benchTransport) WriteMessage(ctx context.Context, data []byte) error {
+	select {
+	case t.messages <- data:
+	default:
+		// Drop server notifications nobody is waiting for
+	}
+	return nil
+}
+
+func (t *benchTransport) Close() error {
+	return nil
+}
+
+// roundTrip sends a request and waits for the server's reply
+func (t *benchTransport) roundTrip(tb testing.TB, request []byte) []byte {
+	t.requests <- request
+	select {
+	case data := <-t.messages:
+		return data
+	case <-time.After(5 * time.Second):
+		tb.Fatal("timed out waiting for response")
+		return nil
+	}
+}
+
+// encodeRequest marshals a JSON-RPC request up front so the benchmark loop measures only the server
+func encodeRequest(tb testing.TB, req map[string]interface{}) []byte {
+	tb.Helper()
+	req["jsonrpc"] = "2.0"
+	data, err := json.Marshal(req)
+	if err != nil {
+		tb.Fatalf("failed to marshal request: %v", err)
+	}
+	return data
+}
+
+// startBenchServer runs ts over a bench transport and completes the handshake
+func startBenchServer(b *testing.B, ts *testServer) *benchTransport {
+	b.Helper()
+	transport := newBenchTransport()
+	ts.srv.SetTransport(transport)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = ts.srv.Run(context.Background())
+	}()
+	b.Cleanup(func() {
+		close(transport.requests)
+		<-done
+	})
+
+	transport.roundTrip(b, encodeRequest(b, initializeRequest(1)))
+	transport.requests <- encodeRequest(b, initializedNotification())
+	return transport
+}
+
+// observedTestServer builds a test server with debug logging and metrics enabled,
+// as a production deployment with observability turned on would run
+func observedTestServer(b *testing.B) *testServer {
+	b.Helper()
+	reader := sdkmetric.NewManualReader()
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	b.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	metrics, err := telemetry.NewMetrics("telemetryflow-go-mcp-bench")
+	if err != nil {
+		b.Fatalf("failed to create metrics: %v", err)
+	}
+
+	ts := newLoggedTestServer(b, zerolog.New(io.Discard).Level(zerolog.DebugLevel))
+	ts.srv.SetMetrics(metrics)
+	ts.sessionHandler.SetMetrics(metrics)
+	return ts
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	requests := []struct {
+		name    string
+		request map[string]interface{}
+	}{
+		{"ping", map[string]interface{}{"id": 2, "method": "ping"}},
+		{"tools/list", map[string]interface{}{"id": 2, "method": "tools/list"}},
+		{"tools/call", map[string]interface{}{
+			"id":     2,
+			"method": "tools/call",
+			"params": map[string]interface{}{
+				"name":      "echo",
+				"arguments": map[string]interface{}{"message": "hello"},
+			},
+		}},
+	}
+	variants := []struct {
+		name  string
+		build func(b *testing.B) *testServer
+	}{
+		{"observability=off", func(b *testing.B) *testServer { return newTestServer(b) }},
+		{"observability=on", observedTestServer},
+	}
+
+	for _, variant := range variants {
+		b.Run(variant.name, func(b *testing.B) {
+			for _, tc := range requests {
+				b.Run(tc.name, func(b *testing.B) {
+					transport := startBenchServer(b, variant.build(b))
+					request := encodeRequest(b, tc.request)
+
+					// Check the request succeeds so a broken path does not benchmark as fast
+					var resp JSONRPCResponse
+					if err := json.Unmarshal(transport.roundTrip(b, request), &resp); err != nil {
+						b.Fatalf("failed to decode response: %v", err)
+					}
+					if resp.Error != nil {
+						b.Fatalf("%s failed: %s", tc.name, resp.Error.Message)
+					}
+
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						transport.roundTrip(b, request)
+					}
+				})
+			}
+		})
+	}
+}
+
+// The bench transport must satisfy the server's transport interface
+var _ server.Transport = (*benchTransport)(nil)
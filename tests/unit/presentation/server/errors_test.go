@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/middleware"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func TestAsMCPError_RateLimitIsRetryable(t *testing.T) {
+	mcpErr := server.AsMCPError(middleware.ErrRateLimitExceeded)
+
+	if mcpErr.Code != vo.ErrorCodeRateLimited {
+		t.Errorf("error code = %d, want %d", mcpErr.Code, vo.ErrorCodeRateLimited)
+	}
+	if !mcpErr.Retryable {
+		t.Error("rate limit error should be retryable")
+	}
+	if mcpErr.RetryAfter != server.RateLimitRetryAfter {
+		t.Errorf("retry after = %s, want %s", mcpErr.RetryAfter, server.RateLimitRetryAfter)
+	}
+
+	data := mcpErr.ErrorData()
+	if data["retryable"] != true {
+		t.Errorf("data retryable = %v, want true", data["retryable"])
+	}
+	if data["retryAfterSeconds"] != int(server.RateLimitRetryAfter.Seconds()) {
+		t.Errorf("data retryAfterSeconds = %v, want %d", data["retryAfterSeconds"], int(server.RateLimitRetryAfter.Seconds()))
+	}
+}
+
+func TestAsMCPError_TimeoutIsRetryable(t *testing.T) {
+	mcpErr := server.AsMCPError(context.DeadlineExceeded)
+
+	if mcpErr.Code != vo.ErrorCodeTimeout {
+		t.Errorf("error code = %d, want %d", mcpErr.Code, vo.ErrorCodeTimeout)
+	}
+	if !mcpErr.Retryable {
+		t.Error("timeout error should be retryable")
+	}
+	if _, ok := mcpErr.ErrorData()["retryAfterSeconds"]; ok {
+		t.Error("timeout error should not carry a retry-after hint")
+	}
+}
+
+func TestMCPServer_InvalidParamsNotRetryable(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "tools/call", "params": "not an object"},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+
+	resp := responses[1]
+	if resp.Error == nil {
+		t.Fatal("expected invalid params error")
+	}
+	if resp.Error.Code != int(vo.ErrorCodeInvalidParams) {
+		t.Errorf("error code = %d, want %d", resp.Error.Code, vo.ErrorCodeInvalidParams)
+	}
+	data, ok := resp.Error.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected retry hints in error data, got %T", resp.Error.Data)
+	}
+	if data["retryable"] != false {
+		t.Errorf("data retryable = %v, want false", data["retryable"])
+	}
+	if _, ok := data["retryAfterSeconds"]; ok {
+		t.Error("invalid params error should not carry a retry-after hint")
+	}
+}
+
+func TestMCPServer_ThrottledToolCallIsRetryable(t *testing.T) {
+	ts := newTestServer(t)
+	ts.toolHandler.SetToolRateLimits(map[string]int{"echo": 1})
+
+	echo := func(id int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":     id,
+			"method": "tools/call",
+			"params": map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"message": "hi"}},
+		}
+	}
+	responses := ts.call(t, initializeRequest(1), initializedNotification(), echo(2), echo(3))
+
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("first call failed: %+v", responses[1].Error)
+	}
+	resp := responses[2]
+	if resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeRateLimited) {
+		t.Fatalf("error = %+v, want rate limited", resp.Error)
+	}
+	data, _ := resp.Error.Data.(map[string]interface{})
+	if data["retryable"] != true || data["tool"] != "echo" || data["retryAfterSeconds"] == nil {
+		t.Errorf("error data = %v, want a retryable throttle of echo", data)
+	}
+}
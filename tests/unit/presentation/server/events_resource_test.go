@@ -0,0 +1,187 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// eventEntry is one event listed by status://events
+type eventEntry struct {
+	ID      string                 `json:"id"`
+	Type    string                 `json:"type"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// secretEvent is a domain event carrying a credential in its payload
+type secretEvent struct {
+	sessionID string
+}
+
+func (e secretEvent) EventID() string       { return "secret-event" }
+func (e secretEvent) EventType() string     { return "test.secret" }
+func (e secretEvent) AggregateID() string   { return e.sessionID }
+func (e secretEvent) AggregateType() string { return "Session" }
+func (e secretEvent) OccurredAt() time.Time { return time.Now().UTC() }
+func (e secretEvent) Payload() map[string]interface{} {
+	return map[string]interface{}{
+		"sessionId": e.sessionID,
+		"apiKey":    "sk-live-123",
+		"toolName":  "deploy",
+		"request":   map[string]interface{}{"X-Api-Key": "sk-live-456", "webhook_secret": "whsec-789"},
+	}
+}
+
+// startEventsSession initializes a session over a stream and returns the client and session
+func startEventsSession(t *testing.T, ts *testServer) (*streamClient, *aggregates.Session) {
+	t.Helper()
+	client := ts.stream(t)
+	client.send(t, initializeRequest(1))
+	if resp := client.receive(t); resp.Error != nil {
+		t.Fatalf("initialize failed: %+v", resp.Error)
+	}
+	client.send(t, initializedNotification())
+
+	sessions, err := ts.sessionRepo.FindAll(context.Background())
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("expected one session, got %d (%v)", len(sessions), err)
+	}
+	return client, sessions[0]
+}
+
+// readEvents reads the given status://events URI and returns the listed events, or the error
+func readEvents(t *testing.T, client *streamClient, id int, uri string) ([]eventEntry, *JSONRPCError) {
+	t.Helper()
+	client.send(t, map[string]interface{}{
+		"id":     id,
+		"method": "resources/read",
+		"params": map[string]interface{}{"uri": uri},
+	})
+	resp := client.receive(t)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	result := resp.Result.(map[string]interface{})
+	text := result["contents"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	var report struct {
+		Events []eventEntry `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(text), &report); err != nil {
+		t.Fatalf("invalid events JSON: %v", err)
+	}
+	return report.Events, nil
+}
+
+func eventTypes(list []eventEntry) []string {
+	types := make([]string, len(list))
+	for i, event := range list {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func TestMCPServer_EventsResource(t *testing.T) {
+	ts := newTestServer(t)
+	client, session := startEventsSession(t, ts)
+
+	_, err := ts.toolHandler.HandleRegisterTool(context.Background(), &commands.RegisterToolCommand{
+		SessionID:   session.ID(),
+		Name:        "custom_tool",
+		Description: "A tool registered by the test",
+		InputSchema: &entities.JSONSchema{Type: "object"},
+	})
+	if err != nil {
+		t.Fatalf("failed to register tool: %v", err)
+	}
+
+	list, rpcErr := readEvents(t, client, 2, server.EventsResourceURI)
+	if rpcErr != nil {
+		t.Fatalf("unexpected error: %+v", rpcErr)
+	}
+	seen := make(map[string]bool)
+	for _, event := range list {
+		seen[event.Type] = true
+	}
+	if !seen["session.created"] || !seen["tool.registered"] {
+		t.Errorf("events = %v, want session.created and tool.registered", eventTypes(list))
+	}
+
+	list, rpcErr = readEvents(t, client, 3, server.EventsResourceURI+"?type=tool.registered")
+	if rpcErr != nil {
+		t.Fatalf("unexpected error: %+v", rpcErr)
+	}
+	if len(list) != 1 || list[0].Payload["toolName"] != "custom_tool" {
+		t.Errorf("filtered events = %+v, want the custom_tool registration", list)
+	}
+
+	list, rpcErr = readEvents(t, client, 4, server.EventsResourceURI+"?limit=1")
+	if rpcErr != nil {
+		t.Fatalf("unexpected error: %+v", rpcErr)
+	}
+	if len(list) != 1 || list[0].Type != "tool.registered" {
+		t.Errorf("limited events = %v, want only the latest", eventTypes(list))
+	}
+}
+
+func TestMCPServer_EventsResourceRedactsSecrets(t *testing.T) {
+	ts := newTestServer(t)
+	client, session := startEventsSession(t, ts)
+	_ = ts.events.Store(context.Background(), secretEvent{sessionID: session.ID().String()})
+
+	list, rpcErr := readEvents(t, client, 2, server.EventsResourceURI+"?type=test.secret")
+	if rpcErr != nil {
+		t.Fatalf("unexpected error: %+v", rpcErr)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected the secret event, got %v", eventTypes(list))
+	}
+	if list[0].Payload["apiKey"] != config.RedactedValue || list[0].Payload["toolName"] != "deploy" {
+		t.Errorf("payload = %v, want apiKey redacted and toolName kept", list[0].Payload)
+	}
+	request, _ := list[0].Payload["request"].(map[string]interface{})
+	if request["X-Api-Key"] != config.RedactedValue || request["webhook_secret"] != config.RedactedValue {
+		t.Errorf("nested payload = %v, want the header and the secret redacted like the request log", request)
+	}
+}
+
+func TestMCPServer_EventsResourceScopes(t *testing.T) {
+	other := aggregates.NewSession()
+
+	t.Run("all sessions requires admin methods", func(t *testing.T) {
+		ts := newTestServer(t)
+		client, _ := startEventsSession(t, ts)
+		if _, rpcErr := readEvents(t, client, 2, server.EventsResourceURI+"?scope=all"); rpcErr == nil {
+			t.Error("expected scope=all to be rejected without admin methods")
+		}
+	})
+
+	t.Run("session scope hides other sessions", func(t *testing.T) {
+		ts := newTestServer(t)
+		client, _ := startEventsSession(t, ts)
+		_ = ts.events.Store(context.Background(), secretEvent{sessionID: other.ID().String()})
+
+		list, rpcErr := readEvents(t, client, 2, server.EventsResourceURI+"?type=test.secret")
+		if rpcErr != nil || len(list) != 0 {
+			t.Errorf("events = %v (%v), want none from another session", eventTypes(list), rpcErr)
+		}
+	})
+
+	t.Run("admins read all sessions", func(t *testing.T) {
+		ts := newTestServer(t, func(cfg *config.Config) { cfg.Server.EnableAdminMethods = true })
+		client, _ := startEventsSession(t, ts)
+		_ = ts.events.Store(context.Background(), secretEvent{sessionID: other.ID().String()})
+
+		list, rpcErr := readEvents(t, client, 2, server.EventsResourceURI+"?type=test.secret&scope=all")
+		if rpcErr != nil || len(list) != 1 {
+			t.Errorf("events = %v (%v), want the other session's event", eventTypes(list), rpcErr)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMCPServer_WatchFileNotification(t *testing.T) {
+	ts := newTestServer(t)
+	ts.registry.FileWatcher().SetNotifier(ts.srv)
+	t.Cleanup(ts.registry.FileWatcher().Close)
+
+	path := filepath.Join(t.TempDir(), "watched.txt")
+	if err := os.WriteFile(path, []byte("v1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	defer close(transport.incoming)
+
+	transport.send(t, initializeRequest(1))
+	transport.receive(t)
+	transport.send(t, initializedNotification())
+	transport.send(t, map[string]interface{}{
+		"id":     2,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "watch_file", "arguments": map[string]interface{}{"path": path}},
+	})
+	resp := transport.receive(t)
+	result, _ := resp["result"].(map[string]interface{})
+	if resp["error"] != nil || result["isError"] == true {
+		t.Fatalf("watch_file failed: %v", resp)
+	}
+
+	if err := os.WriteFile(path, []byte("v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := transport.receive(t)
+	if msg["method"] != "notifications/message" {
+		t.Fatalf("expected notifications/message, got %v", msg)
+	}
+	params, _ := msg["params"].(map[string]interface{})
+	data, _ := params["data"].(map[string]interface{})
+	if params["logger"] != "watch_file" || data["event"] != "file_changed" || data["path"] != path {
+		t.Errorf("unexpected file change notification: %v", msg)
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/prompts"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// testServer bundles a server with the repositories backing it
+type testServer struct {
+	srv            *server.Server
+	sessionRepo    *persistence.InMemorySessionRepository
+	toolRepo       *persistence.InMemoryToolRepository
+	events         *persistence.InMemoryEventRepository
+	toolHandler    *handlers.ToolHandler
+	registry       *tools.ToolRegistry
+	sessionHandler *handlers.SessionHandler
+	subscriptions  *persistence.InMemoryResourceSubscriptionRepository
+}
+
+// newTestServer builds a server wired with in-memory repositories and the built-in tools.
+// Options may adjust the configuration before the server is created.
+func newTestServer(t testing.TB, opts ...func(*config.Config)) *testServer {
+	t.Helper()
+	return newLoggedTestServer(t, zerolog.Nop(), opts...)
+}
+
+// newLoggedTestServer builds a test server that logs to logger
+func newLoggedTestServer(t testing.TB, logger zerolog.Logger, opts ...func(*config.Config)) *testServer {
+	t.Helper()
+	ctx := context.Background()
+
+	cfg := config.DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	conversationRepo := persistence.NewInMemoryConversationRepository()
+	toolRepo := persistence.NewInMemoryToolRepository()
+	subscriptionRepo := persistence.NewInMemoryResourceSubscriptionRepository()
+	eventRepo := persistence.NewInMemoryEventRepository(100)
+	claude := mocks.NewMockClaudeService()
+
+	sessionHandler := handlers.NewSessionHandler(sessionRepo, eventRepo)
+	sessionHandler.SetSubscriptionRepository(subscriptionRepo)
+	toolHandler := handlers.NewToolHandler(sessionRepo, toolRepo, eventRepo)
+	conversationHandler := handlers.NewConversationHandler(sessionRepo, conversationRepo, claude, eventRepo)
+
+	registry := tools.NewToolRegistry(claude)
+	for _, tool := range registry.GetTools() {
+		if err := toolRepo.Register(ctx, tool); err != nil {
+			t.Fatalf("failed to register tool: %v", err)
+		}
+		toolHandler.RegisterToolHandler(tool.Name().String(), tool.Handler())
+	}
+
+	srv := server.NewServer(cfg, logger, sessionHandler, toolHandler, conversationHandler)
+	srv.SetResourceHandler(handlers.NewResourceHandler(sessionRepo, subscriptionRepo))
+	builtinPrompts, err := prompts.BuiltinPrompts()
+	if err != nil {
+		t.Fatalf("failed to load prompts: %v", err)
+	}
+	srv.SetPrompts(builtinPrompts)
+	srv.SetEventRepository(eventRepo)
+	return &testServer{
+		srv:            srv,
+		sessionRepo:    sessionRepo,
+		toolRepo:       toolRepo,
+		events:         eventRepo,
+		toolHandler:    toolHandler,
+		registry:       registry,
+		sessionHandler: sessionHandler,
+		subscriptions:  subscriptionRepo,
+	}
+}
+
+// call sends the given requests through the stdio transport and returns the
+// decoded responses, ignoring notifications. The server stops at EOF, so call can be used once per server.
+func (ts *testServer) call(t *testing.T, requests ...map[string]interface{}) []JSONRPCResponse {
+	t.Helper()
+
+	var in strings.Builder
+	for _, req := range requests {
+		if _, ok := req["jsonrpc"]; !ok {
+			req["jsonrpc"] = "2.0"
+		}
+		data, err := json.Marshal(req)
+		if err != nil {
+			t.Fatalf("failed to marshal request: %v", err)
+		}
+		in.Write(data)
+		in.WriteByte('\n')
+	}
+
+	var out bytes.Buffer
+	ts.srv.SetIO(strings.NewReader(in.String()), &out)
+	_ = ts.srv.Run(context.Background())
+	ts.srv.Stop()
+
+	var responses []JSONRPCResponse
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		// Skip server notifications such as the shutdown notice sent by Stop
+		var envelope struct {
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(raw, &envelope); err == nil && envelope.Method != "" {
+			continue
+		}
+		var resp JSONRPCResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// initializeRequest returns a standard initialize request
+func initializeRequest(id int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "initialize",
+		"params": map[string]interface{}{
+			"protocolVersion": "2024-11-05",
+			"capabilities":    map[string]interface{}{},
+			"clientInfo": map[string]interface{}{
+				"name":    "test-client",
+				"version": "1.0.0",
+			},
+		},
+	}
+}
+
+// initializedNotification returns the notification that completes the handshake
+func initializedNotification() map[string]interface{} {
+	return map[string]interface{}{
+		"method": "notifications/initialized",
+	}
+}
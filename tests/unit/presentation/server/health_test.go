@@ -0,0 +1,52 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func TestMCPServer_HealthResource(t *testing.T) {
+	ts := newTestServer(t)
+	ts.srv.RegisterHealthCheck("queue", func() server.HealthStatus {
+		return server.HealthStatus{
+			Status:  server.HealthStatusDegraded,
+			Details: map[string]interface{}{"state": "degraded"},
+		}
+	})
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "resources/read",
+			"params": map[string]interface{}{"uri": server.HealthResourceURI},
+		},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("unexpected error: %+v", responses[1].Error)
+	}
+
+	result := responses[1].Result.(map[string]interface{})
+	contents := result["contents"].([]interface{})
+	text := contents[0].(map[string]interface{})["text"].(string)
+
+	var health map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &health); err != nil {
+		t.Fatalf("failed to decode health: %v", err)
+	}
+	if health["status"] != server.HealthStatusDegraded {
+		t.Errorf("status = %v, want degraded", health["status"])
+	}
+	components := health["components"].(map[string]interface{})
+	queueHealth := components["queue"].(map[string]interface{})
+	if queueHealth["status"] != server.HealthStatusDegraded {
+		t.Errorf("queue status = %v, want degraded", queueHealth["status"])
+	}
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+// registerCancellableTool registers a tool that runs until its context is cancelled
+func registerCancellableTool(t *testing.T, ts *testServer, started chan<- struct{}) {
+	t.Helper()
+	name, _ := vo.NewToolName("slow")
+	desc, _ := vo.NewToolDescription("Runs until cancelled")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetTimeout(time.Minute)
+	tool.SetContextHandler(func(ctx context.Context, _ map[string]interface{}) (*entities.ToolResult, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startConcurrentSession starts an initialized session whose requests run on a worker pool
+func startConcurrentSession(t *testing.T, opts ...func(*config.Config)) (*testServer, *fakeTransport) {
+	t.Helper()
+	opts = append(opts, func(cfg *config.Config) { cfg.Server.MaxConcurrentRequests = 2 })
+	ts := newTestServer(t, opts...)
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	transport.send(t, initializeRequest(1))
+	transport.receive(t)
+	transport.send(t, initializedNotification())
+	return ts, transport
+}
+
+// receiveByID reads n responses and indexes them by id
+func receiveByID(t *testing.T, transport *fakeTransport, n int) map[float64]map[string]interface{} {
+	t.Helper()
+	byID := make(map[float64]map[string]interface{})
+	for len(byID) < n {
+		msg := transport.receive(t)
+		if id, ok := msg["id"].(float64); ok {
+			byID[id] = msg
+		}
+	}
+	return byID
+}
+
+func TestMCPServer_ListAndCancelInflightRequest(t *testing.T) {
+	ts, transport := startConcurrentSession(t, enableAdminMethods)
+	started := make(chan struct{}, 1)
+	registerCancellableTool(t, ts, started)
+
+	transport.send(t, callToolRequest(2, "slow"))
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("tool was not called")
+	}
+
+	transport.send(t, map[string]interface{}{"id": 3, "method": "admin/listRequests"})
+	list := transport.receive(t)
+	result, _ := list["result"].(map[string]interface{})
+	requests, _ := result["requests"].([]interface{})
+	var found map[string]interface{}
+	for _, r := range requests {
+		if req, _ := r.(map[string]interface{}); req["id"] == float64(2) {
+			found = req
+		}
+	}
+	if found == nil {
+		t.Fatalf("slow request not listed: %v", list)
+	}
+	if found["method"] != "tools/call" || found["sessionId"] != ts.srv.Session().ID().String() {
+		t.Errorf("listed request = %v", found)
+	}
+	if _, ok := found["elapsedMs"].(float64); !ok {
+		t.Errorf("listed request has no elapsedMs: %v", found)
+	}
+
+	transport.send(t, map[string]interface{}{"id": 4, "method": "admin/cancelRequest", "params": map[string]interface{}{"id": 2}})
+	responses := receiveByID(t, transport, 2)
+	cancelled, _ := responses[4]["result"].(map[string]interface{})
+	if cancelled["cancelled"] != true || cancelled["method"] != "tools/call" {
+		t.Errorf("cancelRequest result = %v", responses[4])
+	}
+	callErr, _ := responses[2]["error"].(map[string]interface{})
+	if callErr["code"] != float64(vo.ErrorCodeCancelled) {
+		t.Errorf("cancelled call response = %v, want cancelled error", responses[2])
+	}
+
+	// The request is gone once it has been answered
+	transport.send(t, map[string]interface{}{"id": 5, "method": "admin/cancelRequest", "params": map[string]interface{}{"id": 2}})
+	missing, _ := transport.receive(t)["error"].(map[string]interface{})
+	if missing["code"] != float64(vo.ErrorCodeInvalidParams) {
+		t.Errorf("cancelling a finished request: error = %v, want invalid params", missing)
+	}
+}
+
+func TestMCPServer_CancelledNotificationDropsResponse(t *testing.T) {
+	ts, transport := startConcurrentSession(t)
+	started := make(chan struct{}, 1)
+	registerCancellableTool(t, ts, started)
+
+	transport.send(t, callToolRequest(2, "slow"))
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("tool was not called")
+	}
+	transport.send(t, map[string]interface{}{
+		"method": "notifications/cancelled",
+		"params": map[string]interface{}{"requestId": 2, "reason": "user aborted"},
+	})
+	transport.send(t, map[string]interface{}{"id": 3, "method": "ping"})
+
+	for _, msg := range transport.collect(t, 200*time.Millisecond) {
+		if msg["id"] == float64(2) {
+			t.Errorf("cancelled request was answered: %v", msg)
+		}
+	}
+}
+
+func TestMCPServer_InflightAdminMethodsDisabledByDefault(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "admin/listRequests"},
+		map[string]interface{}{"id": 3, "method": "admin/cancelRequest", "params": map[string]interface{}{"id": 1}},
+	)
+	for _, resp := range responses[1:] {
+		if resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeMethodNotFound) {
+			t.Errorf("response %v: error = %+v, want method not found", resp.ID, resp.Error)
+		}
+	}
+}
+
+// initializeWebSocketClient initializes a session over client and returns its id
+func initializeWebSocketClient(t *testing.T, client *wsClient) string {
+	t.Helper()
+	if msg := client.call(t, initializeRequest(1)); msg["error"] != nil {
+		t.Fatalf("initialize failed: %v", msg)
+	}
+	client.send(t, initializedNotification())
+	metrics := client.call(t, map[string]interface{}{"id": 100, "method": "session/metrics"})
+	result, _ := metrics["result"].(map[string]interface{})
+	id, _ := result["sessionId"].(string)
+	if id == "" {
+		t.Fatalf("unexpected session/metrics response: %v", metrics)
+	}
+	return id
+}
+
+func TestMCPServer_CancellationIsScopedToTheConnection(t *testing.T) {
+	ts, baseURL := startWebSocketServer(t, enableAdminMethods, func(cfg *config.Config) {
+		cfg.Server.MaxConcurrentRequests = 4
+	})
+	started := make(chan struct{}, 2)
+	registerCancellableTool(t, ts, started)
+
+	a, b, admin := connectWebSocket(t, baseURL), connectWebSocket(t, baseURL), connectWebSocket(t, baseURL)
+	initializeWebSocketClient(t, a)
+	sessionB := initializeWebSocketClient(t, b)
+	initializeWebSocketClient(t, admin)
+
+	// Both clients run a request with the same id
+	a.send(t, callToolRequest(2, "slow"))
+	b.send(t, callToolRequest(2, "slow"))
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatal("tool was not called")
+		}
+	}
+
+	// Without a session the id is ambiguous
+	msg := admin.call(t, map[string]interface{}{"id": 3, "method": "admin/cancelRequest", "params": map[string]interface{}{"id": 2}})
+	if rpcErr, _ := msg["error"].(map[string]interface{}); rpcErr["code"] != float64(vo.ErrorCodeInvalidParams) {
+		t.Errorf("ambiguous cancelRequest = %v, want invalid params", msg)
+	}
+
+	// A cancels its own request only
+	a.send(t, map[string]interface{}{
+		"method": "notifications/cancelled",
+		"params": map[string]interface{}{"requestId": 2},
+	})
+	if msg := a.call(t, map[string]interface{}{"id": 4, "method": "ping"}); msg["error"] != nil {
+		t.Fatalf("ping failed: %v", msg)
+	}
+
+	// B's request is still running, so the administrator can cancel it by session
+	msg = admin.call(t, map[string]interface{}{
+		"id":     5,
+		"method": "admin/cancelRequest",
+		"params": map[string]interface{}{"id": 2, "sessionId": sessionB},
+	})
+	result, _ := msg["result"].(map[string]interface{})
+	if result["cancelled"] != true || result["sessionId"] != sessionB {
+		t.Fatalf("cancelRequest for B = %v", msg)
+	}
+	callErr, _ := b.next(t)["error"].(map[string]interface{})
+	if callErr["code"] != float64(vo.ErrorCodeCancelled) {
+		t.Errorf("B's cancelled call error = %v, want cancelled", callErr)
+	}
+}
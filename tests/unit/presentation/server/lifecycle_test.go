@@ -0,0 +1,97 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+func TestMCPServer_RejectsCallsBeforeInitialized(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		map[string]interface{}{"id": 1, "method": "tools/list"},
+		initializeRequest(2),
+		map[string]interface{}{"id": 3, "method": "tools/list"},
+		map[string]interface{}{"id": 4, "method": "ping"},
+	)
+
+	if len(responses) != 4 {
+		t.Fatalf("expected 4 responses, got %d", len(responses))
+	}
+
+	for _, i := range []int{0, 2} {
+		resp := responses[i]
+		if resp.Error == nil {
+			t.Fatalf("response %v: expected error before initialized notification", resp.ID)
+		}
+		if resp.Error.Code != int(vo.ErrorCodeInvalidRequest) {
+			t.Errorf("response %v: error code = %d, want %d", resp.ID, resp.Error.Code, vo.ErrorCodeInvalidRequest)
+		}
+	}
+
+	if responses[1].Error != nil {
+		t.Errorf("initialize should succeed: %+v", responses[1].Error)
+	}
+	if responses[3].Error != nil {
+		t.Errorf("ping should be allowed before ready: %+v", responses[3].Error)
+	}
+
+	if state := ts.srv.Session().State(); state != aggregates.SessionStateInitializing {
+		t.Errorf("session state = %s, want %s", state, aggregates.SessionStateInitializing)
+	}
+}
+
+func TestMCPServer_InitializedNotificationMarksReady(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "tools/list"},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Errorf("tools/list should succeed once ready: %+v", responses[1].Error)
+	}
+
+	if state := ts.srv.Session().State(); state != aggregates.SessionStateReady {
+		t.Errorf("session state = %s, want %s", state, aggregates.SessionStateReady)
+	}
+}
+
+func TestMCPServer_InitializeAtCapacity(t *testing.T) {
+	ts := newTestServer(t)
+	ts.sessionHandler.SetMaxSessions(1)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializeRequest(2),
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[0].Error != nil {
+		t.Fatalf("first initialize should succeed: %+v", responses[0].Error)
+	}
+
+	resp := responses[1]
+	if resp.Error == nil {
+		t.Fatal("expected second initialize to be rejected")
+	}
+	if resp.Error.Code != int(vo.ErrorCodeServerAtCapacity) {
+		t.Errorf("error code = %d, want %d", resp.Error.Code, vo.ErrorCodeServerAtCapacity)
+	}
+	data, ok := resp.Error.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected retry hint in error data, got %T", resp.Error.Data)
+	}
+	if data["retryAfterSeconds"] == nil {
+		t.Error("expected retryAfterSeconds in error data")
+	}
+}
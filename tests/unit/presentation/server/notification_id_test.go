@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// callWithNotificationID completes the handshake with an initialized notification that
+// carries an id, then lists tools, returning the responses keyed by id
+func callWithNotificationID(t *testing.T, policy string) map[float64]JSONRPCResponse {
+	t.Helper()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.NotificationIDPolicy = policy })
+
+	initialized := initializedNotification()
+	initialized["id"] = 2
+	responses := ts.call(t,
+		initializeRequest(1),
+		initialized,
+		map[string]interface{}{"id": 3, "method": "tools/list"},
+	)
+
+	byID := make(map[float64]JSONRPCResponse)
+	for _, resp := range responses {
+		id, _ := resp.ID.(float64)
+		byID[id] = resp
+	}
+	return byID
+}
+
+func TestMCPServer_NotificationWithID_RequestPolicy(t *testing.T) {
+	responses := callWithNotificationID(t, server.NotificationIDPolicyRequest)
+
+	resp, ok := responses[2]
+	if !ok {
+		t.Fatal("expected a reply to the notification sent with an id")
+	}
+	if resp.Error != nil || resp.Result == nil {
+		t.Errorf("expected an empty result, got result %v, error %+v", resp.Result, resp.Error)
+	}
+	if responses[3].Error != nil {
+		t.Errorf("notification should still be handled, tools/list failed: %+v", responses[3].Error)
+	}
+}
+
+func TestMCPServer_NotificationWithID_ErrorPolicy(t *testing.T) {
+	responses := callWithNotificationID(t, server.NotificationIDPolicyError)
+
+	resp, ok := responses[2]
+	if !ok {
+		t.Fatal("expected a reply to the notification sent with an id")
+	}
+	if resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeInvalidRequest) {
+		t.Fatalf("expected an invalid request error, got %+v", resp.Error)
+	}
+	if responses[3].Error == nil {
+		t.Error("a rejected initialized notification must not make the session ready")
+	}
+}
+
+func TestMCPServer_NotificationWithID_IgnorePolicy(t *testing.T) {
+	responses := callWithNotificationID(t, server.NotificationIDPolicyIgnore)
+
+	if resp, ok := responses[2]; ok {
+		t.Errorf("expected no reply to the notification, got %+v", resp)
+	}
+	if responses[3].Error != nil {
+		t.Errorf("notification should still be handled, tools/list failed: %+v", responses[3].Error)
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMCPServer_Ping(t *testing.T) {
+	ts := newTestServer(t)
+
+	before := time.Now().UTC()
+	responses := ts.call(t,
+		map[string]interface{}{"id": 1, "method": "ping"},
+		map[string]interface{}{"id": 2, "method": "ping", "params": map[string]interface{}{}},
+		map[string]interface{}{
+			"id":     3,
+			"method": "ping",
+			"params": map[string]interface{}{"nonce": "abc123", "clientTime": 1700000000123},
+		},
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	for _, resp := range responses {
+		if resp.Error != nil {
+			t.Fatalf("response %v: unexpected error %+v", resp.ID, resp.Error)
+		}
+	}
+
+	// Pings without a payload keep returning an empty object
+	for _, resp := range responses[:2] {
+		result, ok := resp.Result.(map[string]interface{})
+		if !ok || len(result) != 0 {
+			t.Errorf("response %v: expected empty object, got %#v", resp.ID, resp.Result)
+		}
+	}
+
+	result, ok := responses[2].Result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected object result, got %T", responses[2].Result)
+	}
+	if result["nonce"] != "abc123" {
+		t.Errorf("nonce = %v, want abc123", result["nonce"])
+	}
+	if result["clientTime"] != float64(1700000000123) {
+		t.Errorf("clientTime = %v, want 1700000000123", result["clientTime"])
+	}
+
+	serverTime, err := time.Parse(time.RFC3339Nano, result["serverTime"].(string))
+	if err != nil {
+		t.Fatalf("serverTime is not RFC 3339: %v", err)
+	}
+	if serverTime.Before(before.Add(-time.Second)) {
+		t.Errorf("serverTime %s is before the request was sent", serverTime)
+	}
+}
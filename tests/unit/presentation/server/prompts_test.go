@@ -0,0 +1,238 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+func TestMCPServer_PromptsListIncludesArguments(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "prompts/list"},
+	)
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("unexpected error: %+v", responses[1].Error)
+	}
+
+	result, _ := responses[1].Result.(map[string]interface{})
+	list, _ := result["prompts"].([]interface{})
+
+	var codeReview map[string]interface{}
+	var names []string
+	for _, item := range list {
+		prompt, _ := item.(map[string]interface{})
+		names = append(names, prompt["name"].(string))
+		if prompt["name"] == "code_review" {
+			codeReview = prompt
+		}
+	}
+	if codeReview == nil {
+		t.Fatalf("code_review not listed in %v", names)
+	}
+	if want := "code_review,debug_help,explain_code"; strings.Join(names, ",") != want {
+		t.Errorf("prompts = %v, want %s in name order", names, want)
+	}
+	if codeReview["description"] == "" {
+		t.Error("code_review should have a description")
+	}
+
+	args, ok := codeReview["arguments"].([]interface{})
+	if !ok || len(args) != 2 {
+		t.Fatalf("arguments = %v, want code and language", codeReview["arguments"])
+	}
+	want := []struct {
+		name     string
+		required bool
+	}{
+		{"code", true},
+		{"language", false},
+	}
+	for i, w := range want {
+		arg, _ := args[i].(map[string]interface{})
+		if arg["name"] != w.name {
+			t.Errorf("argument %d name = %v, want %s", i, arg["name"], w.name)
+		}
+		if required, ok := arg["required"].(bool); !ok || required != w.required {
+			t.Errorf("argument %s required = %v, want %v", w.name, arg["required"], w.required)
+		}
+		if arg["description"] == nil || arg["description"] == "" {
+			t.Errorf("argument %s should have a description", w.name)
+		}
+	}
+}
+
+func TestMCPServer_PromptsGetRendersArguments(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "prompts/get",
+			"params": map[string]interface{}{
+				"name":      "code_review",
+				"arguments": map[string]interface{}{"code": "fmt.Println(1)", "language": "go"},
+			},
+		},
+		map[string]interface{}{
+			"id":     3,
+			"method": "prompts/get",
+			"params": map[string]interface{}{"name": "code_review", "arguments": map[string]interface{}{"language": "go"}},
+		},
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+
+	if responses[1].Error != nil {
+		t.Fatalf("unexpected error: %+v", responses[1].Error)
+	}
+	result, _ := responses[1].Result.(map[string]interface{})
+	messages, _ := result["messages"].([]interface{})
+	if len(messages) != 1 {
+		t.Fatalf("messages = %v, want one message", result["messages"])
+	}
+	content, _ := messages[0].(map[string]interface{})["content"].(map[string]interface{})
+	text, _ := content["text"].(string)
+	if !strings.Contains(text, "```go\nfmt.Println(1)\n```") {
+		t.Errorf("rendered prompt = %q", text)
+	}
+
+	if responses[2].Error == nil {
+		t.Error("expected an error when the required code argument is missing")
+	}
+}
+
+func TestMCPServer_PromptsDisabled(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.EnablePrompts = false })
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "prompts/list"},
+	)
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	result, _ := responses[1].Result.(map[string]interface{})
+	if list, _ := result["prompts"].([]interface{}); len(list) != 0 {
+		t.Errorf("prompts = %v, want none when prompts are disabled", list)
+	}
+}
+
+func TestMCPServer_PromptsGetPreviewWithMissingArguments(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "prompts/get",
+			"params": map[string]interface{}{
+				"name":      "code_review",
+				"arguments": map[string]interface{}{"language": "go"},
+				"preview":   true,
+			},
+		},
+		map[string]interface{}{
+			"id":     3,
+			"method": "prompts/get",
+			"params": map[string]interface{}{"name": "debug_help", "preview": true},
+		},
+		map[string]interface{}{
+			"id":     4,
+			"method": "prompts/get",
+			"params": map[string]interface{}{"name": "code_review", "arguments": map[string]interface{}{"language": "go"}},
+		},
+	)
+	if len(responses) != 4 {
+		t.Fatalf("expected 4 responses, got %d", len(responses))
+	}
+
+	previewText := func(resp JSONRPCResponse) (string, map[string]interface{}) {
+		t.Helper()
+		if resp.Error != nil {
+			t.Fatalf("preview failed: %+v", resp.Error)
+		}
+		result, _ := resp.Result.(map[string]interface{})
+		meta, _ := result["_meta"].(map[string]interface{})
+		messages, _ := result["messages"].([]interface{})
+		if len(messages) != 1 {
+			t.Fatalf("messages = %v, want one message", result["messages"])
+		}
+		content, _ := messages[0].(map[string]interface{})["content"].(map[string]interface{})
+		text, _ := content["text"].(string)
+		return text, meta
+	}
+
+	text, meta := previewText(responses[1])
+	if !strings.Contains(text, "```go\n{{code}}\n```") {
+		t.Errorf("preview should keep the missing code placeholder: %q", text)
+	}
+	if meta["preview"] != true {
+		t.Errorf("_meta = %v, want preview: true", meta)
+	}
+	if missing := stringList(meta["missingArguments"]); len(missing) != 1 || missing[0] != "code" {
+		t.Errorf("missingArguments = %v, want [code]", missing)
+	}
+
+	text, meta = previewText(responses[2])
+	if !strings.Contains(text, "Error: {{error}}") || strings.Contains(text, "Context:") {
+		t.Errorf("debug_help preview = %q, want the error marker and no context block", text)
+	}
+	if missing := stringList(meta["missingArguments"]); len(missing) != 2 {
+		t.Errorf("missingArguments = %v, want error and context", missing)
+	}
+
+	// Without the flag a missing required argument still fails
+	if responses[3].Error == nil {
+		t.Error("expected an error without preview when code is missing")
+	}
+}
+
+func TestMCPServer_PromptsGetPreviewUnsupported(t *testing.T) {
+	fake := newFakeTransport()
+	defer close(fake.incoming)
+	ts := newTestServer(t)
+	ts.srv.SetTransport(fake)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	fake.send(t, initializeRequest(1))
+	fake.receive(t)
+	fake.send(t, initializedNotification())
+
+	generated := false
+	name, _ := vo.NewToolName("deploy")
+	prompt, _ := entities.NewPrompt(name, "Deploys when rendered")
+	prompt.SetGenerator(func(args map[string]string) (*entities.PromptMessages, error) {
+		generated = true
+		return &entities.PromptMessages{}, nil
+	})
+	ts.srv.Session().RegisterPrompt(prompt)
+
+	fake.send(t, map[string]interface{}{
+		"id":     2,
+		"method": "prompts/get",
+		"params": map[string]interface{}{"name": "deploy", "preview": true},
+	})
+	resp := fake.receive(t)
+	errObj, _ := resp["error"].(map[string]interface{})
+	if errObj == nil || errObj["code"] != float64(vo.ErrorCodeInvalidParams) {
+		t.Fatalf("preview of a prompt without a preview generator = %v, want invalid params", resp)
+	}
+	if generated {
+		t.Error("preview ran the prompt generator")
+	}
+}
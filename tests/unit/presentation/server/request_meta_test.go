@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// registerMetaProbe registers a tool that records the request _meta its handler sees
+func registerMetaProbe(t *testing.T, ts *testServer) chan *entities.RequestMeta {
+	t.Helper()
+	seen := make(chan *entities.RequestMeta, 1)
+	name, _ := vo.NewToolName("meta_probe")
+	description, _ := vo.NewToolDescription("Records the request meta")
+	tool, err := entities.NewTool(name, description, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetContextHandler(func(ctx context.Context, _ map[string]interface{}) (*entities.ToolResult, error) {
+		meta, _ := entities.RequestMetaFromContext(ctx)
+		seen <- meta
+		return &entities.ToolResult{Content: []entities.ToolResultContent{{Type: "text", Text: "ok"}}}, nil
+	})
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+	return seen
+}
+
+func TestMCPServer_RequestMetaInHandlerContext(t *testing.T) {
+	ts := newTestServer(t)
+	seen := registerMetaProbe(t, ts)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "tools/call",
+			"params": map[string]interface{}{
+				"name": "meta_probe",
+				"_meta": map[string]interface{}{
+					"progressToken": "tok-1",
+					"timeoutMs":     5000,
+					"correlationId": "corr-42",
+					"traceparent":   "00-abc-def-01",
+				},
+			},
+		},
+	)
+	if len(responses) != 2 || responses[1].Error != nil {
+		t.Fatalf("tools/call failed: %+v", responses)
+	}
+
+	meta := <-seen
+	if meta == nil {
+		t.Fatal("handler context carried no request meta")
+	}
+	if meta.ProgressToken != "tok-1" || meta.CorrelationID != "corr-42" || meta.TimeoutMs == nil || *meta.TimeoutMs != 5000 {
+		t.Errorf("meta = %+v", meta)
+	}
+	unknown := meta.Unknown()
+	var traceparent string
+	if err := json.Unmarshal(unknown["traceparent"], &traceparent); err != nil || traceparent != "00-abc-def-01" || len(unknown) != 1 {
+		t.Errorf("unknown meta = %v, want traceparent passed through", unknown)
+	}
+}
+
+func TestMCPServer_RequestWithoutMeta(t *testing.T) {
+	ts := newTestServer(t)
+	seen := registerMetaProbe(t, ts)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "tools/call", "params": map[string]interface{}{"name": "meta_probe"}},
+	)
+	if len(responses) != 2 || responses[1].Error != nil {
+		t.Fatalf("tools/call failed: %+v", responses)
+	}
+	if meta := <-seen; meta != nil {
+		t.Errorf("meta = %+v, want none for a request without _meta", meta)
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func TestMCPServer_ResourceAnnotationsRoundTrip(t *testing.T) {
+	fake := newFakeTransport()
+	defer close(fake.incoming)
+	ts := newTestServer(t)
+	ts.srv.SetTransport(fake)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	fake.send(t, initializeRequest(1))
+	fake.receive(t)
+	fake.send(t, initializedNotification())
+
+	uri, _ := vo.NewResourceURI("file:///notes.txt")
+	resource, _ := entities.NewResource(uri, "Notes")
+	resource.SetReader(func(uri string) (*entities.ResourceContent, error) {
+		return &entities.ResourceContent{URI: uri, MimeType: "text/plain", Text: "remember"}, nil
+	})
+	if err := resource.SetAudience(entities.ResourceAudienceAssistant); err != nil {
+		t.Fatal(err)
+	}
+	// A priority of 0 is meaningful and must not be dropped
+	if err := resource.SetPriority(0); err != nil {
+		t.Fatal(err)
+	}
+	ts.srv.Session().RegisterResource(resource)
+
+	fake.send(t, map[string]interface{}{"id": 2, "method": "resources/list"})
+	listed := fake.receive(t)["result"].(map[string]interface{})["resources"].([]interface{})
+	byURI := map[string]map[string]interface{}{}
+	for _, r := range listed {
+		entry := r.(map[string]interface{})
+		uri, _ := entry["uri"].(string)
+		byURI[uri] = entry
+	}
+	assertAnnotations(t, "list notes", byURI["file:///notes.txt"], []interface{}{"assistant"}, 0)
+	assertAnnotations(t, "list health", byURI[server.HealthResourceURI], []interface{}{"user", "assistant"}, 0.8)
+
+	fake.send(t, map[string]interface{}{"id": 3, "method": "resources/read", "params": map[string]interface{}{"uri": "file:///notes.txt"}})
+	resp := fake.receive(t)
+	if resp["error"] != nil {
+		t.Fatalf("resources/read failed: %v", resp["error"])
+	}
+	contents := resp["result"].(map[string]interface{})["contents"].([]interface{})
+	content := contents[0].(map[string]interface{})
+	if content["text"] != "remember" {
+		t.Errorf("text = %v", content["text"])
+	}
+	assertAnnotations(t, "read notes", content, []interface{}{"assistant"}, 0)
+}
+
+func assertAnnotations(t *testing.T, what string, entry map[string]interface{}, audience []interface{}, priority float64) {
+	t.Helper()
+	annotations, ok := entry["annotations"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s: no annotations in %v", what, entry)
+	}
+	got, _ := annotations["audience"].([]interface{})
+	if len(got) != len(audience) {
+		t.Errorf("%s: audience = %v, want %v", what, got, audience)
+	}
+	for i := range got {
+		if i < len(audience) && got[i] != audience[i] {
+			t.Errorf("%s: audience = %v, want %v", what, got, audience)
+		}
+	}
+	if p, ok := annotations["priority"].(float64); !ok || p != priority {
+		t.Errorf("%s: priority = %v, want %v", what, annotations["priority"], priority)
+	}
+}
+
+func TestResourceAnnotationsValidation(t *testing.T) {
+	uri, _ := vo.NewResourceURI("file:///notes.txt")
+	resource, _ := entities.NewResource(uri, "Notes")
+
+	if err := resource.SetAudience("robot"); !errors.Is(err, entities.ErrInvalidResourceAudience) {
+		t.Errorf("SetAudience(robot) error = %v, want %v", err, entities.ErrInvalidResourceAudience)
+	}
+	for _, priority := range []float64{-0.1, 1.5} {
+		if err := resource.SetPriority(priority); !errors.Is(err, entities.ErrInvalidResourcePriority) {
+			t.Errorf("SetPriority(%v) error = %v, want %v", priority, err, entities.ErrInvalidResourcePriority)
+		}
+	}
+	if resource.Annotations() != nil {
+		t.Errorf("rejected annotations were kept: %+v", resource.Annotations())
+	}
+
+	if err := resource.SetPriority(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := resource.SetAudience(entities.ResourceAudienceUser); err != nil {
+		t.Fatal(err)
+	}
+	annotations := resource.Annotations()
+	if annotations.Priority == nil || *annotations.Priority != 1 || len(annotations.Audience) != 1 {
+		t.Errorf("annotations = %+v, want both setters applied", annotations)
+	}
+}
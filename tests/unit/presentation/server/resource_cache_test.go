@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+)
+
+// fakeFileWatches reports the watches set by the test
+type fakeFileWatches struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+}
+
+func (w *fakeFileWatches) WatchExpiry(sessionID vo.SessionID, path string) (time.Time, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	expiry, ok := w.expires[path]
+	return expiry, ok
+}
+
+func (w *fakeFileWatches) watch(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expires[path] = time.Now().Add(time.Minute)
+}
+
+// cacheRecorder records the cache outcome of each resource read
+type cacheRecorder struct {
+	mu   sync.Mutex
+	hits []bool
+}
+
+func (r *cacheRecorder) RecordResourceRead(ctx context.Context, uri string, cacheHit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hits = append(r.hits, cacheHit)
+}
+
+// last returns the cache outcome of the latest read
+func (r *cacheRecorder) last() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hits[len(r.hits)-1]
+}
+
+// cacheFixture is a running server whose session has a file-backed resource
+type cacheFixture struct {
+	ts        *testServer
+	transport *fakeTransport
+	path      string
+	uri       string
+	watches   *fakeFileWatches
+	metrics   *cacheRecorder
+	nextID    int
+}
+
+func newCacheFixture(t *testing.T) *cacheFixture {
+	t.Helper()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.ResourceUpdateDebounce = 0 })
+	f := &cacheFixture{
+		ts:        ts,
+		transport: newFakeTransport(),
+		path:      filepath.Join(t.TempDir(), "app.log"),
+		watches:   &fakeFileWatches{expires: make(map[string]time.Time)},
+		metrics:   &cacheRecorder{},
+		nextID:    100,
+	}
+	f.uri = "file://" + f.path
+	ts.srv.SetFileWatches(f.watches)
+	ts.srv.SetResourceCacheMetrics(f.metrics)
+	ts.srv.SetTransport(f.transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(f.transport.incoming) })
+
+	f.transport.send(t, initializeRequest(1))
+	f.transport.receive(t)
+	f.transport.send(t, initializedNotification())
+
+	f.write(t, "v1")
+	uri, err := vo.NewResourceURI(f.uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, err := resources.NewFileResource(uri, "app.log", f.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.srv.Session().RegisterResource(resource)
+	return f
+}
+
+func (f *cacheFixture) write(t *testing.T, content string) {
+	t.Helper()
+	if err := os.WriteFile(f.path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *cacheFixture) request(t *testing.T, method string) {
+	t.Helper()
+	f.nextID++
+	f.transport.send(t, subscribeRequest(f.nextID, method, f.uri))
+	if resp := f.transport.receive(t); resp["error"] != nil {
+		t.Fatalf("%s failed: %v", method, resp)
+	}
+}
+
+// read reads the resource and returns its text and whether it came from the cache
+func (f *cacheFixture) read(t *testing.T) (string, bool) {
+	t.Helper()
+	f.nextID++
+	_, resp := readResource(t, f.transport, f.nextID, f.uri)
+	result, _ := resp["result"].(map[string]interface{})
+	contents, _ := result["contents"].([]interface{})
+	if len(contents) != 1 {
+		t.Fatalf("unexpected read response: %v", resp)
+	}
+	text, _ := contents[0].(map[string]interface{})["text"].(string)
+	return text, f.metrics.last()
+}
+
+func TestMCPServer_ResourceReadCachedWhileWatched(t *testing.T) {
+	f := newCacheFixture(t)
+	f.request(t, "resources/subscribe")
+	f.watches.watch(f.path)
+
+	if text, hit := f.read(t); text != "v1" || hit {
+		t.Fatalf("first read = %q (hit %v), want v1 from disk", text, hit)
+	}
+	// An undetected write is not seen: the second read is served from the cache
+	f.write(t, "v2")
+	if text, hit := f.read(t); text != "v1" || !hit {
+		t.Fatalf("second read = %q (hit %v), want cached v1", text, hit)
+	}
+}
+
+func TestMCPServer_ResourceReadMissesAfterFileChange(t *testing.T) {
+	f := newCacheFixture(t)
+	f.request(t, "resources/subscribe")
+	f.watches.watch(f.path)
+	f.read(t)
+
+	f.write(t, "v2")
+	f.ts.srv.NotifyFileChanged(context.Background(), f.ts.srv.Session().ID(), tools.FileChange{Path: f.path, URI: f.uri, Op: "write"})
+
+	if text, hit := f.read(t); text != "v2" || hit {
+		t.Fatalf("read after change = %q (hit %v), want v2 from disk", text, hit)
+	}
+	if text, hit := f.read(t); text != "v2" || !hit {
+		t.Fatalf("read after re-caching = %q (hit %v), want cached v2", text, hit)
+	}
+}
+
+func TestMCPServer_ResourceUpdateInvalidatesCache(t *testing.T) {
+	f := newCacheFixture(t)
+	f.request(t, "resources/subscribe")
+	f.watches.watch(f.path)
+	f.read(t)
+
+	f.write(t, "v2")
+	if err := f.ts.srv.NotifyResourceUpdated(context.Background(), f.uri); err != nil {
+		t.Fatalf("NotifyResourceUpdated() error = %v", err)
+	}
+	if text, hit := f.read(t); text != "v2" || hit {
+		t.Fatalf("read after update notification = %q (hit %v), want v2 from disk", text, hit)
+	}
+}
+
+func TestMCPServer_ResourceReadBypassesCacheWithoutChangeDetection(t *testing.T) {
+	f := newCacheFixture(t)
+
+	// Watched but not subscribed
+	f.watches.watch(f.path)
+	f.read(t)
+	if _, hit := f.read(t); hit {
+		t.Error("read without a subscription was served from the cache")
+	}
+
+	// A new watch may have missed changes made before it started
+	f.request(t, "resources/subscribe")
+	f.read(t)
+	f.write(t, "v2")
+	f.watches.watch(f.path)
+	if text, hit := f.read(t); text != "v2" || hit {
+		t.Errorf("read under a new watch = %q (hit %v), want v2 from disk", text, hit)
+	}
+
+	// Unsubscribing stops change notifications, so the cached content is dropped
+	f.request(t, "resources/unsubscribe")
+	f.request(t, "resources/subscribe")
+	f.write(t, "v3")
+	if text, hit := f.read(t); text != "v3" || hit {
+		t.Errorf("read after resubscribing = %q (hit %v), want v3 from disk", text, hit)
+	}
+}
+
+func TestMCPServer_FileTemplateReadCachedWhileWatched(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.ResourceUpdateDebounce = 0 })
+	dir := t.TempDir()
+	f := &cacheFixture{
+		ts:        ts,
+		transport: newFakeTransport(),
+		path:      filepath.Join(dir, "app.log"),
+		watches:   &fakeFileWatches{expires: make(map[string]time.Time)},
+		metrics:   &cacheRecorder{},
+		nextID:    100,
+	}
+	f.uri = "file://" + f.path
+	ts.srv.SetFileWatches(f.watches)
+	ts.srv.SetResourceCacheMetrics(f.metrics)
+	ts.srv.SetFileResourceReader(resources.NewResourceHandler([]string{dir}, 0))
+	ts.srv.SetTransport(f.transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(f.transport.incoming) })
+
+	f.transport.send(t, initializeRequest(1))
+	f.transport.receive(t)
+	f.transport.send(t, initializedNotification())
+	f.write(t, "v1")
+
+	f.request(t, "resources/subscribe")
+	f.watches.watch(f.path)
+
+	if text, hit := f.read(t); text != "v1" || hit {
+		t.Fatalf("first read = %q (hit %v), want v1 from disk", text, hit)
+	}
+	if text, hit := f.read(t); text != "v1" || !hit {
+		t.Errorf("second read = %q (hit %v), want v1 from the cache", text, hit)
+	}
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// streamingTransport is a fake transport that, like SSE, accepts streamed results
+type streamingTransport struct {
+	*fakeTransport
+}
+
+func (t *streamingTransport) SupportsStreaming() bool { return true }
+
+// streamFixture starts a server with a small stream threshold over transport, which
+// wraps fake, and registers file-backed resources holding large and small
+func streamFixture(t *testing.T, fake *fakeTransport, transport server.Transport, large, small string) {
+	t.Helper()
+	ts := newTestServer(t, func(cfg *config.Config) {
+		cfg.MCP.ResourceStreamThreshold = 4096
+		cfg.MCP.ResourceStreamChunkSize = 1024
+	})
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+
+	fake.send(t, initializeRequest(1))
+	fake.receive(t)
+	fake.send(t, initializedNotification())
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"large.txt": large, "small.txt": small} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		uri, _ := vo.NewResourceURI("file:///" + name)
+		resource, err := resources.NewFileResource(uri, name, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts.srv.Session().RegisterResource(resource)
+	}
+}
+
+// readResource sends resources/read for uri with a progress token and returns the
+// notifications received before the response, and the response
+func readResource(t *testing.T, transport *fakeTransport, id int, uri string) ([]map[string]interface{}, map[string]interface{}) {
+	t.Helper()
+	transport.send(t, map[string]interface{}{
+		"id":     id,
+		"method": "resources/read",
+		"params": map[string]interface{}{"uri": uri, "_meta": map[string]interface{}{"progressToken": "read-1"}},
+	})
+	var notifications []map[string]interface{}
+	for {
+		msg := transport.receive(t)
+		if msg["id"] == float64(id) {
+			return notifications, msg
+		}
+		notifications = append(notifications, msg)
+	}
+}
+
+func largeResourceText() string {
+	// Three-byte runes so chunk boundaries fall inside runes
+	return strings.Repeat("日本語 text ", 1000)
+}
+
+func TestMCPServer_ResourceReadStreamsLargeFile(t *testing.T) {
+	fake := newFakeTransport()
+	defer close(fake.incoming)
+	large := largeResourceText()
+	streamFixture(t, fake, &streamingTransport{fake}, large, "small")
+
+	notifications, resp := readResource(t, fake, 2, "file:///large.txt")
+	if resp["error"] != nil {
+		t.Fatalf("resources/read failed: %v", resp["error"])
+	}
+	if len(notifications) < 2 {
+		t.Fatalf("got %d notifications, want the content in several chunks", len(notifications))
+	}
+
+	var text strings.Builder
+	var lastProgress float64
+	for i, msg := range notifications {
+		if msg["method"] != "notifications/progress" {
+			t.Fatalf("notification %d = %v, want notifications/progress", i, msg["method"])
+		}
+		params := msg["params"].(map[string]interface{})
+		content := params["content"].(map[string]interface{})
+		if params["progressToken"] != "read-1" || params["total"] != float64(len(large)) {
+			t.Errorf("notification %d params = %v", i, params)
+		}
+		if content["offset"] != float64(text.Len()) {
+			t.Errorf("chunk %d offset = %v, want %d", i, content["offset"], text.Len())
+		}
+		chunk, ok := content["text"].(string)
+		if !ok {
+			t.Fatalf("chunk %d has no text: %v", i, content)
+		}
+		text.WriteString(chunk)
+		progress := params["progress"].(float64)
+		if progress <= lastProgress || progress != float64(text.Len()) {
+			t.Errorf("chunk %d progress = %v after %v", i, progress, lastProgress)
+		}
+		lastProgress = progress
+	}
+	if text.String() != large {
+		t.Error("reassembled chunks differ from the file")
+	}
+	if first := notifications[0]["params"].(map[string]interface{})["content"].(map[string]interface{}); first["mimeType"] != "text/plain" {
+		t.Errorf("first chunk mimeType = %v, want text/plain", first["mimeType"])
+	}
+
+	result := resp["result"].(map[string]interface{})
+	meta, _ := result["_meta"].(map[string]interface{})
+	if meta["streamed"] != true || meta["chunks"] != float64(len(notifications)) || meta["size"] != float64(len(large)) {
+		t.Errorf("final _meta = %v", meta)
+	}
+	contents := result["contents"].([]interface{})
+	if len(contents) != 1 || contents[0].(map[string]interface{})["text"] != nil {
+		t.Errorf("final contents = %v, want the resource without content", contents)
+	}
+}
+
+func TestMCPServer_ResourceReadWholeResponse(t *testing.T) {
+	large := largeResourceText()
+
+	t.Run("small file", func(t *testing.T) {
+		fake := newFakeTransport()
+		defer close(fake.incoming)
+		streamFixture(t, fake, &streamingTransport{fake}, large, "small")
+
+		notifications, resp := readResource(t, fake, 2, "file:///small.txt")
+		assertWholeResource(t, notifications, resp, "small")
+	})
+
+	t.Run("non-streaming transport", func(t *testing.T) {
+		fake := newFakeTransport()
+		defer close(fake.incoming)
+		streamFixture(t, fake, fake, large, "small")
+
+		notifications, resp := readResource(t, fake, 2, "file:///large.txt")
+		assertWholeResource(t, notifications, resp, large)
+	})
+}
+
+func TestMCPServer_ResourceReadStreamsFileTemplate(t *testing.T) {
+	dir, outside := t.TempDir(), t.TempDir()
+	large := largeResourceText()
+	for _, path := range []string{filepath.Join(dir, "large.txt"), filepath.Join(outside, "secret.txt")} {
+		if err := os.WriteFile(path, []byte(large), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fake := newFakeTransport()
+	defer close(fake.incoming)
+	ts := newTestServer(t, func(cfg *config.Config) {
+		cfg.MCP.ResourceStreamThreshold = 4096
+		cfg.MCP.ResourceStreamChunkSize = 1024
+	})
+	ts.srv.SetFileResourceReader(resources.NewResourceHandler([]string{dir}, 0))
+	ts.srv.SetTransport(&streamingTransport{fake})
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	fake.send(t, initializeRequest(1))
+	fake.receive(t)
+	fake.send(t, initializedNotification())
+
+	uri := "file://" + filepath.Join(dir, "large.txt")
+	notifications, resp := readResource(t, fake, 2, uri)
+	if resp["error"] != nil {
+		t.Fatalf("resources/read failed: %v", resp["error"])
+	}
+	if len(notifications) < 2 {
+		t.Fatalf("got %d notifications, want the templated file streamed", len(notifications))
+	}
+	first := notifications[0]["params"].(map[string]interface{})["content"].(map[string]interface{})
+	if first["uri"] != uri {
+		t.Errorf("chunk uri = %v, want the requested %s", first["uri"], uri)
+	}
+	contents := resp["result"].(map[string]interface{})["contents"].([]interface{})
+	if got := contents[0].(map[string]interface{})["uri"]; got != uri {
+		t.Errorf("final contents uri = %v, want %s", got, uri)
+	}
+
+	// A file outside the allowed paths is refused rather than streamed
+	notifications, resp = readResource(t, fake, 3, "file://"+filepath.Join(outside, "secret.txt"))
+	if len(notifications) != 0 {
+		t.Errorf("got %d notifications for a file outside the allowed paths", len(notifications))
+	}
+	if resp["error"] == nil {
+		t.Error("resources/read outside the allowed paths succeeded")
+	}
+}
+
+func assertWholeResource(t *testing.T, notifications []map[string]interface{}, resp map[string]interface{}, want string) {
+	t.Helper()
+	if len(notifications) != 0 {
+		t.Errorf("got %d notifications, want a single response", len(notifications))
+	}
+	if resp["error"] != nil {
+		t.Fatalf("resources/read failed: %v", resp["error"])
+	}
+	contents := resp["result"].(map[string]interface{})["contents"].([]interface{})
+	if text := contents[0].(map[string]interface{})["text"]; text != want {
+		t.Errorf("text has %d bytes, want %d", len(text.(string)), len(want))
+	}
+}
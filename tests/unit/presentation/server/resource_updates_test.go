@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// collect returns the messages written to the transport during d
+func (f *fakeTransport) collect(t *testing.T, d time.Duration) []map[string]interface{} {
+	t.Helper()
+	var msgs []map[string]interface{}
+	deadline := time.After(d)
+	for {
+		select {
+		case data := <-f.outgoing:
+			var msg map[string]interface{}
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("invalid message %q: %v", data, err)
+			}
+			msgs = append(msgs, msg)
+		case <-deadline:
+			return msgs
+		}
+	}
+}
+
+// resourceUpdates returns the changes counts of notifications/resources/updated messages for uri
+func resourceUpdates(msgs []map[string]interface{}, uri string) []float64 {
+	var changes []float64
+	for _, msg := range msgs {
+		if msg["method"] != "notifications/resources/updated" {
+			continue
+		}
+		params, _ := msg["params"].(map[string]interface{})
+		if params["uri"] != uri {
+			continue
+		}
+		meta, _ := params["_meta"].(map[string]interface{})
+		count, _ := meta["changes"].(float64)
+		changes = append(changes, count)
+	}
+	return changes
+}
+
+// startSubscribedServer runs a server with the given debounce window whose session subscribes to uris
+func startSubscribedServer(t *testing.T, window time.Duration, uris ...string) (*testServer, *fakeTransport) {
+	t.Helper()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.ResourceUpdateDebounce = window })
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	transport.send(t, initializeRequest(1))
+	transport.receive(t)
+	transport.send(t, initializedNotification())
+	for i, uri := range uris {
+		transport.send(t, subscribeRequest(100+i, "resources/subscribe", uri))
+		if resp := transport.receive(t); resp["error"] != nil {
+			t.Fatalf("subscribe %s failed: %v", uri, resp)
+		}
+	}
+	return ts, transport
+}
+
+func TestMCPServer_ResourceUpdatesCoalesced(t *testing.T) {
+	ctx := context.Background()
+	window := 200 * time.Millisecond
+	ts, transport := startSubscribedServer(t, window, server.HealthResourceURI, server.ConfigResourceURI)
+
+	for i := 0; i < 5; i++ {
+		if err := ts.srv.NotifyResourceUpdated(ctx, server.HealthResourceURI); err != nil {
+			t.Fatalf("NotifyResourceUpdated() error = %v", err)
+		}
+	}
+	if err := ts.srv.NotifyResourceUpdated(ctx, server.ConfigResourceURI); err != nil {
+		t.Fatalf("NotifyResourceUpdated() error = %v", err)
+	}
+
+	msgs := transport.collect(t, window+300*time.Millisecond)
+	if got := resourceUpdates(msgs, server.HealthResourceURI); len(got) != 1 || got[0] != 5 {
+		t.Errorf("expected one notification covering 5 changes, got %v", got)
+	}
+	if got := resourceUpdates(msgs, server.ConfigResourceURI); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected a separate notification per URI, got %v", got)
+	}
+
+	// A change after the window closed opens a new window and is still delivered
+	if err := ts.srv.NotifyResourceUpdated(ctx, server.HealthResourceURI); err != nil {
+		t.Fatalf("NotifyResourceUpdated() error = %v", err)
+	}
+	msgs = transport.collect(t, window+300*time.Millisecond)
+	if got := resourceUpdates(msgs, server.HealthResourceURI); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected the final change to be delivered, got %v", got)
+	}
+}
+
+func TestMCPServer_ResourceUpdatesFlushedOnStop(t *testing.T) {
+	ts, transport := startSubscribedServer(t, time.Hour, server.HealthResourceURI)
+
+	if err := ts.srv.NotifyResourceUpdated(context.Background(), server.HealthResourceURI); err != nil {
+		t.Fatalf("NotifyResourceUpdated() error = %v", err)
+	}
+	ts.srv.Stop()
+
+	msgs := transport.collect(t, 200*time.Millisecond)
+	if got := resourceUpdates(msgs, server.HealthResourceURI); len(got) != 1 {
+		t.Errorf("expected pending update to be flushed on stop, got %v", got)
+	}
+}
+
+func TestMCPServer_FileChangesCoalesced(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "build.log")
+	if err := os.WriteFile(path, []byte("v0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	uri := "file://" + path
+
+	window := time.Second
+	ts, transport := startSubscribedServer(t, window, uri)
+	ts.registry.FileWatcher().SetNotifier(ts.srv)
+	t.Cleanup(ts.registry.FileWatcher().Close)
+
+	transport.send(t, map[string]interface{}{
+		"id":     2,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "watch_file", "arguments": map[string]interface{}{"path": path}},
+	})
+	if resp := transport.receive(t); resp["error"] != nil {
+		t.Fatalf("watch_file failed: %v", resp)
+	}
+
+	// Spread the writes past the watcher's own debounce so several changes are reported
+	for i := 0; i < 4; i++ {
+		if err := os.WriteFile(path, []byte{byte('a' + i)}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(150 * time.Millisecond)
+	}
+
+	msgs := transport.collect(t, window+500*time.Millisecond)
+	got := resourceUpdates(msgs, uri)
+	if len(got) != 1 {
+		t.Fatalf("expected a single coalesced notification, got %v", got)
+	}
+	if got[0] < 2 {
+		t.Errorf("expected the notification to count several changes, got %v", got[0])
+	}
+}
+
+func TestMCPServer_ResourceUpdatesCoalescedPerSession(t *testing.T) {
+	window := 100 * time.Millisecond
+	ts, baseURL := startWebSocketServer(t, func(cfg *config.Config) { cfg.MCP.ResourceUpdateDebounce = window })
+
+	clients := []*wsClient{connectWebSocket(t, baseURL), connectWebSocket(t, baseURL)}
+	for _, client := range clients {
+		initializeWebSocketClient(t, client)
+		if msg := client.call(t, subscribeRequest(2, "resources/subscribe", server.HealthResourceURI)); msg["error"] != nil {
+			t.Fatalf("subscribe failed: %v", msg)
+		}
+	}
+
+	// Every subscribed session gets its own notification, not only the last one added
+	if err := ts.srv.NotifyResourceUpdated(context.Background(), server.HealthResourceURI); err != nil {
+		t.Fatalf("NotifyResourceUpdated() error = %v", err)
+	}
+	for i, client := range clients {
+		msg := client.next(t)
+		params, _ := msg["params"].(map[string]interface{})
+		if msg["method"] != "notifications/resources/updated" || params["uri"] != server.HealthResourceURI {
+			t.Errorf("client %d: expected resources/updated for %s, got %v", i, server.HealthResourceURI, msg)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+// registerLargeOutputTool registers a tool returning size bytes of text
+func registerLargeOutputTool(t *testing.T, ts *testServer, size int) {
+	t.Helper()
+	name, _ := vo.NewToolName("large_output")
+	desc, _ := vo.NewToolDescription("Returns a large result")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetHandler(func(input map[string]interface{}) (*entities.ToolResult, error) {
+		return entities.NewTextToolResult(strings.Repeat("x", size)), nil
+	})
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func callLargeOutput(id int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "large_output", "arguments": map[string]interface{}{}},
+	}
+}
+
+func TestMCPServer_OversizedResponseReplacedWithError(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.MaxResponseBytes = 4096 })
+	registerLargeOutputTool(t, ts, 8192)
+
+	responses := ts.call(t, initializeRequest(1), initializedNotification(), callLargeOutput(2))
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+
+	resp := responses[1]
+	if resp.Result != nil {
+		t.Fatal("oversized result must not be sent")
+	}
+	if resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeResponseTooLarge) {
+		t.Fatalf("expected response too large error, got %+v", resp.Error)
+	}
+	if id, _ := resp.ID.(float64); id != 2 {
+		t.Errorf("error must answer the original request id, got %v", resp.ID)
+	}
+	if !strings.Contains(resp.Error.Message, "pagination") {
+		t.Errorf("error should suggest pagination, got %q", resp.Error.Message)
+	}
+
+	data, ok := resp.Error.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected error data, got %v", resp.Error.Data)
+	}
+	if data["maxResponseBytes"] != float64(4096) {
+		t.Errorf("maxResponseBytes = %v, want 4096", data["maxResponseBytes"])
+	}
+	if size, _ := data["responseBytes"].(float64); size <= 4096 {
+		t.Errorf("responseBytes = %v, want the oversized response's size", data["responseBytes"])
+	}
+	if data["retryable"] != false {
+		t.Errorf("retrying the same request cannot succeed, got retryable=%v", data["retryable"])
+	}
+}
+
+func TestMCPServer_ResponseWithinLimitSent(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.MaxResponseBytes = 4096 })
+	registerLargeOutputTool(t, ts, 1024)
+
+	responses := ts.call(t, initializeRequest(1), initializedNotification(), callLargeOutput(2))
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("unexpected error: %+v", responses[1].Error)
+	}
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// startRootsSession runs a server for a client advertising roots and answers the
+// roots/list request the server sends once the session is ready
+func startRootsSession(t *testing.T, ts *testServer, roots ...string) *fakeTransport {
+	t.Helper()
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	init := initializeRequest(1)
+	init["params"].(map[string]interface{})["capabilities"] = map[string]interface{}{
+		"roots": map[string]interface{}{"listChanged": true},
+	}
+	transport.send(t, init)
+	if resp := transport.receive(t); resp["error"] != nil {
+		t.Fatalf("initialize failed: %v", resp)
+	}
+	transport.send(t, initializedNotification())
+	answerRootsList(t, ts, transport, roots...)
+	return transport
+}
+
+// answerRootsList answers the server's roots/list request with the given directories
+// and waits until the session holds them
+func answerRootsList(t *testing.T, ts *testServer, transport *fakeTransport, dirs ...string) {
+	t.Helper()
+	req := transport.receive(t)
+	if req["method"] != "roots/list" || req["id"] == nil {
+		t.Fatalf("expected a roots/list request, got %v", req)
+	}
+
+	roots := make([]entities.Root, 0, len(dirs))
+	list := make([]interface{}, 0, len(dirs))
+	for _, dir := range dirs {
+		root := entities.Root{URI: "file://" + filepath.ToSlash(dir), Name: filepath.Base(dir)}
+		roots = append(roots, root)
+		list = append(list, map[string]interface{}{"uri": root.URI, "name": root.Name})
+	}
+	transport.send(t, map[string]interface{}{"id": req["id"], "result": map[string]interface{}{"roots": list}})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if got, ok := ts.srv.Session().Roots(); ok && reflect.DeepEqual(got, roots) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("session roots were not updated to %v", roots)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// readFile calls read_file and returns the text of the result and whether it is an error
+func readFile(t *testing.T, transport *fakeTransport, id int, path string) (string, bool) {
+	t.Helper()
+	transport.send(t, map[string]interface{}{
+		"id":     id,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "read_file", "arguments": map[string]interface{}{"path": path}},
+	})
+	resp := transport.receive(t)
+	result, ok := resp["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected tools/call response: %v", resp)
+	}
+	items, _ := result["content"].([]interface{})
+	if len(items) == 0 {
+		t.Fatalf("tools/call result without content: %v", result)
+	}
+	text, _ := items[0].(map[string]interface{})["text"].(string)
+	isError, _ := result["isError"].(bool)
+	return text, isError
+}
+
+func writeRootFile(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMCPServer_RootsChangeMidSession(t *testing.T) {
+	ts := newTestServer(t)
+	projectA, projectB := t.TempDir(), t.TempDir()
+	fileA := writeRootFile(t, projectA, "from A")
+	fileB := writeRootFile(t, projectB, "from B")
+
+	transport := startRootsSession(t, ts, projectA)
+
+	if text, isError := readFile(t, transport, 2, fileA); isError || text != "from A" {
+		t.Fatalf("read_file inside the root = %q (error %v), want from A", text, isError)
+	}
+	if _, isError := readFile(t, transport, 3, fileB); !isError {
+		t.Fatal("read_file outside the roots succeeded")
+	}
+
+	// The client swaps its roots without reconnecting
+	transport.send(t, map[string]interface{}{"method": "notifications/roots/list_changed"})
+	answerRootsList(t, ts, transport, projectB)
+
+	text, isError := readFile(t, transport, 4, fileA)
+	if !isError || !strings.Contains(text, "outside the allowed directories") {
+		t.Errorf("read_file in the removed root = %q (error %v), want it disallowed", text, isError)
+	}
+	if text, isError := readFile(t, transport, 5, fileB); isError || text != "from B" {
+		t.Errorf("read_file in the new root = %q (error %v), want from B", text, isError)
+	}
+}
+
+func TestMCPServer_RootsNarrowConfiguredAllowedPaths(t *testing.T) {
+	ts := newTestServer(t)
+	workspace := t.TempDir()
+	project := filepath.Join(workspace, "project")
+	if err := os.Mkdir(project, 0750); err != nil {
+		t.Fatal(err)
+	}
+	outside := writeRootFile(t, workspace, "workspace")
+	inside := writeRootFile(t, project, "project")
+	ts.registry.SetAllowedPaths([]string{workspace})
+
+	// A root wider than the configured paths does not widen them
+	transport := startRootsSession(t, ts, project, filepath.Dir(workspace))
+
+	if text, isError := readFile(t, transport, 2, inside); isError || text != "project" {
+		t.Errorf("read_file in the root = %q (error %v)", text, isError)
+	}
+	if text, isError := readFile(t, transport, 3, outside); isError || text != "workspace" {
+		t.Errorf("read_file in the configured path under a wider root = %q (error %v)", text, isError)
+	}
+	if _, isError := readFile(t, transport, 4, filepath.Join(filepath.Dir(workspace), "elsewhere")); !isError {
+		t.Error("read_file outside the configured paths succeeded")
+	}
+}
+
+func TestMCPServer_InFlightToolKeepsRootsSnapshot(t *testing.T) {
+	ts := newTestServer(t)
+	projectA, projectB := t.TempDir(), t.TempDir()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	observed := make(chan []entities.Root, 2)
+	name, _ := vo.NewToolName("roots_probe")
+	desc, _ := vo.NewToolDescription("reports the roots it runs with")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetContextHandler(func(ctx context.Context, _ map[string]interface{}) (*entities.ToolResult, error) {
+		before, _ := entities.RootsFromContext(ctx)
+		close(started)
+		<-release
+		after, _ := entities.RootsFromContext(ctx)
+		observed <- before
+		observed <- after
+		return entities.NewTextToolResult("done"), nil
+	})
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+
+	transport := startRootsSession(t, ts, projectA)
+
+	transport.send(t, map[string]interface{}{
+		"id":     2,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "roots_probe", "arguments": map[string]interface{}{}},
+	})
+	<-started
+	// The roots change while the call is running
+	ts.srv.Session().SetRoots([]entities.Root{{URI: "file://" + filepath.ToSlash(projectB)}})
+	close(release)
+	transport.receive(t)
+
+	before, after := <-observed, <-observed
+	if len(before) != 1 || !reflect.DeepEqual(before, after) {
+		t.Errorf("roots changed during the call: before %v, after %v", before, after)
+	}
+	if path, _ := before[0].Path(); path != projectA {
+		t.Errorf("call ran with root %s, want %s", path, projectA)
+	}
+}
+
+func TestMCPServer_RootsDenyUntilListed(t *testing.T) {
+	ts := newTestServer(t)
+	project := t.TempDir()
+	file := writeRootFile(t, project, "project")
+
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	init := initializeRequest(1)
+	init["params"].(map[string]interface{})["capabilities"] = map[string]interface{}{
+		"roots": map[string]interface{}{"listChanged": true},
+	}
+	transport.send(t, init)
+	if resp := transport.receive(t); resp["error"] != nil {
+		t.Fatalf("initialize failed: %v", resp)
+	}
+	transport.send(t, initializedNotification())
+	req := transport.receive(t)
+	if req["method"] != "roots/list" {
+		t.Fatalf("expected a roots/list request, got %v", req)
+	}
+
+	// Before the client answers, nothing is allowed
+	if _, isError := readFile(t, transport, 2, file); !isError {
+		t.Fatal("read_file succeeded before the client listed its roots")
+	}
+
+	answerRootsFailure := func(req map[string]interface{}) {
+		t.Helper()
+		transport.send(t, map[string]interface{}{
+			"id":    req["id"],
+			"error": map[string]interface{}{"code": -32603, "message": "roots unavailable"},
+		})
+	}
+	answerRootsFailure(req)
+	if _, isError := readFile(t, transport, 3, file); !isError {
+		t.Fatal("read_file succeeded after roots/list failed")
+	}
+
+	// A failed refresh drops the roots listed earlier
+	transport.send(t, map[string]interface{}{"method": "notifications/roots/list_changed"})
+	answerRootsList(t, ts, transport, project)
+	if text, isError := readFile(t, transport, 4, file); isError || text != "project" {
+		t.Fatalf("read_file in the root = %q (error %v)", text, isError)
+	}
+	transport.send(t, map[string]interface{}{"method": "notifications/roots/list_changed"})
+	answerRootsFailure(transport.receive(t))
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if roots, ok := ts.srv.Session().Roots(); ok && len(roots) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("session roots were not cleared after roots/list failed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, isError := readFile(t, transport, 5, file); !isError {
+		t.Error("read_file succeeded after the roots refresh failed")
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+func TestMCPServer_SafeMode(t *testing.T) {
+	ts := newTestServer(t, enableAdminMethods)
+	ts.toolHandler.SetSafeMode(true)
+	disabled := ts.registry.DisableMutatingTools()
+	for _, name := range []string{"write_file", "execute_command", "move_file", "copy_file", "delete_file"} {
+		if !slices.Contains(disabled, name) {
+			t.Errorf("DisableMutatingTools() = %v, missing %s", disabled, name)
+		}
+	}
+	if slices.Contains(disabled, "read_file") || slices.Contains(disabled, "echo") {
+		t.Errorf("DisableMutatingTools() = %v, should keep read-only tools", disabled)
+	}
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callToolRequest(2, "echo"),
+		callToolRequest(3, "write_file"),
+		setToolEnabledRequest(4, "write_file", true),
+		callToolRequest(5, "write_file"),
+		map[string]interface{}{"id": 6, "method": "tools/list"},
+	)
+	if len(responses) != 6 {
+		t.Fatalf("expected 6 responses, got %d", len(responses))
+	}
+
+	if responses[1].Error != nil {
+		t.Errorf("read-only tool failed in safe mode: %+v", responses[1].Error)
+	}
+	for _, resp := range []JSONRPCResponse{responses[2], responses[3], responses[4]} {
+		assertToolError(t, resp, vo.ErrorCodeToolDisabled, "write_file")
+		if data, _ := resp.Error.Data.(map[string]interface{}); data["safeMode"] != true {
+			t.Errorf("response %v: error data = %v, want safeMode", resp.ID, resp.Error.Data)
+		}
+	}
+
+	for _, tool := range responses[5].Result.(map[string]interface{})["tools"].([]interface{}) {
+		if name := tool.(map[string]interface{})["name"]; name == "write_file" || name == "execute_command" {
+			t.Errorf("tools/list includes %s in safe mode", name)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+// initializeClient completes the handshake over a WebSocket connection and returns the session ID
+func initializeClient(t *testing.T, client *wsClient, id int) string {
+	t.Helper()
+	msg := client.call(t, initializeRequest(id))
+	if msg["error"] != nil {
+		t.Fatalf("initialize failed: %v", msg)
+	}
+	client.send(t, initializedNotification())
+	metrics := client.call(t, map[string]interface{}{"id": id + 1, "method": "session/metrics"})
+	result, _ := metrics["result"].(map[string]interface{})
+	sessionID, _ := result["sessionId"].(string)
+	if sessionID == "" {
+		t.Fatalf("unexpected session/metrics response: %v", metrics)
+	}
+	return sessionID
+}
+
+func mustSessionID(t *testing.T, value string) vo.SessionID {
+	t.Helper()
+	id, err := vo.NewSessionID(value)
+	if err != nil {
+		t.Fatalf("invalid session ID %q: %v", value, err)
+	}
+	return id
+}
+
+func TestSessionManager_RoutesBySession(t *testing.T) {
+	ts, baseURL := startWebSocketServer(t)
+
+	first := connectWebSocket(t, baseURL)
+	second := connectWebSocket(t, baseURL)
+	firstID := initializeClient(t, first, 1)
+	secondID := initializeClient(t, second, 1)
+
+	if got := len(ts.srv.Sessions()); got != 2 {
+		t.Fatalf("expected 2 sessions, got %d", got)
+	}
+	for _, id := range []string{firstID, secondID} {
+		session := ts.srv.SessionByID(mustSessionID(t, id))
+		if session == nil || session.ID().String() != id {
+			t.Errorf("SessionByID(%s) = %v", id, session)
+		}
+	}
+
+	// Initializing the second connection did not replace the first connection's session
+	metrics := first.call(t, map[string]interface{}{"id": 10, "method": "session/metrics"})
+	result, _ := metrics["result"].(map[string]interface{})
+	if result["sessionId"] != firstID {
+		t.Errorf("first connection reports session %v, want %s", result["sessionId"], firstID)
+	}
+}
+
+func TestSessionManager_ClosesSessionOnDisconnect(t *testing.T) {
+	ts, baseURL := startWebSocketServer(t)
+	ts.sessionHandler.SetMaxSessions(1)
+
+	first := connectWebSocket(t, baseURL)
+	firstID := initializeClient(t, first, 1)
+	session := ts.srv.SessionByID(mustSessionID(t, firstID))
+	_ = first.conn.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !session.IsClosed() {
+		if time.Now().After(deadline) {
+			t.Fatal("session was not closed after its client disconnected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if ts.srv.SessionByID(mustSessionID(t, firstID)) != nil {
+		t.Error("expected the disconnected session to be forgotten")
+	}
+
+	// The closed session no longer counts against the limit
+	second := connectWebSocket(t, baseURL)
+	initializeClient(t, second, 1)
+}
+
+func TestSessionManager_ReinitializeClosesPreviousSession(t *testing.T) {
+	ts, baseURL := startWebSocketServer(t)
+
+	client := connectWebSocket(t, baseURL)
+	firstID := initializeClient(t, client, 1)
+	previous := ts.srv.SessionByID(mustSessionID(t, firstID))
+	secondID := initializeClient(t, client, 3)
+
+	if firstID == secondID {
+		t.Fatal("expected initialize to start a new session")
+	}
+	if !previous.IsClosed() {
+		t.Error("expected the replaced session to be closed")
+	}
+	if ts.srv.SessionByID(mustSessionID(t, firstID)) != nil {
+		t.Error("expected the replaced session to be forgotten")
+	}
+	if got := len(ts.srv.Sessions()); got != 1 {
+		t.Errorf("expected 1 session, got %d", got)
+	}
+}
+
+func TestSessionManager_LatestFallsBackOnDisconnect(t *testing.T) {
+	ts, baseURL := startWebSocketServer(t)
+
+	first := connectWebSocket(t, baseURL)
+	second := connectWebSocket(t, baseURL)
+	firstID := initializeClient(t, first, 1)
+	secondID := initializeClient(t, second, 1)
+	if got := ts.srv.Session(); got == nil || got.ID().String() != secondID {
+		t.Fatalf("Session() = %v, want %s", got, secondID)
+	}
+
+	waitForSession := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			got := ts.srv.Session()
+			if (want == "" && got == nil) || (got != nil && got.ID().String() == want) {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Session() = %v, want %q", got, want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// The latest session falls back to the one still connected
+	_ = second.conn.Close()
+	waitForSession(firstID)
+
+	_ = first.conn.Close()
+	waitForSession("")
+}
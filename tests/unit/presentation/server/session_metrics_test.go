@@ -0,0 +1,88 @@
+package server
+
+import (
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+func TestMCPServer_SessionMetrics(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callToolRequest(2, "echo"),
+		callToolRequest(3, "echo"),
+		callToolRequest(4, "no_such_tool"),
+		map[string]interface{}{"id": 5, "method": "tools/list"},
+		map[string]interface{}{"id": 6, "method": "bogus/method"},
+		map[string]interface{}{"id": 7, "method": "session/metrics"},
+	)
+	if len(responses) != 7 {
+		t.Fatalf("expected 7 responses, got %d", len(responses))
+	}
+
+	metrics, _ := responses[6].Result.(map[string]interface{})
+	if responses[6].Error != nil || metrics == nil {
+		t.Fatalf("session/metrics failed: %+v", responses[6].Error)
+	}
+	if metrics["sessionId"] != ts.srv.Session().ID().String() {
+		t.Errorf("sessionId = %v, want the calling session", metrics["sessionId"])
+	}
+	if uptime, _ := metrics["uptimeMs"].(float64); uptime < 0 || metrics["startedAt"] == nil {
+		t.Errorf("uptime = %v ms since %v", metrics["uptimeMs"], metrics["startedAt"])
+	}
+
+	// initialize, three tool calls, tools/list and the unknown method; not the metrics call itself
+	requests, _ := metrics["requests"].(map[string]interface{})
+	if requests["total"] != float64(6) || requests["errors"] != float64(2) {
+		t.Errorf("requests = %v total, %v errors, want 6 and 2", requests["total"], requests["errors"])
+	}
+	byMethod := make(map[string]float64)
+	methods, _ := requests["byMethod"].([]interface{})
+	for _, item := range methods {
+		m, _ := item.(map[string]interface{})
+		method, _ := m["method"].(string)
+		byMethod[method], _ = m["requests"].(float64)
+	}
+	want := map[string]float64{"initialize": 1, "tools/call": 3, "tools/list": 1, "unknown": 1}
+	for method, count := range want {
+		if byMethod[method] != count {
+			t.Errorf("requests for %s = %v, want %v (all: %v)", method, byMethod[method], count, byMethod)
+		}
+	}
+	if busiest, _ := methods[0].(map[string]interface{}); busiest["method"] != "tools/call" || busiest["errors"] != float64(1) {
+		t.Errorf("busiest method = %v, want tools/call with 1 error", busiest)
+	}
+
+	tools, _ := metrics["tools"].(map[string]interface{})
+	if tools["totalCalls"] != float64(2) {
+		t.Errorf("tool calls = %v, want 2 echo calls", tools)
+	}
+	tokens, _ := metrics["tokens"].(map[string]interface{})
+	if tokens["total"] != float64(0) {
+		t.Errorf("tokens = %v, want none without conversations", tokens)
+	}
+}
+
+func TestMCPServer_SessionMetricsResetOnClose(t *testing.T) {
+	ts := newTestServer(t)
+	ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callToolRequest(2, "echo"),
+	)
+
+	session := ts.srv.Session()
+	if usage := session.RequestUsage(); usage.TotalRequests == 0 {
+		t.Fatal("requests were not counted")
+	}
+	session.Close()
+	if usage := session.RequestUsage(); usage.TotalRequests != 0 || len(usage.Methods) != 0 {
+		t.Errorf("usage after close = %+v, want reset", usage)
+	}
+	if unknown := vo.MCPMethod("session/metrics"); !unknown.IsValid() {
+		t.Error("session/metrics should be a known method")
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func TestMCPServer_SessionReset(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t, enableAdminMethods)
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	transport.send(t, initializeRequest(1))
+	transport.receive(t)
+	transport.send(t, initializedNotification())
+	transport.send(t, subscribeRequest(2, "resources/subscribe", server.HealthResourceURI))
+	if resp := transport.receive(t); resp["error"] != nil {
+		t.Fatalf("subscribe failed: %v", resp)
+	}
+
+	session := ts.srv.Session()
+	sessionID := session.ID()
+	capabilities := session.Capabilities()
+	defaultResources := len(session.ListResources())
+	defaultPrompts := len(session.ListPrompts())
+
+	echo, _ := ts.registry.GetTool("echo")
+	if err := session.RegisterTool(echo); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	conversation, err := session.CreateConversation(vo.ModelClaude4Sonnet)
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	uri, _ := vo.NewResourceURI("file:///tmp/extra.txt")
+	extra, _ := entities.NewResource(uri, "Extra")
+	session.RegisterResource(extra)
+
+	transport.send(t, map[string]interface{}{"id": 3, "method": "session/reset"})
+	msgs := transport.collect(t, 300*time.Millisecond)
+
+	var result map[string]interface{}
+	notified := map[string]bool{}
+	for _, msg := range msgs {
+		if method, ok := msg["method"].(string); ok {
+			notified[method] = true
+			continue
+		}
+		if msg["error"] != nil {
+			t.Fatalf("session/reset failed: %v", msg["error"])
+		}
+		result, _ = msg["result"].(map[string]interface{})
+	}
+	if result == nil {
+		t.Fatal("no session/reset response")
+	}
+
+	for _, method := range []string{
+		"notifications/tools/list_changed",
+		"notifications/resources/list_changed",
+		"notifications/prompts/list_changed",
+	} {
+		if !notified[method] {
+			t.Errorf("expected %s notification, got %v", method, notified)
+		}
+	}
+
+	if result["sessionId"] != sessionID.String() {
+		t.Errorf("sessionId = %v, want %s", result["sessionId"], sessionID)
+	}
+	cleared, _ := result["cleared"].(map[string]interface{})
+	want := map[string]float64{
+		"tools":         1,
+		"resources":     float64(defaultResources + 1),
+		"prompts":       float64(defaultPrompts),
+		"subscriptions": 1,
+		"conversations": 1,
+	}
+	for key, count := range want {
+		if cleared[key] != count {
+			t.Errorf("cleared[%s] = %v, want %v", key, cleared[key], count)
+		}
+	}
+
+	// The same session carries on, ready, with its capabilities and only the defaults
+	current := ts.srv.Session()
+	if !current.ID().Equals(sessionID) || !current.IsReady() || current.Capabilities() != capabilities {
+		t.Errorf("session identity or state changed by reset")
+	}
+	if _, ok := current.GetTool("echo"); ok {
+		t.Error("session tool survived reset")
+	}
+	if _, ok := current.GetResource("file:///tmp/extra.txt"); ok {
+		t.Error("session resource survived reset")
+	}
+	if got := len(current.ListResources()); got != defaultResources {
+		t.Errorf("resources after reset = %d, want %d defaults", got, defaultResources)
+	}
+	if got := len(current.ListPrompts()); got != defaultPrompts {
+		t.Errorf("prompts after reset = %d, want %d defaults", got, defaultPrompts)
+	}
+	if len(current.ListConversations()) != 0 {
+		t.Error("conversations survived reset")
+	}
+	if conversation.Status() != aggregates.ConversationStatusClosed {
+		t.Errorf("conversation status = %s, want closed", conversation.Status())
+	}
+	if current.IsSubscribed(server.HealthResourceURI) {
+		t.Error("subscription survived reset")
+	}
+	if uris, _ := ts.subscriptions.FindBySessionID(ctx, sessionID); len(uris) != 0 {
+		t.Errorf("persisted subscriptions survived reset: %v", uris)
+	}
+
+	// Calls keep working on the reset session
+	transport.send(t, callToolRequest(4, "echo"))
+	if resp := transport.receive(t); resp["error"] != nil {
+		t.Errorf("tools/call after reset failed: %v", resp["error"])
+	}
+}
+
+func TestMCPServer_SessionResetRequiresAdmin(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{"id": 2, "method": "session/reset"},
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != int(vo.ErrorCodeMethodNotFound) {
+		t.Errorf("expected method not found without admin methods, got %+v", responses[1])
+	}
+}
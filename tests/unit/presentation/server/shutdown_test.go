@@ -0,0 +1,255 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// rawLine reads the next line written by the server, failing after a timeout
+func rawLine(t *testing.T, lines <-chan string) (string, bool) {
+	t.Helper()
+	select {
+	case line, ok := <-lines:
+		return line, ok
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for server output")
+		return "", false
+	}
+}
+
+func TestMCPServer_ShutdownNotificationBeforeTransportCloses(t *testing.T) {
+	ts := newTestServer(t)
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	ts.srv.SetIO(inR, outW)
+
+	done := make(chan error, 1)
+	go func() {
+		err := ts.srv.Run(context.Background())
+		_ = outW.Close()
+		done <- err
+	}()
+
+	lines := make(chan string, 16)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(outR)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	write := func(req map[string]interface{}) {
+		req["jsonrpc"] = "2.0"
+		data, _ := json.Marshal(req)
+		if _, err := inW.Write(append(data, '\n')); err != nil {
+			t.Fatalf("failed to write request: %v", err)
+		}
+	}
+	write(initializeRequest(1))
+	if _, ok := rawLine(t, lines); !ok {
+		t.Fatal("transport closed before initialize response")
+	}
+	write(initializedNotification())
+
+	stopped := make(chan struct{})
+	go func() {
+		ts.srv.Stop()
+		close(stopped)
+	}()
+
+	line, ok := rawLine(t, lines)
+	if !ok {
+		t.Fatal("transport closed before shutdown notification was sent")
+	}
+	var notification struct {
+		ID     interface{} `json:"id"`
+		Method string      `json:"method"`
+		Params struct {
+			Level string                 `json:"level"`
+			Data  map[string]interface{} `json:"data"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(line), &notification); err != nil {
+		t.Fatalf("invalid notification %q: %v", line, err)
+	}
+	if notification.Method != "notifications/message" || notification.ID != nil {
+		t.Fatalf("expected notifications/message, got %s", line)
+	}
+	if notification.Params.Level != "warning" || notification.Params.Data["event"] != server.ShutdownEvent {
+		t.Errorf("unexpected shutdown notification params: %s", line)
+	}
+
+	// Unblock the reader; the server then observes the stop and closes the transport
+	<-stopped
+	write(map[string]interface{}{"method": "notifications/cancelled"})
+	select {
+	case err := <-done:
+		if err != server.ErrServerClosed {
+			t.Errorf("expected ErrServerClosed, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not stop")
+	}
+	if _, ok := rawLine(t, lines); ok {
+		t.Error("expected no output after the shutdown notification")
+	}
+}
+
+// stallingWriter forwards writes until stalled, then blocks every write
+type stallingWriter struct {
+	w       io.Writer
+	stalled int32
+	block   chan struct{}
+}
+
+func (w *stallingWriter) Write(p []byte) (int, error) {
+	if atomic.LoadInt32(&w.stalled) == 1 {
+		<-w.block
+	}
+	return w.w.Write(p)
+}
+
+func TestMCPServer_ShutdownNotificationTimeout(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) {
+		cfg.Server.ShutdownTimeout = 50 * time.Millisecond
+	})
+
+	inR, inW := io.Pipe()
+	defer inW.Close()
+	var out bytes.Buffer
+	writer := &stallingWriter{w: &out, block: make(chan struct{})}
+	defer close(writer.block)
+	ts.srv.SetIO(inR, writer)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+
+	req := initializeRequest(1)
+	req["jsonrpc"] = "2.0"
+	data, _ := json.Marshal(req)
+	if _, err := inW.Write(append(data, '\n')); err != nil {
+		t.Fatalf("failed to write request: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for ts.srv.Session() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("session was not initialized")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	atomic.StoreInt32(&writer.stalled, 1)
+	start := time.Now()
+	ts.srv.Stop()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Stop blocked for %v on a stuck transport", elapsed)
+	}
+}
+
+type fakeShutdownMetrics struct {
+	outcomes chan string
+}
+
+func (m *fakeShutdownMetrics) RecordShutdown(ctx context.Context, outcome string) {
+	m.outcomes <- outcome
+}
+
+// registerStuckTool registers a tool whose handler ignores cancellation until released
+func registerStuckTool(t *testing.T, ts *testServer, entered chan<- struct{}, release <-chan struct{}) {
+	t.Helper()
+	name, _ := vo.NewToolName("stuck")
+	desc, _ := vo.NewToolDescription("Blocks until released, ignoring cancellation")
+	tool, err := entities.NewTool(name, desc, &entities.JSONSchema{Type: "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.SetTimeout(time.Minute)
+	tool.SetHandler(func(input map[string]interface{}) (*entities.ToolResult, error) {
+		close(entered)
+		<-release
+		return entities.NewTextToolResult("released"), nil
+	})
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMCPServer_ShutdownForcesStuckWork(t *testing.T) {
+	ts := newTestServer(t)
+	entered, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	registerStuckTool(t, ts, entered, release)
+	metrics := &fakeShutdownMetrics{outcomes: make(chan string, 1)}
+	ts.srv.SetShutdownMetrics(metrics)
+
+	client := ts.stream(t)
+	client.send(t, initializeRequest(1))
+	client.receive(t)
+	client.send(t, initializedNotification())
+	client.send(t, map[string]interface{}{
+		"id":     2,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "stuck", "arguments": map[string]interface{}{}},
+	})
+	select {
+	case <-entered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("tool was not called")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := ts.srv.Shutdown(ctx)
+	elapsed := time.Since(start)
+
+	if err != server.ErrForcedShutdown {
+		t.Fatalf("Shutdown() error = %v, want ErrForcedShutdown", err)
+	}
+	if elapsed < 100*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("Shutdown took %v, want about the 100ms deadline", elapsed)
+	}
+	if outcome := <-metrics.outcomes; outcome != server.ShutdownOutcomeForced {
+		t.Errorf("shutdown outcome = %q, want %q", outcome, server.ShutdownOutcomeForced)
+	}
+	select {
+	case <-client.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after a forced shutdown")
+	}
+}
+
+func TestMCPServer_ShutdownClean(t *testing.T) {
+	ts := newTestServer(t)
+	metrics := &fakeShutdownMetrics{outcomes: make(chan string, 1)}
+	ts.srv.SetShutdownMetrics(metrics)
+
+	client := ts.stream(t)
+	client.send(t, initializeRequest(1))
+	client.receive(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := ts.srv.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v, want a clean shutdown", err)
+	}
+	if outcome := <-metrics.outcomes; outcome != server.ShutdownOutcomeClean {
+		t.Errorf("shutdown outcome = %q, want %q", outcome, server.ShutdownOutcomeClean)
+	}
+	select {
+	case <-client.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after shutdown")
+	}
+}
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// sseClient is a client of the SSE transport: it reads events from one stream and
+// posts its messages to the endpoint the stream announced
+type sseClient struct {
+	baseURL  string
+	endpoint string
+	events   chan sseEvent
+}
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+// connectSSE opens an event stream and waits for its endpoint event
+func connectSSE(t *testing.T, baseURL string) *sseClient {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+server.SSEStreamPath, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	c := &sseClient{baseURL: baseURL, events: make(chan sseEvent, 16)}
+	t.Cleanup(cancel)
+	go func() {
+		defer func() { _ = resp.Body.Close() }()
+		defer close(c.events)
+		reader := bufio.NewReader(resp.Body)
+		var event sseEvent
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case line == "":
+				if event.name != "" {
+					c.events <- event
+				}
+				event = sseEvent{}
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data += strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	endpoint := c.next(t)
+	if endpoint.name != "endpoint" || !strings.HasPrefix(endpoint.data, server.SSEMessagePath+"?sessionId=") {
+		t.Fatalf("unexpected first event: %+v", endpoint)
+	}
+	c.endpoint = endpoint.data
+	return c
+}
+
+func (c *sseClient) next(t *testing.T) sseEvent {
+	t.Helper()
+	select {
+	case event, ok := <-c.events:
+		if !ok {
+			t.Fatal("event stream closed")
+		}
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return sseEvent{}
+	}
+}
+
+// post sends a message and returns the HTTP status
+func (c *sseClient) post(t *testing.T, endpoint string, req map[string]interface{}) int {
+	t.Helper()
+	req["jsonrpc"] = "2.0"
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	resp, err := http.Post(c.baseURL+endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to post message: %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+// call posts a request and returns the message answering it
+func (c *sseClient) call(t *testing.T, req map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	if status := c.post(t, c.endpoint, req); status != http.StatusAccepted {
+		t.Fatalf("POST status = %d, want %d", status, http.StatusAccepted)
+	}
+	for {
+		event := c.next(t)
+		var msg map[string]interface{}
+		if err := json.Unmarshal([]byte(event.data), &msg); err != nil {
+			t.Fatalf("invalid message %q: %v", event.data, err)
+		}
+		if msg["id"] == float64(req["id"].(int)) {
+			return msg
+		}
+	}
+}
+
+// startSSEServer runs a server accepting the SSE connections access allows on a test
+// HTTP server
+func startSSEServer(t *testing.T, access server.TransportAccess) (*testServer, string) {
+	t.Helper()
+	ts := newTestServer(t)
+	listener := server.NewSSEListener(access)
+	httpServer := httptest.NewServer(listener)
+	ts.srv.SetListener(listener)
+
+	done := make(chan error, 1)
+	go func() { done <- ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() {
+		ts.srv.Stop()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Error("server did not stop")
+		}
+		httpServer.Close()
+	})
+	return ts, httpServer.URL
+}
+
+func TestSSETransport_SessionPerConnection(t *testing.T) {
+	_, baseURL := startSSEServer(t, server.TransportAccess{})
+
+	first := connectSSE(t, baseURL)
+	second := connectSSE(t, baseURL)
+	if first.endpoint == second.endpoint {
+		t.Fatal("expected each stream to get its own endpoint")
+	}
+
+	sessionIDs := make(map[string]bool)
+	for _, client := range []*sseClient{first, second} {
+		msg := client.call(t, initializeRequest(1))
+		if msg["error"] != nil {
+			t.Fatalf("initialize failed: %v", msg)
+		}
+		if status := client.post(t, client.endpoint, initializedNotification()); status != http.StatusAccepted {
+			t.Fatalf("POST status = %d, want %d", status, http.StatusAccepted)
+		}
+		metrics := client.call(t, map[string]interface{}{"id": 2, "method": "session/metrics"})
+		result, _ := metrics["result"].(map[string]interface{})
+		id, _ := result["sessionId"].(string)
+		if id == "" {
+			t.Fatalf("unexpected session/metrics response: %v", metrics)
+		}
+		sessionIDs[id] = true
+	}
+	if len(sessionIDs) != 2 {
+		t.Errorf("expected two distinct sessions, got %v", sessionIDs)
+	}
+
+	// A connection that has not initialized gets no session of another connection
+	third := connectSSE(t, baseURL)
+	msg := third.call(t, map[string]interface{}{"id": 1, "method": "tools/list"})
+	if msg["error"] == nil {
+		t.Errorf("expected tools/list before initialize to fail, got %v", msg)
+	}
+}
+
+func TestSSETransport_RejectsInvalidMessages(t *testing.T) {
+	_, baseURL := startSSEServer(t, server.TransportAccess{})
+	client := connectSSE(t, baseURL)
+
+	if status := client.post(t, server.SSEMessagePath+"?sessionId=unknown", initializeRequest(1)); status != http.StatusNotFound {
+		t.Errorf("unknown session status = %d, want %d", status, http.StatusNotFound)
+	}
+
+	resp, err := http.Post(baseURL+client.endpoint, "application/json", strings.NewReader("{not json"))
+	if err != nil {
+		t.Fatalf("failed to post message: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid JSON status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	resp, err = http.Get(baseURL + server.SSEMessagePath)
+	if err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET message status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+// sseStatus sends a request to the SSE transport and returns the HTTP status, without
+// waiting for an event stream to end
+func sseStatus(t *testing.T, method, url string, header http.Header) int {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestSSETransport_RequiresAPIKey(t *testing.T) {
+	_, baseURL := startSSEServer(t, server.TransportAccess{RequireAPIKey: true, APIKeys: []string{"secret-key"}})
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		header http.Header
+		want   int
+	}{
+		{name: "stream without key", method: http.MethodGet, url: baseURL + server.SSEStreamPath, want: http.StatusUnauthorized},
+		{name: "message without key", method: http.MethodPost, url: baseURL + server.SSEMessagePath + "?sessionId=x", want: http.StatusUnauthorized},
+		{
+			name:   "message with wrong key",
+			method: http.MethodPost,
+			url:    baseURL + server.SSEMessagePath + "?sessionId=x",
+			header: http.Header{"Authorization": []string{"Bearer other-key"}},
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "stream with bearer token",
+			method: http.MethodGet,
+			url:    baseURL + server.SSEStreamPath,
+			header: http.Header{"Authorization": []string{"Bearer secret-key"}},
+			want:   http.StatusOK,
+		},
+		{name: "message with query parameter", method: http.MethodPost, url: baseURL + server.SSEMessagePath + "?sessionId=x&api_key=secret-key", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := sseStatus(t, tt.method, tt.url, tt.header); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSETransport_RejectsOtherOrigins(t *testing.T) {
+	// The default CORS setting allows "*", which must not open the transport to every page
+	_, baseURL := startSSEServer(t, server.TransportAccess{AllowedOrigins: []string{"*", "https://allowed.example"}})
+	foreign := http.Header{"Origin": []string{"https://evil.example"}}
+
+	if status := sseStatus(t, http.MethodGet, baseURL+server.SSEStreamPath, foreign); status != http.StatusForbidden {
+		t.Errorf("stream status = %d, want %d", status, http.StatusForbidden)
+	}
+	if status := sseStatus(t, http.MethodPost, baseURL+server.SSEMessagePath+"?sessionId=x", foreign); status != http.StatusForbidden {
+		t.Errorf("message status = %d, want %d", status, http.StatusForbidden)
+	}
+
+	for _, origin := range []string{"https://allowed.example", baseURL} {
+		header := http.Header{"Origin": []string{origin}}
+		if status := sseStatus(t, http.MethodGet, baseURL+server.SSEStreamPath, header); status != http.StatusOK {
+			t.Errorf("stream from %s: status = %d, want %d", origin, status, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func subscribeRequest(id int, method, uri string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": method,
+		"params": map[string]interface{}{"uri": uri},
+	}
+}
+
+func TestMCPServer_ResourceSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	// Without a debounce window each update is written immediately
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.MCP.ResourceUpdateDebounce = 0 })
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		subscribeRequest(2, "resources/subscribe", server.HealthResourceURI),
+		subscribeRequest(3, "resources/subscribe", ""),
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	if responses[1].Error != nil {
+		t.Fatalf("unexpected subscribe error: %+v", responses[1].Error)
+	}
+	if responses[2].Error == nil {
+		t.Error("subscribe without uri should be rejected")
+	}
+
+	session := ts.srv.Session()
+	uris, err := ts.subscriptions.FindBySessionID(ctx, session.ID())
+	if err != nil {
+		t.Fatalf("FindBySessionID() error = %v", err)
+	}
+	if len(uris) != 1 || uris[0] != server.HealthResourceURI {
+		t.Errorf("persisted subscriptions = %v, want [%s]", uris, server.HealthResourceURI)
+	}
+
+	// Updates to subscribed resources notify the connected client; others are ignored
+	ts, transport := startSubscribedServer(t, 0, server.HealthResourceURI)
+	if err := ts.srv.NotifyResourceUpdated(ctx, "file:///unrelated.txt"); err != nil {
+		t.Fatalf("NotifyResourceUpdated() error = %v", err)
+	}
+	if msgs := transport.collect(t, 50*time.Millisecond); len(msgs) != 0 {
+		t.Errorf("unsubscribed resource should not notify, got %v", msgs)
+	}
+	if err := ts.srv.NotifyResourceUpdated(ctx, server.HealthResourceURI); err != nil {
+		t.Fatalf("NotifyResourceUpdated() error = %v", err)
+	}
+
+	notification := transport.receive(t)
+	if notification["method"] != "notifications/resources/updated" {
+		t.Errorf("method = %v, want notifications/resources/updated", notification["method"])
+	}
+	if params := notification["params"].(map[string]interface{}); params["uri"] != server.HealthResourceURI {
+		t.Errorf("uri = %v, want %s", params["uri"], server.HealthResourceURI)
+	}
+}
+
+func TestMCPServer_ResourceUnsubscribe(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		subscribeRequest(2, "resources/subscribe", server.HealthResourceURI),
+		subscribeRequest(3, "resources/unsubscribe", server.HealthResourceURI),
+	)
+
+	for _, resp := range responses {
+		if resp.Error != nil {
+			t.Fatalf("response %v: unexpected error %+v", resp.ID, resp.Error)
+		}
+	}
+
+	subscribers, err := ts.subscriptions.FindSubscribers(context.Background(), server.HealthResourceURI)
+	if err != nil {
+		t.Fatalf("FindSubscribers() error = %v", err)
+	}
+	if len(subscribers) != 0 {
+		t.Errorf("unsubscribe should remove the persisted subscription, got %v", subscribers)
+	}
+}
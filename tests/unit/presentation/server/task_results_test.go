@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/queue"
+)
+
+// fakeTaskResults serves task results from a map
+type fakeTaskResults map[string]*queue.TaskResult
+
+func (f fakeTaskResults) GetResult(taskID string) (*queue.TaskResult, error) {
+	result, ok := f[taskID]
+	if !ok {
+		return nil, queue.ErrTaskNotFound
+	}
+	return result, nil
+}
+
+func taskResultRequest(id int, taskID string) map[string]interface{} {
+	return map[string]interface{}{"id": id, "method": "admin/taskResult", "params": map[string]interface{}{"taskId": taskID}}
+}
+
+func TestMCPServer_AdminTaskResult(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.Server.EnableAdminMethods = true })
+	ts.srv.SetTaskResults(fakeTaskResults{
+		"task-1": {TaskID: "task-1", Success: true, Retries: 1, Duration: time.Second, Timestamp: time.Now()},
+	})
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		taskResultRequest(2, "task-1"),
+		taskResultRequest(3, "task-2"),
+	)
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+
+	data, _ := json.Marshal(responses[1].Result)
+	var result queue.TaskResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("failed to decode task result: %v", err)
+	}
+	if result.TaskID != "task-1" || !result.Success || result.Retries != 1 {
+		t.Errorf("unexpected task result: %+v", result)
+	}
+
+	if resp := responses[2]; resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeInvalidParams) {
+		t.Errorf("unknown task: error = %+v, want invalid params", resp.Error)
+	}
+}
+
+func TestMCPServer_AdminTaskResultNeedsQueueAndAdminMethods(t *testing.T) {
+	t.Run("admin methods disabled", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.srv.SetTaskResults(fakeTaskResults{})
+		responses := ts.call(t, initializeRequest(1), initializedNotification(), taskResultRequest(2, "task-1"))
+		if resp := responses[1]; resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeMethodNotFound) {
+			t.Errorf("error = %+v, want method not found", resp.Error)
+		}
+	})
+
+	t.Run("queue disabled", func(t *testing.T) {
+		ts := newTestServer(t, func(cfg *config.Config) { cfg.Server.EnableAdminMethods = true })
+		responses := ts.call(t, initializeRequest(1), initializedNotification(), taskResultRequest(2, "task-1"))
+		if resp := responses[1]; resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeInvalidRequest) {
+			t.Errorf("error = %+v, want invalid request", resp.Error)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"testing"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+)
+
+func callToolRequest(id int, name string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": name, "arguments": map[string]interface{}{"message": "hi"}},
+	}
+}
+
+func setToolEnabledRequest(id int, name string, enabled bool) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "admin/setToolEnabled",
+		"params": map[string]interface{}{"name": name, "enabled": enabled},
+	}
+}
+
+func enableAdminMethods(cfg *config.Config) {
+	cfg.Server.EnableAdminMethods = true
+}
+
+// assertToolError checks the error code and the tool name carried in the error data
+func assertToolError(t *testing.T, resp JSONRPCResponse, code vo.MCPErrorCode, tool string) {
+	t.Helper()
+	if resp.Error == nil {
+		t.Fatalf("response %v: expected error %d, got result %#v", resp.ID, code, resp.Result)
+	}
+	if resp.Error.Code != int(code) {
+		t.Errorf("response %v: error code = %d, want %d (%s)", resp.ID, resp.Error.Code, code, resp.Error.Message)
+	}
+	data, _ := resp.Error.Data.(map[string]interface{})
+	if data["tool"] != tool {
+		t.Errorf("response %v: error data tool = %v, want %s", resp.ID, data["tool"], tool)
+	}
+}
+
+func TestMCPServer_ToolNotFoundVersusDisabled(t *testing.T) {
+	ts := newTestServer(t, enableAdminMethods)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callToolRequest(2, "no_such_tool"),
+		setToolEnabledRequest(3, "echo", false),
+		callToolRequest(4, "echo"),
+	)
+
+	if len(responses) != 4 {
+		t.Fatalf("expected 4 responses, got %d", len(responses))
+	}
+	assertToolError(t, responses[1], vo.ErrorCodeToolNotFound, "no_such_tool")
+	if responses[2].Error != nil {
+		t.Fatalf("unexpected error disabling tool: %+v", responses[2].Error)
+	}
+	assertToolError(t, responses[3], vo.ErrorCodeToolDisabled, "echo")
+}
+
+func TestMCPServer_SetToolEnabledToggle(t *testing.T) {
+	ts := newTestServer(t, enableAdminMethods)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		setToolEnabledRequest(2, "echo", false),
+		map[string]interface{}{"id": 3, "method": "tools/list"},
+		setToolEnabledRequest(4, "echo", true),
+		callToolRequest(5, "echo"),
+		setToolEnabledRequest(6, "no_such_tool", true),
+	)
+
+	if len(responses) != 6 {
+		t.Fatalf("expected 6 responses, got %d", len(responses))
+	}
+
+	result := responses[1].Result.(map[string]interface{})
+	if result["enabled"] != false {
+		t.Errorf("enabled = %v, want false", result["enabled"])
+	}
+
+	listed := responses[2].Result.(map[string]interface{})["tools"].([]interface{})
+	for _, tool := range listed {
+		if tool.(map[string]interface{})["name"] == "echo" {
+			t.Error("disabled tool should not be listed")
+		}
+	}
+
+	if responses[4].Error != nil {
+		t.Errorf("re-enabled tool should be callable: %+v", responses[4].Error)
+	}
+	assertToolError(t, responses[5], vo.ErrorCodeToolNotFound, "no_such_tool")
+}
+
+func TestMCPServer_AdminMethodsDisabledByDefault(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		setToolEnabledRequest(2, "echo", false),
+		callToolRequest(3, "echo"),
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != int(vo.ErrorCodeMethodNotFound) {
+		t.Errorf("admin methods should be unavailable unless enabled, got %+v", responses[1].Error)
+	}
+	if responses[2].Error != nil {
+		t.Errorf("tool should remain enabled: %+v", responses[2].Error)
+	}
+}
+
+func TestMCPServer_ConfiguredBuiltinTools(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  []string
+		disabled []string
+		listed   []string
+		hidden   []string
+	}{
+		{
+			name:     "disabled list",
+			disabled: []string{"execute_command", "write_file"},
+			listed:   []string{"echo", "read_file"},
+			hidden:   []string{"execute_command", "write_file"},
+		},
+		{
+			name:    "enabled list",
+			enabled: []string{"echo", "read_file"},
+			listed:  []string{"echo", "read_file"},
+			hidden:  []string{"execute_command", "write_file", "claude_conversation"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			if err := ts.registry.ConfigureBuiltinTools(tt.enabled, tt.disabled); err != nil {
+				t.Fatalf("ConfigureBuiltinTools() error = %v", err)
+			}
+
+			responses := ts.call(t,
+				initializeRequest(1),
+				initializedNotification(),
+				map[string]interface{}{"id": 2, "method": "tools/list"},
+			)
+			if len(responses) != 2 {
+				t.Fatalf("expected 2 responses, got %d", len(responses))
+			}
+
+			names := make(map[string]bool)
+			for _, tool := range responses[1].Result.(map[string]interface{})["tools"].([]interface{}) {
+				names[tool.(map[string]interface{})["name"].(string)] = true
+			}
+			for _, name := range tt.listed {
+				if !names[name] {
+					t.Errorf("enabled built-in %s should be listed", name)
+				}
+			}
+			for _, name := range tt.hidden {
+				if names[name] {
+					t.Errorf("disabled built-in %s should not be listed", name)
+				}
+			}
+		})
+	}
+}
+
+func TestMCPServer_SetToolEnabledMapsErrors(t *testing.T) {
+	ts := newTestServer(t, enableAdminMethods)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		setToolEnabledRequest(2, "bad name!", true),
+		setToolEnabledRequest(3, "no_such_tool", false),
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+	assertToolError(t, responses[1], vo.ErrorCodeInvalidParams, "bad name!")
+	assertToolError(t, responses[2], vo.ErrorCodeToolNotFound, "no_such_tool")
+}
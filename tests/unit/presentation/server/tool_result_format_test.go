@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+)
+
+func TestMCPServer_ToolsCallMimeTypeHint(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		map[string]interface{}{
+			"id":     2,
+			"method": "tools/call",
+			"params": map[string]interface{}{"name": "system_info", "arguments": map[string]interface{}{}},
+		},
+		map[string]interface{}{
+			"id":     3,
+			"method": "tools/call",
+			"params": map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"message": "hi"}},
+		},
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("expected 3 responses, got %d", len(responses))
+	}
+
+	content := toolCallContent(t, responses[1])
+	if content["mimeType"] != entities.MimeTypeJSON {
+		t.Errorf("system_info mimeType = %v, want %s", content["mimeType"], entities.MimeTypeJSON)
+	}
+	var info map[string]interface{}
+	if err := json.Unmarshal([]byte(content["text"].(string)), &info); err != nil {
+		t.Errorf("system_info text should be JSON: %v", err)
+	}
+
+	if mimeType, ok := toolCallContent(t, responses[2])["mimeType"]; ok {
+		t.Errorf("echo should not carry a mimeType hint, got %v", mimeType)
+	}
+}
+
+// toolCallContent returns the first content item of a tools/call response
+func toolCallContent(t *testing.T, resp JSONRPCResponse) map[string]interface{} {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("response %v: unexpected error %+v", resp.ID, resp.Error)
+	}
+	result, ok := resp.Result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("response %v: expected object result, got %T", resp.ID, resp.Result)
+	}
+	items, ok := result["content"].([]interface{})
+	if !ok || len(items) == 0 {
+		t.Fatalf("response %v: expected content, got %#v", resp.ID, result)
+	}
+	return items[0].(map[string]interface{})
+}
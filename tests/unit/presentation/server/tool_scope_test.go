@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+)
+
+// newProcessListServer returns a test server with admin methods enabled, exposing the
+// admin-scoped list_processes tool to a local client holding scopes
+func newProcessListServer(t *testing.T, scopes ...string) *testServer {
+	t.Helper()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.Server.EnableAdminMethods = true })
+	ts.registry.EnableProcessListing()
+	tool, _ := ts.registry.GetTool("list_processes")
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+	ts.srv.SetLocalScopes(scopes)
+	return ts
+}
+
+func listProcessesCall(id int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "list_processes", "arguments": map[string]interface{}{"limit": 1}},
+	}
+}
+
+func TestMCPServer_ToolCallWithoutRequiredScope(t *testing.T) {
+	ts := newProcessListServer(t)
+
+	responses := ts.call(t, initializeRequest(1), initializedNotification(), listProcessesCall(2))
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	resp := responses[1]
+	if resp.Error == nil {
+		t.Fatalf("expected list_processes to be refused without the admin scope, got %v", resp.Result)
+	}
+	if resp.Error.Code != int(vo.ErrorCodeUnauthorized) {
+		t.Errorf("error code = %d, want %d", resp.Error.Code, vo.ErrorCodeUnauthorized)
+	}
+}
+
+func TestMCPServer_ToolCallWithRequiredScope(t *testing.T) {
+	ts := newProcessListServer(t, tools.ProcessListScope)
+
+	responses := ts.call(t, initializeRequest(1), initializedNotification(), listProcessesCall(2))
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if resp := responses[1]; resp.Error != nil {
+		t.Fatalf("unexpected error with the admin scope granted: %+v", resp.Error)
+	}
+}
+
+func TestMCPServer_ToolScopesNeedAdminMethods(t *testing.T) {
+	disabled := newTestServer(t)
+	disabled.registry.EnableProcessListing()
+	tool, _ := disabled.registry.GetTool("list_processes")
+	if err := disabled.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+	disabled.srv.SetLocalScopes([]string{tools.ProcessListScope})
+
+	responses := disabled.call(t, initializeRequest(1), initializedNotification(), listProcessesCall(2))
+	if resp := responses[1]; resp.Error == nil || resp.Error.Code != int(vo.ErrorCodeUnauthorized) {
+		t.Errorf("expected list_processes to be refused without admin methods, got %+v", resp)
+	}
+}
+
+// fakeAPIKeys is an issued key store holding keys and their scopes
+type fakeAPIKeys map[string][]string
+
+func (f fakeAPIKeys) LookupAPIKey(_ context.Context, key string) ([]string, bool, error) {
+	scopes, ok := f[key]
+	return scopes, ok, nil
+}
+
+func TestMCPServer_ToolScopesFollowTheConnectionAPIKey(t *testing.T) {
+	ts := newProcessListServer(t, tools.ProcessListScope)
+	listener := server.NewWebSocketListener(server.TransportAccess{
+		Keys: fakeAPIKeys{"admin-key": {"read", tools.ProcessListScope}, "user-key": {"read"}},
+	})
+	httpServer := httptest.NewServer(listener)
+	ts.srv.SetListener(listener)
+	done := make(chan error, 1)
+	go func() { done <- ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() {
+		ts.srv.Stop()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Error("server did not stop")
+		}
+		httpServer.Close()
+	})
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + server.WebSocketPath
+	connect := func(key string) *wsClient {
+		header := http.Header{}
+		if key != "" {
+			header.Set("Authorization", "Bearer "+key)
+		}
+		conn, _, err := websocket.DefaultDialer.Dial(url, header)
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+		client := &wsClient{conn: conn}
+		if msg := client.call(t, initializeRequest(1)); msg["error"] != nil {
+			t.Fatalf("initialize failed: %v", msg)
+		}
+		client.send(t, initializedNotification())
+		return client
+	}
+
+	// The local scopes of the server do not extend to remote clients
+	tests := []struct {
+		name    string
+		key     string
+		allowed bool
+	}{
+		{name: "admin key", key: "admin-key", allowed: true},
+		{name: "key without the scope", key: "user-key"},
+		{name: "no key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := connect(tt.key).call(t, listProcessesCall(2))
+			if tt.allowed && msg["error"] != nil {
+				t.Errorf("expected list_processes to succeed, got %v", msg)
+			}
+			if !tt.allowed && msg["error"] == nil {
+				t.Errorf("expected list_processes to be refused, got %v", msg)
+			}
+		})
+	}
+}
+
+// unavailableDB is a database connector that cannot connect
+type unavailableDB struct{}
+
+func (unavailableDB) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("database unavailable")
+}
+func (unavailableDB) Driver() driver.Driver { return nil }
+
+func TestMCPServer_DBQueryNeedsTheCallersScope(t *testing.T) {
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.Server.EnableAdminMethods = true })
+	db := sql.OpenDB(unavailableDB{})
+	t.Cleanup(func() { _ = db.Close() })
+	ts.registry.EnableDBQuery(db, tools.DBQueryOptions{})
+	tool, _ := ts.registry.GetTool("db_query")
+	if err := ts.toolRepo.Register(context.Background(), tool); err != nil {
+		t.Fatal(err)
+	}
+	ts.toolHandler.RegisterToolHandler("db_query", tool.Handler())
+
+	listener := server.NewWebSocketListener(server.TransportAccess{
+		Keys: fakeAPIKeys{"admin-key": {tools.DBQueryScope}, "user-key": {"read"}},
+	})
+	httpServer := httptest.NewServer(listener)
+	ts.srv.SetListener(listener)
+	done := make(chan error, 1)
+	go func() { done <- ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() {
+		ts.srv.Stop()
+		<-done
+		httpServer.Close()
+	})
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + server.WebSocketPath
+	query := map[string]interface{}{
+		"id":     2,
+		"method": "tools/call",
+		"params": map[string]interface{}{"name": "db_query", "arguments": map[string]interface{}{"query": "SELECT id FROM messages"}},
+	}
+	callAs := func(key string) map[string]interface{} {
+		conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Authorization": []string{"Bearer " + key}})
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+		client := &wsClient{conn: conn}
+		client.call(t, initializeRequest(1))
+		client.send(t, initializedNotification())
+		return client.call(t, query)
+	}
+
+	// An admin connected at the same time does not let another client read the tables
+	admin := callAs("admin-key")
+	if errObj, _ := admin["error"].(map[string]interface{}); errObj != nil && errObj["code"] == float64(vo.ErrorCodeUnauthorized) {
+		t.Errorf("expected the admin key to pass the scope check, got %v", admin)
+	}
+	user := callAs("user-key")
+	errObj, _ := user["error"].(map[string]interface{})
+	if errObj == nil || errObj["code"] != float64(vo.ErrorCodeUnauthorized) {
+		t.Errorf("expected db_query to be refused without the scope, got %v", user)
+	}
+}
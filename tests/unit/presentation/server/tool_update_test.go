@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+)
+
+func toolUpdateRequest(id int, name string, patch map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": "tools/update",
+		"params": map[string]interface{}{"name": name, "patch": patch},
+	}
+}
+
+// startAdminSession starts a server with admin methods over a fake transport and
+// completes the initialize handshake
+func startAdminSession(t *testing.T) (*testServer, *fakeTransport) {
+	t.Helper()
+	ts := newTestServer(t, enableAdminMethods)
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() { close(transport.incoming) })
+
+	transport.send(t, initializeRequest(1))
+	transport.receive(t)
+	transport.send(t, initializedNotification())
+	return ts, transport
+}
+
+func TestMCPServer_ToolsUpdatePatchesMutableFields(t *testing.T) {
+	ts, transport := startAdminSession(t)
+	session := ts.srv.Session()
+	echo, _ := ts.registry.GetTool("echo")
+	if err := session.RegisterTool(echo); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	schema := echo.InputSchema()
+
+	transport.send(t, toolUpdateRequest(2, "echo", map[string]interface{}{
+		"description": "Echoes the message back, for connectivity checks",
+		"timeoutMs":   1500,
+		"enabled":     false,
+		"tags":        []string{"debug"},
+		"category":    "diagnostics",
+		"annotations": map[string]interface{}{"readOnlyHint": true, "idempotentHint": true},
+	}))
+	msgs := transport.collect(t, 300*time.Millisecond)
+
+	var result map[string]interface{}
+	notified := false
+	for _, msg := range msgs {
+		if msg["method"] == "notifications/tools/list_changed" {
+			notified = true
+			continue
+		}
+		if msg["error"] != nil {
+			t.Fatalf("tools/update failed: %v", msg["error"])
+		}
+		result, _ = msg["result"].(map[string]interface{})
+	}
+	if !notified {
+		t.Error("expected a tools list changed notification")
+	}
+	if result == nil {
+		t.Fatal("no tools/update response")
+	}
+	if result["description"] != "Echoes the message back, for connectivity checks" || result["enabled"] != false ||
+		result["timeoutMs"] != 1500.0 || result["category"] != "diagnostics" {
+		t.Errorf("result = %v", result)
+	}
+
+	name, _ := vo.NewToolName("echo")
+	stored, _ := ts.toolRepo.FindByName(context.Background(), name)
+	sessionTool, _ := session.GetTool("echo")
+	for source, tool := range map[string]interface{ IsEnabled() bool }{"repository": stored, "session": sessionTool} {
+		if tool.IsEnabled() {
+			t.Errorf("%s tool should be disabled", source)
+		}
+	}
+	if stored.Timeout() != 1500*time.Millisecond || stored.Category() != "diagnostics" ||
+		len(stored.Tags()) != 1 || stored.Tags()[0] != "debug" || !stored.IsReadOnly() {
+		t.Errorf("stored tool timeout = %s category = %q tags = %v read-only = %v",
+			stored.Timeout(), stored.Category(), stored.Tags(), stored.IsReadOnly())
+	}
+	if stored.InputSchema() != schema || stored.Name().String() != "echo" {
+		t.Error("name and schema should be unchanged")
+	}
+}
+
+func TestMCPServer_ToolsUpdateMergesAnnotations(t *testing.T) {
+	ts, transport := startAdminSession(t)
+
+	transport.send(t, toolUpdateRequest(2, "echo", map[string]interface{}{
+		"annotations": map[string]interface{}{"title": "Echo", "idempotentHint": true, "openWorldHint": true},
+	}))
+	transport.collect(t, 200*time.Millisecond)
+	transport.send(t, toolUpdateRequest(3, "echo", map[string]interface{}{
+		"annotations": map[string]interface{}{"openWorldHint": false, "destructiveHint": true},
+	}))
+	transport.collect(t, 200*time.Millisecond)
+
+	name, _ := vo.NewToolName("echo")
+	stored, _ := ts.toolRepo.FindByName(context.Background(), name)
+	annotations := stored.Annotations()
+	if annotations == nil {
+		t.Fatal("annotations were not set")
+	}
+	if annotations.Title != "Echo" || !annotations.IdempotentHint || annotations.OpenWorldHint || !annotations.DestructiveHint {
+		t.Errorf("annotations = %+v, want the second patch merged into the first", annotations)
+	}
+}
+
+func TestMCPServer_ToolsUpdateMapsErrors(t *testing.T) {
+	_, transport := startAdminSession(t)
+
+	tests := []struct {
+		name string
+		tool string
+		code vo.MCPErrorCode
+	}{
+		{"malformed name", "bad name!", vo.ErrorCodeInvalidParams},
+		{"unknown tool", "no_such_tool", vo.ErrorCodeToolNotFound},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport.send(t, toolUpdateRequest(30+i, tt.tool, map[string]interface{}{"enabled": false}))
+			resp := transport.receive(t)
+			errObj, _ := resp["error"].(map[string]interface{})
+			if errObj == nil || errObj["code"] != float64(tt.code) {
+				t.Errorf("error = %v, want code %d", resp["error"], tt.code)
+			}
+		})
+	}
+}
+
+func TestMCPServer_ToolsUpdateRejectsImmutableFields(t *testing.T) {
+	ts, transport := startAdminSession(t)
+	echo, _ := ts.registry.GetTool("echo")
+	description := echo.Description().String()
+
+	for i, patch := range []map[string]interface{}{
+		{"description": "changed", "inputSchema": map[string]interface{}{"type": "object"}},
+		{"name": "echo2"},
+		{"description": "changed", "unknown": true},
+		{"timeoutMs": 0},
+	} {
+		transport.send(t, toolUpdateRequest(10+i, "echo", patch))
+		resp := transport.receive(t)
+		errObj, _ := resp["error"].(map[string]interface{})
+		if errObj == nil || errObj["code"] != float64(vo.ErrorCodeInvalidParams) {
+			t.Errorf("patch %v: expected invalid params, got %v", patch, resp)
+		}
+	}
+
+	transport.send(t, toolUpdateRequest(20, "no_such_tool", map[string]interface{}{"enabled": false}))
+	if resp := transport.receive(t); resp["error"] == nil {
+		t.Error("updating an unknown tool should fail")
+	}
+
+	if echo.Description().String() != description || !echo.IsEnabled() {
+		t.Error("rejected patches should leave the tool unchanged")
+	}
+}
+
+func TestMCPServer_ToolsUpdateRequiresAdminMethods(t *testing.T) {
+	ts := newTestServer(t)
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		toolUpdateRequest(2, "echo", map[string]interface{}{"enabled": false}),
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != int(vo.ErrorCodeMethodNotFound) {
+		t.Errorf("tools/update should be unavailable unless admin methods are enabled, got %+v", responses[1].Error)
+	}
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+func TestMCPServer_ToolUsageResource(t *testing.T) {
+	ts := newTestServer(t)
+
+	callTool := func(id int, name string, args map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"id":     id,
+			"method": "tools/call",
+			"params": map[string]interface{}{"name": name, "arguments": args},
+		}
+	}
+
+	responses := ts.call(t,
+		initializeRequest(1),
+		initializedNotification(),
+		callTool(2, "echo", map[string]interface{}{"message": "one"}),
+		callTool(3, "echo", map[string]interface{}{"message": "two"}),
+		callTool(4, "read_file", map[string]interface{}{"path": "/nonexistent/file.txt"}),
+		map[string]interface{}{
+			"id":     5,
+			"method": "resources/read",
+			"params": map[string]interface{}{"uri": server.ToolUsageResourceURI},
+		},
+	)
+
+	if len(responses) != 5 {
+		t.Fatalf("expected 5 responses, got %d", len(responses))
+	}
+	if responses[4].Error != nil {
+		t.Fatalf("unexpected error: %+v", responses[4].Error)
+	}
+
+	result := responses[4].Result.(map[string]interface{})
+	text := result["contents"].([]interface{})[0].(map[string]interface{})["text"].(string)
+
+	var usage struct {
+		TotalCalls    int `json:"totalCalls"`
+		TotalFailures int `json:"totalFailures"`
+		Tools         []struct {
+			Name     string `json:"name"`
+			Calls    int    `json:"calls"`
+			Failures int    `json:"failures"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal([]byte(text), &usage); err != nil {
+		t.Fatalf("invalid usage JSON: %v", err)
+	}
+
+	if usage.TotalCalls != 3 || usage.TotalFailures != 1 {
+		t.Errorf("totals = %d calls, %d failures, want 3 and 1", usage.TotalCalls, usage.TotalFailures)
+	}
+	if len(usage.Tools) != 2 {
+		t.Fatalf("expected 2 tools, got %+v", usage.Tools)
+	}
+	if usage.Tools[0].Name != "echo" || usage.Tools[0].Calls != 2 || usage.Tools[0].Failures != 0 {
+		t.Errorf("unexpected echo usage: %+v", usage.Tools[0])
+	}
+	if usage.Tools[1].Name != "read_file" || usage.Tools[1].Failures != 1 {
+		t.Errorf("unexpected read_file usage: %+v", usage.Tools[1])
+	}
+}
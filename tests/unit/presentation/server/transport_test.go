@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// fakeTransport is an in-memory transport fed by the test
+type fakeTransport struct {
+	incoming chan []byte
+	outgoing chan []byte
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func newFakeTransport() *fakeTransport {
+	return &fakeTransport{incoming: make(chan []byte, 16), outgoing: make(chan []byte, 16)}
+}
+
+func (f *fakeTransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	select {
+	case data, ok := <-f.incoming:
+		if !ok {
+			return nil, io.EOF
+		}
+		return data, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *fakeTransport) WriteMessage(ctx context.Context, data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return server.ErrTransportClosed
+	}
+	f.outgoing <- append([]byte(nil), data...)
+	return nil
+}
+
+func (f *fakeTransport) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeTransport) isClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+func (f *fakeTransport) send(t *testing.T, req map[string]interface{}) {
+	t.Helper()
+	req["jsonrpc"] = "2.0"
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	f.incoming <- data
+}
+
+func (f *fakeTransport) receive(t *testing.T) map[string]interface{} {
+	t.Helper()
+	select {
+	case data := <-f.outgoing:
+		var msg map[string]interface{}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("invalid message %q: %v", data, err)
+		}
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func TestMCPServer_FakeTransport(t *testing.T) {
+	ts := newTestServer(t)
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+
+	done := make(chan error, 1)
+	go func() { done <- ts.srv.Run(context.Background()) }()
+
+	transport.send(t, initializeRequest(1))
+	if msg := transport.receive(t); msg["error"] != nil || msg["id"] != float64(1) {
+		t.Fatalf("unexpected initialize response: %v", msg)
+	}
+	transport.send(t, initializedNotification())
+	transport.send(t, map[string]interface{}{"id": 2, "method": "tools/list"})
+
+	msg := transport.receive(t)
+	if msg["id"] != float64(2) || msg["error"] != nil {
+		t.Fatalf("unexpected tools/list response: %v", msg)
+	}
+	result, _ := msg["result"].(map[string]interface{})
+	if tools, _ := result["tools"].([]interface{}); len(tools) == 0 {
+		t.Error("expected tools in tools/list response")
+	}
+
+	close(transport.incoming)
+	select {
+	case err := <-done:
+		if err != io.EOF {
+			t.Errorf("expected io.EOF when the client disconnects, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not stop at end of input")
+	}
+	if !transport.isClosed() {
+		t.Error("expected the server to close the transport")
+	}
+}
+
+func TestMCPServer_NotificationsUseTransport(t *testing.T) {
+	ts := newTestServer(t)
+	transport := newFakeTransport()
+	ts.srv.SetTransport(transport)
+
+	go func() { _ = ts.srv.Run(context.Background()) }()
+	defer close(transport.incoming)
+
+	transport.send(t, initializeRequest(1))
+	transport.receive(t)
+
+	ts.srv.Stop()
+	msg := transport.receive(t)
+	if msg["method"] != "notifications/message" {
+		t.Errorf("expected shutdown notification through the transport, got %v", msg)
+	}
+}
+
+func TestMCPServer_NotificationWithoutTransport(t *testing.T) {
+	ts := newTestServer(t)
+	err := ts.srv.SendNotification("notifications/message", nil)
+	if !errors.Is(err, server.ErrTransportClosed) {
+		t.Errorf("expected ErrTransportClosed, got %v", err)
+	}
+}
+
+func TestStdioTransport(t *testing.T) {
+	ctx := context.Background()
+	var out bytes.Buffer
+	transport := server.NewStdioTransport(strings.NewReader("\n{\"a\":1}\n\n{\"b\":2}\n"), &out)
+
+	for _, want := range []string{`{"a":1}`, `{"b":2}`} {
+		data, err := transport.ReadMessage(ctx)
+		if err != nil {
+			t.Fatalf("ReadMessage failed: %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("expected %s, got %s", want, data)
+		}
+	}
+	if _, err := transport.ReadMessage(ctx); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+
+	if err := transport.WriteMessage(ctx, []byte(`{"c":3}`)); err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+	if out.String() != "{\"c\":3}\n" {
+		t.Errorf("expected newline-delimited output, got %q", out.String())
+	}
+
+	if err := transport.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := transport.WriteMessage(ctx, []byte(`{}`)); err != server.ErrTransportClosed {
+		t.Errorf("expected ErrTransportClosed after Close, got %v", err)
+	}
+	if _, err := transport.ReadMessage(ctx); err != server.ErrTransportClosed {
+		t.Errorf("expected ErrTransportClosed after Close, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/config"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/server"
+)
+
+// wsClient is a client of the WebSocket transport
+type wsClient struct {
+	conn *websocket.Conn
+}
+
+// connectWebSocket opens a WebSocket connection to the server at baseURL
+func connectWebSocket(t *testing.T, baseURL string) *wsClient {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(baseURL, "http") + server.WebSocketPath
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &wsClient{conn: conn}
+}
+
+func (c *wsClient) send(t *testing.T, msg map[string]interface{}) {
+	t.Helper()
+	msg["jsonrpc"] = "2.0"
+	if err := c.conn.WriteJSON(msg); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+}
+
+func (c *wsClient) next(t *testing.T) map[string]interface{} {
+	t.Helper()
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg map[string]interface{}
+	if err := c.conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	return msg
+}
+
+// call sends a request and returns the message answering it
+func (c *wsClient) call(t *testing.T, req map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	c.send(t, req)
+	for {
+		msg := c.next(t)
+		if msg["id"] == float64(req["id"].(int)) {
+			return msg
+		}
+	}
+}
+
+// startWebSocketServer runs a server accepting WebSocket connections on a test HTTP server
+func startWebSocketServer(t *testing.T, opts ...func(*config.Config)) (*testServer, string) {
+	t.Helper()
+	ts := newTestServer(t, opts...)
+	listener := server.NewWebSocketListener(server.TransportAccess{})
+	httpServer := httptest.NewServer(listener)
+	ts.srv.SetListener(listener)
+
+	done := make(chan error, 1)
+	go func() { done <- ts.srv.Run(context.Background()) }()
+	t.Cleanup(func() {
+		ts.srv.Stop()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Error("server did not stop")
+		}
+		httpServer.Close()
+	})
+	return ts, httpServer.URL
+}
+
+func TestWebSocketTransport_SessionPerConnection(t *testing.T) {
+	ts, baseURL := startWebSocketServer(t)
+
+	clients := []*wsClient{connectWebSocket(t, baseURL), connectWebSocket(t, baseURL)}
+	sessionIDs := make(map[string]bool)
+	for _, client := range clients {
+		msg := client.call(t, initializeRequest(1))
+		if msg["error"] != nil {
+			t.Fatalf("initialize failed: %v", msg)
+		}
+		client.send(t, initializedNotification())
+		metrics := client.call(t, map[string]interface{}{"id": 2, "method": "session/metrics"})
+		result, _ := metrics["result"].(map[string]interface{})
+		id, _ := result["sessionId"].(string)
+		if id == "" {
+			t.Fatalf("unexpected session/metrics response: %v", metrics)
+		}
+		sessionIDs[id] = true
+	}
+	if len(sessionIDs) != 2 {
+		t.Errorf("expected two distinct sessions, got %v", sessionIDs)
+	}
+
+	// Notifications are pushed to every connection without the client asking
+	if err := ts.srv.SendNotification(vo.MethodNotificationsToolsListChanged, nil); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+	for i, client := range clients {
+		msg := client.next(t)
+		if msg["method"] != string(vo.MethodNotificationsToolsListChanged) {
+			t.Errorf("client %d: expected tools/list_changed notification, got %v", i, msg)
+		}
+	}
+}
+
+func TestWebSocketTransport_RejectsOtherOrigins(t *testing.T) {
+	listener := server.NewWebSocketListener(server.TransportAccess{AllowedOrigins: []string{"https://allowed.example"}})
+	httpServer := httptest.NewServer(listener)
+	defer httpServer.Close()
+	defer func() { _ = listener.Close() }()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + server.WebSocketPath
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://evil.example"}})
+	if err == nil {
+		t.Fatal("expected upgrade from a foreign origin to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for a foreign origin, got %v", resp)
+	}
+
+	// An allowed origin is upgraded and handed to Accept
+	accepted := make(chan error, 1)
+	go func() {
+		transport, err := listener.Accept(context.Background())
+		if err == nil {
+			_ = transport.Close()
+		}
+		accepted <- err
+	}()
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://allowed.example"}})
+	if err != nil {
+		t.Fatalf("expected upgrade from an allowed origin to succeed: %v", err)
+	}
+	_ = conn.Close()
+	if err := <-accepted; err != nil {
+		t.Errorf("Accept failed: %v", err)
+	}
+}
+
+// startWebSocketListener serves listener on a test HTTP server, accepting and closing
+// every connection it hands over, and returns the WebSocket URL
+func startWebSocketListener(t *testing.T, listener *server.WebSocketListener) string {
+	t.Helper()
+	httpServer := httptest.NewServer(listener)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			transport, err := listener.Accept(context.Background())
+			if err != nil {
+				return
+			}
+			_ = transport.Close()
+		}
+	}()
+	return "ws" + strings.TrimPrefix(httpServer.URL, "http") + server.WebSocketPath
+}
+
+func TestWebSocketTransport_WildcardOriginDoesNotAllowOtherOrigins(t *testing.T) {
+	// The default CORS setting allows "*", which must not open the WebSocket to every page
+	url := startWebSocketListener(t, server.NewWebSocketListener(server.TransportAccess{AllowedOrigins: []string{"*"}}))
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://evil.example"}})
+	if err == nil {
+		t.Fatal("expected upgrade from a foreign origin to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for a foreign origin, got %v", resp)
+	}
+
+	// The server's own origin is still accepted
+	sameOrigin := "http" + strings.TrimPrefix(strings.TrimSuffix(url, server.WebSocketPath), "ws")
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{sameOrigin}})
+	if err != nil {
+		t.Fatalf("expected upgrade from the server's own origin to succeed: %v", err)
+	}
+	_ = conn.Close()
+}
+
+func TestWebSocketTransport_RequiresAPIKey(t *testing.T) {
+	url := startWebSocketListener(t, server.NewWebSocketListener(server.TransportAccess{
+		RequireAPIKey: true,
+		APIKeys:       []string{"secret-key"},
+	}))
+
+	tests := []struct {
+		name   string
+		url    string
+		header http.Header
+		ok     bool
+	}{
+		{name: "no key", url: url},
+		{name: "wrong key", url: url, header: http.Header{"Authorization": []string{"Bearer other-key"}}},
+		{name: "bearer token", url: url, header: http.Header{"Authorization": []string{"Bearer secret-key"}}, ok: true},
+		{name: "query parameter", url: url + "?api_key=secret-key", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, resp, err := websocket.DefaultDialer.Dial(tt.url, tt.header)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("expected upgrade to succeed: %v", err)
+				}
+				_ = conn.Close()
+				return
+			}
+			if err == nil {
+				_ = conn.Close()
+				t.Fatal("expected upgrade to fail")
+			}
+			if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("expected 401, got %v", resp)
+			}
+		})
+	}
+}
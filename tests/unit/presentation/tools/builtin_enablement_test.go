@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func TestConfigureBuiltinTools(t *testing.T) {
+	t.Run("disabled tools stay registered", func(t *testing.T) {
+		registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+		if err := registry.ConfigureBuiltinTools(nil, []string{"execute_command"}); err != nil {
+			t.Fatalf("ConfigureBuiltinTools() error = %v", err)
+		}
+		tool, ok := registry.GetTool("execute_command")
+		if !ok || tool.IsEnabled() {
+			t.Error("execute_command should be registered disabled")
+		}
+		if echo, _ := registry.GetTool("echo"); !echo.IsEnabled() {
+			t.Error("echo should stay enabled")
+		}
+	})
+
+	t.Run("unknown names are rejected", func(t *testing.T) {
+		registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+		for _, lists := range [][2][]string{
+			{{"no_such_tool"}, nil},
+			{nil, {"no_such_tool"}},
+			{nil, {"list_processes"}}, // Not enabled, so not a built-in here
+		} {
+			if err := registry.ConfigureBuiltinTools(lists[0], lists[1]); !errors.Is(err, tools.ErrUnknownBuiltinTool) {
+				t.Errorf("ConfigureBuiltinTools(%v, %v) error = %v, want ErrUnknownBuiltinTool", lists[0], lists[1], err)
+			}
+		}
+		if echo, _ := registry.GetTool("echo"); !echo.IsEnabled() {
+			t.Error("a rejected configuration should not disable tools")
+		}
+	})
+	t.Run("optional built-ins can be configured", func(t *testing.T) {
+		registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+		registry.EnableProcessListing()
+		if err := registry.ConfigureBuiltinTools(nil, []string{"list_processes"}); err != nil {
+			t.Fatalf("ConfigureBuiltinTools() error = %v", err)
+		}
+		if tool, _ := registry.GetTool("list_processes"); tool.IsEnabled() {
+			t.Error("list_processes should be disabled")
+		}
+
+		registry = tools.NewToolRegistry(mocks.NewMockClaudeService())
+		registry.EnableProcessListing()
+		if err := registry.ConfigureBuiltinTools([]string{"echo"}, nil); err != nil {
+			t.Fatalf("ConfigureBuiltinTools() error = %v", err)
+		}
+		if tool, _ := registry.GetTool("list_processes"); tool.IsEnabled() {
+			t.Error("enabled_tools should turn list_processes off when it does not name it")
+		}
+	})
+}
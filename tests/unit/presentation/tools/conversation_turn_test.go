@@ -0,0 +1,344 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/commands"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/handlers"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/application/queries"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/infrastructure/persistence"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/resources"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(ctx context.Context, event interface{}) error { return nil }
+
+// conversationFixture runs built-in tools through a tool handler whose registry routes
+// claude_conversation through the conversation handler
+type conversationFixture struct {
+	registry      *tools.ToolRegistry
+	toolHandler   *handlers.ToolHandler
+	conversations *handlers.ConversationHandler
+	sessionRepo   *persistence.InMemorySessionRepository
+	session       *aggregates.Session
+}
+
+func newConversationFixture(t *testing.T, claude *mocks.MockClaudeService) *conversationFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionRepo := persistence.NewInMemorySessionRepository()
+	toolRepo := persistence.NewInMemoryToolRepository()
+	session := aggregates.NewSession()
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	toolHandler := handlers.NewToolHandler(sessionRepo, toolRepo, nopPublisher{})
+	conversations := handlers.NewConversationHandler(sessionRepo, persistence.NewInMemoryConversationRepository(), claude, nopPublisher{})
+	conversations.SetToolHandler(toolHandler)
+
+	registry := tools.NewToolRegistry(claude)
+	registry.SetConversationHandler(conversations)
+	for _, tool := range registry.GetTools() {
+		require.NoError(t, toolRepo.Register(ctx, tool))
+	}
+
+	return &conversationFixture{registry: registry, toolHandler: toolHandler, conversations: conversations, sessionRepo: sessionRepo, session: session}
+}
+
+// converseInSession calls claude_conversation as tools/call would, within the fixture session
+func (f *conversationFixture) converseInSession(t *testing.T, input map[string]interface{}) *entities.ToolResult {
+	t.Helper()
+	result, err := f.toolHandler.HandleExecuteTool(context.Background(), &commands.ExecuteToolCommand{
+		SessionID: f.session.ID(),
+		Name:      "claude_conversation",
+		Arguments: input,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+// history returns the messages of the conversation a claude_conversation result ran in
+func (f *conversationFixture) history(t *testing.T, result *entities.ToolResult) []*entities.Message {
+	t.Helper()
+	id, err := vo.NewConversationID(result.Meta["conversationId"].(string))
+	require.NoError(t, err)
+	messages, err := f.conversations.HandleGetConversationMessages(context.Background(), &queries.GetConversationMessagesQuery{ConversationID: id})
+	require.NoError(t, err)
+	return messages
+}
+
+func TestClaudeConversationTool_RunsAgenticTurn(t *testing.T) {
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeToolUseResponse("echo", "toolu_1", map[string]interface{}{"message": "ping"}), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeResponse("echo said ping"), nil).Once()
+
+	f := newConversationFixture(t, claude)
+	result := f.converseInSession(t, map[string]interface{}{
+		"message": "call echo",
+		"tools":   []interface{}{"echo"},
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "echo said ping", result.Content[0].Text)
+	assert.Equal(t, 2, result.Meta["iterations"])
+	assert.Equal(t, []string{"echo"}, result.Meta["toolsInvoked"])
+	assert.Len(t, f.history(t, result), 4)
+	claude.AssertExpectations(t)
+}
+
+func TestClaudeConversationTool_ContinuesConversation(t *testing.T) {
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(mocks.MockClaudeResponse("first"), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(mocks.MockClaudeResponse("second"), nil).Once()
+
+	f := newConversationFixture(t, claude)
+	first := f.converseInSession(t, map[string]interface{}{"message": "hello"})
+	require.False(t, first.IsError, first.Content[0].Text)
+
+	second := f.converseInSession(t, map[string]interface{}{
+		"message":         "again",
+		"conversation_id": first.Meta["conversationId"],
+	})
+	require.False(t, second.IsError, second.Content[0].Text)
+
+	assert.Equal(t, first.Meta["conversationId"], second.Meta["conversationId"])
+	assert.Len(t, f.history(t, second), 4)
+}
+
+func TestClaudeConversationTool_ResumesFromAnotherSession(t *testing.T) {
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(mocks.MockClaudeResponse("first"), nil).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(mocks.MockClaudeResponse("second"), nil).Once()
+
+	f := newConversationFixture(t, claude)
+	tokens, err := handlers.NewResumeTokens(nil, time.Hour)
+	require.NoError(t, err)
+	f.conversations.SetResumeTokens(tokens)
+
+	first := f.converseInSession(t, map[string]interface{}{"message": "hello"})
+	require.False(t, first.IsError, first.Content[0].Text)
+	token, ok := first.Meta["resumeToken"].(string)
+	require.True(t, ok, "no resume token returned")
+
+	// A stateless client comes back in a new session with only the token
+	f.session = aggregates.NewSession()
+	require.NoError(t, f.sessionRepo.Save(context.Background(), f.session))
+
+	tampered := f.converseInSession(t, map[string]interface{}{"message": "again", "resume_token": token + "x"})
+	require.True(t, tampered.IsError)
+	assert.Contains(t, tampered.Content[0].Text, handlers.ErrInvalidResumeToken.Error())
+
+	mismatched := f.converseInSession(t, map[string]interface{}{
+		"message":         "again",
+		"resume_token":    token,
+		"conversation_id": vo.GenerateConversationID().String(),
+	})
+	require.True(t, mismatched.IsError)
+	assert.Contains(t, mismatched.Content[0].Text, tools.ErrResumeTokenMismatch.Error())
+
+	second := f.converseInSession(t, map[string]interface{}{"message": "again", "resume_token": token})
+	require.False(t, second.IsError, second.Content[0].Text)
+	assert.Equal(t, first.Meta["conversationId"], second.Meta["conversationId"])
+	assert.Len(t, f.history(t, second), 4)
+}
+
+func TestClaudeConversationTool_TruncatedReplyKeptInHistory(t *testing.T) {
+	reply := strings.Repeat("a", 150)
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(mocks.MockClaudeResponse(reply), nil).Once()
+
+	f := newConversationFixture(t, claude)
+	f.registry.SetMaxResponseChars(100)
+	result := f.converseInSession(t, map[string]interface{}{"message": "hello"})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, true, result.Meta["truncated"])
+	assert.NotContains(t, result.Content[0].Text, strings.Repeat("a", 101))
+	assert.NotContains(t, result.Meta, "fullText")
+
+	messages := f.history(t, result)
+	require.Len(t, messages, 2)
+	assert.Equal(t, reply, messages[1].GetTextContent())
+}
+
+func TestClaudeConversationTool_RejectsItselfAsTool(t *testing.T) {
+	f := newConversationFixture(t, mocks.NewMockClaudeService())
+	result := f.converseInSession(t, map[string]interface{}{
+		"message": "recurse",
+		"tools":   []interface{}{"claude_conversation"},
+	})
+
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, tools.ErrRecursiveConversationTool.Error())
+}
+
+func TestClaudeConversationTool_NotReadOnly(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	tool, ok := registry.GetTool("claude_conversation")
+	require.True(t, ok)
+
+	// A turn can run mutating tools, so its results must never be cached
+	assert.False(t, tool.IsReadOnly())
+	assert.False(t, tool.IsCacheable())
+}
+
+func TestClaudeConversationTool_Attachments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(path, []byte("remember the milk"), 0644))
+
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.MatchedBy(func(req *services.ClaudeRequest) bool {
+		content := req.Messages[0].Content
+		return len(content) == 2 && strings.Contains(content[1].Text, "remember the milk")
+	})).Return(mocks.MockClaudeResponse("noted"), nil).Once()
+
+	f := newConversationFixture(t, claude)
+	f.conversations.SetResourceResolver(resources.NewResourceHandler([]string{dir}, 0))
+	result := f.converseInSession(t, map[string]interface{}{
+		"message":     "summarize",
+		"attachments": []interface{}{"file://" + path},
+	})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	messages := f.history(t, result)
+	assert.Equal(t, "file://"+path, messages[0].Content()[1].URI)
+	claude.AssertExpectations(t)
+}
+
+func TestClaudeConversationTool_AttachmentsNeedConversation(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	result := runFileOp(t, registry, "claude_conversation", map[string]interface{}{
+		"message":     "summarize",
+		"attachments": []interface{}{"file:///tmp/notes.txt"},
+	})
+
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, tools.ErrAttachmentsNeedConversation.Error())
+}
+
+func TestClaudeConversationTool_StartsFromTemplate(t *testing.T) {
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.MatchedBy(func(req *services.ClaudeRequest) bool {
+		names := make([]string, 0, len(req.Tools))
+		for _, tool := range req.Tools {
+			names = append(names, tool.Name)
+		}
+		return req.MaxTokens == 8192 && req.Temperature == 0.2 &&
+			strings.HasPrefix(req.SystemPrompt.String(), "You are a careful software engineer") &&
+			assert.ObjectsAreEqual([]string{"read_file", "list_directory", "search_files"}, names)
+	})).Return(mocks.MockClaudeResponse("ready"), nil).Once()
+
+	f := newConversationFixture(t, claude)
+	templates, err := persistence.DefaultConversationTemplates()
+	require.NoError(t, err)
+	for _, template := range templates {
+		f.session.RegisterConversationTemplate(template)
+	}
+
+	result := f.converseInSession(t, map[string]interface{}{"message": "hello", "template": "code_assistant"})
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	claude.AssertExpectations(t)
+
+	unknown := f.converseInSession(t, map[string]interface{}{"message": "hello", "template": "missing"})
+	require.True(t, unknown.IsError)
+	assert.Contains(t, unknown.Content[0].Text, aggregates.ErrTemplateNotFound.Error())
+}
+
+func TestClaudeConversationTool_TemplateNeedsConversation(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	result := runFileOp(t, registry, "claude_conversation", map[string]interface{}{
+		"message":  "hello",
+		"template": "code_assistant",
+	})
+
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, tools.ErrTemplateNeedsConversation.Error())
+}
+
+func TestClaudeConversationTool_StreamsStatelessReply(t *testing.T) {
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessageStream", mock.Anything, mock.Anything).
+		Return(mocks.MockClaudeStreamEvents("a reply streamed in several chunks"), nil).Once()
+
+	registry := tools.NewToolRegistry(claude)
+	tool, ok := registry.GetTool("claude_conversation")
+	require.True(t, ok)
+
+	var chunks []string
+	ctx := entities.ContextWithToolOutput(context.Background(), func(chunk string) {
+		chunks = append(chunks, chunk)
+	})
+	result, err := tool.ExecuteContext(ctx, map[string]interface{}{"message": "hello"})
+	require.NoError(t, err)
+
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Greater(t, len(chunks), 1)
+	assert.Equal(t, "a reply streamed in several chunks", strings.Join(chunks, ""))
+	assert.Equal(t, "a reply streamed in several chunks", result.Content[0].Text)
+	claude.AssertExpectations(t)
+}
+
+func TestClaudeConversationTool_EditsLastMessage(t *testing.T) {
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(nil, errors.New("overloaded")).Once()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(nil, errors.New("overloaded")).Once()
+	claude.On("CreateMessage", mock.Anything, mock.MatchedBy(func(request *services.ClaudeRequest) bool {
+		return len(request.Messages) == 1 && request.Messages[0].Content[0].Text == "edited again"
+	})).Return(mocks.MockClaudeResponse("answer"), nil).Once()
+
+	f := newConversationFixture(t, claude)
+	failed := f.converseInSession(t, map[string]interface{}{"message": "original"})
+	require.True(t, failed.IsError)
+	conversationID := failed.Meta["conversationId"]
+	require.NotEmpty(t, conversationID)
+
+	// The edit is kept even though Claude fails again
+	retried := f.converseInSession(t, map[string]interface{}{
+		"message":           "edited",
+		"conversation_id":   conversationID,
+		"edit_last_message": true,
+	})
+	require.True(t, retried.IsError)
+	messages := f.history(t, retried)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "edited", messages[0].GetTextContent())
+
+	result := f.converseInSession(t, map[string]interface{}{
+		"message":           "edited again",
+		"conversation_id":   conversationID,
+		"edit_last_message": true,
+	})
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "answer", result.Content[0].Text)
+	assert.Len(t, f.history(t, result), 2)
+	claude.AssertExpectations(t)
+}
+
+func TestClaudeConversationTool_EditNeedsConversation(t *testing.T) {
+	f := newConversationFixture(t, mocks.NewMockClaudeService())
+
+	result := f.converseInSession(t, map[string]interface{}{"message": "edited", "edit_last_message": true})
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, tools.ErrEditNeedsConversation.Error())
+}
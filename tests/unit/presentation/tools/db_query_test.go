@@ -0,0 +1,239 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// fakeDB is a database/sql driver returning fixed rows and recording what it ran
+type fakeDB struct {
+	mu       sync.Mutex
+	columns  []string
+	rows     [][]driver.Value
+	queries  []string
+	readOnly []bool
+	commits  int
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("use BeginTx") }
+
+func (c *fakeConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.readOnly = append(c.db.readOnly, opts.ReadOnly)
+	return &fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.queries = append(c.db.queries, query)
+	return &fakeRows{columns: c.db.columns, rows: c.db.rows}, nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (t *fakeTx) Commit() error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	t.db.commits++
+	return nil
+}
+func (t *fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// newDBQueryRegistry returns a registry with db_query enabled over a fake database
+// holding rows of sessions
+func newDBQueryRegistry(t *testing.T, rows int, opts tools.DBQueryOptions) (*tools.ToolRegistry, *fakeDB) {
+	t.Helper()
+	fake := &fakeDB{columns: []string{"id", "state"}}
+	for i := 0; i < rows; i++ {
+		fake.rows = append(fake.rows, []driver.Value{int64(i + 1), []byte("ready")})
+	}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { _ = db.Close() })
+
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.EnableDBQuery(db, opts)
+	return registry, fake
+}
+
+func TestDBQuery_NotRegisteredByDefault(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	if _, ok := registry.GetTool("db_query"); ok {
+		t.Error("db_query should not be registered unless enabled")
+	}
+}
+
+func TestDBQuery_RequiresAdminScope(t *testing.T) {
+	registry, _ := newDBQueryRegistry(t, 0, tools.DBQueryOptions{})
+	tool, ok := registry.GetTool("db_query")
+	if !ok {
+		t.Fatal("db_query should be registered once enabled")
+	}
+	if tool.RequiredScope() != tools.DBQueryScope || tools.DBQueryScope != "admin" {
+		t.Errorf("required scope = %q, want admin", tool.RequiredScope())
+	}
+	if tool.Annotations() == nil || !tool.Annotations().ReadOnlyHint {
+		t.Error("db_query should be annotated read-only")
+	}
+}
+
+func TestDBQuery_Select(t *testing.T) {
+	registry, fake := newDBQueryRegistry(t, 2, tools.DBQueryOptions{})
+
+	result := runFileOp(t, registry, "db_query", map[string]interface{}{
+		"query": "SELECT id, state FROM sessions WHERE state = 'ready';",
+	})
+	if result.IsError {
+		t.Fatalf("query failed: %s", result.Content[0].Text)
+	}
+	var out tools.DBQueryResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.RowCount != 2 || out.Truncated {
+		t.Errorf("row_count = %d truncated = %v, want 2 rows", out.RowCount, out.Truncated)
+	}
+	if out.Rows[0]["state"] != "ready" || out.Rows[1]["id"] != 2.0 {
+		t.Errorf("rows = %v", out.Rows)
+	}
+
+	if len(fake.queries) != 1 {
+		t.Fatalf("ran %d queries, want 1", len(fake.queries))
+	}
+	ran := fake.queries[0]
+	if !strings.Contains(ran, "WHERE state = 'ready'") || strings.Contains(ran, ";") || !strings.HasSuffix(ran, "LIMIT 101") {
+		t.Errorf("ran %q, want the statement wrapped with a row limit", ran)
+	}
+	if len(fake.readOnly) != 1 || !fake.readOnly[0] || fake.commits != 0 {
+		t.Errorf("transactions read-only = %v commits = %d, want one rolled back read-only transaction", fake.readOnly, fake.commits)
+	}
+}
+
+func TestDBQuery_TruncatesAtMaxRows(t *testing.T) {
+	registry, _ := newDBQueryRegistry(t, 5, tools.DBQueryOptions{MaxRows: 10})
+
+	result := runFileOp(t, registry, "db_query", map[string]interface{}{"query": "SELECT * FROM sessions", "max_rows": 3.0})
+	var out tools.DBQueryResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &out); err != nil {
+		t.Fatalf("decode %q: %v", result.Content[0].Text, err)
+	}
+	if out.RowCount != 3 || !out.Truncated {
+		t.Errorf("row_count = %d truncated = %v, want 3 truncated rows", out.RowCount, out.Truncated)
+	}
+
+	result = runFileOp(t, registry, "db_query", map[string]interface{}{"query": "SELECT * FROM sessions", "max_rows": 11.0})
+	if !result.IsError {
+		t.Error("max_rows above the configured limit should be rejected")
+	}
+}
+
+func TestDBQuery_RejectsWrites(t *testing.T) {
+	registry, fake := newDBQueryRegistry(t, 1, tools.DBQueryOptions{})
+
+	for _, query := range []string{
+		"INSERT INTO sessions (id) VALUES ('x')",
+		"UPDATE sessions SET state = 'closed'",
+		"DELETE FROM sessions",
+		"SELECT 1; DROP TABLE sessions",
+		"WITH gone AS (DELETE FROM sessions RETURNING *) SELECT * FROM gone",
+		"SELECT * INTO backup FROM sessions",
+	} {
+		result := runFileOp(t, registry, "db_query", map[string]interface{}{"query": query})
+		if !result.IsError {
+			t.Errorf("%q was not rejected", query)
+		}
+	}
+	if len(fake.queries) != 0 || len(fake.readOnly) != 0 {
+		t.Errorf("rejected queries reached the database: %v", fake.queries)
+	}
+}
+
+func TestValidateReadOnlyQuery(t *testing.T) {
+	allowed := []string{"sessions", "tool_executions"}
+
+	tests := []struct {
+		name  string
+		query string
+		want  error
+	}{
+		{"select", "select * from sessions", nil},
+		{"qualified and aliased", `SELECT s.id FROM public.sessions AS s JOIN tool_executions e ON e.session_id = s.id`, nil},
+		{"comma list", "SELECT * FROM sessions s, tool_executions", nil},
+		{"cte", "WITH recent AS (SELECT * FROM sessions) SELECT * FROM recent", nil},
+		{"subquery", "SELECT * FROM (SELECT id FROM sessions) sub, tool_executions", nil},
+		{"extract", "SELECT extract(epoch FROM created_at) FROM sessions", nil},
+		{"keywords in strings and comments", "SELECT 'delete; drop' AS note FROM sessions -- update\n", nil},
+		{"trailing semicolons", "SELECT 1 FROM sessions;;", nil},
+		{"insert", "INSERT INTO sessions DEFAULT VALUES", tools.ErrQueryNotReadOnly},
+		{"update", "update sessions set state = 'x'", tools.ErrQueryNotReadOnly},
+		{"multiple statements", "SELECT 1; SELECT 2", tools.ErrQueryNotReadOnly},
+		{"admin function", "SELECT pg_sleep(10)", tools.ErrQueryNotReadOnly},
+		{"quoted admin function", `SELECT "pg_terminate_backend"(1)`, tools.ErrQueryNotReadOnly},
+		{"settings", "SELECT current_setting('data_directory')", tools.ErrQueryNotReadOnly},
+		{"table not allowed", "SELECT * FROM api_keys", tools.ErrQueryTableNotAllowed},
+		{"table after a subquery", "SELECT * FROM (SELECT 1) sub, api_keys", tools.ErrQueryTableNotAllowed},
+		{"table in a subquery", "SELECT * FROM sessions WHERE id IN (SELECT session_id FROM api_keys)", tools.ErrQueryTableNotAllowed},
+		{"catalog schema", "SELECT * FROM pg_catalog.sessions", tools.ErrQueryTableNotAllowed},
+		{"table function", "SELECT * FROM generate_series(1, 10)", tools.ErrQueryTableNotAllowed},
+		{"functions", "SELECT count(*), lower(client_name), coalesce(max(created_at), now()) FROM sessions GROUP BY 2", nil},
+		{"cast with modifiers", "SELECT CAST(id AS varchar(36)), state::character varying(20) FROM sessions", nil},
+		{"cte with columns", "WITH recent (session_id) AS (SELECT id FROM sessions) SELECT * FROM recent", nil},
+		{"subquery alias with columns", "SELECT * FROM (SELECT id FROM sessions) sub(session_id)", nil},
+		{"table in a from subquery", "SELECT * FROM (TABLE api_keys) t", tools.ErrQueryNotReadOnly},
+		{"table in an in list", "SELECT * FROM sessions WHERE id IN (TABLE api_keys)", tools.ErrQueryNotReadOnly},
+		{"table to xml", "SELECT table_to_xml_and_xmlschema('api_keys', true, false, '')", tools.ErrQueryNotReadOnly},
+		{"schema to xml", "SELECT schema_to_xml('public', true, false, '')", tools.ErrQueryNotReadOnly},
+		{"database to xml", "SELECT database_to_xml(true, false, '')", tools.ErrQueryNotReadOnly},
+		{"query to xml", "SELECT query_to_xml('SELECT * FROM api_keys', true, false, '')", tools.ErrQueryNotReadOnly},
+		{"read file", "SELECT pg_read_file('/etc/passwd')", tools.ErrQueryNotReadOnly},
+		{"unknown function", "SELECT some_function(id) FROM sessions", tools.ErrQueryNotReadOnly},
+		{"unterminated string", "SELECT 'oops FROM sessions", tools.ErrQuerySyntax},
+		{"escape string", `SELECT E'\'' FROM sessions`, tools.ErrQuerySyntax},
+		{"dollar quoting", "SELECT $$x$$ FROM sessions", tools.ErrQuerySyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tools.ValidateReadOnlyQuery(tt.query, allowed)
+			if tt.want == nil && err != nil {
+				t.Errorf("ValidateReadOnlyQuery() error = %v, want none", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("ValidateReadOnlyQuery() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
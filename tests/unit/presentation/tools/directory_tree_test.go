@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newTreeFixture creates a nested temporary directory tree
+func newTreeFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"a.txt":              "aaaa",
+		"sub/b.txt":          "bb",
+		"sub/deep/c.txt":     "c",
+		"sub/deep/d.txt":     "dd",
+		"sub/deep/e/f/g.txt": "g",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func callDirectoryTree(t *testing.T, registry *tools.ToolRegistry, input map[string]interface{}) string {
+	t.Helper()
+	tool, ok := registry.GetTool("directory_tree")
+	if !ok {
+		t.Fatal("directory_tree should be registered")
+	}
+	result, err := tool.Handler()(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+	}
+	return result.Content[0].Text
+}
+
+func findChild(node *tools.TreeNode, name string) *tools.TreeNode {
+	for _, child := range node.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+func TestDirectoryTreeTool_DepthLimit(t *testing.T) {
+	root := newTreeFixture(t)
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+
+	output := callDirectoryTree(t, registry, map[string]interface{}{
+		"path":      root,
+		"max_depth": float64(2),
+		"format":    "json",
+	})
+
+	var tree tools.DirectoryTree
+	if err := json.Unmarshal([]byte(output), &tree); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if tree.Truncated {
+		t.Error("tree should not be truncated by the node cap")
+	}
+
+	sub := findChild(tree.Root, "sub")
+	if sub == nil {
+		t.Fatal("expected sub directory at depth 1")
+	}
+	deep := findChild(sub, "deep")
+	if deep == nil {
+		t.Fatal("expected deep directory at depth 2")
+	}
+	if len(deep.Children) != 0 || !deep.Truncated {
+		t.Errorf("deep should not be expanded beyond max_depth: %+v", deep)
+	}
+
+	// Counts and sizes cover the entries within the depth limit
+	if sub.FileCount != 1 || sub.TotalSize != 2 {
+		t.Errorf("sub file_count=%d total_size=%d, want 1 and 2", sub.FileCount, sub.TotalSize)
+	}
+	if tree.Root.FileCount != 2 || tree.Root.TotalSize != 6 {
+		t.Errorf("root file_count=%d total_size=%d, want 2 and 6", tree.Root.FileCount, tree.Root.TotalSize)
+	}
+}
+
+func TestDirectoryTreeTool_NodeCap(t *testing.T) {
+	root := newTreeFixture(t)
+
+	tree, err := tools.BuildDirectoryTree(root, tools.MaxTreeDepth, 3)
+	if err != nil {
+		t.Fatalf("BuildDirectoryTree() error = %v", err)
+	}
+	if tree.Nodes != 3 {
+		t.Errorf("nodes = %d, want 3", tree.Nodes)
+	}
+	if !tree.Truncated {
+		t.Error("tree should be marked truncated when the node cap is reached")
+	}
+
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	output := callDirectoryTree(t, registry, map[string]interface{}{
+		"path":      root,
+		"max_nodes": float64(3),
+	})
+	if !strings.Contains(output, "truncated after 3 entries") {
+		t.Errorf("text output should note truncation, got:\n%s", output)
+	}
+}
+
+func TestDirectoryTreeTool_TextFormat(t *testing.T) {
+	root := newTreeFixture(t)
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+
+	output := callDirectoryTree(t, registry, map[string]interface{}{"path": root, "max_depth": float64(1)})
+
+	for _, want := range []string{"├── a.txt (4 B)", "└── sub/ (0 files, 0 B) [truncated]"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestDirectoryTreeTool_Allowlist(t *testing.T) {
+	root := newTreeFixture(t)
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{filepath.Join(root, "sub")})
+
+	tool, _ := registry.GetTool("directory_tree")
+	result, err := tool.Handler()(map[string]interface{}{"path": root})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("paths outside the allowlist should be rejected")
+	}
+
+	callDirectoryTree(t, registry, map[string]interface{}{"path": filepath.Join(root, "sub", "deep")})
+}
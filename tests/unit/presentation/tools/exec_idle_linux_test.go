@@ -0,0 +1,144 @@
+//go:build linux
+
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func runExecuteCommand(t *testing.T, idle time.Duration, command string) (*entities.ToolResult, time.Duration) {
+	t.Helper()
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetExecIdleTimeout(idle)
+	tool, ok := registry.GetTool("execute_command")
+	if !ok {
+		t.Fatal("execute_command not registered")
+	}
+
+	start := time.Now()
+	result, err := tool.ExecuteContext(context.Background(), map[string]interface{}{
+		"command":     command,
+		"working_dir": t.TempDir(),
+		"timeout":     float64(20),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result, time.Since(start)
+}
+
+// processAlive reports whether pid exists and has not yet exited
+func processAlive(pid int) bool {
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+	// The state field follows the parenthesised command name
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) > 0 && fields[0] != "Z" && fields[0] != "X"
+}
+
+func TestExecuteCommandIdleTimeout(t *testing.T) {
+	result, elapsed := runExecuteCommand(t, 200*time.Millisecond, "echo started; sleep 10")
+
+	if !result.IsError {
+		t.Fatalf("expected idle timeout error, got %q", result.Content[0].Text)
+	}
+	text := result.Content[0].Text
+	if !strings.Contains(text, tools.ErrCommandIdle.Error()) {
+		t.Errorf("expected idle timeout error, got %q", text)
+	}
+	if !strings.Contains(text, "started") {
+		t.Errorf("expected output before the kill to be returned, got %q", text)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("idle command ran for %s", elapsed)
+	}
+}
+
+func TestExecuteCommandIdleTimeoutKillsProcessGroup(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	result, _ := runExecuteCommand(t, 200*time.Millisecond, "sleep 300 & echo $! > "+pidFile+"; wait")
+
+	if !result.IsError || !strings.Contains(result.Content[0].Text, tools.ErrCommandIdle.Error()) {
+		t.Fatalf("expected idle timeout error, got %q", result.Content[0].Text)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("read child pid: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("parse child pid: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for processAlive(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("background child %d survived the idle kill", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestExecuteCommandIdleTimeoutResetByOutput(t *testing.T) {
+	result, _ := runExecuteCommand(t, 300*time.Millisecond, "for i in 1 2 3 4 5 6; do echo tick; sleep 0.1; done")
+
+	if result.IsError {
+		t.Fatalf("command writing output was killed: %q", result.Content[0].Text)
+	}
+	if got := strings.Count(result.Content[0].Text, "tick"); got != 6 {
+		t.Errorf("expected 6 ticks, got %d", got)
+	}
+}
+
+func TestExecuteCommandWithoutIdleTimeout(t *testing.T) {
+	result, _ := runExecuteCommand(t, 0, "sleep 0.5; echo done")
+
+	if result.IsError || !strings.Contains(result.Content[0].Text, "done") {
+		t.Fatalf("expected command to complete, got %q", result.Content[0].Text)
+	}
+}
+
+func TestExecuteCommandStreamsOutput(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	tool, _ := registry.GetTool("execute_command")
+
+	var chunks []string
+	var firstAt time.Time
+	ctx := entities.ContextWithToolOutput(context.Background(), func(chunk string) {
+		if firstAt.IsZero() {
+			firstAt = time.Now()
+		}
+		chunks = append(chunks, chunk)
+	})
+	result, err := tool.ExecuteContext(ctx, map[string]interface{}{
+		"command":     "echo one; sleep 0.5; echo two; printf tail",
+		"working_dir": t.TempDir(),
+	})
+	done := time.Now()
+	if err != nil || result.IsError {
+		t.Fatalf("execute_command failed: %v %+v", err, result)
+	}
+
+	if strings.Join(chunks, "|") != "one\n|two\n|tail" {
+		t.Errorf("streamed chunks = %q, want each line as it is written", chunks)
+	}
+	if done.Sub(firstAt) < 300*time.Millisecond {
+		t.Error("the first line was not streamed before the command finished")
+	}
+	if !strings.Contains(result.Content[0].Text, "one\ntwo\ntail") {
+		t.Errorf("result = %q, want the whole output", result.Content[0].Text)
+	}
+}
@@ -0,0 +1,61 @@
+//go:build linux
+
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func TestExecuteCommandTimeoutKillsProcessGroup(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	tool, ok := registry.GetTool("execute_command")
+	if !ok {
+		t.Fatal("execute_command not registered")
+	}
+
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "grandchild.pid")
+
+	start := time.Now()
+	result, err := tool.ExecuteContext(context.Background(), map[string]interface{}{
+		// The grandchild keeps running after sh is killed unless the whole group is signalled
+		"command":     "sh -c 'sleep 300 & echo $! > " + pidFile + "; wait'",
+		"working_dir": dir,
+		"timeout":     float64(1),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timed out command ran for %s", elapsed)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "timed out") {
+		t.Fatalf("expected timeout error, got %q", result.Content[0].Text)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("read grandchild pid: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("parse grandchild pid: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for processAlive(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("grandchild %d survived the timeout", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
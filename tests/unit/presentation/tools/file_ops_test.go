@@ -0,0 +1,300 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newFileOpsFixture returns a registry allowing two separate temp roots and a third, disallowed one
+func newFileOpsFixture(t *testing.T) (registry *tools.ToolRegistry, root, otherRoot, outside string) {
+	t.Helper()
+	root, otherRoot, outside = t.TempDir(), t.TempDir(), t.TempDir()
+	registry = tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{root, otherRoot})
+	return registry, root, otherRoot, outside
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runFileOp(t *testing.T, registry *tools.ToolRegistry, name string, input map[string]interface{}) *entities.ToolResult {
+	t.Helper()
+	tool, ok := registry.GetTool(name)
+	if !ok {
+		t.Fatalf("tool %s not registered", name)
+	}
+	result, err := tool.ExecuteContext(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result
+}
+
+func decodeFileOp(t *testing.T, result *entities.ToolResult) tools.FileOperation {
+	t.Helper()
+	if result.IsError {
+		t.Fatalf("tool failed: %s", result.Content[0].Text)
+	}
+	var op tools.FileOperation
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &op); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	return op
+}
+
+func expectFileOpError(t *testing.T, result *entities.ToolResult, contains string) {
+	t.Helper()
+	if !result.IsError {
+		t.Fatalf("expected an error result, got %s", result.Content[0].Text)
+	}
+	if !strings.Contains(result.Content[0].Text, contains) {
+		t.Errorf("error %q does not mention %q", result.Content[0].Text, contains)
+	}
+}
+
+func TestFileOps_AreMarkedDestructive(t *testing.T) {
+	registry, _, _, _ := newFileOpsFixture(t)
+	for _, name := range []string{"move_file", "copy_file", "delete_file"} {
+		tool, ok := registry.GetTool(name)
+		if !ok {
+			t.Fatalf("tool %s not registered", name)
+		}
+		if tool.Annotations() == nil || !tool.Annotations().DestructiveHint {
+			t.Errorf("%s should be annotated as destructive", name)
+		}
+	}
+}
+
+func TestMoveFile(t *testing.T) {
+	registry, root, otherRoot, outside := newFileOpsFixture(t)
+
+	t.Run("moves a file", func(t *testing.T) {
+		src := filepath.Join(root, "a.txt")
+		dst := filepath.Join(root, "sub", "b.txt")
+		writeTestFile(t, src, "hello")
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		op := decodeFileOp(t, runFileOp(t, registry, "move_file", map[string]interface{}{"source": src, "destination": dst}))
+		if op.Operation != "move" || op.Source != src || op.Destination != dst || op.Files != 1 || op.Bytes != 5 {
+			t.Errorf("unexpected result %+v", op)
+		}
+		if _, err := os.Stat(src); !os.IsNotExist(err) {
+			t.Error("source should no longer exist")
+		}
+		if data, _ := os.ReadFile(dst); string(data) != "hello" {
+			t.Errorf("destination content = %q", data)
+		}
+	})
+
+	t.Run("moves between allowed roots", func(t *testing.T) {
+		src := filepath.Join(root, "dir")
+		writeTestFile(t, filepath.Join(src, "one.txt"), "1")
+		writeTestFile(t, filepath.Join(src, "nested", "two.txt"), "22")
+		dst := filepath.Join(otherRoot, "dir")
+
+		op := decodeFileOp(t, runFileOp(t, registry, "move_file", map[string]interface{}{"source": src, "destination": dst}))
+		if op.Type != "directory" || op.Files != 2 || op.Bytes != 3 {
+			t.Errorf("unexpected result %+v", op)
+		}
+		if _, err := os.Stat(filepath.Join(dst, "nested", "two.txt")); err != nil {
+			t.Errorf("moved tree incomplete: %v", err)
+		}
+	})
+
+	t.Run("rejects destination outside the allowlist", func(t *testing.T) {
+		src := filepath.Join(root, "stay.txt")
+		writeTestFile(t, src, "x")
+
+		result := runFileOp(t, registry, "move_file", map[string]interface{}{"source": src, "destination": filepath.Join(outside, "stay.txt")})
+		expectFileOpError(t, result, tools.ErrPathNotAllowed.Error())
+		if _, err := os.Stat(src); err != nil {
+			t.Error("source should be untouched")
+		}
+	})
+
+	t.Run("rejects source outside the allowlist", func(t *testing.T) {
+		src := filepath.Join(outside, "secret.txt")
+		writeTestFile(t, src, "x")
+
+		result := runFileOp(t, registry, "move_file", map[string]interface{}{"source": src, "destination": filepath.Join(root, "secret.txt")})
+		expectFileOpError(t, result, tools.ErrPathNotAllowed.Error())
+	})
+
+	t.Run("requires overwrite for existing destination", func(t *testing.T) {
+		src := filepath.Join(root, "new.txt")
+		dst := filepath.Join(root, "old.txt")
+		writeTestFile(t, src, "new")
+		writeTestFile(t, dst, "old")
+
+		result := runFileOp(t, registry, "move_file", map[string]interface{}{"source": src, "destination": dst})
+		expectFileOpError(t, result, "overwrite")
+
+		op := decodeFileOp(t, runFileOp(t, registry, "move_file", map[string]interface{}{"source": src, "destination": dst, "overwrite": true}))
+		if !op.Overwrote {
+			t.Error("result should report the overwrite")
+		}
+		if data, _ := os.ReadFile(dst); string(data) != "new" {
+			t.Errorf("destination content = %q", data)
+		}
+	})
+
+	t.Run("refuses to move an allowed root", func(t *testing.T) {
+		result := runFileOp(t, registry, "move_file", map[string]interface{}{"source": otherRoot, "destination": filepath.Join(root, "moved")})
+		expectFileOpError(t, result, "allowed root")
+	})
+}
+
+func TestCopyFile(t *testing.T) {
+	registry, root, otherRoot, outside := newFileOpsFixture(t)
+
+	t.Run("copies a file", func(t *testing.T) {
+		src := filepath.Join(root, "a.txt")
+		dst := filepath.Join(otherRoot, "a.txt")
+		writeTestFile(t, src, "hello")
+
+		op := decodeFileOp(t, runFileOp(t, registry, "copy_file", map[string]interface{}{"source": src, "destination": dst}))
+		if op.Operation != "copy" || op.Files != 1 || op.Bytes != 5 {
+			t.Errorf("unexpected result %+v", op)
+		}
+		for _, path := range []string{src, dst} {
+			if data, _ := os.ReadFile(path); string(data) != "hello" {
+				t.Errorf("%s content = %q", path, data)
+			}
+		}
+	})
+
+	t.Run("requires recursive for directories", func(t *testing.T) {
+		src := filepath.Join(root, "tree")
+		writeTestFile(t, filepath.Join(src, "one.txt"), "1")
+		writeTestFile(t, filepath.Join(src, "nested", "two.txt"), "22")
+		dst := filepath.Join(root, "tree-copy")
+
+		result := runFileOp(t, registry, "copy_file", map[string]interface{}{"source": src, "destination": dst})
+		expectFileOpError(t, result, "recursive")
+
+		op := decodeFileOp(t, runFileOp(t, registry, "copy_file", map[string]interface{}{"source": src, "destination": dst, "recursive": true}))
+		if op.Type != "directory" || op.Files != 2 || op.Bytes != 3 {
+			t.Errorf("unexpected result %+v", op)
+		}
+		if data, _ := os.ReadFile(filepath.Join(dst, "nested", "two.txt")); string(data) != "22" {
+			t.Errorf("copied content = %q", data)
+		}
+	})
+
+	t.Run("rejects copying into itself", func(t *testing.T) {
+		src := filepath.Join(root, "self")
+		writeTestFile(t, filepath.Join(src, "f.txt"), "x")
+
+		result := runFileOp(t, registry, "copy_file", map[string]interface{}{"source": src, "destination": filepath.Join(src, "inner"), "recursive": true})
+		expectFileOpError(t, result, "inside the source")
+	})
+
+	t.Run("rejects destination outside the allowlist", func(t *testing.T) {
+		src := filepath.Join(root, "b.txt")
+		writeTestFile(t, src, "x")
+
+		result := runFileOp(t, registry, "copy_file", map[string]interface{}{"source": src, "destination": filepath.Join(outside, "b.txt")})
+		expectFileOpError(t, result, tools.ErrPathNotAllowed.Error())
+		if _, err := os.Stat(filepath.Join(outside, "b.txt")); !os.IsNotExist(err) {
+			t.Error("nothing should be written outside the allowlist")
+		}
+	})
+
+	t.Run("refuses to overwrite a directory", func(t *testing.T) {
+		src := filepath.Join(root, "c.txt")
+		dst := filepath.Join(root, "existing-dir")
+		writeTestFile(t, src, "x")
+		if err := os.MkdirAll(dst, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		result := runFileOp(t, registry, "copy_file", map[string]interface{}{"source": src, "destination": dst, "overwrite": true})
+		expectFileOpError(t, result, "overwrite a directory")
+	})
+}
+
+func TestDeleteFile(t *testing.T) {
+	registry, root, _, outside := newFileOpsFixture(t)
+
+	t.Run("requires confirmation", func(t *testing.T) {
+		path := filepath.Join(root, "keep.txt")
+		writeTestFile(t, path, "x")
+
+		for _, input := range []map[string]interface{}{
+			{"path": path},
+			{"path": path, "confirm": false},
+			{"path": path, "confirm": "true"},
+		} {
+			expectFileOpError(t, runFileOp(t, registry, "delete_file", input), "confirm")
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Error("file should not be deleted without confirmation")
+		}
+	})
+
+	t.Run("deletes a file", func(t *testing.T) {
+		path := filepath.Join(root, "gone.txt")
+		writeTestFile(t, path, "bye")
+
+		op := decodeFileOp(t, runFileOp(t, registry, "delete_file", map[string]interface{}{"path": path, "confirm": true}))
+		if op.Operation != "delete" || op.Path != path || op.Files != 1 || op.Bytes != 3 {
+			t.Errorf("unexpected result %+v", op)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Error("file should be deleted")
+		}
+	})
+
+	t.Run("requires recursive for directories", func(t *testing.T) {
+		dir := filepath.Join(root, "dir")
+		writeTestFile(t, filepath.Join(dir, "one.txt"), "1")
+		writeTestFile(t, filepath.Join(dir, "nested", "two.txt"), "22")
+
+		result := runFileOp(t, registry, "delete_file", map[string]interface{}{"path": dir, "confirm": true})
+		expectFileOpError(t, result, "recursive")
+
+		op := decodeFileOp(t, runFileOp(t, registry, "delete_file", map[string]interface{}{"path": dir, "confirm": true, "recursive": true}))
+		if op.Type != "directory" || op.Files != 2 || op.Bytes != 3 {
+			t.Errorf("unexpected result %+v", op)
+		}
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Error("directory should be deleted")
+		}
+	})
+
+	t.Run("rejects paths outside the allowlist", func(t *testing.T) {
+		path := filepath.Join(outside, "protected.txt")
+		writeTestFile(t, path, "x")
+
+		result := runFileOp(t, registry, "delete_file", map[string]interface{}{"path": path, "confirm": true})
+		expectFileOpError(t, result, tools.ErrPathNotAllowed.Error())
+		if _, err := os.Stat(path); err != nil {
+			t.Error("file outside the allowlist should be untouched")
+		}
+	})
+
+	t.Run("refuses to delete an allowed root", func(t *testing.T) {
+		result := runFileOp(t, registry, "delete_file", map[string]interface{}{"path": root, "confirm": true, "recursive": true})
+		expectFileOpError(t, result, "allowed root")
+		if _, err := os.Stat(root); err != nil {
+			t.Error("allowed root should be untouched")
+		}
+	})
+}
@@ -0,0 +1,480 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/aggregates"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newManifestRegistry returns a registry limited to a temp dir and a manifest file path inside it
+func newManifestRegistry(t *testing.T, manifest string) (*tools.ToolRegistry, string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{dir})
+	registry.SetManifestCommands([]string{"echo", "ls", "cat"})
+	_, err := registry.LoadManifest(path)
+	return registry, dir, err
+}
+
+func runManifestTool(t *testing.T, registry *tools.ToolRegistry, name string, input map[string]interface{}) *entities.ToolResult {
+	t.Helper()
+	tool, ok := registry.GetTool(name)
+	if !ok {
+		t.Fatalf("tool %s not registered", name)
+	}
+	result, err := tool.ExecuteContext(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result
+}
+
+func TestManifest_ExecTool(t *testing.T) {
+	registry, dir, err := newManifestRegistry(t, `
+tools:
+  - name: greet
+    description: Print a greeting
+    timeout: 5s
+    tags: [demo]
+    input_schema:
+      type: object
+      properties:
+        name: {type: string, minLength: 1}
+        dir: {type: string}
+      required: [name]
+    exec:
+      command: echo
+      args: ["hello", "{{name}}"]
+  - name: list_here
+    description: List a directory
+    input_schema:
+      type: object
+      properties:
+        dir: {type: string}
+      required: [dir]
+    exec:
+      command: ls
+      working_dir: "{{dir}}"
+`)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+
+	tool, _ := registry.GetTool("greet")
+	if tool.Timeout() != 5*time.Second {
+		t.Errorf("timeout = %s, want 5s", tool.Timeout())
+	}
+	if tool.Annotations() == nil || !tool.Annotations().DestructiveHint {
+		t.Error("exec tools should be annotated as destructive")
+	}
+
+	t.Run("substitutes arguments without a shell", func(t *testing.T) {
+		result := runManifestTool(t, registry, "greet", map[string]interface{}{"name": "world; rm -rf /"})
+		if result.IsError {
+			t.Fatalf("greet failed: %s", result.Content[0].Text)
+		}
+		if got := strings.TrimSpace(result.Content[0].Text); got != "hello world; rm -rf /" {
+			t.Errorf("output = %q", got)
+		}
+	})
+
+	t.Run("streams output while running", func(t *testing.T) {
+		var chunks []string
+		ctx := entities.ContextWithToolOutput(context.Background(), func(chunk string) {
+			chunks = append(chunks, chunk)
+		})
+		tool, _ := registry.GetTool("greet")
+		result, err := tool.ExecuteContext(ctx, map[string]interface{}{"name": "world"})
+		if err != nil || result.IsError {
+			t.Fatalf("greet failed: %v %+v", err, result)
+		}
+		if len(chunks) != 1 || chunks[0] != "hello world\n" {
+			t.Errorf("streamed chunks = %q", chunks)
+		}
+	})
+
+	t.Run("validates input against the schema", func(t *testing.T) {
+		result := runManifestTool(t, registry, "greet", map[string]interface{}{"name": ""})
+		if !result.IsError {
+			t.Error("empty name should be rejected")
+		}
+	})
+
+	t.Run("rejects option injection", func(t *testing.T) {
+		result := runManifestTool(t, registry, "greet", map[string]interface{}{"name": "--help"})
+		if !result.IsError || !strings.Contains(result.Content[0].Text, "option") {
+			t.Errorf("expected an option injection error, got %+v", result.Content)
+		}
+	})
+
+	t.Run("enforces the path allowlist", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(dir, "marker.txt"), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		result := runManifestTool(t, registry, "list_here", map[string]interface{}{"dir": dir})
+		if result.IsError || !strings.Contains(result.Content[0].Text, "marker.txt") {
+			t.Errorf("listing the allowed dir failed: %+v", result.Content)
+		}
+
+		result = runManifestTool(t, registry, "list_here", map[string]interface{}{"dir": t.TempDir()})
+		if !result.IsError || !strings.Contains(result.Content[0].Text, tools.ErrPathNotAllowed.Error()) {
+			t.Errorf("expected a path not allowed error, got %+v", result.Content)
+		}
+	})
+}
+
+func TestManifest_ExecToolGetsResolvedPaths(t *testing.T) {
+	registry, dir, err := newManifestRegistry(t, `
+tools:
+  - name: show
+    description: Print a file from another directory
+    input_schema:
+      type: object
+      properties:
+        file: {type: string}
+        dir: {type: string}
+      required: [file, dir]
+    exec:
+      command: cat
+      args: ["{{file}}"]
+      working_dir: "{{dir}}"
+      path_params: [file]
+`)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+
+	sessionDir := filepath.Join(dir, "session", "nested")
+	otherDir := filepath.Join(dir, "other")
+	for _, d := range []string{sessionDir, otherDir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("inside"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := entities.NewSessionStore()
+	if err := store.Set(tools.WorkingDirKey, sessionDir); err != nil {
+		t.Fatal(err)
+	}
+	ctx := entities.ContextWithSessionStore(context.Background(), store)
+
+	// The path is checked against the session dir; run from otherDir, the raw
+	// relative path would point outside the allowed dir
+	tool, _ := registry.GetTool("show")
+	result, err := tool.ExecuteContext(ctx, map[string]interface{}{"file": "../../notes.txt", "dir": otherDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("show failed: %s", result.Content[0].Text)
+	}
+	if got := result.Content[0].Text; got != "inside" {
+		t.Errorf("output = %q, want the file resolved against the session dir", got)
+	}
+}
+
+func TestManifest_HTTPTool(t *testing.T) {
+	var gotPath, gotQuery, gotBody, gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery, gotHeader = r.URL.EscapedPath(), r.URL.RawQuery, r.Header.Get("X-Item")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	registry, _, err := newManifestRegistry(t, `{
+  "tools": [{
+    "name": "update_item",
+    "description": "Update an item",
+    "input_schema": {
+      "type": "object",
+      "properties": {"id": {"type": "string"}, "note": {"type": "string"}, "count": {"type": "integer"}},
+      "required": ["id"]
+    },
+    "http": {
+      "method": "POST",
+      "url": "`+server.URL+`/items/{{id}}?count={{count}}",
+      "headers": {"Content-Type": "application/json", "X-Item": "{{id}}"},
+      "body": "{\"note\": \"{{note}}\"}"
+    }
+  }]
+}`)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+
+	result := runManifestTool(t, registry, "update_item", map[string]interface{}{
+		"id":    "a/b",
+		"note":  `say "hi"`,
+		"count": float64(3),
+	})
+	if result.IsError {
+		t.Fatalf("update_item failed: %s", result.Content[0].Text)
+	}
+	if result.Content[0].Text != `{"ok":true}` {
+		t.Errorf("response = %q", result.Content[0].Text)
+	}
+	if gotPath != "/items/a%2Fb" || gotQuery != "count=3" || gotHeader != "a/b" {
+		t.Errorf("request path=%q query=%q header=%q", gotPath, gotQuery, gotHeader)
+	}
+	if gotBody != `{"note": "say \"hi\""}` {
+		t.Errorf("body = %q", gotBody)
+	}
+
+	result = runManifestTool(t, registry, "update_item", map[string]interface{}{"id": "missing"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "HTTP 404") {
+		t.Errorf("expected an HTTP 404 error, got %+v", result.Content)
+	}
+}
+
+func TestManifest_HTTPToolRedirects(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("leaked"))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/final", http.StatusFound)
+		case "/elsewhere":
+			http.Redirect(w, r, other.URL+"/final", http.StatusFound)
+		default:
+			_, _ = w.Write([]byte("final"))
+		}
+	}))
+	defer server.Close()
+
+	registry, _, err := newManifestRegistry(t, `{
+  "tools": [{
+    "name": "fetch",
+    "description": "Fetch a path",
+    "input_schema": {"type": "object", "properties": {"path": {"type": "string"}}},
+    "http": {"method": "GET", "url": "`+server.URL+`/{{path}}"}
+  }]
+}`)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+
+	result := runManifestTool(t, registry, "fetch", map[string]interface{}{"path": "moved"})
+	if result.IsError || result.Content[0].Text != "final" {
+		t.Errorf("same-host redirect should be followed, got %+v", result.Content)
+	}
+
+	result = runManifestTool(t, registry, "fetch", map[string]interface{}{"path": "elsewhere"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, tools.ErrUnsafeAction.Error()) {
+		t.Errorf("redirect to another host should be refused, got %+v", result.Content)
+	}
+}
+
+func TestManifest_RejectsInvalidManifests(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     error
+	}{
+		{
+			name: "shell command",
+			manifest: `
+tools:
+  - name: run
+    description: Run anything
+    exec: {command: /bin/sh, args: ["-c", "{{cmd}}"]}
+    input_schema: {type: object, properties: {cmd: {type: string}}}
+`,
+			want: tools.ErrUnsafeAction,
+		},
+		{
+			name: "interpreter",
+			manifest: `
+tools:
+  - name: run
+    description: Run a script
+    exec: {command: python3, args: ["-c", "{{code}}"]}
+    input_schema: {type: object, properties: {code: {type: string}}}
+`,
+			want: tools.ErrUnsafeAction,
+		},
+		{
+			name: "command outside the allowlist",
+			manifest: `
+tools:
+  - name: search
+    description: Find files
+    exec: {command: find, args: [".", "-name", "{{pattern}}"]}
+    input_schema: {type: object, properties: {pattern: {type: string}}}
+`,
+			want: tools.ErrUnsafeAction,
+		},
+		{
+			name: "allowed command at another path",
+			manifest: `
+tools:
+  - name: greet
+    description: Greet
+    exec: {command: /tmp/bin/echo}
+`,
+			want: tools.ErrUnsafeAction,
+		},
+		{
+			name: "templated command",
+			manifest: `
+tools:
+  - name: run
+    description: Run a chosen binary
+    exec: {command: "{{bin}}"}
+    input_schema: {type: object, properties: {bin: {type: string}}}
+`,
+			want: tools.ErrUnsafeAction,
+		},
+		{
+			name: "templated host",
+			manifest: `
+tools:
+  - name: fetch
+    description: Fetch from any host
+    http: {method: GET, url: "https://{{host}}/data"}
+    input_schema: {type: object, properties: {host: {type: string}}}
+`,
+			want: tools.ErrUnsafeAction,
+		},
+		{
+			name: "non-http scheme",
+			manifest: `
+tools:
+  - name: fetch
+    description: Fetch a file
+    http: {method: GET, url: "file:///etc/passwd"}
+`,
+			want: tools.ErrInvalidManifest,
+		},
+		{
+			name: "unknown placeholder",
+			manifest: `
+tools:
+  - name: greet
+    description: Greet
+    exec: {command: echo, args: ["{{name}}"]}
+`,
+			want: tools.ErrInvalidManifest,
+		},
+		{
+			name: "no action",
+			manifest: `
+tools:
+  - name: nothing
+    description: Does nothing
+`,
+			want: tools.ErrInvalidManifest,
+		},
+		{
+			name: "unknown field",
+			manifest: `
+tools:
+  - name: greet
+    description: Greet
+    script: "echo hi"
+`,
+			want: tools.ErrInvalidManifest,
+		},
+		{
+			name: "excessive timeout",
+			manifest: `
+tools:
+  - name: greet
+    description: Greet
+    timeout: 1h
+    exec: {command: echo}
+`,
+			want: tools.ErrInvalidManifest,
+		},
+		{
+			name: "collides with a built-in",
+			manifest: `
+tools:
+  - name: read_file
+    description: Shadow a built-in
+    exec: {command: cat}
+`,
+			want: aggregates.ErrToolAlreadyRegistered,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, _, err := newManifestRegistry(t, tt.manifest)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("LoadManifest() error = %v, want %v", err, tt.want)
+			}
+			if tool, ok := registry.GetTool("read_file"); !ok || tool.Category() == "manifest" {
+				t.Error("built-in tools must not be replaced")
+			}
+		})
+	}
+}
+
+func TestManifest_RejectsWholeManifestOnError(t *testing.T) {
+	registry, _, err := newManifestRegistry(t, `
+tools:
+  - name: greet
+    description: Greet
+    exec: {command: echo}
+  - name: run
+    description: Run anything
+    exec: {command: bash}
+`)
+	if !errors.Is(err, tools.ErrUnsafeAction) {
+		t.Fatalf("LoadManifest() error = %v, want %v", err, tools.ErrUnsafeAction)
+	}
+	if _, ok := registry.GetTool("greet"); ok {
+		t.Error("no tool should be registered from an invalid manifest")
+	}
+}
+
+func TestManifest_ExecToolsNeedManifestCommands(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	_, err := registry.RegisterManifest(&tools.ToolManifest{Tools: []tools.ManifestTool{
+		{Name: "greet", Description: "Greet", Exec: &tools.ExecAction{Command: "echo"}},
+	}})
+	if !errors.Is(err, tools.ErrUnsafeAction) {
+		t.Fatalf("RegisterManifest() error = %v, want %v", err, tools.ErrUnsafeAction)
+	}
+}
+
+func TestManifest_ExampleIsValid(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetManifestCommands([]string{"git"})
+	loaded, err := registry.LoadManifest(filepath.Join("..", "..", "..", "..", "configs", "tools.example.yaml"))
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Errorf("loaded %d tools, want 2", len(loaded))
+	}
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func TestMaxTokensPolicy_Resolve(t *testing.T) {
+	haikuCap := vo.ModelClaude35Haiku.MaxOutputTokens()
+
+	tests := []struct {
+		name      string
+		policy    tools.MaxTokensPolicy
+		model     vo.Model
+		requested int
+		want      int
+		wantErr   error
+	}{
+		{"default when unset", tools.MaxTokensPolicy{Default: 2048}, vo.ModelClaude4Sonnet, 0, 2048, nil},
+		{"default lowered to model cap", tools.MaxTokensPolicy{Default: 16000}, vo.ModelClaude3Haiku, 0, 4096, nil},
+		{"within model cap", tools.MaxTokensPolicy{}, vo.ModelClaude35Haiku, 1000, 1000, nil},
+		{"at model cap", tools.MaxTokensPolicy{}, vo.ModelClaude35Haiku, haikuCap, haikuCap, nil},
+		{"above model cap rejected", tools.MaxTokensPolicy{}, vo.ModelClaude35Haiku, haikuCap + 1, 0, tools.ErrMaxTokensExceeded},
+		{"above model cap clamped", tools.MaxTokensPolicy{Clamp: true}, vo.ModelClaude35Haiku, 100000, haikuCap, nil},
+		{"server limit below model cap", tools.MaxTokensPolicy{Limit: 1024}, vo.ModelClaude4Sonnet, 1025, 0, tools.ErrMaxTokensExceeded},
+		{"at server limit", tools.MaxTokensPolicy{Limit: 1024}, vo.ModelClaude4Sonnet, 1024, 1024, nil},
+		{"server limit for unknown model", tools.MaxTokensPolicy{Limit: 1024, Clamp: true}, vo.Model("custom-model"), 5000, 1024, nil},
+		{"unknown model without limit", tools.MaxTokensPolicy{}, vo.Model("custom-model"), 500000, 500000, nil},
+		{"negative rejected", tools.MaxTokensPolicy{Clamp: true}, vo.ModelClaude4Sonnet, -1, 0, tools.ErrInvalidMaxTokens},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.Resolve(tt.model, tt.requested)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaudeConversationTool_MaxTokensGuard(t *testing.T) {
+	tests := []struct {
+		name        string
+		clamp       bool
+		maxTokens   float64
+		wantError   bool
+		wantRequest int
+	}{
+		{"within cap", false, 1000, false, 1000},
+		{"at cap", false, 8192, false, 8192},
+		{"above cap rejected", false, 8193, true, 0},
+		{"above cap clamped", true, 8193, false, 8192},
+		{"fractional rejected", false, 10.5, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claude := mocks.NewMockClaudeService()
+			var sent int
+			claude.On("CreateMessage", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { sent = args.Get(1).(*services.ClaudeRequest).MaxTokens }).
+				Return(&services.ClaudeResponse{Model: string(vo.ModelClaude35Haiku)}, nil)
+
+			registry := tools.NewToolRegistry(claude)
+			registry.SetMaxTokensPolicy(tools.MaxTokensPolicy{Default: tools.DefaultMaxTokens, Clamp: tt.clamp})
+			tool, _ := registry.GetTool("claude_conversation")
+
+			result, err := tool.Handler()(map[string]interface{}{
+				"message":    "hello",
+				"model":      string(vo.ModelClaude35Haiku),
+				"max_tokens": tt.maxTokens,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError != tt.wantError {
+				t.Fatalf("IsError = %v, want %v (%s)", result.IsError, tt.wantError, result.Content[0].Text)
+			}
+			if tt.wantError {
+				claude.AssertNotCalled(t, "CreateMessage", mock.Anything, mock.Anything)
+				return
+			}
+			if sent != tt.wantRequest {
+				t.Errorf("request max_tokens = %d, want %d", sent, tt.wantRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+//go:build linux
+
+package tools
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+func TestListProcessesTool_NotRegisteredByDefault(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+
+	if _, ok := registry.GetTool("list_processes"); ok {
+		t.Error("list_processes should not be registered unless enabled")
+	}
+}
+
+func TestListProcessesTool(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.EnableProcessListing()
+
+	tool, ok := registry.GetTool("list_processes")
+	if !ok {
+		t.Fatal("list_processes should be registered once enabled")
+	}
+	if !tool.IsReadOnly() {
+		t.Error("list_processes should be read-only")
+	}
+	if scope := tool.Metadata()["required_scope"]; scope != tools.ProcessListScope {
+		t.Errorf("required_scope = %v, want %s", scope, tools.ProcessListScope)
+	}
+
+	for _, sortBy := range []string{"cpu", "memory"} {
+		t.Run("sort_by_"+sortBy, func(t *testing.T) {
+			result, err := tool.Handler()(map[string]interface{}{
+				"limit":   float64(tools.MaxProcessLimit),
+				"sort_by": sortBy,
+			})
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", result.Content[0].Text)
+			}
+
+			var processes []tools.ProcessInfo
+			if err := json.Unmarshal([]byte(result.Content[0].Text), &processes); err != nil {
+				t.Fatalf("failed to decode processes: %v", err)
+			}
+
+			found := false
+			for _, p := range processes {
+				if p.PID == os.Getpid() {
+					found = true
+					if p.Name == "" {
+						t.Error("current process should have a name")
+					}
+					if p.MemoryBytes == 0 {
+						t.Error("current process should report memory usage")
+					}
+				}
+			}
+			if !found {
+				t.Errorf("current process %d not found in %d processes", os.Getpid(), len(processes))
+			}
+		})
+	}
+}
+
+func TestListProcessesTool_InvalidInput(t *testing.T) {
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.EnableProcessListing()
+	tool, _ := registry.GetTool("list_processes")
+
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+	}{
+		{"limit too small", map[string]interface{}{"limit": float64(0)}},
+		{"limit too large", map[string]interface{}{"limit": float64(tools.MaxProcessLimit + 1)}},
+		{"unknown sort", map[string]interface{}{"sort_by": "pid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Handler()(tt.input)
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if !result.IsError {
+				t.Error("expected error result")
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// readConfig writes content to a file named name and returns read_config's decoded JSON output
+func readConfig(t *testing.T, name, content string, extra map[string]interface{}) (interface{}, map[string]interface{}) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{dir})
+
+	input := map[string]interface{}{"path": path}
+	for key, value := range extra {
+		input[key] = value
+	}
+	result := runFileOp(t, registry, "read_config", input)
+	if result.IsError {
+		t.Fatalf("read_config failed: %s", result.Content[0].Text)
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &parsed); err != nil {
+		t.Fatalf("read_config returned invalid JSON: %v", err)
+	}
+	return parsed, result.Meta
+}
+
+func assertConfig(t *testing.T, got interface{}, want string) {
+	t.Helper()
+	var expected interface{}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		data, _ := json.Marshal(got)
+		t.Errorf("config = %s, want %s", data, want)
+	}
+}
+
+func TestReadConfig_YAML(t *testing.T) {
+	got, meta := readConfig(t, "app.yaml", "server:\n  port: 8080\n  tls: true\nhosts:\n  - a\n  - b\n1: numeric key\n", nil)
+	assertConfig(t, got, `{"server": {"port": 8080, "tls": true}, "hosts": ["a", "b"], "1": "numeric key"}`)
+	if meta["format"] != tools.ConfigFormatYAML {
+		t.Errorf("format = %v, want yaml", meta["format"])
+	}
+}
+
+func TestReadConfig_TOML(t *testing.T) {
+	got, _ := readConfig(t, "app.toml", "title = \"demo\"\n\n[database]\nport = 5432\nreplicas = [\"r1\", \"r2\"]\n", nil)
+	assertConfig(t, got, `{"title": "demo", "database": {"port": 5432, "replicas": ["r1", "r2"]}}`)
+}
+
+func TestReadConfig_INI(t *testing.T) {
+	got, _ := readConfig(t, "app.ini", "name = demo\n\n[database]\nhost = localhost\nport = 5432\n", nil)
+	assertConfig(t, got, `{"name": "demo", "database": {"host": "localhost", "port": "5432"}}`)
+}
+
+func TestReadConfig_Env(t *testing.T) {
+	got, meta := readConfig(t, ".env.local", "# comment\nexport API_URL=https://example.com\nNAME=\"quoted value\"\n", nil)
+	assertConfig(t, got, `{"API_URL": "https://example.com", "NAME": "quoted value"}`)
+	if meta["format"] != tools.ConfigFormatEnv {
+		t.Errorf("format = %v, want env", meta["format"])
+	}
+}
+
+func TestReadConfig_ExplicitFormat(t *testing.T) {
+	got, _ := readConfig(t, "settings", "key: value\n", map[string]interface{}{"format": "yaml"})
+	assertConfig(t, got, `{"key": "value"}`)
+}
+
+func TestReadConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{dir})
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		want  string
+	}{
+		{"parse error", map[string]interface{}{"path": write("bad.yaml", "key: [unclosed\n")}, "failed to parse"},
+		{"unknown extension", map[string]interface{}{"path": write("notes.txt", "a=b\n")}, "pass format"},
+		{"outside allowed paths", map[string]interface{}{"path": "/etc/hosts", "format": "ini"}, "outside"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runFileOp(t, registry, "read_config", tt.input)
+			if !result.IsError {
+				t.Fatal("expected an error result")
+			}
+			if !strings.Contains(result.Content[0].Text, tt.want) {
+				t.Errorf("error = %q, want it to mention %q", result.Content[0].Text, tt.want)
+			}
+		})
+	}
+}
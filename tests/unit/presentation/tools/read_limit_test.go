@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newReadFileRegistry returns a registry whose read_file returns at most limit bytes, and a file holding content
+func newReadFileRegistry(t *testing.T, limit int64, content string) (*tools.ToolRegistry, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{dir})
+	registry.SetReadFileMaxBytes(limit)
+	return registry, path
+}
+
+func TestReadFile_SmallFileNotTruncated(t *testing.T) {
+	registry, path := newReadFileRegistry(t, 1024, "hello world")
+
+	result := runFileOp(t, registry, "read_file", map[string]interface{}{"path": path})
+	if result.IsError {
+		t.Fatalf("read_file failed: %s", result.Content[0].Text)
+	}
+	if result.Content[0].Text != "hello world" {
+		t.Errorf("content = %q", result.Content[0].Text)
+	}
+	if result.Meta["truncated"] != false || result.Meta["totalBytes"] != int64(11) ||
+		result.Meta["bytesReturned"] != 11 || result.Meta["offset"] != int64(0) {
+		t.Errorf("unexpected meta: %v", result.Meta)
+	}
+}
+
+func TestReadFile_LargeFileTruncated(t *testing.T) {
+	content := strings.Repeat("0123456789", 30)
+	registry, path := newReadFileRegistry(t, 100, content)
+
+	result := runFileOp(t, registry, "read_file", map[string]interface{}{"path": path})
+	if result.IsError {
+		t.Fatalf("read_file failed: %s", result.Content[0].Text)
+	}
+	if result.Content[0].Text != content[:100] {
+		t.Errorf("expected the first 100 bytes, got %q", result.Content[0].Text)
+	}
+	if result.Meta["truncated"] != true || result.Meta["totalBytes"] != int64(300) || result.Meta["bytesReturned"] != 100 {
+		t.Errorf("unexpected meta: %v", result.Meta)
+	}
+
+	// Continuing from the offset returns the next window
+	result = runFileOp(t, registry, "read_file", map[string]interface{}{"path": path, "offset": float64(250)})
+	if result.Content[0].Text != content[250:] {
+		t.Errorf("expected the last 50 bytes, got %q", result.Content[0].Text)
+	}
+	if result.Meta["truncated"] != false || result.Meta["offset"] != int64(250) || result.Meta["bytesReturned"] != 50 {
+		t.Errorf("unexpected meta: %v", result.Meta)
+	}
+}
+
+func TestReadFile_TruncationKeepsRunesWhole(t *testing.T) {
+	// Each "é" is two bytes, so a 5-byte cap would split the third one
+	registry, path := newReadFileRegistry(t, 5, strings.Repeat("é", 10))
+
+	result := runFileOp(t, registry, "read_file", map[string]interface{}{"path": path})
+	if result.Content[0].Text != "éé" || result.Meta["bytesReturned"] != 4 {
+		t.Errorf("content = %q, meta = %v", result.Content[0].Text, result.Meta)
+	}
+}
+
+func TestReadFile_OffsetOutsideFile(t *testing.T) {
+	registry, path := newReadFileRegistry(t, 1024, "short")
+
+	result := runFileOp(t, registry, "read_file", map[string]interface{}{"path": path, "offset": float64(100)})
+	if !result.IsError {
+		t.Error("expected an error for an offset past the end of the file")
+	}
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/services"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// converse runs claude_conversation against a mock returning reply
+func converse(t *testing.T, limit int, reply string) *entities.ToolResult {
+	t.Helper()
+	claude := mocks.NewMockClaudeService()
+	claude.On("CreateMessage", mock.Anything, mock.Anything).Return(&services.ClaudeResponse{
+		Model:   string(vo.ModelClaude4Sonnet),
+		Content: []entities.ContentBlock{{Type: vo.ContentTypeText, Text: reply}},
+	}, nil)
+
+	registry := tools.NewToolRegistry(claude)
+	registry.SetMaxResponseChars(limit)
+	tool, _ := registry.GetTool("claude_conversation")
+
+	result, err := tool.Handler()(map[string]interface{}{"message": "hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected tool error: %s", result.Content[0].Text)
+	}
+	return result
+}
+
+func TestClaudeConversationTool_ResponseUnderCap(t *testing.T) {
+	reply := strings.Repeat("a", 100)
+	result := converse(t, 100, reply)
+
+	if result.Content[0].Text != reply {
+		t.Errorf("expected the full reply, got %q", result.Content[0].Text)
+	}
+	if _, ok := result.Meta["truncated"]; ok {
+		t.Error("did not expect truncated meta for a reply within the cap")
+	}
+}
+
+func TestClaudeConversationTool_ResponseOverCap(t *testing.T) {
+	reply := strings.Repeat("é", 150)
+	result := converse(t, 100, reply)
+
+	text := result.Content[0].Text
+	if !strings.HasPrefix(text, strings.Repeat("é", 100)+"\n\n[response truncated") {
+		t.Errorf("expected 100 characters followed by a truncation marker, got %q", text)
+	}
+	if strings.Contains(text, strings.Repeat("é", 101)) {
+		t.Error("expected text beyond the cap to be dropped")
+	}
+	if !strings.Contains(text, "showing 100 of 150 characters") {
+		t.Errorf("expected marker to name the full length, got %q", text)
+	}
+	if result.Meta["truncated"] != true || result.Meta["fullLength"] != 150 {
+		t.Errorf("unexpected meta: %v", result.Meta)
+	}
+	if _, ok := result.Meta["fullText"]; ok {
+		t.Error("expected the text beyond the cap not to reach the client in the result metadata")
+	}
+}
+
+func TestClaudeConversationTool_ResponseCapDisabled(t *testing.T) {
+	reply := strings.Repeat("a", tools.DefaultMaxResponseChars+1)
+	result := converse(t, 0, reply)
+
+	if result.Content[0].Text != reply {
+		t.Error("expected the full reply when the cap is disabled")
+	}
+}
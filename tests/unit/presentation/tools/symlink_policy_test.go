@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+// newSymlinkFixture returns a registry allowing root, which holds links to a directory
+// inside root and to one outside it
+func newSymlinkFixture(t *testing.T, policy tools.SymlinkPolicy) (registry *tools.ToolRegistry, root, outside string) {
+	t.Helper()
+	root, outside = t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(root, "real", "inside.txt"), "inside")
+	writeTestFile(t, filepath.Join(outside, "secret.txt"), "secret")
+	if err := os.Symlink(filepath.Join(root, "real"), filepath.Join(root, "inside-link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "outside-link")); err != nil {
+		t.Fatal(err)
+	}
+
+	registry = tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{root})
+	registry.SetSymlinkPolicy(policy)
+	return registry, root, outside
+}
+
+func TestSymlinkPolicy(t *testing.T) {
+	tests := []struct {
+		policy      tools.SymlinkPolicy
+		insideErr   string
+		outsideErr  string
+		danglingErr string
+	}{
+		{policy: tools.SymlinkFollow},
+		{
+			policy:      tools.SymlinkDeny,
+			insideErr:   tools.ErrSymlinkNotAllowed.Error(),
+			outsideErr:  tools.ErrSymlinkNotAllowed.Error(),
+			danglingErr: tools.ErrSymlinkNotAllowed.Error(),
+		},
+		{
+			policy:      tools.SymlinkResolveAndCheck,
+			outsideErr:  tools.ErrPathNotAllowed.Error(),
+			danglingErr: tools.ErrPathNotAllowed.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			t.Run("link inside root", func(t *testing.T) {
+				registry, root, _ := newSymlinkFixture(t, tt.policy)
+				result := runFileOp(t, registry, "directory_tree", map[string]interface{}{
+					"path": filepath.Join(root, "inside-link"),
+				})
+				expectPolicyResult(t, result, tt.insideErr)
+			})
+
+			t.Run("link outside root", func(t *testing.T) {
+				registry, root, _ := newSymlinkFixture(t, tt.policy)
+				result := runFileOp(t, registry, "copy_file", map[string]interface{}{
+					"source":      filepath.Join(root, "outside-link", "secret.txt"),
+					"destination": filepath.Join(root, "copied.txt"),
+				})
+				expectPolicyResult(t, result, tt.outsideErr)
+			})
+
+			t.Run("dangling link outside root", func(t *testing.T) {
+				registry, root, outside := newSymlinkFixture(t, tt.policy)
+				target := filepath.Join(outside, "created.txt")
+				if err := os.Symlink(target, filepath.Join(root, "dangling")); err != nil {
+					t.Fatal(err)
+				}
+				writeTestFile(t, filepath.Join(root, "source.txt"), "payload")
+
+				result := runFileOp(t, registry, "copy_file", map[string]interface{}{
+					"source":      filepath.Join(root, "source.txt"),
+					"destination": filepath.Join(root, "dangling"),
+					"overwrite":   true,
+				})
+				expectPolicyResult(t, result, tt.danglingErr)
+				if tt.danglingErr != "" {
+					if _, err := os.Lstat(filepath.Join(root, "dangling")); err != nil {
+						t.Errorf("rejected link was modified: %v", err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestCoreFileToolsRefuseOutsideLinks(t *testing.T) {
+	tests := []struct {
+		tool  string
+		input func(root string) map[string]interface{}
+	}{
+		{"read_file", func(root string) map[string]interface{} {
+			return map[string]interface{}{"path": filepath.Join(root, "outside-link", "secret.txt")}
+		}},
+		{"write_file", func(root string) map[string]interface{} {
+			return map[string]interface{}{"path": filepath.Join(root, "outside-link", "secret.txt"), "content": "overwritten"}
+		}},
+		{"list_directory", func(root string) map[string]interface{} {
+			return map[string]interface{}{"path": filepath.Join(root, "outside-link")}
+		}},
+		{"search_files", func(root string) map[string]interface{} {
+			return map[string]interface{}{"path": filepath.Join(root, "outside-link"), "pattern": "*.txt"}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			registry, root, outside := newSymlinkFixture(t, tools.SymlinkResolveAndCheck)
+			result := runFileOp(t, registry, tt.tool, tt.input(root))
+			expectFileOpError(t, result, tools.ErrPathNotAllowed.Error())
+
+			content, err := os.ReadFile(filepath.Join(outside, "secret.txt"))
+			if err != nil || string(content) != "secret" {
+				t.Errorf("file outside the root was modified: %q, %v", content, err)
+			}
+		})
+	}
+
+	t.Run("link inside root", func(t *testing.T) {
+		registry, root, _ := newSymlinkFixture(t, tools.SymlinkResolveAndCheck)
+		result := runFileOp(t, registry, "read_file", map[string]interface{}{
+			"path": filepath.Join(root, "inside-link", "inside.txt"),
+		})
+		expectPolicyResult(t, result, "")
+	})
+}
+
+func TestSymlinkPolicyDefaultsToResolveAndCheck(t *testing.T) {
+	_, root, _ := newSymlinkFixture(t, tools.SymlinkFollow)
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{root})
+
+	result := runFileOp(t, registry, "directory_tree", map[string]interface{}{
+		"path": filepath.Join(root, "outside-link"),
+	})
+	expectPolicyResult(t, result, tools.ErrPathNotAllowed.Error())
+}
+
+func TestParseSymlinkPolicy(t *testing.T) {
+	for _, name := range []string{"follow", "deny", "resolve-and-check"} {
+		policy, err := tools.ParseSymlinkPolicy(name)
+		if err != nil || string(policy) != name {
+			t.Errorf("ParseSymlinkPolicy(%q) = %q, %v", name, policy, err)
+		}
+	}
+
+	if policy, err := tools.ParseSymlinkPolicy(""); err != nil || policy != tools.SymlinkResolveAndCheck {
+		t.Errorf("ParseSymlinkPolicy(\"\") = %q, %v; want resolve-and-check", policy, err)
+	}
+	if _, err := tools.ParseSymlinkPolicy("ignore"); !errors.Is(err, tools.ErrInvalidSymlinkPolicy) {
+		t.Errorf("expected ErrInvalidSymlinkPolicy, got %v", err)
+	}
+}
+
+// expectPolicyResult expects success when wantErr is empty and an error mentioning it otherwise
+func expectPolicyResult(t *testing.T, result *entities.ToolResult, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if result.IsError {
+			t.Fatalf("expected success, got %q", result.Content[0].Text)
+		}
+		return
+	}
+	expectFileOpError(t, result, wantErr)
+}
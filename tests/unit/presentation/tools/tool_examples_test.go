@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+)
+
+func TestBuiltinToolExamples_SchemaValid(t *testing.T) {
+	registry := tools.NewToolRegistry(nil)
+
+	withExamples := 0
+	for _, tool := range registry.GetTools() {
+		if len(tool.Examples()) > 0 {
+			withExamples++
+		}
+		if err := tool.ValidateExamples(); err != nil {
+			t.Errorf("invalid example: %v", err)
+		}
+	}
+
+	echo, ok := registry.GetTool("echo")
+	if !ok || len(echo.Examples()) == 0 {
+		t.Fatal("expected echo to have examples")
+	}
+	if withExamples < 5 {
+		t.Errorf("expected most built-in tools to have examples, got %d", withExamples)
+	}
+}
+
+func TestBuiltinToolExamples_RoundTrip(t *testing.T) {
+	registry := tools.NewToolRegistry(nil)
+
+	for _, tool := range registry.GetTools() {
+		if len(tool.Examples()) == 0 {
+			continue
+		}
+
+		data, err := tool.ToJSON()
+		if err != nil {
+			t.Fatalf("ToJSON() error = %v", err)
+		}
+		var listed struct {
+			Examples []map[string]interface{} `json:"examples"`
+		}
+		if err := json.Unmarshal(data, &listed); err != nil {
+			t.Fatalf("invalid tool JSON: %v", err)
+		}
+
+		// Compare through JSON so numbers decode the same way on both sides
+		want, _ := json.Marshal(tool.Examples())
+		var wantExamples []map[string]interface{}
+		_ = json.Unmarshal(want, &wantExamples)
+		if !reflect.DeepEqual(listed.Examples, wantExamples) {
+			t.Errorf("%s examples = %v, want %v", tool.Name(), listed.Examples, wantExamples)
+		}
+
+		// Decoded examples are still valid inputs
+		for i, example := range listed.Examples {
+			if err := tool.InputSchema().Validate(example); err != nil {
+				t.Errorf("%s decoded example %d: %v", tool.Name(), i, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/entities"
+	vo "github.com/telemetryflow/telemetryflow-go-mcp/internal/domain/valueobjects"
+	"github.com/telemetryflow/telemetryflow-go-mcp/internal/presentation/tools"
+	"github.com/telemetryflow/telemetryflow-go-mcp/tests/mocks"
+)
+
+type fileChange struct {
+	sessionID vo.SessionID
+	change    tools.FileChange
+}
+
+type fakeFileNotifier struct {
+	changes chan fileChange
+}
+
+func (n *fakeFileNotifier) NotifyFileChanged(ctx context.Context, sessionID vo.SessionID, change tools.FileChange) {
+	n.changes <- fileChange{sessionID: sessionID, change: change}
+}
+
+// newWatchFixture returns a registry limited to a temp dir holding a single file
+func newWatchFixture(t *testing.T) (*tools.ToolRegistry, *fakeFileNotifier, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watched.txt")
+	if err := os.WriteFile(path, []byte("v1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := tools.NewToolRegistry(mocks.NewMockClaudeService())
+	registry.SetAllowedPaths([]string{dir})
+	notifier := &fakeFileNotifier{changes: make(chan fileChange, 16)}
+	registry.FileWatcher().SetNotifier(notifier)
+	t.Cleanup(registry.FileWatcher().Close)
+	return registry, notifier, path
+}
+
+func runWatchTool(t *testing.T, registry *tools.ToolRegistry, name string, sessionID vo.SessionID, input map[string]interface{}) *entities.ToolResult {
+	t.Helper()
+	tool, ok := registry.GetTool(name)
+	if !ok {
+		t.Fatalf("tool %s not registered", name)
+	}
+	ctx := entities.ContextWithSessionID(context.Background(), sessionID)
+	result, err := tool.ExecuteContext(ctx, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result
+}
+
+func TestWatchFile_NotifiesOnChange(t *testing.T) {
+	registry, notifier, path := newWatchFixture(t)
+	sessionID := vo.GenerateSessionID()
+
+	result := runWatchTool(t, registry, "watch_file", sessionID, map[string]interface{}{"path": path})
+	if result.IsError {
+		t.Fatalf("watch_file failed: %s", result.Content[0].Text)
+	}
+	if result.Meta["uri"] != "file://"+path {
+		t.Errorf("uri meta = %v, want file://%s", result.Meta["uri"], path)
+	}
+
+	if err := os.WriteFile(path, []byte("v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-notifier.changes:
+		if !got.sessionID.Equals(sessionID) {
+			t.Errorf("notified session %s, want %s", got.sessionID, sessionID)
+		}
+		if got.change.Path != path || !strings.Contains(got.change.Op, "write") {
+			t.Errorf("unexpected change: %+v", got.change)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for file change notification")
+	}
+}
+
+func TestWatchFile_IgnoresSiblingFiles(t *testing.T) {
+	registry, notifier, path := newWatchFixture(t)
+	runWatchTool(t, registry, "watch_file", vo.GenerateSessionID(), map[string]interface{}{"path": path})
+
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "other.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-notifier.changes:
+		t.Errorf("unexpected notification for sibling file: %+v", got.change)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestWatchFile_Unwatch(t *testing.T) {
+	registry, notifier, path := newWatchFixture(t)
+	sessionID := vo.GenerateSessionID()
+	runWatchTool(t, registry, "watch_file", sessionID, map[string]interface{}{"path": path})
+
+	result := runWatchTool(t, registry, "unwatch_file", sessionID, map[string]interface{}{"path": path})
+	if result.IsError {
+		t.Fatalf("unwatch_file failed: %s", result.Content[0].Text)
+	}
+	if err := os.WriteFile(path, []byte("v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-notifier.changes:
+		t.Errorf("unexpected notification after unwatch: %+v", got.change)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	result = runWatchTool(t, registry, "unwatch_file", sessionID, map[string]interface{}{"path": path})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, tools.ErrWatchNotFound.Error()) {
+		t.Errorf("expected ErrWatchNotFound, got %+v", result.Content)
+	}
+}
+
+func TestWatchFile_UnwatchSession(t *testing.T) {
+	registry, _, path := newWatchFixture(t)
+	watcher := registry.FileWatcher()
+	sessionID, otherID := vo.GenerateSessionID(), vo.GenerateSessionID()
+
+	runWatchTool(t, registry, "watch_file", sessionID, map[string]interface{}{"path": path})
+	runWatchTool(t, registry, "watch_file", otherID, map[string]interface{}{"path": path})
+
+	watcher.UnwatchSession(sessionID)
+	if got := watcher.Watches(sessionID); len(got) != 0 {
+		t.Errorf("expected no watches after session cleanup, got %v", got)
+	}
+	if got := watcher.Watches(otherID); len(got) != 1 {
+		t.Errorf("expected other session's watch to remain, got %v", got)
+	}
+}
+
+func TestWatchFile_Expires(t *testing.T) {
+	_, _, path := newWatchFixture(t)
+	watcher := tools.NewFileWatcher()
+	defer watcher.Close()
+	sessionID := vo.GenerateSessionID()
+
+	if _, err := watcher.Watch(sessionID, path, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for len(watcher.Watches(sessionID)) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("watch did not expire")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatchFile_ExpiredWatchNotReported(t *testing.T) {
+	_, _, path := newWatchFixture(t)
+	watcher := tools.NewFileWatcher()
+	defer watcher.Close()
+	sessionID := vo.GenerateSessionID()
+
+	if _, err := watcher.Watch(sessionID, path, time.Nanosecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if _, ok := watcher.WatchExpiry(sessionID, path); ok {
+		t.Error("an expired watch should not be reported")
+	}
+	if got := watcher.Watches(sessionID); len(got) != 0 {
+		t.Errorf("expected the expired watch to be dropped, got %v", got)
+	}
+}
+
+func TestWatchFile_Validation(t *testing.T) {
+	registry, _, path := newWatchFixture(t)
+	sessionID := vo.GenerateSessionID()
+
+	tests := []struct {
+		name    string
+		input   map[string]interface{}
+		wantErr string
+	}{
+		{"outside allowlist", map[string]interface{}{"path": "/etc/hostname"}, tools.ErrPathNotAllowed.Error()},
+		{"directory", map[string]interface{}{"path": filepath.Dir(path)}, "is a directory"},
+		{"missing file", map[string]interface{}{"path": path + ".missing"}, "no such file"},
+		{"duration too long", map[string]interface{}{"path": path, "duration_seconds": 7200.0}, "duration_seconds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runWatchTool(t, registry, "watch_file", sessionID, tt.input)
+			if !result.IsError || !strings.Contains(result.Content[0].Text, tt.wantErr) {
+				t.Errorf("expected error containing %q, got %+v", tt.wantErr, result.Content)
+			}
+		})
+	}
+
+	tool, _ := registry.GetTool("watch_file")
+	result, _ := tool.ExecuteContext(context.Background(), map[string]interface{}{"path": path})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, tools.ErrSessionRequired.Error()) {
+		t.Errorf("expected ErrSessionRequired without a session, got %+v", result.Content)
+	}
+}
+
+func TestWatchFile_LimitPerSession(t *testing.T) {
+	_, _, path := newWatchFixture(t)
+	dir := filepath.Dir(path)
+	watcher := tools.NewFileWatcher()
+	defer watcher.Close()
+	sessionID := vo.GenerateSessionID()
+
+	for i := 0; i < tools.MaxWatchesPerSession; i++ {
+		p := filepath.Join(dir, "f"+strings.Repeat("x", i))
+		if _, err := watcher.Watch(sessionID, p, time.Minute); err != nil {
+			t.Fatalf("watch %d failed: %v", i, err)
+		}
+	}
+	if _, err := watcher.Watch(sessionID, filepath.Join(dir, "one-too-many"), time.Minute); err == nil {
+		t.Fatal("expected ErrWatchLimitReached")
+	}
+	// Re-watching an existing path replaces it rather than counting against the limit
+	if _, err := watcher.Watch(sessionID, filepath.Join(dir, "f"), time.Minute); err != nil {
+		t.Errorf("re-watch failed: %v", err)
+	}
+}
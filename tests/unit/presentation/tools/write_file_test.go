@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// assertNoTempFiles checks that a write left nothing but the listed files in dir
+func assertNoTempFiles(t *testing.T, dir string, want ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(want) {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name()
+		}
+		t.Errorf("directory holds %v, want only %v", names, want)
+	}
+}
+
+func TestWriteFile_ReplacesAtomically(t *testing.T) {
+	registry, root, _, _ := newFileOpsFixture(t)
+	path := filepath.Join(root, "notes.txt")
+	writeTestFile(t, path, "original")
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	op := decodeFileOp(t, runFileOp(t, registry, "write_file", map[string]interface{}{"path": path, "content": "updated content"}))
+	if op.Operation != "write" || op.Path != path || op.Bytes != int64(len("updated content")) || !op.Overwrote {
+		t.Errorf("result = %+v", op)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil || string(content) != "updated content" {
+		t.Errorf("content = %q, %v", content, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %v, %v, want the original 0640", info.Mode().Perm(), err)
+	}
+	assertNoTempFiles(t, root, "notes.txt")
+
+	created := decodeFileOp(t, runFileOp(t, registry, "write_file", map[string]interface{}{"path": filepath.Join(root, "new.txt"), "content": ""}))
+	if created.Overwrote || created.Bytes != 0 {
+		t.Errorf("result = %+v, want a new empty file", created)
+	}
+}
+
+func TestWriteFile_FailedWriteKeepsOriginal(t *testing.T) {
+	registry, root, _, _ := newFileOpsFixture(t)
+	path := filepath.Join(root, "notes.txt")
+	writeTestFile(t, path, "original")
+
+	tool, _ := registry.GetTool("write_file")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := tool.ExecuteContext(ctx, map[string]interface{}{
+		"path":    path,
+		"content": strings.Repeat("x", 256*1024),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectFileOpError(t, result, context.Canceled.Error())
+
+	content, err := os.ReadFile(path)
+	if err != nil || string(content) != "original" {
+		t.Errorf("original file changed to %d bytes, %v", len(content), err)
+	}
+	assertNoTempFiles(t, root, "notes.txt")
+}
+
+func TestWriteFile_WritesThroughSymlink(t *testing.T) {
+	registry, root, _, _ := newFileOpsFixture(t)
+	target := filepath.Join(root, "target.txt")
+	writeTestFile(t, target, "original")
+	link := filepath.Join(root, "link.txt")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	op := decodeFileOp(t, runFileOp(t, registry, "write_file", map[string]interface{}{"path": link, "content": "via link"}))
+	if op.Path != target {
+		t.Errorf("path = %s, want the link target %s", op.Path, target)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Error("the symlink should be kept")
+	}
+	if content, _ := os.ReadFile(target); string(content) != "via link" {
+		t.Errorf("target content = %q", content)
+	}
+}